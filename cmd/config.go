@@ -128,6 +128,59 @@ Examples:
 		},
 		Args: cobra.NoArgs,
 	}
+	configWhichCmd = &cobra.Command{
+		Use:     "which",
+		Aliases: []string{"path"},
+		Short:   "Show which config file is used and the search order",
+		Long: `gocli config which (alias: path) prints the resolved config file and every directory searched for it.
+
+When --config is given the file must exist; otherwise the first .gocli.{yaml,yml,json,toml}
+or gocli.{yaml,yml,json,toml} found in the search order below is used. Directories passed with
+the global --config-dir flag are searched first.
+
+Examples:
+  gocli config which
+  gocli config path --config-dir ~/dotfiles/gocli
+  gocli config which --config ./custom.yaml`,
+		Run: func(cmd *cobra.Command, _ []string) {
+			out := cmd.OutOrStdout()
+			used := gocliCtx.Viper.ConfigFileUsed()
+			switch {
+			case globalFlags.ConfigPath != "":
+				fmt.Fprintf(out, "Config file: %s (from --config)\n", used)
+				return
+			case used == "":
+				fmt.Fprintln(out, "Config file: none (using defaults)")
+			default:
+				fmt.Fprintf(out, "Config file: %s\n", used)
+			}
+
+			extra := map[string]bool{}
+			for _, d := range configs.ExtraConfigDirs() {
+				extra[os.ExpandEnv(d)] = true
+			}
+			fmt.Fprintln(out, "Search order:")
+			for i, dir := range configs.ConfigSearchDirs(configs.GetModuleRoot("")) {
+				mark := "-"
+				if fi, err := os.Stat(dir); err == nil && fi.IsDir() {
+					mark = " "
+				}
+				if found := configs.FindConfigFile(dir); found != "" {
+					mark = "*"
+					if found == used {
+						mark = ">"
+					}
+				}
+				note := ""
+				if extra[dir] {
+					note = " (from --config-dir)"
+				}
+				fmt.Fprintf(out, "%s %2d. %s%s\n", mark, i+1, dir, note)
+			}
+			fmt.Fprintln(out, "(> used, * contains a config file, - does not exist; GOCLI_* environment variables override file values)")
+		},
+		Args: cobra.NoArgs,
+	}
 )
 
 func init() {
@@ -137,6 +190,7 @@ func init() {
 		configListCmd,
 		configValidateCmd,
 		configInitCmd,
+		configWhichCmd,
 	)
 
 	// 添加 config list 标志
@@ -4,9 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/yeisme/gocli/pkg/debug"
+	"github.com/yeisme/gocli/pkg/utils/executor"
 )
 
 var (
@@ -17,6 +21,12 @@ var (
 	traceDebugMode string
 	traceVerbose   bool
 
+	// trace capture flags (bound in init)
+	traceCapture     bool
+	traceCaptureTest string
+	traceOutput      string
+	traceView        bool
+
 	// nm flags (bound in init)
 	nmNumeric bool
 	nmSize    bool
@@ -31,6 +41,14 @@ var (
 	memMod     string
 	memJSON    bool
 	memVerbose bool
+	memPID     int
+	memURL     string
+	memWatch   time.Duration
+
+	// stack flags (bound in init)
+	stackPID     int
+	stackURL     string
+	stackTimeout time.Duration
 
 	debugCmd = &cobra.Command{
 		Use:     "debug",
@@ -51,7 +69,7 @@ var (
 	debugTraceCmd = &cobra.Command{
 		Use:   "trace",
 		Short: "View or analyze Go execution trace (wrapper of 'go tool trace')",
-		Long: `Run and view Go execution trace.
+		Long: `Run and view Go execution trace, or capture one by running a test binary / go test.
 
 Examples:
   # Run trace server on default address and open in browser manually
@@ -66,14 +84,41 @@ Examples:
   # Provide test binary (rarely needed for Go >1.7)
   gocli debug trace ./pkg.test trace.out
 
+  # Capture: run a test binary built with 'go test -c' with tracing enabled, then open the viewer
+  gocli debug trace --capture -o server.trace --view -- ./server.test -test.run TestLoad
+
+  # Capture: go test -trace for one package (flags after -- go to go test)
+  gocli debug trace --capture-test ./pkg/cache -o cache.trace -- -run TestEviction -count 1
+
 Wrapper logic:
   gocli will map the provided flags to 'go tool trace'.
   Argument rules:
     1 arg  -> trace file
     2 args -> binary + trace file (kept for backward compatibility)
+
+Notes:
+  - --capture runs the command after -- with -test.trace=<-o>. The runtime trace can only be switched on from
+    outside a program through the testing flags, so the command must be a test binary ('go test -c'); other
+    programs have to call runtime/trace.Start themselves.
+  - --capture-test runs 'go test -trace <-o> <package>'; go test accepts a single package with -trace.
+  - gocli waits for the command to finish; Ctrl+C is forwarded to it as an interrupt. The trace file is checked
+    afterwards (non-empty, valid trace header) and its path is printed; --view then opens it like
+    'gocli debug trace <file>', honoring --http/--pprof/--d.
+  - A failing command (e.g. a failing test) still keeps a valid trace, but gocli exits non-zero.
+  - -o defaults to capture.trace; it cannot be the root --trace file (trace.out by default), which gocli itself
+    writes while the command runs.
 `,
-		Args: cobra.RangeArgs(1, 2),
+		Args: cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if traceCapture || traceCaptureTest != "" {
+				return runTraceCapture(cmd, args)
+			}
+			if traceView || cmd.Flags().Changed("output") {
+				return fmt.Errorf("--view and --output require --capture or --capture-test")
+			}
+			if len(args) < 1 || len(args) > 2 {
+				return fmt.Errorf("accepts 1 or 2 arg(s), received %d", len(args))
+			}
 			// Parse positional args
 			var (
 				bin       string
@@ -96,15 +141,7 @@ Wrapper logic:
 				}
 			}
 
-			// Collect options
-			opt := debug.TraceOptions{
-				HTTPAddr: traceHTTPAddr,
-				PProf:    tracePProfType,
-				Debug:    traceDebugMode,
-				Verbose:  traceVerbose,
-			}
-
-			return debug.RunTrace(cmd.ErrOrStderr(), cmd.OutOrStdout(), opt, bin, traceFile)
+			return debug.RunTrace(cmd.ErrOrStderr(), cmd.OutOrStdout(), traceViewOptions(), bin, traceFile)
 		},
 	}
 
@@ -159,12 +196,37 @@ This is a thin wrapper over 'go tool nm' with flags mapped 1:1:
 
 	debugStackCmd = &cobra.Command{
 		Use:   "stack",
-		Short: "Show stack trace information",
+		Short: "Dump the goroutine stacks of a running Go process",
+		Long: `
+Dump all goroutine stacks of a running Go process.
+
+Examples:
+  # Read the dump from a program serving net/http/pprof (the process keeps running)
+  gocli debug stack --url localhost:6060
+
+  # Send SIGQUIT to a Go process and print the dump it writes to stderr
+  gocli debug stack --pid 12345
+
+Notes:
+  - --pid sends SIGQUIT: the Go runtime prints every goroutine stack to stderr and then EXITS with status 2.
+    Use --url when the process must keep running.
+  - The dump is captured for processes started by "gocli project run", which keeps a copy of up to 1 MiB of
+    their latest stderr in the temp dir (removed when they exit), and when the process's stderr is a regular
+    file (for example a redirected log); otherwise it goes to wherever that stderr points (terminal, pipe)
+    and gocli only reports the target.
+  - --pid relies on /proc and is only available on Linux; non-Go processes are rejected.
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opt := debug.StackOptions{PID: stackPID, URL: stackURL, Timeout: stackTimeout}
+			return debug.RunStack(cmd.Context(), cmd.OutOrStdout(), opt)
+		},
 	}
 
 	debugMemCmd = &cobra.Command{
-		Use:   "memory",
-		Short: "Static memory/escape diagnostics from Go compiler",
+		Use:     "memory",
+		Aliases: []string{"mem"},
+		Short:   "Static memory/escape diagnostics, or runtime memory stats of a running Go process",
 		Long: `
 Run static memory and escape analysis via 'go build -gcflags=all=-m[=2]' and group the diagnostics for readability.
 
@@ -196,13 +258,33 @@ Examples:
   # Use wildcard packages (pattern expansion is handled by the shell/go tool)
   gocli debug memory -m 2 ./...
 
+  # Runtime memory stats (heap, sys, GC cycles, pause total) of a running program
+  gocli debug mem --url localhost:6060
+
+  # Find the debug endpoint of a local Go process and refresh every 2 seconds
+  gocli debug mem --pid 12345 --watch 2s
+
 Notes:
   - Diagnostics are produced by the Go compiler on stderr during build.
+  - With --pid or --url, no build runs: runtime.MemStats is read from /debug/vars (expvar) or from the
+    header of /debug/pprof/heap?debug=1 (net/http/pprof). --pid probes the ports the process listens on (Linux only).
   - A temporary build output is used and cleaned up automatically to avoid polluting your workspace.
   - Use --tags/--mod to match your real build context.
 `,
 		Args: cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if memPID != 0 || memURL != "" {
+				if len(args) > 0 {
+					return fmt.Errorf("packages cannot be combined with --pid/--url")
+				}
+				ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+				defer stop()
+				opt := debug.MemRuntimeOptions{PID: memPID, URL: memURL, Watch: memWatch, JSON: memJSON}
+				return debug.RunMemRuntime(ctx, cmd.OutOrStdout(), opt)
+			}
+			if memWatch > 0 {
+				return fmt.Errorf("--watch requires --pid or --url")
+			}
 			opt := debug.MemStaticOptions{
 				Level:   memLevel,
 				Only:    memOnly,
@@ -253,6 +335,63 @@ Examples:
 	}
 )
 
+// traceViewOptions collects the flags passed to 'go tool trace'
+func traceViewOptions() debug.TraceOptions {
+	return debug.TraceOptions{
+		HTTPAddr: traceHTTPAddr,
+		PProf:    tracePProfType,
+		Debug:    traceDebugMode,
+		Verbose:  traceVerbose,
+	}
+}
+
+// sameFile reports whether two flag paths name the same file once made absolute
+func sameFile(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
+
+// runTraceCapture handles --capture / --capture-test: the command (or go test flags) comes after "--",
+// the trace is written to --output and opened with --view
+func runTraceCapture(cmd *cobra.Command, args []string) error {
+	before, after := args, []string(nil)
+	if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+		before, after = args[:dash], args[dash:]
+	}
+	if sameFile(traceOutput, globalFlags.Trace) {
+		return fmt.Errorf("--output %s is also the root --trace file (gocli's own execution trace); choose another path", traceOutput)
+	}
+	opt := debug.TraceCaptureOptions{Output: traceOutput, Verbose: traceVerbose}
+	if traceCapture {
+		if len(before) > 0 || len(after) == 0 {
+			return fmt.Errorf("--capture runs the command given after --, e.g. gocli debug trace --capture -- ./app.test")
+		}
+		opt.Command = after
+	} else {
+		if len(before) > 0 {
+			return fmt.Errorf("unexpected arguments %v: pass extra go test flags after --", before)
+		}
+		opt.TestPackage = traceCaptureTest
+		opt.TestArgs = after
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	file, err := debug.CaptureTrace(ctx, cmd.OutOrStdout(), cmd.ErrOrStderr(), opt)
+	stop()
+	if file == "" {
+		return err
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "trace written to %s\n", file)
+	if traceView && !executor.Recording() {
+		return errors.Join(err, debug.RunTrace(cmd.ErrOrStderr(), cmd.OutOrStdout(), traceViewOptions(), "", file))
+	}
+	return err
+}
+
 // ensureFile checks existence & regular file
 func ensureFile(path string) error {
 	if path == "" {
@@ -274,6 +413,11 @@ func registerTraceFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&tracePProfType, "pprof", "", "Generate pprof-like profile (net|sync|syscall|sched)")
 	cmd.Flags().StringVar(&traceDebugMode, "d", "", "Print debug info and exit (wire|parsed|footprint)")
 	cmd.Flags().BoolVarP(&traceVerbose, "verbose", "v", false, "Show underlying 'go tool trace' command")
+	cmd.Flags().BoolVar(&traceCapture, "capture", false, "Run the test binary given after -- with tracing enabled (-test.trace) and keep the trace")
+	cmd.Flags().StringVar(&traceCaptureTest, "capture-test", "", "Run 'go test -trace' for this package and keep the trace (go test flags go after --)")
+	cmd.Flags().StringVarP(&traceOutput, "output", "o", debug.DefaultCaptureTrace, "Trace file written by --capture/--capture-test (must differ from the root --trace file)")
+	cmd.Flags().BoolVar(&traceView, "view", false, "Open the captured trace with 'go tool trace' once the command finishes")
+	cmd.MarkFlagsMutuallyExclusive("capture", "capture-test")
 }
 
 // registerNMFlags binds flags for the nm command
@@ -294,6 +438,18 @@ func registerMemFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&memMod, "mod", "", "Module download mode (passed to 'go build -mod')")
 	cmd.Flags().BoolVar(&memJSON, "json", false, "Output diagnostics in JSON format")
 	cmd.Flags().BoolVarP(&memVerbose, "verbose", "v", false, "Show underlying 'go build' command")
+	cmd.Flags().IntVar(&memPID, "pid", 0, "Read runtime memory stats from this running Go process (Linux)")
+	cmd.Flags().StringVar(&memURL, "url", "", "Read runtime memory stats from this debug HTTP endpoint (host:port or URL)")
+	cmd.Flags().DurationVar(&memWatch, "watch", 0, "Refresh runtime memory stats at this interval (e.g. 2s) until interrupted")
+	cmd.MarkFlagsMutuallyExclusive("pid", "url")
+}
+
+// registerStackFlags binds flags for the stack command
+func registerStackFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&stackPID, "pid", 0, "Send SIGQUIT to this Go process and capture its goroutine dump (terminates it)")
+	cmd.Flags().StringVar(&stackURL, "url", "", "Read the goroutine dump from this net/http/pprof endpoint (host:port or URL)")
+	cmd.Flags().DurationVar(&stackTimeout, "timeout", 5*time.Second, "How long to wait for the process to write its dump (--pid)")
+	cmd.MarkFlagsMutuallyExclusive("pid", "url")
 }
 
 func init() {
@@ -319,4 +475,6 @@ func init() {
 	registerNMFlags(debugNMCmd)
 	// mem flags
 	registerMemFlags(debugMemCmd)
+	// stack flags
+	registerStackFlags(debugStackCmd)
 }
@@ -0,0 +1,18 @@
+package cmd
+
+import "testing"
+
+// 测试 debug trace --capture 的 -o 默认值不与根命令 --trace 的文件相同，相同路径（含相对写法）被识别
+func TestTraceCaptureOutputDefault(t *testing.T) {
+	captureDefault := debugTraceCmd.Flags().Lookup("output").DefValue
+	rootDefault := rootCmd.PersistentFlags().Lookup("trace").DefValue
+	if sameFile(captureDefault, rootDefault) {
+		t.Fatalf("--output default %q collides with the root --trace default %q", captureDefault, rootDefault)
+	}
+	if !sameFile("trace.out", "./trace.out") {
+		t.Error("relative spellings of one path should be the same file")
+	}
+	if sameFile("trace.out", "") {
+		t.Error("an empty --trace never collides")
+	}
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// dryRunFlag is shared by every command that supports --dry-run (only one command runs per invocation)
+var dryRunFlag bool
+
+// addDryRunFlag registers --dry-run on cmd; shorthand may be empty when -n is already taken
+func addDryRunFlag(cmd *cobra.Command, shorthand string) {
+	cmd.Flags().BoolVarP(&dryRunFlag, "dry-run", shorthand, false, "Print the external commands that would be executed without running them")
+}
+
+// withDryRun 在 --dry-run 时以执行器录制模式运行 fn，结束后按 "$ cd dir && ENV=V go ..." 格式打印将要执行的命令
+func withDryRun(cmd *cobra.Command, fn func() error) error {
+	if !dryRunFlag {
+		return fn()
+	}
+	rec := executor.StartRecording()
+	defer executor.StopRecording()
+	err := fn()
+	rec.Print(cmd.OutOrStdout())
+	return err
+}
+
+// dryRunnable 包装不返回错误的 Run 函数，使其支持 --dry-run
+func dryRunnable(run func(cmd *cobra.Command, args []string)) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		_ = withDryRun(cmd, func() error {
+			run(cmd, args)
+			return nil
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/yeisme/gocli/pkg/configs"
+	"github.com/yeisme/gocli/pkg/style"
+)
+
+var (
+	envJSON bool
+	envDiff bool
+
+	envCmd = &cobra.Command{
+		Use:   "env",
+		Short: "Show the Go environment gocli resolves",
+		Long: `
+gocli env prints the environment variables gocli sets when it runs go commands, after combining the env
+section of the config file, the process environment, 'go env' and gocli's defaults, with the source of each value.
+
+Examples:
+  # Every variable with its resolved value and source
+  gocli env
+
+  # Machine-readable output
+  gocli env --json
+
+  # Only the variables whose value differs from plain 'go env'
+  gocli env --diff
+
+  # KEY=VALUE lines, like 'go env'
+  gocli env --output-format plain
+
+Notes:
+  - Sources: config (env section of the config file), os (environment gocli was started with),
+    go env (including 'go env -w' settings and toolchain defaults), default (gocli's built-in fallback), unset.
+  - Variables left empty in the config are not set by gocli, so their value is the one from 'go env'.
+  - --diff runs 'go env -json' with the environment gocli was started with, i.e. without the config applied,
+    and lists the variables known to go env whose value gocli changes; GOGCFLAGS and other gocli-only
+    variables are never reported as different.
+  - GOCLI_GOENV selects the toolchain used for 'go env' (a GOROOT directory or a go binary).`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, _ []string) {
+			goEnv, err := configs.RawGoEnv()
+			if err != nil {
+				cmd.PrintErrf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			entries := gocliCtx.Config.Env.Resolve(goEnv)
+			if envDiff {
+				changed := entries[:0]
+				for _, e := range entries {
+					if e.Differs {
+						changed = append(changed, e)
+					}
+				}
+				entries = changed
+			}
+
+			format := outputFormat(cmd, "json")
+			switch {
+			case format.Structured():
+				printEnvelope(cmd, format, entries, nil)
+			case format == style.OutputPlain:
+				for _, e := range entries {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s=%s\n", e.Key, e.Value)
+				}
+			case envJSON:
+				b, err := json.MarshalIndent(entries, "", "  ")
+				if err != nil {
+					cmd.PrintErrf("failed to marshal json: %v\n", err)
+					os.Exit(1)
+				}
+				_ = style.PrintJSON(cmd.OutOrStdout(), b)
+			case envDiff:
+				printEnvDiff(cmd, entries)
+			default:
+				rows := make([][]string, 0, len(entries))
+				for _, e := range entries {
+					mark := ""
+					if e.Differs {
+						mark = "*"
+					}
+					rows = append(rows, []string{e.Key, e.Value, e.Source, mark})
+				}
+				if err := style.PrintTable(cmd.OutOrStdout(), []string{"variable", "value", "source", "≠ go env"}, rows, 0); err != nil {
+					log.Error().Err(err).Msg("failed to print env table")
+				}
+			}
+		},
+	}
+)
+
+// printEnvDiff 以表格输出与原始 go env 不同的变量，没有差异时输出一行说明
+func printEnvDiff(cmd *cobra.Command, entries []configs.EnvEntry) {
+	if len(entries) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "gocli uses the same values as go env")
+		return
+	}
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		rows = append(rows, []string{e.Key, e.Value, e.GoEnv, e.Source})
+	}
+	if err := style.PrintTable(cmd.OutOrStdout(), []string{"variable", "gocli", "go env", "source"}, rows, 0); err != nil {
+		log.Error().Err(err).Msg("failed to print env diff")
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "%d variable(s) differ from go env\n", len(entries))
+}
+
+func init() {
+	rootCmd.AddCommand(envCmd)
+
+	envCmd.Flags().BoolVarP(&envJSON, "json", "j", false, "Output the resolved variables as JSON")
+	envCmd.Flags().BoolVar(&envDiff, "diff", false, "Only show variables whose value differs from plain 'go env'")
+}
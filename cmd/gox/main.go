@@ -2,6 +2,7 @@
 package main
 
 import (
+	"fmt"
 	"os"
 	"strings"
 
@@ -14,8 +15,9 @@ import (
 var (
 	gocliCtx    *context.GocliContext
 	globalFlags = context.GlobalFlags{}
-	configPath  = globalFlags.ConfigPath
-	log         log2.Logger
+	// toolArgs 是去掉 gox 自身前置参数（--config）后转发给工具的参数
+	toolArgs []string
+	log      log2.Logger
 
 	gox = cobra.Command{
 		Use:   "gox <tool> [args...]",
@@ -26,19 +28,57 @@ var (
 		// `gocli tools run --help` to show the run command help, so detect that
 		// specific form in PreRun and print help for the run command.
 		DisableFlagParsing: true,
-		PreRun: func(cmd *cobra.Command, _ []string) {
-			ctx := context.InitGocliContext(configPath, false, false, true)
+		PreRun: func(cmd *cobra.Command, args []string) {
+			globalFlags.ConfigPath, toolArgs = splitConfigFlag(args)
+			ctx, err := context.InitGocliContext(context.GlobalFlags{ConfigPath: globalFlags.ConfigPath, Quiet: true})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
 
 			gocliCtx = ctx
 			log = ctx.Logger
 
 			log.Info().Msgf("Execute Command: %s %s", "gocli", strings.Join(os.Args[1:], " "))
 
+			if len(toolArgs) > 0 && toolArgs[0] == completionFlag {
+				if err := genCompletion(cmd, toolArgs[1:]); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+				os.Exit(0)
+			}
+
 			toolsPkg.ShowRunHelpIfRequested(cmd)
 		},
-		Run: func(cmd *cobra.Command, args []string) {
-			gocliToolsPath := gocliCtx.Config.Tools.GoCLIToolsPath
-			if err := toolsPkg.ExecuteToolRun(args, cmd.OutOrStdout(), false, gocliToolsPath); err != nil {
+		ValidArgsFunction: func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			// __complete 不会执行 PreRun，这里单独加载配置
+			configPath, rest := splitConfigFlag(args)
+			ctx, err := context.InitGocliContext(context.GlobalFlags{ConfigPath: configPath, Quiet: true})
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveDefault
+			}
+			for _, p := range ctx.Config.Tools.ToolsConfigDir {
+				_ = toolsPkg.LoadUserTools(p)
+			}
+			return toolsPkg.CompleteRunArgs(ctx.Config.Tools.GoCLIToolsPath, rest, toComplete)
+		},
+		Run: func(cmd *cobra.Command, _ []string) {
+			runOpts := toolsPkg.RunOptions{GoCLIToolsPath: gocliCtx.Config.Tools.GoCLIToolsPath}
+			if gocliCtx.Config.Tools.History {
+				runOpts.History = toolsPkg.DefaultHistoryFile()
+			}
+			args, err := toolsPkg.SplitRunFlags(toolArgs, &runOpts)
+			if err != nil {
+				log.Error().Err(err).Msg("invalid run flags")
+				os.Exit(1)
+			}
+			if runOpts.GoRun {
+				for _, p := range gocliCtx.Config.Tools.ToolsConfigDir {
+					_ = toolsPkg.LoadUserTools(p)
+				}
+			}
+			if err := toolsPkg.ExecuteToolRun(args, cmd.OutOrStdout(), runOpts); err != nil {
 				log.Error().Err(err).Msg("failed to execute tool")
 			}
 		},
@@ -49,6 +89,49 @@ var (
 	}
 )
 
+// splitConfigFlag 从工具名之前的参数中取出 gox 自身的 --config/-c，
+// 由于禁用了 cobra 的 flag 解析，需要手动处理；工具名之后的参数原样转发
+func splitConfigFlag(args []string) (configPath string, rest []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--config" || arg == "-c":
+			if i+1 < len(args) {
+				configPath = args[i+1]
+				i++
+			}
+		case strings.HasPrefix(arg, "--config="):
+			configPath = strings.TrimPrefix(arg, "--config=")
+		default:
+			return configPath, args[i:]
+		}
+	}
+	return configPath, nil
+}
+
+// completionFlag 输出 gox 的 shell 补全脚本；gox 没有子命令，因此用工具名之前的参数代替 completion 子命令
+const completionFlag = "--completion"
+
+// genCompletion 使用 cobra 的生成器输出指定 shell 的补全脚本
+func genCompletion(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: gox %s bash|zsh|fish|powershell", completionFlag)
+	}
+	out := cmd.OutOrStdout()
+	switch args[0] {
+	case "bash":
+		return cmd.GenBashCompletionV2(out, true)
+	case "zsh":
+		return cmd.GenZshCompletion(out)
+	case "fish":
+		return cmd.GenFishCompletion(out, true)
+	case "powershell":
+		return cmd.GenPowerShellCompletionWithDesc(out)
+	default:
+		return fmt.Errorf("unsupported shell %q, expected bash, zsh, fish or powershell", args[0])
+	}
+}
+
 func main() {
 	if err := gox.Execute(); err != nil {
 		log.Error().Err(err).Msg("failed to execute gocli")
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yeisme/gocli/pkg/project"
+	"github.com/yeisme/gocli/pkg/style"
+)
+
+// outputFormat 返回命令应使用的全局 --output-format；
+// 命令自身的格式标志（如 --json）被显式设置时优先，此时返回 OutputDefault 以保持原有行为
+func outputFormat(cmd *cobra.Command, localFlags ...string) style.OutputFormat {
+	for _, name := range localFlags {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Changed {
+			return style.OutputDefault
+		}
+	}
+	if gocliCtx == nil {
+		return style.OutputDefault
+	}
+	return gocliCtx.OutputFormat
+}
+
+// printEnvelope 以 {command, data, error} 信封输出结构化结果，command 为完整的命令路径（如 "gocli tools list"）
+func printEnvelope(cmd *cobra.Command, format style.OutputFormat, data any, err error) {
+	if perr := style.PrintEnvelope(cmd.OutOrStdout(), format, cmd.CommandPath(), data, err); perr != nil {
+		log.Error().Err(perr).Msg("failed to print output envelope")
+	}
+}
+
+// addOutputFileFlag 注册 -o/--output，取值与 project doc -o 相同：文件路径、-、stderr、clipboard:，路径末尾 :append 表示追加
+func addOutputFileFlag(cmd *cobra.Command, target *string) {
+	cmd.Flags().StringVarP(target, "output", "o", "", "Write the result to a file instead of stdout (also -, stderr or clipboard:; a trailing :append appends)")
+}
+
+// redirectOutput 按 -o 的取值把命令输出（cmd.OutOrStdout 与信封输出）重定向，返回写完后需调用的关闭函数
+func redirectOutput(cmd *cobra.Command, target string) (func() error, error) {
+	w, closeFn, err := project.OpenOutput(target, false, cmd.OutOrStdout())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open output: %w", err)
+	}
+	cmd.SetOut(w)
+	if closeFn == nil {
+		closeFn = func() error { return nil }
+	}
+	return closeFn, nil
+}
+
+// closeOutput 调用 redirectOutput 返回的关闭函数（用于 defer），命令本身成功时把关闭错误（文件写入、剪贴板）作为结果返回
+func closeOutput(closeOut func() error, err *error) {
+	if cerr := closeOut(); cerr != nil && *err == nil {
+		*err = fmt.Errorf("failed to close output: %w", cerr)
+	}
+}
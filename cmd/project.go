@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -10,6 +12,7 @@ import (
 	"github.com/yeisme/gocli/pkg/style"
 	"github.com/yeisme/gocli/pkg/utils/doc"
 	"github.com/yeisme/gocli/pkg/utils/executor"
+	"github.com/yeisme/gocli/pkg/utils/fsop"
 )
 
 var (
@@ -25,6 +28,24 @@ var (
 	docOptions    project.DocOptions
 	addOptions    project.AddOptions
 	testOptions   project.TestOptions
+	cleanOptions  project.CleanOptions
+	genOptions    project.GenerateOptions
+
+	// listOutput / infoOutput 对应 project list / info 的 -o：结果写入文件、stderr 或剪贴板
+	listOutput string
+	infoOutput string
+
+	// buildWorkspace / listWorkspace / infoWorkspace / depsWorkspace 对应 --workspace：在 go.work 的每个模块中执行
+	buildWorkspace bool
+	listWorkspace  bool
+	infoWorkspace  bool
+	depsWorkspace  bool
+
+	// docListThemes 对应 project doc --list-themes：列出可用的 Markdown 主题后退出
+	docListThemes bool
+	// docNoCache / docClearCache 对应 project doc --no-cache / --clear-cache
+	docNoCache    bool
+	docClearCache bool
 
 	projectCmd = &cobra.Command{
 		Use:     "project",
@@ -67,9 +88,10 @@ Examples:
   # 8. Add license & author meta
   gocli project init mylib --license MIT --author "Alice" --email alice@example.com
 
-  # 9. Output templates as JSON / YAML when listing
+  # 9. Output templates as JSON / YAML / TOML when listing
   gocli project init --list --json
   gocli project init --list --format yaml
+  gocli project init --list --format toml
 
   # 10. Force overwrite existing files from template
   gocli project init myapp --template basic --force
@@ -81,14 +103,22 @@ Examples:
   # 12. Combine: create dir, apply template, init task & goreleaser
   gocli project init svc-user --dir ./services/user --template api --go-task --goreleaser
 
+  # 13. Run the template's post_init commands (e.g. go mod tidy, git add -A) without prompting
+  gocli project init myweb --template webui --git --yes
+
 Notes:
   - If go.mod already exists in the target directory, go mod init is skipped.
   - --force overwrites files that already exist when copying template content.
   - --json / --yaml only affect template list output (when --list specified).
   - Author/email/license insertion depends on template support.
+  - A template descriptor (template.yaml) may list post_init commands; they run in the target directory after
+    go mod init and the other init steps, with GOCLI_PROJECT_NAME and GOCLI_MODULE_PATH set. The commands are
+    shown and need confirmation unless --yes. A failing command is reported but created files are kept.
 `,
 		Run: func(cmd *cobra.Command, args []string) {
-			if err := project.ExecuteInitCommand(gocliCtx, args, initOptions, cmd.OutOrStdout()); err != nil {
+			opts := initOptions
+			opts.Input = cmd.InOrStdin()
+			if err := project.ExecuteInitCommand(gocliCtx, args, opts, cmd.OutOrStdout()); err != nil {
 				// 如果是 ExecError（包含 stderr），直接把格式化后的错误作为消息打印，避免 zerolog 将换行转义
 				if ee, ok := err.(*executor.ExecError); ok {
 					log.Warn().Msg("failed to initialize project: " + ee.Error())
@@ -158,14 +188,48 @@ Examples:
   # 15. Debug-style build (no optimizations, full symbols)
   gocli project build --debug-mode ./cmd/cli
 
+  # 16. Cross-compile for multiple platforms (outputs <name>_<goos>_<goarch>)
+  gocli project build --platforms linux/amd64,windows/arm64 ./cmd/cli
+
+  # 17. Name binaries from a template, rendered per target platform
+  gocli project build --platforms linux/amd64,darwin/arm64 --output-template "dist/{{.Name}}_{{.OS}}_{{.Arch}}" ./cmd/cli
+  gocli project build --output-template "bin/{{.Name}}-{{.Version}}{{.Ext}}" ./cmd/cli
+
+  # 18. Report which packages were recompiled vs served from the build cache
+  gocli project build --build-summary ./...
+  gocli project build -x --build-summary ./...
+
+  # 19. Explain build cache misses (compared with the previous --explain-cache run)
+  gocli project build --explain-cache ./...
+
+  # 20. Start from a cold build cache
+  gocli project build --clean-cache ./...
+
+  # 21. Build ./... in every module of the go.work workspace
+  gocli project build --workspace
+
 Notes:
   - Most flags map directly to 'go build' counterparts (asmflags/gcflags/ldflags...).
+  - --platforms pairs are validated against 'go tool dist list' before any build starts.
   - --release-mode / --debug-mode are opinionated presets combining common flags.
+  - --output-template cannot be combined with -o; {{.Version}} comes from 'git describe --tags --always --dirty'
+    ("dev" outside a git repo) and windows targets get ".exe" appended when missing.
+  - --build-summary implies -x; the raw -x commands are only shown when -x is also given (or at debug level).
+  - --explain-cache implies --build-summary and records the build tags, -gcflags/-ldflags, target platform and
+    Go version in .gocli/build-state.json; the next run lists which of them changed as likely cache-busting reasons.
+  - --clean-cache runs once before the build (not on every hot reload) and removes test results too.
+    Cache hits are computed as the 'go list -deps' package count minus the packages actually compiled.
   - Can be combined with --hot-reload (more commonly used under 'run').
+  - --workspace reads the go.work selected by GOWORK (env.GOWORK in the config) and builds from each 'use' directory
+    in turn, with ./... when no packages are given; relative paths such as -o bin/ resolve per module. A failing module
+    does not stop the others. It cannot be combined with --hot-reload.
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			buildOptions.V = gocliCtx.Config.App.Verbose
-			if err := project.ExecuteBuildCommand(gocliCtx, buildOptions, args); err != nil {
+			if buildWorkspace {
+				buildOptions.Workspace = workspaceModules()
+			}
+			if err := project.ExecuteBuildCommand(gocliCtx, buildOptions, restoreArgsSeparator(cmd, args)); err != nil {
 				cmd.PrintErrf("Error: %v\n", err)
 				os.Exit(1)
 			}
@@ -213,19 +277,78 @@ Examples:
   gocli project run -r ./cmd/server
   # 10. Hot reload without respecting .gitignore
   gocli project run -r --no-gitignore ./cmd/server
+  # 11. Also restart when files in a sibling directory change
+  gocli project run -r --watch-path ../shared/templates ./cmd/server
+  # Print event/rebuild statistics on demand (kill -USR1 <gocli pid>, or type s + Enter)
+  gocli project run -r --stats ./cmd/server
+
+  # Environment:
+  # 12. Load variables from dotenv files (default: .env then .env.local if present)
+  gocli project run --env-file .env --env-file .env.dev ./cmd/server
+
+  # Program arguments:
+  # 13. Forward everything after -- to the program (gocli/go flags go before it)
+  gocli project run ./cmd/server -- --port 9000
+  gocli project run -r main.go util.go -- -v
+
+  # Several entrypoints (procfile-style):
+  # 14. Run api and worker together; output lines are prefixed with the process name
+  gocli project run ./cmd/api ./cmd/worker
+  # 15. Run every main package of the module, with hot reload, and survive a crashing process
+  gocli project run --all-mains -r --keep-running
+  gocli project run ./cmd/... -- --config dev.yaml
+
+  # Port conflicts:
+  # 16. Make sure :8080 is free before starting (reports the process holding it otherwise)
+  gocli project run --check-port 8080 ./cmd/server
+  # 17. Offer to terminate whatever holds :8080 and :9090 (asks before killing anything)
+  gocli project run -r --check-port 8080 --check-port 9090 --kill-conflicting ./cmd/server
 
 Notes:
   - Hot reload is for local dev; for production prefer a static build + external supervisor.
+  - app.hotload.pre_hooks / post_hooks run shell commands before / after each (re)start (post hooks run once
+    the program has started, it keeps running in the background), and app.hotload.exec replaces the default
+    build + run; with stop_on_hook_error a failing pre hook skips the restart.
+  - --watch-path (or app.hotload.watch_paths) adds paths outside the watch dir; relative paths resolve against
+    app.hotload.dir and each path honors its own .gitignore. Missing paths are picked up once they are created.
+  - After each restart a status line is printed on stderr, e.g. "[hot-reload] #12 rebuilt in 1.8s (trigger:
+    pkg/server/handler.go) — running for 34m, 12 restarts, 2 failed"; app.hotload.status (auto|true|false,
+    default auto = only when stderr is a terminal) controls it. --stats dumps the counts of events received,
+    events filtered by ignore rules, debounce coalesces and the average rebuild time on SIGUSR1 (not on Windows)
+    or when s + Enter is typed on a terminal.
+  - Env files only affect the started program (never gocli itself) and are re-read on every hot reload restart.
   - --release-mode may also be used here to emulate production flags for a quick run.
   - Use -n / --dry-run to only print the underlying commands.
+  - Arguments after -- are passed to the program unchanged; everything before it is treated as
+    packages/files. Without --, the first argument is the package and the rest go to the program.
+  - Several entrypoints are run when --all-mains is given, when a pattern contains ... or when every argument is a
+    directory holding a main package. Each package is built with 'go build' into a temporary directory and
+    started directly; arguments after -- go to every process. Lines are prefixed with the process name (last
+    import path element) in its own color. Ctrl+C (SIGINT/SIGTERM) is forwarded to every process, which is killed
+    if it has not exited after 5s. When a process fails all others are stopped and gocli exits non-zero, unless
+    --keep-running is given; a process exiting cleanly does not stop the others.
+  - With -r and several entrypoints only the processes whose package or module dependencies contain the changed
+    files are rebuilt and restarted ('go list -deps'); other changes (go.mod, assets outside any package) restart
+    all of them. Failures never stop the session in this mode, and app.hotload.exec is ignored.
+  - --check-port (or run.check_ports) tests that each TCP port is free before the program starts and names the
+    process holding a busy port (best effort: /proc on Linux, lsof elsewhere, netstat on Windows). With -r the
+    check runs before every restart; a port still held by the previous instance is waited on (up to 5s) instead
+    of failing. With several entrypoints the ports are only checked once before they start.
+  - --kill-conflicting asks on the terminal before sending SIGTERM to the process holding a port; without a
+    terminal nothing is terminated and the run aborts as usual.
 `,
 		Run: func(cmd *cobra.Command, args []string) {
-			if len(args) == 0 {
+			if len(args) == 0 && !runOptions.AllMains {
 				_ = cmd.Help()
 				return
 			}
 			runOptions.V = gocliCtx.Config.App.Verbose
-			if err := project.ExecuteRunCommand(gocliCtx, runOptions, args); err != nil {
+			// --kill-conflicting 只有在终端上得到确认后才会终止占用端口的进程
+			if style.IsTerminal(os.Stdin) && style.IsTerminal(os.Stderr) {
+				runOptions.Prompt = cmd.ErrOrStderr()
+				runOptions.Input = cmd.InOrStdin()
+			}
+			if err := project.ExecuteRunCommand(gocliCtx, runOptions, restoreArgsSeparator(cmd, args)); err != nil {
 				cmd.PrintErrf("Error: %v\n", err)
 				os.Exit(1)
 			}
@@ -256,23 +379,79 @@ Examples:
   # JSON output
   gocli project list --json > pkgs.json
 
+  # Package overview: Go files, test files and direct imports per package
+  gocli project list --format table
+  gocli project list ./pkg/... --format table --test
+
+  # Write the result to a file (parent directories are created) instead of redirecting
+  gocli project list --json -o build/pkgs.json
+
+  # Envelope {"command": ..., "data": [<go list -json objects>], "error": null}
+  gocli project list --output-format json
+
   # Verbose (show total count)
   gocli project list -v
+
+  # Every module of the go.work workspace (grouped per module; the table gets a Module column)
+  gocli project list --workspace
+  gocli project list --workspace --format table
+
+Notes:
+  - --workspace reads the go.work selected by GOWORK (env.GOWORK in the config) and runs 'go list' from each 'use'
+    directory; packages of a nested workspace module are only listed under that module.
 `,
-		Run: func(cmd *cobra.Command, args []string) {
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			closeOut, err := redirectOutput(cmd, listOutput)
+			if err != nil {
+				return err
+			}
+			defer closeOutput(closeOut, &err)
+			format := outputFormat(cmd, "json", "format")
+			opts := listOptions
+			if listWorkspace {
+				opts.Workspace = workspaceModules()
+			}
+			if format.Structured() {
+				opts.JSON = true
+				opts.Format = ""
+			}
+			if strings.EqualFold(opts.Format, project.ListFormatJSON) {
+				opts.JSON = true
+			}
+			// 表格与按模块分组的工作区列表直接写到输出，不经过下面的包名列表处理
+			if strings.EqualFold(opts.Format, project.ListFormatTable) || (len(opts.Workspace) > 0 && !opts.JSON) {
+				if err := project.RunList(opts, cmd.OutOrStdout(), args); err != nil {
+					return fmt.Errorf("failed to run project list: %w", err)
+				}
+				return nil
+			}
 			// Execute list
 			var b strings.Builder
-			if err := project.RunList(listOptions, &b, args); err != nil {
-				log.Error().Err(err).Msg("failed to run project list")
-				os.Exit(1)
+			err = project.RunList(opts, &b, args)
+			if format.Structured() {
+				var data any
+				if err == nil {
+					data, err = style.DecodeJSONStream(b.String())
+				}
+				printEnvelope(cmd, format, data, err)
+				return err
+			}
+			if err != nil {
+				return fmt.Errorf("failed to run project list: %w", err)
 			}
 			output := b.String()
 			// JSON: pass-through
-			if listOptions.JSON {
-				_ = style.PrintJSONLine(cmd.OutOrStdout(), output)
-				return
+			if opts.JSON {
+				return style.PrintJSONLine(cmd.OutOrStdout(), output)
 			}
 			trimmed := strings.TrimSpace(output)
+			if format == style.OutputPlain {
+				if trimmed != "" {
+					fmt.Fprintln(cmd.OutOrStdout(), trimmed)
+				}
+				return nil
+			}
 			if trimmed != "" {
 				lines := strings.Split(trimmed, "\n")
 				pkgs := make([]string, 0, len(lines))
@@ -286,12 +465,13 @@ Examples:
 				if len(pkgs) > 0 {
 					_ = style.PrintPackageList(cmd.OutOrStdout(), pkgs)
 				}
-				if verboseFlag && !quietFlag {
+				if globalFlags.Verbose && !globalFlags.Quiet {
 					cmd.Printf("Total: %d packages\n", len(pkgs))
 				}
-			} else if verboseFlag && !quietFlag {
+			} else if globalFlags.Verbose && !globalFlags.Quiet {
 				cmd.Println("No packages found")
 			}
+			return nil
 		},
 	}
 	projectInfoCmd = &cobra.Command{
@@ -323,6 +503,9 @@ Examples:
   # Follow symbolic links when collecting files
   gocli project info --follow-symlinks
 
+  # Follow symbolic links that point outside the project, limiting the directory depth
+  gocli project info --follow-symlinks --stay-in-root=false --max-depth 20
+
   # Skip very large files (>1MB)
   gocli project info --max-file-size 1048576
 
@@ -344,11 +527,66 @@ Examples:
   # Short-form: include per-language file lists and enable JSON
   gocli project info -i "**/*.go" -l -j
 
+  # README-ready markdown section (with Go package count)
+  gocli project info --format markdown --packages > docs/stats.md
+
+  # Emit shields.io endpoint badges for CI
+  gocli project info --badge-json out/badges
+
+  # Show counting progress on stderr (auto on terminals for repos with 2000+ files)
+  gocli project info --progress
+  gocli project info --json --progress > info.json
+
+  # Add git statistics (branch, commits, contributors, first/last commit)
+  gocli project info --git
+  gocli project info --git --json
+
+  # Who owns the code: top 3 authors per language and the overall distribution
+  gocli project info --owners
+  gocli project info --owners-fast --json
+
+  # Write the report to a file instead of redirecting stdout (works the same on Windows)
+  gocli project info --json -o report.json
+  gocli project info --format markdown -o docs/stats.md
+
+  # One report per module of the go.work workspace
+  gocli project info --workspace
+  gocli project info --workspace --json
+
+  # Only Go code (prod vs tests), skipping every other file
+  gocli project info --go-only
+
 Notes:
   - When using --with-files or explicitly supplying language-specific flags, JSON output is auto-enabled to ensure structured data.
-  - Use glob-style patterns for --include/--exclude; Windows backslashes are accepted but forward slashes are recommended.
+  - Use glob-style patterns for --include/--exclude; "**" matches any number of directories (e.g. "pkg/**/*.go"),
+    and directories that cannot match are skipped during traversal. Windows backslashes are accepted but forward slashes are recommended.
+  - --follow-symlinks enters each directory and counts each file once by its real path, so symlink cycles terminate;
+    links resolving outside the project are skipped unless --stay-in-root=false. Directories deeper than --max-depth are not entered.
+  - --badge-json writes loc.json, go-files.json and a coverage.json placeholder (an existing coverage.json is kept); use them with https://img.shields.io/endpoint?url=...
+  - Files larger than --max-file-size are not counted; they are listed after the table and in the "skipped" field of the JSON output.
+  - Progress is written to stderr only when it is a terminal and the line is cleared before results are printed,
+    so JSON/markdown on stdout is never affected; use --progress=never to disable it.
+  - --git counts the commits reachable from HEAD and their distinct author emails; when git is not installed or the
+    path is not inside a repository a warning is logged and the "git" field is omitted.
+  - --owners runs 'git blame --line-porcelain' on every counted file with the --concurrency workers; files above
+    --max-file-size and untracked files are skipped and uncommitted lines belong to nobody. --owners-fast sums the
+    added minus deleted lines of 'git log --numstat' per author instead (one git call; rewritten lines stay with
+    their first author). JSON adds "owners" (name, email, lines, percent) to each language (top 3) and to "total"
+    (every author). Outside a git repository the section is omitted.
+  - -o accepts a file path (missing parent directories are created, a trailing ":append" appends), "-", "stderr"
+    or "clipboard:", like project doc -o. Files never contain color codes.
+  - --workspace ignores the path argument and analyzes each 'use' directory of the go.work selected by GOWORK:
+    text and markdown get a section per module, JSON an array of {"module", "dir", "summary"}.
+    It cannot be combined with --badge-json.
+  - Go code is split into production (.go) and tests (_test.go), e.g. "Go: 42k code (34k prod / 8k tests)".
+    Files the host platform does not build (//go:build, GOOS/GOARCH file name suffixes) are reported as inactive;
+    JSON adds "go" to the Go language with prod, tests, generated, inactive and per-//go:build "constraints".
 `,
-		Run: func(cmd *cobra.Command, args []string) {
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if infoWorkspace {
+				infoOptions.Workspace = workspaceModules()
+			}
 			// determine JSON output
 			jsonOut, _ := cmd.Flags().GetBool("json")
 			if lf, _ := cmd.Flags().GetBool("language-files"); lf { // auto enable JSON
@@ -398,10 +636,13 @@ Notes:
 			noGitignore, _ := cmd.Flags().GetBool("no-gitignore")
 			infoOptions.RespectGitignore = !noGitignore
 
-			if err := project.ExecuteInfoCommand(gocliCtx, infoOptions, args, jsonOut, !quietFlag, cmd.OutOrStdout()); err != nil {
-				cmd.PrintErrf("Error: %v\n", err)
-				os.Exit(1)
+			closeOut, err := redirectOutput(cmd, infoOutput)
+			if err != nil {
+				return err
 			}
+			defer closeOutput(closeOut, &err)
+
+			return project.ExecuteInfoCommand(gocliCtx, infoOptions, args, jsonOut, !globalFlags.Quiet, cmd.OutOrStdout())
 		},
 	}
 	projectAddCmd = &cobra.Command{
@@ -410,7 +651,8 @@ Notes:
 		Aliases: []string{"get", "g", "a"},
 		Run: func(cmd *cobra.Command, args []string) {
 			addOptions.Verbose = gocliCtx.Config.App.Verbose
-			if err := project.RunAdd(addOptions, args, cmd.OutOrStdout()); err != nil {
+			err := withDryRun(cmd, func() error { return project.RunAdd(addOptions, args, cmd.OutOrStdout()) })
+			if err != nil {
 				cmd.PrintErrf("Error: %v\n", err)
 				os.Exit(1)
 			}
@@ -469,15 +711,26 @@ Examples:
   # Compile test binary without running
   gocli project test -c -o mytest
 
+  # Show the 10 slowest tests and per-package totals
+  gocli project test --slowest 10
+
+  # Save all test timings for later comparison
+  gocli project test --timing-out timings.json
+
 Notes:
   - Most flags map directly to 'go test' counterparts.
   - Test output follows 'go test' behavior: successful tests show summary only,
     failed tests show detailed output.
   - Supports all standard 'go test' flags for comprehensive test control.
+  - Use -n / --dry-run to print the go test command without running it.
+  - --slowest / --timing-out run go test with -json internally and use the
+    elapsed time reported for each test; regular output is still shown unless
+    -json is given. The report is printed even when tests fail.
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			testOptions.Verbose = gocliCtx.Config.App.Verbose
-			if err := project.RunTest(testOptions, args, cmd.OutOrStdout()); err != nil {
+			err := withDryRun(cmd, func() error { return project.RunTest(testOptions, args, cmd.OutOrStdout()) })
+			if err != nil {
 				cmd.PrintErrf("Error: %v\n", err)
 				os.Exit(1)
 			}
@@ -510,12 +763,25 @@ Examples:
   # Specify the configuration file path
   gocli project lint --config ./path/to/config.yaml
 
+  # Install golangci-lint without prompting when it is missing
+  gocli project lint --yes
+
+  # Warn when the installed golangci-lint is older than v2.1.0
+  gocli project lint --min-version 2.1.0
+
+Notes:
+  - When golangci-lint is not in PATH, gocli first looks for an existing install (tools.gocli_tools_path, GOBIN, GOPATH/bin),
+    otherwise asks to install it into tools.gocli_tools_path (--yes or lint.auto_install: true skip the prompt)
+    and runs it by absolute path.
+  - Without --min-version, the minimum version is taken from the "version" field of .golangci.yml
+    (version: "2" requires golangci-lint v2.0.0+). An older golangci-lint only produces a warning.
 `,
 		Run: func(cmd *cobra.Command, _ []string) {
 			lintOptions.Verbose = gocliCtx.Config.App.Verbose
+			lintOptions.Golangci = golangciOptions(lintOptions.Golangci)
 			err := project.RunLint(lintOptions, cmd.OutOrStdout())
 			if err != nil {
-				log.Warn().Msg("have some lint issues")
+				log.Warn().Err(err).Msg("have some lint issues")
 				os.Exit(1)
 			}
 		},
@@ -534,15 +800,22 @@ Examples:
 
   # List all available formatters
   gocli project fmt --list
+
+  # Install golangci-lint without prompting when it is missing
+  gocli project fmt --yes
+
+Notes:
+  - golangci-lint is resolved and version-checked the same way as in 'gocli project lint'.
 	`,
 		Run: func(cmd *cobra.Command, args []string) {
 			fmtOptions.Verbose = gocliCtx.Config.App.Verbose
+			fmtOptions.Golangci = golangciOptions(fmtOptions.Golangci)
 			if len(args) > 0 { // 若用户传入路径，取第一个作为路径
 				fmtOptions.Path = args[0]
 			}
 			err := project.RunFmt(fmtOptions, cmd.OutOrStdout())
 			if err != nil {
-				log.Warn().Msg("have some format issues")
+				log.Warn().Err(err).Msg("have some format issues")
 				os.Exit(1)
 			}
 		},
@@ -563,18 +836,140 @@ Examples:
 
   # Update specific module
   gocli project update github.com/charmbracelet/lipgloss
+
+  # Keep some modules at their current version
+  gocli project update --exclude github.com/spf13/cobra,golang.org/x/tools
+
+  # Machine-readable report of what changed
+  gocli project update --json
+
+  # Preview available updates (go list -m -u) without changing go.mod
+  gocli project update --dry-run
+
+Notes:
+  - After updating, a summary lists every module whose version changed
+    (~ updated old -> new, + added, - removed) followed by a count line.
+  - Excluded modules (--exclude plus update.exclude in the config file) are
+    pinned back to their previous version if go get -u moved them.
+  - If go get fails, go.mod and go.sum are restored to their previous content.
 	`,
 		Run: func(cmd *cobra.Command, args []string) {
 			opts := updateOptions
 			if gocliCtx.Config.App.Verbose {
 				opts.Verbose = true
 			}
-			if err := project.RunUpdate(opts, cmd.OutOrStdout(), args); err != nil {
+			opts.Exclude = append(append([]string{}, gocliCtx.Config.Update.Exclude...), opts.Exclude...)
+			err := withDryRun(cmd, func() error { return project.RunUpdate(opts, cmd.OutOrStdout(), args) })
+			if err != nil {
 				log.Error().Err(err).Msg("failed to run project update")
 				os.Exit(1)
 			}
 		},
 	}
+	projectGenerateCmd = &cobra.Command{
+		Use:     "generate [packages]",
+		Short:   "Run or list go:generate directives",
+		Aliases: []string{"gen"},
+		Long: `
+Run 'go generate' for the Go project, or list the //go:generate directives without executing them.
+
+Basic usage:
+  gocli project generate [flags] [packages]
+	Defaults to ./... when no packages are given.
+
+Examples:
+  # List every //go:generate directive with file:line (nothing is executed)
+  gocli project generate --list
+
+  # Run all directives of the module
+  gocli project generate
+
+  # Only run / list directives matching a regular expression
+  gocli project generate --run stringer ./pkg/...
+  gocli project generate --list --run mockgen
+
+  # Skip directives and print the commands as they run
+  gocli project generate --skip protoc -x
+
+  # Print the go command that would be executed
+  gocli project generate --dry-run
+
+Notes:
+  - --run / --skip match the full directive text (including "//go:generate"), like 'go generate -run/-skip'.
+  - --list only considers files included by the current build constraints, test files included.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			opts := genOptions
+			opts.V = opts.V || gocliCtx.Config.App.Verbose
+			if err := withDryRun(cmd, func() error { return project.RunGenerate(opts, cmd.OutOrStdout(), args) }); err != nil {
+				log.Error().Err(err).Msg("failed to run project generate")
+				os.Exit(1)
+			}
+		},
+	}
+	projectCleanCmd = &cobra.Command{
+		Use:   "clean [packages]",
+		Short: "Remove build artifacts and caches",
+		Long: `
+Remove build artifacts and caches of the Go project (wraps 'go clean') and the build
+detritus left in the module: binaries, coverage files, profiles and gocli state.
+
+Basic usage:
+  gocli project clean [flags] [packages]
+
+Examples:
+  # 'go clean' for the current package, plus untracked coverage/profile files
+  gocli project clean
+
+  # Remove the build cache and the configured output directories (clean.dirs, default dist)
+  gocli project clean --cache --dist
+
+  # Expire cached test results / remove the module download cache
+  gocli project clean --testcache
+  gocli project clean --modcache
+
+  # Remove specific output directories
+  gocli project clean --dist --dist-dir dist --dist-dir bin
+
+  # Remove selected kinds of artifacts only (no 'go clean')
+  gocli project clean --bin --cover
+  gocli project clean --profiles --state
+
+  # Remove every kind of artifact, including files committed to git
+  gocli project clean --all --tracked-too
+
+  # Print what would be executed and removed without doing it
+  gocli project clean --cache --dist --dry-run
+  gocli project clean --all --dry-run
+
+Notes:
+  - Artifact kinds:
+      --bin       output directories (clean.dirs or --dist-dir) and test binaries (*.test)
+      --cover     coverage.out, *.coverprofile
+      --profiles  *.prof, trace.out, capture.trace
+      --state     gocli state under .gocli/ (build-state.json); templates, tools and logs are kept
+      --all       all of the above
+  - Without any flag, 'go clean' runs and only --cover and --profiles are applied.
+  - Files are searched from the module root (the directory of go.mod) and every removed path is printed.
+    Hidden directories and vendor/ are skipped, and symlinks are never followed out of the module root.
+  - Files tracked by git (and output directories containing tracked files) are skipped unless --tracked-too is given.
+  - --dist only removes directories inside the current working directory.
+  - When only artifact flags are given (no packages), 'go clean' is not invoked.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			opts := cleanOptions
+			opts.Verbose = opts.Verbose || gocliCtx.Config.App.Verbose
+			if len(opts.DistDirs) > 0 {
+				opts.Dist = true
+			} else {
+				opts.DistDirs = gocliCtx.Config.Clean.Dirs
+			}
+			if err := withDryRun(cmd, func() error { return project.RunClean(opts, cmd.OutOrStdout(), args) }); err != nil {
+				log.Error().Err(err).Msg("failed to run project clean")
+				os.Exit(1)
+			}
+		},
+	}
 	projectDepsCmd = &cobra.Command{
 		Use:   "deps",
 		Short: "Manage dependencies of the Go project",
@@ -609,6 +1004,9 @@ Examples:
   gocli project deps -n    # shorthand for vendor
   gocli project deps --download
   gocli project deps -w    # shorthand for download
+  # preview what tidy would change without touching go.mod/go.sum, or tidy despite uncommitted go.mod edits
+  gocli project deps --tidy-diff
+  gocli project deps --tidy --allow-dirty
 
   # 6. Verify module checksums (go mod verify)
   gocli project deps --verify
@@ -617,6 +1015,9 @@ Examples:
   # 7. Check for available updates (adds -u) and output JSON
   gocli project deps --update
   gocli project deps -u -j
+  # choose upgrades one module at a time (y = latest, p = patch only, q = stop asking)
+  gocli project deps --update --interactive
+  gocli project deps -u -i github.com/spf13/cobra golang.org/x/mod
 
   # 8. Explain why packages/modules are required (defaults to ./... when no target provided)
   # - basic why for current module
@@ -628,12 +1029,32 @@ Examples:
   gocli project deps -y -m std
   gocli project deps --why --why-vendor ./...
   gocli project deps -y -V ./...
-
-  # 10. Verbose output combined with tree/graph views
+  # - several targets at once, merged into one tree (or JSON with -j)
+  gocli project deps --why github.com/spf13/cobra golang.org/x/mod/semver
+  gocli project deps -y -j github.com/spf13/cobra
+  # - also show how the providing module was pulled in: main module -> ... -> module@version
+  gocli project deps --why-paths golang.org/x/text/unicode/norm
+  gocli project deps --why-paths --why-module golang.org/x/sys
+
+  # 10. Inspect go.mod replace/exclude/retract directives (JSON with -j, retracted requirements with -u)
+  gocli project deps --directives
+  gocli project deps --directives -j
+  # - fail in CI when a local 'replace foo => ../foo' was committed
+  gocli project deps --check-replaces
+  # - develop against a local fork, then restore the upstream module (add --tidy to sync go.sum afterwards)
+  gocli project deps --replace github.com/spf13/cobra=../cobra --tidy
+  gocli project deps --replace golang.org/x/mod@v0.20.0=example.com/mod-fork@v0.20.1
+  gocli project deps --drop-replace github.com/spf13/cobra --tidy
+
+  # 11. Verbose output combined with tree/graph views
   gocli project deps --tree --verbose
   gocli project deps -t -v
 
-  # 11. Full example that exercises most flags and uses short forms where available
+  # 12. Run for every module of the go.work workspace (a heading per module)
+  gocli project deps --workspace
+  gocli project deps --workspace --tidy
+
+  # 13. Full example that exercises most flags and uses short forms where available
   # (lists updates in JSON, shows tree, enables verbose output, and runs verify)
   gocli project deps -u -j -t -v -f ./...
 
@@ -641,8 +1062,32 @@ Notes:
   - Short flags: -j (json), -u (update), -t (tree), -g (graph), -v (verbose),
 	-d (tidy), -n (vendor), -w (download), -f (verify), -y (why), -m (why-module), -V (why-vendor).
   - Maintenance actions like --tidy, --vendor and --download modify module files; run intentionally and commit changes if desired.
+    They can be combined (run in the order tidy, vendor, download, verify); add --dry-run to only print the commands.
+  - --tidy, --vendor and --download refuse to run when 'git status' shows uncommitted changes to go.mod or go.sum
+    (on a terminal they ask first); pass --allow-dirty to proceed anyway. Outside a git repository there is no check.
+  - --tidy ends with a summary of the requirements added, removed or changed and the go.sum entries delta.
+    --tidy-diff runs tidy on a temporary copy of go.mod/go.sum (GOFLAGS=-modfile) and only prints that summary.
+  - --update --interactive lists the go.mod requirements with a newer version ('go list -m -u'), limited to the given
+    module paths if any, and asks y/N/p/q for each; the selection is applied with a single 'go get path@version'
+    (path@patch for p), go.mod/go.sum are restored if it fails, and the version changes are printed like 'project update'.
+  - --replace and --drop-replace edit go.mod with 'go mod edit' before any maintenance action, so combining them with
+    --tidy syncs go.sum in one run. Relative local paths are resolved from the current directory and written relative
+    to go.mod; an existing directory without ./ is treated as a local path. A warning is printed when it has no go.mod.
   - --why accepts package patterns (e.g. ./... or a specific import path). When no target is provided it defaults to ./...
+  - Multiple --why targets are queried concurrently; targets not needed by the main module are listed at the end.
+  - --why-paths implies --why and follows the 'go mod graph' requirement edges from the main module to the module
+    providing each target; up to 10 chains per module are shown, those through the modules of the import chain first,
+    then shortest first (JSON: "module" and "module_paths").
   - Use --verbose (-v) to get more diagnostic output when combining views (tree/graph/why).
+  - The default listing ends with a short warning section when go.mod has replace/exclude directives;
+    with --json a final {"directives": ...} object (the --directives -j value) follows the 'go list -m -json' objects.
+  - --directives -u queries the module proxy to find required versions that were retracted upstream.
+  - With the global --output-format json|yaml the result is wrapped in {"command", "data", "error"}: the default
+    listing becomes an array of 'go list -m -json' objects (plus the directives object above), --why/--directives their JSON value, and text-only
+    views (tree, graph, tidy, ...) a string. -j keeps the previous bare JSON output.
+  - --workspace runs the command from each 'use' directory of the go.work selected by GOWORK (env.GOWORK in the
+    config) with GOWORK=off, so every module reports its own go.mod rather than the merged workspace build list.
+    JSON output concatenates the results of all modules.
 `,
 		Aliases: []string{"dep", "mod"},
 		Run: func(cmd *cobra.Command, args []string) {
@@ -650,8 +1095,42 @@ Notes:
 			if gocliCtx.Config.App.Verbose {
 				opts.Verbose = true
 			}
+			if depsWorkspace {
+				opts.Workspace = workspaceModules()
+			}
+			// 输出会先缓冲，go.mod/go.sum 有未提交修改时的确认提示直接写到 stderr
+			if style.IsTerminal(os.Stdin) && style.IsTerminal(os.Stderr) {
+				opts.Prompt = cmd.ErrOrStderr()
+				opts.Input = cmd.InOrStdin()
+			}
+			// 交互式升级直接读写终端：提示需要在读取输入之前显示，不能先缓冲
+			if opts.Update && opts.Interactive {
+				opts.Input = cmd.InOrStdin()
+				if err := withDryRun(cmd, func() error { return project.RunDeps(opts, cmd.OutOrStdout(), args) }); err != nil {
+					log.Error().Err(err).Msg("failed to upgrade dependencies")
+					os.Exit(1)
+				}
+				return
+			}
+			format := outputFormat(cmd, "json")
+			if format.Structured() && !dryRunFlag {
+				if err := runDepsEnvelope(cmd, format, opts, args); err != nil {
+					os.Exit(1)
+				}
+				return
+			}
+			// --dry-run：先输出只读命令生成的内容，再列出将要执行的命令
+			if dryRunFlag {
+				if err := withDryRun(cmd, func() error { return project.RunDeps(opts, cmd.OutOrStdout(), args) }); err != nil {
+					log.Error().Err(err).Msg("failed to run project deps")
+					os.Exit(1)
+				}
+				return
+			}
 			var b strings.Builder
 			if err := project.RunDeps(opts, &b, args); err != nil {
+				// 先输出已生成的内容（例如 --check-replaces 列出的本地替换）
+				cmd.Print(b.String())
 				log.Error().Err(err).Msg("failed to run project deps")
 				os.Exit(1)
 			}
@@ -689,25 +1168,219 @@ Examples:
   gocli project doc go/doc
   gocli project doc gorm.io/gorm
 
+  # Fetch a module that is not in the module cache yet (optionally pin a version)
+  gocli project doc --fetch github.com/google/uuid
+  gocli project doc --fetch golang.org/x/mod/semver@v0.20.0
+
   # Render a markdown file (mode will auto set to markdown when extension is .md or .markdown)
   gocli project doc ./README.md --style=markdown -o README_rendered.md
 
+  # Write into a directory that does not exist yet, or append to an existing file
+  gocli project doc ./pkg -o build/docs/pkg.md
+  gocli project doc ./cmd -o build/docs/all.md:append
+
+  # Copy rendered markdown docs to the system clipboard
+  gocli project doc ./pkg --style markdown -o clipboard:
+
+  # Render every package of the module, one after another or one file per package
+  gocli project doc ./...
+  gocli project doc --all ./pkg
+  gocli project doc ./... --style markdown -o docs/
+
   # Include tests and examples
   gocli project doc ./cmd --tests
   gocli project doc ./cmd --examples
 
+  # Check that examples still compile and their // Output: matches
+  gocli project doc ./pkg --examples --verify-examples
+  gocli project doc ./pkg --verify-examples --detailed
+
+  # Show which in-package interfaces each type implements (and their implementations)
+  gocli project doc ./pkg/tools --detailed --type-info
+
+  # Also list implementations (and implemented interfaces) from other packages of the module
+  gocli project doc ./pkg/tools --detailed --implementers=module
+
+  # Show only selected sections (consts, vars, funcs, types, examples)
+  gocli project doc ./pkg/tools --only funcs,types
+  gocli project doc ./pkg/tools --skip consts,vars
+  gocli project doc ./pkg/tools --only examples --examples
+
+  # Document the declarations of another platform or of files behind a build tag
+  gocli project doc ./pkg/utils/hotload --tags windows --private
+  gocli project doc ./pkg --tags linux,arm64,integration
+
+  # Navigable markdown: anchors per declaration, linked symbol mentions and a symbol index
+  gocli project doc ./pkg --style markdown --xref
+
+  # Keep the declaration order of the source files instead of sorting by name
+  gocli project doc ./pkg/tools --sort source
+
+  # Generate a markdown tree of the whole module (docs/api/pkg/tools.md, ...) with a SUMMARY.md index
+  gocli project doc --tree -o docs/api/
+  gocli project doc ./pkg --tree -o docs/api/ --include-internal --keep-stale
+
+  # API changes since a release (added/removed/changed signatures), e.g. for release notes
+  gocli project doc ./pkg --diff v1.2.0
+  gocli project doc --diff v1.2.0 --style markdown -o CHANGES-API.md
+
+  # Prepend the package README (raw for markdown, stripped for plain, converted for html)
+  gocli project doc ./pkg/tools --with-readme
+
+  # Plain output is colored on terminals; pick the light palette or turn colors off
+  gocli project doc ./pkg/tools --theme light
+  gocli project doc ./pkg/tools --no-color
+
+  # Markdown themes: list them, pick one by name or use a glamour style JSON file
+  gocli project doc --list-themes
+  gocli project doc ./pkg/tools --mode markdown --theme tokyo-night
+  gocli project doc ./pkg/tools --mode markdown --theme ./mytheme.json
+
+  # Render markdown or a doc comment snippet read from stdin
+  cat NOTES.md | gocli project doc - --theme dracula
+  pbpaste | gocli project doc - --mode godoc --width 72
+
+  # Reuse rendered docs across runs (doc.cache: true), bypass or empty the cache
+  gocli project doc ./pkg/tools --no-cache
+  gocli project doc --clear-cache
+
+  # Browse docs of the current module in a local HTTP server (godoc-like)
+  gocli project doc --serve
+  gocli project doc --serve=:0
+
 Notes:
-- For remote package docs the tool may need network access to fetch module source (behaves like 'go list'/'go doc').
-- Large outputs can be redirected to a file using -o. Themes and --width can help produce readable markdown/HTML.
+- Third-party import paths are looked up in GOMODCACHE; with --fetch, missing modules are downloaded with
+  'go mod download' (network access, does not modify the current go.mod/go.sum).
+- Large outputs can be redirected to a file using -o. Special -o targets: '-' (stdout), 'stderr' and 'clipboard:'
+  (copies the rendered docs via pbcopy, clip, wl-copy, xclip or xsel). Missing parent directories of the -o file are
+  created; a trailing ':append' (or --append) appends instead of overwriting. Themes and --width can help produce readable markdown/HTML.
+- --only and --skip are mutually exclusive; --only also omits the package overview, file/import lists, notes and tests.
+- --sort alpha (default, also doc.sort in the config) orders constants, variables, functions, types with their
+  methods, examples, notes and tests by name, so renaming or splitting files does not reorder the output;
+  --sort source follows the declaration position (file name, then offset).
+- --style markdown renders one heading per function, type and method with the declaration in a go code block;
+  [Name] and [pkg.Name] doc links become links (other packages point to pkg.go.dev). --xref (markdown only) adds an
+  anchor to every declaration, links mentions of exported symbols of the package (Name or Type.Method) in doc
+  comments and appends an alphabetical "Index" section.
+- --verify-examples runs 'go test' in the package directory; examples without an // Output: comment are
+  only compiled and shown as NOT RUN. In --detailed mode the got/want output of failing examples is shown.
+- --detailed lists struct fields (type, struct tags split by key, first doc line; embedded fields are marked) and
+  interface methods after each type declaration; with --style markdown they are rendered as tables. Fields of
+  anonymous structs are listed as Parent.Field, and multi-line field comments (or a doc comment plus a trailing
+  comment) are shown in full, indented beneath the table.
+- --type-info loads the package with go/packages and type-checks it, so it is slower than plain parsing; only
+  interfaces and types declared in the same package are related. When type checking fails (e.g. missing
+  dependencies) the implements/implemented by lines are omitted.
+- --implementers (same as --implementers=package) is equivalent to --type-info; --implementers=module loads every
+  package of the module ('./...' from the go.mod directory) and also relates exported types of other packages,
+  written as pkg.Type. Packages of the module that fail to type-check are skipped.
+- '-' reads the content to render from stdin and cannot be combined with other paths, --all, --tree or --diff.
+  It is rendered as markdown unless --mode godoc or --style plain is given, which treat it as doc comment text
+  (leading // markers are stripped) and wrap it to --width; --mode wins over --style. --style html, json and
+  yaml are rejected. Empty input is an error.
+- --serve binds to localhost by default; pages are rendered lazily and refreshed when source files change.
+  The address is optional and must be attached with '=' (--serve=:0, --serve=127.0.0.1:8080): in
+  '--serve :0' the address is parsed as a package argument, which is rejected.
+- With --all or a ./... pattern, packages are listed with 'go list'; when -o is a directory (existing or ending in
+  '/') each package is written to its own file named after its path inside the module (e.g. pkg_tools.md).
+  Packages are parsed and rendered concurrently (--concurrency or doc.concurrency, default: CPU cores); the output
+  order always follows 'go list'.
+- --tree renders every package under the arguments (default: the whole module) as markdown into a mirrored layout
+  under the -o directory (pkg/utils/doc -> docs/api/pkg/utils/doc.md) and writes a SUMMARY.md listing each
+  package with its synopsis, usable by mkdocs or GitBook. Unchanged files are not rewritten. Files generated by a
+  previous run (recorded in .gocli-doc-tree) whose package no longer exists are deleted unless --keep-stale is
+  given; other files in the directory are never touched. internal and testdata packages are skipped unless
+  --include-internal / --include-testdata is given.
+- --diff <gitref> compares the exported API of every package under the arguments (default: the whole module)
+  between the git revision (tag, branch or commit) and the working tree. The old files are read with 'git show'
+  (no checkout); output lists added (+), removed (-) and changed (~) constants, variables, functions, types and
+  methods. --style markdown writes a diff block per package, --style json the raw changes; --private also compares
+  unexported symbols. Packages deleted since the revision are listed with all their symbols removed.
+- Only files whose build constraints (//go:build lines and _GOOS/_GOARCH file name suffixes) match the build
+  configuration are documented, like 'go doc' does for the current platform. --tags (or doc.tags) changes it:
+  GOOS and GOARCH names select the target platform, other entries are passed as build tags (-tags); --all, --tree,
+  --diff and --type-info use the same configuration. Cached renders are keyed by it.
+- Doc comments are wrapped to --width (default: terminal width, 80 with -o or when piped); code blocks and signatures are never wrapped.
+- --theme accepts a built-in glamour theme, the name of ~/.gocli/themes/<name>.json or a path to a style JSON file;
+  unknown names fail before rendering. Without --theme, dracula is used on dark terminals and light otherwise.
+  When colors are disabled (NO_COLOR, pipes, files) the colorless notty style is always used.
+- --style plain colors the package line, section headings, declarations and Deprecated notes when writing to a
+  terminal (NO_COLOR and FORCE_COLOR are honored; pipes and -o files stay plain). --theme picks the palette: names
+  containing "light" use the light one, notty/ascii disable colors, others use the dark one; without --theme the
+  terminal background decides. --no-color (alias --plain-no-color, or doc.no_color) always disables it.
+- With doc.cache enabled in the config, rendered package docs are stored under ~/.gocli/cache/doc, keyed by the
+  names, sizes and modification times of the package files plus the render options; the least recently used
+  entries are evicted beyond doc.cache_size_mb (default 100). --verify-examples, --type-info and --implementers
+  always render fresh.
+- Output longer than the terminal is paged through $PAGER (default "less -R"); use --no-pager or app.pager=false to disable.
 `,
 		Run: func(cmd *cobra.Command, args []string) {
+			// doc.concurrency 来自配置文件，命令行 --concurrency 优先
+			if !cmd.Flags().Changed("concurrency") && gocliCtx.Config.Doc.Concurrency > 0 {
+				docOptions.Concurrency = gocliCtx.Config.Doc.Concurrency
+			}
+			// doc.sort 来自配置文件，命令行 --sort 优先
+			if !cmd.Flags().Changed("sort") && gocliCtx.Config.Doc.Sort != "" {
+				docOptions.Sort = gocliCtx.Config.Doc.Sort
+			}
+			// doc.no_color 来自配置文件，命令行 --no-color 优先
+			if !cmd.Flags().Changed("no-color") && !cmd.Flags().Changed("plain-no-color") {
+				docOptions.NoColor = gocliCtx.Config.Doc.NoColor
+			}
+			// doc.tags 来自配置文件，命令行 --tags 优先
+			if !cmd.Flags().Changed("tags") && len(gocliCtx.Config.Doc.Tags) > 0 {
+				docOptions.Tags = gocliCtx.Config.Doc.Tags
+			}
+			// 渲染缓存只能由配置开启，--no-cache 在本次运行中关闭
+			docOptions.Cache = gocliCtx.Config.Doc.Cache && !docNoCache
+			docOptions.CacheSizeMB = gocliCtx.Config.Doc.CacheSizeMB
 			gocliCtx.Config.Doc = docOptions
-			if len(args) == 0 {
+			if docClearCache {
+				files, freed, err := doc.ClearCache()
+				if err != nil {
+					log.Error().Err(err).Msg("failed to clear doc cache")
+					os.Exit(1)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "cleared %d cached doc(s) from %s, freed %s\n", files, doc.CacheDir(), formatBytes(freed))
+				return
+			}
+			if docListThemes {
+				if err := printMarkdownThemes(cmd.OutOrStdout()); err != nil {
+					log.Error().Err(err).Msg("failed to list themes")
+					os.Exit(1)
+				}
+				return
+			}
+			if docOptions.Tree {
+				// --tree 总是写 markdown，未指定参数时生成整个模块
+				if !cmd.Flags().Changed("style") {
+					docOptions.Style = doc.StyleMarkdown
+				}
+				if len(args) == 0 {
+					args = []string{"."}
+				}
+			}
+			// "-" 读取标准输入：--mode 优先，其次 --style（plain 按文档注释文本渲染），默认按 markdown 渲染
+			if slices.Contains(args, "-") && !cmd.Flags().Changed("mode") {
+				docOptions.Mode = doc.ModeMarkdown
+				if cmd.Flags().Changed("style") && docOptions.Style == doc.StylePlain {
+					docOptions.Mode = doc.ModeGodoc
+				}
+			}
+			if docOptions.Diff != "" && len(args) == 0 {
+				args = []string{"."}
+			}
+			if len(args) == 0 && docOptions.Serve == "" {
 				_ = cmd.Help()
 				os.Exit(0)
 			}
 
-			if err := project.RunDoc(gocliCtx, docOptions, cmd.OutOrStdout(), args); err != nil {
+			// --serve 为阻塞的 HTTP 服务，不能经过分页器缓冲；写入文件时也不需要分页
+			paged := usePager() && docOptions.Serve == "" && project.IsStdoutOutput(docOptions.Output)
+			err := style.WithPager(cmd.OutOrStdout(), paged, func(w io.Writer) error {
+				return project.RunDoc(gocliCtx, docOptions, w, args)
+			})
+			if err != nil {
 				log.Error().Err(err).Msg("failed to run project doc")
 				os.Exit(1)
 			}
@@ -715,10 +1388,77 @@ Notes:
 	}
 )
 
+// printMarkdownThemes 输出 --theme 可用的主题：glamour 内置主题与 ~/.gocli/themes 中的自定义主题
+func printMarkdownThemes(w io.Writer) error {
+	rows := [][]string{}
+	for _, t := range style.ListThemes() {
+		source := "built-in"
+		if t.Path != "" {
+			source = t.Path
+		}
+		rows = append(rows, []string{t.Name, source})
+	}
+	return style.PrintTable(w, []string{"Theme", "Source"}, rows, 0)
+}
+
+// formatBytes 以 1024 进制输出可读的字节数
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// runDepsEnvelope 以全局 --output-format json|yaml 的信封输出 project deps 的结果
+// 支持 JSON 的视图放入结构化数据，其余视图的文本输出作为字符串放入 data
+func runDepsEnvelope(cmd *cobra.Command, format style.OutputFormat, opts project.DepsOptions, args []string) error {
+	supported, stream := project.DepsJSONMode(opts)
+	opts.JSON = supported
+	var b strings.Builder
+	err := project.RunDeps(opts, &b, args)
+	var data any
+	switch {
+	case err != nil && b.Len() > 0:
+		data = strings.TrimRight(b.String(), "\n")
+	case err != nil:
+	case !supported:
+		data = strings.TrimRight(b.String(), "\n")
+	default:
+		var values []any
+		values, err = style.DecodeJSONStream(b.String())
+		if err == nil {
+			data = values
+			if !stream && len(values) == 1 {
+				data = values[0]
+			}
+		}
+	}
+	printEnvelope(cmd, format, data, err)
+	return err
+}
+
+// restoreArgsSeparator 重新插入被 cobra 移除的 "--"，以便区分包参数与传给程序的参数
+func restoreArgsSeparator(cmd *cobra.Command, args []string) []string {
+	dash := cmd.ArgsLenAtDash()
+	if dash < 0 {
+		return args
+	}
+	out := make([]string, 0, len(args)+1)
+	out = append(out, args[:dash]...)
+	out = append(out, "--")
+	return append(out, args[dash:]...)
+}
+
 func addInitFlags(cmd *cobra.Command, opts *project.InitOptions) {
 	// List Flags (also output format)
 	cmd.Flags().BoolVarP(&opts.List, "list", "l", false, "List available templates")
-	cmd.Flags().StringVarP(&opts.Format, "format", "f", "", "Output format (json|yaml|plain|table) only used with --list")
+	cmd.Flags().StringVarP(&opts.Format, "format", "f", "", "Output format (json|yaml|toml|plain|table) only used with --list")
 	cmd.Flags().BoolVarP(&opts.JSON, "json", "j", false, "Output in JSON format")
 	cmd.Flags().BoolVarP(&opts.YAML, "yaml", "y", false, "Output in YAML format")
 	cmd.Flags().BoolVarP(&opts.Plain, "plain", "p", false, "Output plain list")
@@ -728,6 +1468,7 @@ func addInitFlags(cmd *cobra.Command, opts *project.InitOptions) {
 	cmd.Flags().StringVarP(&opts.Template, "template", "m", "", "Project template name (use --list to see available templates)")
 	cmd.Flags().StringVarP(&opts.Project.Dir, "dir", "d", "", "Project directory (defaults to current directory)")
 	cmd.Flags().BoolVarP(&opts.Force, "force", "F", false, "Force overwrite existing files")
+	cmd.Flags().BoolVar(&opts.Yes, "yes", false, "Run the template's post_init commands without asking for confirmation")
 
 	// Project Init
 	cmd.Flags().BoolVar(&opts.Project.GoTaskInit, "go-task", false, "Initialize go-task configuration")
@@ -774,6 +1515,28 @@ func addBuildRunFlags(cmd *cobra.Command, opts *project.BuildRunOptions) {
 	cmd.Flags().BoolVar(&opts.DebugBuild, "debug-mode", false, "Build in debug mode (disable optimizations and enable debug info)")
 	cmd.Flags().BoolVarP(&opts.HotReload, "hot-reload", "r", false, "Enable hot reloading of code changes")
 	cmd.Flags().BoolVar(&opts.NoGitIgnore, "no-gitignore", false, "Disable .gitignore file filtering during hot reload")
+	cmd.Flags().StringArrayVar(&opts.WatchPaths, "watch-path", nil, "Additional path to watch during hot reload, e.g. ../shared/templates (repeatable, overrides app.hotload.watch_paths)")
+	cmd.Flags().BoolVar(&opts.Stats, "stats", false, "During hot reload, print event and rebuild statistics on SIGUSR1 or when 's' + Enter is typed")
+}
+
+// addBuildOnlyFlags adds flags that only apply to `project build`.
+func addBuildOnlyFlags(cmd *cobra.Command, opts *project.BuildRunOptions) {
+	cmd.Flags().StringSliceVar(&opts.Platforms, "platforms", nil, "Cross-compile for these GOOS/GOARCH targets (comma or repeated), e.g. linux/amd64,windows/arm64")
+	cmd.Flags().StringVar(&opts.OutputTemplate, "output-template", "", "Template for the output path with {{.Name}} {{.OS}} {{.Arch}} {{.Version}} {{.Ext}}, rendered per target")
+	cmd.MarkFlagsMutuallyExclusive("output", "output-template")
+	cmd.Flags().BoolVar(&opts.BuildSummary, "build-summary", false, "Parse -x output and report recompiled packages, build cache hits and total time")
+	cmd.Flags().BoolVar(&opts.ExplainCache, "explain-cache", false, "Report rebuilt vs cached packages, likely cache-busting reasons and GOCACHE size/age")
+	cmd.Flags().BoolVar(&opts.CleanCache, "clean-cache", false, "Run 'go clean -cache -testcache' before building and report the GOCACHE size before/after")
+	addWorkspaceFlag(cmd, &buildWorkspace, "Build ./... (or the given packages) in every module of the go.work workspace")
+}
+
+// addRunOnlyFlags adds flags that only apply to `project run`.
+func addRunOnlyFlags(cmd *cobra.Command, opts *project.BuildRunOptions) {
+	cmd.Flags().StringArrayVar(&opts.EnvFiles, "env-file", nil, "Load environment variables for the program from a dotenv file (repeatable, later files win; overrides run.env_files)")
+	cmd.Flags().BoolVar(&opts.AllMains, "all-mains", false, "Run every main package under the given patterns (default ./...) concurrently with prefixed output")
+	cmd.Flags().BoolVar(&opts.KeepRunning, "keep-running", false, "With several entrypoints, keep the other processes running when one of them fails")
+	cmd.Flags().IntSliceVar(&opts.CheckPorts, "check-port", nil, "Abort before starting when this TCP port is already in use and report the owning process (repeatable; overrides run.check_ports)")
+	cmd.Flags().BoolVar(&opts.KillConflicting, "kill-conflicting", false, "With --check-port/run.check_ports, offer to terminate the process holding a port (always asks for confirmation)")
 }
 
 func addInfoFlags(cmd *cobra.Command, opts *project.InfoOptions) {
@@ -784,7 +1547,10 @@ func addInfoFlags(cmd *cobra.Command, opts *project.InfoOptions) {
 	// keep --no-gitignore without a short alias to avoid confusion with --gitignore
 	cmd.Flags().Bool("no-gitignore", false, "Do not respect .gitignore (overrides --gitignore)")
 	cmd.Flags().BoolVarP(&opts.FollowSymlinks, "follow-symlinks", "L", false, "Follow symbolic links")
+	cmd.Flags().BoolVar(&opts.StayInRoot, "stay-in-root", true, "With --follow-symlinks, skip symlinks that resolve outside the project root")
+	cmd.Flags().IntVar(&opts.MaxDepth, "max-depth", fsop.DefaultMaxDepth, "Maximum directory depth to descend into")
 	cmd.Flags().Int64VarP(&opts.MaxFileSizeBytes, "max-file-size", "m", 0, "Skip files larger than this size in bytes (0 means no limit)")
+	cmd.Flags().BoolVar(&opts.GoOnly, "go-only", false, "Only count .go files and skip everything else (faster on huge repositories)")
 	cmd.Flags().IntVarP(&opts.Concurrency, "concurrency", "C", 0, "Number of concurrent workers (0 uses CPU cores)")
 	cmd.Flags().BoolVarP(&opts.WithFunctions, "funcs", "F", true, "Count functions for supported languages (Go)")
 	cmd.Flags().BoolVarP(&opts.WithStructs, "structs", "S", true, "Count structs/types for supported languages (Go)")
@@ -793,28 +1559,69 @@ func addInfoFlags(cmd *cobra.Command, opts *project.InfoOptions) {
 	cmd.Flags().BoolP("json", "j", false, "Output result in JSON format (auto-enabled if --language-files or explicit --lang-specific used)")
 	cmd.Flags().BoolVarP(&opts.WithLanguageDetails, "language-files", "l", false, "Include per-file list inside each language (auto enables --json)")
 	cmd.Flags().BoolVarP(&opts.WithLanguageSpecific, "lang-specific", "k", true, "Include language specific metadata (e.g. Go imports) (explicit use auto enables --json)")
+	cmd.Flags().StringVar(&opts.Format, "format", "", "Output format: text|json|markdown (markdown prints a README-ready section)")
+	cmd.Flags().BoolVar(&opts.WithPackages, "packages", false, "Include the Go package count in markdown output (runs 'go list ./...')")
+	cmd.Flags().StringVar(&opts.BadgeDir, "badge-json", "", "Write shields.io endpoint badge files (loc.json, go-files.json, coverage.json) to this directory")
+	cmd.Flags().StringVar(&opts.ProgressMode, "progress", "auto", "Show counting progress on stderr: auto|always|never (--progress alone means always)")
+	cmd.Flags().Lookup("progress").NoOptDefVal = "always"
+	addOutputFileFlag(cmd, &infoOutput)
+	cmd.Flags().BoolVar(&opts.Git, "git", false, "Add git statistics: current branch, commit and contributor counts, first/last commit dates")
+	cmd.Flags().BoolVar(&opts.Owners, "owners", false, "Add code ownership: top 3 authors per language and the project-wide distribution by surviving lines (git blame)")
+	cmd.Flags().BoolVar(&opts.OwnersFast, "owners-fast", false, "Like --owners but approximated from one 'git log --numstat' (added minus deleted lines), much faster")
+	addWorkspaceFlag(cmd, &infoWorkspace, "Analyze every module of the go.work workspace separately")
 
 }
 
 func addDepsFlags(cmd *cobra.Command, opts *project.DepsOptions) {
 	cmd.Flags().BoolVarP(&opts.JSON, "json", "j", false, "Output dependencies as JSON (go list -m -json)")
 	cmd.Flags().BoolVarP(&opts.Update, "update", "u", false, "Check for available updates (adds -u)")
+	cmd.Flags().BoolVarP(&opts.Interactive, "interactive", "i", false, "With --update, ask per module whether to upgrade (y/n/patch only) and run 'go get' for the selection")
 	cmd.Flags().BoolVarP(&opts.Tree, "tree", "t", false, "Display dependency tree (from 'go mod graph')")
 	cmd.Flags().BoolVarP(&opts.Graph, "graph", "g", false, "Display dependency graph (raw 'go mod graph')")
 	cmd.Flags().BoolVarP(&opts.Verbose, "verbose", "v", false, "Verbose output")
-	cmd.Flags().BoolVarP(&opts.Tidy, "tidy", "d", false, "Run 'go mod tidy'")
+	cmd.Flags().BoolVarP(&opts.Tidy, "tidy", "d", false, "Run 'go mod tidy' and summarize the go.mod/go.sum changes")
+	cmd.Flags().BoolVar(&opts.TidyDiff, "tidy-diff", false, "Report what 'go mod tidy' would change (run on a temporary copy via -modfile) without modifying go.mod/go.sum")
+	cmd.Flags().BoolVar(&opts.AllowDirty, "allow-dirty", false, "Run --tidy/--vendor/--download even when go.mod or go.sum has uncommitted changes")
 	cmd.Flags().BoolVarP(&opts.Vendor, "vendor", "n", false, "Run 'go mod vendor'")
 	cmd.Flags().BoolVarP(&opts.Download, "download", "w", false, "Run 'go mod download'")
 	cmd.Flags().BoolVarP(&opts.Verify, "verify", "f", false, "Run 'go mod verify'")
 	cmd.Flags().BoolVarP(&opts.Why, "why", "y", false, "Run 'go mod why' for given targets (defaults to ./... if none)")
 	cmd.Flags().BoolVarP(&opts.WhyModule, "why-module", "m", false, "Explain why modules are needed (adds -m)")
 	cmd.Flags().BoolVarP(&opts.WhyVendor, "why-vendor", "V", false, "Explain use of vendored packages (adds -vendor)")
+	cmd.Flags().BoolVar(&opts.WhyPaths, "why-paths", false, "Like --why, plus the module dependency chains from the main module to each target (from 'go mod graph')")
+	cmd.Flags().BoolVar(&opts.Directives, "directives", false, "List go.mod replace/exclude/retract directives (with -u also checks retracted requirements)")
+	cmd.Flags().BoolVar(&opts.CheckReplaces, "check-replaces", false, "Exit non-zero when go.mod contains filesystem replace directives (for CI)")
+	cmd.Flags().StringArrayVar(&opts.Replace, "replace", nil, "Add or update a go.mod replace directive: old[@version]=new[@version] (repeatable; 'go mod edit -replace')")
+	cmd.Flags().StringArrayVar(&opts.DropReplace, "drop-replace", nil, "Remove the go.mod replace directive for old[@version] (repeatable; 'go mod edit -dropreplace')")
+	cmd.MarkFlagsMutuallyExclusive("interactive", "json")
+	cmd.MarkFlagsMutuallyExclusive("tidy", "tidy-diff")
+	addWorkspaceFlag(cmd, &depsWorkspace, "Run for every module of the go.work workspace (each with GOWORK=off)")
+	addDryRunFlag(cmd, "")
 }
 
 // addListFlags registers flags for the `project list` command.
 func addListFlags(cmd *cobra.Command, opts *project.ListOptions) {
 	cmd.Flags().BoolVarP(&opts.JSON, "json", "j", false, "Output packages as JSON array")
 	cmd.Flags().BoolVar(&opts.Test, "test", false, "Include test packages (adds -test)")
+	cmd.Flags().StringVar(&opts.Format, "format", project.ListFormatList, "Output format: list|table|json (table shows Go file, test file and import counts per package)")
+	cmd.MarkFlagsMutuallyExclusive("json", "format")
+	addOutputFileFlag(cmd, &listOutput)
+	addWorkspaceFlag(cmd, &listWorkspace, "List the packages of every module of the go.work workspace")
+}
+
+// addWorkspaceFlag registers --workspace for the project commands that can iterate the go.work modules.
+func addWorkspaceFlag(cmd *cobra.Command, target *bool, usage string) {
+	cmd.Flags().BoolVar(target, "workspace", false, usage)
+}
+
+// workspaceModules loads the modules of the go.work selected by the GOWORK setting, exiting on failure.
+func workspaceModules() []project.WorkspaceModule {
+	mods, err := project.LoadWorkspace(gocliCtx.Config.Env.GoWork)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to load the go.work workspace")
+		os.Exit(1)
+	}
+	return mods
 }
 
 // addAddFlags registers flags for the `project add` command.
@@ -824,12 +1631,14 @@ func addAddFlags(cmd *cobra.Command, opts *project.AddOptions) {
 	cmd.Flags().BoolVar(&opts.UPatch, "update-patch", false, "Update to patch releases (equivalent to -u=patch)")
 	cmd.Flags().BoolVar(&opts.Tool, "tool", false, "Add tool line to go.mod")
 	cmd.Flags().BoolVarP(&opts.X, "print-commands", "x", false, "Print commands as they are executed")
+	addDryRunFlag(cmd, "n")
 }
 
 // addTestFlags registers flags for the `project test` command.
 func addTestFlags(cmd *cobra.Command, opts *project.TestOptions) {
 	// Core selection & execution flags
 	cmd.Flags().BoolVarP(&opts.V, "verbose", "v", false, "Verbose output (alias of -v)")
+	addDryRunFlag(cmd, "n")
 	cmd.Flags().StringVar(&opts.Run, "run", "", "Run only those tests matching the regular expression")
 	cmd.Flags().StringVar(&opts.Bench, "bench", "", "Run only benchmarks matching the regular expression (use '.' to run all)")
 	cmd.Flags().StringVar(&opts.Benchtime, "benchtime", "", "Run enough iterations of each benchmark to take this duration or N times (e.g. 2s, 100x)")
@@ -858,6 +1667,10 @@ func addTestFlags(cmd *cobra.Command, opts *project.TestOptions) {
 	// JSON / output formatting
 	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Log verbose output and test results in JSON (machine-readable)")
 
+	// Timing report (implies -json internally)
+	cmd.Flags().IntVar(&opts.Slowest, "slowest", 0, "After the run, print the N slowest tests and per-package totals")
+	cmd.Flags().StringVar(&opts.TimingOut, "timing-out", "", "Write the elapsed time of every test and package to a JSON file")
+
 	// Build / binary control
 	cmd.Flags().BoolVar(&opts.C, "compile-only", false, "Compile test binary to -o file but do not run tests (alias of -c)")
 	cmd.Flags().StringVar(&opts.O, "output", "", "Name of compiled test binary when using -c / --compile-only")
@@ -893,6 +1706,21 @@ func addLintFlags(cmd *cobra.Command, opts *project.LintOptions) {
 	cmd.Flags().BoolVarP(&opts.Config.Validate, "verify", "V", false, "Verify configuration against JSON schema")
 	cmd.Flags().BoolVarP(&opts.Config.Path, "config-path", "C", false, "Specify the configuration file path")
 	cmd.Flags().StringVarP(&opts.ConfigPath, "config", "c", "", "Specify the configuration file path")
+	addGolangciFlags(cmd, &opts.Golangci)
+}
+
+// addGolangciFlags registers the golangci-lint install/version flags shared by `project lint` and `project fmt`.
+func addGolangciFlags(cmd *cobra.Command, opts *project.GolangciOptions) {
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Install golangci-lint without prompting when it is not found")
+	cmd.Flags().StringVar(&opts.MinVersion, "min-version", "", "Warn when golangci-lint is older than this version (default: inferred from .golangci.yml)")
+}
+
+// golangciOptions fills the config-derived fields of the golangci-lint options.
+func golangciOptions(opts project.GolangciOptions) project.GolangciOptions {
+	opts.AutoInstall = gocliCtx.Config.Lint.AutoInstall
+	opts.ToolsPath = gocliCtx.Config.Tools.GoCLIToolsPath
+	opts.ToolsConfigDir = gocliCtx.Config.Tools.ToolsConfigDir
+	return opts
 }
 
 // addFmtFlags registers flags for the `project fmt` command.
@@ -901,25 +1729,85 @@ func addFmtFlags(cmd *cobra.Command, opts *project.FmtOptions) {
 	cmd.Flags().StringVarP(&opts.Path, "path", "p", "", "Target path to format (default current directory)")
 	cmd.Flags().BoolVarP(&opts.Verbose, "verbose", "v", false, "Verbose output (line by line)")
 	cmd.Flags().StringVarP(&opts.ConfigPath, "config", "c", "", "Specify the configuration file path")
+	addGolangciFlags(cmd, &opts.Golangci)
 }
 
 // addUpdateFlags registers flags for the `project update` command.
 func addUpdateFlags(cmd *cobra.Command, opts *project.UpdateOptions) {
 	cmd.Flags().BoolVarP(&opts.Verbose, "verbose", "v", false, "Verbose output (line by line)")
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Print the update report as JSON")
+	cmd.Flags().StringSliceVar(&opts.Exclude, "exclude", nil, "Modules to keep at their current version (comma separated, merged with update.exclude)")
+	addDryRunFlag(cmd, "n")
+}
+
+// addGenerateFlags registers flags for the `project generate` command.
+func addGenerateFlags(cmd *cobra.Command, opts *project.GenerateOptions) {
+	cmd.Flags().BoolVarP(&opts.List, "list", "l", false, "List //go:generate directives with file:line instead of running them")
+	cmd.Flags().StringVar(&opts.Run, "run", "", "Only run/list directives whose full text matches this regular expression")
+	cmd.Flags().StringVar(&opts.Skip, "skip", "", "Skip directives whose full text matches this regular expression")
+	cmd.Flags().BoolVarP(&opts.X, "print-commands", "x", false, "Print commands as they are executed")
+	cmd.Flags().BoolVarP(&opts.V, "verbose", "v", false, "Print the names of packages and files as they are processed")
+	addDryRunFlag(cmd, "n")
+}
+
+// addCleanFlags registers flags for the `project clean` command.
+func addCleanFlags(cmd *cobra.Command, opts *project.CleanOptions) {
+	cmd.Flags().BoolVar(&opts.Cache, "cache", false, "Remove the entire go build cache (go clean -cache)")
+	cmd.Flags().BoolVar(&opts.TestCache, "testcache", false, "Expire all cached test results (go clean -testcache)")
+	cmd.Flags().BoolVar(&opts.ModCache, "modcache", false, "Remove the entire module download cache (go clean -modcache)")
+	cmd.Flags().BoolVar(&opts.Dist, "dist", false, "Remove the build output directories configured in clean.dirs")
+	cmd.Flags().StringSliceVar(&opts.DistDirs, "dist-dir", nil, "Output directory to remove instead of clean.dirs (repeatable, implies --dist)")
+	cmd.Flags().BoolVar(&opts.Bin, "bin", false, "Remove the output directories (like --dist) and test binaries (*.test)")
+	cmd.Flags().BoolVar(&opts.Cover, "cover", false, "Remove coverage files (coverage.out, *.coverprofile)")
+	cmd.Flags().BoolVar(&opts.Profiles, "profiles", false, "Remove profile and trace files (*.prof, trace.out, capture.trace)")
+	cmd.Flags().BoolVar(&opts.State, "state", false, "Remove gocli state files under .gocli/")
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Remove every kind of artifact (--bin --cover --profiles --state)")
+	cmd.Flags().BoolVar(&opts.TrackedToo, "tracked-too", false, "Also remove files tracked by git")
+	cmd.Flags().BoolVarP(&opts.Verbose, "verbose", "v", false, "Print the remove commands executed by go clean (-x)")
+	addDryRunFlag(cmd, "n")
 }
 
 // addDocFlags registers flags for the `project doc` command.
 func addDocFlags(cmd *cobra.Command, opts *project.DocOptions) {
 	cmd.Flags().StringVarP((*string)(&opts.Style), "style", "s", string(doc.StylePlain), "Render style: plain|markdown|html")
 	cmd.Flags().StringVarP((*string)(&opts.Mode), "mode", "m", string(doc.ModeGodoc), "Doc mode: godoc|markdown")
-	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "Output file path (default stdout)")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "Output target: file path, - (stdout), stderr or clipboard: (default stdout)")
 	cmd.Flags().BoolVarP(&opts.IncludePrivate, "private", "p", false, "Include unexported (private) symbols in analysis")
 	cmd.Flags().BoolVarP(&opts.IncludeTests, "tests", "t", false, "Include *_test.go files (auto enables --examples if not set)")
 	cmd.Flags().BoolVarP(&opts.IncludeExamples, "examples", "e", false, "Include example functions (auto-enabled by --tests)")
+	cmd.Flags().BoolVar(&opts.VerifyExamples, "verify-examples", false, "Run testable examples with 'go test -run Example' and mark each as PASS/FAIL (implies --examples)")
 	cmd.Flags().BoolVar(&opts.TOC, "toc", true, "Generate table of contents where applicable")
-	cmd.Flags().StringVarP(&opts.Theme, "theme", "T", "", "Theme for styled output (markdown renderer)")
+	cmd.Flags().StringVarP(&opts.Theme, "theme", "T", "", "Markdown theme name or path to a glamour style JSON file (default: by terminal background)")
+	cmd.Flags().BoolVar(&docListThemes, "list-themes", false, "List the available markdown themes (built-in and ~/.gocli/themes/*.json) and exit")
 	cmd.Flags().IntVarP(&opts.Width, "width", "w", 0, "Render width (0 auto)")
+	cmd.Flags().BoolVar(&opts.NoColor, "no-color", false, "Do not color headings and signatures of --style plain output on terminals")
+	cmd.Flags().BoolVar(&opts.NoColor, "plain-no-color", false, "Alias of --no-color")
+	_ = cmd.Flags().MarkHidden("plain-no-color")
 	cmd.Flags().BoolVarP(&opts.Detailed, "detailed", "d", false, "Enable detailed output")
+	cmd.Flags().BoolVar(&opts.XRef, "xref", false, "With --style markdown, link symbol mentions in doc comments and append an alphabetical symbol index")
+	cmd.Flags().BoolVar(&opts.TypeInfo, "type-info", false, "Type-check the package and show in-package interface implementations (with --detailed, slower)")
+	cmd.Flags().StringVar(&opts.Implementers, "implementers", "", "List interface implementations in detailed mode: package|module (module also searches the other packages of the module)")
+	cmd.Flags().Lookup("implementers").NoOptDefVal = doc.ImplementersPackage
+	cmd.Flags().StringSliceVar(&opts.Only, "only", nil, "Render only these sections: consts,vars,funcs,types,examples")
+	cmd.Flags().StringSliceVar(&opts.Skip, "skip", nil, "Omit these sections: consts,vars,funcs,types,examples")
+	cmd.MarkFlagsMutuallyExclusive("only", "skip")
+	cmd.Flags().StringSliceVar(&opts.Tags, "tags", nil, "Build configuration to document: GOOS/GOARCH names (linux, arm64) and build tags (comma or repeated)")
+	cmd.Flags().StringVar(&opts.Sort, "sort", doc.SortAlpha, "Symbol order: alpha (by name) or source (by declaration position)")
+	cmd.Flags().BoolVarP(&opts.All, "all", "a", false, "Render every package under the given directories (same as passing ./...)")
+	cmd.Flags().IntVarP(&opts.Concurrency, "concurrency", "C", 0, "Number of packages parsed and rendered concurrently with --all (0 uses CPU cores)")
+	cmd.Flags().BoolVar(&opts.Fetch, "fetch", false, "Download third-party modules that are not in the module cache yet (go mod download, needs network)")
+	cmd.Flags().BoolVar(&opts.Tree, "tree", false, "Write a markdown file per package mirroring the module layout under -o, plus a SUMMARY.md index")
+	cmd.Flags().BoolVar(&opts.KeepStale, "keep-stale", false, "With --tree, keep files generated by earlier runs whose packages no longer exist")
+	cmd.Flags().BoolVar(&opts.IncludeInternal, "include-internal", false, "With --tree, also document internal packages")
+	cmd.Flags().BoolVar(&opts.IncludeTestdata, "include-testdata", false, "With --tree, also document packages under testdata directories")
+	cmd.Flags().StringVar(&opts.Diff, "diff", "", "Compare the exported API with a git revision (tag, branch or commit) and print added/removed/changed symbols")
+	cmd.MarkFlagsMutuallyExclusive("diff", "tree")
+	cmd.Flags().BoolVar(&opts.Append, "append", false, "Append to the -o file instead of overwriting it (same as a trailing :append)")
+	cmd.Flags().BoolVar(&opts.IncludeReadme, "with-readme", false, "Prepend the package directory README (README.md/README) to the package docs")
+	cmd.Flags().BoolVar(&docNoCache, "no-cache", false, "Render fresh and bypass the doc render cache (doc.cache)")
+	cmd.Flags().BoolVar(&docClearCache, "clear-cache", false, "Empty the doc render cache (~/.gocli/cache/doc) and exit")
+	cmd.Flags().StringVar(&opts.Serve, "serve", "", "Serve module docs over HTTP on the given address, written as --serve=ADDR (default :6060, localhost only)")
+	cmd.Flags().Lookup("serve").NoOptDefVal = ":6060"
 }
 
 // registerProjectFlags centralizes all flag registrations for project subcommands
@@ -930,9 +1818,11 @@ func registerProjectFlags() {
 
 	// 2) build
 	addBuildRunFlags(projectBuildCmd, &buildOptions)
+	addBuildOnlyFlags(projectBuildCmd, &buildOptions)
 
 	// 3) run
 	addBuildRunFlags(projectRunCmd, &runOptions)
+	addRunOnlyFlags(projectRunCmd, &runOptions)
 
 	// 4) list
 	addListFlags(projectListCmd, &listOptions)
@@ -961,6 +1851,12 @@ func registerProjectFlags() {
 	// 12) doc
 	addDocFlags(projectDocCmd, &docOptions)
 
+	// 13) clean
+	addCleanFlags(projectCleanCmd, &cleanOptions)
+
+	// 14) generate
+	addGenerateFlags(projectGenerateCmd, &genOptions)
+
 	// Keep build/run flag ordering as originally intended
 	projectBuildCmd.Flags().SortFlags = false
 	projectRunCmd.Flags().SortFlags = false
@@ -986,5 +1882,7 @@ func init() {
 		projectUpdateCmd,
 		projectDepsCmd,
 		projectDocCmd,
+		projectCleanCmd,
+		projectGenerateCmd,
 	)
 }
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// 测试 project info/list -o：结果写入文件（自动创建父目录），命令返回前文件已关闭并写完整
+func TestProjectOutputFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/demo\n\ngo 1.21\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	infoFile := filepath.Join(dir, "out", "info.json")
+	rootCmd.SetArgs([]string{"project", "info", "--json", "--quiet", "-o", infoFile, dir})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(infoFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var info map[string]any
+	if err := json.Unmarshal(data, &info); err != nil {
+		t.Fatalf("info output is not JSON: %v\n%s", err, data)
+	}
+
+	listFile := filepath.Join(dir, "out", "pkgs.txt")
+	rootCmd.SetArgs([]string{"project", "list", "--quiet", "--output-format", "plain", "-o", listFile, "./..."})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	data, err = os.ReadFile(listFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(string(data)) != "example.com/demo" {
+		t.Errorf("unexpected list output %q", data)
+	}
+}
+
+// 测试 -o 指向无法创建的路径时命令返回错误而不是直接退出进程
+func TestProjectOutputFileError(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	blocker := filepath.Join(dir, "file")
+	if err := os.WriteFile(blocker, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rootCmd.SetArgs([]string{"project", "info", "--quiet", "-o", filepath.Join(blocker, "info.txt"), dir})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "failed to open output") {
+		t.Fatalf("expected an open error, got %v", err)
+	}
+}
+
+// 测试 project deps --dry-run：只读查询照常执行并输出结果（不列为将要执行的命令）
+func TestProjectDepsDryRunOutput(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/demo\n\ngo 1.21\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	t.Setenv("GOFLAGS", "")
+	t.Setenv("GOPROXY", "off")
+	t.Cleanup(func() {
+		dryRunFlag = false
+		rootCmd.SetOut(nil)
+	})
+
+	var out strings.Builder
+	rootCmd.SetOut(&out)
+	// 之前失败的命令不会执行 PersistentPostRun，trace 仍在运行，这里关闭 --trace 避免重复启动
+	rootCmd.SetArgs([]string{"project", "deps", "--quiet", "--trace=", "--dry-run"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "example.com/demo") || strings.Contains(out.String(), "$ go list") {
+		t.Errorf("dry-run should print the module list:\n%s", out.String())
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/yeisme/gocli/pkg/context"
+	"github.com/yeisme/gocli/pkg/style"
 	log2 "github.com/yeisme/gocli/pkg/utils/log"
 	"github.com/yeisme/gocli/pkg/utils/version"
 )
@@ -17,15 +18,8 @@ var (
 	gocliCtx *context.GocliContext
 	log      log2.Logger
 
-	// Global flags
-	globalFlags       = context.GlobalFlags{}
-	configPathFlag    = globalFlags.ConfigPath
-	debugFlag         = globalFlags.Debug
-	verboseFlag       = globalFlags.Verbose
-	quietFlag         = globalFlags.Quiet
-	cpuProfileFlag    = globalFlags.CPUProfile
-	traceFlag         = globalFlags.Trace
-	versionEnableFlag = globalFlags.VersionEnable
+	// Global flags, bound in init() and read in PersistentPreRun after parsing
+	globalFlags context.GlobalFlags
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -34,7 +28,7 @@ var rootCmd = &cobra.Command{
 	Short: "gocli is a CLI application for managing your Go projects",
 	Long:  `gocli is a command line interface application that helps you manage your Go projects efficiently.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if versionEnableFlag {
+		if globalFlags.VersionEnable {
 			fmt.Fprintln(cmd.OutOrStdout(), version.GetShortVersionString())
 			os.Exit(0)
 		}
@@ -43,9 +37,9 @@ var rootCmd = &cobra.Command{
 			_ = cmd.Help()
 		}
 	},
-	PersistentPreRun: func(_ *cobra.Command, _ []string) {
-		if cpuProfileFlag != "" {
-			f, err := os.Create(cpuProfileFlag)
+	PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+		if globalFlags.CPUProfile != "" {
+			f, err := os.Create(globalFlags.CPUProfile)
 			if err != nil {
 				log.Fatal().Err(err).Msg("could not create CPU profile")
 			}
@@ -53,8 +47,8 @@ var rootCmd = &cobra.Command{
 				log.Fatal().Err(err).Msg("could not start CPU profile")
 			}
 		}
-		if traceFlag != "" {
-			f, err := os.Create(traceFlag)
+		if globalFlags.Trace != "" {
+			f, err := os.Create(globalFlags.Trace)
 			if err != nil {
 				log.Fatal().Err(err).Msg("could not create trace file")
 			}
@@ -62,23 +56,49 @@ var rootCmd = &cobra.Command{
 				log.Fatal().Err(err).Msg("could not start trace")
 			}
 		}
-		ctx := context.InitGocliContext(configPathFlag, debugFlag, verboseFlag, quietFlag)
+		// shell 补全请求的 stdout 只能包含候选项，不输出日志
+		flags := globalFlags
+		if cmd.Name() == cobra.ShellCompRequestCmd {
+			flags.Quiet, flags.LogLevel = true, ""
+		}
+		style.DisableProgress(flags.Quiet)
+		ctx, err := context.InitGocliContext(flags)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		format, err := style.ParseOutputFormat(globalFlags.OutputFormat)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		ctx.OutputFormat = format
 
 		gocliCtx = ctx
+		log2.SetCommand(cmd.CommandPath())
 		log = ctx.Logger
 
 		log.Info().Msgf("Execute Command: %s %s", "gocli", strings.Join(os.Args[1:], " "))
 	},
 	PersistentPostRun: func(_ *cobra.Command, _ []string) {
-		if cpuProfileFlag != "" {
+		if globalFlags.CPUProfile != "" {
 			pprof.StopCPUProfile()
 		}
-		if traceFlag != "" {
+		if globalFlags.Trace != "" {
 			trace.Stop()
 		}
 	},
 }
 
+// usePager 判断长输出是否应通过分页器显示（--no-pager 优先于配置 app.pager）
+func usePager() bool {
+	if globalFlags.NoPager || globalFlags.Quiet || gocliCtx == nil {
+		return false
+	}
+	return gocliCtx.Config.App.Pager
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
@@ -87,11 +107,17 @@ func Execute() {
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVarP(&configPathFlag, "config", "c", "", "config file")
-	rootCmd.PersistentFlags().StringVar(&cpuProfileFlag, "cpu-profile", "", "write cpu profile to `file`")
-	rootCmd.PersistentFlags().StringVar(&traceFlag, "trace", "trace.out", "write execution trace to `file`")
-	rootCmd.PersistentFlags().BoolVar(&debugFlag, "debug", false, "enable debug mode (prints additional information)")
-	rootCmd.PersistentFlags().BoolVarP(&verboseFlag, "verbose", "V", false, "enable verbose output (prints more detailed information)")
-	rootCmd.PersistentFlags().BoolVar(&quietFlag, "quiet", false, "suppress all output except errors")
-	rootCmd.Flags().BoolVarP(&versionEnableFlag, "version", "v", false, "show version information")
+	rootCmd.PersistentFlags().StringVarP(&globalFlags.ConfigPath, "config", "c", "", "config file")
+	rootCmd.PersistentFlags().StringSliceVar(&globalFlags.ConfigDirs, "config-dir", nil, "directory searched for .gocli.yaml/gocli.yaml before the default search paths (repeatable)")
+	rootCmd.PersistentFlags().StringVar(&globalFlags.CPUProfile, "cpu-profile", "", "write cpu profile to `file`")
+	rootCmd.PersistentFlags().StringVar(&globalFlags.Trace, "trace", "trace.out", "write execution trace to `file`")
+	rootCmd.PersistentFlags().BoolVar(&globalFlags.Debug, "debug", false, "enable debug mode (prints additional information)")
+	rootCmd.PersistentFlags().BoolVarP(&globalFlags.Verbose, "verbose", "V", false, "enable verbose output (prints more detailed information)")
+	rootCmd.PersistentFlags().BoolVar(&globalFlags.Quiet, "quiet", false, "suppress all output except errors")
+	rootCmd.PersistentFlags().BoolVar(&globalFlags.NoPager, "no-pager", false, "do not pipe long output through $PAGER")
+	rootCmd.PersistentFlags().BoolVar(&globalFlags.StrictConfig, "strict-config", false, "treat configuration validation warnings (GOFLAGS, GOEXPERIMENT, GOOS/GOARCH, ...) as errors")
+	rootCmd.PersistentFlags().StringVar(&globalFlags.OutputFormat, "output-format", "", "output format for commands with structured data: json|yaml|table|plain (json/yaml wrap results in {command, data, error})")
+	rootCmd.PersistentFlags().StringVar(&globalFlags.LogLevel, "log-level", "", "level of gocli's own logs: trace|debug|info|warn|error (overrides the level implied by --quiet/--debug/--verbose and log.level)")
+	rootCmd.PersistentFlags().StringVar(&globalFlags.LogFormat, "log-format", "", "format of gocli's own logs on stderr: console|json (default from log.json)")
+	rootCmd.Flags().BoolVarP(&globalFlags.VersionEnable, "version", "v", false, "show version information")
 }
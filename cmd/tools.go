@@ -3,10 +3,14 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/yeisme/gocli/pkg/style"
 	toolsPkg "github.com/yeisme/gocli/pkg/tools"
 )
 
@@ -14,10 +18,36 @@ var (
 	toolInstallOptions toolsPkg.InstallOptions
 	toolInstallGlobal  bool
 	toolInstallYes     bool
-	toolUninstallYes   bool
-	toolUninstallDry   bool
-	toolUninstallFuzzy bool
-	toolUninstallAll   bool
+	toolInstallJSON    bool
+	// toolInstallFromFile 对应 tools install --from-file：从 YAML/JSON 清单批量安装
+	toolInstallFromFile string
+	toolUninstallYes    bool
+	toolUninstallDry    bool
+	toolUninstallFuzzy  bool
+	toolUninstallAll    bool
+
+	toolUninstallForceUnverified bool
+
+	toolHistoryLimit int
+	toolHistoryTool  string
+	toolHistoryStats bool
+
+	toolAddOptions toolsPkg.AddOptions
+	toolAddInstall bool
+
+	toolSearchInstall bool
+	toolSearchGlobal  bool
+
+	toolExportOutput string
+	toolExportFormat string
+	toolImportGlobal bool
+	toolImportEnv    []string
+
+	// --group：只处理 tools.groups 中所选分组的工具
+	toolInstallGroups []string
+	toolListGroups    []string
+
+	toolPrefetchEnv []string
 
 	toolsCmd = &cobra.Command{
 		Use:     "tools",
@@ -35,14 +65,44 @@ gocli tools list displays all available tools that can be used with gocli.
 Examples:
   gocli tools list
   gocli tools list --json
+
+  # Machine-readable envelope {"command": ..., "data": [...], "error": null}
+  gocli tools list --output-format yaml
+
+  # Show which members of the tools.groups 'lint' and 'proto' groups are installed
+  gocli tools list --group lint --group proto
+
+Notes:
+  - --json prints the bare tool array and takes precedence over the global --output-format.
+  - --output-format plain prints one "name<TAB>source<TAB>path" line per tool.
+  - --group (repeatable) lists the union of the named tools.groups instead, marking each member as installed
+    (with its path) or missing; with --output-format plain the lines are "name<TAB>status<TAB>path".
+  - Long tables are paged through $PAGER (default "less -R") when stdout is a terminal; use --no-pager or app.pager=false to disable.
 `,
 		Run: func(cmd *cobra.Command, _ []string) {
 			listJSON, _ := cmd.Flags().GetBool("json")
 			// 优先使用全局 verbose；若未设置，则读取本地 flags
-			v := verboseFlag
+			v := globalFlags.Verbose
+
+			format := outputFormat(cmd, "json")
 
 			gocliToolsPath := gocliCtx.Config.Tools.GoCLIToolsPath
 			tools := toolsPkg.FindTools(v, gocliToolsPath)
+			if len(toolListGroups) > 0 {
+				printToolGroups(cmd, format, listJSON, tools)
+				return
+			}
+			if format.Structured() {
+				printEnvelope(cmd, format, tools, nil)
+				return
+			}
+			if format == style.OutputPlain {
+				// 每行一个工具：name<TAB>source<TAB>path，便于 shell 处理
+				for _, t := range tools {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\t%s\n", t.Name, t.Source, t.Path)
+				}
+				return
+			}
 			if listJSON {
 				b, err := json.MarshalIndent(tools, "", "  ")
 				if err != nil {
@@ -56,7 +116,10 @@ Examples:
 				return
 			}
 
-			if err := toolsPkg.PrintToolsTable(cmd.OutOrStdout(), tools, v); err != nil {
+			err := style.WithPager(cmd.OutOrStdout(), usePager(), func(w io.Writer) error {
+				return toolsPkg.PrintToolsTable(w, tools, v)
+			})
+			if err != nil {
 				log.Error().Err(err).Msg("failed to print tools list in table format")
 			}
 		},
@@ -120,15 +183,92 @@ Examples:
   # 12. Clone + goreleaser with custom config and extra flags
   gocli tools install --clone https://github.com/owner/repo.git --build goreleaser --goreleaser-config .goreleaser.yml --build-arg --skip=validate
 
+  # 13. Print the result as JSON for scripts (install dir, mode, success)
+  gocli tools install golangci-lint --yes --json --quiet
+
+  # 14. Cross-install a tool for another platform (sets GOOS/GOARCH for the build)
+  gocli tools install --target-os windows --target-arch amd64 --path ./dist/windows golangci-lint
+
+  # 15. Install on an air-gapped machine from a module cache filled by 'gocli tools prefetch'
+  gocli tools install --offline golangci-lint
+  gocli tools install --offline ./tools/cmd/gen
+
+  # 16. Install a team toolset from a manifest (YAML/JSON list of name/url/clone/version entries)
+  gocli tools install --from-file tools.yaml
+  gocli tools install --from-file tools.json --global
+
+  # 17. Install only some of the configured tools, grouped in tools.groups
+  gocli tools install --group lint
+  gocli tools install --group lint --group proto
+
+  # 18. Reinstall under a new binary name and remove the binary left under the old name
+  gocli tools install golangci-lint --binary-name gcl --prune
+
+  # 19. Check the installed binary against a known sha256 (a mismatch removes it and fails the install)
+  gocli tools install github.com/owner/repo/cmd/foo@v1.2.3 --sha256 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+
+  # 20. Choose the post-install smoke test, or fail and remove the binary when it does not run
+  gocli tools install github.com/owner/repo/cmd/foo@latest --check "version --short"
+  gocli tools install golangci-lint --strict-check
+
 Notes:
   - When invoked without arguments and without --clone, gocli installs tools configured in your config file.
 	- Use --global to install configured global tools or to default single installs to ~/.gocli/tools.
   - --release-build and --debug-build are mutually exclusive.
   - When a short builtin tool name is provided (no path separator), gocli may map it to a configured module or clone URL from builtin tool mappings.
   - Do not specify both a module/local spec and --clone at the same time; they are mutually exclusive.
+  - After a successful install the sha256 of every new binary is printed and recorded in a .gocli-sha256 file
+    next to it. When --sha256 or the tool's 'sha256' field (builtin/user tools table, tools.deps/tools.global
+    entries) is set, the binary must match it: a mismatch removes the binary and fails the install unless
+    --skip-verify is given. 'gocli tools verify' re-checks later.
+  - --dry-run prints the go/git/make commands that would be executed without installing anything.
+  - --json prints {"success", "error", "mode", "install_dir", "probable_install_dir", "output", "digests", "checks"} to stdout;
+    prompts and notes go to stderr (add --quiet to keep log lines out of stdout). It is not supported
+    for batch installs (no arguments).
+  - The exit status is 1 whenever an install fails (single, batch, --group or --from-file), also when the
+    --json report says "success": false (including a declined confirmation).
+  - --target-os/--target-arch set GOOS/GOARCH in the build environment; an omitted side defaults to the
+    current platform, and windows targets get a .exe binary name. go install refuses to cross-install with
+    GOBIN set, so the binary is built into GOPATH/bin/<os>_<arch> and then moved to the install directory.
+  - --offline (or tools.offline: true) uses GOMODCACHE/cache/download as a file:// GOPROXY with GOSUMDB=off,
+    GONOSUMDB=* and GOTOOLCHAIN=local; GOPROXY=off is not used because go install pkg@version also looks up
+    module deprecations. Module installs use GOFLAGS=-mod=mod, local paths inside a vendored module -mod=vendor.
+    --clone only accepts local paths and file:// URLs. When modules are missing from the cache the error lists them.
+  - --from-file reads a list of entries, at the top level or under a 'tools' key, for example:
+      - name: golangci-lint          # builtin/user tool name, optional 'version'
+      - url: golang.org/x/tools/cmd/stringer
+        version: v0.24.0
+      - clone: https://github.com/owner/repo.git
+        version: v1.2.3              # becomes #v1.2.3
+        build: make
+    Entries also accept make_target, workdir, bin, binary_name, env and tags. Every tool is installed even when an
+    earlier one fails, and a per-tool summary is printed. --path, --global and tools.path choose the install
+    directory as for single installs, and --offline (or tools.offline) applies to every entry. Manifests written by 'gocli tools export' are accepted too: their deps and
+    global entries (module becomes url) all go to that directory; 'gocli tools import' keeps the deps/global split.
+  - git clone (including --recurse-submodules) and go install are retried when the failure looks like a transient
+    network error (timeouts, connection resets, DNS failures, 502/503/504, early EOF); each retry is logged to
+    stderr. --retry-attempts (default 3, 1 disables) and --retry-delay (default 2s, doubled per retry, capped at
+    30s) tune it; batch and --from-file installs use the defaults. Compile errors and unknown versions fail at once.
+  - --prune removes, after a successful install, binaries in the install directory that existed before, were not
+    rewritten by this install and whose Go build info names the same main package as the new binary (e.g. the old
+    name after switching --binary-name). Removed files are reported; binaries without build info are never touched.
+  - After a successful install every new binary is smoke-tested with '<binary> --version || <binary> version ||
+    <binary> -h' (10s timeout each) and the first output line is printed, e.g. "golangci-lint installed: v1.59.1".
+    --check (or 'check' in a tools config entry) sets the arguments or full command instead; "none" disables it.
+    A binary that does not run is reported with a warning; --strict-check removes it and fails the install.
+    In batch, --group and --from-file installs both flags apply to every tool, --check overriding 'check' entries.
+    Cross installs (--target-os/--target-arch) are never run.
+  - --group (repeatable) limits a batch install to the union of the named tools.groups, e.g.
+      tools:
+        groups:
+          lint: [golangci-lint, staticcheck]
+          proto: [buf, protoc-gen-go]
+    Names match tools.deps/tools.global entries by module path, its last element, cmd or clone URL (the same
+    lookup as batch installs); names not configured there are taken from the builtin/user tools table. Unknown
+    groups or names fail before anything is installed. 'gocli tools list --group' shows what is missing.
 `,
 
-		Run: func(cmd *cobra.Command, args []string) {
+		Run: dryRunnable(func(cmd *cobra.Command, args []string) {
 			cloneURL := toolInstallOptions.CloneURL
 			makeTarget := toolInstallOptions.MakeTarget
 			pathFlag := toolInstallOptions.Path
@@ -138,25 +278,84 @@ Notes:
 			debugBuild := toolInstallOptions.DebugBuild
 			globalFlag := toolInstallGlobal
 
-			v := verboseFlag
+			v := globalFlags.Verbose
 
 			// 校验互斥选项
 			if releaseBuild && debugBuild {
 				log.Error().Msg("--release-build and --debug-build cannot be used together")
+				os.Exit(1)
+			}
+
+			// --offline 与配置 tools.offline 等价，批量安装通过配置传递
+			if toolInstallOptions.Offline {
+				gocliCtx.Config.Tools.Offline = true
+			}
+
+			// 0. --from-file -> 按清单批量安装
+			if toolInstallFromFile != "" {
+				if cloneURL != "" || len(args) > 0 || toolInstallJSON || len(toolInstallGroups) > 0 {
+					log.Error().Msg("--from-file cannot be combined with a tool argument, --clone, --group or --json")
+					os.Exit(1)
+				}
+				// 与单个安装相同：--path 优先，其次 --global（~/.gocli/tools），最后是配置的 tools.path
+				toolsPath := pathFlag
+				if toolsPath == "" && !globalFlag {
+					toolsPath = gocliCtx.Config.Tools.GoCLIToolsPath
+				}
+				results, err := toolsPkg.InstallFromFile(toolsPkg.FromFileOptions{
+					File:           toolInstallFromFile,
+					Global:         toolsPath == "",
+					GoCLIToolsPath: toolsPath,
+					ToolsConfigDir: gocliCtx.Config.Tools.ToolsConfigDir,
+					Env:            envFlags,
+					Verbose:        v,
+					Offline:        gocliCtx.Config.Tools.Offline,
+					Check:          toolInstallOptions.Check,
+					StrictCheck:    toolInstallOptions.StrictCheck,
+				})
+				if err == nil {
+					err = toolsPkg.PrintFromFileSummary(cmd.OutOrStdout(), toolInstallFromFile, results)
+				}
+				if err != nil {
+					log.Error().Err(err).Msg("install from file finished with errors")
+					os.Exit(1)
+				}
 				return
 			}
 
+			if len(toolInstallGroups) > 0 && (cloneURL != "" || len(args) > 0) {
+				log.Error().Msg("--group installs configured tool groups and cannot be combined with a tool argument or --clone")
+				os.Exit(1)
+			}
+
 			// 1. 无参数 && 无 --clone -> 批量安装配置中工具
 			if cloneURL == "" && len(args) == 0 {
+				if toolInstallJSON {
+					log.Error().Msg("--json is only supported when installing a single tool")
+					os.Exit(1)
+				}
+				// --group：只安装所选分组成员的并集
+				cfg := gocliCtx.Config
+				if len(toolInstallGroups) > 0 {
+					sel, err := toolsPkg.ResolveToolGroups(cfg.Tools, toolInstallGroups)
+					if err != nil {
+						log.Error().Err(err).Msg("invalid --group")
+						os.Exit(1)
+					}
+					cfg = sel.Config(cfg)
+				}
 				// batch install will load user tools and perform installation
+				batch := toolsPkg.BatchInstallOptions{Check: toolInstallOptions.Check, StrictCheck: toolInstallOptions.StrictCheck}
 				if globalFlag {
-					if err := toolsPkg.BatchInstallConfiguredGlobalTools(gocliCtx.Config, envFlags, v); err != nil {
+					if err := toolsPkg.BatchInstallConfiguredGlobalTools(cfg, envFlags, v, batch); err != nil {
 						log.Error().Err(err).Msg("batch install (global) finished with errors")
+						os.Exit(1)
 					}
 					return
 				}
-				if err := toolsPkg.BatchInstallConfiguredTools(gocliCtx.Config, envFlags, v); err != nil {
+				if err := toolsPkg.BatchInstallConfiguredTools(cfg, envFlags, v, batch); err != nil {
 					log.Error().Err(err).Msg("batch install finished with errors")
+					os.Exit(1)
 				}
 				return
 			}
@@ -184,7 +383,7 @@ Notes:
 			// 同时给出 --clone 与 spec -> 不允许，避免歧义
 			if cloneURL != "" && spec != "" {
 				log.Error().Msg("please specify either a module/local path or --clone, not both")
-				return
+				os.Exit(1)
 			}
 
 			installOpts := toolsPkg.InstallCommandOptions{
@@ -198,31 +397,289 @@ Notes:
 					ReleaseBuild:      releaseBuild,
 					DebugBuild:        debugBuild,
 					BinaryName:        toolInstallOptions.BinaryName,
+					SHA256:            toolInstallOptions.SHA256,
+					SkipVerify:        toolInstallOptions.SkipVerify,
 					BuildMethod:       toolInstallOptions.BuildMethod,
 					BuildArgs:         toolInstallOptions.BuildArgs,
 					WorkDir:           toolInstallOptions.WorkDir,
 					GoreleaserConfig:  toolInstallOptions.GoreleaserConfig,
 					RecurseSubmodules: toolInstallOptions.RecurseSubmodules,
 					Force:             toolInstallOptions.Force,
+					TargetOS:          toolInstallOptions.TargetOS,
+					TargetArch:        toolInstallOptions.TargetArch,
+					Offline:           gocliCtx.Config.Tools.Offline,
+					RetryAttempts:     toolInstallOptions.RetryAttempts,
+					RetryDelay:        toolInstallOptions.RetryDelay,
+					Prune:             toolInstallOptions.Prune,
+					Check:             toolInstallOptions.Check,
+					StrictCheck:       toolInstallOptions.StrictCheck,
 					Verbose:           v,
 				},
 				Global:         globalFlag,
-				Quiet:          quietFlag,
+				Quiet:          globalFlags.Quiet,
 				GoCLIToolsPath: gocliCtx.Config.Tools.GoCLIToolsPath,
 				ToolsConfigDir: gocliCtx.Config.Tools.ToolsConfigDir,
-				Yes:            toolInstallYes,
+				Yes:            toolInstallYes || dryRunFlag,
 				Input:          cmd.InOrStdin(),
+				JSON:           toolInstallJSON,
 			}
 
 			if err := toolsPkg.ExecuteInstallCommand(installOpts, cmd.OutOrStdout()); err != nil {
 				log.Error().Err(err).Msg("install failed")
-				return
+				os.Exit(1)
+			}
+		}),
+	}
+	toolExportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export installed tools as a shareable manifest",
+		Long: `
+gocli tools export writes the currently installed tools as a manifest in the same
+shape as the 'tools' section of the config file, so teammates can reproduce your toolbox.
+
+Examples:
+  gocli tools export
+  gocli tools export -o tools.yaml
+  gocli tools export -o tools.json
+  gocli tools export --format json
+
+Notes:
+  - Each binary's Go build info decides its entry: 'go' tools are exported as <main package>@<version>.
+  - Binaries built from source report version (devel) and are exported as @latest (listed under "Notes").
+  - Binaries without Go build info are looked up by name in the builtin/user tools table; matches are
+    exported unpinned and listed under "Notes", the rest are listed under "Skipped" instead of being dropped.
+  - The format follows --format, else the -o extension (.json writes JSON), else YAML.
+  - Tools in ~/.gocli/tools go to 'tools.global', everything else to 'tools.deps'.
+`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, _ []string) {
+			m := toolsPkg.BuildToolsManifest(toolsPkg.ExportOptions{
+				GoCLIToolsPath: gocliCtx.Config.Tools.GoCLIToolsPath,
+				ToolsConfigDir: gocliCtx.Config.Tools.ToolsConfigDir,
+			})
+			if err := writeToolsManifest(cmd.OutOrStdout(), m, toolExportOutput, toolExportFormat); err != nil {
+				log.Error().Err(err).Msg("export failed")
+				os.Exit(1)
+			}
+		},
+	}
+	toolImportCmd = &cobra.Command{
+		Use:   "import <file>",
+		Short: "Install the tools listed in a manifest",
+		Long: `
+gocli tools import installs every tool listed in a manifest produced by 'gocli tools export'
+(or any file with a 'tools.deps' / 'tools.global' section, e.g. a teammate's .gocli.yaml).
+
+Examples:
+  gocli tools import tools.yaml
+  gocli tools import tools.yaml --global
+  gocli tools import tools.yaml --dry-run
+
+Notes:
+  - Without --global, 'deps' entries install to tools.path and 'global' entries to ~/.gocli/tools.
+  - With --global, every entry installs to ~/.gocli/tools.
+`,
+		Args: cobra.ExactArgs(1),
+		Run: dryRunnable(func(cmd *cobra.Command, args []string) {
+			err := toolsPkg.ImportToolsManifest(toolsPkg.ImportOptions{
+				File:           args[0],
+				Global:         toolImportGlobal,
+				GoCLIToolsPath: gocliCtx.Config.Tools.GoCLIToolsPath,
+				Env:            toolImportEnv,
+				Verbose:        globalFlags.Verbose,
+			}, cmd.OutOrStdout())
+			if err != nil {
+				log.Error().Err(err).Msg("import failed")
+			}
+		}),
+	}
+	toolPrefetchCmd = &cobra.Command{
+		Use:   "prefetch",
+		Short: "Download configured tool modules for later offline installs",
+		Long: `
+gocli tools prefetch downloads every configured go install tool ('tools.deps' and 'tools.global')
+with all of its dependencies into the module cache, so that 'gocli tools install --offline'
+succeeds later on a machine (or in a container) without network access.
+
+Examples:
+  gocli tools prefetch
+  gocli tools prefetch --env GOPROXY=https://goproxy.cn
+  GOMODCACHE=/mnt/cache gocli tools prefetch
+
+Notes:
+  - Each tool is resolved in a scratch module with 'go get <module>@<version>' followed by 'go mod download',
+    which fetches the tool module and everything needed to build it (go mod download alone only accepts module paths).
+  - Builtin/user tool mappings are resolved like a batch install; tools without a version are fetched @latest.
+  - Clone-based tools and local paths are skipped: mirror those repositories locally instead.
+`,
+		Args: cobra.NoArgs,
+		Run: dryRunnable(func(cmd *cobra.Command, _ []string) {
+			for _, p := range gocliCtx.Config.Tools.ToolsConfigDir {
+				_ = toolsPkg.LoadUserTools(p)
+			}
+			_, err := toolsPkg.PrefetchConfiguredTools(gocliCtx.Config, toolsPkg.PrefetchOptions{
+				Env:     toolPrefetchEnv,
+				Verbose: globalFlags.Verbose,
+			}, cmd.OutOrStdout())
+			if err != nil {
+				log.Error().Err(err).Msg("prefetch failed")
+				os.Exit(1)
+			}
+		}),
+	}
+	toolHistoryCmd = &cobra.Command{
+		Use:   "history",
+		Short: "Show the execution history of 'tools run'",
+		Long: `
+gocli tools history lists recent 'gocli tools run' / 'gox' executions recorded in
+~/.gocli/history.jsonl, newest first.
+
+Examples:
+  gocli tools history
+  gocli tools history --limit 50 --tool golangci-lint
+  gocli tools history --json
+
+  # Run counts and failure rates per tool
+  gocli tools history --stats
+
+Notes:
+  - Each record holds the time, tool name, resolved binary, arguments, exit code, duration and working directory.
+  - Set tools.history: false in the config to stop recording.
+  - The file rotates to history.jsonl.1 at 1 MiB; both files are read.
+  - A failure is any non-zero exit code (-1 when the tool could not be started).
+`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, _ []string) {
+			listJSON, _ := cmd.Flags().GetBool("json")
+			format := outputFormat(cmd, "json")
+			records, err := toolsPkg.ReadHistory(toolsPkg.DefaultHistoryFile())
+			if err != nil {
+				if format.Structured() {
+					printEnvelope(cmd, format, nil, err)
+					return
+				}
+				log.Error().Err(err).Msg("failed to read tools history")
+				os.Exit(1)
+			}
+
+			records = toolsPkg.FilterHistory(records, toolHistoryTool, 0)
+			var data any
+			if toolHistoryStats {
+				data = toolsPkg.HistoryStats(records)
+			} else {
+				if toolHistoryLimit > 0 && len(records) > toolHistoryLimit {
+					records = records[:toolHistoryLimit]
+				}
+				data = records
+			}
+
+			switch {
+			case format.Structured():
+				printEnvelope(cmd, format, data, nil)
+			case listJSON:
+				if err := style.PrintJSON(cmd.OutOrStdout(), data); err != nil {
+					log.Error().Err(err).Msg("failed to print tools history")
+				}
+			case toolHistoryStats:
+				if err := toolsPkg.PrintHistoryStatsTable(cmd.OutOrStdout(), data.([]toolsPkg.ToolStats)); err != nil {
+					log.Error().Err(err).Msg("failed to print tools history")
+				}
+			default:
+				if err := toolsPkg.PrintHistoryTable(cmd.OutOrStdout(), records); err != nil {
+					log.Error().Err(err).Msg("failed to print tools history")
+				}
+			}
+		},
+	}
+	toolInfoCmd = &cobra.Command{
+		Use:   "info <name>",
+		Short: "Show what 'tools install' would do for a tool and whether it is installed",
+		Long: `
+gocli tools info resolves a tool from the tool table (builtin + user config) and
+shows its definition, whether it is installed and where, and the install method
+'gocli tools install <name>' would use.
+
+Examples:
+  gocli tools info golangci-lint
+  gocli tools info golangci-lint --json
+
+Notes:
+  - Names are resolved like 'tools install': an exact name first, then a unique fuzzy match.
+    Ambiguous names list the candidates.
+  - A binary found in GOPATH/bin or ~/.gocli/tools without a tool table entry is still reported.
+  - The install method prefers 'go install' (url) over a clone build (clone_url).
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			infoJSON, _ := cmd.Flags().GetBool("json")
+			format := outputFormat(cmd, "json")
+			details, err := toolsPkg.InspectTool(args[0], gocliCtx.Config.Tools.ToolsConfigDir, gocliCtx.Config.Tools.GoCLIToolsPath)
+			switch {
+			case format.Structured():
+				printEnvelope(cmd, format, details, err)
+			case err != nil:
+				log.Error().Err(err).Msg("failed to inspect tool")
+				os.Exit(1)
+			case infoJSON:
+				if err := style.PrintJSON(cmd.OutOrStdout(), details); err != nil {
+					log.Error().Err(err).Msg("failed to print tool info")
+				}
+			default:
+				if err := toolsPkg.PrintToolDetails(cmd.OutOrStdout(), details); err != nil {
+					log.Error().Err(err).Msg("failed to print tool info")
+				}
 			}
 		},
 	}
 	toolAddCmd = &cobra.Command{
-		Use:   "add",
-		Short: "Add a tool",
+		Use:   "add [module@version | name[@version]]",
+		Short: "Record a tool in the project (or user) config without installing it",
+		Long: `
+gocli tools add records a tool in the 'tools.deps' list of the project config (.gocli.yaml in
+the module root) or, with --global, in the 'tools.global' list of the user config. Nothing is
+installed unless --install is given; teammates install the recorded tools with 'gocli tools install'.
+
+Examples:
+  # Go module with an explicit version
+  gocli tools add golang.org/x/tools/cmd/stringer@v0.34.0
+
+  # Short name from the builtin/user tool table (module and build metadata are filled in)
+  gocli tools add golangci-lint
+  gocli tools add golangci-lint@v2.1.6
+
+  # Tool built from source
+  gocli tools add --clone https://github.com/org/tool.git#v1.2.0 --build goreleaser
+
+  # Record in the user config and install right away
+  gocli tools add gopls --global --install
+
+Notes:
+  - An existing entry for the same module path (or repository URL), ignoring the version, is updated in place.
+  - The YAML config is rewritten through a node tree, so comments and key order are kept as far as possible;
+    only YAML config files can be edited. Use --config to edit a specific file.
+  - Without an existing config, <module root>/.gocli.yaml (or ~/.gocli.yaml with --global) is created.
+`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			opts := toolAddOptions
+			if len(args) > 0 {
+				opts.Spec = args[0]
+			}
+			opts.ConfigFile = globalFlags.ConfigPath
+			opts.ToolsConfigDir = gocliCtx.Config.Tools.ToolsConfigDir
+			res, err := toolsPkg.ExecuteAddCommand(opts)
+			if err != nil {
+				log.Error().Err(err).Msg("failed to add tool")
+				os.Exit(1)
+			}
+			toolsPkg.PrintAddResult(cmd.OutOrStdout(), res)
+			if toolAddInstall {
+				if err := toolsPkg.InstallAdded(res, gocliCtx.Config.Tools.GoCLIToolsPath, globalFlags.Verbose); err != nil {
+					log.Error().Err(err).Msg("failed to install tool")
+					os.Exit(1)
+				}
+			}
+		},
 	}
 	toolUninstallCmd = &cobra.Command{
 		Use:   "uninstall",
@@ -237,11 +694,15 @@ Examples:
   gocli tools uninstall golangci-lint
   gocli tools uninstall golangci-lint --yes
   gocli tools uninstall golangci-lint --dry-run
+  gocli tools uninstall golangci-lint --force-unverified
 
 Notes:
   - The command searches for tools by name using the same search logic as other commands.
   - For each match it will ask for confirmation before deleting unless -y/--yes is provided.
   - Use --dry-run to perform a dry-run: actions will be logged but no file will be removed.
+  - Each match is checked against its Go build info: binaries whose module does not belong to the
+    requested tool are flagged "(unverified — ...)" and skipped unless --force-unverified is given.
+  - Paths that resolve (via symlinks) outside the scanned tool directories are always refused.
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			out := cmd.OutOrStdout()
@@ -251,15 +712,16 @@ Notes:
 			}
 
 			opts := toolsPkg.UninstallCommandOptions{
-				Args:           args,
-				Yes:            toolUninstallYes,
-				Dry:            toolUninstallDry,
-				Fuzzy:          toolUninstallFuzzy,
-				All:            toolUninstallAll,
-				Verbose:        verboseFlag,
-				GoCLIToolsPath: gocliCtx.Config.Tools.GoCLIToolsPath,
-				ToolsConfigDir: gocliCtx.Config.Tools.ToolsConfigDir,
-				Input:          cmd.InOrStdin(),
+				Args:            args,
+				Yes:             toolUninstallYes,
+				Dry:             toolUninstallDry,
+				Fuzzy:           toolUninstallFuzzy,
+				All:             toolUninstallAll,
+				ForceUnverified: toolUninstallForceUnverified,
+				Verbose:         globalFlags.Verbose,
+				GoCLIToolsPath:  gocliCtx.Config.Tools.GoCLIToolsPath,
+				ToolsConfigDir:  gocliCtx.Config.Tools.ToolsConfigDir,
+				Input:           cmd.InOrStdin(),
 			}
 
 			if err := toolsPkg.ExecuteUninstallCommand(opts, out); err != nil {
@@ -269,6 +731,48 @@ Notes:
 		},
 	}
 
+	toolVerifyCmd = &cobra.Command{
+		Use:   "verify [name...]",
+		Short: "Check installed tools against their recorded sha256",
+		Long: `
+gocli tools verify re-hashes installed tool binaries and compares them with the sha256 recorded in tool definitions
+or at install time, reporting drift as a table.
+
+Examples:
+  # Verify every tool that has a recorded sha256
+  gocli tools verify
+
+  # Verify some tools; tools without a recorded sha256 are reported as unrecorded with their current hash
+  gocli tools verify golangci-lint goimports
+
+  # Machine-readable output
+  gocli tools verify --json
+
+Notes:
+  - Hashes are read from the 'sha256' field of the builtin/user tools table and of tools.deps/tools.global
+    entries in the config file; config entries win. Tools without such a field use the sha256 recorded at
+    install time in the .gocli-sha256 file of the binary's directory.
+  - Binaries are looked up like 'gocli tools list' (GOPATH/bin, tools.path, ~/.gocli/tools); the .exe suffix
+    is ignored on Windows.
+  - Status is ok, mismatch, missing (recorded but not installed) or unrecorded; the command exits with status 1
+    when any tool is mismatched or missing.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			verifyJSON, _ := cmd.Flags().GetBool("json")
+			opts := toolsPkg.VerifyCommandOptions{
+				Names:          args,
+				Config:         gocliCtx.Config,
+				GoCLIToolsPath: gocliCtx.Config.Tools.GoCLIToolsPath,
+				ToolsConfigDir: gocliCtx.Config.Tools.ToolsConfigDir,
+				JSON:           verifyJSON,
+			}
+			if err := toolsPkg.ExecuteVerifyCommand(opts, cmd.OutOrStdout()); err != nil {
+				log.Error().Err(err).Msg("verify failed")
+				os.Exit(1)
+			}
+		},
+	}
+
 	toolSearchCmd = &cobra.Command{
 		Use:   "search [query]",
 		Short: "Search for a tool",
@@ -276,7 +780,22 @@ Notes:
 Search builtin (and user-defined) tools.
 Behaviour change:
   - With a query argument: perform fuzzy search (non-interactive) using github.com/lithammer/fuzzysearch, output results directly.
-  - Without any argument: enter interactive selection (fuzzy finder) to pick a tool, then print it.
+  - Without any argument: enter interactive selection (fuzzy finder) to pick tools, then print them and
+    offer to install the selection.
+
+Examples:
+  # Pick tools (Tab marks, Enter confirms), print them and answer y to install them
+  gocli tools search
+
+  # Install the picked tools by default (Enter at the prompt installs), into ~/.gocli/tools
+  gocli tools search --install --global
+
+Notes:
+  - The selected tools are summarized in a single plan ("will install 3 tools to ~/.gocli/tools: a, b, c")
+    and confirmed once; each tool is then installed like 'gocli tools install <name>'. A failing tool does
+    not stop the others.
+  - Without --global, tools go to tools.path (or GOBIN when it is empty).
+  - When stdin or stdout is not a terminal the picker selects a single tool and only prints it.
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			// format flags
@@ -321,12 +840,19 @@ Behaviour change:
 			}
 
 			searchOpts := toolsPkg.SearchCommandOptions{
-				Query:     query,
-				Format:    fmtFlag,
-				JSON:      listJSON,
-				YAML:      listYAML,
-				Table:     listTable,
-				ConfigDir: gocliCtx.Config.Tools.ToolsConfigDir,
+				Query:          query,
+				Format:         fmtFlag,
+				JSON:           listJSON,
+				YAML:           listYAML,
+				Table:          listTable,
+				ConfigDir:      gocliCtx.Config.Tools.ToolsConfigDir,
+				Interactive:    style.IsTerminal(os.Stdin) && style.IsTerminal(os.Stdout),
+				Install:        toolSearchInstall,
+				Global:         toolSearchGlobal,
+				GoCLIToolsPath: gocliCtx.Config.Tools.GoCLIToolsPath,
+				Verbose:        globalFlags.Verbose,
+				Quiet:          globalFlags.Quiet,
+				Input:          cmd.InOrStdin(),
 			}
 
 			if err := toolsPkg.ExecuteSearchCommand(searchOpts, out); err != nil {
@@ -357,9 +883,35 @@ Behaviour change:
 		PreRun: func(cmd *cobra.Command, _ []string) {
 			toolsPkg.ShowRunHelpIfRequested(cmd)
 		},
+		ValidArgsFunction: func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if gocliCtx == nil {
+				return nil, cobra.ShellCompDirectiveDefault
+			}
+			for _, p := range gocliCtx.Config.Tools.ToolsConfigDir {
+				_ = toolsPkg.LoadUserTools(p)
+			}
+			return toolsPkg.CompleteRunArgs(gocliCtx.Config.Tools.GoCLIToolsPath, args, toComplete)
+		},
 		Run: func(cmd *cobra.Command, args []string) {
-			gocliToolsPath := gocliCtx.Config.Tools.GoCLIToolsPath
-			if err := toolsPkg.ExecuteToolRun(args, cmd.OutOrStdout(), verboseFlag, gocliToolsPath); err != nil {
+			runOpts := toolsPkg.RunOptions{
+				Verbose:        globalFlags.Verbose,
+				GoCLIToolsPath: gocliCtx.Config.Tools.GoCLIToolsPath,
+			}
+			if gocliCtx.Config.Tools.History {
+				runOpts.History = toolsPkg.DefaultHistoryFile()
+			}
+			args, err := toolsPkg.SplitRunFlags(args, &runOpts)
+			if err != nil {
+				log.Error().Err(err).Msg("invalid tools run flags")
+				os.Exit(1)
+			}
+			if runOpts.GoRun {
+				// go run 回退需要完整的工具表（内置 + 用户配置）
+				for _, p := range gocliCtx.Config.Tools.ToolsConfigDir {
+					_ = toolsPkg.LoadUserTools(p)
+				}
+			}
+			if err := toolsPkg.ExecuteToolRun(args, cmd.OutOrStdout(), runOpts); err != nil {
 				log.Error().Err(err).Msg("failed to execute tool")
 			}
 		},
@@ -373,6 +925,44 @@ Behaviour change:
 // addListFlags registers flags for the `tools list` command.
 func addToolsListFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolP("json", "j", false, "Output the list of tools in JSON format")
+	cmd.Flags().StringSliceVar(&toolListGroups, "group", nil, "Only list members of these tools.groups (repeatable), marking installed and missing ones")
+}
+
+// printToolGroups 输出 --group 所选分组成员的安装状态
+func printToolGroups(cmd *cobra.Command, format style.OutputFormat, listJSON bool, installed []toolsPkg.ToolInfo) {
+	sel, err := toolsPkg.ResolveToolGroups(gocliCtx.Config.Tools, toolListGroups)
+	if err != nil {
+		if format.Structured() {
+			printEnvelope(cmd, format, nil, err)
+			return
+		}
+		log.Error().Err(err).Msg("invalid --group")
+		return
+	}
+	sel.MarkInstalled(installed)
+	switch {
+	case format.Structured():
+		printEnvelope(cmd, format, sel.Members, nil)
+	case format == style.OutputPlain:
+		for _, m := range sel.Members {
+			status := "missing"
+			if m.Installed {
+				status = "installed"
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\t%s\n", m.Name, status, m.Path)
+		}
+	case listJSON:
+		b, err := json.MarshalIndent(sel.Members, "", "  ")
+		if err != nil {
+			cmd.PrintErrf("failed to marshal json: %v\n", err)
+			return
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(b))
+	default:
+		if err := toolsPkg.PrintGroupTable(cmd.OutOrStdout(), sel); err != nil {
+			log.Error().Err(err).Msg("failed to print tool groups")
+		}
+	}
 }
 
 // addToolsInstallFlags registers flags for the `tools install` command.
@@ -389,6 +979,9 @@ func addToolsInstallFlags(cmd *cobra.Command, opts *toolsPkg.InstallOptions, glo
 	cmd.Flags().BoolVarP(&opts.DebugBuild, "debug-build", "D", false, "Install in debug mode (-gcflags 'all=-N -l')")
 	// binary name override (avoid conflict with --binary-name used for directories)
 	cmd.Flags().StringVarP(&opts.BinaryName, "binary-name", "n", "", "Override the output binary name (when determinable)")
+	// sha256 verification of the installed binary
+	cmd.Flags().StringVar(&opts.SHA256, "sha256", "", "Expected sha256 of the installed binary; a mismatch removes the binary and fails the install")
+	cmd.Flags().BoolVar(&opts.SkipVerify, "skip-verify", false, "Do not compare the installed binary with the expected sha256 (hashes are still printed)")
 	// clone build method and options
 	cmd.Flags().StringVarP(&opts.BuildMethod, "build", "b", "", "Build method when using --clone: make (default) | goreleaser")
 	cmd.Flags().StringSliceVarP(&opts.BuildArgs, "build-arg", "a", nil, "Extra arguments passed to the build tool (repeatable). For goreleaser, e.g. --build-arg --skip=validate")
@@ -397,15 +990,29 @@ func addToolsInstallFlags(cmd *cobra.Command, opts *toolsPkg.InstallOptions, glo
 	cmd.Flags().BoolVarP(&opts.RecurseSubmodules, "recurse-submodules", "r", false, "Clone Git submodules recursively when using --clone")
 	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Force reinstallation even if the tool already exists (overwrites existing installation)")
 	cmd.Flags().BoolVarP(&toolInstallYes, "yes", "y", false, "Automatic yes to prompts; assume 'yes' for all confirmations")
+	cmd.Flags().BoolVarP(&toolInstallJSON, "json", "j", false, "Print the install result as JSON (install dir, mode, success) for scripting")
+	addDryRunFlag(cmd, "")
 	cmd.Flags().StringSliceVarP(&opts.Tags, "tag", "t", nil, "Build tags to pass to go install, e.g.: --tag sqlite3 --tag postgres")
+	cmd.Flags().StringVar(&opts.TargetOS, "target-os", "", "Target operating system for a cross install (GOOS), e.g. windows")
+	cmd.Flags().StringVar(&opts.TargetArch, "target-arch", "", "Target architecture for a cross install (GOARCH), e.g. arm64")
+	cmd.Flags().StringSliceVar(&toolInstallGroups, "group", nil, "Without arguments, install only the tools of these tools.groups (repeatable; the union is installed)")
+	cmd.Flags().StringVar(&toolInstallFromFile, "from-file", "", "Install every tool listed in a YAML/JSON manifest of name/url/clone/version entries and print a per-tool summary")
+	cmd.Flags().BoolVar(&opts.Offline, "offline", false, "Install only from the local module cache without network access (see 'gocli tools prefetch')")
+	cmd.Flags().BoolVar(&opts.Prune, "prune", false, "After a successful install, remove older binaries in the install directory built from the same main package")
+	cmd.Flags().StringVar(&opts.Check, "check", "", "Post-install smoke test: arguments or full command run against the installed binary ('none' disables; default --version, version, -h)")
+	cmd.Flags().BoolVar(&opts.StrictCheck, "strict-check", false, "Fail the install and remove the binary when the post-install smoke test fails")
+	cmd.Flags().IntVar(&opts.RetryAttempts, "retry-attempts", 3, "Total attempts for git clone / go install on transient network errors (1 disables retries)")
+	cmd.Flags().DurationVar(&opts.RetryDelay, "retry-delay", 2*time.Second, "Wait before the first retry; doubled after every further failure (capped at 30s)")
 }
 
 // addToolsSearchFlags registers flags for the `tools search` command.
 func addToolsSearchFlags(cmd *cobra.Command) {
-	cmd.Flags().StringP("format", "f", "table", "Output format: json|yaml|table (default table)")
+	cmd.Flags().StringP("format", "f", "table", "Output format: json|yaml|toml|table (default table)")
 	cmd.Flags().BoolP("json", "j", false, "Output the search result in JSON format")
 	cmd.Flags().BoolP("yaml", "y", false, "Output the search result in YAML format (overrides -f)")
 	cmd.Flags().BoolP("table", "t", false, "Output the search result in table format (default)")
+	cmd.Flags().BoolVarP(&toolSearchInstall, "install", "i", false, "Without a query, install the picked tools by default (the prompt defaults to yes)")
+	cmd.Flags().BoolVarP(&toolSearchGlobal, "global", "g", false, "Install the picked tools into ~/.gocli/tools")
 }
 
 // addToolsRunFlags registers flags for the `tools run` command.
@@ -414,11 +1021,97 @@ func addToolsSearchFlags(cmd *cobra.Command) {
 func addToolsRunFlags(_ *cobra.Command) {
 }
 
+// addToolsVerifyFlags registers flags for the `tools verify` command.
+func addToolsVerifyFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("json", "j", false, "Output the verification result in JSON format")
+}
+
 func addToolUninstallFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVarP(&toolUninstallYes, "yes", "y", false, "Answer yes to all confirmations")
 	cmd.Flags().BoolVarP(&toolUninstallDry, "dry-run", "n", false, "Dry-run mode: show what would be removed but do not delete files")
 	cmd.Flags().BoolVarP(&toolUninstallFuzzy, "fuzzy", "z", false, "Allow fuzzy substring matching when searching installed binaries (off by default)")
 	cmd.Flags().BoolVarP(&toolUninstallAll, "all", "a", false, "When multiple instances are found, delete all matches (prompt once)")
+	cmd.Flags().BoolVar(&toolUninstallForceUnverified, "force-unverified", false, "Also remove binaries whose build info cannot be attributed to the requested tool")
+}
+
+// writeToolsManifest 将清单写入 file（file 为空时写到 stdout）；format 为空时按 file 的扩展名选择，默认 yaml
+func writeToolsManifest(stdout io.Writer, m *toolsPkg.ToolsManifest, file, format string) error {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" && strings.EqualFold(filepath.Ext(file), ".json") {
+		format = "json"
+	}
+	write := m.WriteYAML
+	switch format {
+	case "", "yaml", "yml":
+	case "json":
+		write = m.WriteJSON
+	default:
+		return fmt.Errorf("unsupported format %q (want yaml or json)", format)
+	}
+	if file == "" {
+		return write(stdout)
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	// 关闭失败可能意味着清单没有完整写入磁盘
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("write %s: %w", file, err)
+	}
+	log.Info().Msgf("exported %d tool(s) to %s", len(m.Deps)+len(m.Global), file)
+	return nil
+}
+
+// addToolsHistoryFlags registers flags for the `tools history` command.
+func addToolsHistoryFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVarP(&toolHistoryLimit, "limit", "l", 20, "Show at most this many recent entries (0 shows all)")
+	cmd.Flags().StringVarP(&toolHistoryTool, "tool", "t", "", "Only show entries for this tool")
+	cmd.Flags().BoolVarP(&toolHistoryStats, "stats", "s", false, "Aggregate run counts and failure rates per tool")
+	cmd.Flags().BoolP("json", "j", false, "Output the history in JSON format")
+}
+
+// addToolsExportFlags registers flags for the `tools export` command.
+func addToolsExportFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&toolExportOutput, "output", "o", "", "Write the manifest to this file (default stdout)")
+	cmd.Flags().StringVarP(&toolExportFormat, "format", "f", "", "Manifest format: yaml|json (default from the -o extension, else yaml)")
+}
+
+// addToolsImportFlags registers flags for the `tools import` command.
+func addToolsImportFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&toolImportGlobal, "global", "g", false, "Install every tool in the manifest to ~/.gocli/tools")
+	cmd.Flags().StringSliceVarP(&toolImportEnv, "env", "e", nil, "Additional build environment variables, e.g.: --env CGO_ENABLED=1")
+	addDryRunFlag(cmd, "")
+}
+
+// addToolsPrefetchFlags registers flags for the `tools prefetch` command.
+func addToolsPrefetchFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVarP(&toolPrefetchEnv, "env", "e", nil, "Additional environment variables for the downloads, e.g.: --env GOPROXY=https://goproxy.cn")
+	addDryRunFlag(cmd, "")
+}
+
+// addToolsAddFlags registers flags for the `tools add` command.
+func addToolsAddFlags(cmd *cobra.Command, opts *toolsPkg.AddOptions) {
+	cmd.Flags().SortFlags = false
+	cmd.Flags().BoolVarP(&opts.Global, "global", "g", false, "Record the tool in tools.global of the user config instead of tools.deps of the project config")
+	cmd.Flags().BoolVarP(&toolAddInstall, "install", "i", false, "Install the tool right after recording it")
+	cmd.Flags().StringVarP(&opts.CloneURL, "clone", "C", "", "Record a tool built from a Git repository, supports URL#ref syntax")
+	cmd.Flags().StringVarP(&opts.Build, "build", "b", "", "Build method when using --clone: make (default) | goreleaser")
+	cmd.Flags().StringVarP(&opts.MakeTarget, "make-target", "m", "", "Make target to run when using --clone")
+	cmd.Flags().StringVarP(&opts.WorkDir, "workdir", "w", "", "Subdirectory inside the repository to run the build in")
+	cmd.Flags().StringSliceVarP(&opts.BinDirs, "dir", "d", nil, "Directory(ies) where the build writes the binaries")
+	cmd.Flags().StringVarP(&opts.BinaryName, "binary-name", "n", "", "Override the output binary name")
+	cmd.Flags().StringSliceVarP(&opts.Env, "env", "e", nil, "Build environment variables stored with the entry, e.g.: --env CGO_ENABLED=1")
+	cmd.Flags().StringSliceVarP(&opts.Tags, "tag", "t", nil, "Build tags stored with the entry (go install -tags)")
+}
+
+// addToolsInfoFlags registers flags for the `tools info` command.
+func addToolsInfoFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("json", "j", false, "Output the tool details in JSON format")
 }
 
 func mustUserHome() string {
@@ -436,7 +1129,13 @@ func init() {
 		toolAddCmd,
 		toolUninstallCmd,
 		toolSearchCmd,
+		toolVerifyCmd,
 		toolRunCmd,
+		toolExportCmd,
+		toolImportCmd,
+		toolPrefetchCmd,
+		toolHistoryCmd,
+		toolInfoCmd,
 	)
 
 	// Reuse the common run-style help formatter so gox and tools run share help
@@ -446,7 +1145,14 @@ func init() {
 	// register flags via helper functions (extracted for clarity / reuse)
 	addToolsListFlags(toolListCmd)
 	addToolsInstallFlags(toolInstallCmd, &toolInstallOptions, &toolInstallGlobal)
+	addToolsAddFlags(toolAddCmd, &toolAddOptions)
 	addToolsSearchFlags(toolSearchCmd)
+	addToolsVerifyFlags(toolVerifyCmd)
 	addToolsRunFlags(toolRunCmd)
 	addToolUninstallFlags(toolUninstallCmd)
+	addToolsExportFlags(toolExportCmd)
+	addToolsImportFlags(toolImportCmd)
+	addToolsPrefetchFlags(toolPrefetchCmd)
+	addToolsHistoryFlags(toolHistoryCmd)
+	addToolsInfoFlags(toolInfoCmd)
 }
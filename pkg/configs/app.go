@@ -10,18 +10,32 @@ type AppConfig struct {
 	Debug   bool          `mapstructure:"debug" jsonschema:"title=Debug,description=Enable debug mode (more verbose internal logging)"`
 	Verbose bool          `mapstructure:"verbose" jsonschema:"title=Verbose,description=Enable verbose output for commands"`
 	Quiet   bool          `mapstructure:"quiet" jsonschema:"title=Quiet,description=Suppress non-error output"`
+	Pager   bool          `mapstructure:"pager" jsonschema:"title=Pager,description=Page long output (doc / tools list) through $PAGER when stdout is a terminal"`
 	Hotload HotloadConfig `mapstructure:"hotload" jsonschema:"title=Hotload,description=File watching / hot reload settings"`
 }
 
 // HotloadConfig 热加载配置
 type HotloadConfig struct {
 	Enabled        bool     `mapstructure:"enabled" jsonschema:"title=Enabled,description=Enable hot file watching"`
-	Dir            string   `mapstructure:"dir" jsonschema:"title=Dir,description=Root directory to watch"`
+	Dir            string   `mapstructure:"dir" gocli:"path" jsonschema:"title=Dir,description=Root directory to watch; ~ and $VAR/${VAR} are expanded and relative paths set in a config file resolve against its directory (defaults against the working directory)"`
 	Filter         []string `mapstructure:"filter" jsonschema:"title=Filter,description=Glob patterns to include for watching,uniqueItems"`
 	Recursive      bool     `mapstructure:"recursive" jsonschema:"title=Recursive,description=Watch directories recursively"`
 	Debounce       int      `mapstructure:"debounce" jsonschema:"title=Debounce,description=Event debounce time in milliseconds,minimum=0"`    // 防抖时间
 	IgnorePatterns []string `mapstructure:"ignore_patterns" jsonschema:"title=IgnorePatterns,description=Glob patterns to ignore,uniqueItems"` // 忽略的文件模式
 	GitIgnore      bool     `mapstructure:"git_ignore" jsonschema:"title=GitIgnore,description=Honor .gitignore exclusions"`                   // 是否使用 .gitignore 文件
+	// WatchPaths 额外监视的路径（可位于项目之外，相对路径基于 Dir），各自遵循其目录下的 .gitignore
+	WatchPaths []string `mapstructure:"watch_paths" jsonschema:"title=WatchPaths,description=Additional paths to watch (may be outside the project; relative to Dir),nullable,uniqueItems"`
+
+	// PreHooks 每次（重新）构建/运行前依次执行的 shell 命令，例如 templ generate、sqlc generate
+	PreHooks []string `mapstructure:"pre_hooks" jsonschema:"title=PreHooks,description=Shell commands run before each build/run on change (e.g. code generators),nullable"`
+	// PostHooks 每次构建完成或 run 的程序启动后依次执行的 shell 命令，例如触发浏览器刷新的 webhook
+	PostHooks []string `mapstructure:"post_hooks" jsonschema:"title=PostHooks,description=Shell commands run after each build/run on change (e.g. browser reload webhook),nullable"`
+	// Exec 替换默认 build/run 的完整命令行（通过 shell 执行），为空则使用默认行为
+	Exec string `mapstructure:"exec" jsonschema:"title=Exec,description=Shell command line replacing the default build/run command on change,nullable"`
+	// StopOnHookError 钩子失败时中止本次重启（保留上一次运行的进程）
+	StopOnHookError bool `mapstructure:"stop_on_hook_error" jsonschema:"title=StopOnHookError,description=Abort the restart (keeping the previous process) when a hook fails"`
+	// Status 每次重建后在 stderr 输出一行摘要（耗时、触发文件、重启与失败次数）：auto（stderr 为终端时输出）、true 或 false
+	Status string `mapstructure:"status" jsonschema:"title=Status,description=Print a summary line on stderr after each rebuild: auto (when stderr is a terminal) or true or false,enum=auto,enum=true,enum=false"`
 }
 
 func setAppConfigDefaults() {
@@ -29,6 +43,7 @@ func setAppConfigDefaults() {
 	viper.SetDefault("app.debug", false)
 	viper.SetDefault("app.verbose", false)
 	viper.SetDefault("app.quiet", false)
+	viper.SetDefault("app.pager", true)
 
 	// 热加载配置默认值
 	viper.SetDefault("app.hotload.enabled", false)
@@ -46,4 +61,5 @@ func setAppConfigDefaults() {
 		"node_modules/*",
 	})
 	viper.SetDefault("app.hotload.git_ignore", true) // 默认使用 .gitignore
+	viper.SetDefault("app.hotload.status", "auto")
 }
@@ -0,0 +1,15 @@
+package configs
+
+import (
+	"github.com/spf13/viper"
+)
+
+// CleanConfig 定义 `project clean` 的行为
+type CleanConfig struct {
+	// Dirs --dist/--bin 时删除的构建输出目录（相对当前目录），例如 dist、bin
+	Dirs []string `mapstructure:"dirs" jsonschema:"title=Dirs,description=Build output directories removed by project clean --dist/--bin (relative to the working directory; must stay inside it)"`
+}
+
+func setCleanConfigDefaults() {
+	viper.SetDefault("clean.dirs", []string{"dist"})
+}
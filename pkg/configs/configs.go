@@ -3,7 +3,9 @@ package configs
 
 import (
 	"embed"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -18,13 +20,17 @@ var goCLIConfigTemplate embed.FS
 
 // Config 应用配置结构
 type Config struct {
-	Version int         `mapstructure:"version" jsonschema:"title=Version,description=Configuration file version,minimum=1,required"`
-	Log     LogConfig   `mapstructure:"log" jsonschema:"title=Log,description=Logging related settings"`
-	Env     EnvConfig   `mapstructure:"env" jsonschema:"title=Env,description=Go related environment variables (auto-detected + overrides)"`
-	App     AppConfig   `mapstructure:"app" jsonschema:"title=App,description=General application behavior flags"`
-	Tools   ToolsConfig `mapstructure:"tools" jsonschema:"title=Tools,description=Project and global tool installation configuration"`
-	Doc     DocConfig   `mapstructure:"doc" jsonschema:"title=Doc,description=Documentation generation options"`
-	Init    InitConfig  `mapstructure:"init" jsonschema:"title=Init,description=Project initialization template settings"`
+	Version int          `mapstructure:"version" jsonschema:"title=Version,description=Configuration file version,minimum=1,required"`
+	Log     LogConfig    `mapstructure:"log" jsonschema:"title=Log,description=Logging related settings"`
+	Env     EnvConfig    `mapstructure:"env" jsonschema:"title=Env,description=Go related environment variables (auto-detected + overrides)"`
+	App     AppConfig    `mapstructure:"app" jsonschema:"title=App,description=General application behavior flags"`
+	Tools   ToolsConfig  `mapstructure:"tools" jsonschema:"title=Tools,description=Project and global tool installation configuration"`
+	Doc     DocConfig    `mapstructure:"doc" jsonschema:"title=Doc,description=Documentation generation options"`
+	Init    InitConfig   `mapstructure:"init" jsonschema:"title=Init,description=Project initialization template settings"`
+	Run     RunConfig    `mapstructure:"run" jsonschema:"title=Run,description=Settings for programs started by project run"`
+	Clean   CleanConfig  `mapstructure:"clean" jsonschema:"title=Clean,description=Settings for project clean"`
+	Update  UpdateConfig `mapstructure:"update" jsonschema:"title=Update,description=Settings for project update"`
+	Lint    LintConfig   `mapstructure:"lint" jsonschema:"title=Lint,description=Settings for project lint and project fmt (golangci-lint)"`
 }
 
 // setDefaults 设置默认配置值
@@ -36,46 +42,78 @@ func setDefaults() {
 	setToolsConfigDefaults()
 	setDocConfigDefaults()
 	setInitConfigDefaults()
+	setRunConfigDefaults()
+	setCleanConfigDefaults()
+	setLintConfigDefaults()
 }
 
 var globalConfig *Config
 
-// tryLoadConfigFiles 尝试加载不同格式的配置文件
-func tryLoadConfigFiles(base string) bool {
+// configFileNames 与 configFileExts 组合出每个搜索目录下依次尝试的配置文件名
+var (
+	configFileNames = []string{".gocli", "gocli"}
+	configFileExts  = []string{"yaml", "yml", "json", "toml"}
+)
+
+// extraConfigDirs 是 --config-dir 指定的目录，优先于其他所有搜索路径
+var extraConfigDirs []string
+
+// SetConfigDirs 设置额外的配置目录（--config-dir），它们会被放在搜索路径的最前面
+func SetConfigDirs(dirs ...string) {
+	extraConfigDirs = nil
+	for _, d := range dirs {
+		if d = strings.TrimSpace(d); d == "" {
+			continue
+		}
+		if abs, err := filepath.Abs(d); err == nil {
+			d = abs
+		}
+		extraConfigDirs = append(extraConfigDirs, d)
+	}
+}
+
+// ExtraConfigDirs 返回 SetConfigDirs 设置的目录
+func ExtraConfigDirs() []string {
+	return append([]string(nil), extraConfigDirs...)
+}
+
+// ConfigSearchDirs 返回隐式查找配置文件时的目录顺序（已展开环境变量并去重）
+//  0. --config-dir 指定的目录
+//  1. base（模块根或显式目录）及其 configs 子目录
+//  2. 当前工作目录向上回溯直到文件系统根（每层含 configs 子目录）
+//  3. GetConfigSearchPaths 中的全局路径（HOME 等）
+func ConfigSearchDirs(base string) []string {
 	// 最终搜索路径列表（按优先级）
-	var searchPaths []string
+	searchPaths := ExtraConfigDirs()
 
 	// 1. 指定 base（模块根或显式目录）
 	if base != "" {
 		searchPaths = append(searchPaths, base, filepath.Join(base, "configs"))
 	}
 
-	// 2. 当前工作目录向上回溯，直到文件系统根或已经包含 base
+	// 2. 当前工作目录向上回溯，直到文件系统根
 	if cwd, err := os.Getwd(); err == nil {
-		// 向上遍历
 		cur := cwd
 		for {
-			// 如果已经添加过则停止
-			already := slices.Contains(searchPaths, cur)
-			if !already {
-				searchPaths = append(searchPaths, cur, filepath.Join(cur, "configs"))
-			}
+			searchPaths = append(searchPaths, cur, filepath.Join(cur, "configs"))
 			parent := filepath.Dir(cur)
 			if parent == cur { // 到根目录
 				break
 			}
 			cur = parent
-			// 如果 base 非空且我们已回溯到 base 之上，可以继续直到根，确保向上所有层都能检测
 		}
 	}
 
 	// 3. 预定义全局搜索路径（HOME 等）
 	searchPaths = append(searchPaths, GetConfigSearchPaths()...)
 
-	// 去重（保持顺序）
+	// 展开环境变量并去重（保持顺序）
 	dedup := make([]string, 0, len(searchPaths))
 	seen := make(map[string]struct{})
 	for _, p := range searchPaths {
+		if strings.Contains(p, "$") {
+			p = os.ExpandEnv(p)
+		}
 		if p == "" {
 			continue
 		}
@@ -85,37 +123,62 @@ func tryLoadConfigFiles(base string) bool {
 		seen[p] = struct{}{}
 		dedup = append(dedup, p)
 	}
-	searchPaths = dedup
-
-	configNames := []string{".gocli", "gocli"}
-	extensions := []string{"yaml", "yml", "json", "toml"}
-
-	for _, dir := range searchPaths {
-		for _, name := range configNames {
-			for _, ext := range extensions {
-				configFile := filepath.Join(dir, name+"."+ext)
-				if strings.Contains(configFile, "$") {
-					configFile = os.ExpandEnv(configFile)
-				}
-				if fi, err := os.Stat(configFile); err == nil && !fi.IsDir() {
-					viper.SetConfigFile(configFile)
-					return true
-				}
+	return dedup
+}
+
+// FindConfigFile 在 dir 中按 .gocli/gocli 与 yaml/yml/json/toml 的顺序查找第一个存在的配置文件，找不到返回空字符串
+func FindConfigFile(dir string) string {
+	for _, name := range configFileNames {
+		for _, ext := range configFileExts {
+			configFile := filepath.Join(dir, name+"."+ext)
+			if fi, err := os.Stat(configFile); err == nil && !fi.IsDir() {
+				return configFile
 			}
 		}
 	}
-	return false
+	return ""
 }
 
-// GetConfigSearchPaths 返回配置和资源搜索路径列表，供其他包复用
+// ResolveConfigFile 解析最终使用的配置文件路径
+//
+// 显式指定（--config）时文件必须存在且为支持的格式，否则返回错误；
+// 未指定时按 ConfigSearchDirs 的顺序隐式查找，找不到返回空字符串（使用默认配置）
+func ResolveConfigFile(configPath string) (string, error) {
+	if configPath != "" {
+		fi, err := os.Stat(configPath)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return "", fmt.Errorf("config file %q does not exist", configPath)
+			}
+			return "", fmt.Errorf("config file %q: %w", configPath, err)
+		}
+		if fi.IsDir() {
+			return "", fmt.Errorf("config file %q is a directory", configPath)
+		}
+		ext := strings.TrimPrefix(filepath.Ext(configPath), ".")
+		if !slices.Contains(configFileExts, strings.ToLower(ext)) {
+			return "", fmt.Errorf("config file %q: unsupported extension %q (want one of %s)", configPath, ext, strings.Join(configFileExts, ", "))
+		}
+		return configPath, nil
+	}
+
+	for _, dir := range ConfigSearchDirs(GetModuleRoot("")) {
+		if file := FindConfigFile(dir); file != "" {
+			return file, nil
+		}
+	}
+	return "", nil
+}
+
+// GetConfigSearchPaths 返回配置和资源搜索路径列表，供其他包复用；--config-dir 指定的目录排在最前
 func GetConfigSearchPaths() []string {
-	searchPaths := []string{
+	searchPaths := append(ExtraConfigDirs(),
 		".",
 		"./configs",
 		"$HOME",
 		"$HOME/.config",
 		"$HOME/.config/gocli",
-	}
+	)
 
 	if runtime.GOOS == "windows" {
 		searchPaths = append(searchPaths,
@@ -134,15 +197,13 @@ func LoadConfig(configPath string) (*Config, error) {
 	// 设置默认值
 	setDefaults()
 
-	if configPath != "" {
-		// 使用指定的配置文件路径
-		viper.SetConfigFile(configPath)
-	} else if moduleRoot := GetModuleRoot(""); moduleRoot != "" {
-		// 使用模块根目录进行搜索（支持在子目录中执行命令）
-		tryLoadConfigFiles(moduleRoot)
-	} else {
-		// 尝试查找多种格式的配置文件
-		tryLoadConfigFiles("")
+	// 显式指定的配置文件必须存在；隐式查找时从模块根目录开始（支持在子目录中执行命令）
+	configFile, err := ResolveConfigFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if configFile != "" {
+		viper.SetConfigFile(configFile)
 	}
 
 	// 设置环境变量前缀
@@ -164,8 +225,12 @@ func LoadConfig(configPath string) (*Config, error) {
 	// 应用环境变量
 	config.Env.ApplyEnvVars()
 
-	// 获取所在的配置文件的目录（如果没有加载到配置文件则不修改）
+	// 展开路径字段中的 ~ 与环境变量；配置文件中写出的相对路径基于该文件所在目录解析，
+	// 默认值（如 app.hotload.dir 的 "."）仍相对当前工作目录
 	cfgFile := viper.ConfigFileUsed()
+	normalizePaths(&config, pathBaseDir(cfgFile), viper.InConfig)
+
+	// 获取所在的配置文件的目录（如果没有加载到配置文件则不修改）
 	if cfgFile != "" {
 		cfgDir := filepath.Dir(cfgFile)
 		if config.Log.FilePath == "" {
@@ -0,0 +1,213 @@
+package configs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// 测试显式指定的配置文件会被加载
+func TestLoadConfig_ExplicitPath(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	path := filepath.Join(t.TempDir(), "custom.yaml")
+	if err := os.WriteFile(path, []byte("version: 1\napp:\n  name: custom-app\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.App.Name != "custom-app" {
+		t.Errorf("expected app.name from explicit file, got %q", cfg.App.Name)
+	}
+	if used := viper.ConfigFileUsed(); used != path {
+		t.Errorf("expected config file %s, got %s", path, used)
+	}
+}
+
+// 测试显式指定但不存在/不支持的配置文件是硬错误，而不是回退到隐式查找
+func TestLoadConfig_ExplicitPathMissing(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	dir := t.TempDir()
+	_, err := LoadConfig(filepath.Join(dir, "missing.yaml"))
+	if err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Fatalf("expected missing file error, got %v", err)
+	}
+	if _, err := LoadConfig(dir); err == nil {
+		t.Errorf("expected error for a directory")
+	}
+	bad := filepath.Join(dir, "gocli.ini")
+	if err := os.WriteFile(bad, []byte("x=1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ResolveConfigFile(bad); err == nil {
+		t.Errorf("expected error for unsupported extension")
+	}
+}
+
+// 测试隐式查找的目录顺序与文件名优先级
+func TestConfigSearch_DefaultDiscovery(t *testing.T) {
+	base := t.TempDir()
+	work := t.TempDir()
+	t.Chdir(work)
+
+	dirs := ConfigSearchDirs(base)
+	if len(dirs) < 4 || dirs[0] != base || dirs[1] != filepath.Join(base, "configs") {
+		t.Fatalf("base should be searched first, got %v", dirs)
+	}
+	cwd, _ := os.Getwd()
+	if dirs[2] != cwd {
+		t.Errorf("cwd should follow base, got %v", dirs)
+	}
+
+	if got := FindConfigFile(work); got != "" {
+		t.Errorf("expected no config file, got %s", got)
+	}
+	for _, name := range []string{"gocli.json", ".gocli.toml", ".gocli.yaml"} {
+		if err := os.WriteFile(filepath.Join(work, name), []byte("version: 1\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := FindConfigFile(work); filepath.Base(got) != ".gocli.yaml" {
+		t.Errorf("expected .gocli.yaml to win, got %s", got)
+	}
+
+	// 未显式指定时不会报错，返回值要么为空要么是搜索路径中的某个文件
+	got, err := ResolveConfigFile("")
+	if err != nil {
+		t.Fatalf("implicit resolve should not fail: %v", err)
+	}
+	if got != "" {
+		if _, err := os.Stat(got); err != nil {
+			t.Errorf("resolved file should exist: %v", err)
+		}
+	}
+}
+
+// 测试 --config-dir 指定的目录排在搜索路径最前，其中的配置文件优先被使用
+func TestSetConfigDirs(t *testing.T) {
+	t.Cleanup(func() { SetConfigDirs() })
+	dir := t.TempDir()
+	path := filepath.Join(dir, "gocli.yaml")
+	if err := os.WriteFile(path, []byte("version: 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	SetConfigDirs("", dir)
+	if dirs := ConfigSearchDirs(""); len(dirs) == 0 || dirs[0] != dir {
+		t.Fatalf("expected %s first in search dirs, got %v", dir, dirs)
+	}
+	if paths := GetConfigSearchPaths(); paths[0] != dir {
+		t.Errorf("expected %s first in GetConfigSearchPaths, got %v", dir, paths)
+	}
+	got, err := ResolveConfigFile("")
+	if err != nil || got != path {
+		t.Errorf("ResolveConfigFile() = %q, %v; want %q", got, err, path)
+	}
+}
+
+// 测试路径字段展开 ~ 与环境变量，并基于配置文件目录解析相对路径
+func TestLoadConfig_PathNormalization(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	homeDir, cfgDir := t.TempDir(), t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv("USERPROFILE", homeDir)
+	t.Setenv("GOPATH", filepath.Join(homeDir, "go"))
+	t.Chdir(t.TempDir())
+
+	path := filepath.Join(cfgDir, "gocli.yaml")
+	content := `version: 1
+tools:
+  path: ~/tools
+  tools_config_dir:
+    - $GOPATH/bin
+    - ./relative
+app:
+  hotload:
+    dir: ./relative/sub
+doc:
+  output: docs/:append
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(homeDir, "tools"); cfg.Tools.GoCLIToolsPath != want {
+		t.Errorf("tools.path = %q, want %q", cfg.Tools.GoCLIToolsPath, want)
+	}
+	// env 配置会在展开路径前写入进程环境，$GOPATH 取应用后的值
+	wantDirs := []string{filepath.Join(os.Getenv("GOPATH"), "bin"), filepath.Join(cfgDir, "relative")}
+	if len(cfg.Tools.ToolsConfigDir) != 2 || cfg.Tools.ToolsConfigDir[0] != wantDirs[0] || cfg.Tools.ToolsConfigDir[1] != wantDirs[1] {
+		t.Errorf("tools.tools_config_dir = %v, want %v", cfg.Tools.ToolsConfigDir, wantDirs)
+	}
+	if want := filepath.Join(cfgDir, "relative", "sub"); cfg.App.Hotload.Dir != want {
+		t.Errorf("app.hotload.dir = %q, want %q", cfg.App.Hotload.Dir, want)
+	}
+	if want := filepath.Join(cfgDir, "docs") + string(filepath.Separator) + ":append"; cfg.Doc.Output != want {
+		t.Errorf("doc.output = %q, want %q", cfg.Doc.Output, want)
+	}
+}
+
+// 测试 ExpandPath 与输出目标的特殊取值
+func TestExpandPath(t *testing.T) {
+	base := t.TempDir()
+	t.Setenv("GOCLI_TEST_DIR", "from-env")
+	cases := map[string]string{
+		"":                         "",
+		"./relative":               filepath.Join(base, "relative"),
+		"a/b/../c":                 filepath.Join(base, "a", "c"),
+		"${GOCLI_TEST_DIR}/x":      filepath.Join(base, "from-env", "x"),
+		"out/":                     filepath.Join(base, "out") + string(filepath.Separator),
+		filepath.Join(base, "abs"): filepath.Join(base, "abs"),
+	}
+	for in, want := range cases {
+		if got := ExpandPath(in, base); got != want {
+			t.Errorf("ExpandPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := ExpandPath("./relative", ""); got != "relative" {
+		t.Errorf("without base relative paths stay relative, got %q", got)
+	}
+	for _, special := range []string{"-", "stderr", "clipboard:"} {
+		if got := expandOutputPath(special, base); got != special {
+			t.Errorf("expandOutputPath(%q) = %q", special, got)
+		}
+	}
+}
+
+// 测试家目录下的配置文件：文件中写出的相对路径基于家目录解析，未设置的 app.hotload.dir 默认值 "." 仍相对当前工作目录
+func TestLoadConfig_HomeConfigKeepsDefaultsRelative(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	homeDir, work := t.TempDir(), t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv("USERPROFILE", homeDir)
+	t.Chdir(work)
+
+	path := filepath.Join(homeDir, ".gocli.yaml")
+	if err := os.WriteFile(path, []byte("version: 1\ntools:\n  path: ./tools\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(homeDir, "tools"); cfg.Tools.GoCLIToolsPath != want {
+		t.Errorf("tools.path = %q, want %q", cfg.Tools.GoCLIToolsPath, want)
+	}
+	if cfg.App.Hotload.Dir != "." {
+		t.Errorf("default app.hotload.dir = %q, want it relative to the working directory", cfg.App.Hotload.Dir)
+	}
+}
@@ -18,8 +18,13 @@ func setDocConfigDefaults() {
 	viper.SetDefault("doc.toc", true)
 	viper.SetDefault("doc.verbose", false)
 	viper.SetDefault("doc.detailed", false)
+	viper.SetDefault("doc.sort", doc.SortAlpha)
 	viper.SetDefault("doc.theme", "")
+	viper.SetDefault("doc.no_color", false)
 	viper.SetDefault("doc.width", 0)
 	viper.SetDefault("doc.include_tests", false)
 	viper.SetDefault("doc.include_examples", false)
+	viper.SetDefault("doc.include_readme", false)
+	viper.SetDefault("doc.cache", false)
+	viper.SetDefault("doc.cache_size_mb", doc.DefaultCacheSizeMB)
 }
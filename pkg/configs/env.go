@@ -3,6 +3,10 @@ package configs
 import (
 	"bufio"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"maps"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -14,62 +18,102 @@ import (
 	"github.com/yeisme/gocli/pkg/utils/executor"
 )
 
+// goEnvOverride 指定读取 go env 的工具链：GOROOT 目录或 go 可执行文件；
+// 无法执行的普通文件按 `go env` 的输出格式（KEY=VALUE 行）直接读取，便于测试注入
+const goEnvOverride = "GOCLI_GOENV"
+
 var (
-	goEnvCache    map[string]string
-	loadGoEnvOnce sync.Once
+	goEnvMu     sync.Mutex // 保护 goEnvCache 与 goEnvLoaded
+	goEnvCache  map[string]string
+	goEnvLoaded bool
 )
 
 // loadGoEnv loads environment variables from `go env` and caches them.
-// It runs only once.
-func loadGoEnv() {
-	loadGoEnvOnce.Do(func() {
-		goEnvCache = make(map[string]string)
-		// The most reliable source is the `go env` command itself.
-		output, err := executor.NewExecutor("go", "env").Output()
-		if err != nil {
-			// Fallback to reading default go.env file if `go env` fails
-			goRoot := os.Getenv("GOROOT")
-			if goRoot != "" {
-				goEnvFile := filepath.Join(goRoot, "go.env")
-				file, err := os.Open(goEnvFile)
-				if err == nil {
-					defer func() {
-						if err := file.Close(); err != nil {
-							fmt.Fprintf(os.Stderr, "error closing go.env file: %v\n", err)
-						}
-					}()
-					scanner := bufio.NewScanner(file)
-					for scanner.Scan() {
-						line := strings.TrimSpace(scanner.Text())
-						if line == "" || strings.HasPrefix(line, "#") {
-							continue
-						}
-						parts := strings.SplitN(line, "=", 2)
-						if len(parts) == 2 {
-							goEnvCache[parts[0]] = parts[1]
-						}
-					}
-				}
-			}
-			return // Exit if we can't get env from `go env` or file
+// The cache is filled once and kept until RefreshGoEnv is called; it returns the cached map,
+// which must not be modified.
+func loadGoEnv() map[string]string {
+	goEnvMu.Lock()
+	defer goEnvMu.Unlock()
+	if !goEnvLoaded {
+		goEnvCache = readGoEnv()
+		goEnvLoaded = true
+	}
+	return goEnvCache
+}
+
+// RefreshGoEnv 清空 go env 缓存，下一次读取时重新执行 `go env`。
+// 在切换工具链（GOTOOLCHAIN、GOROOT 或 GOCLI_GOENV 变化）之后调用
+func RefreshGoEnv() {
+	goEnvMu.Lock()
+	goEnvCache, goEnvLoaded = nil, false
+	goEnvMu.Unlock()
+
+	toolchainExperimentsMu.Lock()
+	toolchainExperiments, toolchainExperimentsLoaded = nil, false
+	toolchainExperimentsMu.Unlock()
+}
+
+// goEnvBinary 返回读取 go env 使用的 go 命令：默认为 PATH 中的 go，设置了 GOCLI_GOENV 时为其指定的工具链或文件
+func goEnvBinary() string {
+	override := strings.TrimSpace(os.Getenv(goEnvOverride))
+	if override == "" {
+		return "go"
+	}
+	if fi, err := os.Stat(override); err == nil && fi.IsDir() {
+		goBin := filepath.Join(override, "bin", "go")
+		if runtime.GOOS == "windows" {
+			goBin += ".exe"
 		}
+		return goBin
+	}
+	return override
+}
 
-		scanner := bufio.NewScanner(strings.NewReader(output))
-		for scanner.Scan() {
-			line := scanner.Text()
-			// On Windows, the output might be `set GOROOT=C:\Go`
-			if runtime.GOOS == "windows" && strings.HasPrefix(line, "set ") {
-				line = strings.TrimPrefix(line, "set ")
-			}
-			parts := strings.SplitN(line, "=", 2)
-			if len(parts) == 2 {
-				key := parts[0]
-				// Values can be enclosed in quotes
-				value := strings.Trim(parts[1], `"`)
-				goEnvCache[key] = value
-			}
+// readGoEnv 执行 `go env`（设置了 GOCLI_GOENV 时使用其指定的工具链），
+// 失败时回退到读取 GOROOT/go.env 中的默认值
+func readGoEnv() map[string]string {
+	goBin := goEnvBinary()
+	// The most reliable source is the `go env` command itself.
+	output, err := executor.NewExecutor(goBin, "env").Output()
+	if err == nil {
+		return parseGoEnv(output)
+	}
+	if goBin != "go" {
+		// GOCLI_GOENV 指向无法执行的文件时，按 go env 的输出格式读取
+		if data, err := os.ReadFile(goBin); err == nil {
+			return parseGoEnv(string(data))
 		}
-	})
+		fmt.Fprintf(os.Stderr, "%s=%s: cannot run '%s env': %v\n", goEnvOverride, os.Getenv(goEnvOverride), goBin, err)
+	}
+	// Fallback to reading default go.env file if `go env` fails
+	if goRoot := os.Getenv("GOROOT"); goRoot != "" {
+		if data, err := os.ReadFile(filepath.Join(goRoot, "go.env")); err == nil {
+			return parseGoEnv(string(data))
+		}
+	}
+	return map[string]string{}
+}
+
+// parseGoEnv 解析 `go env` 的输出或 go.env 文件：忽略空行与 # 注释，
+// 去掉 Windows 的 "set " 前缀以及值两侧的引号
+func parseGoEnv(output string) map[string]string {
+	env := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// On Windows, the output might be `set GOROOT=C:\Go`
+		if runtime.GOOS == "windows" {
+			line = strings.TrimPrefix(line, "set ")
+		}
+		if key, value, ok := strings.Cut(line, "="); ok {
+			// Values can be enclosed in quotes (double on Windows, single on Unix)
+			env[key] = strings.Trim(value, `"'`)
+		}
+	}
+	return env
 }
 
 // EnvConfig 环境变量配置
@@ -210,8 +254,14 @@ func setEnvConfigDefaults() {
 	viper.SetDefault("env.GOEXPERIMENT", getGoEnvOrDefault("GOEXPERIMENT", ""))
 }
 
-// ApplyEnvVars 应用环境变量到当前进程
+// ApplyEnvVars 应用环境变量到当前进程；配置切换了工具链（GOTOOLCHAIN/GOROOT 与 go env 的结果不同）时刷新 go env 缓存
 func (e *EnvConfig) ApplyEnvVars() {
+	switched := (e.GoToolchain != "" && e.GoToolchain != getGoEnvOrDefault("GOTOOLCHAIN", "")) ||
+		(e.GoRoot != "" && e.GoRoot != getGoEnvOrDefault("GOROOT", ""))
+	if switched {
+		defer RefreshGoEnv()
+	}
+
 	// 使用反射获取结构体字段
 	v := reflect.ValueOf(*e)
 	t := reflect.TypeOf(*e)
@@ -248,53 +298,15 @@ func (e *EnvConfig) ApplyEnvVars() {
 	}
 }
 
-// Validate 验证环境变量配置的有效性
-func (e *EnvConfig) Validate() []string {
-	var errors []string
-
-	// 验证 GOEXPERIMENT
-	if invalid := ValidateGoExperiment(e.GoExperiment); len(invalid) > 0 {
-		for _, exp := range invalid {
-			errors = append(errors, "未知的 GOEXPERIMENT 选项: "+exp)
-		}
-	}
-
-	// 验证 GOOS 和 GOARCH 组合
-	if e.GoOS != "" && e.GoArch != "" {
-		if !isValidOSArchCombination(e.GoOS, e.GoArch) {
-			errors = append(errors, "不支持的 GOOS/GOARCH 组合: "+e.GoOS+"/"+e.GoArch)
-		}
-	}
-
-	return errors
-}
-
-// isValidOSArchCombination 检查操作系统和架构组合是否有效
-func isValidOSArchCombination(goos, goarch string) bool {
-	validCombinations := map[string][]string{
-		"linux":     {"386", "amd64", "arm", "arm64", "mips", "mips64", "mips64le", "mipsle", "ppc64", "ppc64le", "riscv64", "s390x"},
-		"darwin":    {"amd64", "arm64"},
-		"windows":   {"386", "amd64", "arm", "arm64"},
-		"freebsd":   {"386", "amd64", "arm", "arm64", "riscv64"},
-		"openbsd":   {"386", "amd64", "arm", "arm64", "mips64"},
-		"netbsd":    {"386", "amd64", "arm", "arm64"},
-		"dragonfly": {"amd64"},
-		"plan9":     {"386", "amd64", "arm"},
-		"solaris":   {"amd64"},
-		"android":   {"386", "amd64", "arm", "arm64"},
-		"ios":       {"arm64"},
-		"js":        {"wasm"},
-		"wasip1":    {"wasm"},
-	}
-
-	if archs, exists := validCombinations[goos]; exists {
-		for _, arch := range archs {
-			if arch == goarch {
-				return true
-			}
-		}
-	}
-	return false
+// Validate 验证环境变量配置的有效性，返回的警告均带有对应的配置键与可接受的取值
+func (e *EnvConfig) Validate() []ConfigWarning {
+	var warnings []ConfigWarning
+	warnings = append(warnings, validateGoExperimentKey(e.GoExperiment)...)
+	warnings = append(warnings, validateOSArchKeys(e.GoOS, e.GoArch)...)
+	warnings = append(warnings, validateGoFlagsKey(e.GoFlags)...)
+	warnings = append(warnings, validateEnumKey("CGO_ENABLED", e.CGOEnabled, validCGOEnabled)...)
+	warnings = append(warnings, validateEnumKey("GOAMD64", e.GoAMD64, validGoAMD64)...)
+	return warnings
 }
 
 // getGoEnvOrDefault gets a value for a Go environment variable with fallback.
@@ -303,10 +315,10 @@ func isValidOSArchCombination(goos, goarch string) bool {
 // 2. Value from the operating system's environment variables.
 // 3. The provided default value.
 func getGoEnvOrDefault(key, defaultValue string) string {
-	loadGoEnv() // Ensures the cache is populated on first call.
+	cache := loadGoEnv() // Ensures the cache is populated on first call.
 
 	// 1. Check our `go env` cache.
-	if value, ok := goEnvCache[key]; ok && value != "" {
+	if value, ok := cache[key]; ok && value != "" {
 		return value
 	}
 	// 2. Check the actual OS environment variables.
@@ -320,35 +332,128 @@ func getGoEnvOrDefault(key, defaultValue string) string {
 // GetModuleRoot returns the directory containing the provided go.mod path.
 // If the provided goMod is empty, it attempts to use the GOMOD value from
 // cached `go env` or environment variables; if still empty, returns an empty string.
+// Outside of a module (GOMOD is os.DevNull, e.g. at the root of a go.work workspace)
+// it falls back to the directory of the active go.work file.
 func GetModuleRoot(goMod string) string {
 	if goMod == "" {
 		goMod = getGoEnvOrDefault("GOMOD", "")
 	}
+	if goMod == os.DevNull {
+		goMod = GetWorkFile("")
+	}
 	if goMod == "" {
 		return ""
 	}
 	return filepath.Dir(goMod)
 }
 
+// GetWorkFile returns the go.work file selected by goWork (the GOWORK setting, see EnvConfig.GoWork):
+//   - "off" disables workspace mode and returns an empty string;
+//   - "" or "auto" uses the GOWORK value reported by `go env` (the go.work found from the current directory);
+//   - any other value is treated as a path to a go.work file.
+//
+// An empty string is returned when the resolved file does not exist.
+func GetWorkFile(goWork string) string {
+	switch goWork = strings.TrimSpace(goWork); goWork {
+	case "off":
+		return ""
+	case "", "auto":
+		goWork = getGoEnvOrDefault("GOWORK", "")
+	}
+	if goWork == "" || goWork == "off" {
+		return ""
+	}
+	if fi, err := os.Stat(goWork); err != nil || fi.IsDir() {
+		return ""
+	}
+	if abs, err := filepath.Abs(goWork); err == nil {
+		return abs
+	}
+	return goWork
+}
+
+// knownGoExperiments 是常见的 GOEXPERIMENT 选项及其说明，
+// 在无法从工具链源码中读取实验列表时作为回退使用
+var knownGoExperiments = map[string]string{
+	"rangefunc":         "启用 range-over-func 特性 (Go 1.22+)",
+	"arenas":            "启用 arenas 内存管理实验",
+	"cgocheck2":         "启用更严格的 cgo 指针检查",
+	"fieldtrack":        "启用字段跟踪功能，用于分析结构体字段使用情况",
+	"preemptibleloops":  "启用可抢占循环，改善调度器性能",
+	"staticlockranking": "启用静态锁排序检查，帮助检测死锁",
+	"boringcrypto":      "启用 BoringSSL 加密库支持",
+	"unified":           "启用统一的类型检查器 (Go 1.18+)",
+	"typeparams":        "启用泛型类型参数支持 (Go 1.18+)",
+	"pacer":             "启用新的 GC pacer 算法",
+	"checkptr":          "启用指针检查（runtime 调试）",
+	"asyncpreempt":      "启用异步抢占",
+	"newinliner":        "启用新的内联器",
+	"coverageredesign":  "启用覆盖率重新设计",
+}
+
+var (
+	toolchainExperimentsMu     sync.Mutex // 保护 toolchainExperiments 与 toolchainExperimentsLoaded，RefreshGoEnv 时清空
+	toolchainExperiments       map[string]string
+	toolchainExperimentsLoaded bool
+)
+
 // GetAvailableGoExperiments 获取当前Go版本支持的实验性功能列表
+// 优先读取已安装工具链的 GOROOT/src/internal/goexperiment/flags.go（与 go 命令校验 GOEXPERIMENT 使用同一份定义），
+// 失败时回退到内置的静态列表
 func GetAvailableGoExperiments() map[string]string {
-	// 这些是常见的 GOEXPERIMENT 选项及其说明
-	experiments := map[string]string{
-		"rangefunc":         "启用 range-over-func 特性 (Go 1.22+)",
-		"arenas":            "启用 arenas 内存管理实验",
-		"cgocheck2":         "启用更严格的 cgo 指针检查",
-		"fieldtrack":        "启用字段跟踪功能，用于分析结构体字段使用情况",
-		"preemptibleloops":  "启用可抢占循环，改善调度器性能",
-		"staticlockranking": "启用静态锁排序检查，帮助检测死锁",
-		"boringcrypto":      "启用 BoringSSL 加密库支持",
-		"unified":           "启用统一的类型检查器 (Go 1.18+)",
-		"typeparams":        "启用泛型类型参数支持 (Go 1.18+)",
-		"pacer":             "启用新的 GC pacer 算法",
-		"checkptr":          "启用指针检查（runtime 调试）",
-		"asyncpreempt":      "启用异步抢占",
-		"newinliner":        "启用新的内联器",
-		"coverageredesign":  "启用覆盖率重新设计",
+	toolchainExperimentsMu.Lock()
+	if !toolchainExperimentsLoaded {
+		goRoot := getGoEnvOrDefault("GOROOT", runtime.GOROOT())
+		toolchainExperiments = parseGoExperimentFlags(filepath.Join(goRoot, "src", "internal", "goexperiment", "flags.go"))
+		toolchainExperimentsLoaded = true
 	}
+	parsed := toolchainExperiments
+	toolchainExperimentsMu.Unlock()
+
+	experiments := make(map[string]string)
+	if len(parsed) == 0 {
+		maps.Copy(experiments, knownGoExperiments)
+		return experiments
+	}
+	for name, desc := range parsed {
+		if known, ok := knownGoExperiments[name]; ok {
+			desc = known
+		}
+		experiments[name] = desc
+	}
+	return experiments
+}
+
+// parseGoExperimentFlags 解析 goexperiment.Flags 结构体，返回小写实验名到注释首行的映射
+func parseGoExperimentFlags(file string) map[string]string {
+	f, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.ParseComments)
+	if err != nil {
+		return nil
+	}
+	experiments := make(map[string]string)
+	ast.Inspect(f, func(n ast.Node) bool {
+		ts, ok := n.(*ast.TypeSpec)
+		if !ok || ts.Name.Name != "Flags" {
+			return true
+		}
+		st, ok := ts.Type.(*ast.StructType)
+		if !ok {
+			return false
+		}
+		for _, field := range st.Fields.List {
+			if ident, ok := field.Type.(*ast.Ident); !ok || ident.Name != "bool" {
+				continue
+			}
+			desc := ""
+			if field.Doc != nil {
+				desc = strings.SplitN(strings.TrimSpace(field.Doc.Text()), "\n", 2)[0]
+			}
+			for _, name := range field.Names {
+				experiments[strings.ToLower(name.Name)] = desc
+			}
+		}
+		return false
+	})
 	return experiments
 }
 
@@ -368,8 +473,14 @@ func ValidateGoExperiment(experiment string) []string {
 			continue
 		}
 
-		// 检查是否是有效的实验选项
-		if _, exists := available[exp]; !exists {
+		// 检查是否是有效的实验选项（支持 "no" 前缀关闭某个实验，如 noregabiargs）
+		_, exists := available[exp]
+		if !exists {
+			if name, ok := strings.CutPrefix(exp, "no"); ok {
+				_, exists = available[name]
+			}
+		}
+		if !exists {
 			invalid = append(invalid, exp)
 		}
 	}
@@ -0,0 +1,17 @@
+package configs
+
+import "testing"
+
+// 测试解析 go env 输出：Unix 的单引号、Windows 的双引号与无引号的值
+func TestParseGoEnv(t *testing.T) {
+	env := parseGoEnv("GOOS='linux'\nGOARCH=\"amd64\"\nGOFLAGS=\nCGO_ENABLED=1\nnot a pair\n")
+	want := map[string]string{"GOOS": "linux", "GOARCH": "amd64", "GOFLAGS": "", "CGO_ENABLED": "1"}
+	if len(env) != len(want) {
+		t.Errorf("parseGoEnv returned %v, want %v", env, want)
+	}
+	for k, v := range want {
+		if got, ok := env[k]; !ok || got != v {
+			t.Errorf("%s = %q, want %q", k, got, v)
+		}
+	}
+}
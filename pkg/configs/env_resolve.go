@@ -0,0 +1,114 @@
+package configs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// initialEnviron 是进程启动时的环境变量，在 ApplyEnvVars 修改进程环境之前取得，
+// 用于得到不受 gocli 配置影响的原始 go env
+var initialEnviron = os.Environ()
+
+// 解析后的环境变量值的来源
+const (
+	EnvSourceConfig  = "config"  // 配置文件的 env 段
+	EnvSourceOS      = "os"      // gocli 启动时的进程环境变量
+	EnvSourceGoEnv   = "go env"  // go env（包括 go env -w 写入的设置与工具链默认值）
+	EnvSourceDefault = "default" // gocli 内置的默认值
+	EnvSourceUnset   = "unset"   // 没有任何来源提供值
+)
+
+// EnvEntry 是 gocli env 输出的一项：gocli 使用的值、原始 go env 中的值以及值的来源
+type EnvEntry struct {
+	Key     string `json:"key" yaml:"key"`
+	Value   string `json:"value" yaml:"value"`                       // gocli 执行 go 命令时使用的值
+	GoEnv   string `json:"go_env,omitempty" yaml:"go_env,omitempty"` // 不受 gocli 配置影响的 go env 中的值
+	InGoEnv bool   `json:"in_go_env" yaml:"in_go_env"`               // go env 是否认识该变量（GOGCFLAGS 等只由 gocli 使用）
+	Source  string `json:"source" yaml:"source"`                     // config|os|go env|default|unset
+	Differs bool   `json:"differs,omitempty" yaml:"differs,omitempty"`
+}
+
+// RawGoEnv 以 gocli 启动时的进程环境执行 `go env -json`（设置了 GOCLI_GOENV 时使用其指定的工具链），
+// 结果不受配置文件 env 段的影响；GOCLI_GOENV 指向无法执行的文件时按 go env 的输出格式读取
+func RawGoEnv() (map[string]string, error) {
+	goBin := goEnvBinary()
+	out, err := executor.NewExecutor(goBin, "env", "-json").WithCleanEnv(initialEnviron...).Output()
+	if err != nil {
+		if goBin != "go" {
+			if data, rerr := os.ReadFile(goBin); rerr == nil {
+				return parseGoEnv(string(data)), nil
+			}
+		}
+		return nil, fmt.Errorf("go env: %w", err)
+	}
+	env := make(map[string]string)
+	if err := json.Unmarshal([]byte(out), &env); err != nil {
+		return nil, fmt.Errorf("parse go env -json output: %w", err)
+	}
+	return env, nil
+}
+
+// Resolve 返回 gocli 对每个环境变量解析出的值，按 EnvConfig 字段顺序排列，其后为按名称排序的自定义变量。
+// 配置中为空的变量不会被 ApplyEnvVars 设置，go 命令看到的是原始 go env 的值，因此以它作为结果；
+// GOWORK=auto 与 go env 报告的 go.work 路径含义相同，不算作差异
+func (e *EnvConfig) Resolve(goEnv map[string]string) []EnvEntry {
+	osEnv := environMap(initialEnviron)
+	var entries []EnvEntry
+	add := func(key, value string, custom bool) {
+		raw, known := goEnv[key]
+		entry := EnvEntry{Key: key, Value: value, GoEnv: raw, InGoEnv: known}
+		switch {
+		case value == "" && raw != "":
+			entry.Value, entry.Source = raw, EnvSourceGoEnv
+		case value == "":
+			entry.Source = EnvSourceUnset
+		case custom || viper.InConfig("env."+key):
+			entry.Source = EnvSourceConfig
+		case osEnv[key] == value:
+			entry.Source = EnvSourceOS
+		case known && raw == value:
+			entry.Source = EnvSourceGoEnv
+		default:
+			entry.Source = EnvSourceDefault
+		}
+		entry.Differs = known && entry.Value != raw && !(key == "GOWORK" && entry.Value == "auto")
+		entries = append(entries, entry)
+	}
+
+	v := reflect.ValueOf(*e)
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		key := t.Field(i).Tag.Get("mapstructure")
+		if v.Field(i).Kind() != reflect.String || key == "" || strings.HasPrefix(key, ",") {
+			continue
+		}
+		add(key, v.Field(i).String(), false)
+	}
+	custom := make([]string, 0, len(e.Custom))
+	for key := range e.Custom {
+		custom = append(custom, key)
+	}
+	slices.Sort(custom)
+	for _, key := range custom {
+		add(key, e.Custom[key], true)
+	}
+	return entries
+}
+
+// environMap 将 KEY=VALUE 形式的环境变量列表转换为映射
+func environMap(environ []string) map[string]string {
+	m := make(map[string]string, len(environ))
+	for _, kv := range environ {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			m[key] = value
+		}
+	}
+	return m
+}
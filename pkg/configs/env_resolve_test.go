@@ -0,0 +1,56 @@
+package configs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// 测试 gocli env 的解析：值的来源、与原始 go env 的差异，配置为空时使用 go env 的值；
+// GOCLI_GOENV 指向普通文件时 RawGoEnv 按 go env 的输出格式读取
+func TestEnvResolve(t *testing.T) {
+	saved := initialEnviron
+	initialEnviron = []string{"GOOS=linux", "HOME=/home/t"}
+	t.Cleanup(func() { initialEnviron = saved })
+
+	e := &EnvConfig{GoProxy: "https://goproxy.cn", GoOS: "linux", GoArch: "amd64", GoWork: "auto", GoGCFlags: "-N", Custom: map[string]string{"my_var": "1"}}
+	goEnv := map[string]string{"GOPROXY": "https://proxy.golang.org", "GOOS": "linux", "GOARCH": "amd64", "GOFLAGS": "-mod=mod", "GOWORK": "/src/go.work"}
+	got := map[string]EnvEntry{}
+	for _, entry := range e.Resolve(goEnv) {
+		got[entry.Key] = entry
+	}
+
+	want := map[string]EnvEntry{
+		"GOPROXY":   {Value: "https://goproxy.cn", Source: EnvSourceDefault, Differs: true},
+		"GOOS":      {Value: "linux", Source: EnvSourceOS},
+		"GOARCH":    {Value: "amd64", Source: EnvSourceGoEnv},
+		"GOFLAGS":   {Value: "-mod=mod", Source: EnvSourceGoEnv},
+		"GOWORK":    {Value: "auto", Source: EnvSourceDefault},
+		"GOGCFLAGS": {Value: "-N", Source: EnvSourceDefault},
+		"GOPRIVATE": {Value: "", Source: EnvSourceUnset},
+		"my_var":    {Value: "1", Source: EnvSourceConfig},
+	}
+	for key, w := range want {
+		g, ok := got[key]
+		if !ok {
+			t.Errorf("%s missing", key)
+			continue
+		}
+		if g.Value != w.Value || g.Source != w.Source || g.Differs != w.Differs {
+			t.Errorf("%s = {%q %s differs=%v}, want {%q %s differs=%v}", key, g.Value, g.Source, g.Differs, w.Value, w.Source, w.Differs)
+		}
+	}
+	if got["GOGCFLAGS"].InGoEnv || !got["GOPROXY"].InGoEnv {
+		t.Error("InGoEnv should reflect whether go env reports the variable")
+	}
+
+	file := filepath.Join(t.TempDir(), "go.env")
+	if err := os.WriteFile(file, []byte("GOPROXY='off'\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(goEnvOverride, file)
+	raw, err := RawGoEnv()
+	if err != nil || raw["GOPROXY"] != "off" {
+		t.Errorf("RawGoEnv = %v, %v", raw, err)
+	}
+}
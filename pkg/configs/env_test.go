@@ -0,0 +1,50 @@
+package configs
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// 测试 GOCLI_GOENV 注入 go env 的结果：缓存在 RefreshGoEnv 之前保持不变，并发读取与刷新是安全的
+func TestRefreshGoEnv(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "go.env")
+	if err := os.WriteFile(file, []byte("# injected\nGOOS='plan9'\nGOCLI_TEST_KEY=\"first\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(goEnvOverride, file)
+	RefreshGoEnv()
+	t.Cleanup(RefreshGoEnv)
+
+	if got := getGoEnvOrDefault("GOOS", ""); got != "plan9" {
+		t.Errorf("GOOS = %q, want the injected value", got)
+	}
+	if err := os.WriteFile(file, []byte("GOCLI_TEST_KEY=second\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := getGoEnvOrDefault("GOCLI_TEST_KEY", ""); got != "first" {
+		t.Errorf("cached value = %q, want first", got)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if i%2 == 0 {
+				RefreshGoEnv()
+			}
+			_ = getGoEnvOrDefault("GOCLI_TEST_KEY", "")
+		}()
+	}
+	wg.Wait()
+
+	RefreshGoEnv()
+	if got := getGoEnvOrDefault("GOCLI_TEST_KEY", ""); got != "second" {
+		t.Errorf("after refresh = %q, want second", got)
+	}
+	if got := getGoEnvOrDefault("GOOS", "fallback"); got != "fallback" && got != os.Getenv("GOOS") {
+		t.Errorf("GOOS should no longer come from the old file, got %q", got)
+	}
+}
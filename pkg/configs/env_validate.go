@@ -0,0 +1,167 @@
+package configs
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ConfigWarning 描述一条配置校验警告（非致命，--strict-config 时转为启动错误）
+type ConfigWarning struct {
+	Key      string   // 配置键，如 env.GOEXPERIMENT
+	Value    string   // 出问题的取值
+	Message  string   // 问题说明
+	Accepted []string // 可接受的取值（可能为空）
+}
+
+// String 返回单行描述，便于拼接错误信息
+func (w ConfigWarning) String() string {
+	s := fmt.Sprintf("%s: %s", w.Key, w.Message)
+	if len(w.Accepted) > 0 {
+		s += " (accepted: " + strings.Join(w.Accepted, ", ") + ")"
+	}
+	return s
+}
+
+var (
+	validCGOEnabled = []string{"0", "1"}
+	validGoAMD64    = []string{"v1", "v2", "v3", "v4"}
+	validGoFlagsMod = []string{"readonly", "vendor", "mod"}
+	validBuildVCS   = []string{"true", "false", "auto"}
+)
+
+// goFlagsBool 是 GOFLAGS 中可用的布尔 go 命令标志
+var goFlagsBool = []string{
+	"a", "asan", "cover", "failfast", "json", "linkshared", "modcacherw",
+	"msan", "n", "race", "short", "trimpath", "v", "work", "x",
+}
+
+// goFlagsValue 是 GOFLAGS 中需要以 -flag=value 形式给出取值的 go 命令标志
+var goFlagsValue = []string{
+	"asmflags", "buildmode", "buildvcs", "compiler", "count", "covermode", "coverpkg",
+	"gccgoflags", "gcflags", "installsuffix", "ldflags", "mod", "modfile", "overlay",
+	"p", "parallel", "pgo", "pkgdir", "shuffle", "tags", "timeout", "toolexec", "vet",
+}
+
+// envKey 返回 env 段中某个变量的完整配置键
+func envKey(name string) string {
+	return "env." + name
+}
+
+// validateGoExperimentKey 检查 GOEXPERIMENT 中的每个实验名（允许 no 前缀）
+func validateGoExperimentKey(value string) []ConfigWarning {
+	invalid := ValidateGoExperiment(value)
+	if len(invalid) == 0 {
+		return nil
+	}
+	accepted := make([]string, 0)
+	for name := range GetAvailableGoExperiments() {
+		accepted = append(accepted, name)
+	}
+	sort.Strings(accepted)
+	warnings := make([]ConfigWarning, 0, len(invalid))
+	for _, exp := range invalid {
+		warnings = append(warnings, ConfigWarning{
+			Key:      envKey("GOEXPERIMENT"),
+			Value:    exp,
+			Message:  fmt.Sprintf("unknown experiment %q", exp),
+			Accepted: accepted,
+		})
+	}
+	return warnings
+}
+
+// validateOSArchKeys 检查 GOOS/GOARCH 组合是否被当前工具链支持
+func validateOSArchKeys(goos, goarch string) []ConfigWarning {
+	if goos == "" || goarch == "" || IsValidOSArch(goos, goarch) {
+		return nil
+	}
+	combos := GetValidOSArchCombinations()
+	if archs, ok := combos[goos]; ok {
+		sort.Strings(archs)
+		return []ConfigWarning{{
+			Key:      envKey("GOARCH"),
+			Value:    goarch,
+			Message:  fmt.Sprintf("unsupported GOOS/GOARCH combination %s/%s", goos, goarch),
+			Accepted: archs,
+		}}
+	}
+	oses := make([]string, 0, len(combos))
+	for name := range combos {
+		oses = append(oses, name)
+	}
+	sort.Strings(oses)
+	return []ConfigWarning{{
+		Key:      envKey("GOOS"),
+		Value:    goos,
+		Message:  fmt.Sprintf("unknown GOOS %q", goos),
+		Accepted: oses,
+	}}
+}
+
+// validateEnumKey 检查取值是否属于固定集合，空值表示使用 go 的默认值
+func validateEnumKey(name, value string, accepted []string) []ConfigWarning {
+	if value == "" || slices.Contains(accepted, value) {
+		return nil
+	}
+	return []ConfigWarning{{
+		Key:      envKey(name),
+		Value:    value,
+		Message:  fmt.Sprintf("invalid value %q", value),
+		Accepted: accepted,
+	}}
+}
+
+// validateGoFlagsKey 按 go 命令解析 GOFLAGS 的规则检查每一项：
+//   - 以空格分隔，每项必须是 -flag 或 -flag=value（取值不能用空格分开）
+//   - flag 必须是 go 命令认识的标志；布尔标志的取值必须能解析为 bool
+func validateGoFlagsKey(value string) []ConfigWarning {
+	var warnings []ConfigWarning
+	warn := func(entry, msg string, accepted []string) {
+		warnings = append(warnings, ConfigWarning{Key: envKey("GOFLAGS"), Value: entry, Message: msg, Accepted: accepted})
+	}
+	for _, entry := range strings.Fields(value) {
+		if !strings.HasPrefix(entry, "-") {
+			warn(entry, fmt.Sprintf("non-flag %q (values must be written as -flag=value)", entry), nil)
+			continue
+		}
+		name, val, hasValue := strings.Cut(strings.TrimLeft(entry, "-"), "=")
+		switch {
+		case name == "buildvcs":
+			// 单独的 -buildvcs 等同于 -buildvcs=true
+			if hasValue && !slices.Contains(validBuildVCS, val) {
+				warn(entry, fmt.Sprintf("invalid value %q for -buildvcs", val), validBuildVCS)
+			}
+		case slices.Contains(goFlagsBool, name):
+			if hasValue {
+				if _, err := strconv.ParseBool(val); err != nil {
+					warn(entry, fmt.Sprintf("invalid boolean value %q for -%s", val, name), []string{"-" + name, "-" + name + "=true", "-" + name + "=false"})
+				}
+			}
+		case slices.Contains(goFlagsValue, name):
+			if !hasValue || val == "" {
+				warn(entry, fmt.Sprintf("-%s requires a value (-%s=value)", name, name), nil)
+			} else if name == "mod" && !slices.Contains(validGoFlagsMod, val) {
+				warn(entry, fmt.Sprintf("invalid value %q for -mod", val), validGoFlagsMod)
+			}
+		default:
+			warn(entry, fmt.Sprintf("unknown go flag -%s", name), knownGoFlags())
+		}
+	}
+	return warnings
+}
+
+// knownGoFlags 返回 GOFLAGS 中可用的全部标志（带 - 前缀，已排序）
+func knownGoFlags() []string {
+	out := make([]string, 0, len(goFlagsBool)+len(goFlagsValue))
+	for _, f := range goFlagsBool {
+		out = append(out, "-"+f)
+	}
+	for _, f := range goFlagsValue {
+		out = append(out, "-"+f)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,107 @@
+package configs
+
+import (
+	"slices"
+	"testing"
+)
+
+// 测试 GOFLAGS 校验：未知标志、非 flag 项、布尔取值与必须带值的标志
+func TestValidateGoFlagsKey(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string // 产生警告的项
+	}{
+		{"", nil},
+		{"-race -trimpath -mod=readonly", nil},
+		{"-race=true --count=1 -tags=integration,e2e", nil},
+		{"-race=yes", []string{"-race=yes"}},
+		{"-tags foo", []string{"-tags", "foo"}},
+		{"-mod=bad", []string{"-mod=bad"}},
+		{"-rce", []string{"-rce"}},
+		{"-buildvcs -buildvcs=auto", nil},
+		{"-buildvcs=yes", []string{"-buildvcs=yes"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, w := range validateGoFlagsKey(tt.value) {
+			if w.Key != "env.GOFLAGS" {
+				t.Errorf("%q: unexpected key %s", tt.value, w.Key)
+			}
+			got = append(got, w.Value)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("validateGoFlagsKey(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+// 测试固定取值集合的校验（CGO_ENABLED、GOAMD64）
+func TestValidateEnumKey(t *testing.T) {
+	tests := []struct {
+		name, value string
+		accepted    []string
+		wantWarn    bool
+	}{
+		{"CGO_ENABLED", "", validCGOEnabled, false},
+		{"CGO_ENABLED", "0", validCGOEnabled, false},
+		{"CGO_ENABLED", "true", validCGOEnabled, true},
+		{"GOAMD64", "v3", validGoAMD64, false},
+		{"GOAMD64", "v5", validGoAMD64, true},
+	}
+	for _, tt := range tests {
+		got := validateEnumKey(tt.name, tt.value, tt.accepted)
+		if (len(got) > 0) != tt.wantWarn {
+			t.Errorf("validateEnumKey(%s=%q) = %v, want warning=%v", tt.name, tt.value, got, tt.wantWarn)
+			continue
+		}
+		if tt.wantWarn && (got[0].Key != "env."+tt.name || !slices.Equal(got[0].Accepted, tt.accepted)) {
+			t.Errorf("unexpected warning %+v", got[0])
+		}
+	}
+}
+
+// 测试 GOOS/GOARCH 组合校验：已知 GOOS 时给出可用架构，未知 GOOS 时给出可用系统
+func TestValidateOSArchKeys(t *testing.T) {
+	tests := []struct {
+		goos, goarch string
+		wantKey      string
+	}{
+		{"linux", "amd64", ""},
+		{"", "amd64", ""},
+		{"darwin", "386", "env.GOARCH"},
+		{"linx", "amd64", "env.GOOS"},
+	}
+	for _, tt := range tests {
+		got := validateOSArchKeys(tt.goos, tt.goarch)
+		if tt.wantKey == "" {
+			if len(got) != 0 {
+				t.Errorf("%s/%s: unexpected warnings %v", tt.goos, tt.goarch, got)
+			}
+			continue
+		}
+		if len(got) != 1 || got[0].Key != tt.wantKey || len(got[0].Accepted) == 0 {
+			t.Errorf("%s/%s: got %+v, want one %s warning with accepted values", tt.goos, tt.goarch, got, tt.wantKey)
+		}
+	}
+}
+
+// 测试 GOEXPERIMENT 校验（支持 no 前缀）
+func TestValidateGoExperimentKey(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"", nil},
+		{"fieldtrack,nofieldtrack", nil},
+		{"fieldtrack, rangefnc", []string{"rangefnc"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, w := range validateGoExperimentKey(tt.value) {
+			got = append(got, w.Value)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("validateGoExperimentKey(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package configs
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// 测试从工具链的 goexperiment.Flags 读取实验名与说明，以及 GOEXPERIMENT 的 no 前缀
+func TestParseGoExperimentFlags(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "flags.go")
+	src := `package goexperiment
+
+type Flags struct {
+	// FieldTrack enables field tracking.
+	// Second line is ignored.
+	FieldTrack bool
+	RegabiArgs, RegabiWrappers bool
+	Count int
+}
+`
+	if err := os.WriteFile(file, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got := parseGoExperimentFlags(file)
+	want := map[string]string{"fieldtrack": "FieldTrack enables field tracking.", "regabiargs": "", "regabiwrappers": ""}
+	if len(got) != len(want) {
+		t.Errorf("parseGoExperimentFlags = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %q, want %q", k, got[k], v)
+		}
+	}
+	if parseGoExperimentFlags(filepath.Join(t.TempDir(), "missing.go")) != nil {
+		t.Errorf("missing file should yield nil")
+	}
+
+	// 设置 GOCLI_GOENV 指向不存在的 GOROOT，回退到内置列表
+	env := filepath.Join(t.TempDir(), "go.env")
+	if err := os.WriteFile(env, []byte("GOROOT="+t.TempDir()+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(goEnvOverride, env)
+	RefreshGoEnv()
+	t.Cleanup(RefreshGoEnv)
+	if invalid := ValidateGoExperiment("fieldtrack,noarenas,bogus"); !slices.Equal(invalid, []string{"bogus"}) {
+		t.Errorf("ValidateGoExperiment invalid = %v, want [bogus]", invalid)
+	}
+}
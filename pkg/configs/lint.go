@@ -0,0 +1,15 @@
+package configs
+
+import (
+	"github.com/spf13/viper"
+)
+
+// LintConfig 定义 `project lint` / `project fmt` 调用 golangci-lint 的行为
+type LintConfig struct {
+	// AutoInstall 为 true 时 golangci-lint 缺失会直接安装到 tools.gocli_tools_path，不再询问
+	AutoInstall bool `mapstructure:"auto_install" jsonschema:"title=Auto Install,description=Install golangci-lint into tools.gocli_tools_path without prompting when it is not found in PATH"`
+}
+
+func setLintConfigDefaults() {
+	viper.SetDefault("lint.auto_install", false)
+}
@@ -0,0 +1,129 @@
+package configs
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// 路径字段通过结构体标签 `gocli:"path"` 标记，加载配置后由 normalizePaths 统一处理：
+//   - 展开 ~ 与 $VAR / ${VAR} 环境变量
+//   - 配置文件中写出的相对路径基于该文件所在目录解析；默认值与环境变量中的相对路径保持不变（相对当前工作目录）
+//
+// `gocli:"output"` 用于输出目标（如 doc.output），在 path 的基础上保留 -、stderr、clipboard: 等特殊取值以及末尾的 :append
+const (
+	pathTagKey    = "gocli"
+	pathTagPath   = "path"
+	pathTagOutput = "output"
+)
+
+// outputSpecialTargets 是输出目标中不代表文件路径的取值
+var outputSpecialTargets = []string{"-", "stderr", "clipboard:"}
+
+// ExpandPath 展开 p 中的 ~ 与环境变量；结果仍为相对路径且 base 非空时基于 base 解析为绝对路径。
+// 路径末尾的分隔符会被保留（例如 "docs/" 仍表示目录）
+func ExpandPath(p, base string) string {
+	if p == "" {
+		return p
+	}
+	if p == "~" || strings.HasPrefix(p, "~/") || strings.HasPrefix(p, `~\`) {
+		if h := home(); h != "" {
+			p = h + p[1:]
+		}
+	}
+	p = os.ExpandEnv(p)
+	trailing := strings.HasSuffix(p, "/") || strings.HasSuffix(p, `\`)
+	p = filepath.FromSlash(p)
+	if !filepath.IsAbs(p) && base != "" {
+		p = filepath.Join(base, p)
+	} else {
+		p = filepath.Clean(p)
+	}
+	if trailing && !strings.HasSuffix(p, string(filepath.Separator)) {
+		p += string(filepath.Separator)
+	}
+	return p
+}
+
+// expandOutputPath 与 ExpandPath 相同，但保留特殊输出目标与 :append 后缀
+func expandOutputPath(p, base string) string {
+	for _, special := range outputSpecialTargets {
+		if p == special {
+			return p
+		}
+	}
+	if path, ok := strings.CutSuffix(p, ":append"); ok && path != "" {
+		return ExpandPath(path, base) + ":append"
+	}
+	return ExpandPath(p, base)
+}
+
+// normalizePaths 处理 cfg 中所有带 gocli:"path" / gocli:"output" 标签的 string 与 []string 字段；
+// 只有 inConfig 报告为配置文件中设置的键（如 "app.hotload.dir"）才基于 base 解析相对路径
+func normalizePaths(cfg *Config, base string, inConfig func(key string) bool) {
+	walkPathFields(reflect.ValueOf(cfg).Elem(), "", base, inConfig)
+}
+
+// walkPathFields 递归遍历结构体字段，展开并解析带路径标签的字段；prefix 是结构体对应的配置键前缀
+func walkPathFields(v reflect.Value, prefix, base string, inConfig func(key string) bool) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field, fv := t.Field(i), v.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key := configKey(prefix, field)
+		if fv.Kind() == reflect.Struct {
+			walkPathFields(fv, key, base, inConfig)
+			continue
+		}
+		expand := ExpandPath
+		switch field.Tag.Get(pathTagKey) {
+		case pathTagPath:
+		case pathTagOutput:
+			expand = expandOutputPath
+		default:
+			continue
+		}
+		fieldBase := base
+		if inConfig == nil || !inConfig(key) {
+			fieldBase = ""
+		}
+		switch {
+		case fv.Kind() == reflect.String:
+			fv.SetString(expand(fv.String(), fieldBase))
+		case fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.String:
+			for j := range fv.Len() {
+				fv.Index(j).SetString(expand(fv.Index(j).String(), fieldBase))
+			}
+		}
+	}
+}
+
+// configKey 按 mapstructure 标签拼出字段的配置键；squash 的嵌入结构体沿用 prefix
+func configKey(prefix string, field reflect.StructField) string {
+	name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+	if strings.Contains(opts, "squash") {
+		return prefix
+	}
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// pathBaseDir 返回解析配置文件中相对路径的基准目录，即配置文件所在目录；未加载配置文件时为空
+func pathBaseDir(cfgFile string) string {
+	if cfgFile == "" {
+		return ""
+	}
+	dir := filepath.Dir(cfgFile)
+	if abs, err := filepath.Abs(dir); err == nil {
+		return abs
+	}
+	return dir
+}
@@ -0,0 +1,95 @@
+package configs
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+var (
+	osArchCache     map[string][]string
+	loadOSArchsOnce sync.Once
+)
+
+// knownOSArchCombinations 是 `go tool dist list` 的静态快照，
+// 在无法调用 go 工具链时作为回退使用
+var knownOSArchCombinations = map[string][]string{
+	"aix":       {"ppc64"},
+	"android":   {"386", "amd64", "arm", "arm64"},
+	"darwin":    {"amd64", "arm64"},
+	"dragonfly": {"amd64"},
+	"freebsd":   {"386", "amd64", "arm", "arm64", "riscv64"},
+	"illumos":   {"amd64"},
+	"ios":       {"amd64", "arm64"},
+	"js":        {"wasm"},
+	"linux":     {"386", "amd64", "arm", "arm64", "loong64", "mips", "mips64", "mips64le", "mipsle", "ppc64", "ppc64le", "riscv64", "s390x"},
+	"netbsd":    {"386", "amd64", "arm", "arm64"},
+	"openbsd":   {"386", "amd64", "arm", "arm64", "ppc64", "riscv64"},
+	"plan9":     {"386", "amd64", "arm"},
+	"solaris":   {"amd64"},
+	"wasip1":    {"wasm"},
+	"windows":   {"386", "amd64", "arm", "arm64"},
+}
+
+// loadOSArchCombinations 通过 `go tool dist list` 获取当前工具链支持的平台并缓存，只执行一次
+func loadOSArchCombinations() {
+	loadOSArchsOnce.Do(func() {
+		output, err := executor.NewExecutor("go", "tool", "dist", "list").Output()
+		if err == nil {
+			if m := parseDistList(output); len(m) > 0 {
+				osArchCache = m
+				return
+			}
+		}
+		osArchCache = knownOSArchCombinations
+	})
+}
+
+// parseDistList 解析 `go tool dist list` 的输出（每行一个 GOOS/GOARCH）
+func parseDistList(output string) map[string][]string {
+	m := make(map[string][]string)
+	for line := range strings.SplitSeq(output, "\n") {
+		goos, goarch, ok := strings.Cut(strings.TrimSpace(line), "/")
+		if !ok || goos == "" || goarch == "" {
+			continue
+		}
+		m[goos] = append(m[goos], goarch)
+	}
+	return m
+}
+
+// GetValidOSArchCombinations 返回当前 Go 工具链支持的 GOOS -> GOARCH 列表
+// 优先使用 `go tool dist list` 的结果，失败时回退到内置表；返回值为副本，可安全修改
+func GetValidOSArchCombinations() map[string][]string {
+	loadOSArchCombinations()
+	out := make(map[string][]string, len(osArchCache))
+	for goos, archs := range osArchCache {
+		out[goos] = slices.Clone(archs)
+	}
+	return out
+}
+
+// IsValidOSArch 检查 GOOS/GOARCH 组合是否被当前 Go 工具链支持；
+// 先查内置表，只有不在表中时才调用 `go tool dist list`，避免每次启动校验配置都运行 go 工具链
+func IsValidOSArch(goos, goarch string) bool {
+	if slices.Contains(knownOSArchCombinations[goos], goarch) {
+		return true
+	}
+	loadOSArchCombinations()
+	return slices.Contains(osArchCache[goos], goarch)
+}
+
+// ValidOSArchList 返回排序后的 "GOOS/GOARCH" 列表，便于在错误提示中展示
+func ValidOSArchList() []string {
+	loadOSArchCombinations()
+	var list []string
+	for _, goos := range slices.Sorted(maps.Keys(osArchCache)) {
+		for _, goarch := range osArchCache[goos] {
+			list = append(list, goos+"/"+goarch)
+		}
+	}
+	return list
+}
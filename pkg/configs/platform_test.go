@@ -0,0 +1,33 @@
+package configs
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// 测试解析 go tool dist list 的输出，以及按当前工具链判断 GOOS/GOARCH 组合
+func TestParseDistList(t *testing.T) {
+	m := parseDistList("linux/amd64\nlinux/arm64\n\njs/wasm\nbogus\n/amd64\n")
+	if !slices.Equal(m["linux"], []string{"amd64", "arm64"}) || !slices.Equal(m["js"], []string{"wasm"}) || len(m) != 2 {
+		t.Errorf("parseDistList = %v", m)
+	}
+
+	if !IsValidOSArch("linux", "amd64") || IsValidOSArch("darwin", "mips") || IsValidOSArch("nosuchos", "amd64") {
+		t.Errorf("IsValidOSArch mismatch")
+	}
+	list := ValidOSArchList()
+	if !slices.Contains(list, "windows/amd64") {
+		t.Errorf("ValidOSArchList = %v", list)
+	}
+	for i := 1; i < len(list); i++ {
+		if prev, cur := strings.Split(list[i-1], "/")[0], strings.Split(list[i], "/")[0]; prev > cur {
+			t.Errorf("ValidOSArchList is not sorted by GOOS: %s before %s", list[i-1], list[i])
+		}
+	}
+	// 返回值是副本，修改不影响缓存
+	GetValidOSArchCombinations()["linux"] = nil
+	if !IsValidOSArch("linux", "amd64") {
+		t.Errorf("GetValidOSArchCombinations should return a copy")
+	}
+}
@@ -0,0 +1,17 @@
+package configs
+
+import (
+	"github.com/spf13/viper"
+)
+
+// RunConfig 定义 `project run` 启动子进程时的行为
+type RunConfig struct {
+	// EnvFiles 启动程序前按顺序加载的 .env 文件（相对工作目录），后者覆盖前者；不存在的文件会被忽略
+	EnvFiles []string `mapstructure:"env_files" jsonschema:"title=EnvFiles,description=Dotenv files loaded (in order; later files win) into the environment of the executed program; missing files are skipped"`
+	// CheckPorts 启动程序前检查的 TCP 端口，被占用时报告占用的进程并中止（热重载时每次重启前都会检查）
+	CheckPorts []int `mapstructure:"check_ports" jsonschema:"title=CheckPorts,description=TCP ports that must be free before the program starts; when one is taken gocli reports the owning process and aborts (checked again before every hot reload restart)"`
+}
+
+func setRunConfigDefaults() {
+	viper.SetDefault("run.env_files", []string{".env", ".env.local"})
+}
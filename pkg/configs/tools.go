@@ -12,9 +12,17 @@ type ToolsConfig struct {
 	Global []Tool `mapstructure:"global,omitempty" jsonschema:"title=Global,description=Global (user-wide) tools list,uniqueItems"`   // 全局工具
 
 	// Go CLI 工具路径
-	GoCLIToolsPath string `mapstructure:"path,omitempty" jsonschema:"title=Path,description=Root directory storing installed tools (may include env vars)"`
+	GoCLIToolsPath string `mapstructure:"path,omitempty" gocli:"path" jsonschema:"title=Path,description=Root directory storing installed tools; ~ and $VAR/${VAR} are expanded and relative paths set in a config file resolve against its directory (defaults against the working directory)"`
 	// 指定可用于解析为 map[string]InstallToolsInfo 配置目录，例如 ~/.gocli/tools.json
-	ToolsConfigDir []string `mapstructure:"tools_config_dir,omitempty" jsonschema:"title=ToolsConfigDir,description=Directory containing tool definitions"`
+	ToolsConfigDir []string `mapstructure:"tools_config_dir,omitempty" gocli:"path" jsonschema:"title=ToolsConfigDir,description=Directory containing tool definitions; ~ and $VAR/${VAR} are expanded and relative paths set in a config file resolve against its directory (defaults against the working directory)"`
+	// clone/build/go install 等外部命令的超时时间（秒），0 表示不限制
+	Timeout int `mapstructure:"timeout" jsonschema:"title=Timeout,description=Timeout in seconds for git clone/make/goreleaser/go install commands (0 disables),minimum=0"`
+	// History 是否将 tools run 的执行记录追加到 ~/.gocli/history.jsonl
+	History bool `mapstructure:"history" jsonschema:"title=History,description=Record tools run executions in ~/.gocli/history.jsonl (default true)"`
+	// Offline 只使用本机模块缓存安装工具，不访问模块代理与校验和数据库（可先在联网时执行 gocli tools prefetch）
+	Offline bool `mapstructure:"offline" jsonschema:"title=Offline,description=Install tools only from the local module cache without network access (populate it with gocli tools prefetch)"`
+	// Groups 命名的工具分组（分组名 -> 工具名列表），tools install/list --group 只处理所选分组的并集
+	Groups map[string][]string `mapstructure:"groups,omitempty" jsonschema:"title=Groups,description=Named tool groups (group name to tool names) used by tools install/list --group; names resolve like tools install <name> against tools.deps/tools.global and the tools table,nullable"`
 }
 
 // Tool represents a single tool configuration.
@@ -43,6 +51,8 @@ type Tool struct {
 	GoreleaserConfig string `mapstructure:"goreleaser_config,omitempty" jsonschema:"title=GoreleaserConfig,description=Path to goreleaser config file,nullable"`
 	// 输出二进制文件名（可选）
 	BinaryName string `mapstructure:"binary_name,omitempty" jsonschema:"title=BinaryName,description=Override output binary name,nullable"`
+	// 安装后二进制期望的 sha256（可选），安装时校验，tools verify 以它检测变化
+	SHA256 string `mapstructure:"sha256,omitempty" jsonschema:"title=SHA256,description=Expected sha256 of the installed binary; checked after install and by tools verify,nullable"`
 	// 是否递归克隆子模块
 	RecurseSubmodules bool `mapstructure:"recurse_submodules,omitempty" jsonschema:"title=RecurseSubmodules,description=Clone git submodules recursively"`
 	// 预设构建模式
@@ -50,11 +60,15 @@ type Tool struct {
 	DebugBuild   bool `mapstructure:"debug_build,omitempty" jsonschema:"title=DebugBuild,description=Enable debug build mode (extra symbols, no optimizations)"`
 	// 构建标签，用于 go install 的 -tags 参数
 	Tags []string `mapstructure:"tags,omitempty" jsonschema:"title=Tags,description=Build tags to pass to go install,uniqueItems,nullable"`
+	// 安装后的冒烟测试参数或完整命令，为空时依次尝试 --version、version、-h，"none" 关闭
+	Check string `mapstructure:"check,omitempty" jsonschema:"title=Check,description=Post-install smoke test: arguments or full command run against the installed binary; empty tries --version then version then -h; none disables,nullable"`
 }
 
 func setToolsConfigDefaults() {
 	viper.SetDefault("tools.path", home()+"/.gocli/tools")
 	viper.SetDefault("tools.tools_config_dir", []string{home() + "/.gocli/tools.json"})
+	viper.SetDefault("tools.timeout", 1800)
+	viper.SetDefault("tools.history", true)
 }
 
 func home() string {
@@ -0,0 +1,7 @@
+package configs
+
+// UpdateConfig 定义 `project update` 的行为
+type UpdateConfig struct {
+	// Exclude 更新时保持原版本不变的模块路径
+	Exclude []string `mapstructure:"exclude" jsonschema:"title=Exclude,description=Module paths that project update keeps at their current version,nullable"`
+}
@@ -4,24 +4,42 @@ package context
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
 
 	"github.com/spf13/viper"
 	"github.com/yeisme/gocli/pkg/configs"
+	"github.com/yeisme/gocli/pkg/style"
+	"github.com/yeisme/gocli/pkg/utils/executor"
 	"github.com/yeisme/gocli/pkg/utils/log"
 )
 
+// ErrCancelled is the cause of GocliContext.Context once gocli receives SIGINT (Ctrl+C) or SIGTERM.
+var ErrCancelled = errors.New("cancelled")
+
 // GocliContext represents the context for the gocli application.
 type GocliContext struct {
+	// Context is cancelled (with cause ErrCancelled) on the first SIGINT/SIGTERM; long operations check it
+	// to stop early, and commands run through the executor are interrupted. A second signal exits at once.
 	Context context.Context
 	Config  *configs.Config // 应用配置
 	Logger  log.Logger      // 日志记录器
 	Viper   *viper.Viper
+	// OutputFormat 全局 --output-format，由支持结构化输出的命令读取（命令自身的格式标志优先）
+	OutputFormat style.OutputFormat
 }
 
 // GlobalFlags holds the global flags for the application
 type GlobalFlags struct {
 	// ConfigPath is the path to the config file
 	ConfigPath string
+	// ConfigDirs are searched for a config file before the default search paths
+	ConfigDirs []string
 	// Debug enables debug mode
 	Debug bool
 	// Verbose enables verbose output
@@ -34,32 +52,138 @@ type GlobalFlags struct {
 	Trace string
 	// VersionEnable enables version output
 	VersionEnable bool
+	// NoPager disables paging of long output
+	NoPager bool
+	// StrictConfig turns configuration validation warnings into a startup error
+	StrictConfig bool
+	// OutputFormat selects json|yaml|table|plain output for commands with structured data
+	OutputFormat string
+	// LogFormat selects console|json for gocli's own logs on stderr (overrides log.json)
+	LogFormat string
+	// LogLevel selects trace|debug|info|warn|error for gocli's own logs; it wins over the level
+	// implied by Quiet/Debug/Verbose (see InitGocliContext for the full resolution order)
+	LogLevel string
 }
 
-// InitGocliContext initializes the GocliContext with the provided configuration path.
-func InitGocliContext(configPath string, debug, verbose, quiet bool) *GocliContext {
-	ctx := context.Background()
-	config, err := configs.LoadConfig(configPath)
+// InitGocliContext initializes the GocliContext from the global flags.
+// An explicitly specified flags.ConfigPath must exist; an empty path falls back to the implicit search,
+// which looks in flags.ConfigDirs before the default search paths.
+// flags.LogFormat (console|json, empty keeps log.json) selects the format of the logs written to stderr.
+// flags.LogLevel (trace|debug|info|warn|error) selects the log level; the level is resolved in this order:
+//  1. LogLevel (--log-level), when set
+//  2. Quiet (--quiet): no logs at all
+//  3. Debug (--debug): debug
+//  4. Verbose (--verbose): info
+//  5. log.level from the config file (default info)
+//
+// LogLevel only changes the log level: quiet still suppresses other output and debug/verbose keep their
+// effect on command output, so `gocli -V --log-level trace` gives verbose output with trace logs.
+// The env section is validated after the logger is ready: findings are logged as warnings,
+// or returned as an error when flags.StrictConfig is set.
+// Flags that only concern the command line (profiling, output format, pager, version) are ignored.
+func InitGocliContext(flags GlobalFlags) (*GocliContext, error) {
+	configs.SetConfigDirs(flags.ConfigDirs...)
+	config, err := configs.LoadConfig(flags.ConfigPath)
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(flags.LogFormat)) {
+	case "":
+	case "json":
+		config.Log.JSON = true
+	case "console", "text":
+		config.Log.JSON = false
+	default:
+		return nil, fmt.Errorf("invalid --log-format %q (want console or json)", flags.LogFormat)
+	}
+
+	switch level := strings.ToLower(strings.TrimSpace(flags.LogLevel)); level {
+	case "":
+	case "trace", "debug", "info", "warn", "error":
+		config.Log.LevelOverride = level
+	default:
+		return nil, fmt.Errorf("invalid --log-level %q (want trace, debug, info, warn or error)", flags.LogLevel)
 	}
 
-	if debug {
-		config.App.Debug = debug
+	if flags.Debug {
+		config.App.Debug = true
 	}
-	if verbose {
-		config.App.Verbose = verbose
+	if flags.Verbose {
+		config.App.Verbose = true
 	}
-	if quiet {
-		config.App.Quiet = quiet
+	if flags.Quiet {
+		config.App.Quiet = true
 	}
 
-	logger := log.InitLogger(ctx, &config.Log, &config.App)
+	logger := log.InitLogger(context.Background(), &config.Log, &config.App)
+	ctx := interruptContext(logger)
+	executor.SetBaseContext(ctx)
+
+	if err := reportConfigWarnings(logger, config.Env.Validate(), flags.StrictConfig); err != nil {
+		return nil, err
+	}
 
 	return &GocliContext{
 		Context: ctx,
 		Config:  config,
 		Logger:  logger,
 		Viper:   configs.GetViperInstance(),
+	}, nil
+}
+
+var (
+	interruptOnce sync.Once
+	interruptCtx  context.Context
+)
+
+// interruptContext returns the process-wide context cancelled with ErrCancelled on the first SIGINT/SIGTERM.
+// Code paths that do not watch the context keep running, so the first signal also prints how to force it:
+// a second signal exits immediately with status 130.
+func interruptContext(logger log.Logger) context.Context {
+	interruptOnce.Do(func() {
+		ctx, cancel := context.WithCancelCause(context.Background())
+		interruptCtx = ctx
+		sigCh := make(chan os.Signal, 2)
+		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-sigCh
+			logger.Warn().Msg("interrupted: cancelling (press Ctrl+C again to force exit)")
+			cancel(ErrCancelled)
+			<-sigCh
+			os.Exit(130)
+		}()
+	})
+	return interruptCtx
+}
+
+// Ctx returns c.Context, or context.Background() when c or its Context is nil (e.g. in tests).
+func (c *GocliContext) Ctx() context.Context {
+	if c == nil || c.Context == nil {
+		return context.Background()
+	}
+	return c.Context
+}
+
+// reportConfigWarnings logs each validation finding with its config key and accepted values.
+// In strict mode the findings are returned as a single error instead.
+func reportConfigWarnings(logger log.Logger, warnings []configs.ConfigWarning, strict bool) error {
+	if len(warnings) == 0 {
+		return nil
+	}
+	if strict {
+		lines := make([]string, 0, len(warnings))
+		for _, w := range warnings {
+			lines = append(lines, "  - "+w.String())
+		}
+		return fmt.Errorf("invalid configuration (--strict-config):\n%s", strings.Join(lines, "\n"))
+	}
+	for _, w := range warnings {
+		ev := logger.Warn().Str("key", w.Key).Str("value", w.Value)
+		if len(w.Accepted) > 0 {
+			ev = ev.Strs("accepted", w.Accepted)
+		}
+		ev.Msg("config: " + w.Message)
 	}
+	return nil
 }
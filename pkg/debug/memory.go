@@ -1 +1,182 @@
 package debug
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/style"
+)
+
+// MemRuntimeOptions 控制从运行中的 Go 进程读取 runtime.MemStats
+type MemRuntimeOptions struct {
+	PID   int           // 目标进程：在其监听端口上查找 /debug/vars 或 /debug/pprof/heap
+	URL   string        // 目标进程的调试 HTTP 地址（host:port 或 URL）
+	Watch time.Duration // >0 时按间隔持续刷新，直到 ctx 取消
+	JSON  bool          // 以 JSON 输出
+}
+
+// MemSummary 是 runtime.MemStats 中与堆和 GC 相关的字段摘要
+type MemSummary struct {
+	Source       string `json:"source"`
+	HeapAlloc    uint64 `json:"heap_alloc"`
+	HeapSys      uint64 `json:"heap_sys"`
+	HeapInuse    uint64 `json:"heap_inuse"`
+	HeapObjects  uint64 `json:"heap_objects"`
+	Sys          uint64 `json:"sys"`
+	NextGC       uint64 `json:"next_gc"`
+	NumGC        uint64 `json:"num_gc"`
+	PauseTotalNs uint64 `json:"pause_total_ns"`
+}
+
+// RunMemRuntime 获取并输出目标进程的内存摘要；Watch>0 时每个间隔输出一次
+func RunMemRuntime(ctx context.Context, out io.Writer, opt MemRuntimeOptions) error {
+	base, err := resolveDebugURL(opt)
+	if err != nil {
+		return err
+	}
+	for {
+		s, err := FetchMemSummary(ctx, base)
+		if err != nil {
+			return err
+		}
+		if opt.JSON {
+			err = style.PrintJSON(out, s)
+		} else {
+			if opt.Watch > 0 {
+				fmt.Fprintf(out, "%s  %s\n", time.Now().Format("15:04:05"), s.Source)
+			}
+			err = PrintMemSummary(out, s)
+		}
+		if err != nil || opt.Watch <= 0 {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(opt.Watch):
+		}
+		fmt.Fprintln(out)
+	}
+}
+
+// resolveDebugURL 返回调试端点的基础 URL：--url 直接使用；--pid 时确认是 Go 程序并依次尝试其监听端口
+func resolveDebugURL(opt MemRuntimeOptions) (string, error) {
+	if opt.URL != "" {
+		return normalizeBaseURL(opt.URL), nil
+	}
+	if opt.PID == 0 {
+		return "", fmt.Errorf("either --pid or --url is required")
+	}
+	if _, err := checkGoProcess(opt.PID); err != nil {
+		return "", err
+	}
+	ports, err := listenPorts(opt.PID)
+	if err != nil {
+		return "", err
+	}
+	for _, p := range ports {
+		base := "http://127.0.0.1:" + strconv.Itoa(p)
+		if _, err := FetchMemSummary(context.Background(), base); err == nil {
+			return base, nil
+		}
+	}
+	return "", fmt.Errorf("process %d exposes no /debug/vars or /debug/pprof/heap endpoint (listening ports: %v); import expvar or net/http/pprof and serve HTTP, or use --url", opt.PID, ports)
+}
+
+// FetchMemSummary 先读取 expvar 的 /debug/vars，失败时解析 /debug/pprof/heap?debug=1 末尾的 runtime.MemStats 注释
+func FetchMemSummary(ctx context.Context, base string) (*MemSummary, error) {
+	varsURL := base + "/debug/vars"
+	data, varsErr := httpGet(ctx, varsURL)
+	if varsErr == nil {
+		var vars struct {
+			MemStats *struct {
+				HeapAlloc, HeapSys, HeapInuse, HeapObjects, Sys, NextGC, PauseTotalNs uint64
+				NumGC                                                                 uint32
+			} `json:"memstats"`
+		}
+		if err := json.Unmarshal(data, &vars); err == nil && vars.MemStats != nil {
+			m := vars.MemStats
+			return &MemSummary{
+				Source:    varsURL,
+				HeapAlloc: m.HeapAlloc, HeapSys: m.HeapSys, HeapInuse: m.HeapInuse, HeapObjects: m.HeapObjects,
+				Sys: m.Sys, NextGC: m.NextGC, NumGC: uint64(m.NumGC), PauseTotalNs: m.PauseTotalNs,
+			}, nil
+		}
+		varsErr = fmt.Errorf("%s has no memstats variable", varsURL)
+	}
+
+	heapURL := base + "/debug/pprof/heap?debug=1"
+	data, heapErr := httpGet(ctx, heapURL)
+	if heapErr == nil {
+		if s := parseHeapProfileMemStats(data); s != nil {
+			s.Source = heapURL
+			return s, nil
+		}
+		heapErr = fmt.Errorf("%s has no runtime.MemStats section", heapURL)
+	}
+	return nil, fmt.Errorf("no Go memory stats at %s (import expvar or net/http/pprof): %v; %v", base, varsErr, heapErr)
+}
+
+// parseHeapProfileMemStats 解析 heap 文本 profile 末尾的 "# runtime.MemStats" 段，例如 "# HeapAlloc = 123"
+func parseHeapProfileMemStats(data []byte) *MemSummary {
+	s := &MemSummary{}
+	found := false
+	fields := map[string]*uint64{
+		"HeapAlloc": &s.HeapAlloc, "HeapSys": &s.HeapSys, "HeapInuse": &s.HeapInuse, "HeapObjects": &s.HeapObjects,
+		"Sys": &s.Sys, "NextGC": &s.NextGC, "NumGC": &s.NumGC, "PauseTotalNs": &s.PauseTotalNs,
+	}
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for sc.Scan() {
+		name, value, ok := strings.Cut(strings.TrimPrefix(sc.Text(), "# "), " = ")
+		if !ok {
+			continue
+		}
+		if p, ok := fields[name]; ok {
+			if v, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64); err == nil {
+				*p = v
+				found = true
+			}
+		}
+	}
+	if !found {
+		return nil
+	}
+	return s
+}
+
+// PrintMemSummary 以表格输出内存摘要
+func PrintMemSummary(out io.Writer, s *MemSummary) error {
+	rows := [][]string{
+		{"Heap Alloc", formatBytes(s.HeapAlloc)},
+		{"Heap Sys", formatBytes(s.HeapSys)},
+		{"Heap In-use", formatBytes(s.HeapInuse)},
+		{"Heap Objects", strconv.FormatUint(s.HeapObjects, 10)},
+		{"Sys", formatBytes(s.Sys)},
+		{"Next GC", formatBytes(s.NextGC)},
+		{"GC Cycles", strconv.FormatUint(s.NumGC, 10)},
+		{"GC Pause Total", time.Duration(s.PauseTotalNs).String()},
+	}
+	return style.PrintTable(out, []string{"Field", "Value"}, rows, 0)
+}
+
+// formatBytes 以 1024 进制输出可读的字节数
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,162 @@
+package debug
+
+import (
+	"bufio"
+	"context"
+	"debug/buildinfo"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// httpTimeout 是访问目标进程调试端点的单次请求超时
+const httpTimeout = 10 * time.Second
+
+// procDir 返回 /proc/<pid>；--pid 依赖 Linux 的 /proc，其他平台提示改用 --url
+func procDir(pid int) (string, error) {
+	if runtime.GOOS != "linux" {
+		return "", fmt.Errorf("--pid requires /proc (Linux); use --url with the process's debug HTTP endpoint instead")
+	}
+	if pid <= 0 {
+		return "", fmt.Errorf("invalid pid %d", pid)
+	}
+	dir := filepath.Join("/proc", strconv.Itoa(pid))
+	if _, err := os.Stat(dir); err != nil {
+		return "", fmt.Errorf("process %d not found", pid)
+	}
+	return dir, nil
+}
+
+// checkGoProcess 读取进程可执行文件的 Go 构建信息，非 Go 程序返回明确的错误
+func checkGoProcess(pid int) (*buildinfo.BuildInfo, error) {
+	dir, err := procDir(pid)
+	if err != nil {
+		return nil, err
+	}
+	exe := filepath.Join(dir, "exe")
+	bi, err := buildinfo.ReadFile(exe)
+	if err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			return nil, fmt.Errorf("cannot inspect process %d: %w", pid, err)
+		}
+		target, _ := os.Readlink(exe)
+		return nil, fmt.Errorf("process %d (%s) is not a Go program: %w", pid, target, err)
+	}
+	return bi, nil
+}
+
+// processGone 判断进程是否已退出（/proc 条目消失或已成为僵尸进程）
+func processGone(pid int) bool {
+	data, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return true
+	}
+	// 格式：pid (comm) state ...，comm 中可能含空格，从最后一个 ')' 之后取状态
+	s := string(data)
+	if i := strings.LastIndexByte(s, ')'); i >= 0 && i+2 < len(s) {
+		return s[i+2] == 'Z' || s[i+2] == 'X'
+	}
+	return false
+}
+
+// parentPID 从 /proc/<pid>/stat 读取父进程 PID，读取失败时返回 0
+func parentPID(pid int) int {
+	data, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return 0
+	}
+	s := string(data)
+	i := strings.LastIndexByte(s, ')')
+	if i < 0 {
+		return 0
+	}
+	// ')' 之后依次是 state 与 ppid
+	fields := strings.Fields(s[i+1:])
+	if len(fields) < 2 {
+		return 0
+	}
+	ppid, _ := strconv.Atoi(fields[1])
+	return ppid
+}
+
+// listenPorts 返回进程正在监听的 TCP 端口（通过 /proc/<pid>/fd 中的 socket inode 匹配 /proc/<pid>/net/tcp{,6}）
+func listenPorts(pid int) ([]int, error) {
+	dir, err := procDir(pid)
+	if err != nil {
+		return nil, err
+	}
+	fds, err := os.ReadDir(filepath.Join(dir, "fd"))
+	if err != nil {
+		return nil, fmt.Errorf("cannot list sockets of process %d: %w", pid, err)
+	}
+	inodes := map[string]bool{}
+	for _, fd := range fds {
+		link, err := os.Readlink(filepath.Join(dir, "fd", fd.Name()))
+		if err == nil && strings.HasPrefix(link, "socket:[") {
+			inodes[strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]")] = true
+		}
+	}
+	seen := map[int]bool{}
+	var ports []int
+	for _, name := range []string{"tcp", "tcp6"} {
+		f, err := os.Open(filepath.Join(dir, "net", name))
+		if err != nil {
+			continue
+		}
+		sc := bufio.NewScanner(f)
+		for sc.Scan() {
+			fields := strings.Fields(sc.Text())
+			// sl local_address rem_address st tx_queue:rx_queue tr:tm->when retrnsmt uid timeout inode
+			if len(fields) < 10 || fields[3] != "0A" || !inodes[fields[9]] {
+				continue
+			}
+			_, portHex, ok := strings.Cut(fields[1], ":")
+			if !ok {
+				continue
+			}
+			if port, err := strconv.ParseInt(portHex, 16, 32); err == nil && !seen[int(port)] {
+				seen[int(port)] = true
+				ports = append(ports, int(port))
+			}
+		}
+		_ = f.Close()
+	}
+	sort.Ints(ports)
+	return ports, nil
+}
+
+// normalizeBaseURL 把 host:port 或完整 URL 规范化为不带结尾斜杠的基础 URL
+func normalizeBaseURL(u string) string {
+	u = strings.TrimSpace(u)
+	if !strings.Contains(u, "://") {
+		u = "http://" + u
+	}
+	return strings.TrimRight(u, "/")
+}
+
+// httpGet 请求 url 并返回响应体，非 2xx 状态视为错误
+func httpGet(ctx context.Context, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, httpTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
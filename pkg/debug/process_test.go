@@ -0,0 +1,119 @@
+package debug
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// startDebugServer 编译并启动 testdata/debugserver，stderr 重定向到文件，返回进程与监听地址
+func startDebugServer(t *testing.T) (*exec.Cmd, string) {
+	t.Helper()
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "debugserver")
+	if runtime.GOOS == "windows" {
+		bin += ".exe"
+	}
+	if out, err := exec.Command("go", "build", "-o", bin, "./testdata/debugserver").CombinedOutput(); err != nil {
+		t.Fatalf("build helper failed: %v\n%s", err, out)
+	}
+	stderr, err := os.Create(filepath.Join(dir, "stderr.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = stderr.Close() })
+	cmd := exec.Command(bin)
+	cmd.Stderr = stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+	addr, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Fatalf("read helper address: %v", err)
+	}
+	return cmd, strings.TrimSpace(addr)
+}
+
+// 测试通过 --url 读取 expvar 内存统计与 pprof goroutine 栈
+func TestRuntimeDebugByURL(t *testing.T) {
+	_, addr := startDebugServer(t)
+	s, err := FetchMemSummary(context.Background(), normalizeBaseURL(addr))
+	if err != nil {
+		t.Fatalf("FetchMemSummary: %v", err)
+	}
+	if s.HeapAlloc == 0 || s.Sys == 0 || !strings.HasSuffix(s.Source, "/debug/vars") {
+		t.Errorf("unexpected summary %+v", s)
+	}
+	var buf bytes.Buffer
+	if err := RunStack(context.Background(), &buf, StackOptions{URL: addr}); err != nil {
+		t.Fatalf("RunStack: %v", err)
+	}
+	if !strings.Contains(buf.String(), "goroutine ") {
+		t.Errorf("goroutine dump missing:\n%s", buf.String())
+	}
+}
+
+// 测试 --pid：通过监听端口找到调试端点；SIGQUIT 后从重定向的 stderr 中读取栈；非 Go 进程给出明确错误
+func TestRuntimeDebugByPID(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("--pid relies on /proc")
+	}
+	cmd, _ := startDebugServer(t)
+	pid := cmd.Process.Pid
+	base, err := resolveDebugURL(MemRuntimeOptions{PID: pid})
+	if err != nil {
+		t.Fatalf("resolveDebugURL: %v", err)
+	}
+	if _, err := FetchMemSummary(context.Background(), base); err != nil {
+		t.Fatalf("FetchMemSummary(%s): %v", base, err)
+	}
+
+	var buf bytes.Buffer
+	if err := RunStack(context.Background(), &buf, StackOptions{PID: pid}); err != nil {
+		t.Fatalf("RunStack: %v", err)
+	}
+	if out := buf.String(); !strings.Contains(out, "goroutine ") || !strings.Contains(out, "main.main") {
+		t.Errorf("unexpected dump:\n%s", out)
+	}
+
+	sleep := exec.Command("sleep", "10")
+	if err := sleep.Start(); err != nil {
+		t.Skip("sleep not available")
+	}
+	defer func() {
+		_ = sleep.Process.Kill()
+		_ = sleep.Wait()
+	}()
+	if _, err := checkGoProcess(sleep.Process.Pid); err == nil || !strings.Contains(err.Error(), "not a Go program") {
+		t.Errorf("expected non-Go error, got %v", err)
+	}
+}
+
+// 测试解析 heap profile 末尾的 runtime.MemStats 注释
+func TestParseHeapProfileMemStats(t *testing.T) {
+	data := []byte("heap profile: 1: 2 [3: 4] @ heap/1048576\n\n# runtime.MemStats\n# Alloc = 100\n# HeapAlloc = 2048\n# Sys = 4096\n# NumGC = 7\n# PauseTotalNs = 1500\n")
+	s := parseHeapProfileMemStats(data)
+	if s == nil || s.HeapAlloc != 2048 || s.Sys != 4096 || s.NumGC != 7 || s.PauseTotalNs != 1500 {
+		t.Errorf("unexpected summary %+v", s)
+	}
+	if parseHeapProfileMemStats([]byte("no stats here")) != nil {
+		t.Error("expected nil without a MemStats section")
+	}
+}
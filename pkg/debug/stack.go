@@ -0,0 +1,114 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// StackOptions 控制 debug stack 获取 goroutine 栈的方式
+type StackOptions struct {
+	PID     int           // 目标进程：发送 SIGQUIT，由 Go 运行时把全部 goroutine 栈写到其 stderr
+	URL     string        // 目标进程的 pprof HTTP 地址（host:port 或 URL），读取 /debug/pprof/goroutine?debug=2
+	Timeout time.Duration // --pid 时等待进程写完栈并退出的时间
+}
+
+// RunStack 按 URL 或 PID 获取 goroutine 栈并写到 out；两者都给出时优先使用 URL（不会终止进程）
+func RunStack(ctx context.Context, out io.Writer, opt StackOptions) error {
+	switch {
+	case opt.URL != "":
+		data, err := httpGet(ctx, normalizeBaseURL(opt.URL)+"/debug/pprof/goroutine?debug=2")
+		if err != nil {
+			return fmt.Errorf("fetch goroutine dump failed (does the program import net/http/pprof?): %w", err)
+		}
+		_, err = out.Write(data)
+		return err
+	case opt.PID != 0:
+		return quitAndCapture(opt.PID, opt.Timeout, out)
+	default:
+		return fmt.Errorf("either --pid or --url is required")
+	}
+}
+
+// quitAndCapture 向 Go 进程发送 SIGQUIT：Go 运行时会把全部 goroutine 栈写到 stderr 并以状态 2 退出
+//   - 由 project run 启动的进程（或 go run 编译出的子进程）：读取 project run 保存的 stderr 副本
+//   - stderr 重定向到普通文件时，读取信号之后追加的内容作为栈输出
+//   - stderr 是终端或管道时无法读取，提示栈输出所在的位置
+func quitAndCapture(pid int, timeout time.Duration, out io.Writer) error {
+	if _, err := checkGoProcess(pid); err != nil {
+		return err
+	}
+	stderrPath, captured := stderrCaptureOf(pid)
+	if !captured {
+		stderrPath, _ = os.Readlink(filepath.Join("/proc", strconv.Itoa(pid), "fd", "2"))
+	}
+	// 在发送信号前打开文件：project run 在子进程退出后会删除其 stderr 副本
+	var f *os.File
+	if fi, err := os.Stat(stderrPath); err == nil && fi.Mode().IsRegular() {
+		if f, err = os.Open(stderrPath); err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		if _, err := f.Seek(0, io.SeekEnd); err != nil {
+			return err
+		}
+	}
+
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	if err := proc.Signal(syscall.SIGQUIT); err != nil {
+		return fmt.Errorf("send SIGQUIT to process %d failed: %w", pid, err)
+	}
+	if f == nil {
+		fmt.Fprintf(out, "sent SIGQUIT to process %d; the goroutine dump was written to its stderr (%s)\n", pid, stderrPath)
+		return nil
+	}
+
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+	for !processGone(pid) && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	// stderr 副本经由管道写入，project run 读完子进程的全部输出后才删除它
+	for captured && time.Now().Before(deadline) {
+		if _, err := os.Stat(stderrPath); err != nil {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	// 副本超过大小上限时会被清空重写，此时从头读取
+	if pos, err := f.Seek(0, io.SeekCurrent); err == nil {
+		if fi, err := f.Stat(); err == nil && fi.Size() < pos {
+			_, _ = f.Seek(0, io.SeekStart)
+		}
+	}
+	n, err := io.Copy(out, f)
+	if err == nil && n == 0 {
+		return fmt.Errorf("process %d did not write a goroutine dump to %s (SIGQUIT may be handled by the program)", pid, stderrPath)
+	}
+	return err
+}
+
+// stderrCaptureOf 查找 project run 为 pid 保存的 stderr 副本；go run 编译出的程序是 go 命令的子进程，
+// 与其共享 stderr，因此也查找父进程的副本
+func stderrCaptureOf(pid int) (string, bool) {
+	for _, p := range []int{pid, parentPID(pid)} {
+		if p <= 0 {
+			continue
+		}
+		path := StderrCaptureFile(p)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+	return "", false
+}
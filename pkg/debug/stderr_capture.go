@@ -0,0 +1,102 @@
+package debug
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// stderrCaptureLimit 是 stderr 副本的大小上限：超过时清空文件重新写入，
+// 长时间运行、输出很多的服务只保留最近的输出，足以容纳一次 goroutine 栈
+const stderrCaptureLimit = 1 << 20
+
+// stderrCaptureStaleAge 之后仍未按 PID 命名的副本视为残留（gocli 被强制终止时留下）
+const stderrCaptureStaleAge = time.Hour
+
+// StderrCaptureFile 返回 project run 为进程 pid 保存的 stderr 副本路径；
+// debug stack --pid 发送 SIGQUIT 后从这里读取 goroutine 栈
+func StderrCaptureFile(pid int) string {
+	return filepath.Join(os.TempDir(), "gocli-stderr", strconv.Itoa(pid)+".log")
+}
+
+// StderrCapture 把子进程的 stderr 同时写入临时文件：进程启动后通过 Started 按 PID 命名，
+// 进程结束后 Close 删除文件。文件最多保留 stderrCaptureLimit 字节；写入失败不会影响子进程输出的正常展示
+type StderrCapture struct {
+	mu   sync.Mutex
+	f    *os.File
+	path string
+	size int64
+}
+
+// NewStderrCapture 在 StderrCaptureFile 所在目录下创建尚未命名的副本文件，并清理已退出进程留下的副本
+func NewStderrCapture() (*StderrCapture, error) {
+	dir := filepath.Dir(StderrCaptureFile(0))
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	removeStaleCaptures(dir)
+	f, err := os.CreateTemp(dir, "start-*.log")
+	if err != nil {
+		return nil, err
+	}
+	return &StderrCapture{f: f, path: f.Name()}, nil
+}
+
+// removeStaleCaptures 删除进程已不存在的 <pid>.log 以及超过 stderrCaptureStaleAge 的未命名副本
+func removeStaleCaptures(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		name := e.Name()
+		stale := false
+		if pid, err := strconv.Atoi(strings.TrimSuffix(name, ".log")); err == nil {
+			stale = processGone(pid)
+		} else if info, err := e.Info(); err == nil && strings.HasPrefix(name, "start-") {
+			stale = time.Since(info.ModTime()) > stderrCaptureStaleAge
+		}
+		if stale {
+			_ = os.Remove(filepath.Join(dir, name))
+		}
+	}
+}
+
+// Write 追加到副本文件，超过上限时先清空；总是报告写入成功，避免中断与之并列的其他 stderr 输出
+func (c *StderrCapture) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.size+int64(len(p)) > stderrCaptureLimit {
+		if err := c.f.Truncate(0); err == nil {
+			_, _ = c.f.Seek(0, 0)
+			c.size = 0
+		}
+	}
+	n, _ := c.f.Write(p)
+	c.size += int64(n)
+	return len(p), nil
+}
+
+// Started 把副本文件重命名为 StderrCaptureFile(pid)，用作 executor.WithOnStart 的回调
+func (c *StderrCapture) Started(pid int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	target := StderrCaptureFile(pid)
+	if err := os.Rename(c.path, target); err == nil {
+		c.path = target
+	}
+}
+
+// Close 关闭并删除副本文件；进程的输出已全部写入，此后不再需要读取
+func (c *StderrCapture) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	err := c.f.Close()
+	if rerr := os.Remove(c.path); err == nil && !os.IsNotExist(rerr) {
+		err = rerr
+	}
+	return err
+}
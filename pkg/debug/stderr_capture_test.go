@@ -0,0 +1,58 @@
+package debug
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"runtime"
+	"testing"
+)
+
+// 测试 stderr 副本：超过大小上限时只保留最近的输出，已退出进程留下的副本在下次创建时被清理
+func TestStderrCaptureLimitAndCleanup(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("stale detection relies on /proc")
+	}
+	t.Setenv("TMPDIR", t.TempDir())
+
+	gone := exec.Command("true")
+	if err := gone.Run(); err != nil {
+		t.Skip("true not available")
+	}
+	stale := StderrCaptureFile(gone.Process.Pid)
+	c, err := NewStderrCapture()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(stale, []byte("old"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c2, err := NewStderrCapture()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = c2.Close() }()
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("capture of an exited process was not removed: %v", err)
+	}
+
+	c.Started(os.Getpid())
+	chunk := bytes.Repeat([]byte("x"), 64<<10)
+	for range 40 {
+		_, _ = c.Write(chunk)
+	}
+	_, _ = c.Write([]byte("tail\n"))
+	data, err := os.ReadFile(StderrCaptureFile(os.Getpid()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) > stderrCaptureLimit || !bytes.HasSuffix(data, []byte("tail\n")) {
+		t.Errorf("capture holds %d bytes (limit %d), want the latest output only", len(data), stderrCaptureLimit)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(StderrCaptureFile(os.Getpid())); !os.IsNotExist(err) {
+		t.Errorf("capture not removed on close: %v", err)
+	}
+}
@@ -0,0 +1,22 @@
+// debugserver 是 pkg/debug 测试使用的辅助程序：在随机端口上提供 expvar 与 net/http/pprof，
+// 启动后把监听地址写到 stdout
+package main
+
+import (
+	_ "expvar"
+	"fmt"
+	"net"
+	"net/http"
+	_ "net/http/pprof"
+	"os"
+)
+
+func main() {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Println(ln.Addr().String())
+	_ = http.Serve(ln, nil)
+}
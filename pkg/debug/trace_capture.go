@@ -0,0 +1,130 @@
+package debug
+
+import (
+	"bytes"
+	"context"
+	"debug/buildinfo"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// TraceCaptureOptions 控制 debug trace --capture / --capture-test 运行命令并采集执行跟踪
+//   - Command: --capture 时 "--" 之后的测试二进制及其参数，追加 -test.trace=<Output>
+//   - TestPackage: --capture-test 时的包，执行 go test -trace <Output> <TestPackage> [TestArgs...]
+type TraceCaptureOptions struct {
+	Command     []string
+	TestPackage string
+	TestArgs    []string // --capture-test 时 "--" 之后传给 go test 的参数（如 -run TestX）
+	Output      string   // 跟踪文件路径，默认 DefaultCaptureTrace
+	Verbose     bool
+}
+
+// DefaultCaptureTrace 是 --capture/--capture-test 默认写出的跟踪文件；
+// 不使用 trace.out，避免与 gocli 自身 --trace 写出的执行跟踪冲突
+const DefaultCaptureTrace = "capture.trace"
+
+// traceHeader 匹配执行跟踪文件的头部，例如 "go 1.26 trace\x00\x00\x00"
+var traceHeader = regexp.MustCompile(`^go (1\.\d+) trace\x00*$`)
+
+// CaptureTrace 运行命令并等待其结束（ctx 取消时向子进程发送中断信号），返回写出的跟踪文件的绝对路径。
+// 命令失败（如测试失败）但跟踪文件有效时同时返回路径与错误；被中断时只要跟踪文件有效就不算失败
+func CaptureTrace(ctx context.Context, stdout, stderr io.Writer, opt TraceCaptureOptions) (string, error) {
+	out := opt.Output
+	if out == "" {
+		out = DefaultCaptureTrace
+	}
+	out, err := filepath.Abs(out)
+	if err != nil {
+		return "", err
+	}
+
+	var name string
+	var args []string
+	switch {
+	case opt.TestPackage != "" && len(opt.Command) > 0:
+		return "", fmt.Errorf("--capture and --capture-test are mutually exclusive")
+	case opt.TestPackage != "":
+		name = "go"
+		args = append([]string{"test", "-trace", out, opt.TestPackage}, opt.TestArgs...)
+	case len(opt.Command) > 0:
+		bin, err := exec.LookPath(opt.Command[0])
+		if err != nil {
+			return "", err
+		}
+		if !isTestBinary(bin) {
+			return "", fmt.Errorf("%s is not a Go test binary: the runtime trace can only be enabled from outside "+
+				"a program through the testing flag -test.trace; build it with 'go test -c' or use --capture-test "+
+				"(other programs must call runtime/trace.Start themselves)", opt.Command[0])
+		}
+		name = bin
+		args = append(append([]string{}, opt.Command[1:]...), "-test.trace="+out)
+	default:
+		return "", fmt.Errorf("nothing to capture: pass --capture -- <test binary> [args] or --capture-test <package>")
+	}
+	if err := os.MkdirAll(filepath.Dir(out), 0o755); err != nil {
+		return "", err
+	}
+	_ = os.Remove(out) // 旧文件会让中途失败的采集看起来像是成功
+
+	if opt.Verbose {
+		fmt.Fprintf(stderr, "running: %s %s\n", name, strings.Join(args, " "))
+	}
+	runErr := executor.NewExecutor(name, args...).
+		WithContext(ctx).
+		WithStopSignal(os.Interrupt).
+		WithStdin(os.Stdin).
+		RunStreaming(stdout, stderr)
+	if executor.Recording() {
+		return out, nil
+	}
+	interrupted := ctx.Err() != nil
+
+	if _, err := CheckTraceFile(out); err != nil {
+		if runErr != nil {
+			return "", fmt.Errorf("no usable trace captured (%w): %w", err, runErr)
+		}
+		return "", fmt.Errorf("no usable trace captured: %w", err)
+	}
+	if interrupted {
+		return out, nil
+	}
+	return out, runErr
+}
+
+// CheckTraceFile 检查文件是否为非空的 Go 执行跟踪（检查头部），返回写入它的 Go 版本（如 "1.26"）
+func CheckTraceFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+	head := make([]byte, 16)
+	n, err := io.ReadFull(f, head)
+	if n == 0 {
+		return "", fmt.Errorf("%s is empty", path)
+	}
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", err
+	}
+	m := traceHeader.FindSubmatch(head[:n])
+	if m == nil {
+		return "", fmt.Errorf("%s is not a Go execution trace (header %q)", path, bytes.TrimRight(head[:n], "\x00"))
+	}
+	return string(m[1]), nil
+}
+
+// isTestBinary 判断可执行文件是否由 go test -c 构建：构建信息中的主包路径以 .test 结尾
+func isTestBinary(bin string) bool {
+	if bi, err := buildinfo.ReadFile(bin); err == nil {
+		return strings.HasSuffix(bi.Path, ".test")
+	}
+	return false
+}
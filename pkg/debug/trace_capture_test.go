@@ -0,0 +1,70 @@
+package debug
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// 测试 --capture-test 与 --capture：在一个很小的测试包上运行 go test -trace 与 go test -c 构建的测试二进制，
+// 得到的跟踪文件非空且头部有效；非测试二进制被拒绝
+func TestCaptureTrace(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+	t.Setenv("GOFLAGS", "")
+	t.Setenv("GOWORK", "off")
+	t.Setenv("GOPROXY", "off")
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":       "module example.com/tiny\n\ngo 1.22\n",
+		"tiny_test.go": "package tiny\n\nimport \"testing\"\n\nfunc TestSum(t *testing.T) {\n\tn := 0\n\tfor i := range 1000 {\n\t\tn += i\n\t}\n\tif n == 0 {\n\t\tt.Fatal(n)\n\t}\n}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+
+	var out, errOut strings.Builder
+	file, err := CaptureTrace(context.Background(), &out, &errOut, TraceCaptureOptions{TestPackage: ".", TestArgs: []string{"-count", "1"}, Output: "go-test.trace"})
+	if err != nil {
+		t.Fatalf("capture-test: %v\n%s", err, errOut.String())
+	}
+	if file != filepath.Join(dir, "go-test.trace") {
+		t.Errorf("trace file = %s", file)
+	}
+	if fi, err := os.Stat(file); err != nil || fi.Size() == 0 {
+		t.Fatalf("trace file missing or empty: %v", err)
+	}
+	if v, err := CheckTraceFile(file); err != nil || !strings.HasPrefix(v, "1.") {
+		t.Errorf("CheckTraceFile = %q, %v", v, err)
+	}
+
+	bin := filepath.Join(dir, "tiny.test")
+	if b, err := exec.Command("go", "test", "-c", "-o", bin, ".").CombinedOutput(); err != nil {
+		t.Fatalf("go test -c: %v\n%s", err, b)
+	}
+	file, err = CaptureTrace(context.Background(), &out, &errOut, TraceCaptureOptions{Command: []string{bin, "-test.run", "TestSum"}, Output: "out/bin.trace"})
+	if err != nil {
+		t.Fatalf("capture: %v\n%s", err, errOut.String())
+	}
+	if _, err := CheckTraceFile(file); err != nil {
+		t.Errorf("binary trace: %v", err)
+	}
+
+	// 非测试二进制与无效的跟踪文件
+	if _, err := CaptureTrace(context.Background(), &out, &errOut, TraceCaptureOptions{Command: []string{"go", "version"}}); err == nil || !strings.Contains(err.Error(), "not a Go test binary") {
+		t.Errorf("expected a non-test binary error, got %v", err)
+	}
+	if err := os.WriteFile("bad.trace", []byte("not a trace"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CheckTraceFile("bad.trace"); err == nil {
+		t.Error("expected an invalid header error")
+	}
+}
@@ -57,4 +57,7 @@ type GoFileTemplate struct {
 
 	// Language 模板所属编程语言（可选，默认 go）
 	Language string `json:"language"`
+
+	// PostInit 模板复制且 go mod init 完成后，在目标目录中依次执行的命令（如 go mod tidy、git add -A）
+	PostInit []string `json:"post_init,omitempty"`
 }
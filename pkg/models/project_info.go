@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // Stats 存储代码、注释和空行的计数，是一个可重用的基本单位
 type Stats struct {
 	Code     int `json:"code" yaml:"code"`         // 代码行数
@@ -16,6 +18,17 @@ type FileInfo struct {
 	// LanguageSpecific 用于存储特定语言的额外信息，提供扩展性
 	// 对于 C/C++，可以存头文件依赖等
 	LanguageSpecific any `json:"language_specific,omitempty" yaml:"language_specific,omitempty"`
+
+	// Authors 文件中各作者仍然存在的行数（--owners），只用于聚合 Owners，不输出
+	Authors []Owner `json:"-" yaml:"-"`
+}
+
+// Owner 描述一位作者在某个范围（语言或整个项目）内仍然存在的行数
+type Owner struct {
+	Name    string  `json:"name" yaml:"name"`       // 作者名
+	Email   string  `json:"email" yaml:"email"`     // 作者邮箱（小写），用于区分作者
+	Lines   int     `json:"lines" yaml:"lines"`     // 仍然存在的行数
+	Percent float64 `json:"percent" yaml:"percent"` // 占该范围内已归属行数的百分比
 }
 
 // GoDetails 存储 Go 文件特有的信息
@@ -27,9 +40,18 @@ type GoDetails struct {
 	Functions int `json:"functions,omitempty" yaml:"functions,omitempty"` // 函数数量（按语言定义）
 	Structs   int `json:"structs,omitempty" yaml:"structs,omitempty"`     // 结构体数量（按语言定义）
 
+	GoBuild
 	LanguageStats
 }
 
+// GoBuild 描述 Go 文件的用途与构建约束，用于区分生产代码、测试代码以及不参与当前平台构建的代码
+type GoBuild struct {
+	Test       bool   `json:"test,omitempty" yaml:"test,omitempty"`             // _test.go 文件
+	Generated  bool   `json:"generated,omitempty" yaml:"generated,omitempty"`   // 带有 "// Code generated ... DO NOT EDIT." 注释
+	Constraint string `json:"constraint,omitempty" yaml:"constraint,omitempty"` // //go:build 表达式
+	Inactive   bool   `json:"inactive,omitempty" yaml:"inactive,omitempty"`     // 当前平台（GOOS/GOARCH、构建标签、文件名后缀）不编译该文件
+}
+
 // LanguageStats 存储单一语言的聚合统计信息
 type LanguageStats struct {
 	FileCount int   `json:"file_count" yaml:"file_count"` // 该语言的文件总数
@@ -38,6 +60,42 @@ type LanguageStats struct {
 	Functions int        `json:"functions,omitempty" yaml:"functions,omitempty"`
 	Structs   int        `json:"structs,omitempty" yaml:"structs,omitempty"`
 	Files     []FileInfo `json:"files,omitempty" yaml:"files,omitempty"`
+	// Owners 按仍然存在的行数排列的作者（--owners）：语言中为前 3 位，Total 中为整个项目的分布
+	Owners []Owner `json:"owners,omitempty" yaml:"owners,omitempty"`
+	// Go 将 Go 代码拆分为生产代码、测试代码与按构建约束分组的统计，仅 Languages["Go"] 中填充
+	Go *GoBreakdown `json:"go,omitempty" yaml:"go,omitempty"`
+}
+
+// SubStats 是某一部分文件的文件数与行数统计
+type SubStats struct {
+	FileCount int   `json:"file_count" yaml:"file_count"`
+	Stats     Stats `json:"stats" yaml:"stats"`
+}
+
+// Add 将一个文件的统计累加到 s
+func (s *SubStats) Add(st Stats) {
+	s.FileCount++
+	s.Stats.Code += st.Code
+	s.Stats.Comments += st.Comments
+	s.Stats.Blanks += st.Blanks
+}
+
+// GoBreakdown 拆分 Go 代码的统计：Prod 与 Tests 互斥且合计等于 Go 语言的总计，
+// Generated 与 Inactive 是与之重叠的子集
+type GoBreakdown struct {
+	Prod      SubStats `json:"prod" yaml:"prod"`           // 非 _test.go 文件
+	Tests     SubStats `json:"tests" yaml:"tests"`         // _test.go 文件
+	Generated SubStats `json:"generated" yaml:"generated"` // 生成的代码
+	Inactive  SubStats `json:"inactive" yaml:"inactive"`   // 当前平台不编译的文件
+	// Constraints 按 //go:build 表达式分组，按表达式排序
+	Constraints []ConstraintStats `json:"constraints,omitempty" yaml:"constraints,omitempty"`
+}
+
+// ConstraintStats 是带有同一 //go:build 表达式的文件的统计
+type ConstraintStats struct {
+	Expr     string `json:"expr" yaml:"expr"`
+	Inactive bool   `json:"inactive,omitempty" yaml:"inactive,omitempty"` // 该表达式在当前平台不成立
+	SubStats `yaml:",inline"`
 }
 
 // AnalysisResult 是最终分析结果的顶层结构体
@@ -51,4 +109,26 @@ type AnalysisResult struct {
 
 	// Files 顶层所有文件明细（当 WithFileDetails=true 时填充）
 	Files []FileInfo `json:"files,omitempty" yaml:"files,omitempty"`
+
+	// Skipped 因超过 MaxFileSizeBytes 而未统计的文件
+	Skipped []SkippedFile `json:"skipped,omitempty" yaml:"skipped,omitempty"`
+
+	// Git 项目所在 git 仓库的统计（project info --git 且目标位于仓库内时填充）
+	Git *GitStats `json:"git,omitempty" yaml:"git,omitempty"`
+}
+
+// GitStats 存储 git 仓库的提交统计，只统计当前分支（HEAD）可达的提交
+type GitStats struct {
+	Branch       string    `json:"branch" yaml:"branch"`                                // 当前分支，分离头指针时为提交短哈希
+	Commits      int       `json:"commits" yaml:"commits"`                              // 提交数
+	Contributors int       `json:"contributors" yaml:"contributors"`                    // 不同作者邮箱的数量
+	FirstCommit  time.Time `json:"first_commit,omitzero" yaml:"first_commit,omitempty"` // 最早提交的作者时间
+	LastCommit   time.Time `json:"last_commit,omitzero" yaml:"last_commit,omitempty"`   // 最近提交的作者时间
+}
+
+// SkippedFile 记录一个未参与统计的文件及原因
+type SkippedFile struct {
+	Path   string `json:"path" yaml:"path"`     // 文件相对于项目根目录的路径
+	Size   int64  `json:"size" yaml:"size"`     // 文件大小（字节）
+	Reason string `json:"reason" yaml:"reason"` // 跳过原因，例如 "exceeds max file size (1048576 bytes)"
 }
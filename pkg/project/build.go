@@ -1,13 +1,20 @@
 package project
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/yeisme/gocli/pkg/configs"
 	"github.com/yeisme/gocli/pkg/context"
+	"github.com/yeisme/gocli/pkg/style"
+	"github.com/yeisme/gocli/pkg/utils/dotenv"
 	"github.com/yeisme/gocli/pkg/utils/executor"
 	"github.com/yeisme/gocli/pkg/utils/hotload"
 )
@@ -48,10 +55,30 @@ type BuildRunOptions struct {
 
 // BuildinOptions contains templated build options for internal use.
 type BuildinOptions struct {
-	ReleaseBuild bool // Release mode: removes debug information to reduce binary size (-ldflags="-s -w")
-	DebugBuild   bool // Debug mode: disables optimizations and enables race detection for easier debugging
-	HotReload    bool // Hot reload: enables automatic reloading of code changes
-	NoGitIgnore  bool // No git ignore: disables .gitignore file filtering during hot reload
+	ReleaseBuild bool     // Release mode: removes debug information to reduce binary size (-ldflags="-s -w")
+	DebugBuild   bool     // Debug mode: disables optimizations and enables race detection for easier debugging
+	HotReload    bool     // Hot reload: enables automatic reloading of code changes
+	NoGitIgnore  bool     // No git ignore: disables .gitignore file filtering during hot reload
+	WatchPaths   []string // Watch paths: additional paths to watch during hot reload, overrides app.hotload.watch_paths
+	Stats        bool     // Stats: print hot reload statistics on SIGUSR1 or when "s" is entered on a terminal
+
+	Platforms []string // Platforms: cross-compile targets in GOOS/GOARCH form (build only)
+	EnvFiles  []string // EnvFiles: dotenv files loaded into the executed program's environment (run only)
+
+	AllMains    bool // AllMains: run every main package under the arguments (default ./...) concurrently (run only)
+	KeepRunning bool // KeepRunning: with several entrypoints, keep the others running when one fails (run only)
+
+	CheckPorts      []int     // CheckPorts: TCP ports that must be free before the program starts, overrides run.check_ports (run only)
+	KillConflicting bool      // KillConflicting: offer to terminate the process holding a checked port, after confirmation (run only)
+	Prompt          io.Writer // Prompt: where the --kill-conflicting confirmation is asked (a terminal); nil means it cannot be confirmed
+	Input           io.Reader // Input: answers to Prompt (default os.Stdin)
+
+	BuildSummary   bool   // BuildSummary: parse -x output to report recompiled packages vs cache hits (build only)
+	ExplainCache   bool   // ExplainCache: build summary plus likely cache-busting reasons and GOCACHE statistics (build only)
+	CleanCache     bool   // CleanCache: run go clean -cache -testcache with a size report before building (build only)
+	OutputTemplate string // OutputTemplate: text/template for the -o value, e.g. dist/{{.Name}}_{{.OS}}_{{.Arch}} (build only)
+
+	Workspace []WorkspaceModule // Workspace: build ./... (or the given packages) in every go.work module (build only)
 }
 
 // applyBuildTemplates modifies build options based on built-in templates (Release/Debug).
@@ -155,15 +182,31 @@ func buildArgsFromOptions(options BuildRunOptions) []string {
 	return args
 }
 
-// runGoCommand runs a go command using tools.Executor. (This function remains unchanged)
-func runGoCommand(options BuildRunOptions, goCmdArgs []string) error {
+// runGoCommand runs a go command using tools.Executor.
+// env 为附加到子进程的环境变量（例如交叉编译时的 GOOS/GOARCH）
+func runGoCommand(options BuildRunOptions, goCmdArgs []string, env ...string) error {
+	return runGoCommandLines(options, goCmdArgs, func(line string) { log.Warn().Msg(line) }, env...)
+}
+
+// runGoCommandLines 与 runGoCommand 相同，但 stderr 的每一行交给 onStderr 处理（用于解析 -x 输出）
+func runGoCommandLines(options BuildRunOptions, goCmdArgs []string, onStderr func(string), env ...string) error {
 	executor := executor.NewExecutor("go", goCmdArgs...)
+	// go run 编译出的程序继承 go 命令的 stderr，保存副本供 gocli debug stack --pid 读取
+	if len(goCmdArgs) > 0 && goCmdArgs[0] == "run" && !options.N {
+		defer withStderrCapture(executor)()
+	}
 	if options.ChangeDir != "" {
 		executor.WithDir(options.ChangeDir)
 	}
+	if len(env) > 0 {
+		executor.WithEnv(env...)
+	}
 
 	if options.N || options.X {
 		fullCmdString := "go " + strings.Join(goCmdArgs, " ")
+		if len(env) > 0 {
+			fullCmdString = strings.Join(env, " ") + " " + fullCmdString
+		}
 		if options.ChangeDir != "" {
 			log.Info().Str("dir", options.ChangeDir).Msg(fullCmdString)
 		} else {
@@ -175,54 +218,161 @@ func runGoCommand(options BuildRunOptions, goCmdArgs []string) error {
 		return nil
 	}
 
-	stdout, stderr, err := executor.Run()
-	if stdout != "" {
-		for line := range strings.SplitSeq(strings.TrimSpace(stdout), "\n") {
-			log.Info().Msg(line)
-		}
+	// 逐行实时输出，避免长时间构建时看起来像卡住
+	err := executor.RunLines(
+		func(line string) { log.Info().Msg(line) },
+		onStderr,
+	)
+	return withoutStreamedStderr(err)
+}
+
+// withoutStreamedStderr 清除 ExecError 中已实时输出过的 stderr，避免错误信息重复打印
+func withoutStreamedStderr(err error) error {
+	var execErr *executor.ExecError
+	if errors.As(err, &execErr) {
+		execErr.Stderr = ""
+	}
+	return err
+}
+
+// executeGoProcessCommand generalizes the execution of "go build" and "go run" commands.
+func executeGoProcessCommand(command string, options BuildRunOptions, args []string, env ...string) error {
+	if command == "build" && options.ExplainCache && !options.N {
+		return runBuildExplainCache(options, args, env...)
+	}
+	if command == "build" && options.BuildSummary && !options.N {
+		return runBuildWithSummary(options, args, env...)
 	}
+	return runGoCommand(options, goProcessArgs(command, options, args), env...)
+}
+
+// programArgsSeparator 分隔包参数与传给被运行程序的参数，例如 gocli project run ./cmd/server -- --port 9000
+const programArgsSeparator = "--"
 
-	if err == nil && stderr != "" {
-		for line := range strings.SplitSeq(strings.TrimSpace(stderr), "\n") {
-			log.Warn().Msg(line)
+// splitProgramArgs 在第一个 "--" 处拆分参数：之前为包/文件参数，之后原样转发给程序
+// 没有 "--" 时 hasSep 为 false，progArgs 为空
+func splitProgramArgs(args []string) (pkgArgs, progArgs []string, hasSep bool) {
+	for i, a := range args {
+		if a == programArgsSeparator {
+			return args[:i], args[i+1:], true
 		}
 	}
-	return err
+	return args, nil, false
 }
 
-// executeGoProcessCommand generalizes the execution of "go build" and "go run" commands. (This function remains unchanged)
-func executeGoProcessCommand(command string, options BuildRunOptions, args []string) error {
+// goProcessArgs 生成 go build / go run 的完整参数列表
+//
+// args 中 "--" 之前的参数视作包路径、目录或 .go 文件（未提供时使用当前目录），之后的参数：
+//   - run: 原样转发给被运行的程序
+//   - build: 忽略并给出警告
+//
+// 未使用 "--" 时保持兼容：第一个参数为包路径，run 会把其余参数转发给程序，build 忽略其余参数
+func goProcessArgs(command string, options BuildRunOptions, args []string) []string {
 	goArgs := []string{command}
 	goArgs = append(goArgs, buildArgsFromOptions(options)...)
 
-	if args[1:] != nil {
-		// 作为子命令提供给 go 命令
-		log.Debug().Msgf("Subcommand args: %v", args[1:])
+	pkgArgs, progArgs := processTargets(args)
+	goArgs = append(goArgs, pkgArgs...)
+
+	if len(progArgs) > 0 {
+		switch command {
+		case "run":
+			log.Debug().Msgf("Program args: %v", progArgs)
+			goArgs = append(goArgs, progArgs...)
+		case "build":
+			log.Warn().Msgf("Ignoring additional arguments for 'build': %v", progArgs)
+		}
 	}
+	return goArgs
+}
 
-	// args 的第一个元素被视作包路径或要构建/运行的目录（如果未提供则使用当前目录）
-	// 对于 run：会把后续的参数也传给运行的程序；对于 build：只使用第一个参数作为包路径并忽略其余参数
-	if len(args) == 0 {
-		goArgs = append(goArgs, ".")
-	} else {
-		// 始终将第一个参数作为 package/path 传入
-		goArgs = append(goArgs, args[0])
+// processTargets 拆分包参数与程序参数；未提供包参数时使用当前目录
+func processTargets(args []string) (pkgArgs, progArgs []string) {
+	pkgArgs, progArgs, hasSep := splitProgramArgs(args)
+	if !hasSep && len(pkgArgs) > 1 {
+		// 兼容旧用法：第一个参数之后的内容视作程序参数
+		progArgs = pkgArgs[1:]
+		pkgArgs = pkgArgs[:1]
+	}
+	if len(pkgArgs) == 0 {
+		pkgArgs = []string{"."}
+	}
+	return pkgArgs, progArgs
+}
 
-		if command == "run" && len(args) > 1 {
-			// run 命令会把后续参数也传递给被运行的程序
-			goArgs = append(goArgs, args[1:]...)
+// parsePlatforms 解析并校验 GOOS/GOARCH 列表，任何不受支持的组合都会在构建前直接报错
+func parsePlatforms(platforms []string) ([][2]string, error) {
+	var targets [][2]string
+	var invalid []string
+	for _, p := range platforms {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
 		}
-
-		if command == "build" && len(args) > 1 {
-			log.Warn().Msgf("Ignoring additional arguments for 'build': %v", args[1:])
+		goos, goarch, ok := strings.Cut(p, "/")
+		if !ok || !configs.IsValidOSArch(goos, goarch) {
+			invalid = append(invalid, p)
+			continue
 		}
+		targets = append(targets, [2]string{goos, goarch})
+	}
+	if len(invalid) > 0 {
+		return nil, fmt.Errorf("unsupported platform(s): %s (see 'go tool dist list' for valid GOOS/GOARCH pairs)", strings.Join(invalid, ", "))
 	}
+	return targets, nil
+}
 
-	return runGoCommand(options, goArgs)
+// defaultOutputName 返回构建目标目录名，作为未指定 -o 时的产物名
+func defaultOutputName(options BuildRunOptions, args []string) string {
+	pkg := "."
+	if pkgArgs, _, _ := splitProgramArgs(args); len(pkgArgs) > 0 {
+		pkg = pkgArgs[0]
+	}
+	if abs, err := filepath.Abs(filepath.Join(options.ChangeDir, pkg)); err == nil {
+		pkg = abs
+	}
+	return filepath.Base(pkg)
 }
 
-// 热重启循环，监听变更并自动执行 build/run
-func hotReloadLoop(gocliCtx *context.GocliContext, options BuildRunOptions, runFunc func() error) error {
+// platformOutputName 生成交叉编译产物名：<name>_<goos>_<goarch>[.exe]
+func platformOutputName(options BuildRunOptions, args []string, goos, goarch string) string {
+	name := options.Output
+	if name == "" {
+		name = defaultOutputName(options, args)
+	}
+	name = strings.TrimSuffix(name, ".exe")
+	name = fmt.Sprintf("%s_%s_%s", name, goos, goarch)
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// executeGoBuildForPlatforms 依次为每个目标平台执行 go build
+func executeGoBuildForPlatforms(options BuildRunOptions, args []string) error {
+	targets, err := parsePlatforms(options.Platforms)
+	if err != nil {
+		return err
+	}
+	for _, t := range targets {
+		opts := options
+		if options.OutputTemplate != "" {
+			if opts.Output, err = renderOutputTemplate(options, args, t[0], t[1]); err != nil {
+				return err
+			}
+		} else {
+			opts.Output = platformOutputName(options, args, t[0], t[1])
+		}
+		log.Info().Str("platform", t[0]+"/"+t[1]).Str("output", opts.Output).Msg("Cross-compiling")
+		if err := executeGoProcessCommand("build", opts, args, "GOOS="+t[0], "GOARCH="+t[1]); err != nil {
+			return fmt.Errorf("build for %s/%s failed: %w", t[0], t[1], err)
+		}
+	}
+	return nil
+}
+
+// hotloadConfigFor 返回应用了 --no-gitignore 与 --watch-path 的热加载配置
+func hotloadConfigFor(gocliCtx *context.GocliContext, options BuildRunOptions) configs.HotloadConfig {
 	hotloadConfig := gocliCtx.Config.App.Hotload
 
 	// 如果指定了 --no-gitignore 参数，则覆盖配置中的 git_ignore 设置
@@ -231,12 +381,26 @@ func hotReloadLoop(gocliCtx *context.GocliContext, options BuildRunOptions, runF
 		log.Info().Msg("[HotReload] --no-gitignore flag specified, disabling .gitignore filtering")
 	}
 
+	// --watch-path 覆盖配置中的 watch_paths
+	if len(options.WatchPaths) > 0 {
+		hotloadConfig.WatchPaths = options.WatchPaths
+	}
+	return hotloadConfig
+}
+
+// 热重启循环，监听变更并自动执行 build/run
+func hotReloadLoop(gocliCtx *context.GocliContext, options BuildRunOptions, runFunc func() error) error {
+	hotloadConfig := hotloadConfigFor(gocliCtx, options)
+
 	// 检查热加载是否启用
 	if !hotloadConfig.Enabled {
 		log.Warn().Msg("[HotReload] Hot reload is disabled in configuration")
 		return runFunc() // 直接执行一次，不进行热加载
 	}
 
+	// 组合 pre_hooks -> build/run（或 exec）-> post_hooks
+	runFunc = withHotloadHooks(hotloadConfig, runFunc)
+
 	// 执行初始构建/运行
 	log.Info().Msg("[HotReload] Executing initial build/run...")
 	if err := runFunc(); err != nil {
@@ -256,34 +420,188 @@ func hotReloadLoop(gocliCtx *context.GocliContext, options BuildRunOptions, runF
 
 	log.Info().Msgf("[HotReload] Start watching: %s (recursive=%t, git_ignore=%t)",
 		watchDir, hotloadConfig.Recursive, hotloadConfig.GitIgnore)
-	log.Debug().Msgf("[HotReload] Configuration - Filter: %v, IgnorePatterns: %v, Debounce: %dms",
-		hotloadConfig.Filter, hotloadConfig.IgnorePatterns, hotloadConfig.Debounce)
+	log.Debug().Msgf("[HotReload] Configuration - Filter: %v, IgnorePatterns: %v, WatchPaths: %v, Debounce: %dms",
+		hotloadConfig.Filter, hotloadConfig.IgnorePatterns, hotloadConfig.WatchPaths, hotloadConfig.Debounce)
+
+	stats := hotload.NewStats()
+	status := hotloadStatusEnabled(hotloadConfig.Status)
+	if options.Stats {
+		stop := stats.ListenDump(os.Stderr, os.Stdin)
+		defer stop()
+		log.Info().Msg("[HotReload] Send SIGUSR1 or type 's' + Enter to print statistics")
+	}
 
 	// 使用配置化的热加载监听器
-	return hotload.WatchWithConfig(hotloadConfig, func() {
+	return hotload.WatchWithStats(hotloadConfig, stats, func() {
 		log.Info().Msg("[HotReload] Change detected, restarting...")
-		if err := runFunc(); err != nil {
+		start := time.Now()
+		err := runFunc()
+		if err != nil {
 			log.Error().Msgf("[HotReload] Execution failed: %v", err)
 		}
+		elapsed := time.Since(start)
+		n := stats.RecordRebuild(elapsed, err)
+		if status {
+			fmt.Fprintln(os.Stderr, stats.StatusLine(n, elapsed, err))
+		}
 	})
 }
 
+// hotloadStatusEnabled 解析 app.hotload.status：auto（或空）在 stderr 为终端时开启，其余按布尔值解析
+func hotloadStatusEnabled(setting string) bool {
+	switch s := strings.ToLower(strings.TrimSpace(setting)); s {
+	case "", "auto":
+		return style.IsTerminal(os.Stderr)
+	default:
+		on, err := strconv.ParseBool(s)
+		if err != nil {
+			log.Warn().Msgf("[HotReload] invalid app.hotload.status %q, using auto", setting)
+			return style.IsTerminal(os.Stderr)
+		}
+		return on
+	}
+}
+
 // ExecuteBuildCommand uses the new executeGoProcessCommand. (This function remains unchanged)
 func ExecuteBuildCommand(gocliCtx *context.GocliContext, options BuildRunOptions, args []string) error {
+	if len(options.Workspace) > 0 {
+		return executeWorkspaceBuild(gocliCtx, options, args)
+	}
+	buildFunc := func() error {
+		opts := options
+		if options.OutputTemplate != "" {
+			goos, goarch := hostPlatform()
+			out, err := renderOutputTemplate(options, args, goos, goarch)
+			if err != nil {
+				return err
+			}
+			opts.Output = out
+		}
+		return executeGoProcessCommand("build", opts, args)
+	}
+	if options.OutputTemplate != "" {
+		// 先解析模板，避免热重载循环启动后才发现模板错误
+		if _, err := parseOutputTemplate(options.OutputTemplate); err != nil {
+			return err
+		}
+	}
+	if len(options.Platforms) > 0 {
+		// 先校验平台列表，避免热重载循环启动后才发现参数错误
+		if _, err := parsePlatforms(options.Platforms); err != nil {
+			return err
+		}
+		buildFunc = func() error {
+			return executeGoBuildForPlatforms(options, args)
+		}
+	}
+	if options.CleanCache {
+		if err := cleanBuildCache(options.V); err != nil {
+			return err
+		}
+	}
 	if options.HotReload {
-		return hotReloadLoop(gocliCtx, options, func() error {
-			return executeGoProcessCommand("build", options, args)
-		})
+		return hotReloadLoop(gocliCtx, options, buildFunc)
 	}
-	return executeGoProcessCommand("build", options, args)
+	return buildFunc()
 }
 
-// ExecuteRunCommand uses the new executeGoProcessCommand. (This function remains unchanged)
-func ExecuteRunCommand(gocliCtx *context.GocliContext, options BuildRunOptions, args []string) error {
+// executeWorkspaceBuild builds each workspace module from its own directory, so package patterns
+// (./... when none are given) and a relative -o resolve per module. Failing modules do not stop the others;
+// the returned error lists all of them.
+func executeWorkspaceBuild(gocliCtx *context.GocliContext, options BuildRunOptions, args []string) error {
 	if options.HotReload {
-		return hotReloadLoop(gocliCtx, options, func() error {
-			return executeGoProcessCommand("run", options, args)
-		})
+		return fmt.Errorf("--hot-reload cannot be combined with --workspace")
+	}
+	if options.CleanCache {
+		if err := cleanBuildCache(options.V); err != nil {
+			return err
+		}
+	}
+	single := options
+	single.Workspace = nil
+	single.CleanCache = false
+	if pkgArgs, _, _ := splitProgramArgs(args); len(pkgArgs) == 0 {
+		args = append([]string{"./..."}, args...)
+	}
+	return inWorkspaceModules(options.Workspace, false, func(m WorkspaceModule) error {
+		log.Info().Str("module", m.Path).Str("dir", m.Dir).Msg("building workspace module")
+		return ExecuteBuildCommand(gocliCtx, single, args)
+	})
+}
+
+// ExecuteRunCommand uses the new executeGoProcessCommand.
+// .env 文件在每次启动前重新读取，因此热重载重启后会使用文件中的最新值
+func ExecuteRunCommand(gocliCtx *context.GocliContext, options BuildRunOptions, args []string) error {
+	mains, progArgs, multi, err := multiRunTargets(options, args)
+	if err != nil {
+		return err
+	}
+	if multi {
+		return executeMultiRun(gocliCtx, options, mains, progArgs)
+	}
+	ports, err := newPortChecker(gocliCtx, options)
+	if err != nil {
+		return err
+	}
+	runFunc := func() error {
+		if err := ports.check(); err != nil {
+			return err
+		}
+		env, err := loadRunEnvFiles(gocliCtx, options)
+		if err != nil {
+			return err
+		}
+		return executeGoProcessCommand("run", options, args, env...)
+	}
+	switch {
+	case !options.HotReload:
+		err = runFunc()
+	case gocliCtx.Config.App.Hotload.Enabled:
+		// 程序在后台运行，post 钩子在程序启动后执行
+		err = executeSingleHotReload(gocliCtx, options, hotloadConfigFor(gocliCtx, options), args, ports)
+	default:
+		err = hotReloadLoop(gocliCtx, options, runFunc)
+	}
+	// Ctrl+C 是结束 run 的正常方式，程序被中断不算失败
+	if errors.Is(err, context.ErrCancelled) {
+		return nil
+	}
+	return err
+}
+
+// loadRunEnvFiles 加载 --env-file 指定的文件（必须存在），未指定时加载配置 run.env_files 中存在的文件
+// 返回的 KEY=VALUE 列表只附加到子进程，不会修改 gocli 自身的环境变量
+func loadRunEnvFiles(gocliCtx *context.GocliContext, options BuildRunOptions) ([]string, error) {
+	files := options.EnvFiles
+	optional := false
+	if len(files) == 0 && gocliCtx != nil && gocliCtx.Config != nil {
+		files = gocliCtx.Config.Run.EnvFiles
+		optional = true
+	}
+
+	var paths []string
+	for _, f := range files {
+		if f == "" {
+			continue
+		}
+		if !filepath.IsAbs(f) && options.ChangeDir != "" {
+			f = filepath.Join(options.ChangeDir, f)
+		}
+		if optional {
+			if fi, err := os.Stat(f); err != nil || fi.IsDir() {
+				continue
+			}
+		}
+		paths = append(paths, f)
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	env, err := dotenv.Load(paths...)
+	if err != nil {
+		return nil, fmt.Errorf("load env files failed: %w", err)
 	}
-	return executeGoProcessCommand("run", options, args)
+	log.Debug().Strs("files", paths).Int("vars", len(env)).Msg("Loaded env files for run")
+	return dotenv.ToEnviron(env), nil
 }
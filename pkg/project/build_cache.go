@@ -0,0 +1,275 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// buildStateFile 按目标平台记录上一次 --explain-cache 构建参数的文件（相对于 -C 目录或当前目录）
+const buildStateFile = ".gocli/build-state.json"
+
+// BuildState 是一次构建中会影响构建缓存键的参数快照
+type BuildState struct {
+	GoVersion string    `json:"go_version"`
+	GOOS      string    `json:"goos"`
+	GOARCH    string    `json:"goarch"`
+	Tags      []string  `json:"tags,omitempty"`
+	Ldflags   string    `json:"ldflags,omitempty"`
+	Gcflags   string    `json:"gcflags,omitempty"`
+	Race      bool      `json:"race,omitempty"`
+	Trimpath  bool      `json:"trimpath,omitempty"`
+	Packages  []string  `json:"packages"`
+	Time      time.Time `json:"time"`
+}
+
+// currentBuildState 根据构建选项生成快照；Release/Debug 模板会先展开，与实际传给 go build 的参数一致
+// env 中的 GOOS/GOARCH（--platforms 交叉编译时传入）优先于宿主平台
+func currentBuildState(options BuildRunOptions, pkgs []string, env []string) BuildState {
+	applyBuildTemplates(&options)
+	goos, goarch := hostPlatform()
+	for _, kv := range env {
+		if v, ok := strings.CutPrefix(kv, "GOOS="); ok {
+			goos = v
+		} else if v, ok := strings.CutPrefix(kv, "GOARCH="); ok {
+			goarch = v
+		}
+	}
+	version, _ := executor.NewExecutor("go", "env", "GOVERSION").ReadOnly().Output()
+	return BuildState{
+		GoVersion: strings.TrimSpace(version),
+		GOOS:      goos,
+		GOARCH:    goarch,
+		Tags:      splitBuildTags(options.Tags),
+		Ldflags:   options.Ldflags,
+		Gcflags:   options.Gcflags,
+		Race:      options.Race,
+		Trimpath:  options.Trimpath,
+		Packages:  pkgs,
+		Time:      time.Now(),
+	}
+}
+
+// splitBuildTags 将 -tags 的值（逗号或空格分隔）规范化为排序去重后的列表
+func splitBuildTags(tags string) []string {
+	list := strings.FieldsFunc(tags, func(r rune) bool { return r == ',' || r == ' ' })
+	slices.Sort(list)
+	return slices.Compact(list)
+}
+
+// explainCacheBusting 比较前后两次构建参数，返回可能导致构建缓存失效的原因；prev 为 nil 时说明没有历史记录
+func explainCacheBusting(prev *BuildState, cur BuildState, forced bool) []string {
+	var reasons []string
+	if forced {
+		reasons = append(reasons, "-a forces all packages to be rebuilt")
+	}
+	if prev == nil {
+		return append(reasons, fmt.Sprintf("no previous %s build recorded in %s", cur.platformKey(), buildStateFile))
+	}
+	if prev.GoVersion != cur.GoVersion {
+		reasons = append(reasons, fmt.Sprintf("Go toolchain changed: %s -> %s (every package is rebuilt)", prev.GoVersion, cur.GoVersion))
+	}
+	if !slices.Equal(prev.Tags, cur.Tags) {
+		reasons = append(reasons, fmt.Sprintf("build tags changed: %q -> %q", strings.Join(prev.Tags, ","), strings.Join(cur.Tags, ",")))
+	}
+	if prev.Gcflags != cur.Gcflags {
+		reasons = append(reasons, fmt.Sprintf("-gcflags changed: %q -> %q", prev.Gcflags, cur.Gcflags))
+	}
+	if prev.Ldflags != cur.Ldflags {
+		reasons = append(reasons, fmt.Sprintf("-ldflags changed: %q -> %q (relinks the binary)", prev.Ldflags, cur.Ldflags))
+	}
+	if prev.Race != cur.Race {
+		reasons = append(reasons, fmt.Sprintf("-race changed: %t -> %t", prev.Race, cur.Race))
+	}
+	if prev.Trimpath != cur.Trimpath {
+		reasons = append(reasons, fmt.Sprintf("-trimpath changed: %t -> %t", prev.Trimpath, cur.Trimpath))
+	}
+	if !slices.Equal(prev.Packages, cur.Packages) {
+		reasons = append(reasons, fmt.Sprintf("different packages than the previous build: %v -> %v", prev.Packages, cur.Packages))
+	}
+	return reasons
+}
+
+// platformKey 返回快照在 build-state.json 中的键，例如 linux/amd64
+func (s BuildState) platformKey() string {
+	return s.GOOS + "/" + s.GOARCH
+}
+
+// loadBuildStates 读取按平台记录的构建快照，文件不存在时返回空 map
+func loadBuildStates(path string) (map[string]BuildState, error) {
+	states := map[string]BuildState{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return states, nil
+	}
+	if err != nil {
+		return states, err
+	}
+	if err := json.Unmarshal(data, &states); err != nil {
+		return map[string]BuildState{}, fmt.Errorf("parse %s failed: %w", path, err)
+	}
+	return states, nil
+}
+
+// saveBuildState 更新 st 所在平台的快照，保留其他平台的记录
+func saveBuildState(path string, st BuildState) error {
+	states, err := loadBuildStates(path)
+	if err != nil {
+		log.Debug().Err(err).Msg("overwriting unreadable build state")
+	}
+	states[st.platformKey()] = st
+	data, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// runBuildExplainCache 以 -x 运行 go build，输出重新编译/命中缓存的包数量、可能的缓存失效原因与 GOCACHE 统计
+// 构建成功后将本次参数写入 .gocli/build-state.json，供下一次比较
+func runBuildExplainCache(options BuildRunOptions, args []string, env ...string) error {
+	pkgArgs, _ := processTargets(args)
+	statePath := filepath.Join(options.ChangeDir, buildStateFile)
+	cur := currentBuildState(options, pkgArgs, env)
+	states, err := loadBuildStates(statePath)
+	if err != nil {
+		log.Warn().Err(err).Msg("ignoring previous build state")
+	}
+	var prev *BuildState
+	if st, ok := states[cur.platformKey()]; ok {
+		prev = &st
+	}
+
+	summary, buildErr := collectBuildSummary(options, args, env...)
+	if summary == nil {
+		return buildErr
+	}
+	logBuildSummary(summary, true)
+
+	if len(summary.Compiled) == 0 {
+		log.Info().Msg("All packages were served from the build cache")
+	} else {
+		for _, r := range explainCacheBusting(prev, cur, options.A) {
+			log.Info().Msgf("Cache busting: %s", r)
+		}
+	}
+	if stats, err := goCacheStats(); err != nil {
+		log.Warn().Err(err).Msg("failed to inspect GOCACHE")
+	} else {
+		logCacheStats(stats, "GOCACHE")
+	}
+
+	if buildErr != nil {
+		return buildErr
+	}
+	if err := saveBuildState(statePath, cur); err != nil {
+		log.Warn().Err(err).Str("file", statePath).Msg("failed to record build state")
+	}
+	return nil
+}
+
+// cacheStats 描述构建缓存目录的大小与文件时间范围
+type cacheStats struct {
+	Dir    string
+	Size   int64
+	Files  int
+	Oldest time.Time
+	Newest time.Time
+}
+
+// goCacheStats 通过 go env GOCACHE 定位构建缓存并遍历统计
+func goCacheStats() (cacheStats, error) {
+	out, err := executor.NewExecutor("go", "env", "GOCACHE").ReadOnly().Output()
+	if err != nil {
+		return cacheStats{}, err
+	}
+	dir := strings.TrimSpace(out)
+	if dir == "" || dir == "off" {
+		return cacheStats{}, fmt.Errorf("build cache is disabled (GOCACHE=%q)", dir)
+	}
+	return walkCacheDir(dir)
+}
+
+// walkCacheDir 统计目录下所有普通文件的总大小和修改时间范围；目录不存在时返回零值统计
+func walkCacheDir(dir string) (cacheStats, error) {
+	stats := cacheStats{Dir: dir}
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		stats.Size += info.Size()
+		stats.Files++
+		if mt := info.ModTime(); stats.Oldest.IsZero() || mt.Before(stats.Oldest) {
+			stats.Oldest = mt
+		}
+		if mt := info.ModTime(); mt.After(stats.Newest) {
+			stats.Newest = mt
+		}
+		return nil
+	})
+	return stats, err
+}
+
+func logCacheStats(s cacheStats, msg string) {
+	ev := log.Info().Str("dir", s.Dir).Str("size", formatCacheSize(s.Size)).Int("files", s.Files)
+	if s.Files > 0 {
+		ev = ev.Str("oldest", time.Since(s.Oldest).Round(time.Minute).String()+" ago").
+			Str("newest", time.Since(s.Newest).Round(time.Second).String()+" ago")
+	}
+	ev.Msg(msg)
+}
+
+// formatCacheSize 将字节数格式化为 B/KiB/MiB/GiB
+func formatCacheSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit && exp < 2; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMG"[exp])
+}
+
+// cleanBuildCache 执行 go clean -cache -testcache，并在前后报告 GOCACHE 大小
+func cleanBuildCache(verbose bool) error {
+	before, statErr := goCacheStats()
+	if statErr == nil && !executor.Recording() {
+		logCacheStats(before, "GOCACHE before clean")
+	}
+	if err := runGoClean([]string{"-cache", "-testcache"}, nil, verbose); err != nil {
+		return err
+	}
+	if statErr != nil || executor.Recording() {
+		return nil
+	}
+	after, err := walkCacheDir(before.Dir)
+	if err != nil {
+		return err
+	}
+	log.Info().Str("freed", formatCacheSize(max(before.Size-after.Size, 0))).
+		Str("size", formatCacheSize(after.Size)).Msg("GOCACHE after clean")
+	return nil
+}
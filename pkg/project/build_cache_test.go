@@ -0,0 +1,99 @@
+package project
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// 测试 -x 输出解析的边界情况：cgo/asm 等工具不计入编译、heredoc 内容与 cd/rm 等 shell 命令被忽略
+func TestParseToolInvocation(t *testing.T) {
+	cases := []struct {
+		line string
+		tool string
+	}{
+		{"/usr/local/go/pkg/tool/linux_amd64/compile -o $WORK/b001/_pkg_.a -p main ./main.go", "compile"},
+		{`C:\Go\pkg\tool\windows_amd64\link.exe -o $WORK\b001\exe\a.out.exe`, "link"},
+		{"CGO_LDFLAGS='\"-g\" \"-O2\"' /usr/local/go/pkg/tool/linux_amd64/cgo -objdir $WORK/b002/ -importpath net", "cgo"},
+		{"/usr/local/go/pkg/tool/linux_amd64/asm -p internal/bytealg -o $WORK/b003/indexbyte.o ./indexbyte_amd64.s", "asm"},
+		{"packagefile runtime=/root/.cache/go-build/12/1234-d", ""},
+		{"cd /src/app", ""},
+		{"rm -r $WORK/b001/", ""},
+		{"mkdir -p $WORK/b001/exe/", ""},
+		{"", ""},
+	}
+	for _, c := range cases {
+		if got, _ := parseToolInvocation(c.line); got != c.tool {
+			t.Errorf("parseToolInvocation(%q) = %q, want %q", c.line, got, c.tool)
+		}
+	}
+
+	s := &BuildSummary{}
+	for _, c := range cases {
+		s.Observe(c.line)
+	}
+	if strings.Join(s.Compiled, ",") != "main" || s.Links != 1 {
+		t.Errorf("compiled = %v, links = %d", s.Compiled, s.Links)
+	}
+}
+
+// 测试缓存失效原因：按构建参数差异逐项列出，缺少历史记录时给出提示
+func TestExplainCacheBusting(t *testing.T) {
+	prev := BuildState{GoVersion: "go1.24.0", GOOS: "linux", GOARCH: "amd64", Tags: splitBuildTags("json,sqlite"), Packages: []string{"./..."}}
+	cur := prev
+	if r := explainCacheBusting(&prev, cur, false); len(r) != 0 {
+		t.Errorf("identical states should have no reasons, got %v", r)
+	}
+	if r := explainCacheBusting(&prev, cur, true); len(r) != 1 || !strings.Contains(r[0], "-a") {
+		t.Errorf("forced rebuild not reported: %v", r)
+	}
+
+	cur.Tags = splitBuildTags("sqlite json") // 顺序与分隔符不同视为相同
+	if r := explainCacheBusting(&prev, cur, false); len(r) != 0 {
+		t.Errorf("tag order should not matter, got %v", r)
+	}
+
+	cur.GoVersion = "go1.25.0"
+	cur.Tags = []string{"json"}
+	cur.Ldflags = "-s -w"
+	r := explainCacheBusting(&prev, cur, false)
+	want := []string{"Go toolchain changed: go1.24.0 -> go1.25.0", "build tags changed", "-ldflags changed"}
+	if len(r) != len(want) {
+		t.Fatalf("reasons = %v", r)
+	}
+	for i, w := range want {
+		if !strings.Contains(r[i], w) {
+			t.Errorf("reason %d = %q, want it to contain %q", i, r[i], w)
+		}
+	}
+
+	if r := explainCacheBusting(nil, cur, false); len(r) != 1 || !strings.Contains(r[0], "no previous linux/amd64 build") {
+		t.Errorf("missing history not reported: %v", r)
+	}
+}
+
+// 测试 build-state.json 按平台保存，更新一个平台不影响其他平台
+func TestBuildStateByPlatform(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gocli", "build-state.json")
+	if states, err := loadBuildStates(path); err != nil || len(states) != 0 {
+		t.Fatalf("missing file should load as empty, got %v, %v", states, err)
+	}
+	linux := BuildState{GoVersion: "go1.25.0", GOOS: "linux", GOARCH: "amd64"}
+	windows := BuildState{GoVersion: "go1.25.0", GOOS: "windows", GOARCH: "arm64", Ldflags: "-s -w"}
+	for _, st := range []BuildState{linux, windows} {
+		if err := saveBuildState(path, st); err != nil {
+			t.Fatal(err)
+		}
+	}
+	linux.Tags = []string{"json"}
+	if err := saveBuildState(path, linux); err != nil {
+		t.Fatal(err)
+	}
+	states, err := loadBuildStates(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(states) != 2 || states["windows/arm64"].Ldflags != "-s -w" || len(states["linux/amd64"].Tags) != 1 {
+		t.Errorf("unexpected states %+v", states)
+	}
+}
@@ -0,0 +1,86 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"text/template"
+
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// OutputTemplateData 是 --output-template 可使用的占位符
+type OutputTemplateData struct {
+	Name    string // 构建目标目录名，例如 ./cmd/server -> server
+	OS      string // 目标 GOOS
+	Arch    string // 目标 GOARCH
+	Version string // git describe --tags --always --dirty 的结果，不在 git 仓库中时为 "dev"
+	Ext     string // 可执行文件扩展名，windows 为 ".exe"，其他平台为空
+}
+
+// parseOutputTemplate 解析产物名模板，未知字段在执行时报错
+func parseOutputTemplate(tpl string) (*template.Template, error) {
+	t, err := template.New("output").Option("missingkey=error").Parse(tpl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --output-template %q: %w", tpl, err)
+	}
+	return t, nil
+}
+
+// renderOutputTemplate 为指定平台渲染 -o 的值；windows 目标在结果缺少 .exe 时自动补齐
+func renderOutputTemplate(options BuildRunOptions, args []string, goos, goarch string) (string, error) {
+	t, err := parseOutputTemplate(options.OutputTemplate)
+	if err != nil {
+		return "", err
+	}
+	data := OutputTemplateData{
+		Name: defaultOutputName(options, args),
+		OS:   goos,
+		Arch: goarch,
+	}
+	if goos == "windows" {
+		data.Ext = ".exe"
+	}
+	if strings.Contains(options.OutputTemplate, ".Version") {
+		data.Version = buildVersion(options.ChangeDir)
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("render --output-template %q failed: %w", options.OutputTemplate, err)
+	}
+	out := strings.TrimSpace(b.String())
+	if out == "" {
+		return "", fmt.Errorf("--output-template %q rendered an empty output path", options.OutputTemplate)
+	}
+	if goos == "windows" && !strings.HasSuffix(out, ".exe") {
+		out += ".exe"
+	}
+	return out, nil
+}
+
+// hostPlatform 返回未指定 --platforms 时的目标平台，遵循环境变量 GOOS/GOARCH
+func hostPlatform() (string, string) {
+	goos, goarch := os.Getenv("GOOS"), os.Getenv("GOARCH")
+	if goos == "" {
+		goos = runtime.GOOS
+	}
+	if goarch == "" {
+		goarch = runtime.GOARCH
+	}
+	return goos, goarch
+}
+
+// buildVersion 使用 git describe 获取版本号，失败时返回 "dev"
+func buildVersion(dir string) string {
+	ex := executor.NewExecutor("git", "describe", "--tags", "--always", "--dirty").ReadOnly()
+	if dir != "" {
+		ex.WithDir(dir)
+	}
+	out, err := ex.Output()
+	if v := strings.TrimSpace(out); err == nil && v != "" {
+		return v
+	}
+	log.Debug().Err(err).Msg("git describe failed, using version \"dev\"")
+	return "dev"
+}
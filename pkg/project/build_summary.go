@@ -0,0 +1,188 @@
+package project
+
+import (
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// BuildSummary 汇总一次 go build -x 的输出：哪些包被重新编译、哪些命中了构建缓存以及总耗时
+type BuildSummary struct {
+	Compiled []string      // 实际调用 go tool compile 的包（取自 -p 参数）
+	Total    int           // 构建涉及的包总数（go list -deps），无法获取时为 0
+	Links    int           // go tool link 调用次数
+	Duration time.Duration // 构建总耗时
+}
+
+// CacheHits 返回命中构建缓存（未重新编译）的包数量；Total 未知时返回 -1
+func (s *BuildSummary) CacheHits() int {
+	if s.Total == 0 {
+		return -1
+	}
+	return max(s.Total-len(s.Compiled), 0)
+}
+
+// Observe 解析 -x 输出的一行，记录 compile/link 调用
+func (s *BuildSummary) Observe(line string) {
+	tool, args := parseToolInvocation(line)
+	switch tool {
+	case "compile":
+		for i := 0; i+1 < len(args); i++ {
+			if args[i] == "-p" {
+				s.Compiled = append(s.Compiled, args[i+1])
+				return
+			}
+		}
+		s.Compiled = append(s.Compiled, "?")
+	case "link":
+		s.Links++
+	}
+}
+
+// parseToolInvocation 识别 -x 输出中的 go tool 调用，例如
+// "/usr/local/go/pkg/tool/linux_amd64/compile -o $WORK/b001/_pkg_.a -p main ..."
+// 或带环境变量前缀的 "GOROOT='/usr/local/go' .../link -o ..."，返回工具名与其后的参数
+func parseToolInvocation(line string) (string, []string) {
+	fields := splitShellFields(line)
+	for i, f := range fields {
+		// 跳过环境变量前缀（值中可能含路径），例如 GOROOT='/usr/local/go'
+		if k, _, ok := strings.Cut(f, "="); ok && k != "" && !strings.ContainsAny(k, `/\$`) {
+			continue
+		}
+		// 统一分隔符，以便同时识别 Windows 下的 ...\pkg\tool\windows_amd64\compile.exe
+		f = strings.ReplaceAll(f, `\`, "/")
+		if !strings.Contains(path.Dir(f), "pkg/tool/") {
+			return "", nil
+		}
+		name := strings.TrimSuffix(path.Base(f), ".exe")
+		return name, fields[i+1:]
+	}
+	return "", nil
+}
+
+// splitShellFields 按空白拆分 -x 打印的命令行，引号内的空白不拆分（引号本身保留）
+// 例如 CGO_LDFLAGS='"-g" "-O2"' 作为一个字段
+func splitShellFields(line string) []string {
+	var fields []string
+	var cur strings.Builder
+	var quote rune
+	inField := false
+	for _, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == ' ' || r == '\t':
+			if inField {
+				fields = append(fields, cur.String())
+				cur.Reset()
+				inField = false
+			}
+			continue
+		}
+		cur.WriteRune(r)
+		inField = true
+	}
+	if inField {
+		fields = append(fields, cur.String())
+	}
+	return fields
+}
+
+// runBuildWithSummary 以 -x 运行 go build 并在结束后输出构建摘要
+// 用户未显式指定 -x 时，-x 的命令行只在 debug 级别输出，编译错误等诊断信息照常显示
+func runBuildWithSummary(options BuildRunOptions, args []string, env ...string) error {
+	summary, err := collectBuildSummary(options, args, env...)
+	if summary != nil {
+		logBuildSummary(summary, options.V)
+	}
+	return err
+}
+
+// collectBuildSummary 以 -x 运行 go build 并解析其输出；录制模式（--dry-run）下返回 nil 摘要
+func collectBuildSummary(options BuildRunOptions, args []string, env ...string) (*BuildSummary, error) {
+	showX := options.X
+	options.X = true
+	goArgs := goProcessArgs("build", options, args)
+	pkgArgs, _ := processTargets(args)
+
+	summary := &BuildSummary{}
+	inHeredoc := false
+	onStderr := func(line string) {
+		summary.Observe(line)
+		switch {
+		case showX:
+			log.Warn().Msg(line)
+		case inHeredoc:
+			inHeredoc = line != "EOF"
+			log.Debug().Msg(line)
+		case strings.Contains(line, "<< 'EOF'"):
+			inHeredoc = true
+			log.Debug().Msg(line)
+		case isBuildDiagnostic(line):
+			log.Warn().Msg(line)
+		default:
+			log.Debug().Msg(line)
+		}
+	}
+
+	start := time.Now()
+	err := runGoCommandLines(options, goArgs, onStderr, env...)
+	summary.Duration = time.Since(start)
+	if executor.Recording() {
+		return nil, err
+	}
+	summary.Total = buildTotalPackages(options, pkgArgs)
+	return summary, err
+}
+
+// goPosition 匹配编译器诊断中的源码位置，例如 ./main.go:12:3:
+var goPosition = regexp.MustCompile(`\.go:\d+(:\d+)?: `)
+
+// isBuildDiagnostic 判断 stderr 行是否为诊断信息（而非 -x 打印的命令）
+func isBuildDiagnostic(line string) bool {
+	return strings.HasPrefix(line, "# ") ||
+		strings.HasPrefix(line, "go: ") ||
+		strings.HasPrefix(line, "package ") ||
+		goPosition.MatchString(line)
+}
+
+// buildTotalPackages 使用 go list -deps 统计构建涉及的包数量（只读查询）
+func buildTotalPackages(options BuildRunOptions, pkgs []string) int {
+	args := []string{"list", "-deps", "-f", "{{.ImportPath}}"}
+	if options.Tags != "" {
+		args = append(args, "-tags", options.Tags)
+	}
+	args = append(args, pkgs...)
+	ex := executor.NewExecutor("go", args...).ReadOnly()
+	if options.ChangeDir != "" {
+		ex.WithDir(options.ChangeDir)
+	}
+	out, err := ex.Output()
+	if err != nil {
+		log.Debug().Err(err).Msg("go list -deps failed, cache hits unknown")
+		return 0
+	}
+	return len(strings.Fields(out))
+}
+
+// logBuildSummary 输出构建摘要；verbose 时列出被重新编译的包
+func logBuildSummary(s *BuildSummary, verbose bool) {
+	ev := log.Info().
+		Int("compiled", len(s.Compiled)).
+		Int("links", s.Links).
+		Str("duration", s.Duration.Round(time.Millisecond).String())
+	if hits := s.CacheHits(); hits >= 0 {
+		ev = ev.Int("packages", s.Total).Int("cached", hits)
+	}
+	ev.Msg("Build summary")
+	if verbose && len(s.Compiled) > 0 {
+		log.Info().Msgf("Recompiled packages: %s", strings.Join(s.Compiled, ", "))
+	}
+}
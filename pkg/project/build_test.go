@@ -0,0 +1,131 @@
+package project
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// 测试 "--" 之后的参数原样转发给被运行的程序
+func TestGoProcessArgs_ProgramArgs(t *testing.T) {
+	cases := []struct {
+		command string
+		args    []string
+		want    string
+	}{
+		{"run", []string{"./cmd/server", "--", "--port", "9000"}, "run ./cmd/server --port 9000"},
+		{"run", []string{"--", "-v"}, "run . -v"},
+		{"run", []string{"main.go", "util.go", "--", "--", "x"}, "run main.go util.go -- x"},
+		// 兼容旧用法：第一个参数之后的内容转发给程序
+		{"run", []string{"./cmd/server", "serve"}, "run ./cmd/server serve"},
+		{"run", nil, "run ."},
+		{"build", []string{"./cmd/server", "--", "--port", "9000"}, "build ./cmd/server"},
+		{"build", []string{"./cmd/server", "extra"}, "build ./cmd/server"},
+	}
+	for _, c := range cases {
+		got := strings.Join(goProcessArgs(c.command, BuildRunOptions{}, c.args), " ")
+		if got != c.want {
+			t.Errorf("goProcessArgs(%s, %q) = %q, want %q", c.command, c.args, got, c.want)
+		}
+	}
+}
+
+// 测试从 -x 输出中识别 compile/link 调用，忽略 heredoc 与其他命令
+func TestBuildSummary_Observe(t *testing.T) {
+	lines := []string{
+		"WORK=/tmp/go-build123",
+		"mkdir -p $WORK/b001/",
+		"cat >/tmp/go-build123/b001/importcfg << 'EOF' # internal",
+		"packagefile fmt=/root/.cache/go-build/ab/abc-d",
+		"EOF",
+		"cd /src/app",
+		"/usr/local/go/pkg/tool/linux_amd64/compile -o $WORK/b001/_pkg_.a -trimpath \"$WORK/b001=>\" -p main -lang=go1.25 ./main.go",
+		`C:\Go\pkg\tool\windows_amd64\compile.exe -o $WORK\b002\_pkg_.a -p example.com/app/util ./util.go`,
+		"/usr/local/go/pkg/tool/linux_amd64/buildid -w $WORK/b001/_pkg_.a # internal",
+		"GOROOT='/usr/local/go' /usr/local/go/pkg/tool/linux_amd64/link -o $WORK/b001/exe/a.out -buildmode=exe $WORK/b001/_pkg_.a",
+	}
+	s := &BuildSummary{Total: 5}
+	for _, l := range lines {
+		s.Observe(l)
+	}
+	if strings.Join(s.Compiled, ",") != "main,example.com/app/util" {
+		t.Errorf("unexpected compiled packages: %v", s.Compiled)
+	}
+	if s.Links != 1 {
+		t.Errorf("links = %d, want 1", s.Links)
+	}
+	if s.CacheHits() != 3 {
+		t.Errorf("cache hits = %d, want 3", s.CacheHits())
+	}
+	if (&BuildSummary{}).CacheHits() != -1 {
+		t.Error("cache hits should be unknown when total is 0")
+	}
+}
+
+// 测试 --output-template 按目标平台渲染产物路径
+func TestRenderOutputTemplate(t *testing.T) {
+	opts := BuildRunOptions{}
+	opts.OutputTemplate = "dist/{{.Name}}_{{.OS}}_{{.Arch}}"
+	cases := []struct {
+		goos, goarch string
+		want         string
+	}{
+		{"linux", "amd64", "dist/server_linux_amd64"},
+		{"darwin", "arm64", "dist/server_darwin_arm64"},
+		{"windows", "amd64", "dist/server_windows_amd64.exe"},
+	}
+	for _, c := range cases {
+		got, err := renderOutputTemplate(opts, []string{"./cmd/server"}, c.goos, c.goarch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want {
+			t.Errorf("renderOutputTemplate(%s/%s) = %q, want %q", c.goos, c.goarch, got, c.want)
+		}
+	}
+
+	// 显式使用 {{.Ext}} 时不重复追加 .exe
+	opts.OutputTemplate = "bin/{{.Name}}{{.Ext}}"
+	if got, _ := renderOutputTemplate(opts, []string{"./cmd/server"}, "windows", "arm64"); got != "bin/server.exe" {
+		t.Errorf("unexpected windows output %q", got)
+	}
+
+	opts.OutputTemplate = "bin/{{.Nmae}}"
+	if _, err := renderOutputTemplate(opts, nil, "linux", "amd64"); err == nil {
+		t.Error("expected error for unknown placeholder")
+	}
+}
+
+// 测试运行同一 main 包的多个 .go 文件（gocli project run main.go util.go -- -v）：
+// go list 把它们报告为一个 command-line-arguments 包，不能当作多个入口
+func TestExecuteRunCommand_GoFiles(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+	dir := t.TempDir()
+	out := filepath.Join(dir, "args.txt")
+	files := map[string]string{
+		"go.mod":  "module example.com/demo\n\ngo 1.21\n",
+		"main.go": "package main\n\nimport (\n\t\"os\"\n\t\"strings\"\n)\n\nfunc main() {\n\t_ = os.WriteFile(" + strconv.Quote(out) + ", []byte(greeting()+\" \"+strings.Join(os.Args[1:], \" \")), 0o644)\n}\n",
+		"util.go": "package main\n\nfunc greeting() string { return \"hello\" }\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("GOFLAGS", "")
+	if err := ExecuteRunCommand(nil, BuildRunOptions{ChangeDir: dir}, []string{"main.go", "util.go", "--", "-v"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "hello -v" {
+		t.Errorf("program wrote %q, want %q", got, "hello -v")
+	}
+}
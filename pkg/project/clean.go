@@ -0,0 +1,290 @@
+package project
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// CleanOptions 是 `project clean` 的选项
+type CleanOptions struct {
+	Cache     bool     // -cache: 删除整个 go 构建缓存
+	TestCache bool     // -testcache: 使构建缓存中的所有测试结果过期
+	ModCache  bool     // -modcache: 删除整个模块下载缓存
+	Dist      bool     // --dist: 删除构建输出目录
+	DistDirs  []string // 要删除的输出目录，为空时使用配置 clean.dirs
+	Verbose   bool
+
+	Bin        bool // --bin: 输出目录（同 --dist）以及测试二进制 *.test
+	Cover      bool // --cover: coverage.out、*.coverprofile
+	Profiles   bool // --profiles: *.prof、trace.out、capture.trace
+	State      bool // --state: .gocli/ 下 gocli 自身的状态文件
+	All        bool // --all: 以上所有类别
+	TrackedToo bool // --tracked-too: 同时删除 git 已跟踪的文件（默认跳过）
+}
+
+// RunClean 执行 go clean 并按需删除构建产物
+//   - 未指定任何选项时执行 go clean [packages]，并删除未被 git 跟踪的覆盖率和 profile 文件
+//   - 只指定产物类别（--bin/--cover/--profiles/--state/--all/--dist）且没有包参数时不调用 go clean
+//   - 产物只在模块根目录（go.mod 所在目录，找不到时为当前目录）之内查找，不跟随符号链接
+//   - 录制模式（--dry-run）下 go clean 仅被记录，产物只打印不删除
+func RunClean(opts CleanOptions, out io.Writer, args []string) error {
+	goFlags := cleanGoFlags(opts)
+	explicit := opts.Dist || opts.Bin || opts.Cover || opts.Profiles || opts.State || opts.All
+	if len(goFlags) > 0 || len(args) > 0 || !explicit {
+		if err := runGoClean(goFlags, args, opts.Verbose); err != nil {
+			return err
+		}
+	}
+	if !explicit {
+		if len(goFlags) > 0 {
+			return nil
+		}
+		// 默认的安全子集：可重新生成的覆盖率与 profile 文件
+		opts.Cover, opts.Profiles = true, true
+	}
+	if opts.All {
+		opts.Bin, opts.Cover, opts.Profiles, opts.State = true, true, true, true
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory failed: %w", err)
+	}
+	root := findModuleRoot(wd)
+	tracked := gitTrackedFiles(root)
+	if opts.Dist || opts.Bin {
+		if err := removeDistDirs(opts.DistDirs, root, tracked, opts.TrackedToo, out); err != nil {
+			return err
+		}
+	}
+	files, err := collectCleanFiles(root, cleanPatterns(opts), opts.State)
+	if err != nil {
+		return err
+	}
+	return removeCleanFiles(root, files, tracked, opts.TrackedToo, out)
+}
+
+func cleanGoFlags(opts CleanOptions) []string {
+	var flags []string
+	if opts.Cache {
+		flags = append(flags, "-cache")
+	}
+	if opts.TestCache {
+		flags = append(flags, "-testcache")
+	}
+	if opts.ModCache {
+		flags = append(flags, "-modcache")
+	}
+	return flags
+}
+
+func runGoClean(flags, args []string, verbose bool) error {
+	goArgs := append([]string{"clean"}, flags...)
+	if verbose {
+		goArgs = append(goArgs, "-x")
+	}
+	goArgs = append(goArgs, args...)
+	log.Info().Msg("go " + strings.Join(goArgs, " "))
+	return executor.NewExecutor("go", goArgs...).RunLines(
+		func(line string) { log.Info().Msg(line) },
+		func(line string) { log.Warn().Msg(line) },
+	)
+}
+
+// removeDistDirs 删除构建输出目录；目录必须位于当前工作目录之内，避免误删
+// 目录中包含 git 已跟踪的文件时跳过（除非 trackedToo）
+func removeDistDirs(dirs []string, root string, tracked map[string]bool, trackedToo bool, out io.Writer) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory failed: %w", err)
+	}
+	if len(dirs) == 0 {
+		return fmt.Errorf("no output directories configured (set clean.dirs or pass --dist-dir)")
+	}
+	for _, d := range dirs {
+		target, err := resolveDistDir(wd, d)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Lstat(target); os.IsNotExist(err) {
+			log.Debug().Str("dir", target).Msg("output directory does not exist, skipping")
+			continue
+		}
+		if !trackedToo && hasTrackedUnder(root, target, tracked) {
+			log.Warn().Str("dir", target).Msg("output directory contains files tracked by git, skipping (use --tracked-too)")
+			continue
+		}
+		if executor.Recording() {
+			fmt.Fprintf(out, "[dry-run] would remove %s\n", target)
+			continue
+		}
+		// RemoveAll 只删除符号链接本身，不会进入链接指向的目录
+		if err := os.RemoveAll(target); err != nil {
+			return fmt.Errorf("remove %s failed: %w", target, err)
+		}
+		fmt.Fprintf(out, "removed %s\n", target)
+	}
+	return nil
+}
+
+// resolveDistDir 将 d 解析为 wd 下的绝对路径，拒绝 wd 本身及其之外的路径
+func resolveDistDir(wd, d string) (string, error) {
+	target := d
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(wd, target)
+	}
+	target = filepath.Clean(target)
+	rel, err := filepath.Rel(wd, target)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || filepath.IsAbs(rel) {
+		return "", fmt.Errorf("refusing to remove %q: output directories must be inside %s", d, wd)
+	}
+	return target, nil
+}
+
+// cleanStateFiles 是 --state 删除的 gocli 状态文件（相对模块根目录）；
+// .gocli/ 下的模板、工具和日志等用户内容不在此列
+var cleanStateFiles = []string{buildStateFile}
+
+// cleanPatterns 返回所选类别对应的文件名匹配模式（filepath.Match 语法，匹配文件名）
+func cleanPatterns(opts CleanOptions) []string {
+	var patterns []string
+	if opts.Bin {
+		patterns = append(patterns, "*.test")
+	}
+	if opts.Cover {
+		patterns = append(patterns, "coverage.out", "*.coverprofile")
+	}
+	if opts.Profiles {
+		patterns = append(patterns, "*.prof", "trace.out", "capture.trace")
+	}
+	return patterns
+}
+
+// findModuleRoot 从 dir 向上查找 go.mod 所在目录，找不到时返回 dir
+func findModuleRoot(dir string) string {
+	for d := dir; ; {
+		if _, err := os.Stat(filepath.Join(d, "go.mod")); err == nil {
+			return d
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return dir
+		}
+		d = parent
+	}
+}
+
+// collectCleanFiles 遍历 root 收集文件名匹配 patterns 的普通文件，跳过隐藏目录和 vendor；
+// WalkDir 不跟随符号链接，符号链接文件本身也不会被收集。state 为 true 时加入存在的状态文件
+func collectCleanFiles(root string, patterns []string, state bool) ([]string, error) {
+	var files []string
+	if len(patterns) > 0 {
+		err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if d.IsDir() {
+				if p != root && (strings.HasPrefix(d.Name(), ".") || d.Name() == "vendor") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			for _, pat := range patterns {
+				if ok, _ := filepath.Match(pat, d.Name()); ok {
+					files = append(files, p)
+					break
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if state {
+		for _, f := range cleanStateFiles {
+			p := filepath.Join(root, filepath.FromSlash(f))
+			if fi, err := os.Lstat(p); err == nil && fi.Mode().IsRegular() {
+				files = append(files, p)
+			}
+		}
+	}
+	return files, nil
+}
+
+// removeCleanFiles 删除收集到的文件并逐个打印；git 已跟踪的文件默认跳过，解析后位于 root 之外的路径一律拒绝
+func removeCleanFiles(root string, files []string, tracked map[string]bool, trackedToo bool, out io.Writer) error {
+	for _, f := range files {
+		if !insideRoot(root, f) {
+			log.Warn().Str("file", f).Msg("refusing to remove a file outside the module root")
+			continue
+		}
+		rel, _ := filepath.Rel(root, f)
+		if tracked[filepath.ToSlash(rel)] && !trackedToo {
+			log.Info().Str("file", rel).Msg("skipping file tracked by git (use --tracked-too)")
+			continue
+		}
+		if executor.Recording() {
+			fmt.Fprintf(out, "[dry-run] would remove %s\n", rel)
+			continue
+		}
+		if err := os.Remove(f); err != nil {
+			return fmt.Errorf("remove %s failed: %w", f, err)
+		}
+		fmt.Fprintf(out, "removed %s\n", rel)
+	}
+	return nil
+}
+
+// insideRoot 报告 p 所在目录在解析符号链接后是否仍位于 root 之内
+func insideRoot(root, p string) bool {
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return false
+	}
+	dir, err := filepath.EvalSymlinks(filepath.Dir(p))
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(realRoot, dir)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+// gitTrackedFiles 返回 root 下被 git 跟踪的文件（相对 root 的 / 分隔路径）；不是 git 仓库时返回空集合
+func gitTrackedFiles(root string) map[string]bool {
+	tracked := map[string]bool{}
+	out, err := executor.NewExecutor("git", "ls-files", "-z").WithDir(root).ReadOnly().Output()
+	if err != nil {
+		return tracked
+	}
+	for _, f := range strings.Split(out, "\x00") {
+		if f != "" {
+			tracked[f] = true
+		}
+	}
+	return tracked
+}
+
+// hasTrackedUnder 报告 dir 下是否有 git 已跟踪的文件
+func hasTrackedUnder(root, dir string, tracked map[string]bool) bool {
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return false
+	}
+	prefix := filepath.ToSlash(rel) + "/"
+	for f := range tracked {
+		if strings.HasPrefix(f, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package project
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+// 测试 --dist 只允许删除工作目录之内的目录
+func TestResolveDistDir(t *testing.T) {
+	wd := filepath.FromSlash("/work/app")
+	ok := map[string]string{
+		"dist":                              filepath.FromSlash("/work/app/dist"),
+		"./bin/":                            filepath.FromSlash("/work/app/bin"),
+		filepath.FromSlash("/work/app/out"): filepath.FromSlash("/work/app/out"),
+	}
+	for in, want := range ok {
+		got, err := resolveDistDir(wd, in)
+		if err != nil || got != want {
+			t.Errorf("resolveDistDir(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{".", "..", "../other", "dist/../..", filepath.FromSlash("/tmp")} {
+		if _, err := resolveDistDir(wd, bad); err == nil {
+			t.Errorf("resolveDistDir(%q) should be refused", bad)
+		}
+	}
+}
+
+// 测试产物收集不跟随符号链接：指向根目录之外的链接目录及链接文件都不会被删除，已跟踪文件默认跳过
+func TestCleanFilesPathSafety(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require extra privileges on windows")
+	}
+	root, outside := t.TempDir(), t.TempDir()
+	for _, f := range []string{
+		filepath.Join(outside, "victim.prof"),
+		filepath.Join(root, "cpu.prof"),
+		filepath.Join(root, "tracked.prof"),
+		filepath.Join(root, "sub", "coverage.out"),
+		filepath.Join(root, ".hidden", "mem.prof"),
+	} {
+		if err := os.MkdirAll(filepath.Dir(f), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(f, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(outside, filepath.Join(root, "escape")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(outside, "victim.prof"), filepath.Join(root, "link.prof")); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := collectCleanFiles(root, cleanPatterns(CleanOptions{Cover: true, Profiles: true}), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rels []string
+	for _, f := range files {
+		rel, _ := filepath.Rel(root, f)
+		rels = append(rels, filepath.ToSlash(rel))
+	}
+	slices.Sort(rels)
+	if want := []string{"cpu.prof", "sub/coverage.out", "tracked.prof"}; !slices.Equal(rels, want) {
+		t.Fatalf("collected %v, want %v", rels, want)
+	}
+	if insideRoot(root, filepath.Join(root, "escape", "victim.prof")) {
+		t.Error("path through a symlink leaving the root should be rejected")
+	}
+
+	if err := removeCleanFiles(root, files, map[string]bool{"tracked.prof": true}, false, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	for f, exists := range map[string]bool{
+		filepath.Join(root, "cpu.prof"):            false,
+		filepath.Join(root, "tracked.prof"):        true,
+		filepath.Join(root, "sub", "coverage.out"): false,
+		filepath.Join(outside, "victim.prof"):      true,
+	} {
+		_, err := os.Stat(f)
+		if (err == nil) != exists {
+			t.Errorf("%s exists=%v, want %v", f, err == nil, exists)
+		}
+	}
+}
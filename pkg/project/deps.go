@@ -1,18 +1,24 @@
 package project
 
 import (
+	"bufio"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/yeisme/gocli/pkg/style"
 	"github.com/yeisme/gocli/pkg/utils/deps"
+	"github.com/yeisme/gocli/pkg/utils/executor"
 )
 
 // DepsOptions 定义了 `gocli project deps` 的各类选项，用于覆盖大部分 `go mod` 能力：
 //   - 输出控制: Graph/Tree/JSON
 //   - 版本更新: Update (透传 -u)
 //   - 子命令包装: Tidy/Vendor/Download/Verify/Why 及其附加开关
+//   - go.mod 编辑: Replace/DropReplace（go mod edit）
 type DepsOptions struct {
 	// 输出样式
 	Graph bool // 生成依赖关系图
@@ -22,6 +28,10 @@ type DepsOptions struct {
 	Update  bool // 检查可用的更新
 	Verbose bool
 
+	// Interactive 与 Update 一起使用时逐个询问并升级存在更新的模块（y/n/patch），Input 为交互输入源（默认 os.Stdin）
+	Interactive bool
+	Input       io.Reader
+
 	// extra go mod subcommands
 	Tidy      bool // go mod tidy
 	Vendor    bool // go mod vendor
@@ -30,16 +40,41 @@ type DepsOptions struct {
 	Why       bool // go mod why
 	WhyModule bool // go mod why -m
 	WhyVendor bool // go mod why -vendor
+	WhyPaths  bool // go mod why 并结合 go mod graph 输出主模块到目标模块的完整依赖链（隐含 Why）
+
+	// AllowDirty 允许在 go.mod/go.sum 有未提交修改时执行 tidy/vendor/download（--allow-dirty）
+	AllowDirty bool
+	// Prompt 非空时（终端上）go.mod/go.sum 有未提交修改会在 Prompt 上询问是否继续（读取 Input），否则直接报错
+	Prompt io.Writer
+	// TidyDiff 在 go.mod/go.sum 的临时副本上执行 go mod tidy，只报告将要发生的变化（--tidy-diff）
+	TidyDiff bool
+
+	// replace 指令编辑，在维护类子命令之前执行（可与 Tidy 组合）
+	Replace     []string // go mod edit -replace，形如 old[@v]=new[@v]
+	DropReplace []string // go mod edit -dropreplace，形如 old[@v]
+
+	// go.mod 指令检查
+	Directives    bool // 列出 replace/exclude/retract 指令
+	CheckReplaces bool // 存在文件系统 replace 时返回错误（用于 CI）
+
+	// Workspace 非空时在每个 go.work 模块中分别执行（--workspace），见 runDepsWorkspace
+	Workspace []WorkspaceModule
 }
 
 // RunDeps 根据传入的 DepsOptions 执行依赖相关操作，并将结果写入 out
 //
-// 行为优先级:
-//  1. 若开启 Tidy/Vendor/Download/Verify/Why，其对应的 `go mod` 子命令将被优先执行并返回；
-//  2. 其次若开启 Tree/Graph：
+// 行为优先级（Workspace 非空时先按工作区模块拆分，每个模块按以下顺序执行，见 runDepsWorkspace）:
+//  1. 若开启 Replace/DropReplace/Tidy/TidyDiff/Vendor/Download/Verify/Why，其对应的 `go mod` 子命令将被优先执行并返回
+//     （replace 编辑先于维护类子命令执行，维护类子命令可组合，修改模块文件前检查 go.mod/go.sum 是否有未提交修改）；
+//  2. 若同时开启 Update 与 Interactive，逐个询问并升级存在更新的模块（RunInteractiveUpgrade）；
+//     其次若开启 CheckReplaces/Directives，解析 go.mod 中的 replace/exclude/retract 指令：
+//     - CheckReplaces: 列出文件系统 replace，存在时返回错误；
+//     - Directives: 输出指令视图（JSON 时为结构化字段，Update 时额外查询被撤回的依赖版本）；
+//  3. 其次若开启 Tree/Graph：
 //     - Tree: 基于 `go mod graph` 构建 DAG，并以树形样式渲染；
 //     - Graph: 直接输出 `go mod graph` 的原始文本；
-//  3. 其他情况下，默认执行 `go list -m`（可加 -json、-u），args 作为目标（默认 all）
+//  4. 其他情况下，默认执行 `go list -m`（可加 -json、-u），args 作为目标（默认 all）；
+//     若 go.mod 含有 replace/exclude，文本输出会在末尾附加简短的警告段落，JSON 输出则追加一个 {"directives": ...} 对象
 //
 // 参数:
 //   - options: 控制输出风格与子命令行为；
@@ -49,66 +84,556 @@ type DepsOptions struct {
 // 返回:
 //   - error: 命令执行或解析过程中的错误
 func RunDeps(options DepsOptions, out io.Writer, args []string) error {
+	if len(options.Workspace) > 0 {
+		return runDepsWorkspace(options, out, args)
+	}
+
 	// 1) 优先处理显式 go mod 子命令
 	if handled, err := handleGoModSubcommands(options, out, args); handled || err != nil {
 		return err
 	}
 
-	// 2) 依赖树视图
+	// 交互式升级
+	if options.Update && options.Interactive {
+		in := options.Input
+		if in == nil {
+			in = os.Stdin
+		}
+		return RunInteractiveUpgrade(in, out, args, options.Verbose)
+	}
+
+	// 2) go.mod 指令
+	if options.CheckReplaces {
+		return checkLocalReplaces(out)
+	}
+	if options.Directives {
+		return renderDirectives(options, out)
+	}
+
+	// 3) 依赖树视图
 	if options.Tree {
 		return renderDepsTree(out)
 	}
 
-	// 3) 原始依赖图
+	// 4) 原始依赖图
 	if options.Graph {
 		return printRawGraph(out)
 	}
 
-	// 4) 默认：go list -m
-	return runGoList(options, out, args)
+	// 5) 默认：go list -m
+	if err := runGoList(options, out, args); err != nil {
+		return err
+	}
+	if options.JSON {
+		return printDirectivesJSON(out)
+	}
+	printDirectivesWarning(out)
+	return nil
 }
 
-// handleGoModSubcommands 处理 go mod 类子命令；若已处理，返回 handled=true
-func handleGoModSubcommands(options DepsOptions, out io.Writer, args []string) (bool, error) {
+// runDepsWorkspace 在每个工作区模块目录中执行 RunDeps，文本输出在每个模块前加上模块标题，
+// JSON 输出按模块顺序依次拼接。执行时设置 GOWORK=off，使依赖列表、依赖图等只反映该模块自己的 go.mod，
+// 而不是整个工作区合并后的构建列表
+func runDepsWorkspace(options DepsOptions, out io.Writer, args []string) error {
+	single := options
+	single.Workspace = nil
+	return inWorkspaceModules(options.Workspace, true, func(m WorkspaceModule) error {
+		if !options.JSON {
+			_ = style.PrintHeading(out, m.Path)
+		}
+		return RunDeps(single, out, args)
+	})
+}
+
+// DepsJSONMode 按 RunDeps 的优先级报告当前选项下的输出能否为 JSON：
+//   - supported: 默认的 go list -m、--why 与 --directives 支持 JSON，其余视图只有文本输出
+//   - stream: 默认的 go list -m -json 输出为连续的 JSON 对象，而不是单个值
+func DepsJSONMode(options DepsOptions) (supported, stream bool) {
 	switch {
-	case options.Tidy:
-		output, err := deps.RunGoModTidy()
+	case len(options.Replace) > 0 || len(options.DropReplace) > 0 || options.Tidy || options.TidyDiff || options.Vendor || options.Download || options.Verify:
+		return false, false
+	case options.Why || options.WhyPaths:
+		return true, false
+	case options.Update && options.Interactive:
+		return false, false
+	case options.CheckReplaces:
+		return false, false
+	case options.Directives:
+		return true, false
+	case options.Tree || options.Graph:
+		return false, false
+	}
+	return true, true
+}
+
+// loadDirectives 定位当前模块的 go.mod 并解析其中的指令
+func loadDirectives() (*deps.Directives, error) {
+	gomod, err := deps.FindGoMod()
+	if err != nil {
+		return nil, err
+	}
+	return deps.ParseDirectives(gomod)
+}
+
+// checkLocalReplaces 列出文件系统 replace；存在任意一条时返回错误，便于 CI 拦截误提交的本地替换
+func checkLocalReplaces(out io.Writer) error {
+	d, err := loadDirectives()
+	if err != nil {
+		return err
+	}
+	local := d.LocalReplaces()
+	if len(local) == 0 {
+		fmt.Fprintf(out, "no filesystem replace directives in %s\n", d.GoMod)
+		return nil
+	}
+	items := make([]any, 0, len(local))
+	for _, r := range local {
+		items = append(items, formatReplace(r))
+	}
+	if err := style.PrintHeading(out, "Filesystem replace directives"); err != nil {
+		return err
+	}
+	if err := style.PrintList(out, items...); err != nil {
+		return err
+	}
+	return fmt.Errorf("%s contains %d filesystem replace directive(s)", d.GoMod, len(local))
+}
+
+// renderDirectives 渲染 replace/exclude/retract 指令视图
+func renderDirectives(options DepsOptions, out io.Writer) error {
+	d, err := loadDirectives()
+	if err != nil {
+		return err
+	}
+	if options.Update {
+		if err := d.CheckRetracted(); err != nil {
+			return err
+		}
+	}
+	if options.JSON {
+		s, err := style.FormatJSON(map[string]any{"directives": d})
 		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(out, s)
+		return err
+	}
+	if d.Empty() {
+		fmt.Fprintf(out, "no replace, exclude or retract directives in %s\n", d.GoMod)
+		return nil
+	}
+
+	if len(d.Replaces) > 0 {
+		if err := style.PrintHeading(out, "Replace"); err != nil {
+			return err
+		}
+		rows := make([][]string, 0, len(d.Replaces))
+		for _, r := range d.Replaces {
+			kind, status := "module", "-"
+			if r.Local {
+				kind, status = "filesystem", "exists"
+				if !r.Exists {
+					status = "missing"
+				}
+			}
+			rows = append(rows, []string{joinModVersion(r.Old, r.OldVersion), joinModVersion(r.New, r.NewVersion), kind, status})
+		}
+		if err := style.PrintTable(out, []string{"Old", "New", "Kind", "Status"}, rows, 0); err != nil {
+			return err
+		}
+	}
+	if len(d.Excludes) > 0 {
+		if err := style.PrintHeading(out, "Exclude"); err != nil {
+			return err
+		}
+		items := make([]any, 0, len(d.Excludes))
+		for _, e := range d.Excludes {
+			items = append(items, joinModVersion(e.Path, e.Version))
+		}
+		if err := style.PrintList(out, items...); err != nil {
+			return err
+		}
+	}
+	if len(d.Retracts) > 0 {
+		if err := style.PrintHeading(out, "Retract"); err != nil {
+			return err
+		}
+		items := make([]any, 0, len(d.Retracts))
+		for _, r := range d.Retracts {
+			items = append(items, formatRetract(r))
+		}
+		if err := style.PrintList(out, items...); err != nil {
+			return err
+		}
+	}
+	if len(d.RetractedRequires) > 0 {
+		if err := style.PrintHeading(out, "Retracted requirements"); err != nil {
+			return err
+		}
+		items := make([]any, 0, len(d.RetractedRequires))
+		for _, r := range d.RetractedRequires {
+			items = append(items, joinModVersion(r.Path, r.Version)+": "+strings.Join(r.Retracted, "; "))
+		}
+		return style.PrintList(out, items...)
+	}
+	return nil
+}
+
+// warnedDirectives 返回默认列表末尾需要提示的指令：go.mod 含有 replace/exclude 时才返回，
+// 不在模块中或解析失败时返回 nil
+func warnedDirectives() *deps.Directives {
+	d, err := loadDirectives()
+	if err != nil || (len(d.Replaces) == 0 && len(d.Excludes) == 0) {
+		return nil
+	}
+	return d
+}
+
+// printDirectivesJSON 在 go list -m -json 的对象流之后追加 {"directives": ...} 对象，与文本输出末尾的提示对应
+func printDirectivesJSON(out io.Writer) error {
+	d := warnedDirectives()
+	if d == nil {
+		return nil
+	}
+	s, err := style.FormatJSON(map[string]any{"directives": d})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, s)
+	return err
+}
+
+// printDirectivesWarning 在默认列表输出后附加 replace/exclude 的简短提示；不在模块中或解析失败时静默跳过
+func printDirectivesWarning(out io.Writer) {
+	d := warnedDirectives()
+	if d == nil {
+		return
+	}
+	fmt.Fprintln(out)
+	_ = style.PrintHeading(out, "Warning: go.mod directives (see --directives)")
+	items := make([]any, 0, len(d.Replaces)+len(d.Excludes))
+	for _, r := range d.Replaces {
+		items = append(items, formatReplace(r))
+	}
+	for _, e := range d.Excludes {
+		items = append(items, "exclude "+joinModVersion(e.Path, e.Version))
+	}
+	_ = style.PrintList(out, items...)
+}
+
+// formatReplace 将 replace 指令格式化为单行文本，本地替换附带路径状态
+func formatReplace(r deps.Replace) string {
+	s := "replace " + joinModVersion(r.Old, r.OldVersion) + " => " + joinModVersion(r.New, r.NewVersion)
+	if r.Local {
+		if r.Exists {
+			return s + " (filesystem)"
+		}
+		return s + " (filesystem, missing: " + r.Dir + ")"
+	}
+	return s
+}
+
+func formatRetract(r deps.Retract) string {
+	s := r.Low
+	if r.High != r.Low {
+		s = "[" + r.Low + ", " + r.High + "]"
+	}
+	if r.Rationale != "" {
+		s += ": " + r.Rationale
+	}
+	return s
+}
+
+func joinModVersion(path, version string) string {
+	if version == "" {
+		return path
+	}
+	return path + "@" + version
+}
+
+// handleGoModSubcommands 处理 go mod 类子命令；若已处理，返回 handled=true
+// 先执行 replace 编辑，维护类子命令可以组合使用，按 tidy -> vendor -> download -> verify 的顺序依次执行，任一失败即停止：
+//   - tidy/vendor/download 会修改模块文件，执行前要求 go.mod/go.sum 没有未提交修改（见 guardDirtyModFiles）
+//   - tidy 之后输出 go.mod/go.sum 的变化摘要；--tidy-diff 只在临时副本上执行 tidy 并报告变化
+func handleGoModSubcommands(options DepsOptions, out io.Writer, args []string) (bool, error) {
+	handled := false
+	if options.TidyDiff {
+		handled = true
+		if err := printTidyDiff(out); err != nil {
 			return true, err
 		}
-		fmt.Fprint(out, output)
-		return true, nil
-	case options.Vendor:
-		output, err := deps.RunGoModVendor()
-		if err != nil {
+	}
+	if options.Tidy || options.Vendor || options.Download {
+		if err := guardDirtyModFiles(options, out); err != nil {
 			return true, err
 		}
-		fmt.Fprint(out, output)
-		return true, nil
-	case options.Download:
-		output, err := deps.RunGoModDownload()
-		if err != nil {
+	}
+	if len(options.Replace) > 0 || len(options.DropReplace) > 0 {
+		handled = true
+		if err := editReplaces(options, out); err != nil {
 			return true, err
 		}
-		fmt.Fprint(out, output)
-		return true, nil
-	case options.Verify:
-		output, err := deps.RunGoModVerify()
+	}
+
+	actions := []struct {
+		enabled bool
+		run     func() (string, error)
+		summary bool   // 执行后输出 go.mod/go.sum 的变化摘要
+		spinner string // 非空时执行期间在 stderr 上显示进度
+	}{
+		{options.Tidy, deps.RunGoModTidy, true, ""},
+		{options.Vendor, deps.RunGoModVendor, false, ""},
+		{options.Download, deps.RunGoModDownload, false, "Downloading modules"},
+		{options.Verify, deps.RunGoModVerify, false, ""},
+	}
+	for _, a := range actions {
+		if !a.enabled {
+			continue
+		}
+		handled = true
+		// --dry-run 时命令不会真正执行，没有变化可以报告
+		var before *deps.ModFiles
+		modDir := ""
+		if a.summary && !executor.Recording() {
+			if modDir, _ = moduleDir(); modDir != "" {
+				if snap, err := deps.ReadModFiles(modDir); err == nil {
+					before = &snap
+				}
+			}
+		}
+		var sp *style.Spinner
+		if a.spinner != "" && !executor.Recording() {
+			sp = style.NewSpinner(os.Stderr, a.spinner)
+			sp.Start()
+		}
+		output, err := a.run()
+		sp.Finish(err)
 		if err != nil {
 			return true, err
 		}
 		fmt.Fprint(out, output)
+		if before != nil {
+			after, err := deps.ReadModFiles(modDir)
+			if err != nil {
+				return true, err
+			}
+			changes, err := deps.DiffModFiles(*before, after)
+			if err != nil {
+				return true, err
+			}
+			deps.WriteModChanges(out, changes)
+		}
+	}
+	if handled {
 		return true, nil
-	case options.Why:
-		output, err := deps.RunGoModWhy(args, struct{ Module, Vendor bool }{Module: options.WhyModule, Vendor: options.WhyVendor})
+	}
+
+	if options.Why || options.WhyPaths {
+		results, err := deps.RunGoModWhyTargets(args, 0, struct{ Module, Vendor bool }{Module: options.WhyModule, Vendor: options.WhyVendor})
 		if err != nil {
 			return true, err
 		}
-		fmt.Fprint(out, output)
-		return true, nil
-	default:
-		return false, nil
+		if options.WhyPaths {
+			raw, err := deps.RunGoModGraph()
+			if err != nil {
+				return true, err
+			}
+			g, err := deps.ParseGoModGraph(raw)
+			if err != nil {
+				return true, err
+			}
+			deps.AnnotateWhyPaths(results, g, options.WhyModule, deps.MaxWhyPaths)
+		}
+		return true, renderWhy(out, results, options.JSON)
+	}
+	return false, nil
+}
+
+// moduleDir 返回当前模块 go.mod 所在的目录
+func moduleDir() (string, error) {
+	gomod, err := deps.FindGoMod()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Dir(gomod), nil
+}
+
+// guardDirtyModFiles 在修改模块文件之前检查 go.mod/go.sum 是否有未提交修改：
+// 没有修改、不在 git 仓库内、设置了 AllowDirty 或处于 --dry-run 时直接通过；
+// 设置了 Prompt 时询问是否继续（默认否），否则返回提示 --allow-dirty 的错误
+func guardDirtyModFiles(options DepsOptions, out io.Writer) error {
+	if options.AllowDirty || executor.Recording() {
+		return nil
+	}
+	dir, err := moduleDir()
+	if err != nil {
+		return err
+	}
+	dirty := deps.DirtyModFiles(dir)
+	if len(dirty) == 0 {
+		return nil
+	}
+	files := strings.Join(dirty, " and ")
+	if options.Prompt != nil {
+		input := options.Input
+		if input == nil {
+			input = os.Stdin
+		}
+		fmt.Fprintf(options.Prompt, "%s has uncommitted changes that tidy/vendor/download may overwrite. Continue? [y/N]: ", files)
+		answer, _ := bufio.NewReader(input).ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer == "y" || answer == "yes" {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s has uncommitted changes; commit or stash them first, preview with --tidy-diff, or pass --allow-dirty", files)
+}
+
+// printTidyDiff 输出 go mod tidy 将对当前模块产生的变化，不修改 go.mod/go.sum
+func printTidyDiff(out io.Writer) error {
+	dir, err := moduleDir()
+	if err != nil {
+		return err
+	}
+	changes, err := deps.TidyDiff(dir)
+	if err != nil {
+		return err
+	}
+	if changes.Empty() {
+		fmt.Fprintln(out, "go mod tidy would not change go.mod or go.sum")
+		return nil
+	}
+	fmt.Fprintln(out, "go mod tidy would change:")
+	deps.WriteModChanges(out, changes)
+	return nil
+}
+
+// editReplaces 校验 --replace 参数并通过 go mod edit 写入 go.mod，逐条输出变更；
+// 本地替换目录缺少 go.mod 时附加警告。dry-run 时 go.mod 没有修改，以 "would ..." 输出将要进行的变更
+func editReplaces(options DepsOptions, out io.Writer) error {
+	edits := make([]deps.ReplaceEdit, 0, len(options.Replace))
+	for _, spec := range options.Replace {
+		r, err := deps.ParseReplaceSpec(spec)
+		if err != nil {
+			return err
+		}
+		edits = append(edits, r)
+	}
+	applied, err := deps.RunGoModEditReplace(edits, options.DropReplace)
+	if err != nil {
+		return err
+	}
+	replaced, dropped := "replace", "dropped replace"
+	if executor.Recording() {
+		replaced, dropped = "would replace", "would drop replace"
+	}
+	for _, r := range applied {
+		fmt.Fprintf(out, "%s %s => %s\n", replaced, r.Old, r.New)
+		if r.MissingGoMod {
+			fmt.Fprintf(out, "  warning: %s has no go.mod; builds will fail until it exists\n", r.New)
+		}
+	}
+	for _, d := range options.DropReplace {
+		fmt.Fprintf(out, "%s %s\n", dropped, strings.TrimSpace(d))
+	}
+	return nil
+}
+
+// renderWhy 渲染 `go mod why` 的结构化结果
+//   - JSON: 输出结果数组（未着色，由调用方统一高亮）；
+//   - 默认: 将所有导入链合并为一棵前缀树（main -> ... -> target），不需要的目标统一列在最后
+func renderWhy(out io.Writer, results []deps.WhyResult, jsonOut bool) error {
+	if jsonOut {
+		s, err := style.FormatJSON(results)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(out, s)
+		return err
+	}
+
+	var needed, notNeeded []deps.WhyResult
+	for _, r := range results {
+		if r.NotNeeded || len(r.Chain) == 0 {
+			notNeeded = append(notNeeded, r)
+			continue
+		}
+		needed = append(needed, r)
+	}
+
+	if len(needed) > 0 {
+		var roots []style.TreeNode
+		for _, r := range needed {
+			roots = insertChain(roots, r.Chain)
+		}
+		root := style.TreeNode{Text: "why", Children: roots}
+		if len(roots) == 1 {
+			root = roots[0]
+		}
+		if err := style.PrintTree(out, root); err != nil {
+			return err
+		}
+		if err := renderWhyPaths(out, needed); err != nil {
+			return err
+		}
+	}
+
+	if len(notNeeded) > 0 {
+		if len(needed) > 0 {
+			fmt.Fprintln(out)
+		}
+		if err := style.PrintHeading(out, "Not needed by the main module"); err != nil {
+			return err
+		}
+		items := make([]any, 0, len(notNeeded))
+		for _, r := range notNeeded {
+			items = append(items, r.Target)
+		}
+		return style.PrintList(out, items...)
+	}
+	return nil
+}
+
+// renderWhyPaths 输出 --why-paths 得到的模块依赖链，每条链逐级缩进；同一模块的多个目标只输出一次
+func renderWhyPaths(out io.Writer, results []deps.WhyResult) error {
+	seen := make(map[string]bool)
+	for _, r := range results {
+		if len(r.ModulePaths) == 0 || seen[r.Module] {
+			continue
+		}
+		seen[r.Module] = true
+		fmt.Fprintln(out)
+		title := fmt.Sprintf("Module paths to %s (%d)", r.Module, len(r.ModulePaths))
+		if len(r.ModulePaths) >= deps.MaxWhyPaths {
+			title = fmt.Sprintf("Module paths to %s (first %d)", r.Module, len(r.ModulePaths))
+		}
+		if err := style.PrintHeading(out, title); err != nil {
+			return err
+		}
+		for i, path := range r.ModulePaths {
+			if i > 0 {
+				fmt.Fprintln(out)
+			}
+			for depth, id := range path {
+				fmt.Fprintf(out, "%s%s\n", strings.Repeat("  ", depth+1), id)
+			}
+		}
+	}
+	return nil
+}
+
+// insertChain 将一条导入链插入前缀树，共享相同前缀的链会被合并
+func insertChain(nodes []style.TreeNode, chain []string) []style.TreeNode {
+	if len(chain) == 0 {
+		return nodes
+	}
+	for i := range nodes {
+		if nodes[i].Text == chain[0] {
+			nodes[i].Children = insertChain(nodes[i].Children, chain[1:])
+			return nodes
+		}
 	}
+	return append(nodes, style.TreeNode{Text: chain[0], Children: insertChain(nil, chain[1:])})
 }
 
 // renderDepsTree 通过 `go mod graph` 构建 DAG，并以树形格式渲染到 out
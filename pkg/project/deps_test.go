@@ -0,0 +1,167 @@
+package project
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yeisme/gocli/pkg/style"
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// 测试 --tidy 的防护：go.mod 有未提交修改时拒绝执行，确认或 --allow-dirty 后执行并输出变化摘要
+func TestRunDepsTidyGuard(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Setenv("GOFLAGS", "")
+	t.Setenv("GOWORK", "off")
+	t.Setenv("GOPROXY", "off")
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":        "module example.com/app\n\ngo 1.21\n\nrequire example.com/unused v0.0.0\n\nreplace example.com/unused => ./unused\n",
+		"main.go":       "package main\n\nfunc main() {}\n",
+		"unused/go.mod": "module example.com/unused\n\ngo 1.21\n",
+		"unused/u.go":   "package unused\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, args := range [][]string{{"init", "-q"}, {"add", "-A"}, {"commit", "-q", "-m", "init"}} {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	t.Chdir(dir)
+	gomod := filepath.Join(dir, "go.mod")
+	if err := os.WriteFile(gomod, []byte(files["go.mod"]+"\n// local edit\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// 非终端：直接报错，go.mod 不变
+	var out strings.Builder
+	err := RunDeps(DepsOptions{Tidy: true}, &out, nil)
+	if err == nil || !strings.Contains(err.Error(), "go.mod has uncommitted changes") || !strings.Contains(err.Error(), "--allow-dirty") {
+		t.Fatalf("expected a dirty go.mod error, got %v", err)
+	}
+	// 终端上拒绝确认
+	var prompt strings.Builder
+	opts := DepsOptions{Tidy: true, Prompt: &prompt, Input: strings.NewReader("\n")}
+	if err := RunDeps(opts, &out, nil); err == nil || !strings.Contains(prompt.String(), "Continue? [y/N]") {
+		t.Fatalf("expected a refused prompt, got %v (prompt %q)", err, prompt.String())
+	}
+	if data, _ := os.ReadFile(gomod); !strings.Contains(string(data), "example.com/unused v0.0.0") {
+		t.Fatalf("go.mod changed although tidy was refused:\n%s", data)
+	}
+
+	// --tidy-diff 不受限制，也不修改文件
+	out.Reset()
+	if err := RunDeps(DepsOptions{TidyDiff: true}, &out, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "go mod tidy would change:\n  - example.com/unused v0.0.0\n") {
+		t.Errorf("unexpected --tidy-diff output:\n%s", out.String())
+	}
+
+	// 确认后执行并输出摘要
+	out.Reset()
+	opts.Input = strings.NewReader("y\n")
+	if err := RunDeps(opts, &out, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "go.mod: 0 updated, 0 added, 1 removed; go.sum: +0 -0 entries") {
+		t.Errorf("missing tidy summary:\n%s", out.String())
+	}
+	if data, _ := os.ReadFile(gomod); strings.Contains(string(data), "require example.com/unused") {
+		t.Errorf("tidy did not remove the unused require:\n%s", data)
+	}
+
+	// --allow-dirty 跳过检查
+	if err := RunDeps(DepsOptions{Tidy: true, AllowDirty: true}, &out, nil); err != nil {
+		t.Errorf("--allow-dirty: %v", err)
+	}
+}
+
+// 测试默认列表的 --json 输出：go list -m -json 的对象之后追加 go.mod 的 replace/exclude 指令
+func TestRunDepsJSONDirectives(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not available")
+	}
+	t.Setenv("GOFLAGS", "")
+	t.Setenv("GOWORK", "off")
+	t.Setenv("GOPROXY", "off")
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":     "module example.com/app\n\ngo 1.21\n\nrequire example.com/lib v0.0.0\n\nreplace example.com/lib => ./lib\n\nexclude example.com/old v1.0.0\n",
+		"lib/go.mod": "module example.com/lib\n\ngo 1.21\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+
+	var out strings.Builder
+	if err := RunDeps(DepsOptions{JSON: true}, &out, nil); err != nil {
+		t.Fatal(err)
+	}
+	values, err := style.DecodeJSONStream(out.String())
+	if err != nil {
+		t.Fatalf("output is not a JSON stream: %v\n%s", err, out.String())
+	}
+	if len(values) != 3 {
+		t.Fatalf("expected 2 modules and the directives object, got %d values:\n%s", len(values), out.String())
+	}
+	last, _ := values[2].(map[string]any)
+	d, _ := last["directives"].(map[string]any)
+	replaces, _ := d["replaces"].([]any)
+	excludes, _ := d["excludes"].([]any)
+	if len(replaces) != 1 || len(excludes) != 1 {
+		t.Errorf("unexpected directives object: %v", last)
+	}
+}
+
+// 测试 dry-run 下的 --replace/--drop-replace：以 "would ..." 输出将要进行的变更，go.mod 保持不变
+func TestEditReplacesDryRun(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not available")
+	}
+	t.Setenv("GOFLAGS", "")
+	t.Setenv("GOWORK", "off")
+	dir := t.TempDir()
+	gomod := "module example.com/app\n\ngo 1.21\n\nreplace example.com/old => ../old\n"
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(gomod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	executor.StartRecording()
+	defer executor.StopRecording()
+	var out strings.Builder
+	opts := DepsOptions{Replace: []string{"example.com/lib=example.com/fork@v1.0.0"}, DropReplace: []string{"example.com/old"}}
+	if err := editReplaces(opts, &out); err != nil {
+		t.Fatal(err)
+	}
+	want := "would replace example.com/lib => example.com/fork@v1.0.0\nwould drop replace example.com/old\n"
+	if out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "go.mod")); string(data) != gomod {
+		t.Errorf("dry-run changed go.mod:\n%s", data)
+	}
+}
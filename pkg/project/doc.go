@@ -1,16 +1,22 @@
 package project
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"net"
+	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/yeisme/gocli/pkg/configs"
 	"github.com/yeisme/gocli/pkg/context"
 	"github.com/yeisme/gocli/pkg/style"
+	"github.com/yeisme/gocli/pkg/utils/clipboard"
 	"github.com/yeisme/gocli/pkg/utils/doc"
+	"github.com/yeisme/gocli/pkg/utils/hotload"
 )
 
 // DocOptions 是文档生成的配置选项，使用 doc.Options 的别名
@@ -19,20 +25,65 @@ type (
 )
 
 // RunDoc 执行文档生成
-func RunDoc(ctx *context.GocliContext, opts DocOptions, out io.Writer, args []string) error {
+func RunDoc(ctx *context.GocliContext, opts DocOptions, out io.Writer, args []string) (err error) {
+	// --serve 模式：启动本地文档服务，不需要参数
+	if opts.Serve != "" {
+		// --serve 的地址是可选值，只能用 = 连接；"--serve :0" 中的 :0 会被解析为位置参数
+		for _, a := range args {
+			if strings.HasPrefix(a, ":") {
+				return fmt.Errorf("doc: %q was parsed as a package argument; pass the address as --serve=%s", a, a)
+			}
+		}
+		return serveDoc(ctx, opts)
+	}
+
 	// args 需要校验，至少有一个参数
 	if len(args) < 1 {
 		return fmt.Errorf("doc: at least one argument is required")
 	}
 
+	// 在生成任何文档之前校验主题，未知主题直接失败并列出可用主题
+	if opts.Mode == doc.ModeMarkdown {
+		if err := style.ValidateTheme(opts.Theme); err != nil {
+			return fmt.Errorf("doc: %w", err)
+		}
+	}
+
+	// 只有写到标准输出/标准错误时才可能着色：文件、目录与剪贴板经过缓冲，无法由 writer 判断是否为终端
+	if !IsStdoutOutput(opts.Output) && opts.Output != outputStderr {
+		opts.NoColor = true
+	}
+	// 同理，写到文件、目录或剪贴板时不按 stdout 所在终端折行，未配置宽度时使用默认宽度
+	if !IsStdoutOutput(opts.Output) && opts.Width == 0 {
+		opts.Width = doc.DefaultWrapWidth
+	}
+
+	// "-"：渲染从标准输入读取的 markdown / godoc 文本
+	if slices.Contains(args, "-") {
+		return runDocStdin(opts, out, args)
+	}
+
+	// --diff 模式：与 git 版本比较导出符号
+	if opts.Diff != "" {
+		return runDocDiff(ctx, opts, out, args)
+	}
+
+	// --tree 模式：为所有包生成镜像目录结构的 markdown 文档树与 SUMMARY.md
+	if opts.Tree {
+		return runDocTree(ctx, opts, args)
+	}
+
+	// --all 或 ./... 模式：逐个渲染 go list 展开的所有包
+	if opts.All || hasRecursivePattern(args) {
+		return runDocAll(ctx, opts, out, args)
+	}
+
 	// 处理输出目标（可能是文件），prepareOutput 返回最终的 writer、可选的关闭函数和 error
 	out, closeOut, err := prepareOutput(&opts, out)
 	if err != nil {
 		return err
 	}
-	if closeOut != nil {
-		defer closeOut()
-	}
+	defer closeOutput(closeOut, &err)
 
 	// 判断是否标准库/三方库的 import path（非文件系统绝对/相对路径）
 	isGoStandardPackage := func(ctx *context.GocliContext, importPath string) bool {
@@ -82,6 +133,17 @@ func RunDoc(ctx *context.GocliContext, opts DocOptions, out io.Writer, args []st
 					root = filepath.Dir(dir) // 更新 root 为三方库所在目录
 					log.Debug().Str("importPath", path).Msg("RunDoc: resolved third-party package directory")
 				}
+			} else if looksLikeThirdPartyImport(path) { // 三方库不在模块缓存中（或指定了 @version）
+				if !cur.Fetch {
+					return fmt.Errorf("doc: package %s not found in module cache (use --fetch to download it)", path)
+				}
+				dir, err := fetchThirdPartyPackage(path)
+				if err != nil {
+					return err
+				}
+				cur.Mode = doc.ModeGodoc
+				path = dir
+				root = filepath.Dir(dir)
 			}
 		}
 
@@ -138,6 +200,87 @@ func RunDoc(ctx *context.GocliContext, opts DocOptions, out io.Writer, args []st
 	return nil
 }
 
+// runDocStdin 读取标准输入（opts.Stdin）的全部内容，按 opts.Mode 渲染：
+// markdown 经 style.RenderMarkdown 渲染，godoc 按文档注释重排后经 RenderGodoc 输出
+func runDocStdin(opts DocOptions, out io.Writer, args []string) (err error) {
+	if len(args) != 1 {
+		return fmt.Errorf("doc: '-' (stdin) cannot be combined with other paths")
+	}
+	if opts.All || opts.Tree || opts.Diff != "" {
+		return fmt.Errorf("doc: '-' (stdin) cannot be used with --all, --tree or --diff")
+	}
+	switch opts.Style {
+	case doc.StyleHTML, doc.StyleJSON, doc.StyleYAML:
+		return fmt.Errorf("doc: '-' (stdin) supports --style markdown or plain, not %s", opts.Style)
+	}
+	in := opts.Stdin
+	if in == nil {
+		in = os.Stdin
+	}
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return fmt.Errorf("doc: read stdin failed: %w", err)
+	}
+	if strings.TrimSpace(string(data)) == "" {
+		return fmt.Errorf("doc: no input on stdin (pipe markdown or doc comment text, e.g. 'cat NOTES.md | gocli project doc -')")
+	}
+
+	out, closeOut, err := prepareOutput(&opts, out)
+	if err != nil {
+		return err
+	}
+	defer closeOutput(closeOut, &err)
+	switch opts.Mode {
+	case doc.ModeMarkdown:
+		if err := style.RenderMarkdown(out, string(data), opts.Width, opts.Theme); err != nil {
+			return fmt.Errorf("doc: failed to render markdown from stdin: %w", err)
+		}
+	case doc.ModeGodoc:
+		if err := doc.RenderGodoc(out, doc.FormatDocText(string(data), opts), opts); err != nil {
+			return fmt.Errorf("doc: failed to render godoc from stdin: %w", err)
+		}
+	default:
+		return fmt.Errorf("doc: unsupported mode %v for stdin", opts.Mode)
+	}
+	return nil
+}
+
+// serveDoc 启动本地 HTTP 文档服务，并复用 hotload 的文件监听在源码变更时清空渲染缓存
+func serveDoc(ctx *context.GocliContext, opts DocOptions) error {
+	root := configs.GetModuleRoot(ctx.Config.Env.GoMod)
+	if root == "" {
+		return fmt.Errorf("doc: --serve requires a Go module (go.mod not found)")
+	}
+	srv, err := doc.NewServer(root, opts)
+	if err != nil {
+		return err
+	}
+
+	addr := opts.Serve
+	// 仅指定端口时默认绑定 localhost，避免把文档服务暴露到局域网
+	if strings.HasPrefix(addr, ":") {
+		addr = "localhost" + addr
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("doc: listen on %s failed: %w", addr, err)
+	}
+	log.Info().Str("addr", "http://"+ln.Addr().String()).Msg("doc server listening")
+
+	watchCfg := ctx.Config.App.Hotload
+	watchCfg.Enabled = true
+	watchCfg.Dir = root
+	watchCfg.Recursive = true
+	watchCfg.Filter = []string{"*.go"}
+	go func() {
+		if err := hotload.WatchWithConfig(watchCfg, srv.Invalidate); err != nil {
+			log.Warn().Err(err).Msg("doc server: file watching stopped, cache will not be refreshed")
+		}
+	}()
+
+	return http.Serve(ln, srv.Handler())
+}
+
 func isMarkdownExt(path string) bool {
 	lower := strings.ToLower(path)
 	return strings.HasSuffix(lower, ".md") || strings.HasSuffix(lower, ".markdown")
@@ -214,22 +357,110 @@ func pickMostRecentDir(paths []string) string {
 	return best
 }
 
-// prepareOutput 根据 opts.Output 决定最终的输出 io.Writer，并返回一个可选的关闭函数（当输出为文件时）
+// 文档输出的特殊目标（-o），其余取值均视为文件路径
+const (
+	outputStdout    = "-"
+	outputStderr    = "stderr"
+	outputClipboard = "clipboard:"
+
+	// outputAppendSuffix 文件路径后的修饰符，表示追加写入而不是覆盖
+	outputAppendSuffix = ":append"
+)
+
+// splitOutputAppend 去掉 -o 路径末尾的 :append 修饰符，返回文件路径以及是否追加写入
+func splitOutputAppend(output string) (string, bool) {
+	if path, ok := strings.CutSuffix(output, outputAppendSuffix); ok && path != "" {
+		return path, true
+	}
+	return output, false
+}
+
+// openOutputFile 打开文档输出文件：先创建缺失的父目录，append 时追加写入，否则截断
+func openOutputFile(path string, appendMode bool) (*os.File, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	flag := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if appendMode {
+		flag = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	return os.OpenFile(path, flag, 0o644)
+}
+
+// IsStdoutOutput 报告 -o 的取值是否表示标准输出（空或 "-"）
+func IsStdoutOutput(output string) bool {
+	return output == "" || output == outputStdout
+}
+
+// isSpecialOutput 报告 -o 是否为特殊目标（stdout/stderr/剪贴板），而非文件或目录
+func isSpecialOutput(output string) bool {
+	return IsStdoutOutput(output) || output == outputStderr || output == outputClipboard
+}
+
+// prepareOutput 根据 opts.Output 决定文档的输出 io.Writer，并返回一个可选的关闭函数，规则见 OpenOutput
+//
 // 返回值: (writer, closeFunc, error)
-func prepareOutput(opts *DocOptions, defaultOut io.Writer) (io.Writer, func(), error) {
-	if opts.Output == "" {
+func prepareOutput(opts *DocOptions, defaultOut io.Writer) (io.Writer, func() error, error) {
+	w, closeFn, err := OpenOutput(opts.Output, opts.Append, defaultOut)
+	if err != nil {
+		return nil, nil, fmt.Errorf("doc: %w", err)
+	}
+	return w, closeFn, nil
+}
+
+// closeOutput 调用 prepareOutput 返回的关闭函数（可为 nil），*err 为空时以关闭错误作为返回值，
+// 用于 defer，使剪贴板复制失败等错误不会被忽略
+func closeOutput(closeOut func() error, err *error) {
+	if closeOut == nil {
+		return
+	}
+	if cerr := closeOut(); cerr != nil && *err == nil {
+		*err = fmt.Errorf("doc: %w", cerr)
+	}
+}
+
+// OpenOutput 根据 -o 的取值决定输出 io.Writer，并返回一个可选的关闭函数（调用方在写完后调用，
+// 剪贴板复制失败或文件关闭失败时返回错误）
+//   - "" 或 "-": 使用 defaultOut（stdout）
+//   - "stderr": 写入标准错误
+//   - "clipboard:": 先写入缓冲区，关闭时复制到系统剪贴板
+//   - 其他: 文件路径，自动创建父目录；默认覆盖，路径以 :append 结尾或 appendMode 为 true 时追加
+//
+// project doc/info/list 共用该规则
+func OpenOutput(output string, appendMode bool, defaultOut io.Writer) (io.Writer, func() error, error) {
+	switch output {
+	case "", outputStdout:
 		return defaultOut, nil, nil
+	case outputStderr:
+		return os.Stderr, nil, nil
+	case outputClipboard:
+		// 提前检查剪贴板工具，避免输出完成后才发现无法复制
+		if err := clipboard.Available(); err != nil {
+			return nil, nil, err
+		}
+		buf := &bytes.Buffer{}
+		closeFn := func() error {
+			if err := clipboard.WriteAll(buf.String()); err != nil {
+				return fmt.Errorf("failed to copy output to clipboard: %w", err)
+			}
+			log.Info().Int("bytes", buf.Len()).Msg("output copied to clipboard")
+			return nil
+		}
+		return buf, closeFn, nil
 	}
-	// TODO 使用 switch 判断 opts.Output 类型进行不同的分支
 
-	file, err := os.Create(opts.Output)
+	path, suffixAppend := splitOutputAppend(output)
+	file, err := openOutputFile(path, suffixAppend || appendMode)
 	if err != nil {
-		return nil, nil, fmt.Errorf("doc: failed to create output file %q: %w", opts.Output, err)
+		return nil, nil, fmt.Errorf("failed to open output file %q: %w", path, err)
 	}
-	closeFn := func() {
+	closeFn := func() error {
 		if err := file.Close(); err != nil {
-			log.Error().Err(err).Msg("failed to close output file")
+			return fmt.Errorf("failed to close output file %q: %w", path, err)
 		}
+		return nil
 	}
 	return file, closeFn, nil
 }
@@ -0,0 +1,205 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yeisme/gocli/pkg/configs"
+	"github.com/yeisme/gocli/pkg/context"
+	"github.com/yeisme/gocli/pkg/utils/doc"
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// docPackage 是 go list 展开得到的一个待渲染包
+type docPackage struct {
+	ImportPath string
+	Dir        string
+	Doc        string // 包注释的第一句（--tree 的 SUMMARY.md 使用）
+	Module     *struct{ Path string }
+}
+
+// docFileExt 按渲染风格返回 -o 为目录时每个包文件的扩展名
+var docFileExt = map[doc.Style]string{
+	doc.StylePlain:    ".txt",
+	doc.StyleMarkdown: ".md",
+	doc.StyleHTML:     ".html",
+	doc.StyleJSON:     ".json",
+	doc.StyleYAML:     ".yaml",
+}
+
+// hasRecursivePattern 报告参数中是否含有 ./... 形式的包模式
+func hasRecursivePattern(args []string) bool {
+	for _, a := range args {
+		if a == "..." || strings.HasSuffix(a, "/...") {
+			return true
+		}
+	}
+	return false
+}
+
+// runDocAll 使用 go list 展开参数中的所有包，并发渲染后按 go list 的顺序输出：
+//   - -o 为目录（已存在或以路径分隔符结尾）时每个包写入一个文件
+//   - 否则所有包依次输出到 out（或 -o 指定的单个文件），包之间用标题分隔
+func runDocAll(ctx *context.GocliContext, opts DocOptions, out io.Writer, args []string) (err error) {
+	pkgs, err := listDocPackages(docPatterns(args), opts)
+	if err != nil {
+		return err
+	}
+	if len(pkgs) == 0 {
+		return fmt.Errorf("doc: no packages matched %s", strings.Join(args, " "))
+	}
+
+	outDir := ""
+	outPath, appendMode := splitOutputAppend(opts.Output)
+	appendMode = appendMode || opts.Append
+	if isOutputDir(outPath) {
+		outDir = outPath
+		if err := os.MkdirAll(outDir, 0o755); err != nil {
+			return fmt.Errorf("doc: failed to create output directory %q: %w", outDir, err)
+		}
+	} else {
+		w, closeOut, perr := prepareOutput(&opts, out)
+		if perr != nil {
+			return perr
+		}
+		defer closeOutput(closeOut, &err)
+		out = w
+	}
+
+	// 各包并发解析与渲染（--concurrency / doc.concurrency），结果已按 go list 的顺序排列
+	dirs := make([]string, len(pkgs))
+	for i, p := range pkgs {
+		dirs[i] = p.Dir
+	}
+	doc.SetLogger(log)
+	opts.Context = ctx.Ctx()
+	docs, err := doc.GetGoDocs(opts, configs.GetModuleRoot(ctx.Config.Env.GoMod), dirs)
+	if err != nil {
+		return err
+	}
+
+	failed, written := 0, 0
+	for i, d := range docs {
+		p := pkgs[i]
+		if d.Err != nil {
+			log.Warn().Err(d.Err).Str("package", p.ImportPath).Msg("doc: skipping package")
+			failed++
+			continue
+		}
+		if outDir != "" {
+			file := filepath.Join(outDir, docFileName(p)+docFileExt[opts.Style])
+			if err := writeDocFile(file, []byte(d.Doc), appendMode); err != nil {
+				return fmt.Errorf("doc: failed to write %q: %w", file, err)
+			}
+			log.Info().Str("package", p.ImportPath).Str("file", file).Msg("doc: written")
+			continue
+		}
+		if written > 0 {
+			fmt.Fprintln(out)
+		}
+		written++
+		writeDocHeading(out, opts.Style, p.ImportPath)
+		if _, err := io.WriteString(out, d.Doc); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("doc: %d of %d package(s) failed to render", failed, len(pkgs))
+	}
+	return nil
+}
+
+// writeDocFile 将单个包的文档写入输出目录中的文件（appendMode 时追加）
+func writeDocFile(file string, data []byte, appendMode bool) error {
+	f, err := openOutputFile(file, appendMode)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// docPatterns 将 --all 的目录参数转换为递归包模式（. -> ./...），已是模式的参数保持不变
+func docPatterns(args []string) []string {
+	out := make([]string, 0, len(args))
+	for _, a := range args {
+		if !hasRecursivePattern([]string{a}) && isDirectory(a) {
+			a = strings.TrimSuffix(filepath.ToSlash(a), "/") + "/..."
+			if !strings.HasPrefix(a, ".") && !filepath.IsAbs(a) {
+				a = "./" + a
+			}
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
+// listDocPackages 使用 go list 展开包模式（只读查询），忽略没有 Go 文件的目录；
+// 按 opts.Tags 的构建配置列出，使只含其他平台文件的包不会出现在结果中
+func listDocPackages(patterns []string, opts DocOptions) ([]docPackage, error) {
+	env, flags := opts.GoBuildArgs()
+	args := append([]string{"list", "-e", "-json=ImportPath,Dir,Doc,Module,GoFiles,CgoFiles"}, flags...)
+	args = append(args, patterns...)
+	outStr, err := executor.NewExecutor("go", args...).WithEnv(env...).ReadOnly().Output()
+	if err != nil {
+		return nil, err
+	}
+	var pkgs []docPackage
+	dec := json.NewDecoder(strings.NewReader(outStr))
+	for dec.More() {
+		var p struct {
+			docPackage
+			GoFiles  []string
+			CgoFiles []string
+		}
+		if err := dec.Decode(&p); err != nil {
+			return nil, fmt.Errorf("decode go list output failed: %w", err)
+		}
+		if p.Dir == "" || len(p.GoFiles)+len(p.CgoFiles) == 0 {
+			continue
+		}
+		pkgs = append(pkgs, p.docPackage)
+	}
+	return pkgs, nil
+}
+
+// isOutputDir 报告 -o 是否指向目录：已存在的目录或以路径分隔符结尾
+func isOutputDir(output string) bool {
+	if isSpecialOutput(output) {
+		return false
+	}
+	if strings.HasSuffix(output, "/") || strings.HasSuffix(output, string(filepath.Separator)) {
+		return true
+	}
+	return isDirectory(output)
+}
+
+// docFileName 返回包在输出目录中的文件名（不含扩展名）：模块内的相对路径，以 _ 连接
+// 例如 github.com/x/y/pkg/tools -> pkg_tools，模块根包使用模块路径的最后一段
+func docFileName(p docPackage) string {
+	rel := p.ImportPath
+	if p.Module != nil && p.Module.Path != "" {
+		if p.ImportPath == p.Module.Path {
+			return filepath.Base(filepath.FromSlash(p.Module.Path))
+		}
+		rel = strings.TrimPrefix(p.ImportPath, p.Module.Path+"/")
+	}
+	return strings.ReplaceAll(rel, "/", "_")
+}
+
+// writeDocHeading 在连续输出多个包时写入分隔标题
+func writeDocHeading(w io.Writer, s doc.Style, importPath string) {
+	if s == doc.StyleMarkdown {
+		fmt.Fprintf(w, "# %s\n\n", importPath)
+		return
+	}
+	line := strings.Repeat("=", max(len(importPath)+8, 40))
+	fmt.Fprintf(w, "%s\npackage %s\n%s\n\n", line, importPath, line)
+}
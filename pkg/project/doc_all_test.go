@@ -0,0 +1,79 @@
+package project
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yeisme/gocli/pkg/configs"
+	"github.com/yeisme/gocli/pkg/context"
+	"github.com/yeisme/gocli/pkg/utils/doc"
+)
+
+// 测试 --all 输出目录中每个包的文件名
+func TestDocFileName(t *testing.T) {
+	mod := &struct{ Path string }{Path: "github.com/yeisme/gocli"}
+	tests := []struct {
+		pkg  docPackage
+		want string
+	}{
+		{docPackage{ImportPath: "github.com/yeisme/gocli", Module: mod}, "gocli"},
+		{docPackage{ImportPath: "github.com/yeisme/gocli/pkg/utils/doc", Module: mod}, "pkg_utils_doc"},
+		{docPackage{ImportPath: "fmt"}, "fmt"},
+	}
+	for _, tt := range tests {
+		if got := docFileName(tt.pkg); got != tt.want {
+			t.Errorf("docFileName(%s) = %s, want %s", tt.pkg.ImportPath, got, tt.want)
+		}
+	}
+	if !hasRecursivePattern([]string{"./cmd", "./pkg/..."}) || hasRecursivePattern([]string{"./pkg"}) {
+		t.Errorf("hasRecursivePattern mismatch")
+	}
+}
+
+// 测试 --all 以 markdown 风格写入目录：每个包一个文件，且文件包含该包的文档
+func TestRunDocAllWritesFiles(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not available")
+	}
+	t.Setenv("GOFLAGS", "")
+	t.Setenv("GOWORK", "off")
+	mod := t.TempDir()
+	files := map[string]string{
+		"go.mod":     "module example.com/m\n\ngo 1.21\n",
+		"m.go":       "// Package m is the root package.\npackage m\n",
+		"pkg/a/a.go": "// Package a does a.\npackage a\n\n// A is a function.\nfunc A() {}\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(mod, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wd, _ := os.Getwd()
+	if err := os.Chdir(mod); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(wd) }()
+
+	out := t.TempDir()
+	ctx := &context.GocliContext{Config: &configs.Config{Env: configs.EnvConfig{GoMod: filepath.Join(mod, "go.mod")}}}
+	opts := DocOptions{Style: doc.StyleMarkdown, Mode: doc.ModeGodoc, Output: out + "/", All: true}
+	if err := RunDoc(ctx, opts, nil, []string{"."}); err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{"m.md": "Package m is the root package.", "pkg_a.md": "A is a function."} {
+		data, err := os.ReadFile(filepath.Join(out, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), want) {
+			t.Errorf("%s is missing %q:\n%s", name, want, data)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package project
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/yeisme/gocli/pkg/configs"
+	"github.com/yeisme/gocli/pkg/context"
+	"github.com/yeisme/gocli/pkg/utils/doc"
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// docDiffWorkingTree 是 --diff 比较的新版本（当前工作区）的名称
+const docDiffWorkingTree = "working tree"
+
+// runDocDiff 比较参数下各包在 git 版本 opts.Diff 与当前工作区之间的导出 API，输出符号级差异：
+//   - 当前版本直接解析包目录；旧版本通过 git ls-tree / git show 把包目录的 Go 文件取到临时目录后解析
+//   - 目录参数与 --tree 一样包含子目录中的包；旧版本中存在而当前已删除的包，其符号全部记为删除
+//   - 没有变化的包不输出
+func runDocDiff(ctx *context.GocliContext, opts DocOptions, out io.Writer, args []string) (err error) {
+	ref := opts.Diff
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	top, err := executor.NewExecutor("git", "rev-parse", "--show-toplevel").WithDir(wd).ReadOnly().Output()
+	if err != nil {
+		return fmt.Errorf("doc: --diff requires a git repository: %w", err)
+	}
+	top = strings.TrimSpace(top)
+	if _, err := executor.NewExecutor("git", "rev-parse", "--verify", "--quiet", ref+"^{commit}").WithDir(top).ReadOnly().Output(); err != nil {
+		return fmt.Errorf("doc: unknown git revision %q", ref)
+	}
+
+	pkgs, err := listDocPackages(docPatterns(args), opts)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp("", "gocli-doc-diff-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+
+	var diffs []doc.PackageDiff
+	seen := map[string]bool{}
+	for _, p := range pkgs {
+		rel, err := gitRelPath(top, p.Dir)
+		if err != nil || seen[rel] {
+			continue
+		}
+		seen[rel] = true
+		files, _, err := gitTreeGoFiles(top, ref, rel, false)
+		if err != nil {
+			return err
+		}
+		changes, err := diffPackageAt(top, ref, files[rel], p.Dir, filepath.Join(tmp, fmt.Sprint(len(seen))), opts)
+		if err != nil {
+			log.Warn().Err(err).Str("package", p.ImportPath).Msg("doc: skipping package")
+			continue
+		}
+		if len(changes) > 0 {
+			diffs = append(diffs, doc.PackageDiff{ImportPath: p.ImportPath, Changes: changes})
+		}
+	}
+
+	// 旧版本中存在、当前已删除的包（只在目录参数下查找）
+	modRoot := configs.GetModuleRoot(ctx.Config.Env.GoMod)
+	modPath := ""
+	if data, err := os.ReadFile(filepath.Join(modRoot, "go.mod")); err == nil {
+		modPath = modfile.ModulePath(data)
+	}
+	for _, a := range args {
+		base := strings.TrimSuffix(strings.TrimSuffix(filepath.ToSlash(a), "..."), "/")
+		if base == "" {
+			base = "."
+		}
+		if !isDirectory(base) || modPath == "" {
+			continue
+		}
+		rel, err := gitRelPath(top, base)
+		if err != nil {
+			continue
+		}
+		files, modDirs, err := gitTreeGoFiles(top, ref, rel, true)
+		if err != nil {
+			return err
+		}
+		for _, dir := range slices.Sorted(maps.Keys(files)) {
+			if seen[dir] || skippedByGoList(dir, rel, modDirs) {
+				continue
+			}
+			seen[dir] = true
+			importPath, ok := removedImportPath(top, modRoot, modPath, dir)
+			if !ok {
+				continue
+			}
+			changes, err := diffPackageAt(top, ref, files[dir], "", filepath.Join(tmp, fmt.Sprint(len(seen))), opts)
+			if err != nil {
+				log.Warn().Err(err).Str("package", importPath).Msg("doc: skipping package")
+				continue
+			}
+			if len(changes) > 0 {
+				diffs = append(diffs, doc.PackageDiff{ImportPath: importPath, Changes: changes})
+			}
+		}
+	}
+	slices.SortFunc(diffs, func(a, b doc.PackageDiff) int { return strings.Compare(a.ImportPath, b.ImportPath) })
+
+	out, closeOut, err := prepareOutput(&opts, out)
+	if err != nil {
+		return err
+	}
+	defer closeOutput(closeOut, &err)
+	return doc.WriteSymbolDiff(out, opts.Style, ref, docDiffWorkingTree, diffs)
+}
+
+// diffPackageAt 把 ref 中包的 Go 文件（相对仓库根目录的路径）写入 tmpDir，与当前目录 curDir 比较符号；
+// curDir 为空表示包已被删除
+func diffPackageAt(top, ref string, files []string, curDir, tmpDir string, opts doc.Options) ([]doc.SymbolChange, error) {
+	if err := os.MkdirAll(tmpDir, 0o755); err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		content, err := executor.NewExecutor("git", "show", ref+":"+f).WithDir(top).ReadOnly().Output()
+		if err != nil {
+			return nil, fmt.Errorf("doc: git show %s:%s failed: %w", ref, f, err)
+		}
+		if err := os.WriteFile(filepath.Join(tmpDir, path.Base(f)), []byte(content), 0o644); err != nil {
+			return nil, err
+		}
+	}
+	old, err := doc.PackageSymbols(tmpDir, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ref, err)
+	}
+	cur := map[string]string{}
+	if curDir != "" {
+		if cur, err = doc.PackageSymbols(curDir, opts); err != nil {
+			return nil, err
+		}
+	}
+	return doc.DiffSymbols(old, cur), nil
+}
+
+// gitTreeGoFiles 列出 ref 中 rel 目录下的非测试 Go 文件，按所在目录（相对仓库根目录，以 / 分隔）分组；
+// recursive 为 false 时只列出 rel 目录本身的文件。modDirs 为其中含有 go.mod 的目录
+func gitTreeGoFiles(top, ref, rel string, recursive bool) (files map[string][]string, modDirs []string, err error) {
+	args := []string{"ls-tree", "-z"}
+	if recursive {
+		args = append(args, "-r")
+	}
+	args = append(args, ref, "--", strings.TrimSuffix(rel, "/")+"/")
+	outStr, err := executor.NewExecutor("git", args...).WithDir(top).ReadOnly().Output()
+	if err != nil {
+		return nil, nil, fmt.Errorf("doc: git ls-tree %s failed: %w", ref, err)
+	}
+	files = map[string][]string{}
+	for entry := range strings.SplitSeq(outStr, "\x00") {
+		// <mode> SP <type> SP <object> TAB <file>
+		meta, file, ok := strings.Cut(entry, "\t")
+		if !ok || !strings.Contains(meta, " blob ") {
+			continue
+		}
+		dir := path.Dir(file)
+		switch {
+		case path.Base(file) == "go.mod":
+			modDirs = append(modDirs, dir)
+		case strings.HasSuffix(file, ".go") && !strings.HasSuffix(file, "_test.go"):
+			files[dir] = append(files[dir], file)
+		}
+	}
+	return files, modDirs, nil
+}
+
+// skippedByGoList 报告 go list ./... 是否会跳过目录 dir（相对仓库根目录）：testdata、vendor、
+// 以 . 或 _ 开头的目录，以及 base 之下带有自己 go.mod 的嵌套模块
+func skippedByGoList(dir, base string, modDirs []string) bool {
+	for _, elem := range strings.Split(dir, "/") {
+		if elem == "testdata" || elem == "vendor" || (elem != "." && (strings.HasPrefix(elem, ".") || strings.HasPrefix(elem, "_"))) {
+			return true
+		}
+	}
+	for _, m := range modDirs {
+		if m != base && (dir == m || strings.HasPrefix(dir, m+"/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// gitRelPath 返回 dir 相对仓库根目录的路径（以 / 分隔，根目录为 "."）
+func gitRelPath(top, dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	// 仓库根目录可能经过符号链接解析（如 macOS 的 /tmp），两侧统一解析
+	if real, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = real
+	}
+	if real, err := filepath.EvalSymlinks(top); err == nil {
+		top = real
+	}
+	rel, err := filepath.Rel(top, abs)
+	if err != nil || !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("doc: %s is outside the git repository %s", dir, top)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// removedImportPath 根据模块路径推导已删除包（仓库内目录 dir）的 import path，不在模块内时返回 false
+func removedImportPath(top, modRoot, modPath, dir string) (string, bool) {
+	relMod, err := gitRelPath(top, modRoot)
+	if err != nil {
+		return "", false
+	}
+	if relMod == "." {
+		relMod = ""
+	}
+	sub, ok := strings.CutPrefix(dir, relMod)
+	if !ok || (relMod != "" && sub != "" && !strings.HasPrefix(sub, "/")) {
+		return "", false
+	}
+	if sub = strings.TrimPrefix(sub, "/"); sub == "" || sub == "." {
+		return modPath, true
+	}
+	return modPath + "/" + sub, true
+}
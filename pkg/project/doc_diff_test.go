@@ -0,0 +1,85 @@
+package project
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yeisme/gocli/pkg/configs"
+	"github.com/yeisme/gocli/pkg/context"
+	"github.com/yeisme/gocli/pkg/utils/doc"
+)
+
+// 测试 --diff：与 git tag 比较，报告新增/删除/签名变化的符号，以及自该版本以来删除的包
+func TestRunDocDiff(t *testing.T) {
+	for _, bin := range []string{"go", "git"} {
+		if _, err := exec.LookPath(bin); err != nil {
+			t.Skip(bin + " not available")
+		}
+	}
+	t.Setenv("GOFLAGS", "")
+	t.Setenv("GOWORK", "off")
+	mod := t.TempDir()
+	write := func(name, content string) {
+		p := filepath.Join(mod, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)...)
+		cmd.Dir = mod
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write("go.mod", "module example.com/m\n\ngo 1.21\n")
+	write("pkg/a/a.go", "package a\n\nfunc Keep(x int) {}\n\nfunc Old() {}\n")
+	write("pkg/gone/gone.go", "package gone\n\nfunc G() {}\n")
+	git("init", "-q")
+	git("add", "-A")
+	git("commit", "-q", "-m", "v1")
+	git("tag", "v1.0.0")
+
+	write("pkg/a/a.go", "package a\n\nfunc Keep(x int, y string) {}\n\nfunc New() {}\n")
+	write("pkg/a/a_test.go", "package a\n\nfunc TestOnly() {}\n")
+	if err := os.RemoveAll(filepath.Join(mod, "pkg", "gone")); err != nil {
+		t.Fatal(err)
+	}
+
+	wd, _ := os.Getwd()
+	if err := os.Chdir(mod); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(wd) }()
+
+	ctx := &context.GocliContext{Config: &configs.Config{Env: configs.EnvConfig{GoMod: filepath.Join(mod, "go.mod")}}}
+	var out bytes.Buffer
+	opts := DocOptions{Style: doc.StylePlain, Mode: doc.ModeGodoc, Diff: "v1.0.0"}
+	if err := RunDoc(ctx, opts, &out, []string{"."}); err != nil {
+		t.Fatal(err)
+	}
+	want := `example.com/m/pkg/a (v1.0.0..working tree)
+  ~ func Keep(x int, y string)
+      was: func Keep(x int)
+  + func New()
+  - func Old()
+
+example.com/m/pkg/gone (v1.0.0..working tree)
+  - func G()
+`
+	if out.String() != want {
+		t.Errorf("unexpected diff:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	opts.Diff = "no-such-tag"
+	if err := RunDoc(ctx, opts, &out, []string{"."}); err == nil || !strings.Contains(err.Error(), "unknown git revision") {
+		t.Errorf("expected an unknown revision error, got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// moduleDownload 对应 go mod download -json 的输出
+type moduleDownload struct {
+	Path    string
+	Version string
+	Dir     string
+	Error   string
+}
+
+// splitImportVersion 拆分 import path 与可选的 @version，未指定版本时为 latest
+func splitImportVersion(arg string) (importPath, version string) {
+	importPath, version, ok := strings.Cut(arg, "@")
+	if !ok || version == "" {
+		version = "latest"
+	}
+	return importPath, version
+}
+
+// modulePathCandidates 返回 import path 可能对应的模块路径，从最长到最短排列
+// 例如 a.com/b/c -> [a.com/b/c a.com/b a.com]
+func modulePathCandidates(importPath string) []string {
+	segs := strings.Split(strings.Trim(importPath, "/"), "/")
+	out := make([]string, 0, len(segs))
+	for i := len(segs); i >= 1; i-- {
+		out = append(out, strings.Join(segs[:i], "/"))
+	}
+	return out
+}
+
+// looksLikeThirdPartyImport 报告参数是否像三方库 import path（首段含点号且不是本地存在的路径）
+func looksLikeThirdPartyImport(arg string) bool {
+	importPath, _ := splitImportVersion(arg)
+	if !looksLikeImportPath(importPath) {
+		return false
+	}
+	first, _, _ := strings.Cut(importPath, "/")
+	if !strings.Contains(first, ".") {
+		return false
+	}
+	_, err := os.Stat(arg)
+	return os.IsNotExist(err)
+}
+
+// fetchThirdPartyPackage 使用 go mod download 拉取 import path 所在模块，并返回包在模块缓存中的目录
+// 依次尝试 import path 的各级前缀作为模块路径；在临时目录中执行，避免修改当前模块的 go.mod/go.sum
+func fetchThirdPartyPackage(arg string) (string, error) {
+	importPath, version := splitImportVersion(arg)
+	var lastErr string
+	for _, mod := range modulePathCandidates(importPath) {
+		log.Info().Str("module", mod+"@"+version).Msg("doc: downloading module")
+		stdout, _, err := executor.NewExecutor("go", "mod", "download", "-json", mod+"@"+version).
+			WithDir(os.TempDir()).
+			Run()
+		var dl moduleDownload
+		if jerr := json.Unmarshal([]byte(stdout), &dl); jerr != nil {
+			if err != nil {
+				lastErr = err.Error()
+			}
+			continue
+		}
+		if dl.Error != "" || dl.Dir == "" {
+			lastErr = dl.Error
+			continue
+		}
+		dir := dl.Dir
+		if sub := strings.TrimPrefix(strings.TrimPrefix(importPath, mod), "/"); sub != "" {
+			dir = filepath.Join(dir, filepath.FromSlash(sub))
+		}
+		if !isDirectory(dir) {
+			return "", fmt.Errorf("doc: module %s@%s does not contain package %s", dl.Path, dl.Version, importPath)
+		}
+		log.Debug().Str("dir", dir).Str("version", dl.Version).Msg("doc: fetched module")
+		return dir, nil
+	}
+	if lastErr == "" {
+		lastErr = "no matching module found"
+	}
+	return "", fmt.Errorf("doc: failed to fetch %s: %s", arg, lastErr)
+}
@@ -0,0 +1,23 @@
+package project
+
+import (
+	"slices"
+	"testing"
+)
+
+// 测试 --fetch 时 import path 的版本拆分与模块路径候选顺序
+func TestModulePathCandidates(t *testing.T) {
+	p, v := splitImportVersion("golang.org/x/mod/semver@v0.20.0")
+	if p != "golang.org/x/mod/semver" || v != "v0.20.0" {
+		t.Errorf("splitImportVersion = %s, %s", p, v)
+	}
+	if _, v := splitImportVersion("github.com/google/uuid"); v != "latest" {
+		t.Errorf("default version = %s, want latest", v)
+	}
+
+	got := modulePathCandidates("golang.org/x/mod/semver")
+	want := []string{"golang.org/x/mod/semver", "golang.org/x/mod", "golang.org/x", "golang.org"}
+	if !slices.Equal(got, want) {
+		t.Errorf("modulePathCandidates = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,155 @@
+package project
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/yeisme/gocli/pkg/style"
+	"github.com/yeisme/gocli/pkg/utils/doc"
+)
+
+// 测试 -o 的特殊目标：stdout/stderr/剪贴板不会被当作文件或目录
+func TestPrepareOutputTargets(t *testing.T) {
+	var def bytes.Buffer
+	for _, target := range []string{"", "-"} {
+		w, closeOut, err := prepareOutput(&DocOptions{Output: target}, &def)
+		if err != nil || w != &def || closeOut != nil {
+			t.Errorf("prepareOutput(%q) should return the default writer", target)
+		}
+	}
+	if w, _, err := prepareOutput(&DocOptions{Output: "stderr"}, &def); err != nil || w != os.Stderr {
+		t.Errorf("prepareOutput(stderr) should return os.Stderr")
+	}
+
+	file := filepath.Join(t.TempDir(), "doc.txt")
+	w, closeOut, err := prepareOutput(&DocOptions{Output: file}, &def)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprint(w, "hello")
+	if err := closeOut(); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(file); string(data) != "hello" {
+		t.Errorf("file content = %q", data)
+	}
+	for _, target := range []string{"-", "stderr", "clipboard:"} {
+		if isOutputDir(target) {
+			t.Errorf("isOutputDir(%q) should be false", target)
+		}
+	}
+}
+
+// 测试剪贴板复制失败时关闭函数与 RunDoc 返回错误，而不是只记录日志
+func TestClipboardOutputError(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("uses a fake xclip")
+	}
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "xclip"), []byte("#!/bin/sh\necho 'cannot open display' >&2\nexit 1\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+	t.Setenv("WAYLAND_DISPLAY", "")
+
+	w, closeOut, err := prepareOutput(&DocOptions{Output: "clipboard:"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprint(w, "hello")
+	if err := closeOut(); err == nil || !strings.Contains(err.Error(), "clipboard") {
+		t.Errorf("closeOut() = %v, want a clipboard error", err)
+	}
+
+	opts := DocOptions{Mode: doc.ModeGodoc, Style: doc.StylePlain, Output: "clipboard:", Stdin: strings.NewReader("Package foo does things.\n")}
+	if err := RunDoc(nil, opts, nil, []string{"-"}); err == nil || !strings.Contains(err.Error(), "clipboard") {
+		t.Errorf("RunDoc() = %v, want a clipboard error", err)
+	}
+}
+
+// 测试 "--serve :0" 形式：地址被解析为位置参数时报错并提示 --serve=:0，而不是在默认端口启动服务
+func TestRunDocServeAddressArg(t *testing.T) {
+	err := RunDoc(nil, DocOptions{Serve: ":6060"}, nil, []string{":0"})
+	if err == nil || !strings.Contains(err.Error(), "--serve=:0") {
+		t.Errorf("RunDoc() = %v, want a hint to use --serve=:0", err)
+	}
+}
+
+// 测试 -o 自动创建父目录以及 :append / --append 追加写入
+func TestPrepareOutputAppend(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "build", "docs", "pkg.md")
+	write := func(opts DocOptions, text string) {
+		t.Helper()
+		w, closeOut, err := prepareOutput(&opts, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprint(w, text)
+		if err := closeOut(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(DocOptions{Output: file}, "a")
+	write(DocOptions{Output: file + ":append"}, "b")
+	write(DocOptions{Output: file, Append: true}, "c")
+	if data, _ := os.ReadFile(file); string(data) != "abc" {
+		t.Errorf("after append: %q, want %q", data, "abc")
+	}
+	write(DocOptions{Output: file}, "d")
+	if data, _ := os.ReadFile(file); string(data) != "d" {
+		t.Errorf("after overwrite: %q, want %q", data, "d")
+	}
+	if path, ok := splitOutputAppend(":append"); ok || path != ":append" {
+		t.Errorf("bare :append should be kept as a file name")
+	}
+}
+
+// 测试 "-"：从标准输入读取 markdown / 文档注释文本渲染，与其他路径混用或输入为空时报错
+func TestRunDocStdin(t *testing.T) {
+	input := "# Notes\n\nSome *emphasis* and a [link](https://example.com).\n"
+	var want bytes.Buffer
+	if err := style.RenderMarkdown(&want, input, 80, "notty"); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	opts := DocOptions{Mode: doc.ModeMarkdown, Style: doc.StylePlain, Width: 80, Theme: "notty", Stdin: bytes.NewReader([]byte(input))}
+	if err := RunDoc(nil, opts, &out, []string{"-"}); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != want.String() {
+		t.Errorf("markdown from stdin:\n%q\nwant:\n%q", out.String(), want.String())
+	}
+
+	// godoc 模式：去掉 // 注释标记并按宽度重排，写入 -o 文件
+	file := filepath.Join(t.TempDir(), "snippet.txt")
+	opts = DocOptions{Mode: doc.ModeGodoc, Style: doc.StylePlain, Width: 40, Output: file,
+		Stdin: bytes.NewReader([]byte("// Package demo does a few things that need a long enough sentence to wrap.\n//\n//\tcode()\n"))}
+	if err := RunDoc(nil, opts, nil, []string{"-"}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(file)
+	if want := "Package demo does a few things that need\na long enough sentence to wrap.\n\n\tcode()\n"; string(data) != want {
+		t.Errorf("godoc from stdin:\n%q\nwant:\n%q", data, want)
+	}
+
+	for _, tc := range []struct {
+		args  []string
+		style doc.Style
+		input string
+		want  string
+	}{
+		{[]string{"-", "./pkg"}, "", input, "cannot be combined"},
+		{[]string{"-"}, "", " \n\t\n", "no input on stdin"},
+		{[]string{"-"}, doc.StyleHTML, input, "supports --style markdown or plain"},
+	} {
+		opts := DocOptions{Mode: doc.ModeMarkdown, Style: tc.style, Stdin: bytes.NewReader([]byte(tc.input))}
+		if err := RunDoc(nil, opts, &out, tc.args); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("RunDoc(%v) error = %v, want %q", tc.args, err, tc.want)
+		}
+	}
+}
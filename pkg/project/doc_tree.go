@@ -0,0 +1,254 @@
+package project
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/yeisme/gocli/pkg/configs"
+	"github.com/yeisme/gocli/pkg/context"
+	"github.com/yeisme/gocli/pkg/utils/doc"
+)
+
+const (
+	// docTreeSummary 是 --tree 在输出根目录生成的索引文件（mkdocs / GitBook 可直接使用）
+	docTreeSummary = "SUMMARY.md"
+	// docTreeManifest 记录 --tree 生成过的文件（相对输出根目录），用于清理不再对应任何包的旧文件；
+	// 只有清单中的文件会被删除，输出目录中手写的文件不受影响
+	docTreeManifest = ".gocli-doc-tree"
+)
+
+// runDocTree 为参数下的所有包生成 markdown 文档树：
+//   - 每个包写入输出根目录下与模块内路径一致的文件（pkg/utils/doc -> <out>/pkg/utils/doc.md）
+//   - 生成 SUMMARY.md，按包路径列出链接与包的简介
+//   - 内容未变化的文件不重写；上次生成但本次不再对应任何包的文件会被删除（--keep-stale 时保留）
+//   - 默认跳过 internal 与 testdata 下的包（--include-internal / --include-testdata）
+func runDocTree(ctx *context.GocliContext, opts DocOptions, args []string) error {
+	outDir, _ := splitOutputAppend(opts.Output)
+	if isSpecialOutput(outDir) {
+		return fmt.Errorf("doc: --tree requires -o <directory>")
+	}
+	if opts.Style != doc.StyleMarkdown {
+		return fmt.Errorf("doc: --tree only writes markdown, got --style %s", opts.Style)
+	}
+
+	patterns := docPatterns(args)
+	if opts.IncludeTestdata {
+		dirs, err := testdataPackageDirs(args)
+		if err != nil {
+			return err
+		}
+		patterns = append(patterns, dirs...)
+	}
+	pkgs, err := listDocPackages(patterns, opts)
+	if err != nil {
+		return err
+	}
+	pkgs = filterTreePackages(pkgs, opts.IncludeInternal, opts.IncludeTestdata)
+	if len(pkgs) == 0 {
+		return fmt.Errorf("doc: no packages matched %s", strings.Join(args, " "))
+	}
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return fmt.Errorf("doc: failed to create output directory %q: %w", outDir, err)
+	}
+
+	dirs := make([]string, len(pkgs))
+	for i, p := range pkgs {
+		dirs[i] = p.Dir
+	}
+	doc.SetLogger(log)
+	opts.Context = ctx.Ctx()
+	docs, err := doc.GetGoDocs(opts, configs.GetModuleRoot(ctx.Config.Env.GoMod), dirs)
+	if err != nil {
+		return err
+	}
+
+	// current 为本次对应包的文件（渲染失败的包也保留其旧文件，不当作过期文件删除）
+	current := []string{docTreeSummary}
+	var summary strings.Builder
+	summary.WriteString("# Summary\n\n")
+	failed := 0
+	for i, d := range docs {
+		p := pkgs[i]
+		rel := docTreePath(p) + ".md"
+		current = append(current, rel)
+		if d.Err != nil {
+			log.Warn().Err(d.Err).Str("package", p.ImportPath).Msg("doc: skipping package")
+			failed++
+			continue
+		}
+		if err := writeTreeFile(outDir, rel, []byte(d.Doc)); err != nil {
+			return err
+		}
+		fmt.Fprintf(&summary, "- [%s](%s)", docTreePath(p), rel)
+		if p.Doc != "" {
+			fmt.Fprintf(&summary, " - %s", p.Doc)
+		}
+		summary.WriteString("\n")
+	}
+	if err := writeTreeFile(outDir, docTreeSummary, []byte(summary.String())); err != nil {
+		return err
+	}
+
+	previous := readTreeManifest(outDir)
+	for _, rel := range previous {
+		if slices.Contains(current, rel) {
+			continue
+		}
+		if opts.KeepStale {
+			current = append(current, rel)
+			continue
+		}
+		if err := removeTreeFile(outDir, rel); err != nil {
+			return err
+		}
+	}
+	slices.Sort(current)
+	if err := writeTreeFile(outDir, docTreeManifest, []byte(strings.Join(current, "\n")+"\n")); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("doc: %d of %d package(s) failed to render", failed, len(pkgs))
+	}
+	return nil
+}
+
+// docTreePath 返回包在文档树中的相对路径（不含扩展名，以 / 分隔）：模块内的相对路径，
+// 模块根包使用模块路径的最后一段，不属于模块的包使用完整的 import path
+func docTreePath(p docPackage) string {
+	if p.Module != nil && p.Module.Path != "" {
+		if p.ImportPath == p.Module.Path {
+			return path.Base(p.Module.Path)
+		}
+		if rel, ok := strings.CutPrefix(p.ImportPath, p.Module.Path+"/"); ok {
+			return rel
+		}
+	}
+	return p.ImportPath
+}
+
+// filterTreePackages 去掉重复的包，并按需跳过 import path 中含 internal / testdata 路径段的包
+func filterTreePackages(pkgs []docPackage, includeInternal, includeTestdata bool) []docPackage {
+	out := make([]docPackage, 0, len(pkgs))
+	seen := map[string]bool{}
+	for _, p := range pkgs {
+		elems := strings.Split(p.ImportPath, "/")
+		if seen[p.ImportPath] ||
+			(!includeInternal && slices.Contains(elems, "internal")) ||
+			(!includeTestdata && slices.Contains(elems, "testdata")) {
+			continue
+		}
+		seen[p.ImportPath] = true
+		out = append(out, p)
+	}
+	return out
+}
+
+// testdataPackageDirs 查找参数目录下 testdata 中含有 Go 源文件的目录（go list 的 ./... 不会进入 testdata），
+// 返回绝对路径以便直接交给 go list；带有自己 go.mod 的嵌套模块会被跳过
+func testdataPackageDirs(args []string) ([]string, error) {
+	var dirs []string
+	for _, a := range args {
+		base := strings.TrimSuffix(strings.TrimSuffix(filepath.ToSlash(a), "..."), "/")
+		if base == "" {
+			base = "."
+		}
+		if !isDirectory(base) {
+			continue
+		}
+		err := filepath.WalkDir(base, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || !d.IsDir() {
+				return err
+			}
+			if p != base && isFile(filepath.Join(p, "go.mod")) {
+				return filepath.SkipDir
+			}
+			if !slices.Contains(strings.Split(filepath.ToSlash(p), "/"), "testdata") || !hasGoSource(p) {
+				return nil
+			}
+			abs, err := filepath.Abs(p)
+			if err != nil {
+				return err
+			}
+			dirs = append(dirs, abs)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return dirs, nil
+}
+
+// hasGoSource 报告目录中是否有非测试的 Go 源文件
+func hasGoSource(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if !e.IsDir() && strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go") {
+			return true
+		}
+	}
+	return false
+}
+
+// isFile 报告 p 是否是已存在的普通文件
+func isFile(p string) bool {
+	fi, err := os.Stat(p)
+	return err == nil && !fi.IsDir()
+}
+
+// writeTreeFile 写入文档树中的文件，内容未变化时不重写（保留修改时间）
+func writeTreeFile(outDir, rel string, data []byte) error {
+	file := filepath.Join(outDir, filepath.FromSlash(rel))
+	if old, err := os.ReadFile(file); err == nil && bytes.Equal(old, data) {
+		log.Debug().Str("file", file).Msg("doc: unchanged")
+		return nil
+	}
+	if err := writeDocFile(file, data, false); err != nil {
+		return fmt.Errorf("doc: failed to write %q: %w", file, err)
+	}
+	log.Info().Str("file", file).Msg("doc: written")
+	return nil
+}
+
+// readTreeManifest 读取上次生成的文件列表，清单不存在时返回空
+func readTreeManifest(outDir string) []string {
+	data, err := os.ReadFile(filepath.Join(outDir, docTreeManifest))
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for line := range strings.Lines(string(data)) {
+		line = strings.TrimSpace(line)
+		// 清单可能被手动修改，只接受输出目录内的相对路径
+		if line == "" || !filepath.IsLocal(filepath.FromSlash(line)) {
+			continue
+		}
+		files = append(files, line)
+	}
+	return files
+}
+
+// removeTreeFile 删除过期的文档文件，并删除因此变空的父目录（不超出输出根目录）
+func removeTreeFile(outDir, rel string) error {
+	file := filepath.Join(outDir, filepath.FromSlash(rel))
+	if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("doc: failed to remove stale %q: %w", file, err)
+	}
+	log.Info().Str("file", file).Msg("doc: removed stale file")
+	for dir := filepath.Dir(file); dir != filepath.Clean(outDir); dir = filepath.Dir(dir) {
+		if entries, err := os.ReadDir(dir); err != nil || len(entries) > 0 || os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
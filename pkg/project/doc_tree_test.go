@@ -0,0 +1,145 @@
+package project
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/configs"
+	"github.com/yeisme/gocli/pkg/context"
+	"github.com/yeisme/gocli/pkg/utils/doc"
+)
+
+// snapshotTree 返回目录下所有文件的内容与修改时间（相对路径 -> 内容@时间）
+func snapshotTree(t *testing.T, root string) map[string]string {
+	t.Helper()
+	files := map[string]string{}
+	err := filepath.Walk(root, func(p string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, p)
+		files[filepath.ToSlash(rel)] = string(data) + "@" + fi.ModTime().Format(time.RFC3339Nano)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+// 测试 --tree：镜像目录结构、SUMMARY.md 与简介、默认跳过 internal/testdata；
+// 重复运行不产生变化，删除包后删除其文件（手写文件保留），--keep-stale 时保留旧文件
+func TestRunDocTree(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not available")
+	}
+	t.Setenv("GOFLAGS", "")
+	t.Setenv("GOWORK", "off")
+	mod := t.TempDir()
+	files := map[string]string{
+		"go.mod":                     "module example.com/m\n\ngo 1.21\n",
+		"m.go":                       "// Package m is the root package.\npackage m\n",
+		"pkg/a/a.go":                 "// Package a does a.\npackage a\n\n// A is a function.\nfunc A() {}\n",
+		"pkg/a/b/b.go":               "// Package b does b.\npackage b\n",
+		"internal/x/x.go":            "// Package x is internal.\npackage x\n",
+		"pkg/a/testdata/fix/f.go":    "// Package fix is a fixture.\npackage fix\n",
+		"pkg/a/b/testdata/x_test.go": "package x\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(mod, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wd, _ := os.Getwd()
+	if err := os.Chdir(mod); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(wd) }()
+
+	out := filepath.Join(t.TempDir(), "api")
+	ctx := &context.GocliContext{Config: &configs.Config{Env: configs.EnvConfig{GoMod: filepath.Join(mod, "go.mod")}}}
+	opts := DocOptions{Style: doc.StyleMarkdown, Mode: doc.ModeGodoc, Output: out + "/", Tree: true}
+	if err := RunDoc(ctx, opts, nil, []string{"."}); err != nil {
+		t.Fatal(err)
+	}
+	first := snapshotTree(t, out)
+	for _, want := range []string{"m.md", "pkg/a.md", "pkg/a/b.md", "SUMMARY.md", docTreeManifest} {
+		if _, ok := first[want]; !ok {
+			t.Errorf("missing %s in %v", want, first)
+		}
+	}
+	for _, skipped := range []string{"internal/x.md", "pkg/a/testdata/fix.md"} {
+		if _, ok := first[skipped]; ok {
+			t.Errorf("%s should be skipped by default", skipped)
+		}
+	}
+	if !strings.Contains(first["pkg/a.md"], "A is a function.") {
+		t.Errorf("package file is missing its docs:\n%s", first["pkg/a.md"])
+	}
+	if !strings.Contains(first["SUMMARY.md"], "- [pkg/a/b](pkg/a/b.md) - Package b does b.") {
+		t.Errorf("unexpected SUMMARY.md:\n%s", first["SUMMARY.md"])
+	}
+
+	// 第二次运行：内容与修改时间都不变
+	if err := RunDoc(ctx, opts, nil, []string{"."}); err != nil {
+		t.Fatal(err)
+	}
+	second := snapshotTree(t, out)
+	if len(second) != len(first) {
+		t.Fatalf("second run changed the file set: %v", second)
+	}
+	for name, v := range first {
+		if second[name] != v {
+			t.Errorf("%s changed on the second run", name)
+		}
+	}
+
+	// 删除包 b：其文件与空目录被删除，手写文件保留
+	if err := os.WriteFile(filepath.Join(out, "README.md"), []byte("hand-written\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(filepath.Join(mod, "pkg", "a", "b")); err != nil {
+		t.Fatal(err)
+	}
+	if err := RunDoc(ctx, opts, nil, []string{"."}); err != nil {
+		t.Fatal(err)
+	}
+	third := snapshotTree(t, out)
+	if _, ok := third["pkg/a/b.md"]; ok {
+		t.Error("stale pkg/a/b.md was not removed")
+	}
+	if _, ok := third["README.md"]; !ok {
+		t.Error("hand-written files must not be removed")
+	}
+	if strings.Contains(third["SUMMARY.md"], "pkg/a/b") {
+		t.Errorf("SUMMARY.md still lists the removed package:\n%s", third["SUMMARY.md"])
+	}
+
+	// --include-internal / --include-testdata 生成对应文件；随后 --keep-stale 不删除它们
+	opts.IncludeInternal, opts.IncludeTestdata = true, true
+	if err := RunDoc(ctx, opts, nil, []string{"."}); err != nil {
+		t.Fatal(err)
+	}
+	opts.IncludeInternal, opts.IncludeTestdata, opts.KeepStale = false, false, true
+	if err := RunDoc(ctx, opts, nil, []string{"."}); err != nil {
+		t.Fatal(err)
+	}
+	kept := snapshotTree(t, out)
+	for _, want := range []string{"internal/x.md", "pkg/a/testdata/fix.md"} {
+		if _, ok := kept[want]; !ok {
+			t.Errorf("%s should be kept with --keep-stale: %v", want, kept)
+		}
+	}
+}
@@ -18,6 +18,8 @@ type FmtOptions struct {
 	Verbose bool   // 逐行输出结果
 
 	ConfigPath string // 配置文件路径
+
+	Golangci GolangciOptions // golangci-lint 缺失时的安装与版本检查
 }
 
 // RunFmt 执行代码格式化操作（使用 golangci-lint fmt）
@@ -43,10 +45,15 @@ func RunFmt(options FmtOptions, out io.Writer) error {
 
 	}
 
+	runner := newGolangciRunner(options.Golangci, out)
+	if err := runner.checkVersion(options.ConfigPath); err != nil {
+		return err
+	}
+
 	var output string
 	var err error
 	if options.List {
-		output, err = execGolangCILint(args, nil, nil)
+		output, err = runner.exec(args, nil, nil)
 	} else {
 		var stdout, stderr io.Writer
 		if out != nil {
@@ -57,7 +64,7 @@ func RunFmt(options FmtOptions, out io.Writer) error {
 			stdout = &discard
 			stderr = &discard
 		}
-		_, err = execGolangCILint(args, stdout, stderr)
+		_, err = runner.exec(args, stdout, stderr)
 	}
 	if err != nil {
 		return err
@@ -0,0 +1,177 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// GenerateOptions holds the options for `project generate`.
+type GenerateOptions struct {
+	Run  string // -run: only run directives whose full text matches this regular expression
+	Skip string // -skip: skip directives whose full text matches this regular expression
+	X    bool   // -x: print commands as they are executed
+	V    bool   // -v: print the names of packages and files as they are processed
+	List bool   // 只列出 //go:generate 指令（file:line），不执行
+}
+
+// GenerateDirective 描述一条 //go:generate 指令
+type GenerateDirective struct {
+	Package string `json:"package"`
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Command string `json:"command"`
+}
+
+// String 以 file:line: command 的形式返回指令，file 相对当前目录
+func (d GenerateDirective) String() string {
+	file := d.File
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, file); err == nil && !strings.HasPrefix(rel, "..") {
+			file = rel
+		}
+	}
+	return fmt.Sprintf("%s:%d: %s", file, d.Line, d.Command)
+}
+
+// generateDirectivePrefix 与 go generate 一致：指令必须位于行首
+const generateDirectivePrefix = "//go:generate"
+
+// RunGenerate 执行 go generate；List 模式下只扫描并打印指令
+func RunGenerate(opts GenerateOptions, out io.Writer, args []string) error {
+	if len(args) == 0 {
+		args = []string{"./..."}
+	}
+	if opts.List {
+		run, skip, err := compileGenerateFilters(opts)
+		if err != nil {
+			return err
+		}
+		directives, err := FindGenerateDirectives(args)
+		if err != nil {
+			return err
+		}
+		n := 0
+		for _, d := range directives {
+			// 与 go generate 一致，正则匹配指令的完整文本（含 //go:generate 前缀）
+			text := generateDirectivePrefix + " " + d.Command
+			if (run != nil && !run.MatchString(text)) || (skip != nil && skip.MatchString(text)) {
+				continue
+			}
+			fmt.Fprintln(out, d.String())
+			n++
+		}
+		if n == 0 {
+			fmt.Fprintln(out, "no //go:generate directives found")
+		}
+		return nil
+	}
+
+	goArgs := []string{"generate"}
+	if opts.Run != "" {
+		goArgs = append(goArgs, "-run", opts.Run)
+	}
+	if opts.Skip != "" {
+		goArgs = append(goArgs, "-skip", opts.Skip)
+	}
+	if opts.X {
+		goArgs = append(goArgs, "-x")
+	}
+	if opts.V {
+		goArgs = append(goArgs, "-v")
+	}
+	goArgs = append(goArgs, args...)
+	log.Info().Msg("go " + strings.Join(goArgs, " "))
+	err := executor.NewExecutor("go", goArgs...).RunLines(
+		func(line string) { fmt.Fprintln(out, line) },
+		func(line string) { log.Warn().Msg(line) },
+	)
+	return withoutStreamedStderr(err)
+}
+
+func compileGenerateFilters(opts GenerateOptions) (run, skip *regexp.Regexp, err error) {
+	if opts.Run != "" {
+		if run, err = regexp.Compile(opts.Run); err != nil {
+			return nil, nil, fmt.Errorf("invalid --run expression: %w", err)
+		}
+	}
+	if opts.Skip != "" {
+		if skip, err = regexp.Compile(opts.Skip); err != nil {
+			return nil, nil, fmt.Errorf("invalid --skip expression: %w", err)
+		}
+	}
+	return run, skip, nil
+}
+
+// FindGenerateDirectives 使用 go list 展开包模式，并解析每个包（含测试文件）中的 //go:generate 指令
+// 只考虑当前构建约束下参与构建的文件，与 go generate 的行为保持一致
+func FindGenerateDirectives(patterns []string) ([]GenerateDirective, error) {
+	args := append([]string{"list", "-e", "-json=ImportPath,Dir,GoFiles,CgoFiles,TestGoFiles,XTestGoFiles"}, patterns...)
+	outStr, err := executor.NewExecutor("go", args...).ReadOnly().Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var directives []GenerateDirective
+	dec := json.NewDecoder(strings.NewReader(outStr))
+	for dec.More() {
+		var p struct {
+			ImportPath   string
+			Dir          string
+			GoFiles      []string
+			CgoFiles     []string
+			TestGoFiles  []string
+			XTestGoFiles []string
+		}
+		if err := dec.Decode(&p); err != nil {
+			return nil, fmt.Errorf("decode go list output failed: %w", err)
+		}
+		files := append(append(append(append([]string{}, p.GoFiles...), p.CgoFiles...), p.TestGoFiles...), p.XTestGoFiles...)
+		for _, name := range files {
+			found, err := parseGenerateDirectives(filepath.Join(p.Dir, name))
+			if err != nil {
+				return nil, err
+			}
+			for i := range found {
+				found[i].Package = p.ImportPath
+			}
+			directives = append(directives, found...)
+		}
+	}
+	return directives, nil
+}
+
+// parseGenerateDirectives 解析单个文件的注释，返回位于行首的 //go:generate 指令
+func parseGenerateDirectives(file string) ([]GenerateDirective, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, nil, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("parse file %s failed: %w", file, err)
+	}
+	var out []GenerateDirective
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			if !strings.HasPrefix(c.Text, generateDirectivePrefix+" ") && !strings.HasPrefix(c.Text, generateDirectivePrefix+"\t") {
+				continue
+			}
+			pos := fset.Position(c.Pos())
+			if pos.Column != 1 {
+				continue
+			}
+			out = append(out, GenerateDirective{
+				File:    file,
+				Line:    pos.Line,
+				Command: strings.TrimSpace(strings.TrimPrefix(c.Text, generateDirectivePrefix)),
+			})
+		}
+	}
+	return out, nil
+}
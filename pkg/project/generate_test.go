@@ -0,0 +1,27 @@
+package project
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// 测试 //go:generate 指令解析：只识别行首的行注释，并记录行号
+func TestParseGenerateDirectives(t *testing.T) {
+	file := filepath.Join("testdata", "generate", "gen.go")
+	got, err := parseGenerateDirectives(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []GenerateDirective{
+		{File: file, Line: 3, Command: "echo first"},
+		{File: file, Line: 4, Command: "stringer -type=Kind"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d directives, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("directive %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package project
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"golang.org/x/mod/semver"
+	"gopkg.in/yaml.v3"
+
+	"github.com/yeisme/gocli/pkg/tools"
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// golangciLintName 是 golangci-lint 在 PATH 与工具表中的名称
+const golangciLintName = "golangci-lint"
+
+// GolangciOptions 控制 lint/fmt 在 golangci-lint 缺失时的安装行为以及版本检查
+type GolangciOptions struct {
+	Yes            bool      // 缺少 golangci-lint 时不询问直接安装（--yes）
+	AutoInstall    bool      // 配置 lint.auto_install，效果同 Yes
+	MinVersion     string    // 期望的最低版本（--min-version），为空时根据 .golangci.yml 的 version 推断
+	ToolsPath      string    // 安装目录，通常为 tools.gocli_tools_path
+	ToolsConfigDir []string  // 用户工具表所在目录，用于解析 golangci-lint 的安装方式
+	Input          io.Reader // 读取确认输入，为空时使用 os.Stdin
+}
+
+// installGolangCILint 按工具表定义把 golangci-lint 安装到 dir，返回二进制的绝对路径；测试中替换为桩
+var installGolangCILint = func(bi *tools.InstallToolsInfo, dir string) (string, error) {
+	res, err := tools.InstallTool(tools.InstallOptions{
+		Spec:       bi.URL,
+		CloneURL:   bi.CloneURL,
+		Path:       dir,
+		Env:        bi.Env,
+		BinaryName: bi.BinaryName,
+		Tags:       bi.Tags,
+	})
+	if err != nil {
+		if res.Output != "" {
+			return "", fmt.Errorf("install %s failed: %w\n%s", bi.Name, err, res.Output)
+		}
+		return "", fmt.Errorf("install %s failed: %w", bi.Name, err)
+	}
+	installDir := res.InstallDir
+	if installDir == "" {
+		installDir = res.ProbableInstallDir
+	}
+	name := bi.BinaryName
+	if name == "" {
+		name = golangciLintName
+	}
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	p := filepath.Join(installDir, name)
+	if fi, err := os.Stat(p); err != nil || fi.IsDir() {
+		return "", fmt.Errorf("%s was installed, but %s does not exist", bi.Name, p)
+	}
+	return filepath.Abs(p)
+}
+
+// golangciRunner 调用 golangci-lint：先使用 PATH 中的 golangci-lint，
+// 执行器报告 exec.ErrNotFound 时定位或安装 golangci-lint，之后使用其绝对路径重试
+type golangciRunner struct {
+	opts      GolangciOptions
+	bin       string
+	out       io.Writer // 安装提示与确认输出
+	ensureErr error     // 定位/安装失败的原因，避免同一次命令中重复询问
+}
+
+func newGolangciRunner(opts GolangciOptions, out io.Writer) *golangciRunner {
+	if out == nil {
+		out = io.Discard
+	}
+	return &golangciRunner{opts: opts, bin: golangciLintName, out: out}
+}
+
+// call 以当前二进制调用 fn，fn 返回 exec.ErrNotFound 时准备好 golangci-lint 后重试一次
+func (r *golangciRunner) call(fn func(bin string) error) error {
+	err := fn(r.bin)
+	if !errors.Is(err, exec.ErrNotFound) || r.bin != golangciLintName {
+		return err
+	}
+	if r.ensureErr != nil {
+		return r.ensureErr
+	}
+	bin, err := r.ensure()
+	if err != nil {
+		r.ensureErr = err
+		return err
+	}
+	r.bin = bin
+	return fn(bin)
+}
+
+// exec 执行 golangci-lint：
+//   - 当 stdout/stderr 为 nil 时，使用 Output 捕获并返回 stdout 字符串；
+//   - 当提供 stdout/stderr 时，使用 RunStreaming 直接写入并返回空字符串
+func (r *golangciRunner) exec(args []string, stdout, stderr io.Writer) (string, error) {
+	var output string
+	err := r.call(func(bin string) error {
+		e := executor.NewExecutor(bin, args...)
+		if stdout == nil && stderr == nil {
+			var err error
+			output, err = e.Output()
+			return err
+		}
+		return e.RunStreaming(stdout, stderr)
+	})
+	return output, err
+}
+
+// ensure 返回可执行的 golangci-lint 绝对路径：
+// 优先使用已安装但不在 PATH 中的二进制（tools 目录、GOBIN 等），否则经确认（--yes 或 lint.auto_install 跳过）后安装到 ToolsPath
+func (r *golangciRunner) ensure() (string, error) {
+	for _, t := range tools.FindTools(false, r.opts.ToolsPath) {
+		if strings.EqualFold(strings.TrimSuffix(t.Name, ".exe"), golangciLintName) {
+			return t.Path, nil
+		}
+	}
+
+	bi := tools.SearchTools(golangciLintName, r.opts.ToolsConfigDir)
+	if bi == nil {
+		return "", fmt.Errorf("%s not found in PATH and not defined in the tool table", golangciLintName)
+	}
+	source := bi.URL
+	if source == "" {
+		source = bi.CloneURL
+	}
+	target := r.opts.ToolsPath
+	if target == "" {
+		target = "the default Go install directory"
+	}
+	if !r.opts.Yes && !r.opts.AutoInstall {
+		input := r.opts.Input
+		if input == nil {
+			input = os.Stdin
+		}
+		fmt.Fprintf(r.out, "%s not found in PATH. Install %s into %s? [y/N]: ", golangciLintName, source, target)
+		answer, _ := bufio.NewReader(input).ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer != "y" && answer != "yes" {
+			return "", fmt.Errorf("%s not found in PATH (install it with 'gocli tools install %s' or rerun with --yes)", golangciLintName, golangciLintName)
+		}
+	}
+	fmt.Fprintf(r.out, "installing %s from %s into %s ...\n", golangciLintName, source, target)
+	bin, err := installGolangCILint(bi, r.opts.ToolsPath)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(r.out, "installed %s -> %s\n", golangciLintName, bin)
+	return bin, nil
+}
+
+var golangciVersionRE = regexp.MustCompile(`v?(\d+\.\d+\.\d+)`)
+
+// version 解析 golangci-lint version --format short 的输出，返回规范化的 semver（如 v2.1.6）
+func (r *golangciRunner) version() (string, error) {
+	var out string
+	err := r.call(func(bin string) error {
+		var err error
+		out, err = executor.NewExecutor(bin, "version", "--format", "short").ReadOnly().Output()
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	m := golangciVersionRE.FindStringSubmatch(out)
+	if m == nil {
+		return "", fmt.Errorf("cannot parse %s version from %q", golangciLintName, strings.TrimSpace(out))
+	}
+	return "v" + m[1], nil
+}
+
+// checkVersion 在已知最低版本时比较已安装的 golangci-lint 版本，过旧只输出警告
+//   - 最低版本来自 --min-version，未指定时由配置文件的 version 字段推断（version: "2" 需要 v2.0.0 及以上）
+//   - golangci-lint 缺失时与正常调用一样走安装流程，安装失败返回错误；版本无法解析时忽略
+func (r *golangciRunner) checkVersion(configPath string) error {
+	minVersion := normalizeVersion(r.opts.MinVersion)
+	if r.opts.MinVersion != "" && minVersion == "" {
+		return fmt.Errorf("invalid --min-version %q", r.opts.MinVersion)
+	}
+	if minVersion == "" {
+		minVersion = configMinVersion(configPath)
+	}
+	if minVersion == "" {
+		return nil
+	}
+	v, err := r.version()
+	if r.ensureErr != nil {
+		return r.ensureErr
+	}
+	if err != nil {
+		log.Debug().Err(err).Msg("cannot determine golangci-lint version")
+		return nil
+	}
+	if semver.Compare(v, minVersion) < 0 {
+		log.Warn().Msgf("%s %s is older than %s required by the project configuration; upgrade with 'gocli tools install %s'", golangciLintName, v, minVersion, golangciLintName)
+	}
+	return nil
+}
+
+// normalizeVersion 把 2、v2.1、2.1.6 等写法规范化为 semver，无法识别时返回空字符串
+func normalizeVersion(v string) string {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return ""
+	}
+	if !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+	return semver.Canonical(v)
+}
+
+// golangciConfigNames 是 golangci-lint 自动查找的配置文件名（toml 不解析）
+var golangciConfigNames = []string{".golangci.yml", ".golangci.yaml", ".golangci.json"}
+
+// configMinVersion 读取配置文件的 version 字段并换算为最低版本；
+// configPath 为空时与 golangci-lint 一样从当前目录向上查找（到模块根为止）
+func configMinVersion(configPath string) string {
+	if configPath == "" {
+		configPath = findGolangciConfig()
+	}
+	if configPath == "" {
+		return ""
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return ""
+	}
+	var cfg struct {
+		Version string `yaml:"version"`
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return ""
+	}
+	return normalizeVersion(cfg.Version)
+}
+
+func findGolangciConfig() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	root := findModuleRoot(dir)
+	for d := dir; ; {
+		for _, name := range golangciConfigNames {
+			p := filepath.Join(d, name)
+			if fi, err := os.Stat(p); err == nil && !fi.IsDir() {
+				return p
+			}
+		}
+		parent := filepath.Dir(d)
+		if d == root || parent == d {
+			return ""
+		}
+		d = parent
+	}
+}
@@ -0,0 +1,128 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/yeisme/gocli/pkg/tools"
+)
+
+// stubGolangciInstaller 把输出固定版本号的假 golangci-lint 写入安装目录，返回调用次数计数
+func stubGolangciInstaller(t *testing.T, version string) *int {
+	t.Helper()
+	calls := 0
+	orig := installGolangCILint
+	installGolangCILint = func(_ *tools.InstallToolsInfo, dir string) (string, error) {
+		calls++
+		p := filepath.Join(dir, golangciLintName)
+		script := "#!/bin/sh\nif [ \"$1\" = version ]; then echo " + version + "; else echo ran \"$@\"; fi\n"
+		if err := os.WriteFile(p, []byte(script), 0o755); err != nil {
+			return "", err
+		}
+		return p, nil
+	}
+	t.Cleanup(func() { installGolangCILint = orig })
+	return &calls
+}
+
+// isolateGolangciLookup 让 PATH、GOBIN、GOPATH 与 HOME 都指向空目录，保证找不到真实的 golangci-lint
+func isolateGolangciLookup(t *testing.T) {
+	t.Helper()
+	empty := t.TempDir()
+	t.Setenv("PATH", empty)
+	t.Setenv("GOBIN", empty)
+	t.Setenv("GOPATH", empty)
+	t.Setenv("HOME", empty)
+}
+
+// 测试缺少 golangci-lint 时确认后安装，版本检查与后续调用都使用安装后的绝对路径且只安装一次
+func TestGolangciRunnerInstallsWhenMissing(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake golangci-lint is a shell script")
+	}
+	isolateGolangciLookup(t)
+	calls := stubGolangciInstaller(t, "1.64.8")
+	toolsDir := t.TempDir()
+
+	var msg strings.Builder
+	r := newGolangciRunner(GolangciOptions{
+		MinVersion: "2",
+		ToolsPath:  toolsDir,
+		Input:      strings.NewReader("y\n"),
+	}, &msg)
+	if err := r.checkVersion(""); err != nil {
+		t.Fatalf("checkVersion: %v", err)
+	}
+	if v, err := r.version(); err != nil || v != "v1.64.8" {
+		t.Fatalf("version() = %q, %v; want v1.64.8", v, err)
+	}
+	out, err := r.exec([]string{"run", "./..."}, nil, nil)
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	if strings.TrimSpace(out) != "ran run ./..." {
+		t.Errorf("exec output = %q", out)
+	}
+	if *calls != 1 {
+		t.Errorf("installer called %d times, want 1", *calls)
+	}
+	if want := filepath.Join(toolsDir, golangciLintName); r.bin != want {
+		t.Errorf("bin = %q, want %q", r.bin, want)
+	}
+	if !strings.Contains(msg.String(), "[y/N]") {
+		t.Errorf("expected install prompt, got %q", msg.String())
+	}
+}
+
+// 测试拒绝安装时返回错误且不调用安装器；--yes 跳过确认
+func TestGolangciRunnerPrompt(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake golangci-lint is a shell script")
+	}
+	isolateGolangciLookup(t)
+	calls := stubGolangciInstaller(t, "2.1.6")
+
+	r := newGolangciRunner(GolangciOptions{ToolsPath: t.TempDir(), Input: strings.NewReader("n\n")}, nil)
+	if _, err := r.exec([]string{"run"}, nil, nil); err == nil {
+		t.Fatalf("expected refusal error, got %v", err)
+	}
+	if *calls != 0 {
+		t.Fatalf("installer should not run when declined")
+	}
+
+	var msg strings.Builder
+	r = newGolangciRunner(GolangciOptions{ToolsPath: t.TempDir(), Yes: true}, &msg)
+	if _, err := r.exec([]string{"run"}, nil, nil); err != nil {
+		t.Fatalf("exec with --yes: %v", err)
+	}
+	if *calls != 1 || strings.Contains(msg.String(), "[y/N]") {
+		t.Errorf("--yes should install without prompting (calls=%d, output=%q)", *calls, msg.String())
+	}
+}
+
+// 测试从 .golangci.yml 的 version 字段推断最低版本
+func TestConfigMinVersion(t *testing.T) {
+	dir := t.TempDir()
+	cases := map[string]string{
+		"version: \"2\"\nlinters:\n  default: standard\n": "v2.0.0",
+		"version: 2\n":                  "v2.0.0",
+		"linters:\n  enable: [govet]\n": "",
+	}
+	for content, want := range cases {
+		p := filepath.Join(dir, ".golangci.yml")
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if got := configMinVersion(p); got != want {
+			t.Errorf("configMinVersion(%q) = %q, want %q", content, got, want)
+		}
+	}
+	for in, want := range map[string]string{"2": "v2.0.0", "v2.1": "v2.1.0", "1.64.8": "v1.64.8", "latest": ""} {
+		if got := normalizeVersion(in); got != want {
+			t.Errorf("normalizeVersion(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package project
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/yeisme/gocli/pkg/configs"
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// withHotloadHooks 按 pre_hooks -> runFunc -> post_hooks 的顺序组合一次重启
+//   - exec 非空时替换默认的 build/run 命令
+//   - 钩子失败默认只记录日志；stop_on_hook_error 为 true 时中止本次重启，
+//     pre 钩子失败时不会执行 build/run，因此上一次运行的进程保持不变
+func withHotloadHooks(cfg configs.HotloadConfig, runFunc func() error) func() error {
+	main := runFunc
+	if cfg.Exec != "" {
+		main = func() error { return runShellCommand(cfg.Exec) }
+	}
+	if len(cfg.PreHooks) == 0 && len(cfg.PostHooks) == 0 && cfg.Exec == "" {
+		return runFunc
+	}
+	return func() error {
+		if err := runHotloadHooks("pre", cfg.PreHooks, cfg.StopOnHookError); err != nil {
+			return err
+		}
+		if err := main(); err != nil {
+			return err
+		}
+		return runHotloadHooks("post", cfg.PostHooks, cfg.StopOnHookError)
+	}
+}
+
+// runHotloadHooks 依次执行钩子；stop 为 true 时遇到第一个失败即返回错误
+func runHotloadHooks(stage string, hooks []string, stop bool) error {
+	for _, h := range hooks {
+		log.Info().Msgf("[HotReload] %s hook: %s", stage, h)
+		if err := runShellCommand(h); err != nil {
+			if stop {
+				return fmt.Errorf("%s hook %q failed, restart aborted: %w", stage, h, err)
+			}
+			log.Warn().Err(err).Msgf("[HotReload] %s hook %q failed", stage, h)
+		}
+	}
+	return nil
+}
+
+// shellArgs 返回通过系统 shell 执行命令行的参数（sh -c / cmd /C）
+func shellArgs(line string) []string {
+	if runtime.GOOS == "windows" {
+		return []string{"cmd", "/C", line}
+	}
+	return []string{"sh", "-c", line}
+}
+
+// runShellCommand 通过系统 shell 执行命令行，输出逐行写入日志
+func runShellCommand(line string) error {
+	args := shellArgs(line)
+	err := executor.NewExecutor(args[0], args[1:]...).RunLines(
+		func(l string) { log.Info().Msg(l) },
+		func(l string) { log.Warn().Msg(l) },
+	)
+	return withoutStreamedStderr(err)
+}
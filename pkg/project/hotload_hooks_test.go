@@ -0,0 +1,137 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/configs"
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// 测试热重载钩子的执行顺序：pre -> build/run -> post，以及 exec 替换默认命令
+func TestWithHotloadHooks_Order(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("records use sh -c")
+	}
+	rec := executor.StartRecording()
+	defer executor.StopRecording()
+
+	cfg := configs.HotloadConfig{
+		PreHooks:  []string{"templ generate", "sqlc generate"},
+		PostHooks: []string{"curl -s localhost:35729/reload"},
+	}
+	build := func() error { _, _, err := executor.NewExecutor("go", "build", ".").Run(); return err }
+	if err := withHotloadHooks(cfg, build)(); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Exec = "make run"
+	if err := withHotloadHooks(cfg, build)(); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, r := range rec.Records() {
+		got = append(got, strings.TrimPrefix(r.String(), "$ "))
+	}
+	want := []string{
+		"sh -c 'templ generate'", "sh -c 'sqlc generate'", "go build .", "sh -c 'curl -s localhost:35729/reload'",
+		"sh -c 'templ generate'", "sh -c 'sqlc generate'", "sh -c 'make run'", "sh -c 'curl -s localhost:35729/reload'",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected execution order:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+// 测试长时间运行的进程：post 钩子在进程启动后执行而不是等待其退出，变更后按 pre -> 重启 -> post 再执行一次
+func TestRunnerHotReload_LongRunningChild(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	dir := t.TempDir()
+	hooksLog := filepath.Join(dir, "hooks.log")
+	readHooks := func() string { b, _ := os.ReadFile(hooksLog); return string(b) }
+	waitHooks := func(want string) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for readHooks() != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("hooks ran %q, want %q", readHooks(), want)
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}
+
+	cfg := configs.HotloadConfig{
+		Enabled:   true,
+		Dir:       dir,
+		Filter:    []string{"*.go"},
+		Recursive: true,
+		Debounce:  50,
+		PreHooks:  []string{"echo pre >> " + hooksLog},
+		PostHooks: []string{"echo post >> " + hooksLog},
+	}
+	out := &syncBuffer{}
+	runner := newProcRunner(out, []*proc{shProc("app", "echo up; exec sleep 30")}, true)
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- runnerHotReload(ctx, cfg, BuildRunOptions{}, runner, nil) }()
+
+	waitHooks("pre\npost\n")
+	waitOutput(t, out, "up\n", 1)
+	if strings.Contains(out.String(), "[exited") {
+		t.Fatalf("child should still be running:\n%s", out.String())
+	}
+
+	// 监视在首次 post 钩子之后才开始，反复写入直到变更被检测到
+	deadline := time.Now().Add(10 * time.Second)
+	for !strings.HasPrefix(readHooks(), "pre\npost\npre\npost\n") {
+		if time.Now().After(deadline) {
+			t.Fatalf("hooks ran %q, want a second pre/post round", readHooks())
+		}
+		if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	waitOutput(t, out, "up\n", 2)
+	if !strings.Contains(out.String(), "[restarting]\n") {
+		t.Errorf("expected an unprefixed restart line:\n%s", out.String())
+	}
+
+	cancel()
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("interrupted child should not fail: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("runnerHotReload did not return after cancel")
+	}
+}
+
+// 测试 stop_on_hook_error：pre 钩子失败时不执行 build/run；关闭时仅记录日志继续执行
+func TestWithHotloadHooks_StopOnHookError(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh exit codes")
+	}
+	ran := false
+	run := func() error { ran = true; return nil }
+
+	cfg := configs.HotloadConfig{PreHooks: []string{"exit 3"}, StopOnHookError: true}
+	err := withHotloadHooks(cfg, run)()
+	var execErr *executor.ExecError
+	if err == nil || !errors.As(err, &execErr) || ran {
+		t.Fatalf("expected aborted restart, got err=%v ran=%v", err, ran)
+	}
+
+	cfg.StopOnHookError = false
+	if err := withHotloadHooks(cfg, run)(); err != nil || !ran {
+		t.Fatalf("hook failure should only be logged, got err=%v ran=%v", err, ran)
+	}
+}
@@ -5,21 +5,43 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	gctx "github.com/yeisme/gocli/pkg/context"
 
 	"github.com/yeisme/gocli/pkg/models"
 	"github.com/yeisme/gocli/pkg/style"
 	"github.com/yeisme/gocli/pkg/utils/count"
+	"github.com/yeisme/gocli/pkg/utils/executor"
 )
 
 // InfoOptions 是用于获取项目详细信息的选项
 type InfoOptions struct {
 	count.Options
+
+	// Format 输出格式：text（默认）、json、markdown
+	Format string
+	// BadgeDir 非空时在该目录写入 shields.io endpoint 徽章 JSON
+	BadgeDir string
+	// WithPackages 在 Markdown 汇总中输出 Go 包数量（通过 go list ./... 统计）
+	WithPackages bool
+	// ProgressMode 统计进度显示：auto（默认，stdout 为终端且文件数超过阈值时）、always、never；
+	// 进度只写入终端 stderr，不会混入 stdout 的 JSON/Markdown
+	ProgressMode string
+	// Git 目标位于 git 仓库内时附加分支、提交数、作者数与首末提交时间；git 不可用时忽略
+	Git bool
+	// Workspace 非空时分别统计每个 go.work 模块目录（--workspace），忽略 root 参数
+	Workspace []WorkspaceModule
 }
 
+// infoProgressThreshold 是 ProgressMode 为 auto 时显示进度所需的最少文件数
+const infoProgressThreshold = 2000
+
 // ExecuteInfoCommand 负责执行业务逻辑（统计 + 输出），与 build/run 的风格保持一致
 // 参数说明:
 //
@@ -30,11 +52,52 @@ type InfoOptions struct {
 func ExecuteInfoCommand(gocliCtx *gctx.GocliContext, opts InfoOptions, args []string, jsonOut bool, showProjectHeader bool, w io.Writer) error {
 	_ = gocliCtx
 
+	if len(opts.Workspace) > 0 {
+		return executeInfoWorkspace(gocliCtx, opts, jsonOut, showProjectHeader, w)
+	}
+
 	root := resolveInfoRoot(args)
-	res, err := collectProjectAnalysis(root, opts)
+	progress, err := newInfoProgress(opts.ProgressMode, w, os.Stderr)
+	if err != nil {
+		return err
+	}
+	if progress != nil {
+		opts.Progress = progress.report
+	}
+	res, err := collectProjectAnalysis(gocliCtx.Ctx(), root, opts)
+	progress.finish()
 	if err != nil {
 		return err
 	}
+	if opts.Git {
+		if gs, err := collectGitStats(root); err != nil {
+			log.Warn().Err(err).Msg("skip git statistics")
+		} else {
+			res.Git = gs
+		}
+	}
+
+	if opts.BadgeDir != "" {
+		written, err := count.WriteBadges(opts.BadgeDir, res)
+		if err != nil {
+			return err
+		}
+		log.Info().Strs("files", written).Msg("badge files written")
+	}
+
+	switch strings.ToLower(opts.Format) {
+	case "", "text", "table":
+	case "json":
+		jsonOut = true
+	case "markdown", "md":
+		mdOpts := count.MarkdownOptions{}
+		if opts.WithPackages {
+			mdOpts.GoPackages = countGoPackages(root)
+		}
+		return count.RenderMarkdown(w, res, mdOpts)
+	default:
+		return fmt.Errorf("unknown info format %q (want text, json or markdown)", opts.Format)
+	}
 
 	if jsonOut {
 		return printInfoJSON(w, res)
@@ -48,6 +111,37 @@ func ExecuteInfoCommand(gocliCtx *gctx.GocliContext, opts InfoOptions, args []st
 	if err := style.PrintTable(w, langHeaders, langRows, 0); err != nil {
 		log.Error().Err(err).Msg("failed to print info table")
 	}
+	if summary := count.GoCodeSummary(res); summary != "" {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, summary)
+	}
+
+	if res.Git != nil {
+		fmt.Fprintln(w)
+		if err := printGitStats(w, res.Git); err != nil {
+			log.Error().Err(err).Msg("failed to print git statistics")
+		}
+	}
+
+	if len(res.Total.Owners) > 0 {
+		fmt.Fprintln(w)
+		if err := printOwners(w, res); err != nil {
+			log.Error().Err(err).Msg("failed to print ownership statistics")
+		}
+	}
+
+	// 超过大小限制而未统计的文件
+	if len(res.Skipped) > 0 {
+		rows := make([][]string, 0, len(res.Skipped))
+		for _, sf := range res.Skipped {
+			rows = append(rows, []string{sf.Path, strconv.FormatInt(sf.Size, 10)})
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Skipped %d file(s) larger than --max-file-size (%d bytes):\n", len(res.Skipped), opts.MaxFileSizeBytes)
+		if err := style.PrintTable(w, []string{"path", "bytes"}, rows, 0); err != nil {
+			log.Error().Err(err).Msg("failed to print skipped files")
+		}
+	}
 
 	// 文件表
 	if opts.WithFileDetails {
@@ -63,6 +157,139 @@ func ExecuteInfoCommand(gocliCtx *gctx.GocliContext, opts InfoOptions, args []st
 	return nil
 }
 
+// workspaceInfo 是 --workspace JSON 输出中的一项
+type workspaceInfo struct {
+	WorkspaceModule
+	Summary *models.AnalysisResult `json:"summary"`
+}
+
+// executeInfoWorkspace 分别统计每个工作区模块目录：
+//   - text: 每个模块一个标题，其后为与单项目相同的表格；
+//   - markdown: 每个模块一个以模块路径为标题的统计段落；
+//   - json: [{"module", "dir", "summary"}] 数组
+//
+// 徽章只描述单个项目，因此不能与 BadgeDir 同时使用
+func executeInfoWorkspace(gocliCtx *gctx.GocliContext, opts InfoOptions, jsonOut bool, showProjectHeader bool, w io.Writer) error {
+	if opts.BadgeDir != "" {
+		return fmt.Errorf("--badge-json cannot be combined with --workspace")
+	}
+	single := opts
+	single.Workspace = nil
+	analyze := func(m WorkspaceModule) (*models.AnalysisResult, error) {
+		res, err := collectProjectAnalysis(gocliCtx.Ctx(), m.Dir, single)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", m.Path, err)
+		}
+		if opts.Git {
+			if gs, err := collectGitStats(m.Dir); err != nil {
+				log.Warn().Err(err).Str("module", m.Path).Msg("skip git statistics")
+			} else {
+				res.Git = gs
+			}
+		}
+		return res, nil
+	}
+
+	switch format := strings.ToLower(opts.Format); {
+	case jsonOut || format == "json":
+		results := make([]workspaceInfo, 0, len(opts.Workspace))
+		for _, m := range opts.Workspace {
+			res, err := analyze(m)
+			if err != nil {
+				return err
+			}
+			results = append(results, workspaceInfo{WorkspaceModule: m, Summary: res})
+		}
+		b, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal project info failed: %w", err)
+		}
+		_ = style.PrintJSON(w, b)
+		return nil
+	case format == "markdown" || format == "md":
+		for i, m := range opts.Workspace {
+			res, err := analyze(m)
+			if err != nil {
+				return err
+			}
+			mdOpts := count.MarkdownOptions{Title: m.Path}
+			if opts.WithPackages {
+				mdOpts.GoPackages = countGoPackages(m.Dir)
+			}
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			if err := count.RenderMarkdown(w, res, mdOpts); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for i, m := range opts.Workspace {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		_ = style.PrintHeading(w, m.Path)
+		if err := ExecuteInfoCommand(gocliCtx, single, []string{m.Dir}, false, showProjectHeader, w); err != nil {
+			return fmt.Errorf("%s: %w", m.Path, err)
+		}
+	}
+	return nil
+}
+
+// infoProgress 在 stderr 上单行刷新统计进度，例如 "counting files: 1200/5000 (24%)"
+type infoProgress struct {
+	out   io.Writer
+	auto  bool
+	last  time.Time
+	shown bool
+}
+
+// newInfoProgress 根据模式创建进度显示；stderr 不是终端、never 或 auto 且 stdout 不是终端时返回 nil
+func newInfoProgress(mode string, stdout, stderr io.Writer) (*infoProgress, error) {
+	switch strings.ToLower(mode) {
+	case "", "auto":
+		if !style.IsTerminal(stdout) || !style.IsTerminal(stderr) {
+			return nil, nil
+		}
+		return &infoProgress{out: stderr, auto: true}, nil
+	case "always", "true":
+		if !style.IsTerminal(stderr) {
+			return nil, nil
+		}
+		return &infoProgress{out: stderr}, nil
+	case "never", "false":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown progress mode %q (want auto, always or never)", mode)
+	}
+}
+
+// report 作为 count.Options.Progress 回调，最多每 100ms 刷新一次（最后一个文件总会刷新）
+func (p *infoProgress) report(done, total int) {
+	if p.auto && total < infoProgressThreshold {
+		return
+	}
+	if done < total && time.Since(p.last) < 100*time.Millisecond {
+		return
+	}
+	p.last = time.Now()
+	p.shown = true
+	pct := 100
+	if total > 0 {
+		pct = done * 100 / total
+	}
+	fmt.Fprintf(p.out, "\r\x1b[Kcounting files: %d/%d (%d%%)", done, total, pct)
+}
+
+// finish 清除进度行，使后续输出从行首开始
+func (p *infoProgress) finish() {
+	if p != nil && p.shown {
+		fmt.Fprint(p.out, "\r\x1b[K")
+	}
+}
+
 // resolveInfoRoot 解析根路径参数
 func resolveInfoRoot(args []string) string {
 	root := "."
@@ -75,17 +302,35 @@ func resolveInfoRoot(args []string) string {
 	return root
 }
 
-// collectProjectAnalysis 调用计数器执行统计
-func collectProjectAnalysis(root string, opts InfoOptions) (*models.AnalysisResult, error) {
-	ctx := context.Background()
+// collectProjectAnalysis 调用计数器执行统计，ctx 取消时尽快返回取消原因
+func collectProjectAnalysis(ctx context.Context, root string, opts InfoOptions) (*models.AnalysisResult, error) {
 	pc := &count.ProjectCounter{}
 	res, err := pc.CountProjectSummary(ctx, root, opts.Options)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, context.Cause(ctx)
+		}
 		return nil, fmt.Errorf("count project summary failed: %w", err)
 	}
 	return res, nil
 }
 
+// countGoPackages 统计 root 下的 Go 包数量，失败时返回 0（不输出该项）
+func countGoPackages(root string) int {
+	out, err := executor.NewExecutor("go", "list", "-e", "./...").WithDir(root).Output()
+	if err != nil {
+		log.Debug().Err(err).Str("root", root).Msg("go list failed, skip package count")
+		return 0
+	}
+	n := 0
+	for line := range strings.SplitSeq(out, "\n") {
+		if strings.TrimSpace(line) != "" {
+			n++
+		}
+	}
+	return n
+}
+
 // printInfoJSON 输出 JSON 结果
 func printInfoJSON(w io.Writer, res *models.AnalysisResult) error {
 	b, err := json.MarshalIndent(res, "", "  ")
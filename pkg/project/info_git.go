@@ -0,0 +1,135 @@
+package project
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/models"
+	"github.com/yeisme/gocli/pkg/style"
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// collectGitStats 统计 root 所在 git 仓库的分支与提交信息；git 不可用或 root 不在仓库内时返回错误
+func collectGitStats(root string) (*models.GitStats, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("git not found in PATH")
+	}
+	inside, err := executor.NewExecutor("git", "rev-parse", "--is-inside-work-tree").WithDir(root).ReadOnly().Output()
+	if err != nil || strings.TrimSpace(inside) != "true" {
+		return nil, fmt.Errorf("%s is not inside a git work tree", root)
+	}
+
+	stats := &models.GitStats{Branch: gitBranch(root)}
+	// 尚无提交的仓库中 git log 会失败，此时只报告分支
+	out, err := executor.NewExecutor("git", "log", "--format=%ae %at", "HEAD").WithDir(root).ReadOnly().Output()
+	if err != nil {
+		log.Debug().Err(err).Str("root", root).Msg("git log failed, reporting no commits")
+		return stats, nil
+	}
+	parseGitLog(out, stats)
+	return stats, nil
+}
+
+// gitBranch 返回当前分支名；分离头指针时返回提交短哈希
+func gitBranch(root string) string {
+	if out, err := executor.NewExecutor("git", "symbolic-ref", "--short", "-q", "HEAD").WithDir(root).ReadOnly().Output(); err == nil {
+		if b := strings.TrimSpace(out); b != "" {
+			return b
+		}
+	}
+	out, _ := executor.NewExecutor("git", "rev-parse", "--short", "HEAD").WithDir(root).ReadOnly().Output()
+	return strings.TrimSpace(out)
+}
+
+// parseGitLog 解析 "git log --format='%ae %at'" 的输出（每行 "<作者邮箱> <unix 时间>"），填充提交数、作者数与首末提交时间
+func parseGitLog(out string, stats *models.GitStats) {
+	authors := map[string]struct{}{}
+	for line := range strings.SplitSeq(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		email, ts, _ := strings.Cut(line, " ")
+		stats.Commits++
+		authors[strings.ToLower(email)] = struct{}{}
+		sec, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			continue
+		}
+		t := time.Unix(sec, 0).UTC()
+		if stats.FirstCommit.IsZero() || t.Before(stats.FirstCommit) {
+			stats.FirstCommit = t
+		}
+		if t.After(stats.LastCommit) {
+			stats.LastCommit = t
+		}
+	}
+	stats.Contributors = len(authors)
+}
+
+// printGitStats 以表格输出 git 统计
+func printGitStats(w io.Writer, g *models.GitStats) error {
+	date := func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.Local().Format("2006-01-02 15:04")
+	}
+	rows := [][]string{
+		{"Branch", g.Branch},
+		{"Commits", strconv.Itoa(g.Commits)},
+		{"Contributors", strconv.Itoa(g.Contributors)},
+		{"First commit", date(g.FirstCommit)},
+		{"Last commit", date(g.LastCommit)},
+	}
+	return style.PrintTable(w, []string{"Git", "Value"}, rows, 0)
+}
+
+// infoOwnersLimit 文本输出中项目整体作者分布最多列出的作者数
+const infoOwnersLimit = 10
+
+// printOwners 输出 --owners 的作者归属：每种语言的前 3 位作者，以及整个项目的作者分布（最多 infoOwnersLimit 位）
+func printOwners(w io.Writer, res *models.AnalysisResult) error {
+	langs := make([]string, 0, len(res.Languages))
+	for l, ls := range res.Languages {
+		if l != "Unknown" && len(ls.Owners) > 0 {
+			langs = append(langs, l)
+		}
+	}
+	sort.Strings(langs)
+	rows := make([][]string, 0, len(langs))
+	for _, l := range langs {
+		row := []string{l, "", "", ""}
+		for i, o := range res.Languages[l].Owners {
+			row[i+1] = fmt.Sprintf("%s (%.1f%%)", o.Name, o.Percent)
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) > 0 {
+		if err := style.PrintTable(w, []string{"language", "owner 1", "owner 2", "owner 3"}, rows, 0); err != nil {
+			return err
+		}
+		fmt.Fprintln(w)
+	}
+
+	owners := res.Total.Owners
+	rows = make([][]string, 0, min(len(owners), infoOwnersLimit)+1)
+	for _, o := range owners[:min(len(owners), infoOwnersLimit)] {
+		rows = append(rows, []string{o.Name, o.Email, strconv.Itoa(o.Lines), fmt.Sprintf("%.1f%%", o.Percent)})
+	}
+	if rest := len(owners) - infoOwnersLimit; rest > 0 {
+		lines := 0
+		pct := 0.0
+		for _, o := range owners[infoOwnersLimit:] {
+			lines += o.Lines
+			pct += o.Percent
+		}
+		rows = append(rows, []string{fmt.Sprintf("(%d others)", rest), "", strconv.Itoa(lines), fmt.Sprintf("%.1f%%", pct)})
+	}
+	return style.PrintTable(w, []string{"owner", "email", "lines", "share"}, rows, 0)
+}
@@ -0,0 +1,31 @@
+package project
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/models"
+)
+
+// 测试解析 git log 输出：提交数、按邮箱去重（忽略大小写）的作者数以及首末提交时间
+func TestParseGitLog(t *testing.T) {
+	out := "b@example.com 1700000300\nA@example.com 1700000200\n\na@example.com 1700000100\n"
+	var gs models.GitStats
+	parseGitLog(out, &gs)
+	if gs.Commits != 3 || gs.Contributors != 2 {
+		t.Fatalf("commits=%d contributors=%d, want 3 and 2", gs.Commits, gs.Contributors)
+	}
+	if !gs.FirstCommit.Equal(time.Unix(1700000100, 0)) || !gs.LastCommit.Equal(time.Unix(1700000300, 0)) {
+		t.Errorf("first=%v last=%v", gs.FirstCommit, gs.LastCommit)
+	}
+}
+
+// 测试目标不在 git 仓库内时返回错误而不是空统计
+func TestCollectGitStats_NotRepo(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+	if gs, err := collectGitStats(dir); err == nil {
+		t.Fatalf("expected an error outside a repository, got %+v", gs)
+	}
+}
@@ -33,6 +33,8 @@ type InitOptions struct {
 	JSON bool
 	// YAML
 	YAML bool
+	// TOML
+	TOML bool
 	// Plain 是否以纯文本格式输出
 	Plain bool
 	// Table 是否以表格格式输出
@@ -46,6 +48,11 @@ type InitOptions struct {
 
 	// Force 是否强制覆盖已存在的文件 TODO 未完成
 	Force bool
+
+	// Yes 不经确认直接执行模板的 post_init 命令
+	Yes bool
+	// Input post_init 确认提示的输入源（默认 os.Stdin）
+	Input io.Reader
 }
 
 // ExecuteInitCommand 执行初始化命令
@@ -92,7 +99,12 @@ func ExecuteInitCommand(ctx *context.GocliContext, args []string, opts InitOptio
 		return err
 	}
 
-	return nil
+	// 模板的 post_init 命令在 go mod init 与其他初始化（git init 等）完成后执行，便于使用 git add -A 等命令
+	modulePath, err := newproject.NormalizeGoProjectName(args)
+	if err != nil {
+		return err
+	}
+	return runTemplatePostInit(opts, opts.Project.Dir, modulePath, out)
 }
 
 // ExecuteGoInitCommand 执行 Go 语言项目初始化命令
@@ -236,39 +248,7 @@ func initLanguageTemplate(opts *InitOptions) {
 				// 识别 template 描述文件
 				lname := strings.ToLower(name)
 				if lname == "template.json" || lname == "template.yaml" || lname == "template.yml" {
-					b, err := os.ReadFile(innerFull)
-					if err != nil {
-						continue
-					}
-
-					var m map[string]struct {
-						Path     string `json:"path"`
-						Type     string `json:"type"`
-						Language string `json:"language,omitempty"`
-					}
-					// yaml 由于 yaml 覆盖了 json 的类型，通常使用 yaml 也能解析 json
-					if err := yaml.Unmarshal(b, &m); err == nil {
-						for k, v := range m {
-							p := v.Path
-							if p == "" {
-								p = filepath.Join(full, k)
-							}
-							t := v.Type
-							if t == "" {
-								t = "file_system"
-							}
-							if err := newproject.AddGoTemplateToOptions(&opts.Project, k, p, t); err != nil {
-								log.Warn().Err(err).Str("template", k).Msg("add template failed")
-							} else {
-								// 覆盖 language (若提供)
-								if v.Language != "" {
-									tpl := opts.Project.Go.Templates[k]
-									tpl.Language = v.Language
-									opts.Project.Go.Templates[k] = tpl
-								}
-							}
-						}
-					}
+					addTemplatesFromDescriptor(opts, full, innerFull)
 				}
 			}
 		}
@@ -276,6 +256,48 @@ func initLanguageTemplate(opts *InitOptions) {
 	log.Debug().Int("count", len(opts.Project.Go.Templates)).Msg("Go templates loaded")
 }
 
+// addTemplatesFromDescriptor 解析模板目录中的 template.yaml/json 描述文件并注册其中的模板
+// 描述文件形如 name: {path, type, language, post_init}，path 为空时使用模板目录下的同名子目录
+func addTemplatesFromDescriptor(opts *InitOptions, dir, file string) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return
+	}
+
+	var m map[string]struct {
+		Path     string   `json:"path"`
+		Type     string   `json:"type"`
+		Language string   `json:"language,omitempty"`
+		PostInit []string `json:"post_init,omitempty" yaml:"post_init"`
+	}
+	// yaml 由于 yaml 覆盖了 json 的类型，通常使用 yaml 也能解析 json
+	if err := yaml.Unmarshal(b, &m); err != nil {
+		log.Warn().Err(err).Str("file", file).Msg("parse template descriptor failed")
+		return
+	}
+	for k, v := range m {
+		p := v.Path
+		if p == "" {
+			p = filepath.Join(dir, k)
+		}
+		t := v.Type
+		if t == "" {
+			t = "file_system"
+		}
+		if err := newproject.AddGoTemplateToOptions(&opts.Project, k, p, t); err != nil {
+			log.Warn().Err(err).Str("template", k).Msg("add template failed")
+			continue
+		}
+		tpl := opts.Project.Go.Templates[k]
+		// 覆盖 language (若提供)
+		if v.Language != "" {
+			tpl.Language = v.Language
+		}
+		tpl.PostInit = v.PostInit
+		opts.Project.Go.Templates[k] = tpl
+	}
+}
+
 func initFormatCfg(opts *InitOptions) error {
 	// format / json / yaml 不能同时设置
 	cut := 0
@@ -288,6 +310,9 @@ func initFormatCfg(opts *InitOptions) error {
 	if opts.YAML {
 		cut++
 	}
+	if opts.TOML {
+		cut++
+	}
 	if opts.Plain {
 		cut++
 	}
@@ -295,7 +320,7 @@ func initFormatCfg(opts *InitOptions) error {
 		cut++
 	}
 	if cut > 1 {
-		return fmt.Errorf("format / json / yaml / plain / table cannot be set at the same time")
+		return fmt.Errorf("format / json / yaml / toml / plain / table cannot be set at the same time")
 	}
 	if cut == 0 {
 		// 默认 plain
@@ -308,6 +333,8 @@ func initFormatCfg(opts *InitOptions) error {
 			opts.JSON = true
 		case "yaml":
 			opts.YAML = true
+		case "toml":
+			opts.TOML = true
 		case "plain":
 			opts.Plain = true
 		case "table":
@@ -321,7 +348,7 @@ func initFormatCfg(opts *InitOptions) error {
 }
 
 // listTemplates 根据当前语言类型输出模板列表
-// 支持三种输出：JSON / YAML / Plain
+// 支持的输出：JSON / YAML / TOML / Table / Plain
 func listTemplates(opts *InitOptions, out io.Writer) error {
 	lang := opts.LangType
 	switch lang {
@@ -357,6 +384,15 @@ func listGoTemplates(opts *InitOptions, out io.Writer) error {
 		_ = style.PrintYAML(out, b)
 		return nil
 	}
+	if opts.TOML {
+		if _, err := fmt.Fprintf(out, "Available templates for language %q:\n", opts.LangType); err != nil {
+			return fmt.Errorf("write output failed: %w", err)
+		}
+		if err := style.PrintTOML(out, tm); err != nil {
+			return fmt.Errorf("marshal templates failed: %v", err)
+		}
+		return nil
+	}
 	if opts.Table {
 		if _, err := fmt.Fprintf(out, "Available templates for language %q:\n", opts.LangType); err != nil {
 			return fmt.Errorf("write output failed: %w", err)
@@ -0,0 +1,81 @@
+package project
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"runtime"
+	"strings"
+
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// post_init 命令可读取的环境变量
+const (
+	envProjectName = "GOCLI_PROJECT_NAME"
+	envModulePath  = "GOCLI_MODULE_PATH"
+)
+
+// runTemplatePostInit 执行所选模板描述文件中的 post_init 命令
+//   - 模板可能来自远程，执行前先列出全部命令并请求确认（--yes 跳过确认）
+//   - 每条命令在目标目录中通过系统 shell 执行，并可读取 GOCLI_PROJECT_NAME / GOCLI_MODULE_PATH
+//   - 单条命令失败不会中断后续命令，也不会回滚已创建的文件；存在失败时最后返回汇总错误
+func runTemplatePostInit(opts InitOptions, dir, modulePath string, out io.Writer) error {
+	name := strings.TrimSpace(opts.Template)
+	if name == "" {
+		return nil
+	}
+	commands := opts.Project.Go.Templates[name].PostInit
+	if len(commands) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(out, "Template %q post_init commands (run in %s):\n", name, dir)
+	for i, c := range commands {
+		fmt.Fprintf(out, "  %d. %s\n", i+1, c)
+	}
+	if !opts.Yes {
+		input := opts.Input
+		if input == nil {
+			input = os.Stdin
+		}
+		fmt.Fprint(out, "Run these commands? [y/N]: ")
+		answer, _ := bufio.NewReader(input).ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Fprintln(out, "post_init skipped (use --yes to run without confirmation)")
+			return nil
+		}
+	}
+
+	env := []string{
+		envProjectName + "=" + path.Base(modulePath),
+		envModulePath + "=" + modulePath,
+	}
+	failed := 0
+	for _, c := range commands {
+		if err := runPostInitCommand(dir, c, env, out); err != nil {
+			failed++
+			fmt.Fprintf(out, "✘ %s: %v\n", c, err)
+			continue
+		}
+		fmt.Fprintf(out, "✔ %s\n", c)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d post_init command(s) failed", failed, len(commands))
+	}
+	return nil
+}
+
+// runPostInitCommand 在 dir 中通过系统 shell（sh -c / cmd /C）执行一条命令，输出缩进后写入 out
+func runPostInitCommand(dir, line string, env []string, out io.Writer) error {
+	name, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		name, flag = "cmd", "/C"
+	}
+	print := func(l string) { fmt.Fprintln(out, "    "+l) }
+	err := executor.NewExecutor(name, flag, line).WithDir(dir).WithEnv(env...).RunLines(print, print)
+	return withoutStreamedStderr(err)
+}
@@ -0,0 +1,62 @@
+package project
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	newproject "github.com/yeisme/gocli/pkg/utils/newproject"
+)
+
+// 测试模板描述文件中的 post_init：逐条执行、暴露项目环境变量，失败的命令单独报告且不影响后续命令
+func TestRunTemplatePostInit(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("post_init commands use sh syntax")
+	}
+	tmplDir := t.TempDir()
+	descriptor := filepath.Join(tmplDir, "template.yaml")
+	if err := os.WriteFile(descriptor, []byte(`hooked:
+  post_init:
+    - echo "$GOCLI_PROJECT_NAME $GOCLI_MODULE_PATH" > hook.txt
+    - exit 3
+    - echo done >> hook.txt
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var opts InitOptions
+	opts.Project.Go = newproject.GoInitOptions{Templates: map[string]newproject.GoFileTemplate{}}
+	addTemplatesFromDescriptor(&opts, tmplDir, descriptor)
+	if got := opts.Project.Go.Templates["hooked"].PostInit; len(got) != 3 {
+		t.Fatalf("expected 3 post_init commands, got %v", got)
+	}
+
+	target := t.TempDir()
+	opts.Template = "hooked"
+
+	// 未确认时不执行任何命令
+	var out bytes.Buffer
+	opts.Input = strings.NewReader("n\n")
+	if err := runTemplatePostInit(opts, target, "example.com/acme/app", &out); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(target, "hook.txt")); err == nil {
+		t.Fatal("post_init should not run without confirmation")
+	}
+
+	out.Reset()
+	opts.Yes = true
+	err := runTemplatePostInit(opts, target, "example.com/acme/app", &out)
+	if err == nil || !strings.Contains(err.Error(), "1 of 3") {
+		t.Fatalf("expected summary error for the failing command, got %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(target, "hook.txt"))
+	if string(data) != "app example.com/acme/app\ndone\n" {
+		t.Errorf("hook.txt = %q", data)
+	}
+	if !strings.Contains(out.String(), "✘ exit 3") || !strings.Contains(out.String(), "✔ echo done >> hook.txt") {
+		t.Errorf("missing per-command report:\n%s", out.String())
+	}
+}
@@ -9,8 +9,6 @@ import (
 	"strings"
 
 	"github.com/yeisme/gocli/pkg/style"
-	"github.com/yeisme/gocli/pkg/tools"
-	"github.com/yeisme/gocli/pkg/utils/executor"
 )
 
 // LintOptions 是用于 lint 代码的选项
@@ -25,6 +23,7 @@ type LintOptions struct {
 	}
 	ConfigPath string // 配置文件路径
 
+	Golangci GolangciOptions // golangci-lint 缺失时的安装与版本检查
 }
 
 // RunLint 执行 lint 操作
@@ -51,13 +50,18 @@ func RunLint(options LintOptions, out io.Writer) error {
 		args = append(args, "-c", options.ConfigPath)
 	}
 
+	runner := newGolangciRunner(options.Golangci, out)
+	if err := runner.checkVersion(options.ConfigPath); err != nil {
+		return err
+	}
+
 	var output string
 	var err error
 
 	// list 模式需要解析输出，因此捕获到字符串；
 	// 其他模式直接把 stdout/stderr 写到 out（例如 run --fix）
 	if options.List {
-		output, err = execGolangCILint(args, nil, nil)
+		output, err = runner.exec(args, nil, nil)
 	} else {
 		// 允许 out 为 nil 的情况
 		var stderr io.Writer
@@ -72,7 +76,7 @@ func RunLint(options LintOptions, out io.Writer) error {
 			stdout = &discard
 			stderr = &discard
 		}
-		_, err = execGolangCILint(args, stdout, stderr)
+		_, err = runner.exec(args, stdout, stderr)
 	}
 	if err != nil {
 		return err
@@ -103,29 +107,6 @@ func RunLint(options LintOptions, out io.Writer) error {
 	return nil
 }
 
-// execGolangCILint 封装对 golangci-lint 的调用：
-//   - 当 stdout/stderr 为 nil 时，使用 Output 捕获并返回 stdout 字符串；
-//   - 当提供 stdout/stderr 时，使用 RunStreaming 直接写入并返回空字符串
-func execGolangCILint(args []string, stdout, stderr io.Writer) (string, error) {
-	_, err := tools.TestExists("golangci-lint")
-	if err != nil {
-		return "", err
-	}
-
-	exec := executor.NewExecutor("golangci-lint", args...)
-	if stdout == nil && stderr == nil {
-		output, err := exec.Output()
-		if err != nil {
-			return "", err
-		}
-		return output, nil
-	}
-	if err := exec.RunStreaming(stdout, stderr); err != nil {
-		return "", err
-	}
-	return "", nil
-}
-
 var linterLineRE = regexp.MustCompile(`^([a-zA-Z0-9_-]+):\s+(.*)$`)
 
 // parseLintersOutput 解析 golangci-lint linters 输出，返回格式化后的结构
@@ -2,12 +2,15 @@ package project
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/yeisme/gocli/pkg/style"
 	"github.com/yeisme/gocli/pkg/utils/list"
 )
 
@@ -15,13 +18,52 @@ import (
 type ListOptions struct {
 	JSON bool
 	Test bool
+	// Format selects the output: "list" (default, import paths), "table" (per-package overview) or "json" (same as JSON)
+	Format string
+	// Workspace, when non-empty, runs `go list` in every go.work module (--workspace):
+	// the list format is grouped under a heading per module and the table format gains a Module column.
+	Workspace []WorkspaceModule
+}
+
+// List output formats accepted by --format.
+const (
+	ListFormatList  = "list"
+	ListFormatTable = "table"
+	ListFormatJSON  = "json"
+)
+
+// listPackage holds the `go list -json` fields used by the table format.
+type listPackage struct {
+	ImportPath   string
+	GoFiles      []string
+	CgoFiles     []string
+	TestGoFiles  []string
+	XTestGoFiles []string
+	Imports      []string
+	Module       *struct{ Path string }
 }
 
 // RunList executes the `go list` command with the provided options and writes the output to the specified writer.
 func RunList(opts ListOptions, out io.Writer, args []string) error {
 	args = normalizeListArgs(args)
 
-	output, err := list.RunGoList(context.Background(), opts, args)
+	format := strings.ToLower(strings.TrimSpace(opts.Format))
+	switch format {
+	case "", ListFormatList:
+	case ListFormatJSON:
+		opts.JSON = true
+	case ListFormatTable:
+	default:
+		return fmt.Errorf("unknown list format %q (want list, table or json)", opts.Format)
+	}
+	if len(opts.Workspace) > 0 {
+		return runListWorkspace(opts, format, out, args)
+	}
+	if format == ListFormatTable {
+		return runListTable(opts, out, args)
+	}
+
+	output, err := list.RunGoList(context.Background(), struct{ JSON, Test bool }{opts.JSON, opts.Test}, args)
 	if err != nil {
 		return err
 	}
@@ -29,6 +71,119 @@ func RunList(opts ListOptions, out io.Writer, args []string) error {
 	return nil
 }
 
+// runListTable lists packages with `go list -json` and prints one row per package:
+// import path, Go file count (including cgo files), test file count and direct import count.
+func runListTable(opts ListOptions, out io.Writer, args []string) error {
+	output, err := list.RunGoList(context.Background(), struct{ JSON, Test bool }{true, opts.Test}, args)
+	if err != nil {
+		return err
+	}
+	pkgs, err := decodeListPackages(output)
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(pkgs))
+	for _, p := range pkgs {
+		rows = append(rows, listTableRow(p))
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return style.PrintTable(out, []string{"Package", "Go Files", "Test Files", "Imports"}, rows, 0)
+}
+
+// listTableRow returns the table cells of one package: import path, Go files, test files and direct imports.
+func listTableRow(p listPackage) []string {
+	return []string{
+		p.ImportPath,
+		strconv.Itoa(len(p.GoFiles) + len(p.CgoFiles)),
+		strconv.Itoa(len(p.TestGoFiles) + len(p.XTestGoFiles)),
+		strconv.Itoa(len(p.Imports)),
+	}
+}
+
+// runListWorkspace runs `go list -json` in each workspace module and merges the results.
+// Packages owned by another module (e.g. a nested workspace module matched by ./...) are left to that module,
+// so every package appears exactly once:
+//   - json: the go list objects of all modules, in workspace order;
+//   - table: one table with a leading Module column;
+//   - list: a heading per module followed by its import paths.
+func runListWorkspace(opts ListOptions, format string, out io.Writer, args []string) error {
+	var rows [][]string
+	err := inWorkspaceModules(opts.Workspace, false, func(m WorkspaceModule) error {
+		output, err := list.RunGoList(context.Background(), struct{ JSON, Test bool }{true, opts.Test}, args)
+		if err != nil {
+			return err
+		}
+		raws, pkgs, err := decodeListPackagesRaw(output)
+		if err != nil {
+			return err
+		}
+		var paths []string
+		for i, p := range pkgs {
+			if p.Module != nil && p.Module.Path != m.Path {
+				continue
+			}
+			switch {
+			case format == ListFormatTable:
+				rows = append(rows, append([]string{m.Path}, listTableRow(p)...))
+			case opts.JSON:
+				fmt.Fprintln(out, string(raws[i]))
+			default:
+				paths = append(paths, p.ImportPath)
+			}
+		}
+		if format != ListFormatTable && !opts.JSON {
+			_ = style.PrintHeading(out, m.Path)
+			_ = style.PrintPackageList(out, paths)
+		}
+		return nil
+	})
+	if len(rows) > 0 {
+		if terr := style.PrintTable(out, []string{"Module", "Package", "Go Files", "Test Files", "Imports"}, rows, 0); terr != nil {
+			return terr
+		}
+	}
+	return err
+}
+
+// decodeListPackagesRaw is like decodeListPackages but also returns each object's original JSON,
+// so that callers can filter packages without dropping fields listPackage does not declare.
+func decodeListPackagesRaw(output string) ([]json.RawMessage, []listPackage, error) {
+	var raws []json.RawMessage
+	var pkgs []listPackage
+	dec := json.NewDecoder(strings.NewReader(output))
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err == io.EOF {
+			return raws, pkgs, nil
+		} else if err != nil {
+			return nil, nil, fmt.Errorf("decode go list output failed: %w", err)
+		}
+		var p listPackage
+		if err := json.Unmarshal(raw, &p); err != nil {
+			return nil, nil, fmt.Errorf("decode go list output failed: %w", err)
+		}
+		raws = append(raws, raw)
+		pkgs = append(pkgs, p)
+	}
+}
+
+// decodeListPackages decodes the concatenated JSON objects printed by `go list -json`.
+func decodeListPackages(output string) ([]listPackage, error) {
+	var pkgs []listPackage
+	dec := json.NewDecoder(strings.NewReader(output))
+	for {
+		var p listPackage
+		if err := dec.Decode(&p); err == io.EOF {
+			return pkgs, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("decode go list output failed: %w", err)
+		}
+		pkgs = append(pkgs, p)
+	}
+}
+
 // normalizeListArgs ensures the first (and each provided) argument is a valid path / pattern
 // understood by `go list`. Behaviour:
 //   - No args => ["./..."]
@@ -0,0 +1,26 @@
+package project
+
+import (
+	"bytes"
+	"testing"
+)
+
+// 测试解析 go list -json 输出的多个对象，以及未知的 --format 报错
+func TestDecodeListPackages(t *testing.T) {
+	out := `{"ImportPath": "example.com/a", "GoFiles": ["a.go", "b.go"], "CgoFiles": ["c.go"], "TestGoFiles": ["a_test.go"], "XTestGoFiles": ["x_test.go"], "Imports": ["fmt"]}
+{"ImportPath": "example.com/b"}
+`
+	pkgs, err := decodeListPackages(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pkgs) != 2 || pkgs[0].ImportPath != "example.com/a" || len(pkgs[0].GoFiles)+len(pkgs[0].CgoFiles) != 3 || len(pkgs[1].Imports) != 0 {
+		t.Fatalf("unexpected packages: %+v", pkgs)
+	}
+	if _, err := decodeListPackages("{broken"); err == nil {
+		t.Error("invalid JSON should fail")
+	}
+	if err := RunList(ListOptions{Format: "xml"}, &bytes.Buffer{}, nil); err == nil {
+		t.Error("unknown format should fail")
+	}
+}
@@ -0,0 +1,344 @@
+package project
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/context"
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// portReleaseTimeout 是等待端口被释放（上一次运行的实例退出、被终止的进程退出）的最长时间
+var portReleaseTimeout = 5 * time.Second
+
+// portOwner 是监听端口的进程，无法确定时 PID 为 0（尽力而为：Linux 读取 /proc，其他平台解析 lsof/netstat）
+type portOwner struct {
+	PID  int
+	Name string
+}
+
+func (o portOwner) String() string {
+	switch {
+	case o.PID == 0:
+		return "unknown process"
+	case o.Name == "":
+		return fmt.Sprintf("pid %d", o.PID)
+	default:
+		return fmt.Sprintf("pid %d %s", o.PID, o.Name)
+	}
+}
+
+// portChecker 在 run 启动程序前检查 run.check_ports / --check-port 中的端口是否空闲：
+//   - 端口被占用时报告占用的进程并中止
+//   - 热重载的重启（非首次检查）中，端口属于 gocli 自己的子进程（上一次运行的实例）时等待其退出，而不是报错
+//   - KillConflicting 时在终端上询问是否终止占用端口的进程，从不在未确认的情况下终止
+//
+// nil *portChecker 表示不检查
+type portChecker struct {
+	ports   []int
+	kill    bool
+	prompt  io.Writer
+	input   io.Reader
+	started bool // 已检查过一次，之后的检查属于热重载的重启
+}
+
+// newPortChecker 读取 --check-port（未指定时使用配置 run.check_ports），没有端口时返回 nil
+func newPortChecker(gocliCtx *context.GocliContext, options BuildRunOptions) (*portChecker, error) {
+	ports := options.CheckPorts
+	if len(ports) == 0 && gocliCtx != nil && gocliCtx.Config != nil {
+		ports = gocliCtx.Config.Run.CheckPorts
+	}
+	if len(ports) == 0 {
+		return nil, nil
+	}
+	for _, p := range ports {
+		if p <= 0 || p > 65535 {
+			return nil, fmt.Errorf("invalid port %d to check (must be 1-65535)", p)
+		}
+	}
+	input := options.Input
+	if input == nil {
+		input = os.Stdin
+	}
+	return &portChecker{ports: ports, kill: options.KillConflicting, prompt: options.Prompt, input: input}, nil
+}
+
+// check 检查所有端口，返回列出仍被占用的端口及其进程的错误；--dry-run 时不检查
+func (c *portChecker) check() error {
+	if c == nil || executor.Recording() {
+		return nil
+	}
+	restart := c.started
+	c.started = true
+
+	var busy []string
+	for _, port := range c.ports {
+		if !portInUse(port) {
+			continue
+		}
+		owner := lookupPortOwner(port)
+		if restart && owner.PID != 0 && ownProcess(owner.PID) {
+			log.Info().Msgf("run: port %d is still held by the previous instance (%s), waiting for it to exit", port, owner)
+			if waitPortFree(port, portReleaseTimeout) {
+				continue
+			}
+		}
+		if c.kill {
+			killed, err := c.killOwner(port, owner)
+			if err != nil {
+				return err
+			}
+			if killed {
+				continue
+			}
+		}
+		busy = append(busy, fmt.Sprintf("%d (%s)", port, owner))
+	}
+	if len(busy) == 0 {
+		return nil
+	}
+	hint := "stop that process or pass --kill-conflicting"
+	if c.kill {
+		hint = "stop that process first"
+	}
+	return fmt.Errorf("port already in use: %s; %s", strings.Join(busy, ", "), hint)
+}
+
+// killOwner 询问是否终止占用端口的进程，确认后发送 SIGTERM（Windows 上直接终止）并等待端口释放。
+// 无法确认（非终端）、进程未知或用户拒绝时返回 false
+func (c *portChecker) killOwner(port int, owner portOwner) (bool, error) {
+	if owner.PID == 0 || owner.PID == os.Getpid() {
+		return false, nil
+	}
+	if c.prompt == nil {
+		log.Warn().Msgf("run: --kill-conflicting needs a terminal to confirm terminating %s", owner)
+		return false, nil
+	}
+	fmt.Fprintf(c.prompt, "Port %d is in use by %s. Terminate it? [y/N]: ", port, owner)
+	answer, _ := bufio.NewReader(c.input).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer != "y" && answer != "yes" {
+		return false, nil
+	}
+	p, err := os.FindProcess(owner.PID)
+	if err == nil {
+		if runtime.GOOS == "windows" {
+			err = p.Kill()
+		} else {
+			err = p.Signal(syscall.SIGTERM)
+		}
+	}
+	if err != nil {
+		return false, fmt.Errorf("terminate %s failed: %w", owner, err)
+	}
+	if !waitPortFree(port, portReleaseTimeout) {
+		return false, fmt.Errorf("port %d is still in use %s after terminating %s", port, portReleaseTimeout, owner)
+	}
+	log.Info().Msgf("run: terminated %s, port %d is free", owner, port)
+	return true, nil
+}
+
+// portInUse 通过尝试监听判断端口是否被占用；没有权限监听（如非 root 的低端口）时改为尝试连接本机端口
+func portInUse(port int) bool {
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err == nil {
+		_ = l.Close()
+		return false
+	}
+	if !errors.Is(err, os.ErrPermission) {
+		return true
+	}
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("127.0.0.1:%d", port), time.Second)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
+
+// waitPortFree 每 100ms 检查一次，直到端口空闲或超时
+func waitPortFree(port int, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for portInUse(port) {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return true
+}
+
+// lookupPortOwner 查找监听端口的进程：Linux 先读取 /proc，其余情况依次尝试 lsof 与（Windows 上的）netstat
+func lookupPortOwner(port int) portOwner {
+	if runtime.GOOS == "linux" {
+		if o := procPortOwner(port); o.PID != 0 {
+			return o
+		}
+	}
+	if out, err := exec.Command("lsof", "-nP", fmt.Sprintf("-iTCP:%d", port), "-sTCP:LISTEN", "-Fpc").Output(); err == nil {
+		if o := parseLsofOwner(string(out)); o.PID != 0 {
+			return o
+		}
+	}
+	if runtime.GOOS == "windows" {
+		if out, err := exec.Command("netstat", "-ano", "-p", "tcp").Output(); err == nil {
+			if pid := parseNetstatOwner(string(out), port); pid != 0 {
+				return portOwner{PID: pid, Name: windowsProcessName(pid)}
+			}
+		}
+	}
+	return portOwner{}
+}
+
+// parseLsofOwner 解析 lsof -F pc 的输出：以 p 开头的行为 PID，以 c 开头的行为命令名
+func parseLsofOwner(out string) portOwner {
+	var o portOwner
+	for line := range strings.Lines(out) {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "p") && o.PID == 0:
+			o.PID, _ = strconv.Atoi(line[1:])
+		case strings.HasPrefix(line, "c") && o.PID != 0:
+			o.Name = line[1:]
+			return o
+		}
+	}
+	return o
+}
+
+// parseNetstatOwner 解析 netstat -ano 的输出，返回在 port 上处于 LISTENING 状态的 PID，
+// 例如 "  TCP    0.0.0.0:8080    0.0.0.0:0    LISTENING    1234"
+func parseNetstatOwner(out string, port int) int {
+	suffix := ":" + strconv.Itoa(port)
+	for line := range strings.Lines(out) {
+		f := strings.Fields(line)
+		if len(f) < 5 || !strings.EqualFold(f[0], "TCP") || !strings.EqualFold(f[3], "LISTENING") {
+			continue
+		}
+		if strings.HasSuffix(f[1], suffix) {
+			if pid, err := strconv.Atoi(f[4]); err == nil {
+				return pid
+			}
+		}
+	}
+	return 0
+}
+
+// windowsProcessName 通过 tasklist 查询进程名，失败时返回空字符串
+func windowsProcessName(pid int) string {
+	out, err := exec.Command("tasklist", "/FI", fmt.Sprintf("PID eq %d", pid), "/FO", "CSV", "/NH").Output()
+	if err != nil {
+		return ""
+	}
+	name, _, _ := strings.Cut(strings.TrimSpace(string(out)), ",")
+	name = strings.Trim(name, `"`)
+	if strings.HasPrefix(name, "INFO:") {
+		return ""
+	}
+	return name
+}
+
+// procPortOwner 在 /proc/net/tcp{,6} 中查找监听 port 的 socket inode，再在 /proc/<pid>/fd 中查找持有它的进程；
+// 无权读取其他用户的进程时找不到
+func procPortOwner(port int) portOwner {
+	inodes := map[string]bool{}
+	for _, f := range []string{"/proc/net/tcp", "/proc/net/tcp6"} {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		for line := range strings.Lines(string(data)) {
+			// sl local_address rem_address st tx_queue:rx_queue tr:tm->when retrnsmt uid timeout inode
+			f := strings.Fields(line)
+			if len(f) < 10 || f[3] != "0A" { // 0A: TCP_LISTEN
+				continue
+			}
+			_, hexPort, ok := strings.Cut(f[1], ":")
+			if p, err := strconv.ParseInt(hexPort, 16, 32); ok && err == nil && int(p) == port && f[9] != "0" {
+				inodes["socket:["+f[9]+"]"] = true
+			}
+		}
+	}
+	if len(inodes) == 0 {
+		return portOwner{}
+	}
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return portOwner{}
+	}
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		fdDir := filepath.Join("/proc", e.Name(), "fd")
+		fds, err := os.ReadDir(fdDir)
+		if err != nil {
+			continue
+		}
+		for _, fd := range fds {
+			if target, err := os.Readlink(filepath.Join(fdDir, fd.Name())); err == nil && inodes[target] {
+				comm, _ := os.ReadFile(filepath.Join("/proc", e.Name(), "comm"))
+				return portOwner{PID: pid, Name: strings.TrimSpace(string(comm))}
+			}
+		}
+	}
+	return portOwner{}
+}
+
+// ownProcess 判断 pid 是否为 gocli 自身或其子孙进程（例如上一次 go run 启动的程序）
+func ownProcess(pid int) bool {
+	self := os.Getpid()
+	for range 64 {
+		if pid == self {
+			return true
+		}
+		if pid <= 1 {
+			return false
+		}
+		pid = parentPID(pid)
+	}
+	return false
+}
+
+// parentPID 返回进程的父进程 PID：Linux 读取 /proc/<pid>/stat，其他 Unix 使用 ps，无法获取时返回 0
+func parentPID(pid int) int {
+	switch runtime.GOOS {
+	case "linux":
+		data, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+		if err != nil {
+			return 0
+		}
+		// pid (comm) state ppid ...，comm 可能包含空格与括号
+		i := strings.LastIndexByte(string(data), ')')
+		if i < 0 {
+			return 0
+		}
+		f := strings.Fields(string(data[i+1:]))
+		if len(f) < 2 {
+			return 0
+		}
+		ppid, _ := strconv.Atoi(f[1])
+		return ppid
+	case "windows":
+		return 0
+	default:
+		out, err := exec.Command("ps", "-o", "ppid=", "-p", strconv.Itoa(pid)).Output()
+		if err != nil {
+			return 0
+		}
+		ppid, _ := strconv.Atoi(strings.TrimSpace(string(out)))
+		return ppid
+	}
+}
@@ -0,0 +1,91 @@
+package project
+
+import (
+	"net"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 测试端口检查：测试进程持有监听时报告端口被占用（Linux 上还能找到占用的进程），
+// 热重载重启时等待自己的进程释放端口，--kill-conflicting 被拒绝时不终止任何进程
+func TestPortChecker(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	if !portInUse(port) {
+		t.Fatalf("port %d held by the test should be in use", port)
+	}
+	owner := lookupPortOwner(port)
+	if runtime.GOOS == "linux" && owner.PID != 0 && owner.PID != os.Getpid() {
+		t.Errorf("owner = %v, want pid %d", owner, os.Getpid())
+	}
+
+	c, err := newPortChecker(nil, BuildRunOptions{BuildinOptions: BuildinOptions{CheckPorts: []int{port}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.check()
+	if err == nil || !strings.Contains(err.Error(), "port already in use: "+strconv.Itoa(port)) || !strings.Contains(err.Error(), "--kill-conflicting") {
+		t.Fatalf("expected a busy port error, got %v", err)
+	}
+	if owner.PID != 0 && !strings.Contains(err.Error(), "pid "+strconv.Itoa(owner.PID)) {
+		t.Errorf("error should name the owner: %v", err)
+	}
+
+	// 拒绝终止：仍然报错，并且只提示手动停止
+	var prompt strings.Builder
+	c = &portChecker{ports: []int{port}, kill: true, prompt: &prompt, input: strings.NewReader("n\n")}
+	if err := c.check(); err == nil || strings.Contains(err.Error(), "--kill-conflicting") {
+		t.Errorf("expected a busy port error without the --kill-conflicting hint, got %v", err)
+	}
+
+	// 重启时端口属于自己的进程：等待释放而不是报错
+	if owner.PID != 0 {
+		saved := portReleaseTimeout
+		portReleaseTimeout = 3 * time.Second
+		t.Cleanup(func() { portReleaseTimeout = saved })
+		c = &portChecker{ports: []int{port}, started: true}
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			_ = l.Close()
+		}()
+		if err := c.check(); err != nil {
+			t.Errorf("restart should wait for the port to be released: %v", err)
+		}
+	}
+	_ = l.Close()
+	if portInUse(port) {
+		t.Errorf("port %d should be free after closing the listener", port)
+	}
+
+	if _, err := newPortChecker(nil, BuildRunOptions{BuildinOptions: BuildinOptions{CheckPorts: []int{70000}}}); err == nil {
+		t.Error("expected an invalid port error")
+	}
+}
+
+// 测试 lsof -F 与 netstat -ano 输出的解析
+func TestParsePortOwner(t *testing.T) {
+	if o := parseLsofOwner("p4321\ncmy server\nf5\n"); o.PID != 4321 || o.Name != "my server" {
+		t.Errorf("lsof owner = %+v", o)
+	}
+	netstat := `
+Active Connections
+
+  Proto  Local Address          Foreign Address        State           PID
+  TCP    0.0.0.0:18080          0.0.0.0:0              LISTENING       99
+  TCP    0.0.0.0:8080           0.0.0.0:0              LISTENING       1234
+  TCP    [::]:8080              [::]:0                 LISTENING       1234
+`
+	if pid := parseNetstatOwner(netstat, 8080); pid != 1234 {
+		t.Errorf("netstat pid = %d", pid)
+	}
+	if pid := parseNetstatOwner(netstat, 80); pid != 0 {
+		t.Errorf("netstat pid for a free port = %d", pid)
+	}
+}
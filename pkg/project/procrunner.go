@@ -0,0 +1,587 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/configs"
+	gctx "github.com/yeisme/gocli/pkg/context"
+	"github.com/yeisme/gocli/pkg/debug"
+	"github.com/yeisme/gocli/pkg/style"
+	"github.com/yeisme/gocli/pkg/utils/executor"
+	"github.com/yeisme/gocli/pkg/utils/hotload"
+)
+
+// procColors 是进程名前缀依次使用的 ANSI 前景色（青、黄、绿、品红、蓝、红）
+var procColors = []string{"36", "33", "32", "35", "34", "31"}
+
+// proc 是 procRunner 管理的一个进程
+type proc struct {
+	Name    string                   // 输出前缀
+	Command []string                 // 程序及其参数
+	Dir     string                   // 工作目录，为空时使用当前目录
+	Build   func() error             // 每次启动前执行的构建，可为 nil
+	Env     func() ([]string, error) // 每次启动前附加到进程的环境变量（如重新读取的 .env），可为 nil
+	Deps    []string                 // 进程的包及其依赖所在的目录，热重载时用于判断变更归属
+
+	color  string
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// procExit 是一次进程运行的结果
+type procExit struct {
+	p       *proc
+	err     error
+	stopped bool // 因重启或整体停止被中止，不算失败
+}
+
+// procRunner 并发运行多个进程（类似 foreman / overmind）：
+//   - 每行输出加上按进程区分颜色、对齐的名称前缀
+//   - Run 的 ctx 取消时（例如收到 SIGINT）向所有进程发送中断信号，超时未退出的进程被强制终止
+//   - 任一进程失败（构建失败或非零退出）时停止其余进程，keepRunning 时只报告失败
+//   - Restart 重新构建并只重启指定的进程
+type procRunner struct {
+	out         io.Writer
+	procs       []*proc
+	keepRunning bool
+	// watch 为 true 时（热重载）所有进程退出后仍等待 Restart，直到 ctx 取消
+	watch   bool
+	width   int
+	colored bool
+	prefix  bool          // 多于一个进程时才输出名称前缀
+	started chan struct{} // 初始构建与启动完成后关闭
+
+	outMu sync.Mutex // 串行化各进程的输出行
+
+	mu     sync.Mutex // 保护以下字段以及各 proc 的 cancel/done
+	ctx    context.Context
+	live   int // 尚未被 Run 回收的运行（含正在重启的进程）
+	exits  chan procExit
+	failed map[string]error // 最近一次运行失败的进程
+}
+
+// newProcRunner 创建 procRunner，进程名前缀按最长的名称对齐
+func newProcRunner(out io.Writer, procs []*proc, keepRunning bool) *procRunner {
+	r := &procRunner{
+		out:         out,
+		procs:       procs,
+		keepRunning: keepRunning,
+		colored:     style.ColorEnabled(out),
+		prefix:      len(procs) > 1,
+		failed:      map[string]error{},
+		started:     make(chan struct{}),
+	}
+	for i, p := range procs {
+		r.width = max(r.width, len(p.Name))
+		p.color = procColors[i%len(procColors)]
+	}
+	return r
+}
+
+// Run 启动所有进程并等待它们全部退出；ctx 取消时停止所有进程。
+// 返回最近一次运行失败的进程的错误（被中断的进程不算失败）
+func (r *procRunner) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	r.mu.Lock()
+	r.ctx = ctx
+	r.live = len(r.procs)
+	r.exits = make(chan procExit, len(r.procs))
+	r.mu.Unlock()
+
+	for _, p := range r.procs {
+		_ = r.launch(p)
+	}
+	close(r.started)
+	stop := ctx.Done()
+	for {
+		r.mu.Lock()
+		live := r.live
+		r.mu.Unlock()
+		if live == 0 && (!r.watch || ctx.Err() != nil) {
+			break
+		}
+		var e procExit
+		select {
+		case e = <-r.exits:
+		case <-stop:
+			stop = nil
+			continue
+		}
+		r.mu.Lock()
+		r.live--
+		r.mu.Unlock()
+		switch {
+		case e.stopped:
+		case e.err != nil:
+			r.printf(e.p, "exited: %v", e.err)
+			r.setFailed(e.p, e.err)
+			if !r.keepRunning && ctx.Err() == nil {
+				r.printf(e.p, "stopping all processes")
+				cancel()
+			}
+		default:
+			r.printf(e.p, "exited")
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var errs []error
+	for _, p := range r.procs {
+		if err := r.failed[p.Name]; err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Restart 依次停止、重新构建并启动 procs（为空时为全部进程），Run 未开始或已停止时不做任何事。
+// 返回构建失败的错误；构建失败的进程保持停止，直到下一次重启
+func (r *procRunner) Restart(procs ...*proc) error {
+	if len(procs) == 0 {
+		procs = r.procs
+	}
+	var errs []error
+	for _, p := range procs {
+		r.mu.Lock()
+		if r.ctx == nil || r.ctx.Err() != nil {
+			r.mu.Unlock()
+			break
+		}
+		// 先占位，避免旧进程退出后 Run 因为没有存活的进程而返回
+		r.live++
+		cancel, done := p.cancel, p.done
+		r.mu.Unlock()
+		if cancel != nil {
+			cancel()
+			<-done
+		}
+		r.printf(p, "restarting")
+		if err := r.launch(p); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// affected 返回受变更文件影响的进程：文件所在目录属于进程的包或其依赖。
+// 有文件不属于任何进程（go.mod、配置文件等）或无法判断时返回全部进程
+func (r *procRunner) affected(changed []string) []*proc {
+	var out []*proc
+	for _, f := range changed {
+		if abs, err := filepath.Abs(f); err == nil {
+			f = abs
+		}
+		dir := filepath.Dir(f)
+		matched := false
+		for _, p := range r.procs {
+			if slices.Contains(p.Deps, dir) {
+				matched = true
+				if !slices.Contains(out, p) {
+					out = append(out, p)
+				}
+			}
+		}
+		if !matched {
+			return r.procs
+		}
+	}
+	if len(out) == 0 {
+		return r.procs
+	}
+	return out
+}
+
+// launch 同步构建 p，成功后在后台运行；运行结束（或构建失败）时把结果发送给 Run
+func (r *procRunner) launch(p *proc) error {
+	r.mu.Lock()
+	ctx, cancel := context.WithCancel(r.ctx)
+	done := make(chan struct{})
+	p.cancel, p.done = cancel, done
+	delete(r.failed, p.Name)
+	r.mu.Unlock()
+
+	if p.Build != nil {
+		if err := p.Build(); err != nil {
+			err = fmt.Errorf("build failed: %w", procError(err))
+			r.exits <- procExit{p: p, err: err, stopped: ctx.Err() != nil}
+			cancel()
+			close(done)
+			return err
+		}
+	}
+	go func() {
+		defer close(done)
+		err := r.runOnce(ctx, p)
+		r.exits <- procExit{p: p, err: err, stopped: ctx.Err() != nil}
+		cancel()
+	}()
+	return nil
+}
+
+// runOnce 运行一次进程，输出逐行加上前缀；ctx 取消时先发送中断信号
+func (r *procRunner) runOnce(ctx context.Context, p *proc) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	e := executor.NewExecutor(p.Command[0], p.Command[1:]...).WithContext(ctx).WithStopSignal(os.Interrupt)
+	if p.Dir != "" {
+		e.WithDir(p.Dir)
+	}
+	if p.Env != nil {
+		env, err := p.Env()
+		if err != nil {
+			return err
+		}
+		if len(env) > 0 {
+			e.WithEnv(env...)
+		}
+	}
+	defer withStderrCapture(e)()
+	r.printf(p, "started")
+	line := func(l string) { r.writeLine(p, l) }
+	return procError(e.RunLines(line, line))
+}
+
+// withStderrCapture 把子进程的 stderr 同时写入 debug.StderrCaptureFile(pid)，
+// 使 gocli debug stack --pid 能读取 SIGQUIT 输出的 goroutine 栈；返回的函数在进程结束后删除该文件。
+// debug stack --pid 依赖 /proc，其他平台不保存副本
+func withStderrCapture(e *executor.Executor) func() {
+	if runtime.GOOS != "linux" {
+		return func() {}
+	}
+	c, err := debug.NewStderrCapture()
+	if err != nil {
+		log.Debug().Err(err).Msg("stderr capture for debug stack disabled")
+		return func() {}
+	}
+	e.WithStderr(c).WithOnStart(c.Started)
+	return func() { _ = c.Close() }
+}
+
+// procError 把命令执行错误简化为退出状态（命令已由输出前缀表明），其他错误原样返回
+func procError(err error) error {
+	var execErr *executor.ExecError
+	if errors.As(err, &execErr) && execErr.ExitCode() >= 0 {
+		return fmt.Errorf("exit status %d", execErr.ExitCode())
+	}
+	return err
+}
+
+func (r *procRunner) setFailed(p *proc, err error) {
+	r.mu.Lock()
+	r.failed[p.Name] = err
+	r.mu.Unlock()
+}
+
+// printf 输出一行 gocli 自身关于进程的状态信息
+func (r *procRunner) printf(p *proc, format string, args ...any) {
+	r.writeLine(p, "["+fmt.Sprintf(format, args...)+"]")
+}
+
+// writeLine 输出带进程名前缀的一行，例如 "api    | listening on :8080"；只有一个进程时不加前缀
+func (r *procRunner) writeLine(p *proc, line string) {
+	r.outMu.Lock()
+	defer r.outMu.Unlock()
+	if !r.prefix {
+		fmt.Fprintln(r.out, line)
+		return
+	}
+	prefix := p.Name + strings.Repeat(" ", r.width-len(p.Name)) + " |"
+	if r.colored {
+		prefix = "\x1b[" + p.color + "m" + prefix + "\x1b[0m"
+	}
+	fmt.Fprintf(r.out, "%s %s\n", prefix, line)
+}
+
+// executeMultiRun 把每个 main 包构建到临时目录并并发运行（gocli project run ./cmd/api ./cmd/worker）：
+// SIGINT/SIGTERM 传递给所有进程；热重载时只重新构建并重启受变更影响的进程
+func executeMultiRun(gocliCtx *gctx.GocliContext, options BuildRunOptions, mains []mainPackage, progArgs []string) error {
+	binDir, err := os.MkdirTemp("", "gocli-run-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(binDir) }()
+
+	importPaths := make([]string, len(mains))
+	for i, m := range mains {
+		importPaths[i] = m.ImportPath
+	}
+	names := procNames(importPaths)
+	procs := make([]*proc, len(mains))
+	for i, m := range mains {
+		bin := filepath.Join(binDir, strings.ReplaceAll(names[i], "/", "_"))
+		if runtime.GOOS == "windows" {
+			bin += ".exe"
+		}
+		buildOpts := options
+		buildOpts.Output = bin
+		importPath := m.ImportPath
+		procs[i] = &proc{
+			Name:    names[i],
+			Command: append([]string{bin}, progArgs...),
+			Dir:     options.ChangeDir,
+			Build: func() error {
+				return runGoCommand(buildOpts, goProcessArgs("build", buildOpts, []string{importPath}))
+			},
+			Env: func() ([]string, error) { return loadRunEnvFiles(gocliCtx, options) },
+		}
+	}
+	// 所有进程共用一组端口，只在首次启动前检查；重启的进程与仍在运行的其他进程之间无法区分端口归属
+	ports, err := newPortChecker(gocliCtx, options)
+	if err != nil {
+		return err
+	}
+	if err := ports.check(); err != nil {
+		return err
+	}
+	log.Info().Strs("packages", importPaths).Msg("run: starting entrypoints")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	runner := newProcRunner(os.Stdout, procs, options.KeepRunning || options.HotReload)
+	if !options.HotReload {
+		return runner.Run(ctx)
+	}
+	cfg := hotloadConfigFor(gocliCtx, options)
+	if cfg.Exec != "" {
+		log.Warn().Msg("[HotReload] app.hotload.exec is ignored when running several entrypoints")
+		cfg.Exec = ""
+	}
+	return runnerHotReload(ctx, cfg, options, runner, importPaths)
+}
+
+// executeSingleHotReload 热重载单个入口：与多入口相同，构建到临时目录后由 procRunner 在后台运行，
+// 因此 post 钩子在程序启动后执行，而不是等到程序退出；app.hotload.exec 非空时改为运行该命令
+func executeSingleHotReload(gocliCtx *gctx.GocliContext, options BuildRunOptions, cfg configs.HotloadConfig, args []string, ports *portChecker) error {
+	binDir, err := os.MkdirTemp("", "gocli-run-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(binDir) }()
+
+	pkgArgs, progArgs := processTargets(args)
+	bin := filepath.Join(binDir, "main")
+	if runtime.GOOS == "windows" {
+		bin += ".exe"
+	}
+	buildOpts := options
+	buildOpts.Output = bin
+	p := &proc{
+		Name:    "run",
+		Command: append([]string{bin}, progArgs...),
+		Dir:     options.ChangeDir,
+		Build: func() error {
+			goArgs := append([]string{"build"}, buildArgsFromOptions(buildOpts)...)
+			return runGoCommand(buildOpts, append(goArgs, pkgArgs...))
+		},
+		Env: func() ([]string, error) {
+			if err := ports.check(); err != nil {
+				return nil, err
+			}
+			return loadRunEnvFiles(gocliCtx, options)
+		},
+	}
+	if cfg.Exec != "" {
+		p.Command, p.Build = shellArgs(cfg.Exec), nil
+		cfg.Exec = ""
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return runnerHotReload(ctx, cfg, options, newProcRunner(os.Stdout, []*proc{p}, true), nil)
+}
+
+// runnerHotReload 运行 runner 中的进程并监听变更：pre 钩子 -> 启动 -> post 钩子包裹初始启动与每次重启，
+// post 钩子在进程启动后执行。importPaths 与进程一一对应时根据 go list -deps 只重新构建并重启
+// 依赖变更文件的进程，为空或无法判断归属时重启全部
+func runnerHotReload(ctx context.Context, cfg configs.HotloadConfig, options BuildRunOptions, runner *procRunner, importPaths []string) error {
+	if !cfg.Enabled {
+		log.Warn().Msg("[HotReload] Hot reload is disabled in configuration")
+		return runner.Run(ctx)
+	}
+	runner.watch = true
+
+	updateDeps := func() {
+		for i, importPath := range importPaths {
+			if deps, err := packageDeps(options.ChangeDir, importPath); err == nil {
+				runner.procs[i].Deps = deps
+			}
+		}
+	}
+	stats := hotload.NewStats()
+	status := hotloadStatusEnabled(cfg.Status)
+	if options.Stats {
+		stop := stats.ListenDump(os.Stderr, os.Stdin)
+		defer stop()
+		log.Info().Msg("[HotReload] Send SIGUSR1 or type 's' + Enter to print statistics")
+	}
+
+	if err := runHotloadHooks("pre", cfg.PreHooks, cfg.StopOnHookError); err != nil {
+		return err
+	}
+	errCh := make(chan error, 1)
+	go func() { errCh <- runner.Run(ctx) }()
+	<-runner.started
+	if err := runHotloadHooks("post", cfg.PostHooks, cfg.StopOnHookError); err != nil {
+		log.Error().Err(err).Msg("[HotReload] post hook failed")
+	}
+	updateDeps()
+
+	var targets []*proc
+	restart := withHotloadHooks(cfg, func() error { return runner.Restart(targets...) })
+	go func() {
+		err := hotload.WatchChanges(cfg, stats, func(changed []string) {
+			if ctx.Err() != nil {
+				return
+			}
+			updateDeps()
+			targets = runner.affected(changed)
+			names := make([]string, len(targets))
+			for i, p := range targets {
+				names[i] = p.Name
+			}
+			log.Info().Msgf("[HotReload] Change detected, restarting %s", strings.Join(names, ", "))
+			start := time.Now()
+			err := restart()
+			if err != nil {
+				log.Error().Msgf("[HotReload] Restart failed: %v", err)
+			}
+			elapsed := time.Since(start)
+			n := stats.RecordRebuild(elapsed, err)
+			if status {
+				fmt.Fprintln(os.Stderr, stats.StatusLine(n, elapsed, err))
+			}
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("[HotReload] watcher stopped")
+		}
+	}()
+	return <-errCh
+}
+
+// mainPackage 是 go list 找到的一个包
+type mainPackage struct {
+	Name       string
+	ImportPath string
+	Dir        string
+}
+
+// multiRunTargets 判断 run 的参数是否指定了多个入口，返回要运行的 main 包与转发给每个程序的参数：
+//   - --all-mains: 参数（默认 ./...）下的所有 main 包
+//   - 含 ... 的包模式，或多个都是 main 包目录的参数（如 ./cmd/api ./cmd/worker）
+//
+// .go 文件参数（如 main.go util.go）组成同一个包，不视为多个入口；
+// 其余情况保持单入口的行为（第一个参数之后的内容转发给程序），ok 为 false
+func multiRunTargets(options BuildRunOptions, args []string) (mains []mainPackage, progArgs []string, ok bool, err error) {
+	pkgArgs, progArgs, hasSep := splitProgramArgs(args)
+	switch {
+	case options.AllMains:
+		if len(pkgArgs) == 0 {
+			pkgArgs = []string{"./..."}
+		}
+	case slices.ContainsFunc(pkgArgs, func(a string) bool { return strings.HasSuffix(a, ".go") }):
+		return nil, nil, false, nil
+	case hasRecursivePattern(pkgArgs):
+	case len(pkgArgs) > 1:
+		// 不带 -- 时只有全部参数都是目录才视为多个入口，避免把程序参数误当作包
+		if !hasSep && slices.ContainsFunc(pkgArgs, func(a string) bool { return !isDirectory(filepath.Join(options.ChangeDir, a)) }) {
+			return nil, nil, false, nil
+		}
+	default:
+		return nil, nil, false, nil
+	}
+
+	pkgs, err := listMainPackages(options.ChangeDir, pkgArgs)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	for _, p := range pkgs {
+		if p.Name == "main" {
+			mains = append(mains, p)
+		}
+	}
+	if !options.AllMains && !hasRecursivePattern(pkgArgs) && len(mains) != len(pkgArgs) {
+		if hasSep {
+			return nil, nil, false, fmt.Errorf("run: every package must be a main package when running several: %s", strings.Join(pkgArgs, " "))
+		}
+		return nil, nil, false, nil
+	}
+	if len(mains) == 0 {
+		return nil, nil, false, fmt.Errorf("run: no main packages found in %s", strings.Join(pkgArgs, " "))
+	}
+	return mains, progArgs, true, nil
+}
+
+// listMainPackages 使用 go list 展开包模式（只读查询），返回包名、import path 与目录
+func listMainPackages(dir string, patterns []string) ([]mainPackage, error) {
+	args := append([]string{"list", "-e", "-f", "{{.Name}}\t{{.ImportPath}}\t{{.Dir}}"}, patterns...)
+	outStr, err := executor.NewExecutor("go", args...).WithDir(dir).ReadOnly().Output()
+	if err != nil {
+		return nil, err
+	}
+	var pkgs []mainPackage
+	for line := range strings.Lines(outStr) {
+		fields := strings.SplitN(strings.TrimRight(line, "\r\n"), "\t", 3)
+		if len(fields) == 3 && fields[0] != "" {
+			pkgs = append(pkgs, mainPackage{Name: fields[0], ImportPath: fields[1], Dir: fields[2]})
+		}
+	}
+	return pkgs, nil
+}
+
+// packageDeps 返回 main 包及其非标准库依赖所在的目录
+func packageDeps(dir, importPath string) ([]string, error) {
+	outStr, err := executor.NewExecutor("go", "list", "-e", "-deps", "-f", "{{if not .Standard}}{{.Dir}}{{end}}", importPath).
+		WithDir(dir).ReadOnly().Output()
+	if err != nil {
+		return nil, err
+	}
+	var dirs []string
+	for line := range strings.Lines(outStr) {
+		if line = strings.TrimSpace(line); line != "" {
+			dirs = append(dirs, line)
+		}
+	}
+	return dirs, nil
+}
+
+// procNames 为 import path 生成进程名：默认为最后一段，重名时向前补充路径段直到唯一
+func procNames(importPaths []string) []string {
+	names := make([]string, len(importPaths))
+	depths := make([]int, len(importPaths))
+	for i := range depths {
+		depths[i] = 1
+	}
+	for {
+		count := map[string]int{}
+		for i, ip := range importPaths {
+			segs := strings.Split(ip, "/")
+			names[i] = strings.Join(segs[max(0, len(segs)-depths[i]):], "/")
+			count[names[i]]++
+		}
+		extended := false
+		for i, ip := range importPaths {
+			if count[names[i]] > 1 && depths[i] < strings.Count(ip, "/")+1 {
+				depths[i]++
+				extended = true
+			}
+		}
+		if !extended {
+			return names
+		}
+	}
+}
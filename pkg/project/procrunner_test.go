@@ -0,0 +1,217 @@
+package project
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/debug"
+)
+
+// syncBuffer 是可并发写入与读取的缓冲区
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func shProc(name, script string) *proc {
+	return &proc{Name: name, Command: []string{"sh", "-c", script}}
+}
+
+// waitOutput 等待输出中出现 want
+func waitOutput(t *testing.T, out *syncBuffer, want string, count int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for strings.Count(out.String(), want) < count {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d x %q in:\n%s", count, want, out.String())
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// 测试多进程运行：输出带对齐的进程名前缀，一个进程失败时停止其余进程并返回该进程的错误
+func TestProcRunnerStopsOnFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	out := &syncBuffer{}
+	r := newProcRunner(out, []*proc{
+		shProc("api", "echo hello; exec sleep 10"),
+		shProc("worker", "echo bye; sleep 0.2; exit 3"),
+	}, false)
+	start := time.Now()
+	err := r.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "worker: exit status 3") {
+		t.Fatalf("expected the worker failure, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("the other process was not stopped")
+	}
+	for _, want := range []string{"api    | hello\n", "worker | bye\n", "worker | [exited: exit status 3]\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("missing %q in:\n%s", want, out.String())
+		}
+	}
+}
+
+// 测试 --keep-running：失败的进程不影响其他进程，正常退出的进程不算失败
+func TestProcRunnerKeepRunning(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	out := &syncBuffer{}
+	r := newProcRunner(out, []*proc{
+		shProc("a", "exit 1"),
+		shProc("b", "sleep 0.3; echo done"),
+	}, true)
+	err := r.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "a: exit status 1") || strings.Contains(err.Error(), "b:") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "b | done\n") {
+		t.Errorf("b was stopped early:\n%s", out.String())
+	}
+}
+
+// 测试 Restart 只重启指定进程（并重新执行其构建），ctx 取消时中断所有进程且不算失败
+func TestProcRunnerRestart(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	out := &syncBuffer{}
+	builds := 0
+	api := shProc("api", "echo api; exec sleep 10")
+	worker := shProc("worker", "echo worker; exec sleep 10")
+	worker.Build = func() error { builds++; return nil }
+	r := newProcRunner(out, []*proc{api, worker}, false)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- r.Run(ctx) }()
+	waitOutput(t, out, "worker | worker\n", 1)
+
+	if err := r.Restart(worker); err != nil {
+		t.Fatal(err)
+	}
+	waitOutput(t, out, "worker | worker\n", 2)
+	if n := strings.Count(out.String(), "api    | api\n"); n != 1 {
+		t.Errorf("api should not be restarted, started %d times", n)
+	}
+	if builds != 2 {
+		t.Errorf("worker built %d times, want 2", builds)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("interrupted processes should not count as failures: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+}
+
+// 测试按依赖目录判断受影响的进程，以及进程名的去重
+func TestProcRunnerAffectedAndNames(t *testing.T) {
+	root := t.TempDir()
+	api := &proc{Name: "api", Deps: []string{filepath.Join(root, "cmd", "api"), filepath.Join(root, "pkg", "db")}}
+	worker := &proc{Name: "worker", Deps: []string{filepath.Join(root, "cmd", "worker"), filepath.Join(root, "pkg", "db")}}
+	r := newProcRunner(&bytes.Buffer{}, []*proc{api, worker}, false)
+
+	names := func(ps []*proc) string {
+		var s []string
+		for _, p := range ps {
+			s = append(s, p.Name)
+		}
+		return strings.Join(s, ",")
+	}
+	for _, tc := range []struct {
+		changed []string
+		want    string
+	}{
+		{[]string{filepath.Join(root, "cmd", "api", "main.go")}, "api"},
+		{[]string{filepath.Join(root, "pkg", "db", "db.go")}, "api,worker"},
+		{[]string{filepath.Join(root, "cmd", "worker", "main.go"), filepath.Join(root, "go.mod")}, "api,worker"},
+	} {
+		if got := names(r.affected(tc.changed)); got != tc.want {
+			t.Errorf("affected(%v) = %s, want %s", tc.changed, got, tc.want)
+		}
+	}
+
+	got := procNames([]string{"example.com/m/cmd/api", "example.com/m/tools/api", "example.com/m/cmd/worker"})
+	if strings.Join(got, ",") != "cmd/api,tools/api,worker" {
+		t.Errorf("procNames = %v", got)
+	}
+}
+
+// 测试 project run 的子进程：stderr 副本按 PID 保存，debug stack --pid 发送 SIGQUIT 后能读到 goroutine 栈
+func TestProcRunnerDebugStackCapture(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("debug stack --pid requires /proc")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+	bin := filepath.Join(t.TempDir(), "debugserver")
+	if out, err := exec.Command("go", "build", "-o", bin, "../debug/testdata/debugserver").CombinedOutput(); err != nil {
+		t.Fatalf("build helper failed: %v\n%s", err, out)
+	}
+
+	out := &syncBuffer{}
+	r := newProcRunner(out, []*proc{{Name: "server", Command: []string{bin}}}, false)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- r.Run(ctx) }()
+	waitOutput(t, out, "127.0.0.1:", 1)
+
+	pid := findProcessByExe(t, bin)
+	var dump bytes.Buffer
+	if err := debug.RunStack(context.Background(), &dump, debug.StackOptions{PID: pid, Timeout: 5 * time.Second}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(dump.String(), "goroutine 1 ") || !strings.Contains(dump.String(), "main.main()") {
+		t.Errorf("expected a goroutine dump, got:\n%s", dump.String())
+	}
+	if err := <-done; err == nil || !strings.Contains(err.Error(), "exit status 2") {
+		t.Errorf("expected the child to exit with status 2 after SIGQUIT, got %v", err)
+	}
+	if _, err := os.Stat(debug.StderrCaptureFile(pid)); !os.IsNotExist(err) {
+		t.Errorf("stderr capture of the exited child was not removed: %v", err)
+	}
+}
+
+// findProcessByExe 通过 /proc/<pid>/exe 查找运行 bin 的进程
+func findProcessByExe(t *testing.T, bin string) int {
+	t.Helper()
+	matches, _ := filepath.Glob("/proc/[0-9]*/exe")
+	for _, m := range matches {
+		if target, err := os.Readlink(m); err == nil && target == bin {
+			pid, _ := strconv.Atoi(filepath.Base(filepath.Dir(m)))
+			return pid
+		}
+	}
+	t.Fatalf("no process running %s", bin)
+	return 0
+}
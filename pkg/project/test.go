@@ -3,6 +3,7 @@ package project
 import (
 	"fmt"
 	"io"
+	"os"
 	"reflect"
 	"strconv"
 	"strings"
@@ -62,6 +63,10 @@ type TestOptions struct {
 	ChangeDir string `cli:"-C"`    // -C: change to dir before running the command
 
 	Verbose bool // Verbose output for gocli itself
+
+	// --- Timing report (gocli only, implies -json internally) ---
+	Slowest   int    // 运行结束后打印最慢的 N 个测试以及各包总耗时
+	TimingOut string // 将全部测试耗时写入该 JSON 文件
 }
 
 // buildTestArgsFromOptions dynamically generates command-line arguments from the options struct using reflection.
@@ -105,6 +110,14 @@ func buildTestArgsFromOptions(options TestOptions) []string {
 
 // RunTest executes the test command
 func RunTest(options TestOptions, args []string, out io.Writer) error {
+	// 耗时报告依赖 test2json 事件中的 Elapsed，需要在内部开启 -json
+	timing := options.Slowest > 0 || options.TimingOut != ""
+	userJSON := options.JSON
+	dryRun := executor.Recording()
+	if timing {
+		options.JSON = true
+	}
+
 	goArgs := []string{"test"}
 	goArgs = append(goArgs, buildTestArgsFromOptions(options)...)
 
@@ -135,29 +148,60 @@ func RunTest(options TestOptions, args []string, out io.Writer) error {
 		}
 	}
 
-	// Execute the test command
-	stdout, stderr, err := executor.Run()
+	// Execute the test command, streaming output line by line as it arrives
+	var onStdout, onStderr func(string)
+	if out != nil {
+		onStdout = func(line string) { fmt.Fprintln(out, line) }
+		onStderr = onStdout
+	} else {
+		onStdout = func(line string) { log.Info().Msg(line) }
+		onStderr = func(line string) { log.Warn().Msg(line) }
+	}
+	if !timing {
+		return withoutStreamedStderr(executor.RunLines(onStdout, onStderr))
+	}
 
-	// Output results
-	if stdout != "" {
-		if out != nil {
-			fmt.Fprint(out, stdout)
+	collector := newTestTimingCollector()
+	printer := newTestOutputPrinter(options.V, onStdout)
+	err := withoutStreamedStderr(executor.RunLines(func(line string) {
+		ev, ok := parseTestEvent(line)
+		if !ok {
+			onStdout(line)
+			return
+		}
+		collector.Observe(ev)
+		if userJSON {
+			onStdout(line)
 		} else {
-			for line := range strings.SplitSeq(strings.TrimSpace(stdout), "\n") {
-				log.Info().Msg(line)
-			}
+			printer.Observe(ev)
 		}
+	}, onStderr))
+	// 测试失败时同样输出报告，便于定位慢测试；--dry-run 时没有事件可用
+	if dryRun {
+		return err
+	}
+	if rerr := reportTestTimings(options, collector.Result(), out); rerr != nil && err == nil {
+		err = rerr
 	}
+	return err
+}
 
-	if stderr != "" {
-		if out != nil {
-			fmt.Fprint(out, stderr)
-		} else {
-			for line := range strings.SplitSeq(strings.TrimSpace(stderr), "\n") {
-				log.Warn().Msg(line)
-			}
+// reportTestTimings 按选项打印最慢测试表格和/或写出耗时 JSON
+func reportTestTimings(options TestOptions, t TestTimings, out io.Writer) error {
+	if options.Slowest > 0 {
+		w := out
+		if w == nil {
+			w = os.Stdout
+		}
+		if err := printTestTimings(w, t, options.Slowest); err != nil {
+			return err
 		}
 	}
-
-	return err
+	if options.TimingOut != "" {
+		if err := writeTestTimings(options.TimingOut, t); err != nil {
+			return err
+		}
+		log.Info().Str("file", options.TimingOut).Int("tests", len(t.Tests)).Msg("Test timings written")
+	}
+	return nil
 }
@@ -0,0 +1,201 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/style"
+)
+
+// TestEvent 对应 go test -json（test2json）输出的一条事件
+type TestEvent struct {
+	Time    time.Time `json:"Time"`
+	Action  string    `json:"Action"`
+	Package string    `json:"Package"`
+	Test    string    `json:"Test"`
+	Elapsed float64   `json:"Elapsed"` // 秒，仅 pass/fail/skip 事件携带
+	Output  string    `json:"Output"`
+}
+
+// TestTiming 单个测试（含子测试）的耗时
+type TestTiming struct {
+	Package string  `json:"package"`
+	Test    string  `json:"test"`
+	Action  string  `json:"action"`
+	Elapsed float64 `json:"elapsed"`
+}
+
+// PackageTiming 单个包的测试总耗时
+type PackageTiming struct {
+	Package string  `json:"package"`
+	Action  string  `json:"action"`
+	Elapsed float64 `json:"elapsed"`
+	Tests   int     `json:"tests"`
+}
+
+// TestTimings 汇总一次 go test 运行的耗时，均按耗时降序排列
+type TestTimings struct {
+	Tests    []TestTiming    `json:"tests"`
+	Packages []PackageTiming `json:"packages"`
+}
+
+// testTimingCollector 从事件流中收集测试耗时
+// 使用结束事件（pass/fail/skip）的 Elapsed，因此 t.Parallel 的 pause/cont 不会影响结果
+type testTimingCollector struct {
+	tests    []TestTiming
+	packages []PackageTiming
+	counts   map[string]int
+}
+
+func newTestTimingCollector() *testTimingCollector {
+	return &testTimingCollector{counts: map[string]int{}}
+}
+
+// Observe 处理一条事件
+func (c *testTimingCollector) Observe(ev TestEvent) {
+	switch ev.Action {
+	case "pass", "fail", "skip":
+	default:
+		return
+	}
+	if ev.Test == "" {
+		c.packages = append(c.packages, PackageTiming{Package: ev.Package, Action: ev.Action, Elapsed: ev.Elapsed})
+		return
+	}
+	c.tests = append(c.tests, TestTiming{Package: ev.Package, Test: ev.Test, Action: ev.Action, Elapsed: ev.Elapsed})
+	c.counts[ev.Package]++
+}
+
+// Result 返回按耗时降序排列的结果，耗时相同时按名称排序保证输出稳定
+func (c *testTimingCollector) Result() TestTimings {
+	tests := append([]TestTiming{}, c.tests...)
+	sort.SliceStable(tests, func(i, j int) bool {
+		if tests[i].Elapsed != tests[j].Elapsed {
+			return tests[i].Elapsed > tests[j].Elapsed
+		}
+		if tests[i].Package != tests[j].Package {
+			return tests[i].Package < tests[j].Package
+		}
+		return tests[i].Test < tests[j].Test
+	})
+	pkgs := make([]PackageTiming, 0, len(c.packages))
+	for _, p := range c.packages {
+		p.Tests = c.counts[p.Package]
+		pkgs = append(pkgs, p)
+	}
+	sort.SliceStable(pkgs, func(i, j int) bool {
+		if pkgs[i].Elapsed != pkgs[j].Elapsed {
+			return pkgs[i].Elapsed > pkgs[j].Elapsed
+		}
+		return pkgs[i].Package < pkgs[j].Package
+	})
+	return TestTimings{Tests: tests, Packages: pkgs}
+}
+
+// parseTestEvent 解析 -json 输出的一行；非 JSON 行（如构建错误）返回 false
+func parseTestEvent(line string) (TestEvent, bool) {
+	var ev TestEvent
+	if !strings.HasPrefix(line, "{") {
+		return ev, false
+	}
+	if err := json.Unmarshal([]byte(line), &ev); err != nil || ev.Action == "" {
+		return ev, false
+	}
+	return ev, true
+}
+
+// printTestTimings 打印最慢的 n 个测试以及各包总耗时
+func printTestTimings(w io.Writer, t TestTimings, n int) error {
+	tests := t.Tests
+	if len(tests) > n {
+		tests = tests[:n]
+	}
+	if len(tests) > 0 {
+		if err := style.PrintHeading(w, fmt.Sprintf("Slowest tests (top %d of %d)", len(tests), len(t.Tests))); err != nil {
+			return err
+		}
+		rows := make([][]string, 0, len(tests))
+		for _, tt := range tests {
+			rows = append(rows, []string{formatElapsed(tt.Elapsed), tt.Action, tt.Package, tt.Test})
+		}
+		if err := style.PrintTable(w, []string{"Elapsed", "Result", "Package", "Test"}, rows, 0); err != nil {
+			return err
+		}
+	}
+	if len(t.Packages) > 0 {
+		if err := style.PrintHeading(w, "Package totals"); err != nil {
+			return err
+		}
+		rows := make([][]string, 0, len(t.Packages))
+		for _, p := range t.Packages {
+			rows = append(rows, []string{formatElapsed(p.Elapsed), p.Action, p.Package, fmt.Sprint(p.Tests)})
+		}
+		if err := style.PrintTable(w, []string{"Elapsed", "Result", "Package", "Tests"}, rows, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeTestTimings 将完整的耗时数据写入 JSON 文件
+func writeTestTimings(file string, t TestTimings) error {
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(file, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write timings to %s failed: %w", file, err)
+	}
+	return nil
+}
+
+func formatElapsed(sec float64) string {
+	return fmt.Sprintf("%.2fs", sec)
+}
+
+// testOutputPrinter 将 -json 事件还原为普通的 go test 输出
+// 非 verbose 时与 go test 一致：通过的测试只显示包摘要，失败的测试才输出其日志
+type testOutputPrinter struct {
+	verbose bool
+	print   func(string)
+	pending map[string][]string // package/test -> 尚未输出的日志
+}
+
+func newTestOutputPrinter(verbose bool, print func(string)) *testOutputPrinter {
+	return &testOutputPrinter{verbose: verbose, print: print, pending: map[string][]string{}}
+}
+
+// Observe 处理一条事件
+func (p *testOutputPrinter) Observe(ev TestEvent) {
+	key := ev.Package + "/" + ev.Test
+	switch ev.Action {
+	case "output":
+		line := strings.TrimSuffix(ev.Output, "\n")
+		if p.verbose {
+			p.print(line)
+			return
+		}
+		if ev.Test == "" {
+			// 包级输出：-json 隐含的 -test.v 会产生 PASS 行，普通模式下 go test 不显示
+			if line != "PASS" && !strings.HasPrefix(line, "=== ") {
+				p.print(line)
+			}
+			return
+		}
+		p.pending[key] = append(p.pending[key], line)
+	case "fail":
+		for _, line := range p.pending[key] {
+			if !strings.HasPrefix(line, "=== ") {
+				p.print(line)
+			}
+		}
+		delete(p.pending, key)
+	case "pass", "skip":
+		delete(p.pending, key)
+	}
+}
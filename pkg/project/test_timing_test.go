@@ -0,0 +1,65 @@
+package project
+
+import (
+	"strings"
+	"testing"
+)
+
+// 测试从 go test -json 事件流中收集耗时：并行测试的 pause/cont、子测试以及非 JSON 行
+func TestTestTimingCollector(t *testing.T) {
+	stream := `{"Action":"start","Package":"example.com/a"}
+{"Action":"run","Package":"example.com/a","Test":"TestFast"}
+{"Action":"output","Package":"example.com/a","Test":"TestFast","Output":"=== RUN   TestFast\n"}
+{"Action":"pass","Package":"example.com/a","Test":"TestFast","Elapsed":0.01}
+{"Action":"run","Package":"example.com/a","Test":"TestParallel"}
+{"Action":"pause","Package":"example.com/a","Test":"TestParallel"}
+{"Action":"cont","Package":"example.com/a","Test":"TestParallel"}
+{"Action":"run","Package":"example.com/a","Test":"TestParallel/sub"}
+{"Action":"output","Package":"example.com/a","Test":"TestParallel/sub","Output":"    a_test.go:10: boom\n"}
+{"Action":"fail","Package":"example.com/a","Test":"TestParallel/sub","Elapsed":1.5}
+{"Action":"fail","Package":"example.com/a","Test":"TestParallel","Elapsed":1.5}
+{"Action":"output","Package":"example.com/a","Output":"FAIL\texample.com/a\t1.6s\n"}
+{"Action":"fail","Package":"example.com/a","Elapsed":1.6}
+# example.com/b
+{"Action":"skip","Package":"example.com/b","Test":"TestSkipped","Elapsed":0}
+{"Action":"pass","Package":"example.com/b","Elapsed":0.2}`
+
+	c := newTestTimingCollector()
+	var printed []string
+	p := newTestOutputPrinter(false, func(line string) { printed = append(printed, line) })
+	for _, line := range strings.Split(stream, "\n") {
+		ev, ok := parseTestEvent(line)
+		if !ok {
+			if !strings.HasPrefix(line, "#") {
+				t.Fatalf("unexpected non-event line %q", line)
+			}
+			continue
+		}
+		c.Observe(ev)
+		p.Observe(ev)
+	}
+
+	got := c.Result()
+	wantTests := []string{"TestParallel", "TestParallel/sub", "TestFast", "TestSkipped"}
+	if len(got.Tests) != len(wantTests) {
+		t.Fatalf("tests = %+v", got.Tests)
+	}
+	for i, name := range wantTests {
+		if got.Tests[i].Test != name {
+			t.Errorf("tests[%d] = %s, want %s", i, got.Tests[i].Test, name)
+		}
+	}
+	if got.Tests[0].Elapsed != 1.5 || got.Tests[0].Action != "fail" {
+		t.Errorf("unexpected slowest test: %+v", got.Tests[0])
+	}
+
+	if len(got.Packages) != 2 || got.Packages[0].Package != "example.com/a" || got.Packages[0].Tests != 3 || got.Packages[1].Tests != 1 {
+		t.Fatalf("packages = %+v", got.Packages)
+	}
+
+	// 非 verbose 模式只输出失败测试的日志与包摘要
+	want := []string{"    a_test.go:10: boom", "FAIL\texample.com/a\t1.6s"}
+	if strings.Join(printed, "\n") != strings.Join(want, "\n") {
+		t.Errorf("printed = %q, want %q", printed, want)
+	}
+}
@@ -0,0 +1,14 @@
+package generate
+
+//go:generate echo first
+//go:generate stringer -type=Kind
+
+// 非行首的指令不会被 go generate 执行
+var trailing = 1 //go:generate echo trailing
+
+/*
+//go:generate echo in block comment
+*/
+
+// Kind is a fixture type.
+type Kind int
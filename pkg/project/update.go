@@ -2,30 +2,69 @@ package project
 
 import (
 	"bufio"
+	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/yeisme/gocli/pkg/style"
 	"github.com/yeisme/gocli/pkg/utils/deps"
+	"github.com/yeisme/gocli/pkg/utils/executor"
 )
 
 // UpdateOptions holds the options for updating dependencies.
 type UpdateOptions struct {
 	Verbose bool
+	JSON    bool     // 以 JSON 输出变更报告
+	Exclude []string // 保持当前版本的模块路径（命令行与配置 update.exclude 合并）
+}
+
+// modSnapshot 保存更新前的 go.mod / go.sum 内容
+type modSnapshot struct {
+	gomod, gosum   string
+	modData        []byte
+	sumData        []byte
+	sumExisted     bool
+	beforeVersions map[string]string
 }
 
 // RunUpdate executes the update command with the given options.
+//   - 更新前记录 go.mod/go.sum，更新后输出每个模块的版本变化（更新/新增/移除）
+//   - Exclude 中的模块在 go get -u 之后回退到原版本
+//   - dry-run 时使用 go list -m -u 预览可用更新，不修改任何文件
 func RunUpdate(opts UpdateOptions, out io.Writer, args []string) error {
+	gomod, err := deps.FindGoMod()
+	if err != nil {
+		return err
+	}
+	snap, err := takeModSnapshot(gomod)
+	if err != nil {
+		return err
+	}
+
 	// Spinner while updating
-	sp := style.NewSpinner(out, "Updating dependencies")
-	sp.Start()
+	var sp *style.Spinner
+	if !opts.JSON {
+		sp = style.NewSpinner(out, "Updating dependencies")
+		sp.Start()
+	}
+	stopSpinner := func() {
+		if sp != nil {
+			sp.Stop()
+			sp = nil
+		}
+	}
+	defer stopSpinner()
 
 	// Respect default behavior from deps.RunGoUpdate: pass nil to mean "./..."
-	var runArgs []string
-	if len(args) > 0 {
-		runArgs = args
-	} else {
-		runArgs = nil
+	runArgs := filterExcludedArgs(args, opts.Exclude)
+	if len(args) > 0 && len(runArgs) == 0 {
+		stopSpinner()
+		log.Info().Strs("exclude", opts.Exclude).Msg("all requested modules are excluded, nothing to update")
+		report := deps.DiffModuleVersions(nil, nil)
+		return printUpdateReport(out, opts, &report, false)
 	}
 
 	// 先执行 go mod tidy
@@ -35,44 +74,305 @@ func RunUpdate(opts UpdateOptions, out io.Writer, args []string) error {
 
 	output, err := deps.RunGoUpdate(runArgs)
 	// Stop spinner before any further output
-	sp.Stop()
+	stopSpinner()
+
+	// dry-run: commands were only recorded, show what would change instead
+	if executor.Recording() {
+		if err != nil {
+			return err
+		}
+		return previewUpdate(out, opts, runArgs)
+	}
 
 	if err != nil {
+		// go get 失败时恢复更新前的 go.mod/go.sum，避免 tidy 留下半更新状态
+		if rerr := snap.restore(); rerr != nil {
+			log.Warn().Err(rerr).Msg("failed to restore go.mod/go.sum")
+		}
 		// Best-effort styled error heading, then return
-		_ = style.PrintHeading(out, "Update Failed")
+		if !opts.JSON {
+			_ = style.PrintHeading(out, "Update Failed")
+		}
 		// Print stderr if available via logger; keep command return for caller
 		if opts.Verbose {
 			log.Error().Err(err).Msg("go get -u returned error")
 		}
 		return err
 	}
+	if opts.Verbose {
+		scanner := bufio.NewScanner(strings.NewReader(output))
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				log.Info().Msg(line)
+			}
+		}
+	}
 
-	// Success heading
-	_ = style.PrintHeading(out, "Dependencies Updated")
+	after, err := readRequiredVersions(gomod)
+	if err != nil {
+		return err
+	}
+	excluded, err := pinExcludedModules(snap.beforeVersions, after, opts.Exclude)
+	if err != nil {
+		return err
+	}
+	if len(excluded) > 0 {
+		if after, err = readRequiredVersions(gomod); err != nil {
+			return err
+		}
+	}
 
-	trimmed := strings.TrimSpace(output)
-	if trimmed == "" {
-		// Many go versions print little on success; give a friendly note
-		log.Info().Msg("No changes reported by go get -u.")
+	report := deps.DiffModuleVersions(snap.beforeVersions, after)
+	report.Excluded = excluded
+	return printUpdateReport(out, opts, &report, false)
+}
+
+// takeModSnapshot 读取 go.mod 与 go.sum（可能不存在）的当前内容
+func takeModSnapshot(gomod string) (*modSnapshot, error) {
+	s := &modSnapshot{gomod: gomod, gosum: filepath.Join(filepath.Dir(gomod), "go.sum")}
+	var err error
+	if s.modData, err = os.ReadFile(gomod); err != nil {
+		return nil, err
+	}
+	if s.sumData, err = os.ReadFile(s.gosum); err == nil {
+		s.sumExisted = true
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	if s.beforeVersions, err = deps.RequiredVersions(gomod, s.modData); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// restore 将 go.mod/go.sum 恢复为快照内容
+func (s *modSnapshot) restore() error {
+	if err := os.WriteFile(s.gomod, s.modData, 0o644); err != nil {
+		return err
+	}
+	if !s.sumExisted {
+		if err := os.Remove(s.gosum); err != nil && !os.IsNotExist(err) {
+			return err
+		}
 		return nil
 	}
+	return os.WriteFile(s.gosum, s.sumData, 0o644)
+}
+
+func readRequiredVersions(gomod string) (map[string]string, error) {
+	data, err := os.ReadFile(gomod)
+	if err != nil {
+		return nil, err
+	}
+	return deps.RequiredVersions(gomod, data)
+}
+
+// filterExcludedArgs 从显式指定的更新目标中去掉被排除的模块（支持 path@version 形式）
+func filterExcludedArgs(args, exclude []string) []string {
+	if len(args) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(args))
+	for _, a := range args {
+		path, _, _ := strings.Cut(a, "@")
+		if slices.Contains(exclude, path) {
+			log.Debug().Str("module", a).Msg("skipping excluded module")
+			continue
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
+// pinExcludedModules 将 go get -u 移动过的排除模块回退到更新前的版本
+// 返回所有仍在 go.mod 中的排除模块（Old 为保持的版本）
+func pinExcludedModules(before, after map[string]string, exclude []string) ([]deps.ModuleChange, error) {
+	var kept []deps.ModuleChange
+	var pins []string
+	for _, path := range exclude {
+		old, ok := before[path]
+		if !ok {
+			continue
+		}
+		kept = append(kept, deps.ModuleChange{Path: path, Old: old})
+		if nv, ok := after[path]; ok && nv != old {
+			pins = append(pins, path+"@"+old)
+		}
+	}
+	if len(pins) == 0 {
+		return kept, nil
+	}
+	log.Info().Strs("modules", pins).Msg("pinning excluded modules back to their previous versions")
+	if _, err := executor.NewExecutor("go", append([]string{"get"}, pins...)...).Output(); err != nil {
+		return nil, fmt.Errorf("pin excluded modules failed: %w", err)
+	}
+	return kept, nil
+}
 
-	// Pretty print each non-empty line as a bullet list
-	lines := make([]string, 0, 16)
-	scanner := bufio.NewScanner(strings.NewReader(trimmed))
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line != "" {
-			lines = append(lines, line)
+// previewUpdate 以 go list -m -u 预览可用更新（dry-run）；args 为 go get -u 的目标（已去掉排除的模块），
+// 指定了模块时只预览这些模块，包模式（如 ./...）与不指定目标时一样预览全部模块
+func previewUpdate(out io.Writer, opts UpdateOptions, args []string) error {
+	changes, err := deps.ListModuleUpdates()
+	if err != nil {
+		return err
+	}
+	var only []string
+	for _, a := range args {
+		path, _, _ := strings.Cut(a, "@")
+		if strings.HasPrefix(path, ".") || strings.Contains(path, "...") {
+			only = nil
+			break
 		}
+		only = append(only, path)
 	}
-	if len(lines) > 0 {
-		// Convert []string -> []any for PrintList
-		items := make([]any, len(lines))
-		for i := range lines {
-			items[i] = lines[i]
+	report := deps.DiffModuleVersions(nil, nil)
+	for _, c := range changes {
+		if len(only) > 0 && !slices.Contains(only, c.Path) {
+			continue
+		}
+		if slices.Contains(opts.Exclude, c.Path) {
+			report.Excluded = append(report.Excluded, deps.ModuleChange{Path: c.Path, Old: c.Old})
+			continue
 		}
-		_ = style.PrintList(out, items...)
+		report.Updated = append(report.Updated, c)
+	}
+	return printUpdateReport(out, opts, &report, true)
+}
+
+// printUpdateReport 以 diff 风格（或 JSON）输出更新报告，最后一行为统计
+func printUpdateReport(out io.Writer, opts UpdateOptions, r *deps.UpdateReport, preview bool) error {
+	if opts.JSON {
+		return style.PrintJSON(out, r)
+	}
+	title, none := "Dependencies Updated", "No module versions changed."
+	if preview {
+		title, none = "Available Updates (dry-run)", "No updates available."
+	}
+	_ = style.PrintHeading(out, title)
+	if r.Empty() {
+		fmt.Fprintln(out, none)
+	}
+	for _, c := range r.Updated {
+		fmt.Fprintf(out, "  ~ %s %s -> %s\n", c.Path, c.Old, c.New)
+	}
+	for _, c := range r.Added {
+		fmt.Fprintf(out, "  + %s %s\n", c.Path, c.New)
+	}
+	for _, c := range r.Removed {
+		fmt.Fprintf(out, "  - %s %s\n", c.Path, c.Old)
+	}
+	for _, c := range r.Excluded {
+		fmt.Fprintf(out, "  = %s %s (excluded)\n", c.Path, c.Old)
+	}
+	fmt.Fprintf(out, "%d updated, %d added, %d removed", len(r.Updated), len(r.Added), len(r.Removed))
+	if len(r.Excluded) > 0 {
+		fmt.Fprintf(out, ", %d excluded", len(r.Excluded))
 	}
+	fmt.Fprintln(out)
 	return nil
 }
+
+// upgradeChoice 是交互式升级中对单个模块的选择
+type upgradeChoice int
+
+const (
+	upgradeSkip upgradeChoice = iota
+	upgradeLatest
+	upgradePatch
+	upgradeQuit
+)
+
+// parseUpgradeChoice 解析交互输入：y/yes 升级到最新版本，p/patch 只升级补丁版本，q/quit 结束询问，其余（包括空输入）跳过
+func parseUpgradeChoice(ans string) upgradeChoice {
+	switch strings.TrimSpace(strings.ToLower(ans)) {
+	case "y", "yes":
+		return upgradeLatest
+	case "p", "patch":
+		return upgradePatch
+	case "q", "quit":
+		return upgradeQuit
+	}
+	return upgradeSkip
+}
+
+// promptUpgrades 逐个询问是否升级 changes 中的模块，返回 go get 的目标（path@version 或 path@patch）；
+// 输入结束（EOF）等同于 quit，已选择的模块仍会升级
+func promptUpgrades(in io.Reader, out io.Writer, changes []deps.ModuleChange) []string {
+	reader := bufio.NewReader(in)
+	var targets []string
+	for _, c := range changes {
+		fmt.Fprintf(out, "  ~ %s %s -> %s  upgrade? [y/N/p(atch only)/q(uit)]: ", c.Path, c.Old, c.New)
+		ans, err := reader.ReadString('\n')
+		if err != nil && ans == "" {
+			fmt.Fprintln(out)
+			break
+		}
+		choice := parseUpgradeChoice(ans)
+		if choice == upgradeQuit {
+			break
+		}
+		switch choice {
+		case upgradeLatest:
+			targets = append(targets, c.Path+"@"+c.New)
+		case upgradePatch:
+			targets = append(targets, c.Path+"@patch")
+		}
+	}
+	return targets
+}
+
+// RunInteractiveUpgrade 实现 `project deps --update --interactive`：
+//   - 使用 go list -m -u 列出 go.mod 中存在更新的模块（only 非空时只包含其中的模块），逐个询问 y/n/patch；
+//   - 对选中的模块执行一次 go get（失败时恢复 go.mod/go.sum），然后输出与 project update 相同的变更报告
+func RunInteractiveUpgrade(in io.Reader, out io.Writer, only []string, verbose bool) error {
+	gomod, err := deps.FindGoMod()
+	if err != nil {
+		return err
+	}
+	snap, err := takeModSnapshot(gomod)
+	if err != nil {
+		return err
+	}
+	all, err := deps.ListModuleUpdates()
+	if err != nil {
+		return err
+	}
+	// 只询问 go.mod 中 require 的模块，构建列表中的其他模块随之变化
+	var changes []deps.ModuleChange
+	for _, c := range all {
+		if _, ok := snap.beforeVersions[c.Path]; ok && (len(only) == 0 || slices.Contains(only, c.Path)) {
+			changes = append(changes, c)
+		}
+	}
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "No updates available.")
+		return nil
+	}
+
+	_ = style.PrintHeading(out, "Available Updates")
+	targets := promptUpgrades(in, out, changes)
+	if len(targets) == 0 {
+		fmt.Fprintln(out, "No modules selected.")
+		return nil
+	}
+
+	output, err := deps.RunGoGet(targets)
+	if executor.Recording() {
+		return err
+	}
+	if err != nil {
+		if rerr := snap.restore(); rerr != nil {
+			log.Warn().Err(rerr).Msg("failed to restore go.mod/go.sum")
+		}
+		return err
+	}
+	if verbose && strings.TrimSpace(output) != "" {
+		fmt.Fprint(out, output)
+	}
+	after, err := readRequiredVersions(gomod)
+	if err != nil {
+		return err
+	}
+	report := deps.DiffModuleVersions(snap.beforeVersions, after)
+	return printUpdateReport(out, UpdateOptions{}, &report, false)
+}
@@ -0,0 +1,163 @@
+package project
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/yeisme/gocli/pkg/utils/deps"
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// 测试交互式升级：y 升级到最新版本，p 只升级补丁版本，其余跳过；q 与输入结束都会停止询问
+func TestPromptUpgrades(t *testing.T) {
+	changes := []deps.ModuleChange{
+		{Path: "example.com/a", Old: "v1.0.0", New: "v1.2.0"},
+		{Path: "example.com/b", Old: "v0.3.1", New: "v0.4.0"},
+		{Path: "example.com/c", Old: "v2.0.0", New: "v2.0.1"},
+		{Path: "example.com/d", Old: "v1.1.0", New: "v1.1.5"},
+	}
+	cases := []struct {
+		input string
+		want  []string
+	}{
+		{"y\np\n\nYES\n", []string{"example.com/a@v1.2.0", "example.com/b@patch", "example.com/d@v1.1.5"}},
+		{"n\nq\ny\ny\n", nil},
+		{"patch\ny", []string{"example.com/a@patch", "example.com/b@v0.4.0"}},
+		{"", nil},
+	}
+	for _, c := range cases {
+		got := promptUpgrades(strings.NewReader(c.input), io.Discard, changes)
+		if !slices.Equal(got, c.want) {
+			t.Errorf("promptUpgrades(%q) = %v, want %v", c.input, got, c.want)
+		}
+	}
+}
+
+// writeProxyModule 在 file:// 模块代理目录中写入一个模块版本（list/info/mod/zip）
+func writeProxyModule(t *testing.T, proxy, path, version string) {
+	t.Helper()
+	dir := filepath.Join(proxy, filepath.FromSlash(path), "@v")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	gomod := "module " + path + "\n\ngo 1.21\n"
+	list, _ := os.ReadFile(filepath.Join(dir, "list"))
+	files := map[string]string{
+		"list":            string(list) + version + "\n",
+		version + ".info": `{"Version":"` + version + `"}`,
+		version + ".mod":  gomod,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	prefix := path + "@" + version + "/"
+	for name, content := range map[string]string{"go.mod": gomod, "lib.go": "package " + filepath.Base(path) + "\n"} {
+		w, err := zw.Create(prefix + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = io.WriteString(w, content)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, version+".zip"), buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// setupUpdateModule 在本地 file:// 代理中发布 example.com/dep 与 example.com/pin 的 v1.0.0、v1.1.0，
+// 并切换到依赖它们 v1.0.0 版本的模块目录，返回其 go.mod 路径
+func setupUpdateModule(t *testing.T) string {
+	t.Helper()
+	proxy := t.TempDir()
+	for _, v := range []string{"v1.0.0", "v1.1.0"} {
+		writeProxyModule(t, proxy, "example.com/dep", v)
+		writeProxyModule(t, proxy, "example.com/pin", v)
+	}
+	t.Setenv("GOPROXY", "file://"+filepath.ToSlash(proxy))
+	t.Setenv("GOFLAGS", "-modcacherw")
+	t.Setenv("GOPATH", t.TempDir())
+	t.Setenv("GOSUMDB", "off")
+	t.Setenv("GOWORK", "off")
+	t.Setenv("GOTOOLCHAIN", "local")
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":  "module example.com/app\n\ngo 1.21\n\nrequire (\n\texample.com/dep v1.0.0\n\texample.com/pin v1.0.0\n)\n",
+		"main.go": "package main\n\nimport (\n\t_ \"example.com/dep\"\n\t_ \"example.com/pin\"\n)\n\nfunc main() {}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+	return filepath.Join(dir, "go.mod")
+}
+
+// 测试 RunUpdate 的完整流程（本地 file:// 代理）：--json 报告版本变化，排除的模块回退到原版本
+func TestRunUpdateReport(t *testing.T) {
+	gomod := setupUpdateModule(t)
+
+	var out bytes.Buffer
+	if err := RunUpdate(UpdateOptions{JSON: true, Exclude: []string{"example.com/pin"}}, &out, nil); err != nil {
+		t.Fatalf("RunUpdate: %v\n%s", err, out.String())
+	}
+	var report deps.UpdateReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON report: %v\n%s", err, out.String())
+	}
+	wantUpdated := []deps.ModuleChange{{Path: "example.com/dep", Old: "v1.0.0", New: "v1.1.0"}}
+	wantExcluded := []deps.ModuleChange{{Path: "example.com/pin", Old: "v1.0.0"}}
+	if !slices.Equal(report.Updated, wantUpdated) || !slices.Equal(report.Excluded, wantExcluded) {
+		t.Errorf("report = %+v, want updated %v and excluded %v", report, wantUpdated, wantExcluded)
+	}
+	data, err := os.ReadFile(gomod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "example.com/dep v1.1.0") || !strings.Contains(string(data), "example.com/pin v1.0.0") {
+		t.Errorf("unexpected go.mod after update:\n%s", data)
+	}
+}
+
+// 测试 dry-run 预览：指定模块时只预览这些模块，go.mod 保持不变
+func TestRunUpdatePreviewArgs(t *testing.T) {
+	gomod := setupUpdateModule(t)
+	// dry-run 不执行 go mod tidy，没有 go.sum 时 go list 需要 -mod=mod
+	t.Setenv("GOFLAGS", "-modcacherw -mod=mod")
+	before, err := os.ReadFile(gomod)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	executor.StartRecording()
+	defer executor.StopRecording()
+	var out bytes.Buffer
+	if err := RunUpdate(UpdateOptions{JSON: true}, &out, []string{"example.com/pin"}); err != nil {
+		t.Fatalf("RunUpdate: %v\n%s", err, out.String())
+	}
+	var report deps.UpdateReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON report: %v\n%s", err, out.String())
+	}
+	want := []deps.ModuleChange{{Path: "example.com/pin", Old: "v1.0.0", New: "v1.1.0"}}
+	if !slices.Equal(report.Updated, want) {
+		t.Errorf("preview = %+v, want %v", report.Updated, want)
+	}
+	if after, _ := os.ReadFile(gomod); !bytes.Equal(after, before) {
+		t.Errorf("dry-run changed go.mod:\n%s", after)
+	}
+}
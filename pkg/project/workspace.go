@@ -0,0 +1,94 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/yeisme/gocli/pkg/configs"
+	"golang.org/x/mod/modfile"
+)
+
+// WorkspaceModule 是 go.work 中 use 指令引用的一个模块
+type WorkspaceModule struct {
+	// Path 模块路径（来自模块目录下的 go.mod；缺少 go.mod 时为 use 指令中的目录）
+	Path string `json:"module"`
+	// Dir 模块目录的绝对路径
+	Dir string `json:"dir"`
+}
+
+// LoadWorkspace 解析 goWork（EnvConfig.GoWork：auto|off|go.work 路径）指向的 go.work，按 use 指令的顺序返回其中的模块；
+// 不在工作区中（GOWORK 为 off 或未找到 go.work）时返回错误
+func LoadWorkspace(goWork string) ([]WorkspaceModule, error) {
+	workFile := configs.GetWorkFile(goWork)
+	if workFile == "" {
+		return nil, fmt.Errorf("not in a Go workspace: no go.work file found (GOWORK=%q); run 'go work init' or set env.GOWORK to a go.work file", goWork)
+	}
+	return parseWorkFile(workFile)
+}
+
+// parseWorkFile 解析 go.work，use 指令中的相对目录基于 go.work 所在目录
+func parseWorkFile(workFile string) ([]WorkspaceModule, error) {
+	data, err := os.ReadFile(workFile)
+	if err != nil {
+		return nil, err
+	}
+	wf, err := modfile.ParseWork(workFile, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s failed: %w", workFile, err)
+	}
+	base := filepath.Dir(workFile)
+	mods := make([]WorkspaceModule, 0, len(wf.Use))
+	for _, u := range wf.Use {
+		dir := filepath.FromSlash(u.Path)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(base, dir)
+		}
+		path := u.Path
+		if gomod, err := os.ReadFile(filepath.Join(dir, "go.mod")); err == nil {
+			if mp := modfile.ModulePath(gomod); mp != "" {
+				path = mp
+			}
+		}
+		mods = append(mods, WorkspaceModule{Path: path, Dir: dir})
+	}
+	if len(mods) == 0 {
+		return nil, fmt.Errorf("%s has no use directives", workFile)
+	}
+	return mods, nil
+}
+
+// inWorkspaceModules 依次切换到每个模块目录执行 fn，结束后恢复工作目录。
+// 某个模块失败时继续处理其余模块，返回合并后的错误（带模块路径）；
+// isolate 为 true 时临时设置 GOWORK=off，使 go 命令只看到该模块自己的 go.mod
+func inWorkspaceModules(mods []WorkspaceModule, isolate bool, fn func(WorkspaceModule) error) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Chdir(wd) }()
+	if isolate {
+		prev, had := os.LookupEnv("GOWORK")
+		_ = os.Setenv("GOWORK", "off")
+		defer func() {
+			if had {
+				_ = os.Setenv("GOWORK", prev)
+			} else {
+				_ = os.Unsetenv("GOWORK")
+			}
+		}()
+	}
+
+	var errs []error
+	for _, m := range mods {
+		if err := os.Chdir(m.Dir); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", m.Path, err))
+			continue
+		}
+		if err := fn(m); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", m.Path, err))
+		}
+	}
+	return errors.Join(errs...)
+}
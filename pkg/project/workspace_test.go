@@ -0,0 +1,143 @@
+package project
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeWorkspace 创建包含 a、b 两个模块的临时工作区（b 嵌套在 a 目录下），返回 go.work 路径
+func writeWorkspace(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	files := map[string]string{
+		"go.work":           "go 1.21\n\nuse (\n\t./a\n\t./a/b\n)\n",
+		"a/go.mod":          "module example.com/a\n\ngo 1.21\n",
+		"a/a.go":            "package a\n\nfunc A() {}\n",
+		"a/b/go.mod":        "module example.com/b\n\ngo 1.21\n",
+		"a/b/b.go":          "package b\n\nimport \"example.com/a\"\n\nfunc B() { a.A() }\n",
+		"a/b/sub/sub.go":    "package sub\n",
+		"a/b/sub/x_test.go": "package sub\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return filepath.Join(root, "go.work")
+}
+
+// 测试解析 go.work：use 目录相对 go.work 解析，模块路径取自各自的 go.mod；GOWORK=off 时不在工作区中
+func TestLoadWorkspace(t *testing.T) {
+	work := writeWorkspace(t)
+	mods, err := LoadWorkspace(work)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := filepath.Dir(work)
+	want := []WorkspaceModule{
+		{Path: "example.com/a", Dir: filepath.Join(root, "a")},
+		{Path: "example.com/b", Dir: filepath.Join(root, "a", "b")},
+	}
+	if len(mods) != len(want) || mods[0] != want[0] || mods[1] != want[1] {
+		t.Fatalf("LoadWorkspace = %+v, want %+v", mods, want)
+	}
+	if _, err := LoadWorkspace("off"); err == nil {
+		t.Error("GOWORK=off should not load a workspace")
+	}
+	if _, err := LoadWorkspace(filepath.Join(root, "missing.work")); err == nil {
+		t.Error("a missing go.work should fail")
+	}
+}
+
+// 测试 --workspace 下的 list：每个包只出现在所属模块下（嵌套模块 b 的包不会列在 a 下），表格带 Module 列
+func TestRunListWorkspace(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not available")
+	}
+	work := writeWorkspace(t)
+	t.Setenv("GOWORK", work)
+	t.Setenv("GOFLAGS", "")
+	mods, err := LoadWorkspace(work)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wd, _ := os.Getwd()
+
+	var b bytes.Buffer
+	if err := RunList(ListOptions{Format: ListFormatTable, Workspace: mods}, &b, nil); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, want := range []string{"MODULE", "example.com/a", "example.com/b/sub"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("table output missing %q:\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, "example.com/b/sub"); n != 1 {
+		t.Errorf("nested module package listed %d times:\n%s", n, out)
+	}
+
+	b.Reset()
+	if err := RunList(ListOptions{JSON: true, Workspace: mods}, &b, nil); err != nil {
+		t.Fatal(err)
+	}
+	pkgs, err := decodeListPackages(b.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, p := range pkgs {
+		paths = append(paths, p.ImportPath)
+	}
+	if got := strings.Join(paths, ","); got != "example.com/a,example.com/b,example.com/b/sub" {
+		t.Errorf("workspace json packages = %s", got)
+	}
+	if now, _ := os.Getwd(); now != wd {
+		t.Errorf("working directory not restored: %s", now)
+	}
+}
+
+// 测试 --workspace 下的 deps：每个模块一个标题，并以 GOWORK=off 只输出该模块自身
+func TestRunDepsWorkspace(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not available")
+	}
+	work := writeWorkspace(t)
+	t.Setenv("GOWORK", work)
+	t.Setenv("GOFLAGS", "")
+	mods, err := LoadWorkspace(work)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := RunDeps(DepsOptions{Workspace: mods}, &b, []string{}); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	ia, ib := strings.Index(out, "EXAMPLE.COM/A"), strings.Index(out, "EXAMPLE.COM/B")
+	if ia < 0 || ib < ia {
+		t.Fatalf("expected a heading per module in order:\n%s", out)
+	}
+	if section := out[ia:ib]; strings.Contains(section, "example.com/b") {
+		t.Errorf("module a section should only list module a:\n%s", out)
+	}
+	if os.Getenv("GOWORK") != work {
+		t.Errorf("GOWORK not restored: %q", os.Getenv("GOWORK"))
+	}
+}
+
+// 测试 --workspace 与 --badge-json 同时使用时报错，并指明用户实际使用的标志
+func TestInfoWorkspaceRejectsBadges(t *testing.T) {
+	err := executeInfoWorkspace(nil, InfoOptions{BadgeDir: t.TempDir()}, false, false, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "--badge-json") {
+		t.Fatalf("expected a --badge-json error, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package style
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	xterm "github.com/charmbracelet/x/term"
+	"github.com/muesli/termenv"
+)
+
+// ansiEscapeRe 匹配 CSI / OSC 等 ANSI 转义序列
+var ansiEscapeRe = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+
+func init() {
+	// 让 lipgloss 默认渲染器与 ColorEnabled 的判定保持一致
+	switch {
+	case noColor():
+		lipgloss.SetColorProfile(termenv.Ascii)
+	case forceColor():
+		lipgloss.SetColorProfile(termenv.TrueColor)
+	}
+}
+
+// ColorEnabled 判断向 w 输出时是否应使用颜色，所有带样式的输出都应以此为准
+//
+// 判定优先级：
+//  1. NO_COLOR 非空：禁用颜色（https://no-color.org）
+//  2. FORCE_COLOR 非空且不为 "0"/"false"：启用颜色
+//  3. w 为文件时按其是否为终端判断；其他 writer（如 strings.Builder 缓冲后再输出）按 stdout 判断
+func ColorEnabled(w io.Writer) bool {
+	if noColor() {
+		return false
+	}
+	if forceColor() {
+		return true
+	}
+	if f, ok := w.(*os.File); ok {
+		return xterm.IsTerminal(f.Fd())
+	}
+	return xterm.IsTerminal(os.Stdout.Fd())
+}
+
+// StripANSI 去除字符串中的 ANSI 转义序列
+func StripANSI(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+	return ansiEscapeRe.ReplaceAllString(s, "")
+}
+
+// IsTerminal 判断 w 是否为终端（非 *os.File 的 writer 视为非终端）
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && xterm.IsTerminal(f.Fd())
+}
+
+func noColor() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+func forceColor() bool {
+	v := strings.TrimSpace(os.Getenv("FORCE_COLOR"))
+	return v != "" && v != "0" && !strings.EqualFold(v, "false")
+}
+
+// colorWriter 在不应输出颜色时包装 w，写入前去除 ANSI 转义序列
+func colorWriter(w io.Writer) io.Writer {
+	if ColorEnabled(w) {
+		return w
+	}
+	return plainWriter{w: w}
+}
+
+// plainWriter 去除写入内容中的 ANSI 转义序列
+// style 包的各 Print 函数都是整段写入，不会把一个转义序列拆到两次 Write 中
+type plainWriter struct {
+	w io.Writer
+}
+
+func (p plainWriter) Write(b []byte) (int, error) {
+	if _, err := io.WriteString(p.w, StripANSI(string(b))); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// NewRenderer 为 w 创建 lipgloss 渲染器，颜色配置与 ColorEnabled 一致，供其他包构造自己的样式
+func NewRenderer(w io.Writer) *lipgloss.Renderer {
+	return newRenderer(w)
+}
+
+// newRenderer 为 w 创建 lipgloss 渲染器，颜色配置与 ColorEnabled 一致
+func newRenderer(w io.Writer) *lipgloss.Renderer {
+	re := lipgloss.NewRenderer(w)
+	switch {
+	case !ColorEnabled(w):
+		re.SetColorProfile(termenv.Ascii)
+	case re.ColorProfile() == termenv.Ascii:
+		// 缓冲 writer 或 FORCE_COLOR 时 lipgloss 无法从 w 探测，沿用默认渲染器的配置
+		re.SetColorProfile(lipgloss.ColorProfile())
+	}
+	return re
+}
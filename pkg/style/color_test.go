@@ -0,0 +1,44 @@
+package style
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// 测试颜色开关：NO_COLOR 优先于 FORCE_COLOR，非终端默认不输出颜色，关闭时写入内容去除 ANSI 转义
+func TestColorEnabled(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "out"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	tests := []struct {
+		noColor, forceColor string
+		want                bool
+	}{
+		{"", "", false},
+		{"", "1", true},
+		{"", "0", false},
+		{"", "false", false},
+		{"1", "1", false},
+	}
+	for _, tt := range tests {
+		t.Setenv("NO_COLOR", tt.noColor)
+		t.Setenv("FORCE_COLOR", tt.forceColor)
+		if got := ColorEnabled(f); got != tt.want {
+			t.Errorf("NO_COLOR=%q FORCE_COLOR=%q: ColorEnabled = %v, want %v", tt.noColor, tt.forceColor, got, tt.want)
+		}
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	var buf bytes.Buffer
+	if _, err := colorWriter(&buf).Write([]byte("\x1b[1;31mred\x1b[0m \x1b]8;;https://x\x07link\x1b]8;;\x07")); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "red link" {
+		t.Errorf("colorWriter output = %q", buf.String())
+	}
+}
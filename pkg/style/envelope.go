@@ -0,0 +1,87 @@
+package style
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// OutputFormat 是全局 --output-format 的取值，空值表示由命令自行决定
+type OutputFormat string
+
+const (
+	OutputDefault OutputFormat = ""
+	OutputJSON    OutputFormat = "json"
+	OutputYAML    OutputFormat = "yaml"
+	OutputTable   OutputFormat = "table"
+	OutputPlain   OutputFormat = "plain"
+)
+
+// OutputFormats 列出所有可用的输出格式
+var OutputFormats = []OutputFormat{OutputJSON, OutputYAML, OutputTable, OutputPlain}
+
+// ParseOutputFormat 解析 --output-format 的取值（大小写不敏感），空字符串返回 OutputDefault
+func ParseOutputFormat(s string) (OutputFormat, error) {
+	f := OutputFormat(strings.ToLower(strings.TrimSpace(s)))
+	if f == OutputDefault {
+		return f, nil
+	}
+	for _, v := range OutputFormats {
+		if f == v {
+			return f, nil
+		}
+	}
+	return OutputDefault, fmt.Errorf("invalid output format %q (accepted: json, yaml, table, plain)", s)
+}
+
+// Structured 报告该格式是否输出机器可读的信封（json/yaml）
+func (f OutputFormat) Structured() bool {
+	return f == OutputJSON || f == OutputYAML
+}
+
+// Envelope 是 json/yaml 输出的统一外层结构，脚本可以用相同的方式解析任意命令的输出
+//
+//	{"command": "gocli tools list", "data": ..., "error": null}
+type Envelope struct {
+	Command string  `json:"command" yaml:"command"`
+	Data    any     `json:"data" yaml:"data"`
+	Error   *string `json:"error" yaml:"error"`
+}
+
+// NewEnvelope 构造信封；err 为 nil 时 Error 序列化为 null
+func NewEnvelope(command string, data any, err error) Envelope {
+	e := Envelope{Command: command, Data: data}
+	if err != nil {
+		msg := err.Error()
+		e.Error = &msg
+	}
+	return e
+}
+
+// PrintEnvelope 以 json 或 yaml 输出信封，其他格式返回错误
+func PrintEnvelope(w io.Writer, format OutputFormat, command string, data any, err error) error {
+	env := NewEnvelope(command, data, err)
+	switch format {
+	case OutputJSON:
+		return PrintJSON(w, env)
+	case OutputYAML:
+		return PrintYAML(w, env)
+	default:
+		return fmt.Errorf("output format %q has no envelope", format)
+	}
+}
+
+// DecodeJSONStream 解析连续的 JSON 值（如 go list -json 的输出）为数组，便于放入信封
+func DecodeJSONStream(s string) ([]any, error) {
+	values := make([]any, 0)
+	dec := json.NewDecoder(strings.NewReader(s))
+	for dec.More() {
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			return nil, fmt.Errorf("decode json output failed: %w", err)
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
@@ -0,0 +1,78 @@
+package style
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 测试 json 信封的结构：command/data/error 三个字段，成功时 error 为 null
+func TestPrintEnvelopeJSON(t *testing.T) {
+	var buf bytes.Buffer
+	data := []map[string]string{{"name": "gopls"}}
+	if err := PrintEnvelope(&buf, OutputJSON, "gocli tools list", data, nil); err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid json %q: %v", buf.String(), err)
+	}
+	if len(got) != 3 {
+		t.Errorf("expected exactly command/data/error keys, got %v", got)
+	}
+	if got["command"] != "gocli tools list" {
+		t.Errorf("command = %v", got["command"])
+	}
+	if v, ok := got["error"]; !ok || v != nil {
+		t.Errorf("error should be present and null, got %v", v)
+	}
+	items, ok := got["data"].([]any)
+	if !ok || len(items) != 1 || items[0].(map[string]any)["name"] != "gopls" {
+		t.Errorf("unexpected data %v", got["data"])
+	}
+}
+
+// 测试 yaml 信封以及失败时的 error 字段
+func TestPrintEnvelopeYAMLError(t *testing.T) {
+	var buf bytes.Buffer
+	if err := PrintEnvelope(&buf, OutputYAML, "gocli project deps", nil, errors.New("boom")); err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := yaml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid yaml %q: %v", buf.String(), err)
+	}
+	if got["command"] != "gocli project deps" || got["error"] != "boom" {
+		t.Errorf("unexpected envelope %v", got)
+	}
+	if v, ok := got["data"]; !ok || v != nil {
+		t.Errorf("data should be present and null, got %v", v)
+	}
+
+	if err := PrintEnvelope(&buf, OutputTable, "gocli", nil, nil); err == nil {
+		t.Error("table format should not produce an envelope")
+	}
+}
+
+// 测试 --output-format 解析与 JSON 流解码
+func TestParseOutputFormatAndDecodeStream(t *testing.T) {
+	for in, want := range map[string]OutputFormat{"": OutputDefault, "JSON": OutputJSON, " yaml ": OutputYAML, "plain": OutputPlain} {
+		if got, err := ParseOutputFormat(in); err != nil || got != want {
+			t.Errorf("ParseOutputFormat(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseOutputFormat("xml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+
+	values, err := DecodeJSONStream("{\"Path\": \"a\"}\n{\"Path\": \"b\"}\n")
+	if err != nil || len(values) != 2 {
+		t.Fatalf("DecodeJSONStream = %v, %v", values, err)
+	}
+	if empty, err := DecodeJSONStream(""); err != nil || empty == nil || len(empty) != 0 {
+		t.Errorf("empty stream should decode to an empty array, got %v, %v", empty, err)
+	}
+}
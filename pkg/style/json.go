@@ -22,6 +22,7 @@ import (
 //   - 数字 / 布尔 / null: AccentPrimary
 //   - 字符串值与空白: 默认文本色
 func PrintJSON(w io.Writer, v any) error {
+	w = colorWriter(w)
 	pretty, err := FormatJSON(v)
 	if err != nil {
 		return err
@@ -33,6 +34,7 @@ func PrintJSON(w io.Writer, v any) error {
 
 // PrintJSONLine 将单行 JSON 以美化样式的方式输出到 writer
 func PrintJSONLine(w io.Writer, v any) error {
+	w = colorWriter(w)
 	s := v.(string)
 	colored := colorizeJSON(s)
 	_, err := fmt.Fprint(w, colored)
@@ -17,6 +17,7 @@ type Formatter struct {
 
 // PrintHeading 打印一个区块标题
 func PrintHeading(w io.Writer, title string) error {
+	w = colorWriter(w)
 	style := lipgloss.NewStyle().
 		Foreground(ColorAccentText).
 		Background(ColorAccentPrimary).
@@ -28,6 +29,7 @@ func PrintHeading(w io.Writer, title string) error {
 
 // PrintFormatterList 以对齐的方式打印 formatter 列表
 func PrintFormatterList(w io.Writer, formatters []Formatter) error {
+	w = colorWriter(w)
 	if len(formatters) == 0 {
 		return nil
 	}
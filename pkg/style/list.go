@@ -12,6 +12,7 @@ import (
 // PrintList 用于渲染一个带有主题样式的列表到指定的 writer
 // items 参数支持嵌套，可以传入另一个 list.New() 对象来创建子列表
 func PrintList(w io.Writer, items ...any) error {
+	w = colorWriter(w)
 	// 为编号符号定义样式: 使用主题强调色
 	enumeratorStyle := lipgloss.NewStyle().
 		Foreground(ColorAccentPrimary).
@@ -37,6 +38,7 @@ func PrintList(w io.Writer, items ...any) error {
 //   - 已经使用 [] 包裹的也使用测试包样式（例如用户已有特殊标记）
 //   - 使用与 PrintList 一致的枚举 bullet 与强调色
 func PrintPackageList(w io.Writer, pkgs []string) error {
+	w = colorWriter(w)
 	if len(pkgs) == 0 {
 		return nil
 	}
@@ -111,6 +113,7 @@ func PrintPackageList(w io.Writer, pkgs []string) error {
 //   - 其余部分（模块名和空白）使用普通文本色(ColorText)
 //   - 每行前加与 PrintList 一致的 bullet
 func PrintGoModUpdatesList(w io.Writer, lines []string) error {
+	w = colorWriter(w)
 	if len(lines) == 0 {
 		return nil
 	}
@@ -1,11 +1,107 @@
 package style
 
 import (
+	"encoding/json"
+	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/muesli/termenv"
 )
 
+// MarkdownTheme 描述一个可用于 RenderMarkdown 的主题
+type MarkdownTheme struct {
+	Name string `json:"name"`
+	Path string `json:"path,omitempty"` // 自定义主题的 glamour 样式 JSON 文件，内置主题为空
+}
+
+// ThemesDir 返回自定义主题目录 ~/.gocli/themes，其中的 <name>.json 可通过 --theme <name> 使用
+func ThemesDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".gocli", "themes")
+}
+
+// ListThemes 返回 glamour 内置主题以及 ThemesDir 中的自定义主题（按名称排序，内置主题在前）
+func ListThemes() []MarkdownTheme {
+	themes := []MarkdownTheme{{Name: styles.AutoStyle}}
+	for name := range styles.DefaultStyles {
+		themes = append(themes, MarkdownTheme{Name: name})
+	}
+	sort.Slice(themes[1:], func(i, j int) bool { return themes[i+1].Name < themes[j+1].Name })
+
+	dir := ThemesDir()
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok || e.IsDir() || name == "" {
+			continue
+		}
+		if _, builtin := styles.DefaultStyles[name]; builtin || name == styles.AutoStyle {
+			continue
+		}
+		themes = append(themes, MarkdownTheme{Name: name, Path: filepath.Join(dir, e.Name())})
+	}
+	return themes
+}
+
+// ValidateTheme 检查主题是否可用：内置名称、自定义主题名称或 glamour 样式 JSON 文件路径；
+// 未知名称返回包含全部可用主题的错误
+func ValidateTheme(theme string) error {
+	_, err := loadTheme(theme)
+	return err
+}
+
+// loadTheme 把主题名称或路径解析为 glamour 的样式配置，nil 表示内置主题由名称直接指定
+func loadTheme(theme string) (*ansi.StyleConfig, error) {
+	if theme == "" || theme == styles.AutoStyle {
+		return nil, nil
+	}
+	if _, ok := styles.DefaultStyles[theme]; ok {
+		return nil, nil
+	}
+	path := theme
+	if !strings.ContainsAny(theme, `/\`) && !strings.HasSuffix(theme, ".json") {
+		themes := ListThemes()
+		names := make([]string, 0, len(themes))
+		path = ""
+		for _, t := range themes {
+			names = append(names, t.Name)
+			if t.Name == theme && t.Path != "" {
+				path = t.Path
+			}
+		}
+		if path == "" {
+			return nil, fmt.Errorf("unknown theme %q (available: %s; or a path to a glamour style JSON file)", theme, strings.Join(names, ", "))
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read theme file: %w", err)
+	}
+	var cfg ansi.StyleConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse theme file %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// defaultTheme 根据终端背景选择默认主题：深色背景使用 dracula，浅色背景使用 light
+func defaultTheme() string {
+	if termenv.HasDarkBackground() {
+		return styles.DraculaStyle
+	}
+	return styles.LightStyle
+}
+
 // RenderMarkdown 渲染传入的 Markdown 文本并输出到指定 writer
 // 与其它 style 包函数风格一致: 写入 w 并返回 error
 // 基于终端宽度自动换行，最小宽度为 80，最大宽度为 120
@@ -15,10 +111,12 @@ import (
 //  1. w: 输出的 io.Writer
 //  2. input: 要渲染的 Markdown 文本
 //  3. width: 渲染的宽度
-//  4. theme: 渲染时使用的主题 (例如 "dracula", "dark", "light" 等)
+//  4. theme: 渲染时使用的主题 (例如 "dracula", "dark", "light" 等，见 ListThemes)，
+//     也可以是 glamour 样式 JSON 文件的路径；为空或 "auto" 时按终端背景选择
 func RenderMarkdown(w io.Writer, input string, width int, theme string) error {
-	if theme == "" {
-		theme = "dracula"
+	custom, err := loadTheme(theme)
+	if err != nil {
+		return err
 	}
 	// 解析并确定最终渲染宽度，优先级：显式参数(width>0) > 终端探测 > 默认80
 	termWidth := detectTerminalWidth(w)
@@ -43,10 +141,21 @@ func RenderMarkdown(w io.Writer, input string, width int, theme string) error {
 		}
 	}
 
+	// 不输出颜色时（NO_COLOR、管道或文件）使用无转义序列的 notty 样式
+	styleOpt := glamour.WithStandardStyle(styles.NoTTYStyle)
+	switch {
+	case !ColorEnabled(w):
+	case custom != nil:
+		styleOpt = glamour.WithStyles(*custom)
+	case theme == "" || theme == styles.AutoStyle:
+		styleOpt = glamour.WithStandardStyle(defaultTheme())
+	default:
+		styleOpt = glamour.WithStandardStyle(theme)
+	}
+
 	r, err := glamour.NewTermRenderer(
-		glamour.WithAutoStyle(),
 		glamour.WithWordWrap(width),
-		glamour.WithStandardStyle(theme),
+		styleOpt,
 		glamour.WithInlineTableLinks(true),
 	)
 	if err != nil {
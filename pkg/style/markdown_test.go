@@ -0,0 +1,52 @@
+package style
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// 测试使用自定义主题文件渲染 Markdown，并且 ~/.gocli/themes 中的主题出现在 ListThemes 中
+func TestRenderMarkdownCustomTheme(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "1")
+
+	theme := `{"document":{"color":"#ff0000"},"heading":{"bold":true,"prefix":">> "}}`
+	file := filepath.Join(t.TempDir(), "mytheme.json")
+	if err := os.WriteFile(file, []byte(theme), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := RenderMarkdown(&buf, "# Title\n\nsome text\n", 80, file); err != nil {
+		t.Fatalf("render with theme file: %v", err)
+	}
+	if out := buf.String(); !strings.Contains(StripANSI(out), ">> Title") || !strings.Contains(out, "\x1b[") {
+		t.Fatalf("custom theme not applied: %q", out)
+	}
+
+	dir := filepath.Join(home, ".gocli", "themes")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "mine.json"), []byte(theme), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, th := range ListThemes() {
+		found = found || (th.Name == "mine" && th.Path != "")
+	}
+	if !found {
+		t.Fatalf("custom theme mine not listed: %v", ListThemes())
+	}
+	if err := RenderMarkdown(&bytes.Buffer{}, "text", 80, "mine"); err != nil {
+		t.Fatalf("render with theme name: %v", err)
+	}
+	if err := ValidateTheme("no-such-theme"); err == nil || !strings.Contains(err.Error(), "dracula") {
+		t.Fatalf("expected unknown theme error listing themes, got %v", err)
+	}
+}
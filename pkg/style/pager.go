@@ -0,0 +1,85 @@
+package style
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	xterm "github.com/charmbracelet/x/term"
+)
+
+// defaultPager 未设置 $PAGER 时使用的分页器
+const defaultPager = "less -R"
+
+// WithPager 将 render 的输出按需通过分页器显示
+//
+// 仅当 enabled 为 true、w 是终端且输出行数超过终端高度时才启动分页器（$PAGER，默认 less -R），
+// 否则直接写入 w；分页器无法启动时回退为直接输出
+func WithPager(w io.Writer, enabled bool, render func(io.Writer) error) error {
+	f, ok := w.(*os.File)
+	if !enabled || !ok {
+		return render(w)
+	}
+	height, ok := terminalHeight(f)
+	if !ok {
+		return render(w)
+	}
+
+	var buf bytes.Buffer
+	renderErr := render(&buf)
+	if buf.Len() == 0 {
+		return renderErr
+	}
+	if bytes.Count(buf.Bytes(), []byte{'\n'}) < height {
+		_, err := w.Write(buf.Bytes())
+		return firstErr(renderErr, err)
+	}
+
+	if err := runPager(f, buf.Bytes()); err != nil {
+		_, err = w.Write(buf.Bytes())
+		return firstErr(renderErr, err)
+	}
+	return renderErr
+}
+
+// terminalHeight 返回终端 f 的行数，f 不是终端或无法取得大小时 ok 为 false；测试中可替换
+var terminalHeight = func(f *os.File) (int, bool) {
+	if !xterm.IsTerminal(f.Fd()) {
+		return 0, false
+	}
+	_, height, err := xterm.GetSize(f.Fd())
+	return height, err == nil && height > 0
+}
+
+// runPager 启动分页器并将 content 作为其标准输入
+// 只返回启动失败的错误；分页器启动后（例如用户提前退出）的退出状态被忽略，避免重复输出
+func runPager(out *os.File, content []byte) error {
+	pager := strings.TrimSpace(os.Getenv("PAGER"))
+	if pager == "" {
+		pager = defaultPager
+	}
+	fields := strings.Fields(pager)
+	if _, err := exec.LookPath(fields[0]); err != nil {
+		return err
+	}
+	cmd := exec.Command(fields[0], fields[1:]...)
+	cmd.Stdin = bytes.NewReader(content)
+	cmd.Stdout = out
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	_ = cmd.Wait()
+	return nil
+}
+
+func firstErr(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
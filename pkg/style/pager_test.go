@@ -0,0 +1,73 @@
+package style
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// 测试分页：非终端与 --no-pager（enabled=false）时直接输出，终端上超过高度的输出交给 $PAGER，
+// 不足一屏时不启动分页器，$PAGER 无法启动时回退为直接输出
+func TestWithPager(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as $PAGER")
+	}
+	dir := t.TempDir()
+	pager := filepath.Join(dir, "pager")
+	if err := os.WriteFile(pager, []byte("#!/bin/sh\necho paged\ncat\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PAGER", pager)
+
+	render := func(lines int) func(io.Writer) error {
+		return func(w io.Writer) error {
+			for i := range lines {
+				fmt.Fprintf(w, "line %d\n", i)
+			}
+			return nil
+		}
+	}
+	run := func(t *testing.T, enabled bool, lines int) string {
+		t.Helper()
+		f, err := os.Create(filepath.Join(t.TempDir(), "out"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = f.Close() }()
+		if err := WithPager(f, enabled, render(lines)); err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(f.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	// 普通文件不是终端，不分页
+	if out := run(t, true, 50); strings.HasPrefix(out, "paged") || !strings.HasSuffix(out, "line 49\n") {
+		t.Errorf("non-TTY output should not be paged:\n%s", out)
+	}
+
+	saved := terminalHeight
+	terminalHeight = func(*os.File) (int, bool) { return 10, true }
+	t.Cleanup(func() { terminalHeight = saved })
+
+	if out := run(t, true, 50); !strings.HasPrefix(out, "paged\nline 0\n") || !strings.HasSuffix(out, "line 49\n") {
+		t.Errorf("long output should go through $PAGER:\n%s", out)
+	}
+	if out := run(t, false, 50); strings.HasPrefix(out, "paged") {
+		t.Errorf("--no-pager output should not be paged:\n%s", out)
+	}
+	if out := run(t, true, 3); out != "line 0\nline 1\nline 2\n" {
+		t.Errorf("short output should be written directly:\n%s", out)
+	}
+	t.Setenv("PAGER", filepath.Join(dir, "missing-pager"))
+	if out := run(t, true, 50); strings.HasPrefix(out, "paged") || strings.Count(out, "\n") != 50 {
+		t.Errorf("unavailable $PAGER should fall back to direct output:\n%s", out)
+	}
+}
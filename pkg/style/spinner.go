@@ -3,58 +3,270 @@ package style
 import (
 	"fmt"
 	"io"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
-// Spinner 是一个简单的终端旋转指示器
-// 用于长时间运行的任务期间提供轻量反馈
+// clearLine 回到行首并清除整行
+const clearLine = "\r\x1b[K"
+
+var (
+	// outputMu 串行化 spinner 帧与经 SyncWriter 写出的日志，避免两者在同一行交错
+	outputMu sync.Mutex
+	// active 当前正在终端上绘制的 spinner（受 outputMu 保护），日志写出前先擦除它所在的行
+	active *Spinner
+	// progressDisabled 为 true 时（--quiet）spinner 不输出任何内容
+	progressDisabled atomic.Bool
+)
+
+// DisableProgress 关闭（或重新开启）所有 spinner 与进度输出，用于 --quiet
+func DisableProgress(disabled bool) {
+	progressDisabled.Store(disabled)
+}
+
+// Spinner 是一个简单的进度指示器，用于长时间运行的任务期间提供轻量反馈：
+//   - 写入终端时在同一行绘制旋转帧与消息，Update 可随时更新消息
+//   - 写入非终端（管道、CI 日志）时不绘制帧，只在开始时与之后每隔 logInterval 输出一行纯文本
+//   - 通过 Wrap 统计读取的字节数时，消息后附加已传输的字节数（已知总数时附加百分比）
+//
+// nil *Spinner 的方法均为空操作，调用方可以在不需要进度时直接传递 nil
 type Spinner struct {
-	out      io.Writer
-	msg      string
-	stopCh   chan struct{}
-	doneCh   chan struct{}
-	interval time.Duration
+	out         io.Writer
+	tty         bool
+	msg         string // 受 outputMu 保护
+	frame       int
+	interval    time.Duration
+	logInterval time.Duration
+	start       time.Time
+
+	counter *CountingReader
+	total   int64
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stopCh    chan struct{}
+	doneCh    chan struct{}
 }
 
-// NewSpinner 创建一个新的 Spinner
-// out: 写入目标（一般为 cmd.OutOrStdout() 或 os.Stdout）
+// spinnerFrames 终端模式下的旋转帧
+var spinnerFrames = []rune{'⠋', '⠙', '⠹', '⠸', '⠼', '⠴', '⠦', '⠧', '⠇', '⠏'}
+
+// NewSpinner 创建一个新的 Spinner，调用 Start 后开始输出
+// out: 写入目标（一般为 os.Stderr，避免混入命令的标准输出）
 // msg: 前缀消息
 func NewSpinner(out io.Writer, msg string) *Spinner {
+	return newSpinner(out, msg, IsTerminal(out))
+}
+
+// NewByteProgress 创建显示传输字节数的 Spinner，total 为已知的总字节数（<=0 表示未知），
+// 通过 Wrap 包装要统计的 Reader
+func NewByteProgress(out io.Writer, msg string, total int64) *Spinner {
+	s := NewSpinner(out, msg)
+	s.total = total
+	return s
+}
+
+func newSpinner(out io.Writer, msg string, tty bool) *Spinner {
 	return &Spinner{
-		out:      out,
-		msg:      msg,
-		stopCh:   make(chan struct{}),
-		doneCh:   make(chan struct{}),
-		interval: 120 * time.Millisecond,
+		out:         out,
+		tty:         tty,
+		msg:         msg,
+		interval:    120 * time.Millisecond,
+		logInterval: 10 * time.Second,
+		stopCh:      make(chan struct{}),
+		doneCh:      make(chan struct{}),
 	}
 }
 
-// Start 启动 spinner，直到 Stop 被调用
+// Wrap 返回统计读取字节数的 Reader，之后的进度消息附加已读取的字节数
+func (s *Spinner) Wrap(r io.Reader) io.Reader {
+	if s == nil {
+		return r
+	}
+	c := NewCountingReader(r)
+	outputMu.Lock()
+	s.counter = c
+	outputMu.Unlock()
+	return c
+}
+
+// Start 启动 spinner，直到 Stop 被调用；重复调用无效
 func (s *Spinner) Start() {
-	go func() {
-		defer close(s.doneCh)
-		frames := []rune{'⠋', '⠙', '⠹', '⠸', '⠼', '⠴', '⠦', '⠧', '⠇', '⠏'}
-		i := 0
-		// 初始一行
-		fmt.Fprintf(s.out, "%s %c\r", s.msg, frames[i])
-		ticker := time.NewTicker(s.interval)
-		defer ticker.Stop()
-		for {
-			select {
-			case <-s.stopCh:
-				// 清理行尾
-				fmt.Fprintf(s.out, "%s ✔\n", s.msg)
-				return
-			case <-ticker.C:
-				i = (i + 1) % len(frames)
-				fmt.Fprintf(s.out, "%s %c\r", s.msg, frames[i])
+	if s == nil {
+		return
+	}
+	s.startOnce.Do(func() {
+		if progressDisabled.Load() {
+			close(s.doneCh)
+			return
+		}
+		outputMu.Lock()
+		s.start = time.Now()
+		if s.tty {
+			active = s
+			s.drawLocked()
+		} else {
+			fmt.Fprintf(s.out, "%s...\n", s.line())
+		}
+		outputMu.Unlock()
+		go s.loop()
+	})
+}
+
+// loop 在终端上按 interval 重绘帧，非终端按 logInterval 输出纯文本行
+func (s *Spinner) loop() {
+	defer close(s.doneCh)
+	interval := s.interval
+	if !s.tty {
+		interval = s.logInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			outputMu.Lock()
+			if s.tty {
+				s.frame = (s.frame + 1) % len(spinnerFrames)
+				s.drawLocked()
+			} else {
+				fmt.Fprintf(s.out, "%s... (%s elapsed)\n", s.line(), time.Since(s.start).Round(time.Second))
 			}
+			outputMu.Unlock()
 		}
-	}()
+	}
 }
 
-// Stop 停止 spinner.
+// Update 更新显示的消息；非终端时立即输出一行新消息
+func (s *Spinner) Update(msg string) {
+	if s == nil {
+		return
+	}
+	outputMu.Lock()
+	defer outputMu.Unlock()
+	if s.msg == msg {
+		return
+	}
+	s.msg = msg
+	if progressDisabled.Load() || s.start.IsZero() {
+		return
+	}
+	if s.tty {
+		s.drawLocked()
+	} else {
+		fmt.Fprintf(s.out, "%s...\n", s.line())
+	}
+}
+
+// Stop 停止 spinner 并输出完成行；重复调用无效
 func (s *Spinner) Stop() {
-	close(s.stopCh)
-	<-s.doneCh
+	s.Finish(nil)
+}
+
+// Finish 停止 spinner，err 为 nil 时输出完成行，否则输出失败行（错误本身由调用方报告）；重复调用无效
+func (s *Spinner) Finish(err error) {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() {
+		s.startOnce.Do(func() { close(s.doneCh) }) // 从未启动：没有需要等待的 goroutine
+		close(s.stopCh)
+		<-s.doneCh
+		outputMu.Lock()
+		defer outputMu.Unlock()
+		if s.start.IsZero() {
+			return
+		}
+		if active == s {
+			active = nil
+		}
+		mark, word := "✔", "done"
+		if err != nil {
+			mark, word = "✘", "failed"
+		}
+		if s.tty {
+			fmt.Fprintf(s.out, "%s%s %s\n", clearLine, s.line(), mark)
+			return
+		}
+		fmt.Fprintf(s.out, "%s %s (%s)\n", s.line(), word, time.Since(s.start).Round(100*time.Millisecond))
+	})
+}
+
+// line 返回消息与字节进度，调用方需持有 outputMu
+func (s *Spinner) line() string {
+	if s.counter == nil {
+		return s.msg
+	}
+	n := s.counter.N()
+	if s.total > 0 {
+		return fmt.Sprintf("%s %s / %s (%d%%)", s.msg, FormatBytes(n), FormatBytes(s.total), min(n*100/s.total, 100))
+	}
+	return fmt.Sprintf("%s %s", s.msg, FormatBytes(n))
+}
+
+// drawLocked 在当前行重绘帧与消息，调用方需持有 outputMu
+func (s *Spinner) drawLocked() {
+	fmt.Fprintf(s.out, "%s%c %s", clearLine, spinnerFrames[s.frame], s.line())
+}
+
+// SyncWriter 返回写入 w 的 io.Writer，与 spinner 共用同一把锁：写入前擦除正在绘制的 spinner 行，
+// 写入后重绘，使日志等输出不会与 spinner 帧交错（用于日志的控制台输出）
+func SyncWriter(w io.Writer) io.Writer {
+	return &syncWriter{w: w}
+}
+
+type syncWriter struct {
+	w io.Writer
+}
+
+func (sw *syncWriter) Write(p []byte) (int, error) {
+	outputMu.Lock()
+	defer outputMu.Unlock()
+	if active != nil {
+		_, _ = io.WriteString(active.out, clearLine)
+	}
+	n, err := sw.w.Write(p)
+	if active != nil {
+		active.drawLocked()
+	}
+	return n, err
+}
+
+// CountingReader 统计经过的字节数，可在其他 goroutine 中读取
+type CountingReader struct {
+	r io.Reader
+	n atomic.Int64
+}
+
+// NewCountingReader 包装 r 并统计读取的字节数
+func NewCountingReader(r io.Reader) *CountingReader {
+	return &CountingReader{r: r}
+}
+
+func (c *CountingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+// N 返回已读取的字节数
+func (c *CountingReader) N() int64 {
+	return c.n.Load()
+}
+
+// FormatBytes 以 1024 进制输出可读的字节数，例如 "1.5 MiB"
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
 }
@@ -0,0 +1,69 @@
+package style
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// 测试 CountingReader 统计读取的字节数，以及字节进度行的格式
+func TestCountingReader(t *testing.T) {
+	c := NewCountingReader(strings.NewReader(strings.Repeat("x", 3000)))
+	if n, err := io.Copy(io.Discard, c); err != nil || n != 3000 || c.N() != 3000 {
+		t.Fatalf("copied %d (err %v), counted %d", n, err, c.N())
+	}
+
+	s := newSpinner(io.Discard, "Downloading", false)
+	s.total = 4096
+	r := s.Wrap(strings.NewReader(strings.Repeat("x", 2048)))
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.line(); got != "Downloading 2.0 KiB / 4.0 KiB (50%)" {
+		t.Errorf("line = %q", got)
+	}
+	if got := FormatBytes(512); got != "512 B" {
+		t.Errorf("FormatBytes(512) = %q", got)
+	}
+}
+
+// 测试非终端输出：不绘制帧，开始、更新与结束各输出一行纯文本
+func TestSpinnerPlainFallback(t *testing.T) {
+	var b strings.Builder
+	s := newSpinner(&b, "Cloning repo", false)
+	s.Start()
+	s.Update("Building repo")
+	s.Stop()
+	s.Stop()
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	if len(lines) != 3 || lines[0] != "Cloning repo..." || lines[1] != "Building repo..." || !strings.HasPrefix(lines[2], "Building repo done (") {
+		t.Errorf("unexpected output:\n%q", b.String())
+	}
+	if strings.ContainsAny(b.String(), "\r\x1b") {
+		t.Errorf("plain output contains terminal control sequences: %q", b.String())
+	}
+
+	b.Reset()
+	failed := newSpinner(&b, "Downloading modules", false)
+	failed.Start()
+	failed.Finish(errors.New("boom"))
+	if !strings.Contains(b.String(), "Downloading modules failed (") {
+		t.Errorf("unexpected failure output: %q", b.String())
+	}
+
+	// 未启动或关闭进度时不输出任何内容；nil Spinner 可安全调用
+	b.Reset()
+	newSpinner(&b, "never started", false).Stop()
+	DisableProgress(true)
+	quiet := newSpinner(&b, "quiet", false)
+	quiet.Start()
+	quiet.Stop()
+	DisableProgress(false)
+	var none *Spinner
+	none.Start()
+	none.Stop()
+	if b.Len() != 0 {
+		t.Errorf("expected no output, got %q", b.String())
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -13,19 +14,47 @@ import (
 	runewidth "github.com/mattn/go-runewidth"
 )
 
+// TableOptions 控制 PrintTableWithOptions 的列宽与溢出处理
+type TableOptions struct {
+	// Width 期望的表格总宽度；<=0 时取自然宽度与终端宽度（探测失败回退到 80）的较小值
+	Width int
+	// MaxColumnWidths 按列下标限制单元格内容的最大显示宽度，0 或缺省表示不限制
+	MaxColumnWidths []int
+	// Wrap 为 true 时超宽内容折行显示，否则以省略号截断
+	Wrap bool
+}
+
 // PrintTable 用于标准化表格输出，支持自定义表头和内容
 // width: 期望的表格宽度；当 width<=0 时自动探测终端宽度（失败则回退到80）
+// 超出宽度时按列收缩，过长的单元格以省略号截断
 func PrintTable(w io.Writer, headers []string, rows [][]string, width int) error {
+	return PrintTableWithOptions(w, headers, rows, TableOptions{Width: width})
+}
+
+// PrintTableWithOptions 与 PrintTable 相同，但允许指定每列最大宽度以及折行/截断方式
+func PrintTableWithOptions(w io.Writer, headers []string, rows [][]string, opts TableOptions) error {
 	termWidth := detectTerminalWidth(w)
 	if termWidth <= 0 {
 		termWidth = 80
 	}
+	width := opts.Width
+	budget := width
+	if budget <= 0 {
+		budget = termWidth
+	}
+
+	// 先按每列上限、再按总宽度收缩列宽，然后截断或折行单元格内容
+	colWidths := capColumnWidths(calcColumnWidths(headers, rows), opts.MaxColumnWidths)
+	colWidths = fitColumnWidths(colWidths, headers, budget-tableChromeWidth(len(headers)))
+	rows = fitCells(rows, colWidths, opts.Wrap)
+	headers = fitCells([][]string{headers}, colWidths, false)[0]
+
 	naturalWidth := calcNaturalTableWidth(headers, rows)
 	if width <= 0 {
 		width = min(naturalWidth, termWidth)
 	}
 
-	re := lipgloss.NewRenderer(w)
+	re := newRenderer(w)
 
 	headerStyle := re.NewStyle().
 		Foreground(ColorAccentText).
@@ -80,6 +109,11 @@ func PrintTable(w io.Writer, headers []string, rows [][]string, width int) error
 	return err
 }
 
+// TerminalWidth 返回 w 所在终端的宽度（w 不是终端时参考 $COLUMNS），无法确定时返回 0
+func TerminalWidth(w io.Writer) int {
+	return detectTerminalWidth(w)
+}
+
 // detectTerminalWidth 尝试从 writer 获取终端宽度，失败则返回 0
 func detectTerminalWidth(w io.Writer) int {
 	if f, ok := w.(*os.File); ok {
@@ -97,37 +131,133 @@ func detectTerminalWidth(w io.Writer) int {
 	return 0
 }
 
-// 计算表格的宽度
-// = 各列最大内容宽度之和 + 每列左右 padding(2) + 边框竖线数量(列数+1)
-// 使用 runewidth 以兼容中英文/emoji 宽度
-func calcNaturalTableWidth(headers []string, rows [][]string) int {
-	if len(headers) == 0 {
+// tableChromeWidth 返回列内容之外占用的宽度：每列左右 padding(2) + 边框竖线数量(列数+1)
+func tableChromeWidth(cols int) int {
+	if cols == 0 {
 		return 0
 	}
+	return 2*cols + cols + 1
+}
+
+// calcColumnWidths 计算每列（含表头）内容的最大显示宽度
+// 使用 runewidth 以兼容中英文/emoji 宽度
+func calcColumnWidths(headers []string, rows [][]string) []int {
 	cols := len(headers)
 	maxW := make([]int, cols)
-	for i := 0; i < cols; i++ {
-		if i < len(headers) {
-			if w := runewidth.StringWidth(headers[i]); w > maxW[i] {
-				maxW[i] = w
-			}
-		}
+	for i, h := range headers {
+		maxW[i] = runewidth.StringWidth(h)
 	}
 	for _, r := range rows {
 		for i := 0; i < cols && i < len(r); i++ {
-			parts := strings.SplitSeq(r[i], "\n")
-			for p := range parts {
+			for p := range strings.SplitSeq(r[i], "\n") {
 				if w := runewidth.StringWidth(p); w > maxW[i] {
 					maxW[i] = w
 				}
 			}
 		}
 	}
+	return maxW
+}
+
+// capColumnWidths 应用每列最大宽度限制
+func capColumnWidths(widths, limits []int) []int {
+	out := slices.Clone(widths)
+	for i := range out {
+		if i < len(limits) && limits[i] > 0 && out[i] > limits[i] {
+			out[i] = limits[i]
+		}
+	}
+	return out
+}
+
+// fitColumnWidths 在内容总宽度超过 available 时，反复收缩当前最宽的列：
+// 先收缩到各列表头宽度，仍放不下时再收缩到 3（容纳省略号），无法继续收缩时按当前结果返回
+func fitColumnWidths(widths []int, headers []string, available int) []int {
+	out := slices.Clone(widths)
+	total := 0
+	for _, w := range out {
+		total += w
+	}
+	shrink := func(minW func(i int) int) {
+		for total > available {
+			widest := -1
+			for i := range out {
+				if out[i] > minW(i) && (widest < 0 || out[i] > out[widest]) {
+					widest = i
+				}
+			}
+			if widest < 0 {
+				return
+			}
+			out[widest]--
+			total--
+		}
+	}
+	shrink(func(i int) int { return max(runewidth.StringWidth(headers[i]), 3) })
+	shrink(func(int) int { return 3 })
+	return out
+}
+
+// fitCells 将超出列宽的单元格截断（末尾加省略号）或按列宽折行，返回新的行数据
+func fitCells(rows [][]string, widths []int, wrap bool) [][]string {
+	out := make([][]string, len(rows))
+	for r, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			if i >= len(widths) || runewidth.StringWidth(cell) <= widths[i] && !strings.Contains(cell, "\n") {
+				cells[i] = cell
+				continue
+			}
+			lines := strings.Split(cell, "\n")
+			var fitted []string
+			for _, line := range lines {
+				if wrap {
+					fitted = append(fitted, hardWrap(line, widths[i])...)
+				} else {
+					fitted = append(fitted, runewidth.Truncate(line, widths[i], "…"))
+				}
+			}
+			cells[i] = strings.Join(fitted, "\n")
+		}
+		out[r] = cells
+	}
+	return out
+}
+
+// hardWrap 按显示宽度将一行切分为多行
+func hardWrap(s string, width int) []string {
+	if width <= 0 || runewidth.StringWidth(s) <= width {
+		return []string{s}
+	}
+	var lines []string
+	var cur strings.Builder
+	curW := 0
+	for _, r := range s {
+		rw := runewidth.RuneWidth(r)
+		if curW+rw > width && curW > 0 {
+			lines = append(lines, cur.String())
+			cur.Reset()
+			curW = 0
+		}
+		cur.WriteRune(r)
+		curW += rw
+	}
+	if cur.Len() > 0 {
+		lines = append(lines, cur.String())
+	}
+	return lines
+}
+
+// 计算表格的宽度
+// = 各列最大内容宽度之和 + 每列左右 padding(2) + 边框竖线数量(列数+1)
+// 使用 runewidth 以兼容中英文/emoji 宽度
+func calcNaturalTableWidth(headers []string, rows [][]string) int {
+	if len(headers) == 0 {
+		return 0
+	}
 	contentSum := 0
-	for _, w := range maxW {
+	for _, w := range calcColumnWidths(headers, rows) {
 		contentSum += w
 	}
-	paddingSum := 2 * cols
-	borders := cols + 1
-	return contentSum + paddingSum + borders
+	return contentSum + tableChromeWidth(len(headers))
 }
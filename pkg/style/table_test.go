@@ -0,0 +1,51 @@
+package style
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	runewidth "github.com/mattn/go-runewidth"
+)
+
+// 测试列宽收缩优先收缩最宽的列，且不低于表头宽度
+func TestFitColumnWidths(t *testing.T) {
+	headers := []string{"name", "path"}
+	got := fitColumnWidths([]int{6, 40}, headers, 26)
+	if got[0] != 6 || got[1] != 20 {
+		t.Fatalf("expected [6 20], got %v", got)
+	}
+	// 放不下表头时继续收缩到 3
+	got = fitColumnWidths([]int{6, 40}, headers, 6)
+	if got[0] != 3 || got[1] != 3 {
+		t.Fatalf("expected [3 3], got %v", got)
+	}
+}
+
+// 测试截断、折行与每列最大宽度
+func TestFitCellsAndMaxColumnWidths(t *testing.T) {
+	rows := [][]string{{"gopls", "/home/user/go/bin/gopls"}}
+	truncated := fitCells(rows, []int{5, 10}, false)
+	if got := truncated[0][1]; runewidth.StringWidth(got) != 10 || !strings.HasSuffix(got, "…") {
+		t.Errorf("expected 10-wide truncated cell, got %q", got)
+	}
+	wrapped := fitCells(rows, []int{5, 10}, true)
+	if got := wrapped[0][1]; got != "/home/user\n/go/bin/go\npls" {
+		t.Errorf("unexpected wrapped cell %q", got)
+	}
+	if rows[0][1] != "/home/user/go/bin/gopls" {
+		t.Errorf("input rows must not be modified")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	var buf bytes.Buffer
+	err := PrintTableWithOptions(&buf, []string{"name", "path"}, rows, TableOptions{MaxColumnWidths: []int{0, 12}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for line := range strings.SplitSeq(strings.TrimSpace(buf.String()), "\n") {
+		if w := runewidth.StringWidth(line); w != 24 {
+			t.Errorf("expected table width 24, got %d: %q", w, line)
+		}
+	}
+}
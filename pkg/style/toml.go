@@ -16,6 +16,7 @@ import (
 //   - string / []byte: 视为原始 TOML 文本，会尝试解析并重新序列化以规范化输出
 //   - 其他任意 Go 值: 使用 toml.Marshal 编码后再渲染
 func PrintTOML(w io.Writer, v any) error {
+	w = colorWriter(w)
 	pretty, err := FormatTOML(v)
 	if err != nil {
 		return err
@@ -109,6 +110,7 @@ func readQuotedTokenTOML(s string, i int) (string, int) {
 
 // PrintTOMLLine 将单行 TOML 以美化样式的方式输出到 writer
 func PrintTOMLLine(w io.Writer, v any) error {
+	w = colorWriter(w)
 	s := v.(string)
 	colored := colorizeTOML(s)
 	_, err := fmt.Fprint(w, colored)
@@ -223,8 +225,8 @@ func colorizeTOML(s string) string {
 			continue
 		}
 
-		// 行中可能包含注释，先分离
-		commentIdx := strings.Index(trimmed, "#")
+		// 行中可能包含注释，先分离（引号内的 # 不是注释）
+		commentIdx := indexUnquoted(trimmed, '#')
 		content := trimmed
 		comment := ""
 		if commentIdx >= 0 {
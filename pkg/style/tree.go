@@ -17,6 +17,7 @@ type TreeNode struct {
 // PrintTree 用于渲染一个带有主题样式的树形结构到指定的 writer
 // 它接收一个 TreeNode 结构作为数据源
 func PrintTree(w io.Writer, rootNode TreeNode) error {
+	w = colorWriter(w)
 	// 定义各部分样式
 	rootStyle := lipgloss.NewStyle().Foreground(ColorAccentText).Bold(true)
 	itemStyle := lipgloss.NewStyle().Foreground(ColorText)
@@ -16,6 +16,7 @@ import (
 //   - string / []byte: 视为原始 YAML 文本，会尝试解析并重新序列化以规范化输出
 //   - 其他任意 Go 值: 使用 yaml.Marshal 编码后再渲染
 func PrintYAML(w io.Writer, v any) error {
+	w = colorWriter(w)
 	pretty, err := FormatYAML(v)
 	if err != nil {
 		return err
@@ -27,6 +28,7 @@ func PrintYAML(w io.Writer, v any) error {
 
 // PrintYAMLLine 将单行 YAML 以美化样式的方式输出到 writer
 func PrintYAMLLine(w io.Writer, v any) error {
+	w = colorWriter(w)
 	s := v.(string)
 	colored := colorizeYAML(s)
 	_, err := fmt.Fprint(w, colored)
@@ -1 +1,334 @@
 package tools
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/yeisme/gocli/pkg/configs"
+)
+
+// AddOptions 定义 tools add 的选项
+//   - Spec: module/path@version 或内置/用户工具表中的短名（可带 @version），与 CloneURL 互斥
+//   - CloneURL 等字段：以源码构建的工具，对应配置中的 clone/build/make_target/workdir
+//   - Global: 写入 tools.global，否则写入 tools.deps
+//   - ConfigFile: 要修改的配置文件，为空时由 ToolsConfigFile 决定
+type AddOptions struct {
+	Spec           string
+	CloneURL       string
+	Build          string
+	MakeTarget     string
+	WorkDir        string
+	BinDirs        []string
+	BinaryName     string
+	Env            []string
+	Tags           []string
+	Global         bool
+	ConfigFile     string
+	ToolsConfigDir []string
+}
+
+// AddResult 记录 tools add 写入的条目
+type AddResult struct {
+	Tool    configs.Tool
+	Section string // deps 或 global
+	File    string
+	Updated bool // 已存在同一工具的条目并被原地更新
+}
+
+// ExecuteAddCommand 解析工具并写入配置文件，不执行安装
+func ExecuteAddCommand(opts AddOptions) (*AddResult, error) {
+	tool, err := BuildToolEntry(opts)
+	if err != nil {
+		return nil, err
+	}
+	file := opts.ConfigFile
+	if file == "" {
+		file = ToolsConfigFile(opts.Global)
+	}
+	section := "deps"
+	if opts.Global {
+		section = "global"
+	}
+	updated, err := AddToolToConfig(file, section, tool)
+	if err != nil {
+		return nil, err
+	}
+	return &AddResult{Tool: tool, Section: section, File: file, Updated: updated}, nil
+}
+
+// InstallAdded 安装刚写入配置的条目，与 tools install 按配置安装时的目录一致：
+// deps 安装到 toolsPath（为空时 ~/.gocli/tools），global 安装到 ~/.gocli/tools
+func InstallAdded(res *AddResult, toolsPath string, verbose bool) error {
+	globalPath := filepath.Join(mustUserHome(), ".gocli", "tools")
+	target, category := toolsPath, "dep"
+	if res.Section == "global" || strings.TrimSpace(target) == "" {
+		target = globalPath
+	}
+	if res.Section == "global" {
+		category = "global"
+	}
+	if _, failed := InstallConfiguredToolsFromList([]configs.Tool{res.Tool}, target, category, nil, verbose); failed > 0 {
+		return fmt.Errorf("install %s failed", toolEntryKey(res.Tool))
+	}
+	return nil
+}
+
+// BuildToolEntry 根据选项生成配置条目：
+//   - --clone 生成 clone 条目
+//   - 含 `/` 的 spec 视为 go install 的模块路径
+//   - 短名通过 SearchTools 在内置/用户工具表中解析，补全模块或 clone 信息；short@version 覆盖版本
+func BuildToolEntry(opts AddOptions) (configs.Tool, error) {
+	spec := strings.TrimSpace(opts.Spec)
+	if err := checkMutualExclusion(opts.CloneURL, spec); err != nil {
+		return configs.Tool{}, err
+	}
+	if opts.CloneURL != "" {
+		return configs.Tool{
+			Type:       "clone",
+			CloneURL:   opts.CloneURL,
+			Build:      opts.Build,
+			MakeTarget: opts.MakeTarget,
+			WorkDir:    opts.WorkDir,
+			BinDirs:    opts.BinDirs,
+			BinaryName: opts.BinaryName,
+			Env:        opts.Env,
+		}, nil
+	}
+	if spec == "" {
+		return configs.Tool{}, fmt.Errorf("a tool spec (module/path@version or short name) or --clone is required")
+	}
+	if strings.Contains(spec, "/") {
+		return configs.Tool{
+			Type:       "go",
+			Module:     spec,
+			BinaryName: opts.BinaryName,
+			Env:        opts.Env,
+			Tags:       opts.Tags,
+		}, nil
+	}
+
+	name, version, _ := strings.Cut(spec, "@")
+	bi := SearchTools(name, opts.ToolsConfigDir)
+	if bi == nil {
+		return configs.Tool{}, fmt.Errorf("unknown tool %q: not found in the builtin or user tool table (use a full module path or --clone)", name)
+	}
+	tool := configs.Tool{
+		BinaryName: firstNonEmpty(opts.BinaryName, bi.BinaryName),
+		Env:        append(append([]string{}, bi.Env...), opts.Env...),
+		Tags:       append(append([]string{}, bi.Tags...), opts.Tags...),
+	}
+	if bi.URL != "" {
+		tool.Type = "go"
+		tool.Module = bi.URL
+		if version != "" {
+			mod, _, _ := strings.Cut(bi.URL, "@")
+			tool.Module = mod + "@" + version
+		}
+		return tool, nil
+	}
+	tool.Type = "clone"
+	tool.CloneURL = bi.CloneURL
+	if version != "" {
+		repo, _, _ := strings.Cut(bi.CloneURL, "#")
+		tool.CloneURL = repo + "#" + version
+	}
+	tool.Build = firstNonEmpty(opts.Build, bi.Build)
+	tool.MakeTarget = firstNonEmpty(opts.MakeTarget, bi.MakeTarget)
+	tool.WorkDir = firstNonEmpty(opts.WorkDir, bi.WorkDir)
+	tool.BinDirs = bi.BinDirs
+	if len(opts.BinDirs) > 0 {
+		tool.BinDirs = opts.BinDirs
+	}
+	tool.Tags = nil
+	return tool, nil
+}
+
+// ToolsConfigFile 返回 tools add 默认修改的配置文件：
+//   - 项目（deps）：模块根目录（不在模块中时为当前目录）下已有的 .gocli/gocli 配置，否则 <root>/.gocli.yaml
+//   - 全局（global）：$HOME、$HOME/.config、$HOME/.config/gocli 中第一个已有的配置，否则 $HOME/.gocli.yaml
+func ToolsConfigFile(global bool) string {
+	var dirs []string
+	if global {
+		home := mustUserHome()
+		dirs = []string{home, filepath.Join(home, ".config"), filepath.Join(home, ".config", "gocli")}
+	} else {
+		root := configs.GetModuleRoot("")
+		if root == "" || !fileExists(filepath.Join(root, "go.mod")) {
+			root = "."
+		}
+		dirs = []string{root}
+	}
+	for _, d := range dirs {
+		if f := configs.FindConfigFile(d); f != "" {
+			return f
+		}
+	}
+	return filepath.Join(dirs[0], ".gocli.yaml")
+}
+
+func fileExists(p string) bool {
+	fi, err := os.Stat(p)
+	return err == nil && !fi.IsDir()
+}
+
+// AddToolToConfig 把 tool 写入 YAML 配置文件的 tools.<section> 列表，文件不存在时创建。
+// 通过 yaml.Node 读写以尽量保留注释与键顺序；同一工具（相同模块路径或仓库地址，忽略版本）
+// 已存在时原地替换并返回 updated=true
+func AddToolToConfig(file, section string, tool configs.Tool) (updated bool, err error) {
+	if ext := strings.ToLower(filepath.Ext(file)); ext != ".yaml" && ext != ".yml" {
+		return false, fmt.Errorf("%s: only YAML config files can be edited by tools add", file)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return false, fmt.Errorf("parse %s failed: %w", file, err)
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return false, fmt.Errorf("%s: top level is not a mapping", file)
+	}
+	toolsNode := mappingValue(root, "tools", yaml.MappingNode)
+	list := mappingValue(toolsNode, section, yaml.SequenceNode)
+	if list == nil || toolsNode == nil {
+		return false, fmt.Errorf("%s: tools.%s is not a list", file, section)
+	}
+
+	var entry yaml.Node
+	if err := entry.Encode(toManifestTool(tool)); err != nil {
+		return false, err
+	}
+	key := toolEntryKey(tool)
+	for i, item := range list.Content {
+		var existing configs.Tool
+		if err := decodeToolNode(item, &existing); err != nil || toolEntryKey(existing) != key {
+			continue
+		}
+		copyComments(item, &entry)
+		list.Content[i] = &entry
+		updated = true
+		break
+	}
+	if !updated {
+		list.Content = append(list.Content, &entry)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return false, fmt.Errorf("encode %s failed: %w", file, err)
+	}
+	_ = enc.Close()
+	if dir := filepath.Dir(file); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return false, err
+		}
+	}
+	mode := fs.FileMode(0o644)
+	if fi, err := os.Stat(file); err == nil {
+		mode = fi.Mode().Perm()
+	}
+	return updated, os.WriteFile(file, buf.Bytes(), mode)
+}
+
+// mappingValue 返回 mapping 中 key 对应的值节点，不存在时以 kind 创建；类型不符时返回 nil
+func mappingValue(m *yaml.Node, key string, kind yaml.Kind) *yaml.Node {
+	if m == nil {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value != key {
+			continue
+		}
+		v := m.Content[i+1]
+		if v.Kind == yaml.ScalarNode && v.Tag == "!!null" {
+			// 例如 "deps:" 没有值
+			v.Kind, v.Tag, v.Value = kind, "", ""
+		}
+		if v.Kind != kind {
+			return nil
+		}
+		return v
+	}
+	v := &yaml.Node{Kind: kind}
+	m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, v)
+	return v
+}
+
+// copyComments 把旧条目（及其同名字段）上的注释复制到替换它的新条目
+func copyComments(from, to *yaml.Node) {
+	to.HeadComment, to.LineComment, to.FootComment = from.HeadComment, from.LineComment, from.FootComment
+	if from.Kind != yaml.MappingNode || to.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(to.Content); i += 2 {
+		for j := 0; j+1 < len(from.Content); j += 2 {
+			if from.Content[j].Value != to.Content[i].Value {
+				continue
+			}
+			k, v := from.Content[j], from.Content[j+1]
+			to.Content[i].HeadComment, to.Content[i].LineComment, to.Content[i].FootComment = k.HeadComment, k.LineComment, k.FootComment
+			to.Content[i+1].LineComment, to.Content[i+1].FootComment = v.LineComment, v.FootComment
+		}
+	}
+}
+
+// decodeToolNode 读取列表中的已有条目，字段名与 configs.Tool 的 mapstructure 标签一致
+func decodeToolNode(n *yaml.Node, t *configs.Tool) error {
+	var raw struct {
+		Type     string `yaml:"type"`
+		Cmd      string `yaml:"cmd"`
+		Module   string `yaml:"module"`
+		CloneURL string `yaml:"clone"`
+	}
+	if err := n.Decode(&raw); err != nil {
+		return err
+	}
+	*t = configs.Tool{Type: raw.Type, Cmd: raw.Cmd, Module: raw.Module, CloneURL: raw.CloneURL}
+	return nil
+}
+
+// toolEntryKey 返回判断重复条目的标识：go 工具为不带版本的模块路径，clone 工具为不带 #ref 的仓库地址
+func toolEntryKey(t configs.Tool) string {
+	if t.CloneURL != "" {
+		repo, _, _ := strings.Cut(t.CloneURL, "#")
+		return "clone:" + strings.TrimSuffix(strings.TrimSuffix(repo, "/"), ".git")
+	}
+	mod := t.Module
+	if mod == "" && t.Cmd != "" {
+		if m, err := ParseGoInstallSpec(t.Cmd); err == nil {
+			mod = m
+		} else {
+			mod = t.Cmd
+		}
+	}
+	mod, _, _ = strings.Cut(strings.TrimSpace(mod), "@")
+	return "go:" + mod
+}
+
+// PrintAddResult 输出 tools add 的结果
+func PrintAddResult(out io.Writer, res *AddResult) {
+	action := "added"
+	if res.Updated {
+		action = "updated"
+	}
+	what := res.Tool.Module
+	if res.Tool.CloneURL != "" {
+		what = res.Tool.CloneURL
+	}
+	fmt.Fprintf(out, "%s %s in tools.%s of %s\n", action, what, res.Section, res.File)
+}
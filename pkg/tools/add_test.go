@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// 测试 tools add 写入已有配置：重复添加同一模块只保留一个（更新后的）条目，注释与其他键保持不变
+func TestAddToolToConfig(t *testing.T) {
+	file := filepath.Join(t.TempDir(), ".gocli.yaml")
+	fixture := `# project config
+app:
+  verbose: true
+tools:
+  path: ./bin # local tools
+  deps:
+    - type: go
+      module: golang.org/x/tools/cmd/goimports@v0.30.0
+  timeout: 60
+`
+	if err := os.WriteFile(file, []byte(fixture), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, spec := range []string{"example.com/demo/cmd/demo@v1.0.0", "example.com/demo/cmd/demo@v1.2.0"} {
+		if _, err := ExecuteAddCommand(AddOptions{Spec: spec, ConfigFile: file}); err != nil {
+			t.Fatalf("add %s: %v", spec, err)
+		}
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	if n := strings.Count(out, "example.com/demo/cmd/demo"); n != 1 {
+		t.Fatalf("expected exactly one demo entry, got %d:\n%s", n, out)
+	}
+	for _, want := range []string{"# project config", "path: ./bin # local tools", "timeout: 60", "verbose: true", "goimports@v0.30.0"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q to be kept:\n%s", want, out)
+		}
+	}
+
+	v := viper.New()
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		t.Fatalf("rewritten config is not valid: %v", err)
+	}
+	deps := v.Get("tools.deps").([]any)
+	if len(deps) != 2 || deps[1].(map[string]any)["module"] != "example.com/demo/cmd/demo@v1.2.0" {
+		t.Fatalf("unexpected tools.deps: %v", deps)
+	}
+
+	// --global 写入 tools.global，且文件不存在时创建
+	global := filepath.Join(t.TempDir(), "user.yaml")
+	res, err := ExecuteAddCommand(AddOptions{CloneURL: "https://github.com/org/tool.git#v1", Build: "goreleaser", Global: true, ConfigFile: global})
+	if err != nil || res.Section != "global" || res.Updated {
+		t.Fatalf("add clone tool: %+v, %v", res, err)
+	}
+	data, _ = os.ReadFile(global)
+	if !strings.Contains(string(data), "global:") || !strings.Contains(string(data), "build: goreleaser") {
+		t.Fatalf("unexpected global config:\n%s", data)
+	}
+}
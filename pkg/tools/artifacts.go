@@ -16,7 +16,12 @@ func collectAndCopyBins(binDirs []string, repoDir string, env []string, absBase
 		destDir = absBase
 	}
 	if err := os.MkdirAll(destDir, 0o755); err != nil {
-		return "", fmt.Errorf("prepare install dir %s failed: %w", destDir, err)
+		return "", fmt.Errorf("prepare install dir %s failed: %w", displayPath(destDir), err)
+	}
+	// 按目标平台识别产物：交叉构建 windows 时 .exe 等产物没有可执行位
+	goos := envLookup(env, "GOOS")
+	if goos == "" {
+		goos = runtime.GOOS
 	}
 
 	// 归一化 binDirs
@@ -41,7 +46,7 @@ func collectAndCopyBins(binDirs []string, repoDir string, env []string, absBase
 		entries, err := os.ReadDir(dd)
 		if err != nil {
 			if verbose {
-				fmt.Fprintf(b, "\n[bin] skip dir (not found): %s", dd)
+				fmt.Fprintf(b, "\n[bin] skip dir (not found): %s", displayPath(dd))
 			}
 			continue
 		}
@@ -50,41 +55,36 @@ func collectAndCopyBins(binDirs []string, repoDir string, env []string, absBase
 				continue
 			}
 			srcName := e.Name()
-			if !isExecutable(srcName, dd) {
+			if !isExecutableFor(goos, srcName, dd) {
 				continue
 			}
 			srcPath := filepath.Join(dd, srcName)
 			dstPath := filepath.Join(destDir, srcName)
 			if err := copyFile(srcPath, dstPath); err != nil {
-				return b.String(), fmt.Errorf("copy %s -> %s failed: %w", srcPath, dstPath, err)
+				return b.String(), fmt.Errorf("copy %s -> %s failed: %w", displayPath(srcPath), displayPath(dstPath), err)
 			}
+			// copyFile 写入 0644，复制的产物需恢复可执行位
+			_ = os.Chmod(dstPath, 0o755)
 			copied++
 			copiedNames = append(copiedNames, e.Name())
 			if verbose {
-				fmt.Fprintf(b, "\n[bin] copied: %s -> %s", srcPath, dstPath)
+				fmt.Fprintf(b, "\n[bin] copied: %s -> %s", displayPath(srcPath), displayPath(dstPath))
 			}
 		}
 	}
 	// 如果指定了目标二进制名，且仅复制了一个可执行文件，则在目标目录中重命名
 	if targetBinary != "" && copied == 1 && len(copiedNames) == 1 {
 		oldName := copiedNames[0]
-		newName := targetBinary
-		lowerOld := strings.ToLower(oldName)
-		lowerNew := strings.ToLower(newName)
-		if runtime.GOOS == "windows" {
-			// 保留 .exe 等后缀；若新名未带后缀而旧名有 .exe，则补上
-			if !strings.HasSuffix(lowerNew, ".exe") && strings.HasSuffix(lowerOld, ".exe") {
-				newName += ".exe"
-			}
-		}
+		// windows 上新名未带可执行后缀时沿用旧名的后缀（默认 .exe）
+		newName := renamedBinaryName(goos, oldName, targetBinary)
 		oldPath := filepath.Join(destDir, oldName)
 		newPath := filepath.Join(destDir, newName)
 		if oldPath != newPath {
-			if err := os.Rename(oldPath, newPath); err != nil {
-				return b.String(), fmt.Errorf("rename %s -> %s failed: %w", oldPath, newPath, err)
+			if err := replaceFile(oldPath, newPath); err != nil {
+				return b.String(), fmt.Errorf("rename %s -> %s failed: %w", displayPath(oldPath), displayPath(newPath), err)
 			}
 			if verbose {
-				fmt.Fprintf(b, "\n[bin] renamed: %s -> %s", oldPath, newPath)
+				fmt.Fprintf(b, "\n[bin] renamed: %s -> %s", displayPath(oldPath), displayPath(newPath))
 			}
 		}
 	}
@@ -39,8 +39,12 @@ type (
 		InstallType *InstallType `mapstructure:"install_type" jsonschema:"description=Platform constraints for installation; contains name/os/arch; may be null,nullable"`
 		// BinaryName 指定该工具生成的二进制文件名（可选）；
 		BinaryName string `mapstructure:"binary_name" jsonschema:"description=Optional binary filename produced by the tool; if empty default rules apply (e.g., go install),nullable"`
+		// SHA256: 安装后二进制期望的 sha256，安装时校验，tools verify 以它检测变化
+		SHA256 string `mapstructure:"sha256" jsonschema:"description=Expected sha256 of the installed binary; checked after install and by tools verify,nullable"`
 		// Tags: 构建标签，用于 go install 的 -tags 参数
 		Tags []string `mapstructure:"tags" jsonschema:"description=Build tags to pass to go install,nullable,uniqueItems"`
+		// Check: 安装后的冒烟测试参数或完整命令（如 "version"），为空时依次尝试 --version、version、-h，"none" 关闭
+		Check string `mapstructure:"check" jsonschema:"description=Post-install smoke test: arguments or full command run against the installed binary; empty tries --version then version then -h; none disables,nullable"`
 	}
 
 	// InstallType 定义了内置工具的安装类型
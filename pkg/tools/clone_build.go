@@ -2,8 +2,13 @@ package tools
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/style"
+	"github.com/yeisme/gocli/pkg/utils/executor"
 )
 
 // CloneBuildOptions 描述 clone 后的构建行为
@@ -21,17 +26,34 @@ type CloneBuildOptions struct {
 	BinDirs           []string
 	BinaryName        string
 	Force             bool // 强制模型，如果目标目录已存在则覆盖，否则就复用
+	// RetryAttempts/RetryDelay: git clone 遇到暂时性网络故障时的总尝试次数与首次重试前的等待，0 使用默认值
+	RetryAttempts int
+	RetryDelay    time.Duration
 }
 
 // CloneAndBuildInstall 克隆仓库并按指定构建方式构建，然后从 bin 目录收集产物
-func CloneAndBuildInstall(o CloneBuildOptions) (string, error) {
+func CloneAndBuildInstall(o CloneBuildOptions) (out string, err error) {
 	// 解析 clone 输入（不删除已有目录；是否删除由复用逻辑控制）
 	repoURL, resolvedRef, displayRef, absBase, repoDir, env2, err := resolveCloneInputs(o.CloneURL, o.InstallDir, o.Env, o.Force)
 	if err != nil {
 		return "", err
 	}
-	// 克隆检出
-	outClone, err := gitCloneAndCheckoutWithOpts(repoURL, repoDir, absBase, resolvedRef, o.RecurseSubmodules)
+	// verbose 时 git/构建输出直接打印到终端，dry-run 时不会真正执行，两者都不显示进度
+	var sp *style.Spinner
+	if !o.Verbose && !executor.Recording() {
+		sp = style.NewSpinner(os.Stderr, "Cloning "+repoURL)
+		sp.Start()
+	}
+	defer func() { sp.Finish(err) }()
+	// 克隆检出；暂时性网络故障时重试，重试前删除上次失败留下的不完整仓库目录
+	_, statErr := os.Stat(repoDir)
+	existed, attempt := statErr == nil, 0
+	outClone, err := newRetryPolicy(o.RetryAttempts, o.RetryDelay).do("git clone "+repoURL, func() (string, error) {
+		if attempt++; attempt > 1 && !existed {
+			_ = os.RemoveAll(repoDir)
+		}
+		return gitCloneAndCheckoutWithOpts(repoURL, repoDir, absBase, resolvedRef, o.RecurseSubmodules)
+	})
 	if err != nil {
 		return outClone, err
 	}
@@ -72,10 +94,15 @@ func CloneAndBuildInstall(o CloneBuildOptions) (string, error) {
 		BuildArgs:        o.BuildArgs,
 	}
 
-	out, err := runner.Build(ctx, params)
+	sp.Update(fmt.Sprintf("Building %s with %s", filepath.Base(repoDir), method))
+	out, err = runner.Build(ctx, params)
 	if err != nil {
 		return out, err
 	}
+	// dry-run（录制模式）下仓库并未真正克隆构建，没有可收集的产物
+	if executor.Recording() {
+		return out, nil
+	}
 
 	// 复制产物：优先使用用户指定的 BinDirs，否则用 runner 默认目录
 	binDirs := o.BinDirs
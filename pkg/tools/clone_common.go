@@ -5,11 +5,22 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/yeisme/gocli/pkg/utils/executor"
 )
 
+// commandTimeout 返回 clone/build/install 等长时间外部命令的超时时间（tools.timeout，单位秒），0 表示不限制
+func commandTimeout() time.Duration {
+	return time.Duration(viper.GetInt("tools.timeout")) * time.Second
+}
+
+// toolExecutor 创建应用了 tools.timeout 的执行器，用于 git/make/goreleaser/go install 等可能卡住的命令
+func toolExecutor(name string, args ...string) *executor.Executor {
+	return executor.NewExecutor(name, args...).WithTimeout(commandTimeout())
+}
+
 // resolveCloneInputs 解析 clone 规格、latest 标签、基础目录与目标仓库目录，并补全 GOBIN
 func resolveCloneInputs(cloneURL, installDir string, env []string, force bool) (repoURL, resolvedRef, displayRef, absBase, repoDir string, env2 []string, err error) {
 	env2 = append([]string{}, env...)
@@ -44,7 +55,7 @@ func resolveCloneInputs(cloneURL, installDir string, env []string, force bool) (
 		return
 	}
 	if e := os.MkdirAll(baseDir, 0o755); e != nil {
-		err = fmt.Errorf("create base dir %s failed: %w", baseDir, e)
+		err = fmt.Errorf("create base dir %s failed: %w", displayPath(baseDir), e)
 		return
 	}
 	absBase, _ = filepath.Abs(baseDir)
@@ -65,7 +76,7 @@ func resolveCloneInputs(cloneURL, installDir string, env []string, force bool) (
 		return
 	}
 	// 清理旧目录
-	if force {
+	if force && !executor.Recording() {
 		_ = os.RemoveAll(repoDir)
 	}
 	return
@@ -78,15 +89,15 @@ func gitCloneAndCheckoutWithOpts(repoURL, repoDir, absBase, resolvedRef string,
 		args = append(args, "--recurse-submodules")
 	}
 	args = append(args, repoURL, repoDir)
-	if out, err := executor.NewExecutor("git", args...).WithDir(absBase).CombinedOutput(); err != nil {
+	if out, err := toolExecutor("git", args...).WithDir(absBase).CombinedOutput(); err != nil {
 		return out, fmt.Errorf("git clone failed: %w", err)
 	}
 	if strings.TrimSpace(resolvedRef) == "" {
 		return "", nil
 	}
-	if out, err := executor.NewExecutor("git", "checkout", resolvedRef).WithDir(repoDir).CombinedOutput(); err != nil {
+	if out, err := toolExecutor("git", "checkout", resolvedRef).WithDir(repoDir).CombinedOutput(); err != nil {
 		// 回退尝试 tags/<ref>
-		if out2, err2 := executor.NewExecutor("git", "checkout", "tags/"+resolvedRef).WithDir(repoDir).CombinedOutput(); err2 == nil {
+		if out2, err2 := toolExecutor("git", "checkout", "tags/"+resolvedRef).WithDir(repoDir).CombinedOutput(); err2 == nil {
 			return out + "\n" + out2, nil
 		}
 		return out, fmt.Errorf("git checkout %s failed: %w", resolvedRef, err)
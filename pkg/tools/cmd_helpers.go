@@ -11,13 +11,30 @@ import (
 
 	"github.com/yeisme/gocli/pkg/configs"
 	"github.com/yeisme/gocli/pkg/style"
+	"github.com/yeisme/gocli/pkg/utils/executor"
 )
 
+// BatchInstallOptions 是命令行对批量安装（配置中的工具、--group、--from-file）中每个工具生效的选项
+type BatchInstallOptions struct {
+	// Check 覆盖每个工具的 check 字段（"none" 关闭冒烟测试）；为空时使用工具定义中的值
+	Check string
+	// StrictCheck 冒烟测试失败时删除该工具的二进制并计为安装失败
+	StrictCheck bool
+	// Offline 只从本机模块缓存安装；配置 tools.offline 同样生效
+	Offline bool
+}
+
+// check 返回工具实际使用的冒烟测试：命令行的 --check 优先于工具定义
+func (o BatchInstallOptions) check(toolCheck string) string {
+	return firstNonEmpty(o.Check, toolCheck)
+}
+
 // BatchInstallConfiguredTools installs tools from a Config (deps and global)
-func BatchInstallConfiguredTools(cfg *configs.Config, envFlags []string, verbose bool) error {
+func BatchInstallConfiguredTools(cfg *configs.Config, envFlags []string, verbose bool, opts BatchInstallOptions) error {
 	if cfg == nil {
 		return fmt.Errorf("config is nil")
 	}
+	opts.Offline = opts.Offline || cfg.Tools.Offline
 
 	depsPath := cfg.Tools.GoCLIToolsPath
 	if strings.TrimSpace(depsPath) == "" {
@@ -31,7 +48,10 @@ func BatchInstallConfiguredTools(cfg *configs.Config, envFlags []string, verbose
 
 	// install deps
 	for _, t := range cfg.Tools.Deps {
-		ok, err := installSingleConfiguredTool(t, depsPath, "dep", envFlags, verbose, cfg.Tools.ToolsConfigDir)
+		if err := executor.Canceled(); err != nil {
+			return err
+		}
+		ok, err := installSingleConfiguredTool(t, depsPath, "dep", envFlags, verbose, cfg.Tools.ToolsConfigDir, opts)
 		if err != nil {
 			failed++
 		}
@@ -42,7 +62,10 @@ func BatchInstallConfiguredTools(cfg *configs.Config, envFlags []string, verbose
 
 	// install globals
 	for _, t := range cfg.Tools.Global {
-		ok, err := installSingleConfiguredTool(t, globalPath, "global", envFlags, verbose, cfg.Tools.ToolsConfigDir)
+		if err := executor.Canceled(); err != nil {
+			return err
+		}
+		ok, err := installSingleConfiguredTool(t, globalPath, "global", envFlags, verbose, cfg.Tools.ToolsConfigDir, opts)
 		if err != nil {
 			failed++
 		}
@@ -62,15 +85,19 @@ func BatchInstallConfiguredTools(cfg *configs.Config, envFlags []string, verbose
 }
 
 // BatchInstallConfiguredGlobalTools installs only global tools to ~/.gocli/tools
-func BatchInstallConfiguredGlobalTools(cfg *configs.Config, envFlags []string, verbose bool) error {
+func BatchInstallConfiguredGlobalTools(cfg *configs.Config, envFlags []string, verbose bool, opts BatchInstallOptions) error {
 	if cfg == nil {
 		return fmt.Errorf("config is nil")
 	}
+	opts.Offline = opts.Offline || cfg.Tools.Offline
 	targetPath := filepath.Join(mustUserHome(), ".gocli", "tools")
 	total := 0
 	failed := 0
 	for _, t := range cfg.Tools.Global {
-		ok, err := installSingleConfiguredTool(t, targetPath, "global", envFlags, verbose, cfg.Tools.ToolsConfigDir)
+		if err := executor.Canceled(); err != nil {
+			return err
+		}
+		ok, err := installSingleConfiguredTool(t, targetPath, "global", envFlags, verbose, cfg.Tools.ToolsConfigDir, opts)
 		if err != nil {
 			failed++
 		}
@@ -92,7 +119,8 @@ func BatchInstallConfiguredGlobalTools(cfg *configs.Config, envFlags []string, v
 // various candidate keys (module base name, full module, cmd, clone url).
 // If a matching InstallToolsInfo is found, its fields are used to construct
 // InstallOptions; otherwise the legacy configs.Tool fields are used.
-func installSingleConfiguredTool(t configs.Tool, targetPath, category string, envFlags []string, verbose bool, configDirs []string) (bool, error) {
+// opts carries the command line options applied to every tool (see BatchInstallOptions).
+func installSingleConfiguredTool(t configs.Tool, targetPath, category string, envFlags []string, verbose bool, configDirs []string, opts BatchInstallOptions) (bool, error) {
 	// 合并环境变量（用户传入的 envFlags 优先，然后是工具配置内的 env）
 	envMerged := mergeEnv(envFlags, t.Env)
 
@@ -109,11 +137,18 @@ func installSingleConfiguredTool(t configs.Tool, targetPath, category string, en
 		}
 		// 合并最终环境变量：先外部合并 envMerged，再追加映射内 env
 		envFinal := mergeEnv(envMerged, bi.Env)
-		return installFromInfo(bi, targetPath, category, envFinal, verbose)
+		// 配置中的 sha256 与 check 优先于映射定义
+		if t.SHA256 != "" || t.Check != "" {
+			info := *bi
+			info.SHA256 = firstNonEmpty(t.SHA256, bi.SHA256)
+			info.Check = firstNonEmpty(t.Check, bi.Check)
+			bi = &info
+		}
+		return installFromInfo(bi, targetPath, category, envFinal, verbose, opts)
 	}
 
 	// 未命中映射，回退到 legacy 行为（使用 configs.Tool 的字段）
-	return installFromConfigTool(t, targetPath, category, envMerged, verbose)
+	return installFromConfigTool(t, targetPath, category, envMerged, verbose, opts)
 }
 
 // mergeEnv 合并两个环境变量切片，返回新的切片（不修改原切片）
@@ -179,7 +214,7 @@ func checkPlatformCompatibility(bi *InstallToolsInfo) (bool, string) {
 }
 
 // installFromInfo 使用 InstallToolsInfo 中的信息进行安装（支持 go install 或 clone 构建）
-func installFromInfo(bi *InstallToolsInfo, targetPath, category string, env []string, verbose bool) (bool, error) {
+func installFromInfo(bi *InstallToolsInfo, targetPath, category string, env []string, verbose bool, opts BatchInstallOptions) (bool, error) {
 	// prefer URL (go install) over CloneURL
 	if strings.TrimSpace(bi.URL) != "" {
 		res, err := InstallTool(InstallOptions{
@@ -190,15 +225,20 @@ func installFromInfo(bi *InstallToolsInfo, targetPath, category string, env []st
 			ReleaseBuild: false,
 			DebugBuild:   false,
 			BinaryName:   bi.BinaryName,
+			SHA256:       bi.SHA256,
 			Tags:         bi.Tags,
+			Offline:      opts.Offline,
+			Check:        opts.check(bi.Check),
+			StrictCheck:  opts.StrictCheck,
 		})
 		PrintInstallOutput(res.Output, err, verbose)
 		if err != nil {
 			return false, err
 		}
 		if res.InstallDir != "" {
-			fmt.Printf("installed %s(go): %s -> %s\n", category, bi.URL, filepath.Clean(res.InstallDir))
+			fmt.Printf("installed %s(go): %s -> %s\n", category, bi.URL, displayPath(res.InstallDir))
 		}
+		printSmokeChecks(os.Stdout, res.Checks)
 		return true, nil
 	}
 
@@ -212,27 +252,32 @@ func installFromInfo(bi *InstallToolsInfo, targetPath, category string, env []st
 			Env:               env,
 			GoreleaserConfig:  bi.GoreleaserConfig,
 			BinaryName:        bi.BinaryName,
+			SHA256:            bi.SHA256,
 			RecurseSubmodules: false,
 			ReleaseBuild:      false,
 			DebugBuild:        false,
 			Path:              targetPath,
 			Verbose:           verbose,
 			Tags:              bi.Tags,
+			Offline:           opts.Offline,
+			Check:             opts.check(bi.Check),
+			StrictCheck:       opts.StrictCheck,
 		})
 		PrintInstallOutput(res.Output, err, verbose)
 		if err != nil {
 			return false, err
 		}
 		if res.InstallDir != "" {
-			fmt.Printf("installed %s(clone): %s -> %s\n", category, bi.CloneURL, filepath.Clean(res.InstallDir))
+			fmt.Printf("installed %s(clone): %s -> %s\n", category, bi.CloneURL, displayPath(res.InstallDir))
 		}
+		printSmokeChecks(os.Stdout, res.Checks)
 		return true, nil
 	}
 	return false, fmt.Errorf("no install method for tool %s", bi.Name)
 }
 
 // installFromConfigTool 按照旧的 configs.Tool 字段进行安装
-func installFromConfigTool(t configs.Tool, targetPath, category string, env []string, verbose bool) (bool, error) {
+func installFromConfigTool(t configs.Tool, targetPath, category string, env []string, verbose bool, opts BatchInstallOptions) (bool, error) {
 	ttype := strings.ToLower(strings.TrimSpace(t.Type))
 	switch ttype {
 	case "", "go":
@@ -255,15 +300,20 @@ func installFromConfigTool(t configs.Tool, targetPath, category string, env []st
 			ReleaseBuild: t.ReleaseBuild,
 			DebugBuild:   t.DebugBuild,
 			BinaryName:   t.BinaryName,
+			SHA256:       t.SHA256,
 			Tags:         t.Tags,
+			Offline:      opts.Offline,
+			Check:        opts.check(t.Check),
+			StrictCheck:  opts.StrictCheck,
 		})
 		PrintInstallOutput(res.Output, err, verbose)
 		if err != nil {
 			return false, err
 		}
 		if res.InstallDir != "" {
-			fmt.Printf("installed %s(go): %s -> %s\n", category, spec, filepath.Clean(res.InstallDir))
+			fmt.Printf("installed %s(go): %s -> %s\n", category, spec, displayPath(res.InstallDir))
 		}
+		printSmokeChecks(os.Stdout, res.Checks)
 		return true, nil
 
 	case "clone", "git":
@@ -279,20 +329,25 @@ func installFromConfigTool(t configs.Tool, targetPath, category string, env []st
 			Env:               env,
 			GoreleaserConfig:  t.GoreleaserConfig,
 			BinaryName:        t.BinaryName,
+			SHA256:            t.SHA256,
 			RecurseSubmodules: t.RecurseSubmodules,
 			ReleaseBuild:      t.ReleaseBuild,
 			DebugBuild:        t.DebugBuild,
 			Path:              targetPath,
 			Verbose:           verbose,
 			Tags:              t.Tags,
+			Offline:           opts.Offline,
+			Check:             opts.check(t.Check),
+			StrictCheck:       opts.StrictCheck,
 		})
 		PrintInstallOutput(res.Output, err, verbose)
 		if err != nil {
 			return false, err
 		}
 		if res.InstallDir != "" {
-			fmt.Printf("installed %s(clone): %s -> %s\n", category, t.CloneURL, filepath.Clean(res.InstallDir))
+			fmt.Printf("installed %s(clone): %s -> %s\n", category, t.CloneURL, displayPath(res.InstallDir))
 		}
+		printSmokeChecks(os.Stdout, res.Checks)
 		return true, nil
 
 	default:
@@ -306,6 +361,9 @@ func InstallConfiguredToolsFromList(list []configs.Tool, targetPath, category st
 	failed := 0
 
 	for _, t := range list {
+		if executor.Canceled() != nil {
+			break
+		}
 		ttype := strings.ToLower(strings.TrimSpace(t.Type))
 		envMerged := append([]string{}, envFlags...)
 		if len(t.Env) > 0 {
@@ -335,7 +393,9 @@ func InstallConfiguredToolsFromList(list []configs.Tool, targetPath, category st
 				ReleaseBuild: t.ReleaseBuild,
 				DebugBuild:   t.DebugBuild,
 				BinaryName:   t.BinaryName,
+				SHA256:       t.SHA256,
 				Tags:         t.Tags,
+				Check:        t.Check,
 			})
 			PrintInstallOutput(res.Output, err, verbose)
 			if err != nil {
@@ -344,8 +404,9 @@ func InstallConfiguredToolsFromList(list []configs.Tool, targetPath, category st
 			}
 			if res.InstallDir != "" {
 				// best effort log via fmt
-				fmt.Printf("installed %s(go): %s -> %s\n", category, spec, filepath.Clean(res.InstallDir))
+				fmt.Printf("installed %s(go): %s -> %s\n", category, spec, displayPath(res.InstallDir))
 			}
+			printSmokeChecks(os.Stdout, res.Checks)
 			total++
 
 		case "clone", "git":
@@ -362,12 +423,14 @@ func InstallConfiguredToolsFromList(list []configs.Tool, targetPath, category st
 				Env:               envMerged,
 				GoreleaserConfig:  t.GoreleaserConfig,
 				BinaryName:        t.BinaryName,
+				SHA256:            t.SHA256,
 				RecurseSubmodules: t.RecurseSubmodules,
 				ReleaseBuild:      t.ReleaseBuild,
 				DebugBuild:        t.DebugBuild,
 				Path:              targetPath,
 				Verbose:           verbose,
 				Tags:              t.Tags,
+				Check:             t.Check,
 			})
 			PrintInstallOutput(res.Output, err, verbose)
 			if err != nil {
@@ -375,8 +438,9 @@ func InstallConfiguredToolsFromList(list []configs.Tool, targetPath, category st
 				continue
 			}
 			if res.InstallDir != "" {
-				fmt.Printf("installed %s(clone): %s -> %s\n", category, t.CloneURL, filepath.Clean(res.InstallDir))
+				fmt.Printf("installed %s(clone): %s -> %s\n", category, t.CloneURL, displayPath(res.InstallDir))
 			}
+			printSmokeChecks(os.Stdout, res.Checks)
 			total++
 
 		default:
@@ -436,6 +500,8 @@ func PrintSingleTool(bi *InstallToolsInfo, fmtFlag string, out io.Writer) error
 			fmt.Fprintln(out, string(b))
 		}
 		return nil
+	case "toml":
+		return style.PrintTOML(out, bi)
 	case "table":
 		kv := func(k, v string) []string { return []string{k, v} }
 		rows := make([][]string, 0, 16)
@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// ToolNames 返回可用于 tools run 的工具名：已安装的工具（FindTools）与工具表中的工具（BuiltinTools，可配合 --go-run），
+// 去重（大小写不敏感）并排序
+func ToolNames(gocliToolsPath string) []string {
+	seen := map[string]bool{}
+	var names []string
+	add := func(n string) {
+		if n == "" || seen[strings.ToLower(n)] {
+			return
+		}
+		seen[strings.ToLower(n)] = true
+		names = append(names, n)
+	}
+	for _, t := range FindTools(false, gocliToolsPath) {
+		add(t.Name)
+	}
+	for k := range BuiltinTools {
+		add(k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CompleteRunArgs 是 tools run / gox 的 ValidArgsFunction 实现：
+// 工具名位置补全工具名（前缀匹配，大小写不敏感），工具名之后的参数交给 shell 默认的文件补全
+func CompleteRunArgs(gocliToolsPath string, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	// 工具名之前的 --env 等参数不影响补全位置
+	rest, err := SplitRunFlags(args, &RunOptions{})
+	if err != nil {
+		// --env/--env-allow 正在等待取值
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	if len(rest) > 0 {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	if strings.HasPrefix(toComplete, "-") {
+		var flags []string
+		for _, f := range []string{goRunFlag, envFlag, envCleanFlag, envAllowFlag} {
+			if strings.HasPrefix(f, toComplete) {
+				flags = append(flags, f)
+			}
+		}
+		return flags, cobra.ShellCompDirectiveNoFileComp
+	}
+	prefix := strings.ToLower(toComplete)
+	var out []string
+	for _, n := range ToolNames(gocliToolsPath) {
+		if strings.HasPrefix(strings.ToLower(n), prefix) {
+			out = append(out, n)
+		}
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,92 @@
+package tools
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// resolveTarget 返回安装的目标平台，未指定的一侧沿用当前平台
+func resolveTarget(targetOS, targetArch string) (goos, goarch string) {
+	goos, goarch = strings.TrimSpace(targetOS), strings.TrimSpace(targetArch)
+	if goos == "" {
+		goos = runtime.GOOS
+	}
+	if goarch == "" {
+		goarch = runtime.GOARCH
+	}
+	return goos, goarch
+}
+
+// isCrossTarget 判断目标平台是否与当前平台不同
+func isCrossTarget(targetOS, targetArch string) bool {
+	goos, goarch := resolveTarget(targetOS, targetArch)
+	return goos != runtime.GOOS || goarch != runtime.GOARCH
+}
+
+// targetEnv 返回写入构建环境的 GOOS/GOARCH；未指定目标平台时返回 nil，保持原有行为
+func targetEnv(targetOS, targetArch string) []string {
+	if strings.TrimSpace(targetOS) == "" && strings.TrimSpace(targetArch) == "" {
+		return nil
+	}
+	goos, goarch := resolveTarget(targetOS, targetArch)
+	return []string{"GOOS=" + goos, "GOARCH=" + goarch}
+}
+
+// targetBinaryName 按目标平台调整二进制名：windows 目标在缺少可执行后缀（.exe/.bat/.cmd/.ps1）时补 .exe
+func targetBinaryName(name, goos string) string {
+	if name != "" && goos == "windows" && executableExt(name) == "" {
+		return name + ".exe"
+	}
+	return name
+}
+
+// crossInstallDir 返回 go install 交叉编译的输出目录 GOPATH/bin/<goos>_<goarch>
+// （go install 在设置 GOBIN 时拒绝安装交叉编译的二进制，只能先装到这里）
+func crossInstallDir(goos, goarch string) string {
+	gopath, _ := executor.NewExecutor("go", "env", "GOPATH").ReadOnly().Output()
+	first, _, _ := strings.Cut(strings.TrimSpace(gopath), string(os.PathListSeparator))
+	if first == "" {
+		return ""
+	}
+	return filepath.Join(first, "bin", goos+"_"+goarch)
+}
+
+// withoutEnv 移除 env 中名为 key 的条目
+func withoutEnv(env []string, key string) []string {
+	out := make([]string, 0, len(env))
+	for _, kv := range env {
+		if k, _, _ := strings.Cut(kv, "="); k == key {
+			continue
+		}
+		out = append(out, kv)
+	}
+	return out
+}
+
+// moveNewExecutables 把 src 中相对快照 pre 新增或更新的 goos 可执行文件移动到 dst，返回移动后的路径
+// （按目标平台判断：例如在 windows 上交叉安装的 linux 二进制没有 .exe 后缀）
+func moveNewExecutables(goos, src string, pre map[string]time.Time, dst string) ([]string, error) {
+	var moved []string
+	for name, mt := range snapshotExecutablesFor(goos, src) {
+		if pmt, ok := pre[name]; ok && !mt.After(pmt) {
+			continue
+		}
+		from, to := filepath.Join(src, name), filepath.Join(dst, name)
+		if err := os.Rename(from, to); err != nil {
+			// 跨设备时 rename 失败，退回到复制后删除
+			if cerr := copyFile(from, to); cerr != nil {
+				return moved, fmt.Errorf("move %s -> %s failed: %w", displayPath(from), displayPath(to), cerr)
+			}
+			_ = os.Chmod(to, 0o755)
+			_ = os.Remove(from)
+		}
+		moved = append(moved, to)
+	}
+	return moved, nil
+}
@@ -0,0 +1,40 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// 测试交叉安装的移动按目标平台判断可执行文件：windows 目标看后缀，其他目标看执行权限（windows 主机上看是否无后缀）
+func TestMoveNewExecutablesForTarget(t *testing.T) {
+	cases := []struct {
+		goos  string
+		files map[string]os.FileMode
+		want  string
+	}{
+		{"windows", map[string]os.FileMode{"tool.exe": 0o644, "README": 0o755}, "tool.exe"},
+		{"linux", map[string]os.FileMode{"tool": 0o755, "notes.exe": 0o644}, "tool"},
+	}
+	for _, c := range cases {
+		src, dst := t.TempDir(), t.TempDir()
+		pre := snapshotExecutablesFor(c.goos, src)
+		for name, mode := range c.files {
+			p := filepath.Join(src, name)
+			if err := os.WriteFile(p, []byte("bin"), mode); err != nil {
+				t.Fatal(err)
+			}
+			// WriteFile 受 umask 影响，显式设置权限
+			if err := os.Chmod(p, mode); err != nil {
+				t.Fatal(err)
+			}
+		}
+		moved, err := moveNewExecutables(c.goos, src, pre, dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(moved) != 1 || moved[0] != filepath.Join(dst, c.want) {
+			t.Errorf("%s: moved %v, want only %s", c.goos, moved, c.want)
+		}
+	}
+}
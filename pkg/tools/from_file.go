@@ -0,0 +1,223 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/yeisme/gocli/pkg/configs"
+	"github.com/yeisme/gocli/pkg/style"
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// FileTool 是 tools install --from-file 清单中的一项，name/url/clone 至少给出一个：
+//   - name: 内置/用户工具表中的短名（与 gocli tools install <name> 相同）；同时给出 url/clone 时只作为显示名称
+//   - url: go install 的模块或包路径，可带 @version
+//   - clone: 以源码构建的 Git 仓库地址，可带 #ref；与 url 互斥
+//   - version: 未在 url/name 中写明版本时使用（go 工具为 @version，clone 工具为 #ref）
+type FileTool struct {
+	Name       string   `yaml:"name"`
+	URL        string   `yaml:"url"`
+	Clone      string   `yaml:"clone"`
+	Version    string   `yaml:"version"`
+	Build      string   `yaml:"build"`
+	MakeTarget string   `yaml:"make_target"`
+	WorkDir    string   `yaml:"workdir"`
+	BinDirs    []string `yaml:"bin"`
+	BinaryName string   `yaml:"binary_name"`
+	Env        []string `yaml:"env"`
+	Tags       []string `yaml:"tags"`
+}
+
+// FromFileOptions 定义 tools install --from-file 的选项
+//   - Global: 为 true 时安装到 ~/.gocli/tools，否则安装到 GoCLIToolsPath（为空时同样是 ~/.gocli/tools）
+//   - Offline: 只使用本机模块缓存安装（--offline 或配置 tools.offline）
+//   - Check / StrictCheck: --check 与 --strict-check，对清单中的每个工具生效
+type FromFileOptions struct {
+	File           string
+	Global         bool
+	GoCLIToolsPath string
+	ToolsConfigDir []string
+	Env            []string
+	Verbose        bool
+	Offline        bool
+	Check          string
+	StrictCheck    bool
+}
+
+// FromFileResult 记录清单中单个工具的安装结果
+type FromFileResult struct {
+	Name   string
+	Source string // go install 的 spec 或 clone 地址，解析失败时为空
+	Err    error
+}
+
+// ReadToolsFile 读取 YAML/JSON 清单：顶层为工具列表、tools 键下的工具列表，
+// 或 tools export 写出的 tools.deps/tools.global（module 对应 url；全部安装到同一目录）
+func ReadToolsFile(file string) ([]FileTool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read tools file %s failed: %w", file, err)
+	}
+	var list []FileTool
+	if err := yaml.Unmarshal(data, &list); err == nil {
+		return list, nil
+	}
+	var doc struct {
+		Tools yaml.Node `yaml:"tools"`
+	}
+	switch err = yaml.Unmarshal(data, &doc); {
+	case err != nil:
+	case doc.Tools.Kind == 0: // 没有 tools 键
+		return nil, nil
+	case doc.Tools.Kind == yaml.MappingNode:
+		var section struct {
+			Deps   []manifestTool `yaml:"deps"`
+			Global []manifestTool `yaml:"global"`
+		}
+		if err = doc.Tools.Decode(&section); err == nil {
+			for _, mt := range append(section.Deps, section.Global...) {
+				list = append(list, fileToolFromManifest(mt))
+			}
+			return list, nil
+		}
+	default:
+		if err = doc.Tools.Decode(&list); err == nil {
+			return list, nil
+		}
+	}
+	return nil, fmt.Errorf("parse tools file %s failed: expected a list of {name, url, clone, version} entries, a 'tools' key holding one, or a 'tools export' manifest: %w", file, err)
+}
+
+// fileToolFromManifest 把 tools export 清单中的条目转换为 --from-file 条目
+func fileToolFromManifest(mt manifestTool) FileTool {
+	return FileTool{
+		URL:        mt.Module,
+		Clone:      mt.CloneURL,
+		Build:      mt.Build,
+		MakeTarget: mt.MakeTarget,
+		WorkDir:    mt.WorkDir,
+		BinDirs:    mt.BinDirs,
+		BinaryName: mt.BinaryName,
+		Env:        mt.Env,
+		Tags:       mt.Tags,
+	}
+}
+
+// label 返回清单条目在摘要中显示的名称：name，否则取 url/clone 路径的最后一段（去掉版本与 .git）
+func (ft FileTool) label() string {
+	if name := strings.TrimSpace(ft.Name); name != "" {
+		return name
+	}
+	if url, _, _ := strings.Cut(strings.TrimSpace(ft.URL), "@"); url != "" {
+		return path.Base(url)
+	}
+	if repo, _, _ := strings.Cut(strings.TrimSpace(ft.Clone), "#"); repo != "" {
+		return strings.TrimSuffix(path.Base(strings.TrimSuffix(repo, "/")), ".git")
+	}
+	return ""
+}
+
+// toolEntry 通过 BuildToolEntry 把清单条目解析为配置条目，短名的解析规则与 tools add 相同
+func (ft FileTool) toolEntry(toolsConfigDir []string) (configs.Tool, error) {
+	url, clone, version := strings.TrimSpace(ft.URL), strings.TrimSpace(ft.Clone), strings.TrimSpace(ft.Version)
+	opts := AddOptions{
+		Build:          ft.Build,
+		MakeTarget:     ft.MakeTarget,
+		WorkDir:        ft.WorkDir,
+		BinDirs:        ft.BinDirs,
+		BinaryName:     ft.BinaryName,
+		Env:            ft.Env,
+		Tags:           ft.Tags,
+		ToolsConfigDir: toolsConfigDir,
+	}
+	switch {
+	case url != "" && clone != "":
+		return configs.Tool{}, fmt.Errorf("url and clone are mutually exclusive")
+	case clone != "":
+		if version != "" && !strings.Contains(clone, "#") {
+			clone += "#" + version
+		}
+		opts.CloneURL = clone
+	case url != "" || strings.TrimSpace(ft.Name) != "":
+		spec := firstNonEmpty(url, strings.TrimSpace(ft.Name))
+		if version != "" && !strings.Contains(spec, "@") {
+			spec += "@" + version
+		}
+		opts.Spec = spec
+	default:
+		return configs.Tool{}, fmt.Errorf("one of name, url or clone is required")
+	}
+	return BuildToolEntry(opts)
+}
+
+// InstallFromFile 读取清单并逐个通过 installFromConfigTool 安装（条目已由 toolEntry 解析），某个工具失败不影响其余工具；
+// 返回每个工具的结果，清单无法读取时返回错误
+func InstallFromFile(opts FromFileOptions) ([]FromFileResult, error) {
+	list, err := ReadToolsFile(opts.File)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range opts.ToolsConfigDir {
+		_ = LoadUserTools(p)
+	}
+	target := opts.GoCLIToolsPath
+	if opts.Global || strings.TrimSpace(target) == "" {
+		target = filepath.Join(mustUserHome(), ".gocli", "tools")
+	}
+
+	batch := BatchInstallOptions{Check: opts.Check, StrictCheck: opts.StrictCheck, Offline: opts.Offline}
+	results := make([]FromFileResult, 0, len(list))
+	for i, ft := range list {
+		if err := executor.Canceled(); err != nil {
+			return results, err
+		}
+		r := FromFileResult{Name: ft.label()}
+		if r.Name == "" {
+			r.Name = fmt.Sprintf("#%d", i+1)
+		}
+		tool, err := ft.toolEntry(opts.ToolsConfigDir)
+		if err != nil {
+			r.Err = err
+			results = append(results, r)
+			continue
+		}
+		r.Source = firstNonEmpty(tool.Module, tool.CloneURL)
+		if _, err := installFromConfigTool(tool, target, "file", mergeEnv(opts.Env, tool.Env), opts.Verbose, batch); err != nil {
+			r.Err = err
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// PrintFromFileSummary 以表格输出每个工具的安装结果，最后一行为统计；存在失败时返回错误
+func PrintFromFileSummary(out io.Writer, file string, results []FromFileResult) error {
+	if len(results) == 0 {
+		fmt.Fprintf(out, "no tools found in %s\n", file)
+		return nil
+	}
+	failed := 0
+	rows := make([][]string, 0, len(results))
+	for _, r := range results {
+		status := "installed"
+		if r.Err != nil {
+			failed++
+			status = "failed: " + r.Err.Error()
+		}
+		rows = append(rows, []string{r.Name, r.Source, status})
+	}
+	if err := style.PrintTableWithOptions(out, []string{"Tool", "Source", "Result"}, rows, style.TableOptions{Wrap: true}); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%d installed, %d failed (from %s)\n", len(results)-failed, failed, file)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d tool(s) failed", failed, len(results))
+	}
+	return nil
+}
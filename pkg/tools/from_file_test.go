@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// 测试 --from-file：YAML 与 JSON 清单都能解析，短名/模块/clone 条目逐个安装，无效条目单独记为失败
+func TestInstallFromFile(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("HOME", root)
+	saved := BuiltinTools
+	BuiltinTools = map[string]InstallToolsInfo{
+		"alpha": {Name: "alpha", URL: "example.com/alpha/cmd/alpha@latest"},
+	}
+	t.Cleanup(func() { BuiltinTools = saved })
+
+	yamlFile := filepath.Join(root, "tools.yaml")
+	jsonFile := filepath.Join(root, "tools.json")
+	files := map[string]string{
+		yamlFile: `tools:
+  - name: alpha
+    version: v1.2.0
+  - url: example.com/beta/cmd/beta
+    version: v0.3.0
+  - clone: https://example.com/gamma.git
+    version: v2.0.0
+  - name: missing
+  - version: v1
+`,
+		jsonFile: `[{"name": "alpha"}, {"url": "example.com/beta/cmd/beta@v0.1.0", "clone": "https://example.com/beta.git"}]`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rec := executor.StartRecording()
+	defer executor.StopRecording()
+	bin := filepath.Join(root, "bin")
+	results, err := InstallFromFile(FromFileOptions{File: yamlFile, GoCLIToolsPath: bin})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct{ name, source string }{
+		{"alpha", "example.com/alpha/cmd/alpha@v1.2.0"},
+		{"beta", "example.com/beta/cmd/beta@v0.3.0"},
+		{"gamma", "https://example.com/gamma.git#v2.0.0"},
+		{"missing", ""},
+		{"#5", ""},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("results = %+v", results)
+	}
+	for i, w := range want {
+		r := results[i]
+		if r.Name != w.name || r.Source != w.source || (r.Err != nil) != (w.source == "") {
+			t.Errorf("results[%d] = %+v, want name %q source %q", i, r, w.name, w.source)
+		}
+	}
+	var installs []string
+	for _, r := range rec.Records() {
+		if r.Name == "go" && len(r.Args) > 0 && r.Args[0] == "install" {
+			installs = append(installs, r.String())
+		}
+	}
+	if len(installs) != 2 || !strings.Contains(installs[0], "example.com/alpha/cmd/alpha@v1.2.0") || !strings.Contains(installs[1], "GOBIN="+bin) {
+		t.Errorf("unexpected installs:\n%s", strings.Join(installs, "\n"))
+	}
+
+	var out strings.Builder
+	if err := PrintFromFileSummary(&out, yamlFile, results); err == nil || !strings.Contains(out.String(), "3 installed, 2 failed") {
+		t.Errorf("unexpected summary (err %v):\n%s", err, out.String())
+	}
+
+	// JSON 顶层列表；url 与 clone 同时给出时报错
+	results, err = InstallFromFile(FromFileOptions{File: jsonFile, Global: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Err != nil || results[1].Err == nil || !strings.Contains(results[1].Err.Error(), "mutually exclusive") {
+		t.Errorf("unexpected JSON results: %+v", results)
+	}
+}
+
+// 测试 --from-file --offline：清单中的 go install 使用模块缓存代理与 -mod=mod，不访问校验和数据库
+func TestInstallFromFileOffline(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+	root := t.TempDir()
+	t.Setenv("HOME", root)
+	file := filepath.Join(root, "tools.yaml")
+	if err := os.WriteFile(file, []byte("- url: example.com/beta/cmd/beta@v0.3.0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := executor.StartRecording()
+	defer executor.StopRecording()
+	results, err := InstallFromFile(FromFileOptions{File: file, GoCLIToolsPath: filepath.Join(root, "bin"), Offline: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Err != nil {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	var install string
+	for _, r := range rec.Records() {
+		if r.Name == "go" && len(r.Args) > 0 && r.Args[0] == "install" {
+			install = r.String()
+		}
+	}
+	for _, want := range []string{"GOPROXY=file://", "GOSUMDB=off", "GOFLAGS=-mod=mod", "example.com/beta/cmd/beta@v0.3.0"} {
+		if !strings.Contains(install, want) {
+			t.Errorf("offline install missing %q:\n%s", want, install)
+		}
+	}
+}
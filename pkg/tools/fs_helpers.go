@@ -2,6 +2,7 @@ package tools
 
 import (
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -120,34 +121,128 @@ func copyFile(src, dst string) error {
 	return out.Chmod(0o644)
 }
 
+// windowsExecExts 是 Windows 上视为可执行文件的扩展名
+var windowsExecExts = []string{".exe", ".bat", ".cmd", ".ps1"}
+
+// executableExt returns the Windows executable extension of name as written (e.g. ".EXE"), or ""
+func executableExt(name string) string {
+	lower := strings.ToLower(name)
+	for _, ext := range windowsExecExts {
+		if strings.HasSuffix(lower, ext) && len(name) > len(ext) {
+			return name[len(name)-len(ext):]
+		}
+	}
+	return ""
+}
+
+// isExecutableMode reports whether a regular file is executable on goos:
+// by extension on windows, by any execute permission bit elsewhere
+func isExecutableMode(goos, name string, mode fs.FileMode) bool {
+	if !mode.IsRegular() {
+		return false
+	}
+	if goos == "windows" {
+		return executableExt(name) != ""
+	}
+	return mode&0o111 != 0
+}
+
 // isExecutable checks if filename is executable in dir (platform specific)
 func isExecutable(name, dir string) bool {
-	if runtime.GOOS == "windows" {
-		lower := strings.ToLower(name)
-		return strings.HasSuffix(lower, ".exe") || strings.HasSuffix(lower, ".bat") || strings.HasSuffix(lower, ".cmd") || strings.HasSuffix(lower, ".ps1")
-	}
+	return isExecutableFor(runtime.GOOS, name, dir)
+}
+
+// isExecutableFor checks if filename in dir is an executable for goos; used for build artifacts of a cross target
+func isExecutableFor(goos, name, dir string) bool {
 	info, err := os.Stat(filepath.Join(dir, name))
 	if err != nil {
 		return false
 	}
-	mode := info.Mode()
-	return mode&0o111 != 0
+	// windows has no execute permission bits: binaries of other targets built there are extensionless regular files
+	if runtime.GOOS == "windows" && goos != "windows" {
+		return info.Mode().IsRegular() && filepath.Ext(name) == ""
+	}
+	return isExecutableMode(goos, name, info.Mode())
 }
 
 func stripExeSuffix(name string) string {
-	if runtime.GOOS == "windows" {
-		lower := strings.ToLower(name)
-		for _, ext := range []string{".exe", ".bat", ".cmd", ".ps1"} {
-			if strings.HasSuffix(lower, ext) {
-				return name[:len(name)-len(ext)]
+	return stripExeSuffixFor(runtime.GOOS, name)
+}
+
+// stripExeSuffixFor removes the executable extension on windows (tool.exe -> tool)
+func stripExeSuffixFor(goos, name string) string {
+	if goos != "windows" {
+		return name
+	}
+	return name[:len(name)-len(executableExt(name))]
+}
+
+// renamedBinaryName returns the final name when renaming oldName to newName on goos:
+// on windows a newName without an executable extension keeps the extension of oldName (default .exe)
+func renamedBinaryName(goos, oldName, newName string) string {
+	if goos != "windows" || newName == "" || executableExt(newName) != "" {
+		return newName
+	}
+	if ext := executableExt(oldName); ext != "" {
+		return newName + strings.ToLower(ext)
+	}
+	return newName + ".exe"
+}
+
+// renameAttempts / renameRetryDelay 控制 replaceFile 的重试：Windows 上杀毒软件等可能短暂锁定刚写入的文件
+var (
+	renameAttempts   = 5
+	renameRetryDelay = 100 * time.Millisecond
+)
+
+// replaceFile renames src to dst, replacing an existing dst. os.Rename does not overwrite on windows,
+// so dst is removed first; failures (e.g. files locked by antivirus scanners) are retried with a growing delay
+func replaceFile(src, dst string) error {
+	if src == dst {
+		return nil
+	}
+	// 仅大小写不同（Windows 上是同一个文件）时不能先删除目标
+	sameFile := runtime.GOOS == "windows" && strings.EqualFold(src, dst)
+	var err error
+	for attempt := range renameAttempts {
+		if attempt > 0 {
+			time.Sleep(renameRetryDelay * time.Duration(attempt))
+		}
+		if err = os.Rename(src, dst); err == nil {
+			return nil
+		}
+		if _, statErr := os.Stat(src); os.IsNotExist(statErr) {
+			return err
+		}
+		if !sameFile {
+			if rmErr := os.Remove(dst); rmErr != nil && !os.IsNotExist(rmErr) {
+				err = rmErr
+				continue
+			}
+			if err = os.Rename(src, dst); err == nil {
+				return nil
 			}
 		}
 	}
-	return name
+	return err
+}
+
+// displayPath cleans p for user-facing output so it uses the platform separator consistently
+// (e.g. C:/Users/me/go\bin -> C:\Users\me\go\bin on windows); empty paths stay empty
+func displayPath(p string) string {
+	if p == "" {
+		return ""
+	}
+	return filepath.Clean(p)
 }
 
 // SnapshotExecutables quick snapshot of executable files modification time
 func SnapshotExecutables(dir string) map[string]time.Time {
+	return snapshotExecutablesFor(runtime.GOOS, dir)
+}
+
+// snapshotExecutablesFor snapshots the files of dir that are executables for goos; used for cross install output
+func snapshotExecutablesFor(goos, dir string) map[string]time.Time {
 	m := make(map[string]time.Time)
 	entries, err := os.ReadDir(dir)
 	if err != nil {
@@ -158,7 +253,7 @@ func SnapshotExecutables(dir string) map[string]time.Time {
 			continue
 		}
 		name := e.Name()
-		if !isExecutable(name, dir) {
+		if !isExecutableFor(goos, name, dir) {
 			continue
 		}
 		if fi, err := e.Info(); err == nil {
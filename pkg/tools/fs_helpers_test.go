@@ -0,0 +1,106 @@
+package tools
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// 测试按目标平台识别可执行文件：windows 看扩展名，其他平台看可执行位，目录永远不是可执行文件
+func TestIsExecutableMode(t *testing.T) {
+	cases := []struct {
+		goos string
+		name string
+		mode fs.FileMode
+		want bool
+	}{
+		{"windows", "tool.exe", 0o644, true},
+		{"windows", "TOOL.EXE", 0o644, true},
+		{"windows", "run.bat", 0o644, true},
+		{"windows", "run.Cmd", 0o644, true},
+		{"windows", "setup.ps1", 0o644, true},
+		{"windows", "tool", 0o755, false},
+		{"windows", ".exe", 0o644, false},
+		{"windows", "dist.exe", fs.ModeDir | 0o755, false},
+		{"linux", "tool", 0o755, true},
+		{"linux", "tool", 0o744, true},
+		{"linux", "tool.exe", 0o644, false},
+		{"darwin", "tool", 0o644, false},
+		{"linux", "bin", fs.ModeDir | 0o755, false},
+	}
+	for _, c := range cases {
+		if got := isExecutableMode(c.goos, c.name, c.mode); got != c.want {
+			t.Errorf("isExecutableMode(%q, %q, %v) = %v, want %v", c.goos, c.name, c.mode, got, c.want)
+		}
+	}
+}
+
+// 测试去除可执行后缀与改名时的后缀处理
+func TestExeSuffixHelpers(t *testing.T) {
+	strip := []struct{ goos, in, want string }{
+		{"windows", "tool.exe", "tool"},
+		{"windows", "Tool.EXE", "Tool"},
+		{"windows", "make.cmd", "make"},
+		{"windows", "tool", "tool"},
+		{"windows", "archive.tar.gz", "archive.tar.gz"},
+		{"linux", "tool.exe", "tool.exe"},
+	}
+	for _, c := range strip {
+		if got := stripExeSuffixFor(c.goos, c.in); got != c.want {
+			t.Errorf("stripExeSuffixFor(%q, %q) = %q, want %q", c.goos, c.in, got, c.want)
+		}
+	}
+
+	rename := []struct{ goos, oldName, newName, want string }{
+		{"windows", "golangci-lint.exe", "lint", "lint.exe"},
+		{"windows", "GOLANGCI.EXE", "lint", "lint.exe"},
+		{"windows", "run.cmd", "lint", "lint.cmd"},
+		{"windows", "tool", "lint", "lint.exe"},
+		{"windows", "tool.exe", "lint.bat", "lint.bat"},
+		{"linux", "tool", "lint", "lint"},
+		{"linux", "tool.exe", "lint", "lint"},
+	}
+	for _, c := range rename {
+		if got := renamedBinaryName(c.goos, c.oldName, c.newName); got != c.want {
+			t.Errorf("renamedBinaryName(%q, %q, %q) = %q, want %q", c.goos, c.oldName, c.newName, got, c.want)
+		}
+	}
+	if got := targetBinaryName("make.cmd", "windows"); got != "make.cmd" {
+		t.Errorf("targetBinaryName keeps existing executable extensions, got %q", got)
+	}
+	if got := displayPath(""); got != "" {
+		t.Errorf("displayPath(\"\") = %q, want empty", got)
+	}
+	if got, want := displayPath("a/b/../c/"), filepath.Join("a", "c"); got != want {
+		t.Errorf("displayPath = %q, want %q", got, want)
+	}
+}
+
+// 测试 replaceFile 覆盖已存在的目标文件
+func TestReplaceFile_Overwrite(t *testing.T) {
+	dir := t.TempDir()
+	src, dst := filepath.Join(dir, "new"), filepath.Join(dir, "old")
+	if err := os.WriteFile(src, []byte("new"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dst, []byte("old"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := replaceFile(src, dst); err != nil {
+		t.Fatalf("replaceFile: %v", err)
+	}
+	if data, _ := os.ReadFile(dst); string(data) != "new" {
+		t.Errorf("dst content = %q, want new", data)
+	}
+	if _, err := os.Stat(src); !os.IsNotExist(err) {
+		t.Errorf("src should be gone, stat err = %v", err)
+	}
+	// 源文件不存在时立即返回错误，不会删除目标
+	if err := replaceFile(src, dst); err == nil {
+		t.Error("missing src should fail")
+	}
+	if _, err := os.Stat(dst); err != nil {
+		t.Errorf("dst should be kept when src is missing: %v", err)
+	}
+}
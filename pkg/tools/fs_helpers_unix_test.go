@@ -0,0 +1,41 @@
+//go:build !windows
+
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// 测试 Unix 上按可执行位识别文件，并在交叉构建 windows 时按扩展名收集 .exe 产物且保留可执行位
+func TestCollectAndCopyBins_Unix(t *testing.T) {
+	repo, dest := t.TempDir(), t.TempDir()
+	bin := filepath.Join(repo, "bin")
+	if err := os.MkdirAll(bin, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]os.FileMode{"tool": 0o755, "README": 0o644, "tool.exe": 0o644}
+	for name, mode := range files {
+		if err := os.WriteFile(filepath.Join(bin, name), []byte(name), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !isExecutable("tool", bin) || isExecutable("README", bin) || isExecutable("tool.exe", bin) {
+		t.Fatal("executables should be detected by mode bits")
+	}
+
+	if _, err := collectAndCopyBins([]string{"bin"}, repo, []string{"GOOS=windows"}, dest, false, "lint"); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(filepath.Join(dest, "lint.exe"))
+	if err != nil {
+		t.Fatalf("windows artifact should be copied and renamed to lint.exe: %v", err)
+	}
+	if fi.Mode()&0o111 == 0 {
+		t.Errorf("copied artifact should be executable, mode %v", fi.Mode())
+	}
+	if _, err := os.Stat(filepath.Join(dest, "README")); !os.IsNotExist(err) {
+		t.Error("non-executable files should not be copied")
+	}
+}
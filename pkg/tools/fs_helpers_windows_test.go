@@ -0,0 +1,45 @@
+//go:build windows
+
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// 测试 Windows 上按扩展名识别可执行文件，并在改名时覆盖已存在的同名二进制
+func TestRenameInstalledBinary_Windows(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"run.cmd", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !isExecutable("run.cmd", dir) || isExecutable("notes.txt", dir) {
+		t.Fatal("executables should be detected by extension")
+	}
+
+	pre := SnapshotExecutables(dir)
+	if err := os.WriteFile(filepath.Join(dir, "golangci-lint.exe"), []byte("new"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "lint.exe"), []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	pre["lint.exe"] = SnapshotExecutables(dir)["lint.exe"]
+	if err := RenameInstalledBinary(dir, pre, "lint", false); err != nil {
+		t.Fatalf("rename over an existing binary: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "lint.exe")); string(data) != "new" {
+		t.Errorf("lint.exe = %q, want the newly installed binary", data)
+	}
+
+	// 仅大小写不同的改名不能删除源文件
+	if err := replaceFile(filepath.Join(dir, "lint.exe"), filepath.Join(dir, "Lint.exe")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "Lint.exe")); err != nil {
+		t.Errorf("case-only rename lost the file: %v", err)
+	}
+}
@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-
-	"github.com/yeisme/gocli/pkg/utils/executor"
 )
 
 // goInstallWithEnv 支持传入额外环境变量（如 GOBIN）
@@ -20,7 +18,7 @@ func goInstallWithEnv(spec string, env []string, verbose bool, buildArgs []strin
 		args = append(args, buildArgs...)
 	}
 	args = append(args, spec)
-	ex := executor.NewExecutor("go", args...)
+	ex := toolExecutor("go", args...)
 	if len(env) > 0 {
 		ex = ex.WithEnv(env...)
 	}
@@ -46,7 +44,7 @@ func InstallGoTool(spec, installDir string, env []string, verbose bool, buildArg
 	if installDir != "" {
 		p := expandPath(installDir)
 		if err := os.MkdirAll(p, 0o755); err != nil {
-			return "", "", fmt.Errorf("create install dir %s failed: %w", p, err)
+			return "", "", fmt.Errorf("create install dir %s failed: %w", displayPath(p), err)
 		}
 		abs, _ := filepath.Abs(p)
 		finalDir = abs
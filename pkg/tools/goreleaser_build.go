@@ -5,8 +5,6 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-
-	"github.com/yeisme/gocli/pkg/utils/executor"
 )
 
 // runGoreleaserWithContext 执行 goreleaser build，并在 verbose 模式下打印上下文
@@ -18,14 +16,14 @@ func runGoreleaserWithContext(dir string, env []string, verbose bool, absBase, r
 	if len(extraArgs) > 0 {
 		args = append(args, extraArgs...)
 	}
-	out, err := executor.NewExecutor("goreleaser", args...).WithDir(dir).WithEnv(env...).CombinedOutput()
+	out, err := toolExecutor("goreleaser", args...).WithDir(dir).WithEnv(env...).CombinedOutput()
 	if err != nil {
 		return out, fmt.Errorf("goreleaser build failed: %w", err)
 	}
 	if verbose {
 		b := &strings.Builder{}
-		fmt.Fprintf(b, "\n[goreleaser] dir: %s\n", dir)
-		fmt.Fprintf(b, "[goreleaser] base dir: %s\n", absBase)
+		fmt.Fprintf(b, "\n[goreleaser] dir: %s\n", displayPath(dir))
+		fmt.Fprintf(b, "[goreleaser] base dir: %s\n", displayPath(absBase))
 		fmt.Fprintf(b, "[goreleaser] repo: %s\n", repoURL)
 		if resolvedRef != "" {
 			if displayRef == "latest" && resolvedRef != "" {
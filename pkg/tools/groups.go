@@ -0,0 +1,204 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/yeisme/gocli/pkg/configs"
+	"github.com/yeisme/gocli/pkg/style"
+)
+
+// GroupMember 是所选分组中的一个工具
+type GroupMember struct {
+	Name   string   `json:"name"`
+	Groups []string `json:"groups"`
+	// Binary 预期安装的二进制名，用于判断是否已安装
+	Binary string `json:"binary"`
+	// Configured 为 true 表示来自 tools.deps/tools.global，否则来自内置/用户工具表
+	Configured bool   `json:"configured"`
+	Installed  bool   `json:"installed"`
+	Path       string `json:"path,omitempty"`
+
+	tool   configs.Tool
+	global bool
+}
+
+// ToolGroupSelection 是 --group 选中的工具：所选分组成员的并集，按首次出现的顺序排列
+type ToolGroupSelection struct {
+	Members []GroupMember
+}
+
+// ResolveToolGroups 解析 tools.groups 中的分组并取成员的并集。成员名按 tools install <name> 的规则解析：
+// 先用与批量安装相同的候选名（模块路径、模块最后一段、cmd、clone 地址及其在工具表中的名称）匹配
+// tools.deps/tools.global 中的条目，找不到时在内置/用户工具表中查找（SearchTools）。
+// 未知分组返回列出可用分组的错误，无法解析的成员同样返回错误
+func ResolveToolGroups(cfg configs.ToolsConfig, groups []string) (*ToolGroupSelection, error) {
+	available := make([]string, 0, len(cfg.Groups))
+	for name := range cfg.Groups {
+		available = append(available, name)
+	}
+	sort.Strings(available)
+	for _, g := range groups {
+		if _, ok := cfg.Groups[g]; !ok {
+			if len(available) == 0 {
+				return nil, fmt.Errorf("unknown tool group %q: no groups defined in tools.groups", g)
+			}
+			return nil, fmt.Errorf("unknown tool group %q (available: %s)", g, strings.Join(available, ", "))
+		}
+	}
+	for _, p := range cfg.ToolsConfigDir {
+		_ = LoadUserTools(p)
+	}
+
+	sel := &ToolGroupSelection{}
+	index := map[string]int{}
+	for _, g := range groups {
+		for _, name := range cfg.Groups[g] {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if i, ok := index[name]; ok {
+				if !slices.Contains(sel.Members[i].Groups, g) {
+					sel.Members[i].Groups = append(sel.Members[i].Groups, g)
+				}
+				continue
+			}
+			m, err := resolveGroupMember(cfg, name)
+			if err != nil {
+				return nil, fmt.Errorf("tool group %q: %w", g, err)
+			}
+			m.Groups = []string{g}
+			index[name] = len(sel.Members)
+			sel.Members = append(sel.Members, m)
+		}
+	}
+	return sel, nil
+}
+
+// resolveGroupMember 在配置的工具中查找 name，找不到时回退到工具表
+func resolveGroupMember(cfg configs.ToolsConfig, name string) (GroupMember, error) {
+	for _, list := range []struct {
+		tools  []configs.Tool
+		global bool
+	}{{cfg.Deps, false}, {cfg.Global, true}} {
+		for _, t := range list.tools {
+			if info, ok := configuredToolMatches(t, name, cfg.ToolsConfigDir); ok {
+				return GroupMember{Name: name, Binary: groupMemberBinary(name, t, info), Configured: true, tool: t, global: list.global}, nil
+			}
+		}
+	}
+	if info := SearchTools(name, cfg.ToolsConfigDir); info != nil {
+		t, _ := toolFromInfo(name, info)
+		return GroupMember{Name: name, Binary: groupMemberBinary(name, t, info), tool: t}, nil
+	}
+	return GroupMember{}, fmt.Errorf("unknown tool %q: not in tools.deps/tools.global nor in the tools table", name)
+}
+
+// configuredToolMatches 报告配置条目 t 是否对应工具名 name，并返回其在工具表中解析到的条目（可能为 nil）
+func configuredToolMatches(t configs.Tool, name string, configDirs []string) (*InstallToolsInfo, bool) {
+	candidates := buildCandidatesFromTool(t)
+	info := resolveInstallInfo(candidates, configDirs)
+	if info != nil && (info.Name == name || info.BinaryName == name) {
+		return info, true
+	}
+	for _, c := range candidates {
+		if mod, _, _ := strings.Cut(c, "@"); c == name || mod == name {
+			return info, true
+		}
+	}
+	if t.BinaryName != "" && t.BinaryName == name {
+		return info, true
+	}
+	return nil, false
+}
+
+// groupMemberBinary 推断成员安装后的二进制名：显式的 binary_name 优先，其次为模块路径的最后一段（跳过 /vN），最后为成员名
+func groupMemberBinary(name string, t configs.Tool, info *InstallToolsInfo) string {
+	if t.BinaryName != "" {
+		return t.BinaryName
+	}
+	if info != nil && info.BinaryName != "" {
+		return info.BinaryName
+	}
+	mod := t.Module
+	if mod == "" && info != nil {
+		mod = info.URL
+	}
+	if mod, _, _ = strings.Cut(mod, "@"); mod != "" {
+		base := path.Base(mod)
+		if len(base) > 1 && base[0] == 'v' && strings.Trim(base[1:], "0123456789") == "" {
+			base = path.Base(path.Dir(mod))
+		}
+		return base
+	}
+	return name
+}
+
+// Config 返回只包含所选工具的配置副本，供 BatchInstallConfiguredTools 等批量安装使用；
+// 来自工具表的成员归入 deps
+func (s *ToolGroupSelection) Config(cfg *configs.Config) *configs.Config {
+	c := *cfg
+	c.Tools.Deps, c.Tools.Global = nil, nil
+	for _, m := range s.Members {
+		if m.global {
+			c.Tools.Global = append(c.Tools.Global, m.tool)
+		} else {
+			c.Tools.Deps = append(c.Tools.Deps, m.tool)
+		}
+	}
+	return &c
+}
+
+// MarkInstalled 根据已安装的工具（FindTools 的结果）标记成员是否已安装
+func (s *ToolGroupSelection) MarkInstalled(installed []ToolInfo) {
+	byName := map[string]ToolInfo{}
+	for _, ti := range installed {
+		byName[ti.Name] = ti
+	}
+	for i := range s.Members {
+		m := &s.Members[i]
+		ti, ok := byName[m.Binary]
+		if !ok {
+			ti, ok = byName[m.Binary+".exe"]
+		}
+		m.Installed, m.Path = ok, ti.Path
+	}
+}
+
+// Missing 返回尚未安装的成员名
+func (s *ToolGroupSelection) Missing() []string {
+	var out []string
+	for _, m := range s.Members {
+		if !m.Installed {
+			out = append(out, m.Name)
+		}
+	}
+	return out
+}
+
+// PrintGroupTable 以表格输出分组成员的安装状态，最后一行汇总缺失的成员
+func PrintGroupTable(w io.Writer, s *ToolGroupSelection) error {
+	rows := make([][]string, 0, len(s.Members))
+	for _, m := range s.Members {
+		status := "missing"
+		if m.Installed {
+			status = "installed"
+		}
+		rows = append(rows, []string{m.Name, strings.Join(m.Groups, ","), status, m.Path})
+	}
+	if err := style.PrintTable(w, []string{"name", "groups", "status", "path"}, rows, 0); err != nil {
+		return fmt.Errorf("failed to print tool groups in table format: %w", err)
+	}
+	missing := s.Missing()
+	fmt.Fprintf(w, "%d of %d group member(s) installed", len(s.Members)-len(missing), len(s.Members))
+	if len(missing) > 0 {
+		fmt.Fprintf(w, "; missing: %s", strings.Join(missing, ", "))
+	}
+	fmt.Fprintln(w)
+	return nil
+}
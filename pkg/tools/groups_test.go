@@ -0,0 +1,70 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yeisme/gocli/pkg/configs"
+)
+
+// 测试 --group：成员按配置条目与工具表解析，多个分组取并集，未知分组与成员报错，并报告缺失的成员
+func TestResolveToolGroups(t *testing.T) {
+	saved := BuiltinTools
+	BuiltinTools = map[string]InstallToolsInfo{
+		"golangci-lint": {Name: "golangci-lint", URL: "github.com/golangci/golangci-lint/v2/cmd/golangci-lint"},
+		"buf":           {Name: "buf", URL: "github.com/bufbuild/buf/cmd/buf"},
+	}
+	t.Cleanup(func() { BuiltinTools = saved })
+
+	cfg := configs.ToolsConfig{
+		Deps: []configs.Tool{
+			{Type: "go", Module: "github.com/golangci/golangci-lint/v2/cmd/golangci-lint@v2.1.0"},
+			{Type: "go", Module: "honnef.co/go/tools/cmd/staticcheck@latest"},
+			{Type: "go", Module: "example.com/unrelated@latest"},
+		},
+		Global: []configs.Tool{{Type: "go", Module: "google.golang.org/protobuf/cmd/protoc-gen-go@v1.34.0"}},
+		Groups: map[string][]string{
+			"lint":  {"golangci-lint", "staticcheck"},
+			"proto": {"buf", "protoc-gen-go", "staticcheck"},
+			"bad":   {"nope"},
+		},
+	}
+
+	sel, err := ResolveToolGroups(cfg, []string{"lint", "proto"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, m := range sel.Members {
+		names = append(names, m.Name+"="+m.Binary+"/"+strings.Join(m.Groups, "+"))
+	}
+	want := "golangci-lint=golangci-lint/lint staticcheck=staticcheck/lint+proto buf=buf/proto protoc-gen-go=protoc-gen-go/proto"
+	if got := strings.Join(names, " "); got != want {
+		t.Errorf("members:\n got %s\nwant %s", got, want)
+	}
+
+	// 并集写回配置：配置条目保持原来的 deps/global 归属，工具表中的成员归入 deps，无关条目被排除
+	c := sel.Config(&configs.Config{Tools: cfg})
+	if len(c.Tools.Deps) != 3 || c.Tools.Deps[0].Module != cfg.Deps[0].Module || c.Tools.Deps[2].Module != "github.com/bufbuild/buf/cmd/buf@latest" {
+		t.Errorf("deps = %+v", c.Tools.Deps)
+	}
+	if len(c.Tools.Global) != 1 || c.Tools.Global[0].Module != cfg.Global[0].Module {
+		t.Errorf("global = %+v", c.Tools.Global)
+	}
+
+	sel.MarkInstalled([]ToolInfo{{Name: "golangci-lint", Path: "/bin/golangci-lint"}, {Name: "buf", Path: "/bin/buf"}})
+	if missing := sel.Missing(); strings.Join(missing, ",") != "staticcheck,protoc-gen-go" {
+		t.Errorf("missing = %v", missing)
+	}
+	var out strings.Builder
+	if err := PrintGroupTable(&out, sel); err != nil || !strings.Contains(out.String(), "2 of 4 group member(s) installed; missing: staticcheck, protoc-gen-go") {
+		t.Errorf("unexpected table (err %v):\n%s", err, out.String())
+	}
+
+	if _, err := ResolveToolGroups(cfg, []string{"lnt"}); err == nil || !strings.Contains(err.Error(), "available: bad, lint, proto") {
+		t.Errorf("unknown group error = %v", err)
+	}
+	if _, err := ResolveToolGroups(cfg, []string{"bad"}); err == nil || !strings.Contains(err.Error(), `unknown tool "nope"`) {
+		t.Errorf("unknown member error = %v", err)
+	}
+}
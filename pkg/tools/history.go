@@ -0,0 +1,220 @@
+package tools
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/style"
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// HistoryMaxSize 是历史文件轮转前的最大字节数，超过后当前文件重命名为 history.jsonl.1
+const HistoryMaxSize int64 = 1 << 20
+
+// HistoryRecord 是 tools run 的一次执行记录，以单行 JSON 追加到历史文件
+type HistoryRecord struct {
+	Time       time.Time `json:"time"`
+	Tool       string    `json:"tool"`
+	Path       string    `json:"path"`
+	Args       []string  `json:"args"`
+	ExitCode   int       `json:"exit_code"`
+	DurationMs int64     `json:"duration_ms"`
+	Cwd        string    `json:"cwd"`
+}
+
+// ToolStats 汇总单个工具的执行次数与失败率
+type ToolStats struct {
+	Tool        string    `json:"tool"`
+	Runs        int       `json:"runs"`
+	Failures    int       `json:"failures"`
+	FailureRate float64   `json:"failure_rate"`
+	LastRun     time.Time `json:"last_run"`
+}
+
+// DefaultHistoryFile 返回默认的历史文件路径 ~/.gocli/history.jsonl
+func DefaultHistoryFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".gocli", "history.jsonl")
+}
+
+// AppendHistory 以单次 O_APPEND 写入追加一条记录，多个 gocli 进程并发写入时记录不会交错
+// 写入前文件大小加上本条记录超过 maxSize（>0）时，先将当前文件轮转为 <file>.1（覆盖旧的 .1）
+func AppendHistory(file string, rec HistoryRecord, maxSize int64) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+	if maxSize > 0 {
+		if fi, err := os.Stat(file); err == nil && fi.Size()+int64(len(line)) > maxSize {
+			// 并发进程可能同时轮转，失败时继续追加到现有文件
+			_ = os.Rename(file, file+".1")
+		}
+	}
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(line); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReadHistory 按时间顺序读取轮转文件与当前文件中的记录，跳过无法解析的行；文件不存在时返回空列表
+func ReadHistory(file string) ([]HistoryRecord, error) {
+	var records []HistoryRecord
+	for _, p := range []string{file + ".1", file} {
+		f, err := os.Open(p)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		recs, err := decodeHistory(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read %s failed: %w", p, err)
+		}
+		records = append(records, recs...)
+	}
+	return records, nil
+}
+
+func decodeHistory(r io.Reader) ([]HistoryRecord, error) {
+	var records []HistoryRecord
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for sc.Scan() {
+		var rec HistoryRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			continue
+		}
+		records = append(records, rec)
+	}
+	return records, sc.Err()
+}
+
+// FilterHistory 返回最近的 limit 条记录（limit<=0 不限制），tool 非空时只保留该工具的记录；结果按时间从新到旧排列
+func FilterHistory(records []HistoryRecord, tool string, limit int) []HistoryRecord {
+	out := []HistoryRecord{}
+	for i := len(records) - 1; i >= 0; i-- {
+		if tool != "" && !strings.EqualFold(records[i].Tool, tool) {
+			continue
+		}
+		out = append(out, records[i])
+		if limit > 0 && len(out) == limit {
+			break
+		}
+	}
+	return out
+}
+
+// HistoryStats 按工具汇总执行次数、失败次数（退出码非 0）与失败率，按执行次数从多到少排序
+func HistoryStats(records []HistoryRecord) []ToolStats {
+	byTool := map[string]*ToolStats{}
+	for _, r := range records {
+		s, ok := byTool[r.Tool]
+		if !ok {
+			s = &ToolStats{Tool: r.Tool}
+			byTool[r.Tool] = s
+		}
+		s.Runs++
+		if r.ExitCode != 0 {
+			s.Failures++
+		}
+		if r.Time.After(s.LastRun) {
+			s.LastRun = r.Time
+		}
+	}
+	stats := make([]ToolStats, 0, len(byTool))
+	for _, s := range byTool {
+		s.FailureRate = float64(s.Failures) / float64(s.Runs)
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Runs != stats[j].Runs {
+			return stats[i].Runs > stats[j].Runs
+		}
+		return stats[i].Tool < stats[j].Tool
+	})
+	return stats
+}
+
+// PrintHistoryTable 以表格输出历史记录
+func PrintHistoryTable(w io.Writer, records []HistoryRecord) error {
+	headers := []string{"Time", "Tool", "Exit", "Duration", "Args", "Cwd"}
+	rows := make([][]string, 0, len(records))
+	for _, r := range records {
+		rows = append(rows, []string{
+			r.Time.Local().Format("2006-01-02 15:04:05"),
+			r.Tool,
+			strconv.Itoa(r.ExitCode),
+			(time.Duration(r.DurationMs) * time.Millisecond).String(),
+			fmt.Sprint(r.Args),
+			r.Cwd,
+		})
+	}
+	return style.PrintTable(w, headers, rows, 0)
+}
+
+// PrintHistoryStatsTable 以表格输出按工具汇总的统计
+func PrintHistoryStatsTable(w io.Writer, stats []ToolStats) error {
+	headers := []string{"Tool", "Runs", "Failures", "Failure Rate", "Last Run"}
+	rows := make([][]string, 0, len(stats))
+	for _, s := range stats {
+		rows = append(rows, []string{
+			s.Tool,
+			strconv.Itoa(s.Runs),
+			strconv.Itoa(s.Failures),
+			fmt.Sprintf("%.1f%%", s.FailureRate*100),
+			s.LastRun.Local().Format("2006-01-02 15:04:05"),
+		})
+	}
+	return style.PrintTable(w, headers, rows, 0)
+}
+
+// recordToolRun 在 opts.History 非空时记录一次执行；录制模式（--dry-run）下不记录，写入失败只输出警告
+func recordToolRun(opts RunOptions, tool, path string, args []string, start time.Time, runErr error) {
+	if opts.History == "" || executor.Recording() {
+		return
+	}
+	exitCode := 0
+	if runErr != nil {
+		exitCode = -1
+		var ee *executor.ExecError
+		if errors.As(runErr, &ee) {
+			exitCode = ee.ExitCode()
+		}
+	}
+	cwd, _ := os.Getwd()
+	rec := HistoryRecord{
+		Time:       start,
+		Tool:       tool,
+		Path:       path,
+		Args:       append([]string{}, args...),
+		ExitCode:   exitCode,
+		DurationMs: time.Since(start).Milliseconds(),
+		Cwd:        cwd,
+	}
+	if err := AppendHistory(opts.History, rec, HistoryMaxSize); err != nil {
+		fmt.Fprintf(os.Stderr, "[gocli][tools] warning: failed to record history: %v\n", err)
+	}
+}
@@ -0,0 +1,121 @@
+package tools
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 测试执行记录序列化为单行 JSON，并能原样读回
+func TestHistoryRecordRoundTrip(t *testing.T) {
+	file := filepath.Join(t.TempDir(), ".gocli", "history.jsonl")
+	rec := HistoryRecord{
+		Time:       time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Tool:       "golangci-lint",
+		Path:       "/home/u/go/bin/golangci-lint",
+		Args:       []string{"run", "--fix", "line\nbreak"},
+		ExitCode:   1,
+		DurationMs: 1500,
+		Cwd:        "/src/app",
+	}
+	for range 2 {
+		if err := AppendHistory(file, rec, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one line per record, got %q", data)
+	}
+	var m map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &m); err != nil {
+		t.Fatal(err)
+	}
+	for _, k := range []string{"time", "tool", "path", "args", "exit_code", "duration_ms", "cwd"} {
+		if _, ok := m[k]; !ok {
+			t.Errorf("missing key %q in %s", k, lines[0])
+		}
+	}
+
+	got, err := ReadHistory(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || !got[0].Time.Equal(rec.Time) || strings.Join(got[0].Args, "|") != strings.Join(rec.Args, "|") || got[0].ExitCode != 1 {
+		t.Errorf("round trip mismatch: %+v", got)
+	}
+}
+
+// 测试超过大小上限时轮转为 .1，读取时两份文件按时间顺序合并，损坏的行被跳过
+func TestHistoryRotation(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "history.jsonl")
+	rec := func(i int) HistoryRecord {
+		return HistoryRecord{Time: time.Unix(int64(i), 0).UTC(), Tool: "t", Args: []string{}}
+	}
+	line, _ := json.Marshal(rec(0))
+	maxSize := int64(len(line)+1) * 3 // 每个文件最多 3 条记录
+	for i := range 5 {
+		if err := AppendHistory(file, rec(i), maxSize); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(file + ".1"); err != nil {
+		t.Fatalf("expected rotated file: %v", err)
+	}
+	if fi, _ := os.Stat(file); fi.Size() > maxSize {
+		t.Errorf("current file exceeds the cap: %d > %d", fi.Size(), maxSize)
+	}
+
+	f, _ := os.OpenFile(file, os.O_APPEND|os.O_WRONLY, 0o644)
+	_, _ = f.WriteString("{not json\n")
+	_ = f.Close()
+
+	got, err := ReadHistory(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 5 {
+		t.Fatalf("expected 5 records across both files, got %d", len(got))
+	}
+	for i, r := range got {
+		if r.Time.Unix() != int64(i) {
+			t.Errorf("record %d out of order: %v", i, r.Time)
+		}
+	}
+	if recent := FilterHistory(got, "", 2); len(recent) != 2 || recent[0].Time.Unix() != 4 {
+		t.Errorf("FilterHistory should return the newest entries first, got %+v", recent)
+	}
+}
+
+// 测试按工具汇总执行次数与失败率
+func TestHistoryStats(t *testing.T) {
+	now := time.Now()
+	records := []HistoryRecord{
+		{Tool: "lint", ExitCode: 0, Time: now.Add(-3 * time.Minute)},
+		{Tool: "lint", ExitCode: 1, Time: now.Add(-2 * time.Minute)},
+		{Tool: "fmt", ExitCode: 0, Time: now.Add(-time.Minute)},
+		{Tool: "lint", ExitCode: -1, Time: now.Add(-4 * time.Minute)},
+		{Tool: "lint", ExitCode: 0, Time: now},
+	}
+	stats := HistoryStats(records)
+	if len(stats) != 2 || stats[0].Tool != "lint" || stats[1].Tool != "fmt" {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	lint := stats[0]
+	if lint.Runs != 4 || lint.Failures != 2 || lint.FailureRate != 0.5 || !lint.LastRun.Equal(now) {
+		t.Errorf("unexpected lint stats %+v", lint)
+	}
+	if stats[1].Runs != 1 || stats[1].FailureRate != 0 {
+		t.Errorf("unexpected fmt stats %+v", stats[1])
+	}
+	if only := FilterHistory(records, "fmt", 0); len(only) != 1 {
+		t.Errorf("tool filter failed: %+v", only)
+	}
+}
@@ -0,0 +1,141 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/style"
+)
+
+// ToolDetails 是 tools info 的输出：工具表中的定义、安装状态以及 tools install 将采用的安装方式
+type ToolDetails struct {
+	Name string `json:"name"`
+	// Info 是工具表（内置 + 用户配置）中解析到的定义；只在本地找到二进制时为 nil
+	Info      *InstallToolsInfo `json:"info,omitempty"`
+	Installed bool              `json:"installed"`
+	Path      string            `json:"path,omitempty"`
+	Source    toolSourceType    `json:"source,omitempty"`
+	Size      int64             `json:"size,omitempty"`
+	ModTime   time.Time         `json:"mod_time,omitzero"`
+	// InstallMethod 描述 gocli tools install <name> 实际会执行的操作
+	InstallMethod string `json:"install_method"`
+	// Compatible 为 false 时 InstallType 限制了其他平台，Reason 给出原因
+	Compatible bool   `json:"compatible"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// majorVersionSuffix 匹配模块路径末尾的 /vN 主版本后缀
+var majorVersionSuffix = regexp.MustCompile(`^v[0-9]+$`)
+
+// InspectTool 通过 ResolveTool 解析工具定义，并在 FindTools 的结果中查找已安装的二进制
+// 名称匹配到多个工具表条目时返回错误并列出候选；工具表和本地都找不到时返回错误
+func InspectTool(name string, configDirs []string, gocliToolsPath string) (*ToolDetails, error) {
+	bi, matches := ResolveTool(name, configDirs)
+	if bi == nil && len(matches) > 1 {
+		names := make([]string, 0, len(matches))
+		for _, m := range matches {
+			names = append(names, m.Name)
+		}
+		return nil, fmt.Errorf("ambiguous tool name %q, candidates: %s", name, strings.Join(names, ", "))
+	}
+
+	d := &ToolDetails{Name: name, Info: bi, Compatible: true}
+	candidates := []string{name}
+	if bi != nil {
+		d.Name = bi.Name
+		candidates = append(binaryCandidates(bi), name)
+		d.Compatible, d.Reason = checkPlatformCompatibility(bi)
+	}
+	d.InstallMethod = describeInstallMethod(bi)
+
+	tools := FindTools(false, gocliToolsPath)
+	for _, c := range candidates {
+		for _, t := range tools {
+			if strings.EqualFold(t.Name, c) {
+				d.Installed, d.Path, d.Source, d.Size, d.ModTime = true, t.Path, t.Source, t.Size, t.ModTime
+				break
+			}
+		}
+		if d.Installed {
+			break
+		}
+	}
+	if bi == nil && !d.Installed {
+		return nil, fmt.Errorf("tool %q not found in the tool table or install locations", name)
+	}
+	return d, nil
+}
+
+// binaryCandidates 返回工具安装后可能的二进制名：BinaryName、工具名以及 go install 模块路径的最后一段（跳过 /vN）
+func binaryCandidates(bi *InstallToolsInfo) []string {
+	var out []string
+	if bi.BinaryName != "" {
+		out = append(out, bi.BinaryName)
+	}
+	out = append(out, bi.Name)
+	if base := specBinaryName(bi.URL); base != "" {
+		out = append(out, base)
+	}
+	return out
+}
+
+// describeInstallMethod 按 installFromInfo 的规则描述安装方式：优先 go install，其次 clone 构建
+func describeInstallMethod(bi *InstallToolsInfo) string {
+	switch {
+	case bi == nil:
+		return "unknown (not in the tool table)"
+	case strings.TrimSpace(bi.URL) != "":
+		s := "go install " + ensureVersionSuffix(bi.URL)
+		if len(bi.Tags) > 0 {
+			s = "go install -tags " + strings.Join(bi.Tags, ",") + " " + ensureVersionSuffix(bi.URL)
+		}
+		return s
+	case strings.TrimSpace(bi.CloneURL) != "":
+		build := bi.Build
+		if build == "" {
+			build = "make"
+		}
+		s := "git clone " + bi.CloneURL + ", build with " + build
+		if build == "make" && bi.MakeTarget != "" {
+			s += " " + bi.MakeTarget
+		}
+		if bi.WorkDir != "" {
+			s += " in " + bi.WorkDir
+		}
+		return s
+	default:
+		return "none (no url or clone_url)"
+	}
+}
+
+// PrintToolDetails 以表格输出工具详情：先用 PrintSingleTool 输出工具定义，再输出安装状态
+func PrintToolDetails(out io.Writer, d *ToolDetails) error {
+	if d.Info != nil {
+		if err := PrintSingleTool(d.Info, "table", out); err != nil {
+			return err
+		}
+		fmt.Fprintln(out)
+	}
+	installed := "no"
+	if d.Installed {
+		installed = "yes"
+	}
+	rows := [][]string{{"Installed", installed}}
+	if d.Installed {
+		rows = append(rows,
+			[]string{"Path", d.Path},
+			[]string{"Source", string(d.Source)},
+			[]string{"Size", strconv.FormatInt(d.Size, 10) + " bytes"},
+			[]string{"Modified", d.ModTime.Local().Format("2006-01-02 15:04:05")},
+		)
+	}
+	rows = append(rows, []string{"Install method", d.InstallMethod})
+	if !d.Compatible {
+		rows = append(rows, []string{"Platform", d.Reason})
+	}
+	return style.PrintTable(out, []string{"Status", "Value"}, rows, 0)
+}
@@ -0,0 +1,52 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// 测试 tools info：通过模块路径推断二进制名（跳过 /vN），并报告安装位置与安装方式
+func TestInspectTool(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("GOPATH", filepath.Join(root, "gopath"))
+	t.Setenv("HOME", filepath.Join(root, "home"))
+	toolsDir := filepath.Join(root, "tools")
+	if err := os.MkdirAll(toolsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(toolsDir, "demo-cli"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	saved := BuiltinTools
+	BuiltinTools = map[string]InstallToolsInfo{
+		"demo":  {Name: "demo", URL: "example.com/demo/cmd/demo-cli/v2", Tags: []string{"netgo"}},
+		"clone": {Name: "clone", CloneURL: "https://example.com/clone.git#v1.0.0", MakeTarget: "build"},
+	}
+	t.Cleanup(func() { BuiltinTools = saved; ClearToolsCache() })
+	ClearToolsCache()
+
+	d, err := InspectTool("demo", nil, toolsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d.Installed || d.Path != filepath.Join(toolsDir, "demo-cli") || d.Size == 0 {
+		t.Errorf("expected demo-cli to be found installed, got %+v", d)
+	}
+	if want := "go install -tags netgo example.com/demo/cmd/demo-cli/v2@latest"; d.InstallMethod != want {
+		t.Errorf("install method = %q, want %q", d.InstallMethod, want)
+	}
+
+	d, err = InspectTool("clone", nil, toolsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Installed || !strings.HasPrefix(d.InstallMethod, "git clone https://example.com/clone.git#v1.0.0, build with make build") {
+		t.Errorf("unexpected clone tool details: %+v", d)
+	}
+
+	if _, err := InspectTool("missing-tool", nil, toolsDir); err == nil {
+		t.Error("expected error for unknown tool")
+	}
+}
@@ -2,6 +2,7 @@ package tools
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -11,6 +12,8 @@ import (
 
 	"github.com/spf13/viper"
 	"github.com/yeisme/gocli/pkg/configs"
+	"github.com/yeisme/gocli/pkg/style"
+	"github.com/yeisme/gocli/pkg/utils/executor"
 )
 
 // goInstallWithEnv 支持传入额外环境变量（如 GOBIN）
@@ -50,6 +53,10 @@ type InstallOptions struct {
 
 	// BinaryName 指定生成的二进制文件名（可选）
 	BinaryName string
+	// SHA256: 安装后二进制期望的 sha256（可带 sha256: 前缀），不一致时删除该二进制并报错
+	SHA256 string
+	// SkipVerify: 不比较 SHA256，只记录安装的二进制的哈希
+	SkipVerify bool
 	// Clone: 是否递归克隆子模块
 	RecurseSubmodules bool
 
@@ -58,18 +65,52 @@ type InstallOptions struct {
 
 	// Tags: 构建标签，用于 go install 的 -tags 参数
 	Tags []string
+
+	// TargetOS/TargetArch: 交叉安装的目标平台，写入构建环境的 GOOS/GOARCH；为空时沿用当前平台
+	TargetOS   string
+	TargetArch string
+
+	// Offline: 只使用本机模块缓存安装（配置 tools.offline 同样生效），clone 安装只允许本地仓库
+	Offline bool
+
+	// RetryAttempts/RetryDelay: git clone 与 go install 遇到暂时性网络故障时的总尝试次数（0 为 3，1 不重试）
+	// 与首次重试前的等待（0 为 2s），之后每次翻倍
+	RetryAttempts int
+	RetryDelay    time.Duration
+
+	// Prune: 安装成功后删除安装目录中被本次安装取代的旧二进制（见 PruneSuperseded）
+	Prune bool
+
+	// Check: 安装后的冒烟测试，传给二进制的参数或完整命令；为空时依次尝试 --version、version、-h，
+	// "none" 关闭（交叉安装从不运行）
+	Check string
+	// StrictCheck: 冒烟测试失败时删除安装的二进制并视为安装失败
+	StrictCheck bool
 }
 
 // InstallResult 统一返回值
 type InstallResult struct {
 	// 原始命令输出（可能为多行）
-	Output string
+	Output string `json:"output,omitempty"`
 	// 明确的安装目录（当设置 Path 或 go install 传入 GOBIN 时）
-	InstallDir string
+	InstallDir string `json:"install_dir,omitempty"`
 	// 根据环境与 go env 推断的安装目录（即便 InstallDir 为空也会提供）
-	ProbableInstallDir string
+	ProbableInstallDir string `json:"probable_install_dir,omitempty"`
 	// 执行模式：go_install 或 clone_make
-	Mode string
+	Mode string `json:"mode"`
+	// 安装的二进制及其 sha256
+	Digests []BinaryDigest `json:"digests,omitempty"`
+	// Prune 时删除的旧二进制
+	Pruned []string `json:"pruned,omitempty"`
+	// 安装后冒烟测试的结果
+	Checks []SmokeCheck `json:"checks,omitempty"`
+}
+
+// InstallReport 是 tools install --json 的输出：安装结果加上是否成功与失败原因
+type InstallReport struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+	InstallResult
 }
 
 // InstallTool 统一入口：根据是否传入 CloneURL 决定使用 go install 或 clone+make
@@ -79,17 +120,41 @@ func InstallTool(opts InstallOptions) (InstallResult, error) {
 
 	// 预处理 env 与 Path -> GOBIN
 	env := append([]string{}, opts.Env...)
+	// 交叉安装：GOOS/GOARCH 写入构建环境，windows 目标的二进制名补 .exe
+	goos, goarch := resolveTarget(opts.TargetOS, opts.TargetArch)
+	cross := isCrossTarget(opts.TargetOS, opts.TargetArch)
+	env = append(env, targetEnv(opts.TargetOS, opts.TargetArch)...)
+	opts.BinaryName = targetBinaryName(opts.BinaryName, goos)
 	finalDir := ""
 	if opts.Path != "" {
 		p := expandPath(opts.Path)
 		if err := os.MkdirAll(p, 0o755); err != nil {
-			return res, fmt.Errorf("create install dir %s failed: %w", p, err)
+			return res, fmt.Errorf("create install dir %s failed: %w", displayPath(p), err)
 		}
 		abs, _ := filepath.Abs(p)
 		finalDir = abs
 		env = append(env, fmt.Sprintf("GOBIN=%s", abs))
 	}
 
+	offline := offlineEnabled(opts)
+	retry := newRetryPolicy(opts.RetryAttempts, opts.RetryDelay)
+	if offline {
+		// 离线安装不访问网络，失败不会是网络故障
+		retry.attempts = 1
+		if opts.CloneURL != "" && !isLocalCloneURL(opts.CloneURL) {
+			return res, fmt.Errorf("offline install cannot clone %s: only local paths and file:// URLs are allowed", opts.CloneURL)
+		}
+		modCache, err := goModCache(env)
+		if err != nil {
+			return res, err
+		}
+		mod := ""
+		if opts.CloneURL == "" {
+			mod = offlineModFlag(opts.Spec)
+		}
+		env = offlineEnv(env, modCache, mod)
+	}
+
 	if opts.CloneURL != "" {
 		// 将 Release/Debug 预设尽力通过 GOFLAGS 传递给 make/go build/goreleaser
 		var goflags []string
@@ -107,6 +172,8 @@ func InstallTool(opts InstallOptions) (InstallResult, error) {
 		// 由构建器自身提供默认 BinDirs；若用户显式传入则优先生效
 		binDirs := append([]string{}, opts.BinDirs...)
 		method := strings.ToLower(strings.TrimSpace(opts.BuildMethod))
+		cloneDir := probableCloneInstallDir(finalDir)
+		cloneSnap := SnapshotExecutables(cloneDir)
 
 		out, err := CloneAndBuildInstall(CloneBuildOptions{
 			CloneURL:          opts.CloneURL,
@@ -122,27 +189,21 @@ func InstallTool(opts InstallOptions) (InstallResult, error) {
 			BinDirs:           binDirs,
 			BinaryName:        opts.BinaryName,
 			Force:             opts.Force,
+			RetryAttempts:     retry.attempts,
+			RetryDelay:        retry.delay,
 		})
 		res.Output = out
 		res.Mode = "clone_build"
 		res.InstallDir = finalDir
-		// 推断 probable 安装目录
-		if finalDir != "" {
-			res.ProbableInstallDir = finalDir
-		} else {
-			p := expandPath(viper.GetString("tools.path"))
-			if p == "" {
-				if home, e := os.UserHomeDir(); e == nil {
-					p = filepath.Join(home, ".gocli", "tools")
-				}
-			}
-			if p != "" {
-				if abs, _ := filepath.Abs(p); abs != "" {
-					res.ProbableInstallDir = abs
-				} else {
-					res.ProbableInstallDir = p
-				}
-			}
+		res.ProbableInstallDir = cloneDir
+		if err == nil {
+			res.Digests, err = verifyInstalled(opts, cloneDir, cloneSnap)
+		}
+		if err == nil {
+			res.Checks, err = smokeTestInstalled(opts, env, cloneDir, cloneSnap)
+		}
+		if err == nil && opts.Prune && finalDir != "" && !executor.Recording() {
+			res.Pruned, err = PruneSuperseded(finalDir, cloneSnap)
 		}
 		return res, err
 	}
@@ -158,7 +219,7 @@ func InstallTool(opts InstallOptions) (InstallResult, error) {
 	if len(opts.Tags) > 0 {
 		buildArgs = append(buildArgs, "-tags="+strings.Join(opts.Tags, ","))
 	}
-	// 在 go install 前快照目标安装目录（若可确定），以便安装后重命名
+	// 在 go install 前快照目标安装目录（若可确定），以便安装后重命名、计算新二进制的哈希、冒烟测试与清理
 	var preSnap map[string]time.Time
 	var targetDir string
 	if finalDir != "" {
@@ -167,11 +228,38 @@ func InstallTool(opts InstallOptions) (InstallResult, error) {
 		// 尝试从 go env 推断 GOBIN（为空则回退 GOPATH/bin）
 		targetDir = DetermineGoBinDir()
 	}
-	if targetDir != "" && opts.BinaryName != "" {
+	// go install 在设置 GOBIN 时拒绝安装交叉编译的二进制：先装到 GOPATH/bin/<goos>_<goarch>，再移动到目标目录
+	installPath, installEnv := opts.Path, env
+	var crossDir string
+	var crossSnap map[string]time.Time
+	if cross {
+		installPath, installEnv = "", append(withoutEnv(env, "GOBIN"), "GOBIN=")
+		crossDir = crossInstallDir(goos, goarch)
+		crossSnap = snapshotExecutablesFor(goos, crossDir)
+		if finalDir == "" {
+			targetDir = crossDir
+		}
+	}
+	if targetDir != "" {
 		preSnap = SnapshotExecutables(targetDir)
 	}
 
-	out, dir, err := InstallGoTool(opts.Spec, opts.Path, env, verbose, buildArgs)
+	var dir string
+	out, err := retry.do("go install "+opts.Spec, func() (out string, err error) {
+		out, dir, err = InstallGoTool(opts.Spec, installPath, installEnv, verbose, buildArgs)
+		return out, err
+	})
+	if err != nil && offline {
+		err = offlineInstallError(out, err)
+	}
+	if err == nil && cross && !executor.Recording() {
+		dir = firstNonEmpty(finalDir, crossDir)
+		if finalDir != "" && crossDir != "" {
+			if _, e := moveNewExecutables(goos, crossDir, crossSnap, finalDir); e != nil {
+				err = e
+			}
+		}
+	}
 	res.Output = out
 	res.Mode = "go_install"
 	res.InstallDir = dir
@@ -179,7 +267,7 @@ func InstallTool(opts InstallOptions) (InstallResult, error) {
 		res.ProbableInstallDir = dir
 	}
 	// 安装成功并要求自定义二进制名时，尝试重命名
-	if err == nil && opts.BinaryName != "" {
+	if err == nil && opts.BinaryName != "" && !executor.Recording() {
 		// 优先使用明确安装目录
 		renameDir := dir
 		if renameDir == "" {
@@ -192,9 +280,39 @@ func InstallTool(opts InstallOptions) (InstallResult, error) {
 			}
 		}
 	}
+	if err == nil {
+		res.Digests, err = verifyInstalled(opts, firstNonEmpty(dir, targetDir), preSnap)
+	}
+	if err == nil {
+		res.Checks, err = smokeTestInstalled(opts, env, firstNonEmpty(dir, targetDir), preSnap)
+	}
+	// 改名与冒烟测试之后再清理：新文件名已经确定，--strict-check 失败时保留旧版本
+	if err == nil && opts.Prune && preSnap != nil && !executor.Recording() {
+		res.Pruned, err = PruneSuperseded(firstNonEmpty(dir, targetDir), preSnap)
+	}
 	return res, err
 }
 
+// probableCloneInstallDir 推断 clone 安装的目录：明确的安装目录，否则为 tools.path（默认 ~/.gocli/tools）
+func probableCloneInstallDir(finalDir string) string {
+	if finalDir != "" {
+		return finalDir
+	}
+	p := expandPath(viper.GetString("tools.path"))
+	if p == "" {
+		if home, e := os.UserHomeDir(); e == nil {
+			p = filepath.Join(home, ".gocli", "tools")
+		}
+	}
+	if p == "" {
+		return ""
+	}
+	if abs, _ := filepath.Abs(p); abs != "" {
+		return abs
+	}
+	return p
+}
+
 // InstallCommandOptions 定义了install命令的选项和上下文
 type InstallCommandOptions struct {
 	// 命令行参数
@@ -213,6 +331,8 @@ type InstallCommandOptions struct {
 	Yes bool
 	// Input: 交互输入源（默认 os.Stdin）
 	Input io.Reader
+	// JSON: 以 JSON 输出安装结果（InstallReport），提示信息改写到 stderr
+	JSON bool
 }
 
 // ExecuteInstallCommand 执行install命令的封装函数
@@ -224,18 +344,25 @@ func ExecuteInstallCommand(opts InstallCommandOptions, outputWriter io.Writer) e
 		return executeBatchInstall(opts)
 	}
 
+	// --json 时 stdout 只输出 JSON，提示与确认信息写到 stderr
+	msgOut := outputWriter
+	if opts.JSON {
+		msgOut = os.Stderr
+	}
+
 	pathFlag, msg, err := resolveInstallPath(opts)
 	if err != nil {
 		return err
 	}
 	if msg != "" {
-		fmt.Fprintln(outputWriter, msg)
+		fmt.Fprintln(msgOut, msg)
 	}
 
 	cloneURL, makeTarget, envFlags, binDirs, releaseBuild, debugBuild, v := prepareInstallVariables(opts)
 	spec := firstArg(opts.Args)
-	spec, cloneURL, makeTarget, binDirs, envFlags, tags, addBuildMethod, workDir, goreleaserConfig, binaryName := mapBuiltinToolIfNeeded(spec, cloneURL, makeTarget, binDirs, envFlags, opts.Tags, opts.ToolsConfigDir, v, outputWriter)
-	if err = maybeSuggestUnknownShortName(spec, opts, outputWriter); err != nil {
+	def := toolDefinition(spec, opts.ToolsConfigDir)
+	spec, cloneURL, makeTarget, binDirs, envFlags, tags, addBuildMethod, workDir, goreleaserConfig, binaryName := mapBuiltinToolIfNeeded(spec, cloneURL, makeTarget, binDirs, envFlags, opts.Tags, opts.ToolsConfigDir, v, msgOut)
+	if err = maybeSuggestUnknownShortName(spec, opts, msgOut); err != nil {
 		return err
 	}
 	if err = checkMutualExclusion(cloneURL, spec); err != nil {
@@ -243,20 +370,36 @@ func ExecuteInstallCommand(opts InstallCommandOptions, outputWriter io.Writer) e
 	}
 	installOpts := buildInstallOptions(spec, cloneURL, makeTarget, pathFlag, envFlags, binDirs, tags,
 		v, releaseBuild, debugBuild, binaryName, addBuildMethod, opts.BuildArgs, workDir, goreleaserConfig, opts)
+	installOpts.SHA256 = firstNonEmpty(installOpts.SHA256, def.SHA256)
+	installOpts.Check = firstNonEmpty(installOpts.Check, def.Check)
 	if err = validateFinalInstallOptions(installOpts); err != nil {
 		return err
 	}
 	if !opts.Yes {
-		proceed, confirmErr := confirmInstall(installOpts, opts, outputWriter)
+		proceed, confirmErr := confirmInstall(installOpts, opts, msgOut)
 		if confirmErr != nil {
 			return confirmErr
 		}
 		if !proceed {
+			if opts.JSON {
+				// 报告中 success 为 false，返回错误使命令以非零状态退出
+				err := errors.New("aborted")
+				if perr := printInstallReport(InstallResult{}, err, outputWriter); perr != nil {
+					return perr
+				}
+				return err
+			}
 			fmt.Fprintln(outputWriter, "aborted.")
 			return nil
 		}
 	}
 	res, err := InstallTool(installOpts)
+	if opts.JSON {
+		if perr := printInstallReport(res, err, outputWriter); perr != nil && err == nil {
+			err = perr
+		}
+		return err
+	}
 	printInstallResult(res, err, outputWriter)
 	return err
 }
@@ -272,10 +415,11 @@ func executeBatchInstall(opts InstallCommandOptions) error {
 		_ = LoadUserTools(p)
 	}
 	cfg := configs.GetConfig()
+	batch := BatchInstallOptions{Check: opts.Check, StrictCheck: opts.StrictCheck, Offline: opts.Offline}
 	if opts.Global {
-		return BatchInstallConfiguredGlobalTools(cfg, opts.Env, opts.Verbose)
+		return BatchInstallConfiguredGlobalTools(cfg, opts.Env, opts.Verbose, batch)
 	}
-	return BatchInstallConfiguredTools(cfg, opts.Env, opts.Verbose)
+	return BatchInstallConfiguredTools(cfg, opts.Env, opts.Verbose, batch)
 }
 
 // prepareInstallVariables extracts frequently used mutable copies
@@ -327,6 +471,18 @@ func maybeSuggestUnknownShortName(spec string, opts InstallCommandOptions, out i
 	return fmt.Errorf("unknown tool: %s", spec)
 }
 
+// toolDefinition returns the builtin or user tool definition for a short name, or the zero value
+func toolDefinition(spec string, toolsConfigDir []string) InstallToolsInfo {
+	if spec == "" || strings.ContainsAny(spec, "/\\") {
+		return InstallToolsInfo{}
+	}
+	name, _, _ := strings.Cut(spec, "@")
+	if bi := SearchTools(name, toolsConfigDir); bi != nil {
+		return *bi
+	}
+	return InstallToolsInfo{}
+}
+
 // checkMutualExclusion validates cloneURL and spec are not both set
 func checkMutualExclusion(cloneURL, spec string) error {
 	if cloneURL != "" && spec != "" {
@@ -361,11 +517,14 @@ func confirmInstall(installOpts InstallOptions, opts InstallCommandOptions, outp
 		fmt.Fprintf(outputWriter, "  Spec      : %s\n", installOpts.Spec)
 	}
 	if installOpts.Path != "" {
-		fmt.Fprintf(outputWriter, "  InstallDir: %s\n", filepath.Clean(installOpts.Path))
+		fmt.Fprintf(outputWriter, "  InstallDir: %s\n", displayPath(installOpts.Path))
 	}
 	if installOpts.BinaryName != "" {
 		fmt.Fprintf(outputWriter, "  BinaryName: %s\n", installOpts.BinaryName)
 	}
+	if installOpts.SHA256 != "" && !installOpts.SkipVerify {
+		fmt.Fprintf(outputWriter, "  SHA256    : %s\n", normalizeSHA256(installOpts.SHA256))
+	}
 	if len(installOpts.Env) > 0 {
 		fmt.Fprintf(outputWriter, "  Env       : %s\n", strings.Join(installOpts.Env, ", "))
 	}
@@ -378,9 +537,16 @@ func confirmInstall(installOpts InstallOptions, opts InstallCommandOptions, outp
 	if installOpts.DebugBuild {
 		fmt.Fprintln(outputWriter, "  Flags     : debug-build")
 	}
+	if offlineEnabled(installOpts) {
+		fmt.Fprintln(outputWriter, "  Flags     : offline")
+	}
 	if len(installOpts.Tags) > 0 {
 		fmt.Fprintf(outputWriter, "  Tags      : %s\n", strings.Join(installOpts.Tags, ", "))
 	}
+	if installOpts.TargetOS != "" || installOpts.TargetArch != "" {
+		goos, goarch := resolveTarget(installOpts.TargetOS, installOpts.TargetArch)
+		fmt.Fprintf(outputWriter, "  Target    : %s/%s\n", goos, goarch)
+	}
 	fmt.Fprint(outputWriter, "Proceed? [y/N]: ")
 	ans, _ := reader.ReadString('\n')
 	ans = strings.TrimSpace(strings.ToLower(ans))
@@ -523,6 +689,16 @@ func buildInstallOptions(spec, cloneURL, makeTarget, pathFlag string, envFlags,
 		RecurseSubmodules: opts.RecurseSubmodules,
 		Force:             opts.Force,
 		Tags:              tags,
+		SHA256:            opts.SHA256,
+		SkipVerify:        opts.SkipVerify,
+		TargetOS:          opts.TargetOS,
+		TargetArch:        opts.TargetArch,
+		Offline:           opts.Offline,
+		RetryAttempts:     opts.RetryAttempts,
+		RetryDelay:        opts.RetryDelay,
+		Prune:             opts.Prune,
+		Check:             opts.Check,
+		StrictCheck:       opts.StrictCheck,
 	}
 }
 
@@ -531,15 +707,37 @@ func printInstallResult(res InstallResult, err error, out io.Writer) {
 	if strings.TrimSpace(res.Output) != "" {
 		fmt.Fprint(out, res.Output)
 	}
-	if err != nil {
+	printDigests(out, res.Digests)
+	// --strict-check 失败时也输出检查结果，说明二进制为何被删除
+	printSmokeChecks(out, res.Checks)
+	// dry-run 时命令只被录制，没有真正安装
+	if err != nil || executor.Recording() {
 		return
 	}
 	if res.InstallDir != "" {
-		fmt.Fprintf(out, "installed to: %s\n", filepath.Clean(res.InstallDir))
+		fmt.Fprintf(out, "installed to: %s\n", displayPath(res.InstallDir))
 	}
 	if res.ProbableInstallDir != "" && res.InstallDir == "" {
-		fmt.Fprintf(out, "probable install dir: %s\n", filepath.Clean(res.ProbableInstallDir))
+		fmt.Fprintf(out, "probable install dir: %s\n", displayPath(res.ProbableInstallDir))
+	}
+	for _, p := range res.Pruned {
+		fmt.Fprintf(out, "pruned superseded binary: %s\n", displayPath(p))
+	}
+}
+
+// printInstallReport 以 JSON 输出安装结果，目录统一为 filepath.Clean 后的形式
+func printInstallReport(res InstallResult, err error, out io.Writer) error {
+	report := InstallReport{Success: err == nil, InstallResult: res}
+	if err != nil {
+		report.Error = err.Error()
+	}
+	if report.InstallDir != "" {
+		report.InstallDir = filepath.Clean(report.InstallDir)
+	}
+	if report.ProbableInstallDir != "" {
+		report.ProbableInstallDir = filepath.Clean(report.ProbableInstallDir)
 	}
+	return style.PrintJSON(out, report)
 }
 
 // firstNonEmpty returns first non-empty string else fallback
@@ -0,0 +1,92 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// 测试 --json 输出：成功时给出规范化的安装目录，失败时 success=false 并附带错误
+func TestPrintInstallReport(t *testing.T) {
+	var buf bytes.Buffer
+	dir := filepath.Join("bin", "tools") + string(filepath.Separator)
+	if err := printInstallReport(InstallResult{InstallDir: dir, Mode: "go_install"}, nil, &buf); err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid json %q: %v", buf.String(), err)
+	}
+	if got["success"] != true || got["install_dir"] != filepath.Join("bin", "tools") || got["mode"] != "go_install" {
+		t.Errorf("unexpected report: %v", got)
+	}
+	if _, ok := got["error"]; ok {
+		t.Errorf("error should be omitted on success: %v", got)
+	}
+
+	buf.Reset()
+	if err := printInstallReport(InstallResult{Mode: "clone_make"}, errors.New("boom"), &buf); err != nil {
+		t.Fatal(err)
+	}
+	var report InstallReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Success || report.Error != "boom" || report.Mode != "clone_make" {
+		t.Errorf("unexpected failure report: %+v", report)
+	}
+}
+
+// 测试交叉安装的目标平台：只给出一侧时另一侧沿用当前平台，windows 目标补 .exe
+func TestCrossTarget(t *testing.T) {
+	if env := targetEnv("", ""); env != nil {
+		t.Errorf("targetEnv without target = %v, want nil", env)
+	}
+	want := []string{"GOOS=windows", "GOARCH=" + runtime.GOARCH}
+	if env := targetEnv("windows", ""); !slices.Equal(env, want) {
+		t.Errorf("targetEnv(windows) = %v, want %v", env, want)
+	}
+	if isCrossTarget(runtime.GOOS, "") || isCrossTarget("", "") {
+		t.Error("current platform should not be a cross target")
+	}
+	cases := map[[2]string]string{
+		{"tool", "windows"}:     "tool.exe",
+		{"tool.EXE", "windows"}: "tool.EXE",
+		{"tool", "linux"}:       "tool",
+		{"", "windows"}:         "",
+	}
+	for in, want := range cases {
+		if got := targetBinaryName(in[0], in[1]); got != want {
+			t.Errorf("targetBinaryName(%q, %q) = %q, want %q", in[0], in[1], got, want)
+		}
+	}
+	if got := withoutEnv([]string{"GOBIN=/x", "CGO_ENABLED=0", "GOBINX=1"}, "GOBIN"); !slices.Equal(got, []string{"CGO_ENABLED=0", "GOBINX=1"}) {
+		t.Errorf("withoutEnv = %v", got)
+	}
+}
+
+// 测试 --json 下拒绝确认：报告 success=false，并返回错误使命令以非零状态退出
+func TestExecuteInstallCommandJSONAborted(t *testing.T) {
+	var buf bytes.Buffer
+	err := ExecuteInstallCommand(InstallCommandOptions{
+		Args:           []string{"example.com/owner/tool/cmd/tool@v1.0.0"},
+		InstallOptions: InstallOptions{Path: t.TempDir()},
+		Input:          strings.NewReader("n\n"),
+		JSON:           true,
+	}, &buf)
+	if err == nil {
+		t.Fatal("declined install should return an error in --json mode")
+	}
+	var report InstallReport
+	if jerr := json.Unmarshal(buf.Bytes(), &report); jerr != nil {
+		t.Fatalf("invalid json %q: %v", buf.String(), jerr)
+	}
+	if report.Success || report.Error != "aborted" {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
@@ -3,8 +3,6 @@ package tools
 import (
 	"fmt"
 	"strings"
-
-	"github.com/yeisme/gocli/pkg/utils/executor"
 )
 
 // MakeRunner 实现 BuildRunner
@@ -23,16 +21,16 @@ func (MakeRunner) Build(ctx BuildContext, params BuildParams) (string, error) {
 		err error
 	)
 	if params.MakeTarget != "" {
-		out, err = executor.NewExecutor("make", params.MakeTarget).WithDir(ctx.BuildDir).WithEnv(ctx.Env...).CombinedOutput()
+		out, err = toolExecutor("make", params.MakeTarget).WithDir(ctx.BuildDir).WithEnv(ctx.Env...).CombinedOutput()
 		if err != nil {
 			return out, fmt.Errorf("make %s failed: %w", params.MakeTarget, err)
 		}
 		if ctx.Verbose {
 			b := &strings.Builder{}
 			fmt.Fprintf(b, "\n[make] target: %s\n", params.MakeTarget)
-			fmt.Fprintf(b, "[make] base dir: %s\n", ctx.AbsBase)
-			fmt.Fprintf(b, "[make] repo dir: %s\n", ctx.RepoDir)
-			fmt.Fprintf(b, "[make] build dir: %s\n", ctx.BuildDir)
+			fmt.Fprintf(b, "[make] base dir: %s\n", displayPath(ctx.AbsBase))
+			fmt.Fprintf(b, "[make] repo dir: %s\n", displayPath(ctx.RepoDir))
+			fmt.Fprintf(b, "[make] build dir: %s\n", displayPath(ctx.BuildDir))
 			fmt.Fprintf(b, "[make] repo: %s\n", ctx.RepoURL)
 			if ctx.ResolvedRef != "" {
 				if ctx.DisplayRef == "latest" && ctx.ResolvedRef != "" {
@@ -49,16 +47,16 @@ func (MakeRunner) Build(ctx BuildContext, params BuildParams) (string, error) {
 		return out, nil
 	}
 
-	out, err = executor.NewExecutor("make").WithDir(ctx.BuildDir).WithEnv(ctx.Env...).CombinedOutput()
+	out, err = toolExecutor("make").WithDir(ctx.BuildDir).WithEnv(ctx.Env...).CombinedOutput()
 	if err != nil {
 		return out, fmt.Errorf("make failed: %w", err)
 	}
 	if ctx.Verbose {
 		b := &strings.Builder{}
 		fmt.Fprintf(b, "\n[make] target: <default>\n")
-		fmt.Fprintf(b, "[make] base dir: %s\n", ctx.AbsBase)
-		fmt.Fprintf(b, "[make] repo dir: %s\n", ctx.RepoDir)
-		fmt.Fprintf(b, "[make] build dir: %s\n", ctx.BuildDir)
+		fmt.Fprintf(b, "[make] base dir: %s\n", displayPath(ctx.AbsBase))
+		fmt.Fprintf(b, "[make] repo dir: %s\n", displayPath(ctx.RepoDir))
+		fmt.Fprintf(b, "[make] build dir: %s\n", displayPath(ctx.BuildDir))
 		fmt.Fprintf(b, "[make] repo: %s\n", ctx.RepoURL)
 		if ctx.ResolvedRef != "" {
 			if ctx.DisplayRef == "latest" && ctx.ResolvedRef != "" {
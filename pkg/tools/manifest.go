@@ -0,0 +1,311 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/yeisme/gocli/pkg/configs"
+	"github.com/yeisme/gocli/pkg/utils/executor"
+	"gopkg.in/yaml.v3"
+)
+
+// ToolsManifest 描述可分享的工具清单，结构与配置文件中的 tools.deps / tools.global 一致
+type ToolsManifest struct {
+	Deps   []configs.Tool
+	Global []configs.Tool
+	// Skipped 无法确定来源（没有 Go build info 且不在工具表中）的工具，导出时写入注释块
+	Skipped []SkippedTool
+	// Notes 需要提醒的条目，例如从源码构建（版本为 (devel)）而按 @latest 导出的工具
+	Notes []string
+}
+
+// SkippedTool 记录导出时被跳过的已安装工具及原因
+type SkippedTool struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// ExportOptions 定义 tools export 的选项
+type ExportOptions struct {
+	GoCLIToolsPath string
+	ToolsConfigDir []string
+}
+
+// ImportOptions 定义 tools import 的选项
+//   - File: 清单文件路径（yaml/json/toml，按扩展名解析）
+//   - Global: 为 true 时全部安装到 ~/.gocli/tools；否则 deps 安装到 GoCLIToolsPath，global 安装到 ~/.gocli/tools
+type ImportOptions struct {
+	File           string
+	Global         bool
+	GoCLIToolsPath string
+	Env            []string
+	Verbose        bool
+}
+
+// manifestTool 是写出清单时使用的精简结构，字段名与 configs.Tool 的 mapstructure 标签保持一致
+type manifestTool struct {
+	Type       string   `yaml:"type" json:"type"`
+	Module     string   `yaml:"module,omitempty" json:"module,omitempty"`
+	CloneURL   string   `yaml:"clone,omitempty" json:"clone,omitempty"`
+	Build      string   `yaml:"build,omitempty" json:"build,omitempty"`
+	MakeTarget string   `yaml:"make_target,omitempty" json:"make_target,omitempty"`
+	WorkDir    string   `yaml:"workdir,omitempty" json:"workdir,omitempty"`
+	BinDirs    []string `yaml:"bin,omitempty" json:"bin,omitempty"`
+	BinaryName string   `yaml:"binary_name,omitempty" json:"binary_name,omitempty"`
+	Env        []string `yaml:"env,omitempty" json:"env,omitempty"`
+	Tags       []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+}
+
+// toManifestTool 把配置条目转换为写出时使用的精简结构
+func toManifestTool(t configs.Tool) manifestTool {
+	return manifestTool{
+		Type:       t.Type,
+		Module:     t.Module,
+		CloneURL:   t.CloneURL,
+		Build:      t.Build,
+		MakeTarget: t.MakeTarget,
+		WorkDir:    t.WorkDir,
+		BinDirs:    t.BinDirs,
+		BinaryName: t.BinaryName,
+		Env:        t.Env,
+		Tags:       t.Tags,
+	}
+}
+
+// BuildToolsManifest 扫描已安装的工具（FindTools），结合二进制的 Go build info 生成清单；
+// 没有 build info 的二进制按名称在工具表中查找（ResolveTool），仍无法确定来源时记入 Skipped。
+// ~/.gocli/tools 下的工具归入 global，其余归入 deps
+func BuildToolsManifest(opts ExportOptions) *ToolsManifest {
+	for _, p := range opts.ToolsConfigDir {
+		_ = LoadUserTools(p)
+	}
+	m := &ToolsManifest{}
+	for _, ti := range FindTools(false, opts.GoCLIToolsPath) {
+		var (
+			tool configs.Tool
+			note string
+		)
+		prov, err := ReadBinaryProvenance(ti.Path)
+		if err != nil || (prov.Package == "" && prov.Module == "") {
+			info := resolveByName(ti.Name, opts.ToolsConfigDir)
+			if info == nil {
+				m.Skipped = append(m.Skipped, SkippedTool{Name: ti.Name, Path: ti.Path, Reason: "no Go build info and not found in the tools table"})
+				continue
+			}
+			tool, note = toolFromInfo(ti.Name, info)
+		} else {
+			tool, note = manifestEntry(ti.Name, prov)
+		}
+		if note != "" {
+			m.Notes = append(m.Notes, note)
+		}
+		if ti.Source == goUserCliPath {
+			m.Global = append(m.Global, tool)
+		} else {
+			m.Deps = append(m.Deps, tool)
+		}
+	}
+	return m
+}
+
+// manifestEntry 将二进制来源转换为配置条目：
+//   - 内置/用户定义中只能 clone 构建的工具导出为 clone 条目
+//   - 其他导出为 go install 的 <main 包>@<版本>；(devel) 构建无法复现版本，按 @latest 导出并给出提示
+func manifestEntry(name string, prov *BinaryProvenance) (configs.Tool, string) {
+	if info := builtinForProvenance(prov); info != nil && info.URL == "" && info.CloneURL != "" {
+		return configs.Tool{
+			Type:       "clone",
+			CloneURL:   info.CloneURL,
+			Build:      info.Build,
+			MakeTarget: info.MakeTarget,
+			WorkDir:    info.WorkDir,
+			BinDirs:    info.BinDirs,
+			BinaryName: info.BinaryName,
+		}, ""
+	}
+
+	pkg := prov.Package
+	if pkg == "" {
+		pkg = prov.Module
+	}
+	version, note := prov.Version, ""
+	if version == "" || version == "(devel)" {
+		version = "latest"
+		note = fmt.Sprintf("%s: built from source (devel), exported as %s@latest", name, pkg)
+	}
+	tool := configs.Tool{Type: "go", Module: pkg + "@" + version}
+	if path.Base(pkg) != name {
+		tool.BinaryName = name
+	}
+	return tool, note
+}
+
+// resolveByName 通过 ResolveTool 按二进制名称查找工具表中的条目；
+// 模糊匹配只在候选的名称或二进制名与 name 一致时采用，避免把无关工具写入清单
+func resolveByName(name string, paths []string) *InstallToolsInfo {
+	info, _ := ResolveTool(name, paths)
+	if info == nil {
+		return nil
+	}
+	if info.Name != name && info.BinaryName != name && !strings.EqualFold(path.Base(strings.Split(info.URL, "@")[0]), name) {
+		return nil
+	}
+	return info
+}
+
+// toolFromInfo 将工具表中的条目转换为配置条目；二进制没有 build info，版本无从得知，按表中的版本（默认 @latest）导出并给出提示
+func toolFromInfo(name string, info *InstallToolsInfo) (configs.Tool, string) {
+	if info.URL == "" {
+		return configs.Tool{
+			Type:       "clone",
+			CloneURL:   info.CloneURL,
+			Build:      info.Build,
+			MakeTarget: info.MakeTarget,
+			WorkDir:    info.WorkDir,
+			BinDirs:    info.BinDirs,
+			BinaryName: info.BinaryName,
+			Env:        info.Env,
+		}, fmt.Sprintf("%s: no Go build info, resolved by name to %s", name, info.CloneURL)
+	}
+	spec := info.URL
+	if !strings.Contains(spec, "@") {
+		spec += "@latest"
+	}
+	tool := configs.Tool{Type: "go", Module: spec, BinaryName: info.BinaryName, Env: info.Env}
+	return tool, fmt.Sprintf("%s: no Go build info, resolved by name to %s", name, spec)
+}
+
+// builtinForProvenance 在内置/用户工具定义中查找与二进制来源匹配的条目
+func builtinForProvenance(prov *BinaryProvenance) *InstallToolsInfo {
+	keys := make([]string, 0, len(BuiltinTools))
+	for k := range BuiltinTools {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		info := BuiltinTools[k]
+		if MatchesProvenance(prov, ExpectedModules(&info)) {
+			return &info
+		}
+	}
+	return nil
+}
+
+// toolsSection 返回清单的 tools 段（deps/global），空列表省略
+func (m *ToolsManifest) toolsSection() map[string][]manifestTool {
+	toEntries := func(list []configs.Tool) []manifestTool {
+		out := make([]manifestTool, 0, len(list))
+		for _, t := range list {
+			out = append(out, toManifestTool(t))
+		}
+		return out
+	}
+	section := map[string][]manifestTool{}
+	if len(m.Deps) > 0 {
+		section["deps"] = toEntries(m.Deps)
+	}
+	if len(m.Global) > 0 {
+		section["global"] = toEntries(m.Global)
+	}
+	return section
+}
+
+// WriteYAML 以配置文件的形式写出清单；跳过的工具与提示写在注释块中
+func (m *ToolsManifest) WriteYAML(w io.Writer) error {
+	doc := map[string]map[string][]manifestTool{"tools": m.toolsSection()}
+
+	var b strings.Builder
+	b.WriteString("# gocli tools manifest, generated by 'gocli tools export'\n")
+	b.WriteString("# install with: gocli tools import <file> [--global]\n")
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encode tools manifest failed: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("encode tools manifest failed: %w", err)
+	}
+	if len(m.Notes) > 0 {
+		b.WriteString("\n# Notes:\n")
+		for _, n := range m.Notes {
+			b.WriteString("#   - " + n + "\n")
+		}
+	}
+	if len(m.Skipped) > 0 {
+		b.WriteString("\n# Skipped (provenance unknown, install these manually):\n")
+		for _, s := range m.Skipped {
+			fmt.Fprintf(&b, "#   - %s (%s): %s\n", s.Name, s.Path, s.Reason)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteJSON 以 JSON 写出清单：tools 段与 YAML 相同，提示与跳过的工具放在 notes/skipped 字段中（tools import 会忽略它们）
+func (m *ToolsManifest) WriteJSON(w io.Writer) error {
+	doc := struct {
+		Tools   map[string][]manifestTool `json:"tools"`
+		Notes   []string                  `json:"notes,omitempty"`
+		Skipped []SkippedTool             `json:"skipped,omitempty"`
+	}{m.toolsSection(), m.Notes, m.Skipped}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encode tools manifest failed: %w", err)
+	}
+	return nil
+}
+
+// ReadToolsManifest 读取清单文件，解析方式与配置文件中的 tools 段一致
+func ReadToolsManifest(file string) (*configs.ToolsConfig, error) {
+	v := viper.New()
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("read tools manifest %s failed: %w", file, err)
+	}
+	var cfg configs.ToolsConfig
+	if err := v.UnmarshalKey("tools", &cfg); err != nil {
+		return nil, fmt.Errorf("parse tools manifest %s failed: %w", file, err)
+	}
+	return &cfg, nil
+}
+
+// ImportToolsManifest 读取清单并通过 InstallConfiguredToolsFromList 安装其中的工具
+func ImportToolsManifest(opts ImportOptions, out io.Writer) error {
+	cfg, err := ReadToolsManifest(opts.File)
+	if err != nil {
+		return err
+	}
+	if len(cfg.Deps) == 0 && len(cfg.Global) == 0 {
+		fmt.Fprintf(out, "no tools found in %s\n", opts.File)
+		return nil
+	}
+
+	globalPath := filepath.Join(mustUserHome(), ".gocli", "tools")
+	depsPath := opts.GoCLIToolsPath
+	if opts.Global || strings.TrimSpace(depsPath) == "" {
+		depsPath = globalPath
+	}
+
+	total, failed := InstallConfiguredToolsFromList(cfg.Deps, depsPath, "dep", opts.Env, opts.Verbose)
+	t, f := InstallConfiguredToolsFromList(cfg.Global, globalPath, "global", opts.Env, opts.Verbose)
+	total, failed = total+t, failed+f
+	if err := executor.Canceled(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "imported %d tool(s) from %s", total, opts.File)
+	if failed > 0 {
+		fmt.Fprintf(out, ", %d failed\n", failed)
+		return fmt.Errorf("%d tool(s) failed", failed)
+	}
+	fmt.Fprintln(out)
+	return nil
+}
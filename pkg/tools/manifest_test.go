@@ -0,0 +1,148 @@
+package tools
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// 测试导出已安装工具清单后再导入：两个带 build info 的二进制都应被请求安装，
+// 无 build info 的可执行文件写入注释块
+func TestToolsManifest_RoundTrip(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script fixture is not portable to windows")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	root := t.TempDir()
+	gopathBin := filepath.Join(root, "gopath", "bin")
+	toolsDir := filepath.Join(root, "tools")
+	buildFixture(t, "gooddemo", filepath.Join(gopathBin, "gooddemo"))
+	buildFixture(t, "otherdemo", filepath.Join(toolsDir, "otherdemo"))
+	if err := os.WriteFile(filepath.Join(toolsDir, "notgo"), []byte("#!/bin/sh\necho hi\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("GOPATH", filepath.Join(root, "gopath"))
+	t.Setenv("HOME", filepath.Join(root, "home"))
+	saved := BuiltinTools
+	BuiltinTools = map[string]InstallToolsInfo{}
+	t.Cleanup(func() {
+		BuiltinTools = saved
+		ClearToolsCache()
+	})
+	ClearToolsCache()
+
+	var manifest bytes.Buffer
+	if err := BuildToolsManifest(ExportOptions{GoCLIToolsPath: toolsDir}).WriteYAML(&manifest); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(manifest.String(), "# Skipped") || !strings.Contains(manifest.String(), "notgo (") {
+		t.Errorf("binary without build info should be listed in a comment block:\n%s", manifest.String())
+	}
+	file := filepath.Join(root, "tools.yaml")
+	if err := os.WriteFile(file, manifest.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// 录制模式代替真实安装，断言将要执行的 go install
+	rec := executor.StartRecording()
+	defer executor.StopRecording()
+	var out bytes.Buffer
+	if err := ImportToolsManifest(ImportOptions{File: file, GoCLIToolsPath: filepath.Join(root, "other")}, &out); err != nil {
+		t.Fatalf("import failed: %v\n%s", err, out.String())
+	}
+	var installs []string
+	for _, r := range rec.Records() {
+		installs = append(installs, r.String())
+	}
+	joined := strings.Join(installs, "\n")
+	for _, spec := range []string{"example.com/gooddemo@", "example.com/otherdemo@"} {
+		if !strings.Contains(joined, "install") || !strings.Contains(joined, spec) {
+			t.Errorf("expected go install of %s, got:\n%s\nmanifest:\n%s", spec, joined, manifest.String())
+		}
+	}
+
+	// 同一份清单也能交给 tools install --from-file：module 作为 url，deps/global 全部安装
+	results, err := InstallFromFile(FromFileOptions{File: file, GoCLIToolsPath: filepath.Join(root, "other")})
+	if err != nil {
+		t.Fatalf("install --from-file rejected the exported manifest: %v\n%s", err, manifest.String())
+	}
+	var sources []string
+	for _, r := range results {
+		if r.Err != nil {
+			t.Errorf("%s: %v", r.Name, r.Err)
+		}
+		sources = append(sources, r.Source)
+	}
+	joined = strings.Join(sources, "\n")
+	for _, spec := range []string{"example.com/gooddemo@", "example.com/otherdemo@"} {
+		if len(results) != 2 || !strings.Contains(joined, spec) {
+			t.Errorf("expected --from-file to install %s, got:\n%s", spec, joined)
+		}
+	}
+}
+
+// 测试没有 build info 的二进制按名称在工具表中解析，JSON 清单保留 notes/skipped 并能被 tools import 读取
+func TestToolsManifest_ResolveByNameJSON(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script fixture is not portable to windows")
+	}
+	root := t.TempDir()
+	toolsDir := filepath.Join(root, "tools")
+	if err := os.MkdirAll(toolsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"known", "unknown"} {
+		if err := os.WriteFile(filepath.Join(toolsDir, name), []byte("#!/bin/sh\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("GOPATH", filepath.Join(root, "gopath"))
+	t.Setenv("HOME", filepath.Join(root, "home"))
+	saved := BuiltinTools
+	BuiltinTools = map[string]InstallToolsInfo{
+		"known":      {Name: "known", URL: "example.com/known/cmd/known"},
+		"unknownish": {Name: "unknownish", URL: "example.com/unknownish"},
+	}
+	t.Cleanup(func() {
+		BuiltinTools = saved
+		ClearToolsCache()
+	})
+	ClearToolsCache()
+
+	m := BuildToolsManifest(ExportOptions{GoCLIToolsPath: toolsDir})
+	if len(m.Deps) != 1 || m.Deps[0].Module != "example.com/known/cmd/known@latest" {
+		t.Fatalf("expected known to resolve by name, got deps %+v", m.Deps)
+	}
+	if len(m.Skipped) != 1 || m.Skipped[0].Name != "unknown" || len(m.Notes) != 1 {
+		t.Fatalf("unexpected skipped %+v / notes %v", m.Skipped, m.Notes)
+	}
+
+	var buf bytes.Buffer
+	if err := m.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"skipped": [`) || !strings.Contains(buf.String(), `"notes": [`) {
+		t.Errorf("unexpected JSON manifest:\n%s", buf.String())
+	}
+	file := filepath.Join(root, "tools.json")
+	if err := os.WriteFile(file, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := ReadToolsManifest(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Deps) != 1 || cfg.Deps[0].Module != "example.com/known/cmd/known@latest" {
+		t.Errorf("JSON manifest read back as %+v", cfg.Deps)
+	}
+}
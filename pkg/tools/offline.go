@@ -0,0 +1,175 @@
+package tools
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+	"golang.org/x/mod/module"
+)
+
+// 离线安装（--offline / tools.offline）只使用本机模块缓存：
+//   - GOPROXY 指向 GOMODCACHE/cache/download（file:// 代理）。GOPROXY=off 时 go install pkg@version 查询模块弃用信息也会失败，
+//     而缓存目录本身就是合法的代理布局，已缓存的模块（包括 @latest 查询需要的 list 文件）都能解析，未缓存的模块直接报错
+//   - GOSUMDB=off、GONOSUMDB=*：不访问校验和数据库
+//   - GOTOOLCHAIN=local：不下载 go.mod 中要求的新工具链
+//   - GOFLAGS 中的 -mod：模块安装为 -mod=mod，本地路径所在模块存在 vendor/modules.txt 时为 -mod=vendor；
+//     clone 构建不覆盖 -mod，由 go 命令按 vendor 目录自动选择
+
+// offlineEnabled 报告本次安装是否离线：--offline 或配置 tools.offline
+func offlineEnabled(opts InstallOptions) bool {
+	return opts.Offline || viper.GetBool("tools.offline")
+}
+
+// goModCache 返回 env 生效时的 GOMODCACHE
+func goModCache(env []string) (string, error) {
+	out, err := toolExecutor("go", "env", "GOMODCACHE").WithEnv(env...).ReadOnly().Output()
+	if err != nil {
+		return "", fmt.Errorf("go env GOMODCACHE failed: %w", err)
+	}
+	dir := strings.TrimSpace(out)
+	if dir == "" {
+		return "", fmt.Errorf("GOMODCACHE is not set")
+	}
+	return dir, nil
+}
+
+// cacheProxyURL 返回以模块缓存下载目录作为 GOPROXY 的 file:// URL
+func cacheProxyURL(modCache string) string {
+	p := filepath.ToSlash(filepath.Join(modCache, "cache", "download"))
+	if !strings.HasPrefix(p, "/") {
+		// Windows: C:/... -> file:///C:/...
+		p = "/" + p
+	}
+	return (&url.URL{Scheme: "file", Path: p}).String()
+}
+
+// offlineEnv 在 env 之后追加离线安装的环境变量；mod 为写入 GOFLAGS 的 -mod 取值，空表示不覆盖
+func offlineEnv(env []string, modCache, mod string) []string {
+	out := append([]string{}, env...)
+	out = append(out,
+		"GOPROXY="+cacheProxyURL(modCache),
+		"GOSUMDB=off",
+		"GONOSUMDB=*",
+		"GOTOOLCHAIN=local",
+	)
+	if mod == "" {
+		return out
+	}
+	return withModFlag(out, mod)
+}
+
+// withModFlag 将 env 中生效的 GOFLAGS（构建预设或用户环境）里的 -mod 替换为 -mod=<mod>，其他标志保留
+func withModFlag(env []string, mod string) []string {
+	goflags := os.Getenv("GOFLAGS")
+	for _, kv := range env {
+		if v, ok := strings.CutPrefix(kv, "GOFLAGS="); ok {
+			goflags = v
+		}
+	}
+	var flags []string
+	for _, f := range strings.Fields(goflags) {
+		if !strings.HasPrefix(f, "-mod=") {
+			flags = append(flags, f)
+		}
+	}
+	flags = append(flags, "-mod="+mod)
+	return append(withoutEnv(env, "GOFLAGS"), "GOFLAGS="+strings.Join(flags, " "))
+}
+
+// offlineModFlag 返回 go install spec 在离线模式下使用的 -mod 取值
+func offlineModFlag(spec string) string {
+	if strings.Contains(spec, "@") {
+		return "mod"
+	}
+	dir, err := filepath.Abs(expandPath(spec))
+	if err != nil {
+		return "mod"
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			if _, err := os.Stat(filepath.Join(dir, "vendor", "modules.txt")); err == nil {
+				return "vendor"
+			}
+			return "mod"
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "mod"
+		}
+		dir = parent
+	}
+}
+
+// isLocalCloneURL 报告 clone 地址是否为本地仓库（file:// URL、绝对/相对路径或已存在的目录），离线时只允许本地仓库
+func isLocalCloneURL(cloneURL string) bool {
+	repo, _ := splitRepoAndRef(cloneURL)
+	repo = strings.TrimSpace(repo)
+	if strings.HasPrefix(repo, "file://") {
+		return true
+	}
+	if strings.Contains(repo, "://") {
+		return false
+	}
+	p := expandPath(repo)
+	if filepath.IsAbs(p) || strings.HasPrefix(p, "./") || strings.HasPrefix(p, "../") ||
+		strings.HasPrefix(p, ".\\") || strings.HasPrefix(p, "..\\") {
+		return true
+	}
+	fi, err := os.Stat(p)
+	return err == nil && fi.IsDir()
+}
+
+var (
+	// missingCacheFileRe 匹配读取 file:// 缓存代理中缺失的版本文件，如 reading file:///.../cache/download/example.com/foo/@v/v1.2.3.info: ...
+	missingCacheFileRe = regexp.MustCompile(`reading file://\S*?/cache/download/(\S+?)/@v/([^/\s:]+)\.(?:info|mod|zip):`)
+	// missingCacheListRe 匹配缺失的版本列表（@latest 等查询），如 reading file:///.../cache/download/example.com/foo/@v/list: ...
+	missingCacheListRe = regexp.MustCompile(`reading file://\S*?/cache/download/(\S+?)/@v/list:`)
+	// lookupDisabledRe 匹配 GOPROXY=off 时的错误，如 go: example.com/foo@v1.2.3: module lookup disabled by GOPROXY=off
+	lookupDisabledRe = regexp.MustCompile(`(\S+@\S+?): (?:\S+ )*?module lookup disabled by GOPROXY=off`)
+)
+
+// missingModules 从 go install 的输出中解析模块缓存里缺失的模块（path@version，@latest 表示缺少版本列表），已排序去重
+func missingModules(output string) []string {
+	seen := map[string]struct{}{}
+	add := func(escaped, version string) {
+		p, err := module.UnescapePath(escaped)
+		if err != nil {
+			p = escaped
+		}
+		if v, err := module.UnescapeVersion(version); err == nil {
+			version = v
+		}
+		seen[p+"@"+version] = struct{}{}
+	}
+	for _, m := range missingCacheFileRe.FindAllStringSubmatch(output, -1) {
+		add(m[1], m[2])
+	}
+	for _, m := range missingCacheListRe.FindAllStringSubmatch(output, -1) {
+		add(m[1], "latest")
+	}
+	for _, m := range lookupDisabledRe.FindAllStringSubmatch(output, -1) {
+		seen[m[1]] = struct{}{}
+	}
+	mods := make([]string, 0, len(seen))
+	for m := range seen {
+		mods = append(mods, m)
+	}
+	slices.Sort(mods)
+	return mods
+}
+
+// offlineInstallError 在离线安装失败时给出缺失的模块列表；无法从输出中解析时返回原错误
+func offlineInstallError(output string, err error) error {
+	missing := missingModules(output)
+	if len(missing) == 0 {
+		return err
+	}
+	return fmt.Errorf("offline install failed: %d module(s) missing from the module cache: %s (run 'gocli tools prefetch' while online): %w",
+		len(missing), strings.Join(missing, ", "), err)
+}
@@ -0,0 +1,146 @@
+package tools
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/zip"
+)
+
+// writeProxyModule 在 proxyDir 中按 GOPROXY 协议布局写入一个模块版本（go.mod + main.go）
+func writeProxyModule(t *testing.T, proxyDir, path, version string) {
+	t.Helper()
+	src := t.TempDir()
+	gomod := "module " + path + "\n\ngo 1.21\n"
+	if err := os.WriteFile(filepath.Join(src, "go.mod"), []byte(gomod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	escaped, err := module.EscapePath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(proxyDir, filepath.FromSlash(escaped), "@v")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Create(filepath.Join(dir, version+".zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := zip.CreateFromDir(f, module.Version{Path: path, Version: version}, src); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+	files := map[string]string{
+		version + ".mod":  gomod,
+		version + ".info": `{"Version":"` + version + `","Time":"2024-01-01T00:00:00Z"}`,
+		"list":            version + "\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// 测试从 go install 输出中解析缺失的模块：file:// 缓存代理、版本列表与 GOPROXY=off 三种形式
+func TestMissingModules(t *testing.T) {
+	out := `go: example.com/tool/cmd/x@v1.2.3: example.com/tool/cmd/x@v1.2.3: reading file:///home/u/go/pkg/mod/cache/download/example.com/tool/cmd/x/@v/v1.2.3.info: no such file or directory
+go: example.com/tool@v1.2.3 requires github.com/!burnt!sushi/toml@v1.3.2: reading file:///C:/Users/u/go/pkg/mod/cache/download/github.com/!burnt!sushi/toml/@v/v1.3.2.mod: The system cannot find the file specified.
+go: example.com/other@latest: reading file:///home/u/go/pkg/mod/cache/download/example.com/other/@v/list: no such file or directory
+go: example.com/off@v0.1.0: module lookup disabled by GOPROXY=off
+go: example.com/dep@v0.2.0: loading deprecation for example.com/dep: module lookup disabled by GOPROXY=off`
+	want := []string{
+		"example.com/dep@v0.2.0",
+		"example.com/off@v0.1.0",
+		"example.com/other@latest",
+		"example.com/tool/cmd/x@v1.2.3",
+		"github.com/BurntSushi/toml@v1.3.2",
+	}
+	if got := missingModules(out); !slices.Equal(got, want) {
+		t.Errorf("missingModules = %v, want %v", got, want)
+	}
+	if got := missingModules("go: build failed: undefined: foo"); len(got) != 0 {
+		t.Errorf("unrelated errors should not report modules, got %v", got)
+	}
+}
+
+// 测试离线环境变量：GOFLAGS 中原有标志保留，-mod 被替换；clone 构建不覆盖 -mod
+func TestOfflineEnv(t *testing.T) {
+	env := offlineEnv([]string{"GOFLAGS=-trimpath -mod=readonly"}, "/cache", "vendor")
+	if got := envLookup(env, "GOFLAGS"); got != "-trimpath -mod=vendor" {
+		t.Errorf("GOFLAGS = %q", got)
+	}
+	for _, want := range []string{"GOSUMDB=off", "GONOSUMDB=*", "GOTOOLCHAIN=local", "GOPROXY=file:///cache/cache/download"} {
+		if !slices.Contains(env, want) {
+			t.Errorf("missing %s in %v", want, env)
+		}
+	}
+	if env := offlineEnv(nil, "/cache", ""); slices.ContainsFunc(env, func(kv string) bool { return strings.HasPrefix(kv, "GOFLAGS=") }) {
+		t.Errorf("clone builds should keep GOFLAGS untouched: %v", env)
+	}
+}
+
+// 测试离线时 clone 地址只允许本地仓库
+func TestIsLocalCloneURL(t *testing.T) {
+	dir := t.TempDir()
+	cases := map[string]bool{
+		"https://github.com/owner/repo.git#v1.0.0": false,
+		"git@github.com:owner/repo.git":            false,
+		"file:///srv/git/repo.git":                 true,
+		"./mirror/repo#main":                       true,
+		dir:                                        true,
+	}
+	for in, want := range cases {
+		if got := isLocalCloneURL(in); got != want {
+			t.Errorf("isLocalCloneURL(%q) = %v, want %v", in, got, want)
+		}
+	}
+	if _, err := InstallTool(InstallOptions{CloneURL: "https://github.com/owner/repo.git", Offline: true}); err == nil || !strings.Contains(err.Error(), "offline") {
+		t.Errorf("remote clone should be refused offline, got %v", err)
+	}
+}
+
+// 测试离线安装：缓存为空时报告缺失的模块；prefetch 从 file:// 代理填充缓存后，GOPROXY 不可用也能安装
+func TestOfflineInstallWithPrefetch(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not available")
+	}
+	proxy := t.TempDir()
+	writeProxyModule(t, proxy, "example.com/hello", "v1.0.0")
+	t.Setenv("GOMODCACHE", t.TempDir())
+	// 模块缓存默认只读，-modcacherw 让 t.TempDir 能清理
+	t.Setenv("GOFLAGS", "-modcacherw")
+	t.Setenv("GOPROXY", "off")
+	bin := t.TempDir()
+	spec := "example.com/hello@v1.0.0"
+
+	_, err := InstallTool(InstallOptions{Spec: spec, Path: bin, Offline: true})
+	if err == nil || !strings.Contains(err.Error(), "missing from the module cache: "+spec) {
+		t.Fatalf("expected missing module error, got %v", err)
+	}
+
+	proxyURL := filepath.ToSlash(proxy)
+	if !strings.HasPrefix(proxyURL, "/") {
+		proxyURL = "/" + proxyURL
+	}
+	if out, err := prefetchSpec(spec, []string{"GOPROXY=file://" + proxyURL, "GOSUMDB=off"}); err != nil {
+		t.Fatalf("prefetch failed: %v\n%s", err, out)
+	}
+	res, err := InstallTool(InstallOptions{Spec: spec, Path: bin, Offline: true})
+	if err != nil {
+		t.Fatalf("offline install failed: %v\n%s", err, res.Output)
+	}
+	if _, err := os.Stat(filepath.Join(bin, targetBinaryName("hello", runtime.GOOS))); err != nil {
+		t.Errorf("binary not installed: %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/yeisme/gocli/pkg/configs"
+)
+
+// PrefetchOptions 定义 tools prefetch 的参数
+type PrefetchOptions struct {
+	// Env 额外环境变量，如 GOPROXY=https://goproxy.cn
+	Env     []string
+	Verbose bool
+}
+
+// PrefetchResult 汇总一次预取：成功预取的 spec、失败原因（spec -> error）以及未预取的工具与原因
+type PrefetchResult struct {
+	Fetched []string
+	Failed  map[string]error
+	Skipped []string
+}
+
+// prefetchSpecs 收集配置中（deps 与 global）所有 go install 类工具的 spec，按出现顺序去重：
+//   - 与 batch install 相同，优先使用内置/用户映射中的 URL；
+//   - clone 构建与本地路径没有可预取的模块，记录到 skipped
+func prefetchSpecs(cfg *configs.Config) (specs, skipped []string) {
+	seen := map[string]bool{}
+	for _, t := range append(append([]configs.Tool{}, cfg.Tools.Deps...), cfg.Tools.Global...) {
+		spec := ""
+		if bi := resolveInstallInfo(buildCandidatesFromTool(t), cfg.Tools.ToolsConfigDir); bi != nil {
+			if strings.TrimSpace(bi.URL) == "" {
+				skipped = append(skipped, bi.Name+": clone-based tool (mirror the repository locally for offline installs)")
+				continue
+			}
+			spec = bi.URL
+		} else {
+			switch strings.ToLower(strings.TrimSpace(t.Type)) {
+			case "", "go":
+				spec = strings.TrimSpace(t.Module)
+				if spec == "" && strings.TrimSpace(t.Cmd) != "" {
+					s, err := ParseGoInstallSpec(t.Cmd)
+					if err != nil {
+						skipped = append(skipped, t.Cmd+": "+err.Error())
+						continue
+					}
+					spec = s
+				}
+			default:
+				skipped = append(skipped, firstNonEmpty(t.CloneURL, t.Type)+": clone-based tool (mirror the repository locally for offline installs)")
+				continue
+			}
+		}
+		if spec == "" {
+			continue
+		}
+		spec = ensureVersionSuffix(spec)
+		if !strings.Contains(spec, "@") {
+			skipped = append(skipped, spec+": local path")
+			continue
+		}
+		if !seen[spec] {
+			seen[spec] = true
+			specs = append(specs, spec)
+		}
+	}
+	return specs, skipped
+}
+
+// prefetchSpec 在临时模块中执行 `go get <spec>` 与 `go mod download`，把工具模块及其构建所需的全部依赖下载到模块缓存。
+// go mod download 只接受模块路径，而 spec 通常是模块内的包路径，因此由 go get 完成包到模块的解析；
+// 临时模块没有其他依赖，解析出的版本与 go install spec 一致
+func prefetchSpec(spec string, env []string) (string, error) {
+	dir, err := os.MkdirTemp("", "gocli-prefetch-*")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	// 临时模块内忽略用户的 -mod=vendor 等设置
+	env = withModFlag(append(append([]string{}, env...), "GOWORK=off"), "mod")
+	var out strings.Builder
+	for _, args := range [][]string{
+		{"mod", "init", "gocli-prefetch"},
+		{"get", spec},
+		{"mod", "download"},
+	} {
+		o, err := toolExecutor("go", args...).WithDir(dir).WithEnv(env...).CombinedOutput()
+		out.WriteString(o)
+		if err != nil {
+			return out.String(), err
+		}
+	}
+	return out.String(), nil
+}
+
+// PrefetchConfiguredTools 联网下载配置中所有 go install 类工具的模块（tools prefetch），
+// 之后即可在离线环境中使用 tools install --offline 安装；逐个输出结果，任一失败时返回错误
+func PrefetchConfiguredTools(cfg *configs.Config, opts PrefetchOptions, out io.Writer) (PrefetchResult, error) {
+	res := PrefetchResult{Failed: map[string]error{}}
+	if cfg == nil {
+		return res, fmt.Errorf("config is nil")
+	}
+	specs, skipped := prefetchSpecs(cfg)
+	res.Skipped = skipped
+	for _, s := range skipped {
+		fmt.Fprintf(out, "skipped %s\n", s)
+	}
+	if len(specs) == 0 {
+		fmt.Fprintln(out, "no go install tools configured; nothing to prefetch")
+		return res, nil
+	}
+	for _, spec := range specs {
+		o, err := prefetchSpec(spec, opts.Env)
+		if opts.Verbose && strings.TrimSpace(o) != "" {
+			fmt.Fprint(out, o)
+		}
+		if err != nil {
+			res.Failed[spec] = err
+			fmt.Fprintf(out, "failed %s: %v\n", spec, err)
+			continue
+		}
+		res.Fetched = append(res.Fetched, spec)
+		fmt.Fprintf(out, "prefetched %s\n", spec)
+	}
+	if len(res.Failed) > 0 {
+		return res, fmt.Errorf("%d of %d tool(s) failed to prefetch", len(res.Failed), len(specs))
+	}
+	return res, nil
+}
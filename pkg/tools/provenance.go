@@ -0,0 +1,137 @@
+package tools
+
+import (
+	"debug/buildinfo"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// BinaryProvenance 描述已安装二进制的来源，数据取自 Go build info
+type BinaryProvenance struct {
+	// Path 二进制文件路径
+	Path string
+	// Module 主模块路径（build info 的 Main.Path）
+	Module string
+	// Package main 包路径（build info 的 Path）
+	Package string
+	// Version 主模块版本，例如 v1.2.3 或 (devel)
+	Version string
+}
+
+// ReadBinaryProvenance 读取二进制的 Go build info；非 Go 程序或被剥离 build info 时返回错误
+func ReadBinaryProvenance(path string) (*BinaryProvenance, error) {
+	bi, err := buildinfo.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read build info of %s failed: %w", path, err)
+	}
+	return &BinaryProvenance{
+		Path:    path,
+		Module:  bi.Main.Path,
+		Package: bi.Path,
+		Version: bi.Main.Version,
+	}, nil
+}
+
+// ExpectedModules 返回工具定义中可用于归属校验的路径：go install 的模块/包路径（去掉 @version）
+// 以及 clone 仓库地址（去掉协议、#ref 与 .git 后缀，例如 github.com/owner/repo）
+func ExpectedModules(info *InstallToolsInfo) []string {
+	if info == nil {
+		return nil
+	}
+	var out []string
+	if spec := strings.TrimSpace(info.URL); spec != "" {
+		if i := strings.Index(spec, "@"); i > 0 {
+			spec = spec[:i]
+		}
+		out = append(out, strings.ToLower(strings.TrimSuffix(spec, "/")))
+	}
+	if clone := strings.TrimSpace(info.CloneURL); clone != "" {
+		repo, _ := splitRepoAndRef(clone)
+		if p := repoModulePath(repo); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// repoModulePath 将仓库地址规范化为模块路径形式
+//   - https://github.com/owner/repo.git -> github.com/owner/repo
+//   - git@github.com:owner/repo.git -> github.com/owner/repo
+func repoModulePath(repo string) string {
+	p := strings.TrimSpace(repo)
+	if i := strings.Index(p, "://"); i >= 0 {
+		p = p[i+3:]
+		// 去掉 user@ 前缀（如 ssh://git@host/...）
+		if at := strings.Index(p, "@"); at >= 0 && at < strings.Index(p+"/", "/") {
+			p = p[at+1:]
+		}
+	} else if at := strings.Index(p, "@"); at >= 0 {
+		// scp 风格：git@host:owner/repo
+		p = strings.Replace(p[at+1:], ":", "/", 1)
+	}
+	p = strings.TrimSuffix(strings.TrimSuffix(p, "/"), ".git")
+	return strings.ToLower(p)
+}
+
+// MatchesProvenance 判断二进制是否可归属到 expected 中的任一路径：
+// main 包路径或主模块路径等于该路径，或位于该路径之下
+func MatchesProvenance(p *BinaryProvenance, expected []string) bool {
+	if p == nil {
+		return false
+	}
+	for _, exp := range expected {
+		if exp == "" {
+			continue
+		}
+		for _, got := range []string{p.Package, p.Module} {
+			got = strings.ToLower(got)
+			if got == exp || strings.HasPrefix(got, exp+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// VerifyToolBinary 校验 path 处的二进制是否由 expected 描述的工具构建
+// 无法归属时返回 false 与原因（用于提示，例如 "different module: example.com/other"）
+func VerifyToolBinary(path string, expected []string) (bool, string) {
+	if len(expected) == 0 {
+		return false, "no known module for this tool"
+	}
+	p, err := ReadBinaryProvenance(path)
+	if err != nil {
+		return false, "no Go build info"
+	}
+	if MatchesProvenance(p, expected) {
+		return true, ""
+	}
+	module := p.Module
+	if module == "" {
+		module = p.Package
+	}
+	return false, "different module: " + module
+}
+
+// ensureWithinDirs 确认 path（解析符号链接后）位于 dirs 之一中，防止通过符号链接操作扫描目录之外的文件
+func ensureWithinDirs(path string, dirs []string) error {
+	real, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return fmt.Errorf("resolve %s failed: %w", path, err)
+	}
+	real, _ = filepath.Abs(real)
+	for _, d := range dirs {
+		rd, err := filepath.EvalSymlinks(d)
+		if err != nil {
+			continue
+		}
+		rd, _ = filepath.Abs(rd)
+		rel, err := filepath.Rel(rd, real)
+		if err != nil || filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		return nil
+	}
+	return fmt.Errorf("%s resolves to %s, outside the scanned tool directories", path, real)
+}
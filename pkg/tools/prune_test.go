@@ -0,0 +1,52 @@
+package tools
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// 测试 --prune：只删除安装前已存在、未被更新且 main 包与新二进制相同的文件
+func TestPruneSuperseded(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script fixture is not portable to windows")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+	dir := t.TempDir()
+	// 旧名称的 gooddemo（之前以 --binary-name olddemo 安装）、无关的 otherdemo 与没有 build info 的脚本
+	buildFixture(t, "gooddemo", filepath.Join(dir, "olddemo"))
+	buildFixture(t, "otherdemo", filepath.Join(dir, "otherdemo"))
+	if err := os.WriteFile(filepath.Join(dir, "script"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	pre := SnapshotExecutables(dir)
+
+	if pruned, err := PruneSuperseded(dir, pre); err != nil || len(pruned) != 0 {
+		t.Fatalf("nothing was installed, pruned %v (err %v)", pruned, err)
+	}
+
+	buildFixture(t, "gooddemo", filepath.Join(dir, "gooddemo"))
+	pruned, err := PruneSuperseded(dir, pre)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pruned) != 1 || filepath.Base(pruned[0]) != "olddemo" {
+		t.Fatalf("pruned = %v", pruned)
+	}
+	for _, name := range []string{"gooddemo", "otherdemo", "script"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s should be kept: %v", name, err)
+		}
+	}
+
+	var out strings.Builder
+	printInstallResult(InstallResult{InstallDir: dir, Pruned: pruned}, nil, &out)
+	if !strings.Contains(out.String(), "pruned superseded binary: ") || !strings.Contains(out.String(), "olddemo") {
+		t.Errorf("unexpected install output:\n%s", out.String())
+	}
+}
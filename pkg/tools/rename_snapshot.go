@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
@@ -13,7 +14,7 @@ import (
 
 // DetermineGoBinDir 尝试通过 `go env` 推断 GOBIN 或 GOPATH/bin
 func DetermineGoBinDir() string {
-	gobin, _ := executor.NewExecutor("go", "env", "GOBIN").Output()
+	gobin, _ := executor.NewExecutor("go", "env", "GOBIN").ReadOnly().Output()
 	gobin = strings.TrimSpace(gobin)
 	if gobin != "" {
 		if abs, _ := filepath.Abs(expandPath(gobin)); abs != "" {
@@ -21,7 +22,7 @@ func DetermineGoBinDir() string {
 		}
 		return expandPath(gobin)
 	}
-	gopath, _ := executor.NewExecutor("go", "env", "GOPATH").Output()
+	gopath, _ := executor.NewExecutor("go", "env", "GOPATH").ReadOnly().Output()
 	gopath = strings.TrimSpace(gopath)
 	if gopath == "" {
 		return ""
@@ -66,21 +67,58 @@ func RenameInstalledBinary(dir string, pre map[string]time.Time, targetName stri
 		return nil
 	}
 	oldName := candidates[0]
-	newName := targetName
-	lowerOld := strings.ToLower(oldName)
-	lowerNew := strings.ToLower(newName)
-	if runtime.GOOS == "windows" {
-		if !strings.HasSuffix(lowerNew, ".exe") && strings.HasSuffix(lowerOld, ".exe") {
-			newName += ".exe"
-		}
-	}
+	newName := renamedBinaryName(runtime.GOOS, oldName, targetName)
 	oldPath := filepath.Join(dir, oldName)
 	newPath := filepath.Join(dir, newName)
 	if oldPath == newPath {
 		return nil
 	}
-	if err := os.Rename(oldPath, newPath); err != nil {
-		return fmt.Errorf("rename %s -> %s failed: %w", oldPath, newPath, err)
+	// 目标已存在时先删除再改名（Windows 上 os.Rename 不会覆盖），并对临时的文件锁重试
+	if err := replaceFile(oldPath, newPath); err != nil {
+		return fmt.Errorf("rename %s -> %s failed: %w", displayPath(oldPath), displayPath(newPath), err)
 	}
 	return nil
 }
+
+// PruneSuperseded 删除 dir 中被本次安装取代的旧二进制，返回被删除文件的路径：
+// 安装前已存在（在快照 pre 中）、本次没有被更新，且 Go build info 中的 main 包与本次新增/更新的某个二进制相同。
+// 典型场景是改用 --binary-name 重新安装后留下的旧文件名；没有 build info 的文件从不删除
+func PruneSuperseded(dir string, pre map[string]time.Time) ([]string, error) {
+	after := SnapshotExecutables(dir)
+	fresh := map[string]bool{}
+	for name, mt := range after {
+		if pmt, ok := pre[name]; !ok || mt.After(pmt) {
+			fresh[name] = true
+		}
+	}
+	pkgs := map[string]string{} // main 包 -> 本次安装的文件名
+	for name := range fresh {
+		if prov, err := ReadBinaryProvenance(filepath.Join(dir, name)); err == nil && prov.Package != "" {
+			pkgs[prov.Package] = name
+		}
+	}
+	if len(pkgs) == 0 {
+		return nil, nil
+	}
+	names := make([]string, 0, len(after))
+	for name := range after {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var pruned []string
+	for _, name := range names {
+		if _, existed := pre[name]; !existed || fresh[name] {
+			continue
+		}
+		p := filepath.Join(dir, name)
+		prov, err := ReadBinaryProvenance(p)
+		if err != nil || pkgs[prov.Package] == "" {
+			continue
+		}
+		if err := os.Remove(p); err != nil {
+			return pruned, fmt.Errorf("prune %s failed: %w", displayPath(p), err)
+		}
+		pruned = append(pruned, p)
+	}
+	return pruned, nil
+}
@@ -0,0 +1,109 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/style"
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// 网络相关步骤（git clone、go install 下载模块）的默认重试策略
+const (
+	defaultRetryAttempts = 3
+	defaultRetryDelay    = 2 * time.Second
+	maxRetryDelay        = 30 * time.Second
+)
+
+var (
+	// retrySleep 重试前的等待，测试中替换以避免真实休眠
+	retrySleep = time.Sleep
+	// retryLog 输出重试提示，与 spinner 共用输出锁
+	retryLog io.Writer = style.SyncWriter(os.Stderr)
+)
+
+// transientPatterns 命令输出或错误中出现这些片段时视为可能是暂时性的网络故障，值得重试；
+// 编译错误、版本不存在等其他失败重试也不会成功，直接返回
+var transientPatterns = []string{
+	"i/o timeout",
+	"timed out",
+	"timeout awaiting",
+	"tls handshake timeout",
+	"connection reset",
+	"connection refused",
+	"dial tcp",
+	"temporary failure in name resolution",
+	"could not resolve host",
+	"unexpected eof",
+	"early eof",
+	"rpc failed",
+	"the remote end hung up",
+	"502 bad gateway",
+	"503 service unavailable",
+	"504 gateway",
+}
+
+// retryPolicy 描述重试次数与指数退避的基础间隔
+type retryPolicy struct {
+	attempts int
+	delay    time.Duration
+}
+
+// newRetryPolicy 返回重试策略：attempts 为总尝试次数（<=0 时为 3，1 表示不重试），delay 为首次重试前的等待（<=0 时为 2s）
+func newRetryPolicy(attempts int, delay time.Duration) retryPolicy {
+	if attempts <= 0 {
+		attempts = defaultRetryAttempts
+	}
+	if delay <= 0 {
+		delay = defaultRetryDelay
+	}
+	return retryPolicy{attempts: attempts, delay: delay}
+}
+
+// backoff 返回第 n 次失败后的等待时间：delay * 2^(n-1)，不超过 maxRetryDelay
+func (p retryPolicy) backoff(n int) time.Duration {
+	d := p.delay
+	for i := 1; i < n && d < maxRetryDelay; i++ {
+		d *= 2
+	}
+	return min(d, maxRetryDelay)
+}
+
+// do 执行 fn，失败且看起来是暂时性网络故障时按指数退避重试，每次重试输出一行提示；
+// dry-run（录制模式）下命令不会真正执行，不重试
+func (p retryPolicy) do(what string, fn func() (string, error)) (string, error) {
+	for attempt := 1; ; attempt++ {
+		out, err := fn()
+		if err == nil || attempt >= p.attempts || executor.Recording() || !isTransientFailure(out, err) {
+			return out, err
+		}
+		wait := p.backoff(attempt)
+		fmt.Fprintf(retryLog, "[gocli][tools] %s failed (attempt %d/%d): %s; retrying in %s\n", what, attempt, p.attempts, failureSummary(out, err), wait)
+		retrySleep(wait)
+	}
+}
+
+// isTransientFailure 报告失败是否像暂时性的网络故障
+func isTransientFailure(out string, err error) bool {
+	text := strings.ToLower(out + "\n" + err.Error())
+	for _, p := range transientPatterns {
+		if strings.Contains(text, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// failureSummary 返回命令输出中包含故障原因的最后一个非空行，没有输出时使用错误本身
+func failureSummary(out string, err error) string {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if l := strings.TrimSpace(lines[i]); l != "" && isTransientFailure(l, err) {
+			return l
+		}
+	}
+	return err.Error()
+}
@@ -0,0 +1,68 @@
+package tools
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 测试重试策略：暂时性网络故障按指数退避重试直到成功或次数用尽，其他失败不重试
+func TestRetryPolicy(t *testing.T) {
+	var waits []time.Duration
+	var log strings.Builder
+	savedSleep, savedLog := retrySleep, retryLog
+	retrySleep = func(d time.Duration) { waits = append(waits, d) }
+	retryLog = &log
+	t.Cleanup(func() { retrySleep, retryLog = savedSleep, savedLog })
+
+	p := newRetryPolicy(4, time.Second)
+	calls := 0
+	out, err := p.do("git clone x", func() (string, error) {
+		calls++
+		if calls < 3 {
+			return "Cloning into 'x'...\nfatal: unable to access 'x': Could not resolve host: example.com", errors.New("exit status 128")
+		}
+		return "ok", nil
+	})
+	if err != nil || out != "ok" || calls != 3 {
+		t.Fatalf("out %q err %v after %d calls", out, err, calls)
+	}
+	if len(waits) != 2 || waits[0] != time.Second || waits[1] != 2*time.Second {
+		t.Errorf("backoff waits = %v", waits)
+	}
+	if !strings.Contains(log.String(), "git clone x failed (attempt 1/4): fatal: unable to access 'x': Could not resolve host") {
+		t.Errorf("unexpected retry log:\n%s", log.String())
+	}
+
+	// 非网络故障立即返回
+	calls = 0
+	if _, err := p.do("go install y", func() (string, error) {
+		calls++
+		return "main.go:3:1: syntax error", errors.New("exit status 1")
+	}); err == nil || calls != 1 {
+		t.Errorf("compile errors should not be retried: err %v, %d calls", err, calls)
+	}
+
+	// 次数用尽后返回最后一次的错误
+	calls = 0
+	if _, err := newRetryPolicy(2, time.Millisecond).do("go install z", func() (string, error) {
+		calls++
+		return "", errors.New("dial tcp 1.2.3.4:443: i/o timeout")
+	}); err == nil || calls != 2 {
+		t.Errorf("expected 2 attempts, got %d (err %v)", calls, err)
+	}
+
+	if d := newRetryPolicy(0, 0).backoff(10); d != maxRetryDelay {
+		t.Errorf("backoff cap = %v", d)
+	}
+}
+
+// 测试 --retry-attempts/--retry-delay 传递到 InstallOptions
+func TestBuildInstallOptionsRetry(t *testing.T) {
+	opts := buildInstallOptions("example.com/x@latest", "", "", "", nil, nil, nil, false, false, false, "", "", nil, "", "",
+		InstallCommandOptions{InstallOptions: InstallOptions{RetryAttempts: 5, RetryDelay: 7 * time.Second}})
+	if opts.RetryAttempts != 5 || opts.RetryDelay != 7*time.Second {
+		t.Errorf("retry options not passed through: attempts=%d delay=%s", opts.RetryAttempts, opts.RetryDelay)
+	}
+}
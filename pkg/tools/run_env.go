@@ -0,0 +1,51 @@
+package tools
+
+import (
+	"os"
+	"path"
+	"runtime"
+	"strings"
+
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// applyRunEnv 按 RunOptions 设置工具进程的环境变量
+//   - 默认继承当前环境，并附加 base（工具表中的 env）与 --env
+//   - --env-clean / --env-allow 时只保留 allowlist 匹配的变量，再附加 base 与 --env
+func applyRunEnv(ex *executor.Executor, opts RunOptions, base []string) {
+	extra := append(append([]string(nil), base...), opts.Env...)
+	if !opts.EnvClean && len(opts.EnvAllow) == 0 {
+		if len(extra) > 0 {
+			ex.WithEnv(extra...)
+		}
+		return
+	}
+	ex.WithCleanEnv(append(filterEnv(os.Environ(), opts.EnvAllow), extra...)...)
+}
+
+// filterEnv 返回 environ 中变量名匹配任一 allow 模式（path.Match 语法，如 GO*）的条目
+// Windows 下变量名不区分大小写
+func filterEnv(environ, allow []string) []string {
+	var kept []string
+	for _, kv := range environ {
+		name, _, ok := strings.Cut(kv, "=")
+		if !ok || name == "" {
+			continue
+		}
+		for _, pattern := range allow {
+			if envNameMatch(pattern, name) {
+				kept = append(kept, kv)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+func envNameMatch(pattern, name string) bool {
+	if runtime.GOOS == "windows" {
+		pattern, name = strings.ToUpper(pattern), strings.ToUpper(name)
+	}
+	ok, err := path.Match(pattern, name)
+	return err == nil && ok
+}
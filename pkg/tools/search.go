@@ -126,6 +126,20 @@ type SearchCommandOptions struct {
 	YAML      bool
 	Table     bool
 	ConfigDir []string
+
+	// Interactive 标准输入输出是终端时为 true：无参数搜索使用多选并提供安装；否则保持单选后输出
+	Interactive bool
+	// Install 选择后默认执行安装（确认提示默认 Y）
+	Install bool
+	// Global 安装到 ~/.gocli/tools（同 tools install --global）
+	Global         bool
+	GoCLIToolsPath string
+	Verbose        bool
+	Quiet          bool
+	// Input 确认提示的输入源（默认 os.Stdin）
+	Input io.Reader
+	// Picker 多选实现，为空时使用 InteractiveSelectMulti
+	Picker Picker
 }
 
 // ExecuteSearchCommand 执行搜索命令
@@ -167,6 +181,13 @@ func ExecuteSearchCommand(opts SearchCommandOptions, outputWriter io.Writer) err
 			all = append(all, t)
 		}
 		sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+		if opts.Interactive {
+			picker := opts.Picker
+			if picker == nil {
+				picker = InteractiveSelectMulti
+			}
+			return pickAndInstall(all, picker, opts, fmtFlag, outputWriter)
+		}
 		sel, err := InteractiveSelect(all)
 		if err != nil {
 			return fmt.Errorf("interactive select failed: %w", err)
@@ -178,9 +199,12 @@ func ExecuteSearchCommand(opts SearchCommandOptions, outputWriter io.Writer) err
 	}
 	matches := FindToolsFuzzy(query, opts.ConfigDir)
 	if len(matches) == 0 {
-		if fmtFlag == "json" || fmtFlag == "yaml" {
+		switch fmtFlag {
+		case "json", "yaml":
 			fmt.Fprintln(outputWriter, "null")
-		} else {
+		case "toml":
+			// TOML 没有 null，输出空文档
+		default:
 			return fmt.Errorf("tool not found: %s", query)
 		}
 		return nil
@@ -198,6 +222,9 @@ func printMultipleTools(matches []InstallToolsInfo, fmtFlag string, out io.Write
 		return json.NewEncoder(out).Encode(matches)
 	case "yaml":
 		return yaml.NewEncoder(out).Encode(matches)
+	case "toml":
+		// TOML 顶层必须是表，多条结果放在 tools 数组中
+		return style.PrintTOML(out, map[string][]InstallToolsInfo{"tools": matches})
 	case "table":
 		headers := []string{"Name", "URL", "CloneURL", "Build"}
 		rows := make([][]string, 0, len(matches))
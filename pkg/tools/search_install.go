@@ -0,0 +1,158 @@
+package tools
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ktr0731/go-fuzzyfinder"
+)
+
+// Picker 在候选工具中交互选择，返回选中的工具（可多选，未选择时返回空）；测试中可替换为假实现
+type Picker func(matches []InstallToolsInfo) ([]InstallToolsInfo, error)
+
+// InteractiveSelectMulti 使用 fuzzyfinder 在候选中多选：Tab 标记，Enter 确认；Esc/Ctrl-C 放弃时返回空选择
+func InteractiveSelectMulti(matches []InstallToolsInfo) ([]InstallToolsInfo, error) {
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no matches to select")
+	}
+	idxs, err := fuzzyfinder.FindMulti(matches, func(i int) string {
+		m := matches[i]
+		return fmt.Sprintf("%s — %s %s", m.Name, m.URL, m.CloneURL)
+	})
+	if errors.Is(err, fuzzyfinder.ErrAbort) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	selected := make([]InstallToolsInfo, 0, len(idxs))
+	for _, i := range idxs {
+		if i < 0 || i >= len(matches) {
+			return nil, fmt.Errorf("invalid selection")
+		}
+		selected = append(selected, matches[i])
+	}
+	return selected, nil
+}
+
+// InstallPlan 汇总一次多选安装：安装目录与按选择顺序排列的工具名
+type InstallPlan struct {
+	Dir   string   // 安装目录，为空时由 go install 决定（GOBIN 或 GOPATH/bin）
+	Tools []string // 工具名
+}
+
+// String 返回单行计划描述，例如 "will install 3 tools to ~/.gocli/tools: a, b, c"
+func (p InstallPlan) String() string {
+	noun := "tools"
+	if len(p.Tools) == 1 {
+		noun = "tool"
+	}
+	dir := "GOBIN"
+	if p.Dir != "" {
+		dir = homeRelPath(p.Dir)
+	}
+	return fmt.Sprintf("will install %d %s to %s: %s", len(p.Tools), noun, dir, strings.Join(p.Tools, ", "))
+}
+
+// BuildInstallPlan 根据选中的工具与搜索选项（--global、tools.path）生成安装计划，重复选择的工具只保留一次
+func BuildInstallPlan(selected []InstallToolsInfo, opts SearchCommandOptions) (InstallPlan, error) {
+	dir, _, err := resolveInstallPath(InstallCommandOptions{Global: opts.Global, GoCLIToolsPath: opts.GoCLIToolsPath})
+	if err != nil {
+		return InstallPlan{}, err
+	}
+	plan := InstallPlan{Dir: dir}
+	seen := map[string]bool{}
+	for _, t := range selected {
+		if t.Name == "" || seen[t.Name] {
+			continue
+		}
+		seen[t.Name] = true
+		plan.Tools = append(plan.Tools, t.Name)
+	}
+	return plan, nil
+}
+
+// pickAndInstall 是无参数交互搜索的多选流程：
+//   - 通过 picker 选择工具；未开启 --install 时先按 fmtFlag 输出选中的工具
+//   - 生成安装计划并询问一次是否安装（--install 时默认安装 [Y/n]，否则默认不安装 [y/N]）
+//   - 确认后逐个通过 ExecuteInstallCommand 安装（不再逐个确认），失败的工具输出其错误，不影响其余工具
+func pickAndInstall(all []InstallToolsInfo, picker Picker, opts SearchCommandOptions, fmtFlag string, out io.Writer) error {
+	selected, err := picker(all)
+	if err != nil {
+		return fmt.Errorf("interactive select failed: %w", err)
+	}
+	if len(selected) == 0 {
+		fmt.Fprintln(out, "no tool selected.")
+		return nil
+	}
+	if !opts.Install {
+		if len(selected) == 1 {
+			err = PrintSingleTool(&selected[0], fmtFlag, out)
+		} else {
+			err = printMultipleTools(selected, fmtFlag, out)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	plan, err := BuildInstallPlan(selected, opts)
+	if err != nil {
+		return err
+	}
+	prompt := "Install? [y/N]: "
+	if opts.Install {
+		prompt = "Proceed? [Y/n]: "
+	}
+	fmt.Fprintf(out, "Plan: %s\n%s", plan, prompt)
+	in := opts.Input
+	if in == nil {
+		in = os.Stdin
+	}
+	ans, _ := bufio.NewReader(in).ReadString('\n')
+	ans = strings.TrimSpace(strings.ToLower(ans))
+	proceed := ans == "y" || ans == "yes" || (opts.Install && ans == "")
+	if !proceed {
+		fmt.Fprintln(out, "skipped install.")
+		return nil
+	}
+
+	var failed []string
+	for _, name := range plan.Tools {
+		err := ExecuteInstallCommand(InstallCommandOptions{
+			Args:           []string{name},
+			InstallOptions: InstallOptions{Path: plan.Dir, Verbose: opts.Verbose},
+			Global:         opts.Global,
+			Quiet:          opts.Quiet,
+			GoCLIToolsPath: opts.GoCLIToolsPath,
+			ToolsConfigDir: opts.ConfigDir,
+			Yes:            true,
+		}, out)
+		if err != nil {
+			fmt.Fprintf(out, "failed to install %s: %v\n", name, err)
+			failed = append(failed, name)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d tool(s) failed to install: %s", len(failed), len(plan.Tools), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// homeRelPath 把用户主目录下的路径写成 ~/...，便于在提示中阅读
+func homeRelPath(p string) string {
+	p = filepath.Clean(p)
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return p
+	}
+	if rel, err := filepath.Rel(home, p); err == nil && filepath.IsLocal(rel) {
+		return filepath.Join("~", rel)
+	}
+	return p
+}
@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// 测试交互搜索的多选安装：假 picker 选择多个工具，计划汇总为一行，确认后逐个 go install 到同一目录
+func TestPickAndInstall(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("HOME", root)
+	saved := BuiltinTools
+	BuiltinTools = map[string]InstallToolsInfo{
+		"alpha": {Name: "alpha", URL: "example.com/alpha@latest"},
+		"beta":  {Name: "beta", URL: "example.com/beta/cmd/beta@v1.0.0"},
+		"gamma": {Name: "gamma", URL: "example.com/gamma@latest"},
+	}
+	t.Cleanup(func() { BuiltinTools = saved })
+
+	all := []InstallToolsInfo{BuiltinTools["alpha"], BuiltinTools["beta"], BuiltinTools["gamma"]}
+	picker := func(matches []InstallToolsInfo) ([]InstallToolsInfo, error) {
+		return []InstallToolsInfo{matches[2], matches[0], matches[2]}, nil
+	}
+
+	plan, err := BuildInstallPlan(all[:2], SearchCommandOptions{Global: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "will install 2 tools to " + filepath.Join("~", ".gocli", "tools") + ": alpha, beta"; plan.String() != want {
+		t.Errorf("plan = %q, want %q", plan.String(), want)
+	}
+
+	// 未开启 --install 时默认不安装：空回答跳过
+	var out bytes.Buffer
+	opts := SearchCommandOptions{Interactive: true, GoCLIToolsPath: filepath.Join(root, "bin"), Input: strings.NewReader("\n")}
+	rec := executor.StartRecording()
+	defer executor.StopRecording()
+	if err := pickAndInstall(all, picker, opts, "table", &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "gamma") || !strings.Contains(out.String(), "skipped install.") || len(rec.Records()) != 0 {
+		t.Errorf("expected printed selection and no install:\n%s\n%v", out.String(), rec.Records())
+	}
+
+	// --install 时空回答即确认
+	out.Reset()
+	opts.Install = true
+	opts.Input = strings.NewReader("\n")
+	if err := pickAndInstall(all, picker, opts, "table", &out); err != nil {
+		t.Fatalf("%v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "Plan: will install 2 tools to ") || !strings.Contains(out.String(), ": gamma, alpha\n") {
+		t.Errorf("missing plan line:\n%s", out.String())
+	}
+	var installs []string
+	for _, r := range rec.Records() {
+		if r.Name == "go" && len(r.Args) > 0 && r.Args[0] == "install" {
+			installs = append(installs, r.String())
+		}
+	}
+	if len(installs) != 2 || !strings.Contains(installs[0], "example.com/gamma@latest") || !strings.Contains(installs[1], "example.com/alpha@latest") {
+		t.Fatalf("unexpected installs:\n%s", strings.Join(installs, "\n"))
+	}
+	for _, i := range installs {
+		if !strings.Contains(i, "GOBIN="+filepath.Join(root, "bin")) {
+			t.Errorf("install does not target the planned directory: %s", i)
+		}
+	}
+
+	// 安装失败时输出该工具的错误，其余工具照常安装（离线模式拒绝克隆远程仓库）
+	out.Reset()
+	opts.Input = strings.NewReader("y\n")
+	BuiltinTools["delta"] = InstallToolsInfo{Name: "delta", CloneURL: "https://example.com/delta.git"}
+	viper.Set("tools.offline", true)
+	t.Cleanup(func() { viper.Set("tools.offline", false) })
+	broken := func([]InstallToolsInfo) ([]InstallToolsInfo, error) {
+		return []InstallToolsInfo{BuiltinTools["delta"], BuiltinTools["alpha"]}, nil
+	}
+	err = pickAndInstall(all, broken, opts, "table", &out)
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 tool(s) failed to install: delta") {
+		t.Fatalf("err = %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "failed to install delta: offline install cannot clone") {
+		t.Errorf("missing the install error of delta:\n%s", out.String())
+	}
+}
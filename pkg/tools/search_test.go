@@ -0,0 +1,47 @@
+package tools
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	toml "github.com/pelletier/go-toml/v2"
+)
+
+// 测试 --format toml：单个工具为一张表，多个工具放在 tools 数组中，输出可解析回原值
+func TestPrintToolsTOML(t *testing.T) {
+	t.Setenv("FORCE_COLOR", "")
+	tools := []InstallToolsInfo{
+		{Name: "alpha", URL: "example.com/alpha@latest", BinDirs: []string{"."}, Env: []string{"CGO_ENABLED=0"}, Tags: []string{"a", "b"}},
+		{Name: "beta", CloneURL: "https://example.com/beta.git#v1.0.0", Build: "make", BinDirs: []string{"bin"}, Env: []string{"GOFLAGS=-trimpath"}, Tags: []string{"netgo"}},
+	}
+
+	var buf bytes.Buffer
+	if err := printMultipleTools(tools, "toml", &buf); err != nil {
+		t.Fatal(err)
+	}
+	var list struct{ Tools []InstallToolsInfo }
+	if err := toml.Unmarshal(buf.Bytes(), &list); err != nil {
+		t.Fatalf("output is not valid TOML: %v\n%s", err, buf.String())
+	}
+	if !reflect.DeepEqual(list.Tools, tools) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", list.Tools, tools)
+	}
+
+	buf.Reset()
+	if err := PrintSingleTool(&tools[1], "toml", &buf); err != nil {
+		t.Fatal(err)
+	}
+	var one InstallToolsInfo
+	if err := toml.Unmarshal(buf.Bytes(), &one); err != nil {
+		t.Fatalf("output is not valid TOML: %v\n%s", err, buf.String())
+	}
+	if !reflect.DeepEqual(one, tools[1]) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", one, tools[1])
+	}
+
+	buf.Reset()
+	if err := ExecuteSearchCommand(SearchCommandOptions{Query: "no-such-tool-xyz", Format: "toml"}, &buf); err != nil || buf.Len() != 0 {
+		t.Errorf("no match should print an empty document, got %q, %v", buf.String(), err)
+	}
+}
@@ -0,0 +1,129 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// SmokeCheck 是安装后对一个二进制的冒烟测试结果
+type SmokeCheck struct {
+	Binary  string `json:"binary"`           // 被检查的二进制路径
+	Command string `json:"command"`          // 成功的命令；全部失败时为最后尝试的命令
+	Output  string `json:"output,omitempty"` // 输出的第一行非空内容（stdout 优先）
+	Error   string `json:"error,omitempty"`  // 全部尝试都失败时的原因
+}
+
+// smokeTimeout 是每次冒烟测试命令的超时时间
+var smokeTimeout = 10 * time.Second
+
+// defaultSmokeArgs 是未配置 check 时依次尝试的参数，任一成功即可
+var defaultSmokeArgs = [][]string{{"--version"}, {"version"}, {"-h"}}
+
+// smokeDisabled 判断 check 是否关闭了冒烟测试
+func smokeDisabled(check string) bool {
+	switch strings.ToLower(strings.TrimSpace(check)) {
+	case "none", "off", "false", "skip":
+		return true
+	}
+	return false
+}
+
+// smokeCommands 根据 check 返回要依次尝试的参数列表：
+//   - 为空时使用 defaultSmokeArgs（<binary> --version || <binary> version || <binary> -h）；
+//   - 第一个字段是二进制名（或其路径）时视为完整命令，用安装的二进制替换它；
+//   - 否则视为传给二进制的参数，如 "--version"
+func smokeCommands(bin, check string) [][]string {
+	fields := strings.Fields(check)
+	if len(fields) == 0 {
+		return defaultSmokeArgs
+	}
+	name := stripExeSuffix(filepath.Base(bin))
+	if first := stripExeSuffix(filepath.Base(fields[0])); first == name {
+		fields = fields[1:]
+	}
+	return [][]string{fields}
+}
+
+// runSmokeCheck 运行二进制的冒烟测试，返回第一个成功（退出码为 0）的命令与其输出的第一行
+func runSmokeCheck(bin, check string) SmokeCheck {
+	res := SmokeCheck{Binary: bin}
+	for _, args := range smokeCommands(bin, check) {
+		res.Command = strings.TrimSpace(filepath.Base(bin) + " " + strings.Join(args, " "))
+		stdout, stderr, err := executor.NewExecutor(bin, args...).WithTimeout(smokeTimeout).Run()
+		if err == nil {
+			res.Output = firstLine(stdout, stderr)
+			res.Error = ""
+			return res
+		}
+		res.Error = err.Error()
+		if line := firstLine(stderr, stdout); line != "" {
+			res.Error += ": " + line
+		}
+	}
+	return res
+}
+
+// firstLine 返回第一个包含非空行的文本中的第一行非空内容
+func firstLine(texts ...string) string {
+	for _, s := range texts {
+		for line := range strings.SplitSeq(s, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				return line
+			}
+		}
+	}
+	return ""
+}
+
+// hostTarget 判断安装环境的 GOOS/GOARCH（env 优先，其次为进程环境变量）是否与当前平台一致
+func hostTarget(env []string) bool {
+	goos := firstNonEmpty(envLookup(env, "GOOS"), os.Getenv("GOOS"), runtime.GOOS)
+	goarch := firstNonEmpty(envLookup(env, "GOARCH"), os.Getenv("GOARCH"), runtime.GOARCH)
+	return goos == runtime.GOOS && goarch == runtime.GOARCH
+}
+
+// smokeTestInstalled 对本次安装的二进制运行冒烟测试；交叉编译的目标、dry-run 以及 check 关闭时不运行。
+// opts.StrictCheck 为 true 时删除检查失败的二进制并返回错误
+func smokeTestInstalled(opts InstallOptions, env []string, dir string, pre map[string]time.Time) ([]SmokeCheck, error) {
+	if dir == "" || executor.Recording() || smokeDisabled(opts.Check) || isCrossTarget(opts.TargetOS, opts.TargetArch) || !hostTarget(env) {
+		return nil, nil
+	}
+	bins := installedBinaries(dir, pre, opts.BinaryName, opts.Spec)
+	checks := make([]SmokeCheck, 0, len(bins))
+	var failed []string
+	for _, bin := range bins {
+		c := runSmokeCheck(bin, opts.Check)
+		checks = append(checks, c)
+		if c.Error != "" {
+			failed = append(failed, bin)
+		}
+	}
+	if len(failed) == 0 || !opts.StrictCheck {
+		return checks, nil
+	}
+	names := make([]string, 0, len(failed))
+	for _, bin := range failed {
+		_ = os.Remove(bin)
+		names = append(names, filepath.Base(bin))
+	}
+	return checks, fmt.Errorf("smoke test failed for %s (removed by --strict-check)", strings.Join(names, ", "))
+}
+
+// printSmokeChecks 输出冒烟测试结果：成功时为 "<name> installed: <输出第一行>"，失败时给出醒目的警告
+func printSmokeChecks(out io.Writer, checks []SmokeCheck) {
+	for _, c := range checks {
+		name := stripExeSuffix(filepath.Base(c.Binary))
+		if c.Error != "" {
+			fmt.Fprintf(out, "WARNING: %s was installed but its smoke test failed (%s): %s\n", name, c.Command, c.Error)
+			continue
+		}
+		fmt.Fprintf(out, "%s installed: %s\n", name, firstNonEmpty(c.Output, "ok"))
+	}
+}
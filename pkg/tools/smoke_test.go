@@ -0,0 +1,137 @@
+package tools
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/configs"
+)
+
+// writeFakeTool 在 dir 中写入一个 shell 脚本形式的假工具
+func writeFakeTool(t *testing.T, dir, name, script string) string {
+	t.Helper()
+	p := filepath.Join(dir, name)
+	if err := os.WriteFile(p, []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// 测试冒烟测试：打印版本的工具取第一行输出，退出码非 0 的工具报告失败
+func TestRunSmokeCheck(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts are not executable on windows")
+	}
+	dir := t.TempDir()
+	ok := writeFakeTool(t, dir, "fake", "[ \"$1\" = --version ] || exit 2\necho 'fake 1.2.3'\necho 'built by test'\n")
+	bad := writeFakeTool(t, dir, "broken", "echo 'cannot run' >&2\nexit 1\n")
+
+	if c := runSmokeCheck(ok, ""); c.Error != "" || c.Output != "fake 1.2.3" || c.Command != "fake --version" {
+		t.Errorf("runSmokeCheck(fake) = %+v", c)
+	}
+	if c := runSmokeCheck(ok, "fake --version"); c.Error != "" || c.Output != "fake 1.2.3" {
+		t.Errorf("runSmokeCheck(fake, full command) = %+v", c)
+	}
+	if c := runSmokeCheck(bad, ""); c.Error == "" || c.Command != "broken -h" {
+		t.Errorf("runSmokeCheck(broken) = %+v, want an error after trying -h", c)
+	}
+}
+
+// 测试 smokeTestInstalled：只检查新安装的二进制，--strict-check 删除失败的二进制，交叉安装不运行
+func TestSmokeTestInstalled(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts are not executable on windows")
+	}
+	t.Setenv("GOOS", "")
+	t.Setenv("GOARCH", "")
+	dir := t.TempDir()
+	old := writeFakeTool(t, dir, "old", "exit 1\n")
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(old, past, past); err != nil {
+		t.Fatal(err)
+	}
+	pre := SnapshotExecutables(dir)
+	writeFakeTool(t, dir, "fake", "echo 'fake 1.2.3'\n")
+	bad := writeFakeTool(t, dir, "broken", "exit 1\n")
+
+	checks, err := smokeTestInstalled(InstallOptions{}, nil, dir, pre)
+	if err != nil || len(checks) != 2 {
+		t.Fatalf("smokeTestInstalled = %+v, %v; want 2 checks and no error", checks, err)
+	}
+	if checks[0].Error == "" || checks[1].Output != "fake 1.2.3" {
+		t.Errorf("checks = %+v", checks)
+	}
+
+	if _, err := smokeTestInstalled(InstallOptions{StrictCheck: true}, nil, dir, pre); err == nil {
+		t.Error("strict check should fail for broken")
+	}
+	if _, err := os.Stat(bad); !os.IsNotExist(err) {
+		t.Errorf("strict check should remove %s", bad)
+	}
+	if _, err := os.Stat(old); err != nil {
+		t.Errorf("binaries not installed by this run must be kept: %v", err)
+	}
+
+	other := "windows"
+	if runtime.GOOS == other {
+		other = "linux"
+	}
+	if checks, err := smokeTestInstalled(InstallOptions{TargetOS: other}, nil, dir, pre); checks != nil || err != nil {
+		t.Errorf("cross install should not be smoke-tested, got %+v, %v", checks, err)
+	}
+	if checks, _ := smokeTestInstalled(InstallOptions{Check: "none"}, nil, dir, pre); checks != nil {
+		t.Errorf("check none should disable the smoke test, got %+v", checks)
+	}
+}
+
+// 测试从模块规范推断 go install 生成的二进制名
+func TestSpecBinaryName(t *testing.T) {
+	for spec, want := range map[string]string{
+		"github.com/x/y/v2@v2.1.0":               "y",
+		"golang.org/x/tools/cmd/stringer@latest": "stringer",
+		"./cmd/foo":                              "foo",
+		"":                                       "",
+	} {
+		if got := specBinaryName(spec); got != want {
+			t.Errorf("specBinaryName(%q) = %q, want %q", spec, got, want)
+		}
+	}
+}
+
+// 测试批量安装把 --check 与 --strict-check 传给每个工具：冒烟测试失败的工具被删除并计为失败
+func TestInstallFromConfigToolStrictCheck(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+	t.Setenv("GOOS", "")
+	t.Setenv("GOARCH", "")
+	t.Setenv("GOFLAGS", "")
+	t.Setenv("GOPROXY", "off")
+	src := t.TempDir()
+	files := map[string]string{
+		"go.mod":  "module example.com/broken\n\ngo 1.21\n",
+		"main.go": "package main\n\nimport \"os\"\n\nfunc main() {\n\tif len(os.Args) > 1 && os.Args[1] == \"--ok\" {\n\t\treturn\n\t}\n\tos.Exit(3)\n}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(src, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(src)
+	bin := t.TempDir()
+	tool := configs.Tool{Module: "."}
+
+	if _, err := installFromConfigTool(tool, bin, "dep", nil, false, BatchInstallOptions{StrictCheck: true}); err == nil {
+		t.Fatal("strict check should fail the install of a binary that does not run")
+	}
+	if _, err := os.Stat(filepath.Join(bin, binaryFileName("broken"))); !os.IsNotExist(err) {
+		t.Errorf("strict check should remove the binary: %v", err)
+	}
+	if _, err := installFromConfigTool(tool, bin, "dep", nil, false, BatchInstallOptions{Check: "--ok", StrictCheck: true}); err != nil {
+		t.Errorf("--check should override the default smoke test: %v", err)
+	}
+}
@@ -78,7 +78,7 @@ func tryResolveInstalledPath(tool string, dirs ...string) string {
 		}
 	}
 	// 解析 go env GOPATH（可能为多路径）
-	if out, err := executor.NewExecutor("go", "env", "GOPATH").Output(); err == nil {
+	if out, err := executor.NewExecutor("go", "env", "GOPATH").ReadOnly().Output(); err == nil {
 		for gp := range strings.SplitSeq(strings.TrimSpace(out), string(os.PathListSeparator)) {
 			gp = strings.TrimSpace(gp)
 			if gp == "" {
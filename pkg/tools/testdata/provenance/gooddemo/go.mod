@@ -0,0 +1,3 @@
+module example.com/gooddemo
+
+go 1.21
@@ -0,0 +1,3 @@
+module example.com/otherdemo
+
+go 1.21
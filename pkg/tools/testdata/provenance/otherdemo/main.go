@@ -0,0 +1,4 @@
+// Command otherdemo 是卸载来源校验测试使用的夹具程序
+package main
+
+func main() {}
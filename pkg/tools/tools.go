@@ -135,7 +135,7 @@ func getGoPaths() []string {
 		return splitList(gp)
 	}
 	// 备用从 `go env GOPATH` 获取
-	out, err := executor.NewExecutor("go", "env", "GOPATH").Output()
+	out, err := executor.NewExecutor("go", "env", "GOPATH").ReadOnly().Output()
 	if err == nil {
 		return splitList(strings.TrimSpace(out))
 	}
@@ -6,15 +6,85 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/yeisme/gocli/pkg/utils/executor"
 )
 
+// RunOptions 控制 tools run 的行为
+type RunOptions struct {
+	Verbose        bool
+	GoCLIToolsPath string
+	// GoRun 工具未安装但在工具表中有 go install 模块路径时，回退为 go run <module>@latest
+	GoRun bool
+	// Env 附加到工具进程的环境变量（KEY=VALUE）
+	Env []string
+	// EnvClean 以清空的环境运行工具，只保留 EnvAllow 匹配的变量和 Env
+	EnvClean bool
+	// EnvAllow 清空环境时保留的变量名，支持 GO* 这类通配符；非空时隐含 EnvClean
+	EnvAllow []string
+	// History 执行记录追加到的文件（如 ~/.gocli/history.jsonl），为空时不记录
+	History string
+}
+
+// tools run 自身的参数，必须写在工具名之前
+const (
+	goRunFlag    = "--go-run"
+	envFlag      = "--env"
+	envCleanFlag = "--env-clean"
+	envAllowFlag = "--env-allow"
+)
+
+// SplitRunFlags 取出工具名之前属于 tools run 自身的参数（--go-run、--env、--env-clean、--env-allow）写入 opts，
+// 由于禁用了 cobra 的 flag 解析，需要手动处理；工具名之后的参数原样转发
+func SplitRunFlags(args []string, opts *RunOptions) ([]string, error) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(arg, "=")
+		switch name {
+		case goRunFlag, envCleanFlag:
+			if hasValue {
+				return nil, fmt.Errorf("%s does not take a value", name)
+			}
+			if name == goRunFlag {
+				opts.GoRun = true
+			} else {
+				opts.EnvClean = true
+			}
+			continue
+		case envFlag, envAllowFlag:
+		default:
+			return args[i:], nil
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s requires a value", name)
+			}
+			i++
+			value = args[i]
+		}
+		if name == envFlag {
+			if k, _, ok := strings.Cut(value, "="); !ok || k == "" {
+				return nil, fmt.Errorf("invalid %s %q, expected KEY=VALUE", envFlag, value)
+			}
+			opts.Env = append(opts.Env, value)
+			continue
+		}
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				opts.EnvAllow = append(opts.EnvAllow, v)
+			}
+		}
+	}
+	return nil, nil
+}
+
 // ExecuteToolRun finds and executes a tool by name or path. This is an exported
 // wrapper so external binaries (like the `gox` shim) can reuse the same logic
 // as the main `gocli tools run` implementation in cmd.
-func ExecuteToolRun(args []string, out io.Writer, verbose bool, gocliToolsPath string) error {
+func ExecuteToolRun(args []string, out io.Writer, opts RunOptions) error {
+	verbose, gocliToolsPath := opts.Verbose, opts.GoCLIToolsPath
 	// 当无参数时，展示工具列表
 	if len(args) == 0 {
 		tools := FindTools(verbose, gocliToolsPath)
@@ -27,12 +97,12 @@ func ExecuteToolRun(args []string, out io.Writer, verbose bool, gocliToolsPath s
 	name := args[0]
 
 	// 1) 在已发现的工具中查找（大小写不敏感）
-	execPath := ""
+	execPath, toolName := "", ""
 	toolsList := FindTools(verbose, gocliToolsPath)
 	for i := range toolsList {
 		t := toolsList[i]
 		if strings.EqualFold(t.Name, name) || strings.EqualFold(filepath.Base(t.Path), name) {
-			execPath = t.Path
+			execPath, toolName = t.Path, t.Name
 			break
 		}
 	}
@@ -42,14 +112,11 @@ func ExecuteToolRun(args []string, out io.Writer, verbose bool, gocliToolsPath s
 		if strings.ContainsAny(name, ":/\\") || filepath.IsAbs(name) {
 			if _, err := os.Stat(name); err == nil {
 				execPath = name
+				toolName = strings.TrimSuffix(filepath.Base(name), ".exe")
 			}
 		}
 	}
 
-	if execPath == "" {
-		return fmt.Errorf("tool not found: %s", name)
-	}
-
 	// 恢复原始命令行中 run 之后的参数（优先使用未解析的 os.Args）
 	raw := rawArgsAfterRun(args)
 	execArgs := []string{}
@@ -57,8 +124,25 @@ func ExecuteToolRun(args []string, out io.Writer, verbose bool, gocliToolsPath s
 		execArgs = raw[1:]
 	}
 
+	if execPath == "" {
+		// 3) 工具表中存在 go install 模块路径的未安装工具
+		info, ok := findGoRunnableTool(name)
+		if !ok {
+			return fmt.Errorf("tool not found: %s", name)
+		}
+		if !opts.GoRun {
+			return fmt.Errorf("tool not installed: %s (install it with 'gocli tools install %s' or rerun with %s to use 'go run %s')",
+				name, name, goRunFlag, goRunModule(info.URL))
+		}
+		return runToolWithGoRun(info, execArgs, opts)
+	}
+
 	exec := executor.NewExecutor(execPath, execArgs...)
-	if err := exec.RunStreaming(os.Stdout, os.Stderr); err != nil {
+	applyRunEnv(exec, opts, nil)
+	start := time.Now()
+	err := exec.RunStreaming(os.Stdout, os.Stderr)
+	recordToolRun(opts, toolName, execPath, execArgs, start, err)
+	if err != nil {
 		if ee, ok := err.(*executor.ExecError); ok {
 			return fmt.Errorf("tool %s failed: exit=%d stderr=%s", execPath, ee.ExitCode(), ee.CleanStderr())
 		}
@@ -67,6 +151,53 @@ func ExecuteToolRun(args []string, out io.Writer, verbose bool, gocliToolsPath s
 	return nil
 }
 
+// findGoRunnableTool 在工具表中按名称（大小写不敏感）查找可通过 go run 执行的工具，clone 构建的工具不支持
+func findGoRunnableTool(name string) (InstallToolsInfo, bool) {
+	for key, info := range BuiltinTools {
+		if !strings.EqualFold(key, name) && !strings.EqualFold(info.Name, name) {
+			continue
+		}
+		if info.URL == "" || info.CloneURL != "" {
+			return InstallToolsInfo{}, false
+		}
+		return info, true
+	}
+	return InstallToolsInfo{}, false
+}
+
+// goRunModule 返回 go run 使用的模块路径：未指定版本时追加 @latest，保留工具表中固定的版本
+func goRunModule(url string) string {
+	if strings.Contains(url, "@") {
+		return url
+	}
+	return url + "@latest"
+}
+
+// runToolWithGoRun 通过 go run <module>@version 执行未安装的工具，工具表中的 env/tags 同样生效
+func runToolWithGoRun(info InstallToolsInfo, execArgs []string, opts RunOptions) error {
+	module := goRunModule(info.URL)
+	goArgs := []string{"run"}
+	if len(info.Tags) > 0 {
+		goArgs = append(goArgs, "-tags", strings.Join(info.Tags, ","))
+	}
+	goArgs = append(goArgs, module)
+	goArgs = append(goArgs, execArgs...)
+	fmt.Fprintf(os.Stderr, "[gocli][tools] %s is not installed, running 'go run %s'\n", info.Name, module)
+
+	exec := executor.NewExecutor("go", goArgs...)
+	applyRunEnv(exec, opts, info.Env)
+	start := time.Now()
+	err := exec.RunStreaming(os.Stdout, os.Stderr)
+	recordToolRun(opts, info.Name, "go run "+module, execArgs, start, err)
+	if err != nil {
+		if ee, ok := err.(*executor.ExecError); ok {
+			return fmt.Errorf("go run %s failed: exit=%d stderr=%s", module, ee.ExitCode(), ee.CleanStderr())
+		}
+		return err
+	}
+	return nil
+}
+
 // rawArgsAfterRun tries to reconstruct the raw argv slice starting at the
 // tool name. It prefers the original os.Args (so flags intended for the
 // executed tool are preserved), and falls back to the cobra-parsed args.
@@ -163,8 +294,8 @@ Run a tool by configured name or by explicit path and forward all remaining
 arguments to the executed binary unchanged.
 
 Basic usage:
-  gocli tool run <tool> [args...]
-  gox run <tool> [args...]
+  gocli tool run [--go-run] [--env KEY=VALUE]... [--env-clean] [--env-allow NAME,...] <tool> [args...]
+  gox run [--go-run] [--env KEY=VALUE]... [--env-clean] [--env-allow NAME,...] <tool> [args...]
 
 Examples:
   # Run a configured tool named "task"
@@ -175,6 +306,19 @@ Examples:
   gocli tools run task --list
   gox run task --list
 
+  # Run a known tool that is not installed yet via 'go run <module>@latest'
+  gocli tools x --go-run golangci-lint run ./...
+
+  # Pass extra environment variables to the tool
+  gocli tools x --env FOO=bar mytool
+
+  # Run with a minimal environment: only PATH, HOME and GO* are kept, plus FOO
+  gocli tools x --env-allow PATH,HOME,GO* --env FOO=bar mytool
+
+  # Enable shell completion of tool names (bash; zsh/fish/powershell also supported)
+  source <(gocli completion bash)
+  source <(gox --completion bash)
+
 Notes:
   - Use 'gocli tools list' to inspect available configured tools and their
     install paths.
@@ -185,5 +329,16 @@ Notes:
   - All flags and arguments after the tool name are forwarded verbatim to the
     invoked executable. Unknown flags are allowed so flags intended for the
     executed tool are not interpreted by cobra.
+  - --go-run must come before the tool name. It only applies to tools from the
+    tool table installed with 'go install' (not --clone builds); a version pinned
+    in the table is kept, otherwise @latest is used. The first run downloads and
+    compiles the module, later runs reuse the Go build cache.
+  - --env/--env-clean/--env-allow must also come before the tool name. By default
+    the tool inherits gocli's environment; --env-clean starts from an empty one and
+    --env-allow (which implies --env-clean) keeps only matching variables. --env
+    values are always applied last and win over inherited ones.
+  - Shell completion suggests installed tools and tools from the tool table
+    (usable with --go-run) for the tool name; arguments after the tool name
+    fall back to file completion.
 `,
 }
@@ -0,0 +1,129 @@
+package tools
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// 测试未安装工具的 go run 回退：需要 --go-run，模块路径补全 @latest，tags/env 与参数原样传递
+func TestExecuteToolRun_GoRunFallback(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("GOPATH", filepath.Join(root, "gopath"))
+	t.Setenv("HOME", filepath.Join(root, "home"))
+	saved := BuiltinTools
+	BuiltinTools = map[string]InstallToolsInfo{
+		"demo":   {Name: "demo", URL: "example.com/demo/cmd/demo", Tags: []string{"netgo"}, Env: []string{"CGO_ENABLED=0"}},
+		"pinned": {Name: "pinned", URL: "example.com/pinned@v1.2.3"},
+		"cloned": {Name: "cloned", CloneURL: "https://example.com/cloned.git"},
+	}
+	t.Cleanup(func() {
+		BuiltinTools = saved
+		ClearToolsCache()
+	})
+	ClearToolsCache()
+
+	var parsed RunOptions
+	args, err := SplitRunFlags([]string{"--go-run", "DEMO", "--go-run", "-v"}, &parsed)
+	if err != nil || !parsed.GoRun || strings.Join(args, " ") != "DEMO --go-run -v" {
+		t.Fatalf("SplitRunFlags = %+v, %v, %v", parsed, args, err)
+	}
+
+	if err := ExecuteToolRun(args, io.Discard, RunOptions{GoCLIToolsPath: filepath.Join(root, "tools")}); err == nil || !strings.Contains(err.Error(), "--go-run") {
+		t.Fatalf("expected a hint about --go-run, got %v", err)
+	}
+	if err := ExecuteToolRun([]string{"cloned"}, io.Discard, RunOptions{GoRun: true}); err == nil || !strings.Contains(err.Error(), "tool not found") {
+		t.Fatalf("clone-only tools cannot fall back to go run, got %v", err)
+	}
+
+	rec := executor.StartRecording()
+	defer executor.StopRecording()
+	opts := RunOptions{GoCLIToolsPath: filepath.Join(root, "tools"), GoRun: true}
+	for _, a := range [][]string{args, {"pinned"}} {
+		if err := ExecuteToolRun(a, io.Discard, opts); err != nil {
+			t.Fatal(err)
+		}
+	}
+	records := rec.Records()
+	if len(records) != 2 {
+		t.Fatalf("expected 2 recorded commands, got %v", records)
+	}
+	if got := records[0].String(); got != "$ CGO_ENABLED=0 go run -tags netgo example.com/demo/cmd/demo@latest --go-run -v" {
+		t.Errorf("unexpected command %s", got)
+	}
+	if got := records[1].String(); got != "$ go run example.com/pinned@v1.2.3" {
+		t.Errorf("unexpected command %s", got)
+	}
+}
+
+// 测试工具名之前的 --env/--env-clean/--env-allow 解析，以及传给子进程的环境变量
+func TestRunEnvFlags(t *testing.T) {
+	var opts RunOptions
+	args, err := SplitRunFlags([]string{"--env", "FOO=bar", "--env=A=b=c", "--env-allow", "PATH, GO*", "--env-allow=HOME", "mytool", "--env", "X=1"}, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(args, " ") != "mytool --env X=1" {
+		t.Errorf("flags after the tool name must be forwarded, got %v", args)
+	}
+	if strings.Join(opts.Env, " ") != "FOO=bar A=b=c" || strings.Join(opts.EnvAllow, " ") != "PATH GO* HOME" {
+		t.Errorf("unexpected options %+v", opts)
+	}
+	for _, bad := range [][]string{{"--env", "NOVALUE", "tool"}, {"--env"}, {"--go-run=true", "tool"}} {
+		if _, err := SplitRunFlags(bad, &RunOptions{}); err == nil {
+			t.Errorf("expected error for %v", bad)
+		}
+	}
+
+	environ := []string{"PATH=/bin", "GOPATH=/go", "GOFLAGS=-mod=mod", "SECRET=x", "HOME=/root"}
+	if got := strings.Join(filterEnv(environ, []string{"PATH", "GO*"}), " "); got != "PATH=/bin GOPATH=/go GOFLAGS=-mod=mod" {
+		t.Errorf("filterEnv = %s", got)
+	}
+
+	t.Setenv("GOCLI_RUN_SECRET", "x")
+	t.Setenv("GOCLI_RUN_KEEP", "y")
+	rec := executor.StartRecording()
+	defer executor.StopRecording()
+	ex := executor.NewExecutor("mytool")
+	applyRunEnv(ex, RunOptions{EnvAllow: []string{"GOCLI_RUN_KEEP"}, Env: []string{"FOO=bar"}}, []string{"CGO_ENABLED=0"})
+	_ = ex.RunStreaming(io.Discard, io.Discard)
+	if got := rec.Records()[0].String(); got != "$ GOCLI_RUN_KEEP=y CGO_ENABLED=0 FOO=bar mytool" {
+		t.Errorf("unexpected clean environment: %s", got)
+	}
+}
+
+// 测试工具名补全：合并已安装工具与工具表并按前缀过滤，工具名之后交给文件补全
+func TestCompleteRunArgs(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("GOPATH", filepath.Join(root, "gopath"))
+	t.Setenv("HOME", filepath.Join(root, "home"))
+	toolsDir := filepath.Join(root, "tools")
+	if err := os.MkdirAll(toolsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(toolsDir, "golocal"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	saved := BuiltinTools
+	BuiltinTools = map[string]InstallToolsInfo{"gotable": {Name: "gotable"}, "other": {Name: "other"}}
+	t.Cleanup(func() { BuiltinTools = saved; ClearToolsCache() })
+	ClearToolsCache()
+
+	got, dir := CompleteRunArgs(toolsDir, nil, "GO")
+	if want := []string{"golocal", "gotable"}; !slices.Equal(got, want) || dir != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("tool names = %v (%d), want %v", got, dir, want)
+	}
+	got, _ = CompleteRunArgs(toolsDir, []string{"--env", "A=b", "--go-run"}, "o")
+	if !slices.Equal(got, []string{"other"}) {
+		t.Errorf("after run flags = %v, want [other]", got)
+	}
+	if got, dir := CompleteRunArgs(toolsDir, []string{"golocal"}, ""); got != nil || dir != cobra.ShellCompDirectiveDefault {
+		t.Errorf("tool args = %v (%d), want file completion", got, dir)
+	}
+}
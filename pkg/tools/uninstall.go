@@ -20,6 +20,7 @@ import (
 //   - Verbose / Quiet: 输出控制
 //   - GoCLIToolsPath: 可选的 gocli 工具目录覆盖（用于搜索）
 //   - ToolsConfigDir: 搜索工具配置文件的目录列表
+//   - ForceUnverified: 是否允许删除无法归属到该工具的二进制（build info 中的模块与工具定义不一致）
 //   - Input: 用于测试或重定向输入的 io.Reader；若为 nil，使用 os.Stdin
 type UninstallCommandOptions struct {
 	Args  []string
@@ -28,6 +29,8 @@ type UninstallCommandOptions struct {
 	Fuzzy bool
 	All   bool
 
+	ForceUnverified bool
+
 	Verbose bool
 	Quiet   bool
 
@@ -50,6 +53,8 @@ type UninstallCommandOptions struct {
 //     - 如果 --all 且未提供 --yes：对每个匹配的文件逐一交互确认（更安全）
 //     - 如果未指定 --all：默认行为为逐文件交互（除非 --yes）
 //     - 如果 --dry：不做实际删除，仅打印将要删除的路径
+//  4. 删除前校验每个匹配项：解析符号链接后位于扫描目录之外的路径一律拒绝；
+//     build info 无法归属到该工具的二进制标记为 unverified，默认跳过（除非 --force-unverified）
 //
 // 该函数同时处理去重（相同 binary name 不重复询问）以及在删除成功后清理内部工具缓存
 func ExecuteUninstallCommand(opts UninstallCommandOptions, out io.Writer) error {
@@ -89,6 +94,12 @@ func ExecuteUninstallCommand(opts UninstallCommandOptions, out io.Writer) error
 
 			exeName := bn
 
+			// 工具定义中的模块/仓库路径，用于校验二进制来源
+			expected := ExpectedModules(&c)
+			if len(expected) == 0 {
+				expected = ExpectedModules(SearchTools(exeName, opts.ToolsConfigDir))
+			}
+
 			// 循环查找该 exe 在所有可能目录中的存在（因为会有多个目录）
 			for {
 				matches := findMatchesForExe(exeName, opts)
@@ -97,6 +108,12 @@ func ExecuteUninstallCommand(opts UninstallCommandOptions, out io.Writer) error
 					fmt.Fprintf(out, "no installed binary file found for '%s'\n", exeName)
 					break
 				}
+				// 过滤掉扫描目录之外以及（默认）无法归属到该工具的二进制
+				var notes map[string]string
+				matches, notes = vetUninstallMatches(matches, expected, opts, out)
+				if len(matches) == 0 {
+					break
+				}
 
 				// 当用户指定 --all 时，行为如下：
 				// - 若同时提供 --yes，则直接删除所有匹配（removePaths 会处理 dry-run）
@@ -105,7 +122,7 @@ func ExecuteUninstallCommand(opts UninstallCommandOptions, out io.Writer) error
 					// 先打印出所有找到的路径，帮助用户决策
 					fmt.Fprintln(out, "matching files:")
 					for _, p := range matches {
-						fmt.Fprintf(out, "  %s\n", p)
+						fmt.Fprintf(out, "  %s%s\n", p, notes[p])
 					}
 
 					if opts.Yes {
@@ -118,7 +135,7 @@ func ExecuteUninstallCommand(opts UninstallCommandOptions, out io.Writer) error
 					// --all 且没有 --yes：对每个文件逐一交互确认并删除
 					for _, p := range matches {
 						// 对单个路径进行确认，使用 confirmYes 读取用户输入
-						if !confirmYes(reader, out, fmt.Sprintf("Delete %s? [y/N]: ", p)) {
+						if !confirmYes(reader, out, fmt.Sprintf("Delete %s%s? [y/N]: ", p, notes[p])) {
 							// 用户选择否：记录 skipped 并继续下一个
 							fmt.Fprintf(out, "skipped: %s\n", p)
 							continue
@@ -144,7 +161,7 @@ func ExecuteUninstallCommand(opts UninstallCommandOptions, out io.Writer) error
 				for _, p := range matches {
 					if !opts.Yes {
 						// 未指定 --yes 时询问用户是否删除当前文件
-						if !confirmYes(reader, out, fmt.Sprintf("Delete %s? [y/N]: ", p)) {
+						if !confirmYes(reader, out, fmt.Sprintf("Delete %s%s? [y/N]: ", p, notes[p])) {
 							fmt.Fprintf(out, "skipped: %s\n", p)
 							continue
 						}
@@ -187,7 +204,7 @@ func findCandidatesForName(name string, opts UninstallCommandOptions) []InstallT
 		tools := FindTools(opts.Verbose, opts.GoCLIToolsPath)
 		for _, t := range tools {
 			if strings.EqualFold(t.Name, bn) {
-				candidates = append(candidates, InstallToolsInfo{Name: t.Name, CloneURL: "", URL: "", BinaryName: t.Name})
+				candidates = append(candidates, InstallToolsInfo{Name: t.Name, CloneURL: bi.CloneURL, URL: bi.URL, BinaryName: t.Name})
 			}
 		}
 	}
@@ -224,33 +241,7 @@ func findMatchesForExe(exeName string, opts UninstallCommandOptions) []string {
 	// binaries in different directories, scan candidate directories directly.
 	var matches []string
 
-	// 收集候选目录：GOPATH/bin 条目 + 配置的 gocli tools 路径 + 用户 ~/.gocli/tools
-	dirs := []struct {
-		path   string
-		source toolSourceType
-	}{}
-
-	for _, gp := range getGoPaths() {
-		if gp == "" {
-			continue
-		}
-		dirs = append(dirs, struct {
-			path   string
-			source toolSourceType
-		}{path: joinPath(gp, "bin"), source: goPath})
-	}
-	if p := getUserToolsDir(opts.GoCLIToolsPath); p != "" {
-		dirs = append(dirs, struct {
-			path   string
-			source toolSourceType
-		}{path: p, source: goCliPath})
-	}
-	if p := getUserToolsDir(""); p != "" {
-		dirs = append(dirs, struct {
-			path   string
-			source toolSourceType
-		}{path: p, source: goUserCliPath})
-	}
+	dirs := uninstallScanDirs(opts)
 
 	// 防止重复扫描相同目录
 	seenDirs := map[string]struct{}{}
@@ -269,6 +260,60 @@ func findMatchesForExe(exeName string, opts UninstallCommandOptions) []string {
 	return matches
 }
 
+// scanDir 是卸载时扫描的一个工具目录
+type scanDir struct {
+	path   string
+	source toolSourceType
+}
+
+// uninstallScanDirs 收集候选目录：GOPATH/bin 条目 + 配置的 gocli tools 路径 + 用户 ~/.gocli/tools
+func uninstallScanDirs(opts UninstallCommandOptions) []scanDir {
+	var dirs []scanDir
+	for _, gp := range getGoPaths() {
+		if gp == "" {
+			continue
+		}
+		dirs = append(dirs, scanDir{path: joinPath(gp, "bin"), source: goPath})
+	}
+	if p := getUserToolsDir(opts.GoCLIToolsPath); p != "" {
+		dirs = append(dirs, scanDir{path: p, source: goCliPath})
+	}
+	if p := getUserToolsDir(""); p != "" {
+		dirs = append(dirs, scanDir{path: p, source: goUserCliPath})
+	}
+	return dirs
+}
+
+// vetUninstallMatches 在删除前校验匹配项，返回允许继续处理的路径及其提示后缀
+//
+//   - 解析符号链接后位于扫描目录之外的路径直接拒绝（与 --force-unverified 无关）
+//   - build info 无法归属到该工具的二进制标记为 "(unverified — ...)"，
+//     未指定 ForceUnverified 时跳过并提示
+func vetUninstallMatches(matches, expected []string, opts UninstallCommandOptions, out io.Writer) ([]string, map[string]string) {
+	var dirs []string
+	for _, d := range uninstallScanDirs(opts) {
+		dirs = append(dirs, d.path)
+	}
+	notes := map[string]string{}
+	kept := make([]string, 0, len(matches))
+	for _, p := range matches {
+		if err := ensureWithinDirs(p, dirs); err != nil {
+			fmt.Fprintf(out, "refused: %v\n", err)
+			continue
+		}
+		if ok, reason := VerifyToolBinary(p, expected); !ok {
+			note := fmt.Sprintf(" (unverified — %s)", reason)
+			if !opts.ForceUnverified {
+				fmt.Fprintf(out, "skipped: %s%s; use --force-unverified to remove it\n", p, note)
+				continue
+			}
+			notes[p] = note
+		}
+		kept = append(kept, p)
+	}
+	return kept, notes
+}
+
 // confirmYes 从 reader 读取用户输入并返回是否确认（用户输入 y 或 yes 为真）
 //
 // 该函数对输入做简单清洗（去除空白并转小写），仅接受 "y" 和 "yes" 为肯定
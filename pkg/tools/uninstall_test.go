@@ -0,0 +1,126 @@
+package tools
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// buildFixture 编译 testdata/provenance 下的夹具程序，得到带有 build info 的二进制
+func buildFixture(t *testing.T, name, out string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(out), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("go", "build", "-o", out, ".")
+	cmd.Dir = filepath.Join("testdata", "provenance", name)
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=")
+	if b, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("build fixture %s failed: %v\n%s", name, err, b)
+	}
+}
+
+// 测试工具定义到模块路径的规范化以及 build info 归属判断
+func TestExpectedModulesAndProvenance(t *testing.T) {
+	got := ExpectedModules(&InstallToolsInfo{
+		URL:      "golang.org/x/tools/gopls@latest",
+		CloneURL: "git@github.com:Owner/Repo.git#v1.2.3",
+	})
+	if len(got) != 2 || got[0] != "golang.org/x/tools/gopls" || got[1] != "github.com/owner/repo" {
+		t.Fatalf("unexpected expected modules: %v", got)
+	}
+	if p := repoModulePath("https://github.com/owner/repo.git"); p != "github.com/owner/repo" {
+		t.Errorf("unexpected repo path %q", p)
+	}
+
+	cases := []struct {
+		prov BinaryProvenance
+		want bool
+	}{
+		{BinaryProvenance{Module: "golang.org/x/tools/gopls", Package: "golang.org/x/tools/gopls"}, true},
+		{BinaryProvenance{Module: "github.com/owner/repo/v2", Package: "github.com/owner/repo/v2/cmd/repo"}, true},
+		{BinaryProvenance{Module: "golang.org/x/tools", Package: "golang.org/x/tools/cmd/stringer"}, false},
+		{BinaryProvenance{Module: "github.com/owner/repository", Package: "github.com/owner/repository"}, false},
+	}
+	for _, c := range cases {
+		if ok := MatchesProvenance(&c.prov, got); ok != c.want {
+			t.Errorf("MatchesProvenance(%+v) = %v, want %v", c.prov, ok, c.want)
+		}
+	}
+}
+
+// 测试卸载时：归属正确的二进制被删除，其他模块构建的同名二进制默认跳过，
+// 指向扫描目录之外的符号链接被拒绝
+func TestExecuteUninstall_Provenance(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlink fixtures are not portable to windows")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	root := t.TempDir()
+	gopathBin := filepath.Join(root, "gopath", "bin")
+	toolsDir := filepath.Join(root, "tools")
+	homeTools := filepath.Join(root, "home", ".gocli", "tools")
+	outside := filepath.Join(root, "outside", "gooddemo")
+
+	buildFixture(t, "gooddemo", filepath.Join(gopathBin, "gooddemo"))
+	buildFixture(t, "otherdemo", filepath.Join(toolsDir, "gooddemo"))
+	buildFixture(t, "gooddemo", outside)
+	if err := os.MkdirAll(homeTools, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(homeTools, "gooddemo")); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("GOPATH", filepath.Join(root, "gopath"))
+	t.Setenv("HOME", filepath.Join(root, "home"))
+	saved := BuiltinTools
+	BuiltinTools = map[string]InstallToolsInfo{"gooddemo": {Name: "gooddemo", URL: "example.com/gooddemo@latest"}}
+	t.Cleanup(func() {
+		BuiltinTools = saved
+		ClearToolsCache()
+	})
+	ClearToolsCache()
+
+	opts := UninstallCommandOptions{Args: []string{"gooddemo"}, Yes: true, GoCLIToolsPath: toolsDir}
+	var out bytes.Buffer
+	if err := ExecuteUninstallCommand(opts, &out); err != nil {
+		t.Fatal(err)
+	}
+	log := out.String()
+	if _, err := os.Stat(filepath.Join(gopathBin, "gooddemo")); !os.IsNotExist(err) {
+		t.Errorf("verified binary should be removed:\n%s", log)
+	}
+	if _, err := os.Stat(filepath.Join(toolsDir, "gooddemo")); err != nil {
+		t.Errorf("unverified binary must be kept: %v\n%s", err, log)
+	}
+	if !strings.Contains(log, "unverified — different module: example.com/otherdemo") {
+		t.Errorf("expected unverified note:\n%s", log)
+	}
+	if _, err := os.Lstat(filepath.Join(homeTools, "gooddemo")); err != nil || !strings.Contains(log, "outside the scanned tool directories") {
+		t.Errorf("symlink pointing outside should be refused: %v\n%s", err, log)
+	}
+	if _, err := os.Stat(outside); err != nil {
+		t.Errorf("symlink target must not be touched: %v", err)
+	}
+
+	// --force-unverified 允许删除无法归属的二进制，但仍拒绝扫描目录之外的路径
+	opts.ForceUnverified = true
+	out.Reset()
+	if err := ExecuteUninstallCommand(opts, &out); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(toolsDir, "gooddemo")); !os.IsNotExist(err) {
+		t.Errorf("unverified binary should be removed with ForceUnverified:\n%s", out.String())
+	}
+	if _, err := os.Lstat(filepath.Join(homeTools, "gooddemo")); err != nil {
+		t.Errorf("symlink must still be refused with ForceUnverified: %v", err)
+	}
+}
@@ -0,0 +1,376 @@
+package tools
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/configs"
+	"github.com/yeisme/gocli/pkg/style"
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// 工具校验的状态
+const (
+	VerifyOK         = "ok"         // 实际哈希与记录一致
+	VerifyMismatch   = "mismatch"   // 实际哈希与记录不一致
+	VerifyMissing    = "missing"    // 记录了哈希但没有找到已安装的二进制
+	VerifyUnrecorded = "unrecorded" // 已安装但没有记录哈希
+)
+
+// BinaryDigest 是安装后二进制文件的 sha256
+type BinaryDigest struct {
+	Binary string `json:"binary"`
+	SHA256 string `json:"sha256"`
+}
+
+// VerifyEntry 是 tools verify 输出的一行
+type VerifyEntry struct {
+	Name     string `json:"name"`
+	Path     string `json:"path,omitempty"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+	Status   string `json:"status"`
+}
+
+// VerifyCommandOptions 定义 tools verify 命令的选项
+type VerifyCommandOptions struct {
+	// 要校验的工具名；为空时校验所有记录了 sha256 的工具
+	Names []string
+	// 配置文件中的 tools.deps / tools.global，其中的 sha256 优先于内置/用户工具表
+	Config         *configs.Config
+	GoCLIToolsPath string
+	ToolsConfigDir []string
+	JSON           bool
+}
+
+// FileSHA256 计算文件内容的 sha256，返回小写十六进制字符串
+func FileSHA256(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("hash %s: %w", file, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// normalizeSHA256 去掉可选的 "sha256:" 前缀与空白并转为小写，便于比较
+func normalizeSHA256(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.TrimPrefix(s, "sha256:")
+}
+
+// binaryFileName 返回名为 name 的工具在当前平台上的文件名，windows 上补 .exe 后缀
+func binaryFileName(name string) string {
+	if name == "" || runtime.GOOS != "windows" || strings.HasSuffix(strings.ToLower(name), ".exe") {
+		return name
+	}
+	return name + ".exe"
+}
+
+// specBinaryName 返回 go install 为模块规范生成的二进制名：去掉 @version 与 /vN 主版本后缀后的最后一段
+func specBinaryName(spec string) string {
+	spec, _, _ = strings.Cut(strings.TrimSpace(spec), "@")
+	spec = strings.TrimRight(filepath.ToSlash(spec), "/")
+	if spec == "" || spec == "." {
+		return ""
+	}
+	base := path.Base(spec)
+	if isMajorVersion(base) {
+		base = path.Base(path.Dir(spec))
+	}
+	if base == "." || base == "/" {
+		return ""
+	}
+	return base
+}
+
+// isMajorVersion 判断路径元素是否为 v2、v10 这样的主版本后缀
+func isMajorVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	for _, r := range s[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// installedBinaries 返回 dir 中本次安装新增或更新的可执行文件（按名称排序）；没有变化时（例如重新安装了相同的版本）
+// 按 binaryName 或 go install 规范推断的名称查找已存在的文件
+func installedBinaries(dir string, pre map[string]time.Time, binaryName, spec string) []string {
+	var names []string
+	for name, mt := range SnapshotExecutables(dir) {
+		if pmt, ok := pre[name]; !ok || mt.After(pmt) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		for _, name := range []string{binaryName, specBinaryName(spec)} {
+			if name = binaryFileName(name); name != "" && isExecutable(name, dir) {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	bins := make([]string, 0, len(names))
+	for _, name := range names {
+		bins = append(bins, filepath.Join(dir, name))
+	}
+	return bins
+}
+
+// verifyInstalled 计算本次安装的二进制的 sha256；opts.SHA256 非空且未设置 SkipVerify 时与之比较，
+// 不一致则删除该二进制并返回错误。安装了多个二进制时，按 BinaryName 或模块规范推断的名称选出要比较的文件
+func verifyInstalled(opts InstallOptions, dir string, pre map[string]time.Time) ([]BinaryDigest, error) {
+	// dry-run 时没有真正安装，不能校验（更不能删除）已有的二进制
+	if dir == "" || executor.Recording() {
+		return nil, nil
+	}
+	bins := installedBinaries(dir, pre, opts.BinaryName, opts.Spec)
+	digests := make([]BinaryDigest, 0, len(bins))
+	for _, bin := range bins {
+		sum, err := FileSHA256(bin)
+		if err != nil {
+			return digests, err
+		}
+		digests = append(digests, BinaryDigest{Binary: bin, SHA256: sum})
+	}
+	expected := normalizeSHA256(opts.SHA256)
+	if expected == "" || opts.SkipVerify {
+		return digests, recordDigests(dir, digests)
+	}
+	target, err := verifyTarget(digests, opts)
+	if err != nil {
+		return digests, err
+	}
+	if target.SHA256 == expected {
+		return digests, recordDigests(dir, digests)
+	}
+	if rmErr := os.Remove(target.Binary); rmErr != nil {
+		return digests, fmt.Errorf("sha256 mismatch for %s: expected %s, got %s (failed to remove it: %v)", target.Binary, expected, target.SHA256, rmErr)
+	}
+	return digests, fmt.Errorf("sha256 mismatch for %s: expected %s, got %s; the binary was removed (use --skip-verify to keep it)", target.Binary, expected, target.SHA256)
+}
+
+// verifyTarget 选出要与期望的 sha256 比较的二进制
+func verifyTarget(digests []BinaryDigest, opts InstallOptions) (BinaryDigest, error) {
+	switch len(digests) {
+	case 0:
+		return BinaryDigest{}, fmt.Errorf("sha256 verification failed: no installed binary found")
+	case 1:
+		return digests[0], nil
+	}
+	for _, name := range []string{opts.BinaryName, specBinaryName(opts.Spec)} {
+		for _, d := range digests {
+			if name != "" && stripExeSuffix(filepath.Base(d.Binary)) == stripExeSuffix(name) {
+				return d, nil
+			}
+		}
+	}
+	return BinaryDigest{}, fmt.Errorf("sha256 verification failed: %d binaries were installed, set a binary name to choose the one to verify", len(digests))
+}
+
+// printDigests 以 sha256sum 的格式输出安装的二进制的哈希
+func printDigests(out io.Writer, digests []BinaryDigest) {
+	for _, d := range digests {
+		fmt.Fprintf(out, "sha256: %s  %s\n", d.SHA256, filepath.Clean(d.Binary))
+	}
+}
+
+// digestRecordFile 是安装目录中记录安装时 sha256 的文件，格式与 sha256sum 相同（"<sha256>  <二进制名>"）
+const digestRecordFile = ".gocli-sha256"
+
+// readDigestRecord 读取 dir 中记录的安装时哈希（二进制名 -> sha256，名称不含 .exe 后缀）；文件不存在时返回空表
+func readDigestRecord(dir string) map[string]string {
+	hashes := make(map[string]string)
+	f, err := os.Open(filepath.Join(dir, digestRecordFile))
+	if err != nil {
+		return hashes
+	}
+	defer func() { _ = f.Close() }()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		sum, name, ok := strings.Cut(strings.TrimSpace(sc.Text()), "  ")
+		if ok && name != "" {
+			hashes[stripExeSuffix(strings.TrimSpace(name))] = normalizeSHA256(sum)
+		}
+	}
+	return hashes
+}
+
+// recordDigests 把本次安装的二进制的哈希合并写入所在目录的 digestRecordFile，供 tools verify 之后校验
+func recordDigests(dir string, digests []BinaryDigest) error {
+	if len(digests) == 0 {
+		return nil
+	}
+	hashes := readDigestRecord(dir)
+	for _, d := range digests {
+		hashes[stripExeSuffix(filepath.Base(d.Binary))] = d.SHA256
+	}
+	names := make([]string, 0, len(hashes))
+	for name := range hashes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s  %s\n", hashes[name], name)
+	}
+	if err := os.WriteFile(filepath.Join(dir, digestRecordFile), []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("record sha256: %w", err)
+	}
+	return nil
+}
+
+// recordedHashes 汇总记录的期望哈希（工具二进制名 -> sha256）：先取内置/用户工具表，再由配置的 tools.deps、tools.global 覆盖
+func recordedHashes(cfg *configs.Config) map[string]string {
+	hashes := make(map[string]string)
+	for key, bi := range BuiltinTools {
+		if sum := normalizeSHA256(bi.SHA256); sum != "" {
+			hashes[firstNonEmpty(bi.BinaryName, specBinaryName(bi.URL), bi.Name, key)] = sum
+		}
+	}
+	if cfg == nil {
+		return hashes
+	}
+	for _, list := range [][]configs.Tool{cfg.Tools.Deps, cfg.Tools.Global} {
+		for _, t := range list {
+			sum := normalizeSHA256(t.SHA256)
+			if sum == "" {
+				continue
+			}
+			name := t.BinaryName
+			if name == "" && t.Module != "" {
+				name = specBinaryName(t.Module)
+			}
+			if name == "" && t.CloneURL != "" {
+				name = extractRepoName(t.CloneURL)
+			}
+			if name == "" {
+				if spec, err := ParseGoInstallSpec(t.Cmd); err == nil {
+					name = specBinaryName(spec)
+				}
+			}
+			if name != "" {
+				hashes[stripExeSuffix(name)] = sum
+			}
+		}
+	}
+	return hashes
+}
+
+// VerifyTools 重新计算已安装工具的 sha256 并与记录的值比较；names 为空时校验所有记录了哈希的工具。
+// 工具定义中没有 sha256 时，使用安装时写入二进制所在目录的 digestRecordFile
+func VerifyTools(opts VerifyCommandOptions) ([]VerifyEntry, error) {
+	for _, p := range opts.ToolsConfigDir {
+		_ = LoadUserTools(p)
+	}
+	hashes := recordedHashes(opts.Config)
+	installed := make(map[string]ToolInfo)
+	records := make(map[string]map[string]string)
+	for _, ti := range FindTools(false, opts.GoCLIToolsPath) {
+		installed[ti.Name] = ti
+		dir := filepath.Dir(ti.Path)
+		if _, ok := records[dir]; !ok {
+			records[dir] = readDigestRecord(dir)
+		}
+	}
+	// 安装记录只对仍在该目录中的二进制生效，卸载或改名后的旧记录不会被报告为 missing
+	for name, ti := range installed {
+		if sum := records[filepath.Dir(ti.Path)][name]; sum != "" && hashes[name] == "" {
+			hashes[name] = sum
+		}
+	}
+
+	names := opts.Names
+	if len(names) == 0 {
+		for name := range hashes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	entries := make([]VerifyEntry, 0, len(names))
+	for _, name := range names {
+		e := VerifyEntry{Name: name, Expected: hashes[name]}
+		ti, ok := installed[name]
+		if !ok {
+			e.Status = VerifyMissing
+			entries = append(entries, e)
+			continue
+		}
+		e.Path = ti.Path
+		sum, err := FileSHA256(ti.Path)
+		if err != nil {
+			return entries, err
+		}
+		e.Actual = sum
+		switch {
+		case e.Expected == "":
+			e.Status = VerifyUnrecorded
+		case e.Expected == sum:
+			e.Status = VerifyOK
+		default:
+			e.Status = VerifyMismatch
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// ExecuteVerifyCommand 执行 tools verify：以表格或 JSON 输出每个工具的校验结果，有不一致或缺失的工具时返回错误
+func ExecuteVerifyCommand(opts VerifyCommandOptions, out io.Writer) error {
+	entries, err := VerifyTools(opts)
+	if err != nil {
+		return err
+	}
+	if opts.JSON {
+		if err := style.PrintJSON(out, entries); err != nil {
+			return err
+		}
+	} else if len(entries) == 0 {
+		fmt.Fprintln(out, "no tool has a recorded sha256; install it with gocli tools install, add 'sha256' to a tool definition or pass tool names")
+	} else {
+		rows := make([][]string, 0, len(entries))
+		for _, e := range entries {
+			rows = append(rows, []string{e.Name, e.Status, shortHash(e.Expected), shortHash(e.Actual), e.Path})
+		}
+		if err := style.PrintTable(out, []string{"name", "status", "expected", "actual", "path"}, rows, 0); err != nil {
+			return err
+		}
+	}
+	drift := 0
+	for _, e := range entries {
+		if e.Status == VerifyMismatch || e.Status == VerifyMissing {
+			drift++
+		}
+	}
+	if drift > 0 {
+		return fmt.Errorf("%d tool(s) do not match their recorded sha256", drift)
+	}
+	return nil
+}
+
+// shortHash 截取哈希的前 12 位用于表格展示
+func shortHash(s string) string {
+	if len(s) > 12 {
+		return s[:12]
+	}
+	return s
+}
@@ -0,0 +1,151 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/configs"
+)
+
+// writeFakeBinary 在 dir 中写入一个可执行文件并返回其路径与 sha256
+func writeFakeBinary(t *testing.T, dir, name, content string) (string, string) {
+	t.Helper()
+	p := filepath.Join(dir, binaryFileName(name))
+	if err := os.WriteFile(p, []byte(content), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	sum, err := FileSHA256(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p, sum
+}
+
+// 测试安装后的校验：哈希不一致时删除二进制并返回错误，--skip-verify 时保留，一致时通过
+func TestVerifyInstalled(t *testing.T) {
+	dir := t.TempDir()
+	pre := SnapshotExecutables(dir)
+	bin, sum := writeFakeBinary(t, dir, "fake", "fake tool v1\n")
+	wrong := strings.Repeat("0", 64)
+
+	digests, err := verifyInstalled(InstallOptions{Spec: "example.com/fake@v1.0.0", SHA256: wrong, SkipVerify: true}, dir, pre)
+	if err != nil || len(digests) != 1 || digests[0].SHA256 != sum {
+		t.Fatalf("skip verify: digests = %+v, err = %v", digests, err)
+	}
+	if _, err := verifyInstalled(InstallOptions{SHA256: "SHA256:" + strings.ToUpper(sum)}, dir, pre); err != nil {
+		t.Fatalf("matching sha256 should pass: %v", err)
+	}
+
+	_, err = verifyInstalled(InstallOptions{SHA256: wrong}, dir, pre)
+	if err == nil || !strings.Contains(err.Error(), "sha256 mismatch") {
+		t.Fatalf("wrong sha256 should fail with a mismatch error, got %v", err)
+	}
+	if _, statErr := os.Stat(bin); !os.IsNotExist(statErr) {
+		t.Errorf("mismatched binary should be removed")
+	}
+}
+
+// 测试没有新文件时（重新安装相同版本）按模块规范推断的名称找到二进制
+func TestInstalledBinariesUnchanged(t *testing.T) {
+	dir := t.TempDir()
+	bin, _ := writeFakeBinary(t, dir, "y", "y\n")
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(bin, past, past); err != nil {
+		t.Fatal(err)
+	}
+	pre := SnapshotExecutables(dir)
+	if got := installedBinaries(dir, pre, "", "github.com/x/y/v2@v2.1.0"); len(got) != 1 || got[0] != bin {
+		t.Errorf("installedBinaries = %v, want [%s]", got, bin)
+	}
+}
+
+// 测试 tools verify：按配置中记录的哈希报告 ok/mismatch/missing/unrecorded
+func TestVerifyTools(t *testing.T) {
+	gopath := t.TempDir()
+	bin := filepath.Join(gopath, "bin")
+	if err := os.MkdirAll(bin, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GOPATH", gopath)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", os.Getenv("HOME"))
+	ClearToolsCache()
+	t.Cleanup(ClearToolsCache)
+
+	_, goodSum := writeFakeBinary(t, bin, "good", "good\n")
+	writeFakeBinary(t, bin, "bad", "tampered\n")
+	writeFakeBinary(t, bin, "plain", "plain\n")
+	cfg := &configs.Config{}
+	cfg.Tools.Deps = []configs.Tool{
+		{Module: "example.com/good/cmd/good@v1.0.0", SHA256: goodSum},
+		{Module: "example.com/bad/v2@v2.0.0", SHA256: strings.Repeat("1", 64)},
+		{CloneURL: "https://example.com/gone.git", SHA256: strings.Repeat("2", 64)},
+	}
+
+	entries, err := VerifyTools(VerifyCommandOptions{Config: cfg, GoCLIToolsPath: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"good": VerifyOK, "bad": VerifyMismatch, "gone": VerifyMissing}
+	if len(entries) != len(want) {
+		t.Fatalf("entries = %+v", entries)
+	}
+	for _, e := range entries {
+		if e.Status != want[e.Name] {
+			t.Errorf("%s: status = %s, want %s", e.Name, e.Status, want[e.Name])
+		}
+	}
+
+	entries, err = VerifyTools(VerifyCommandOptions{Names: []string{"plain"}, Config: cfg, GoCLIToolsPath: t.TempDir()})
+	if err != nil || len(entries) != 1 || entries[0].Status != VerifyUnrecorded || entries[0].Actual == "" {
+		t.Errorf("plain: entries = %+v, err = %v", entries, err)
+	}
+
+	var out strings.Builder
+	if err := ExecuteVerifyCommand(VerifyCommandOptions{Config: cfg, GoCLIToolsPath: t.TempDir()}, &out); err == nil {
+		t.Error("drift should make tools verify fail")
+	}
+	if !strings.Contains(out.String(), VerifyMissing) {
+		t.Errorf("table should report the missing tool:\n%s", out.String())
+	}
+}
+
+// 测试安装时记录的哈希：tools verify 在工具定义没有 sha256 时使用它，二进制被改动后报告 mismatch
+func TestVerifyTools_InstallRecord(t *testing.T) {
+	gopath := t.TempDir()
+	bin := filepath.Join(gopath, "bin")
+	if err := os.MkdirAll(bin, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GOPATH", gopath)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", os.Getenv("HOME"))
+	ClearToolsCache()
+	t.Cleanup(ClearToolsCache)
+
+	pre := SnapshotExecutables(bin)
+	p, _ := writeFakeBinary(t, bin, "rec", "rec v1\n")
+	if _, err := verifyInstalled(InstallOptions{Spec: "example.com/rec@v1.0.0"}, bin, pre); err != nil {
+		t.Fatal(err)
+	}
+	writeFakeBinary(t, bin, "other", "other\n")
+	if err := recordDigests(bin, []BinaryDigest{{Binary: filepath.Join(bin, "gone"), SHA256: strings.Repeat("3", 64)}}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := VerifyTools(VerifyCommandOptions{GoCLIToolsPath: t.TempDir()})
+	if err != nil || len(entries) != 1 || entries[0].Name != "rec" || entries[0].Status != VerifyOK {
+		t.Fatalf("entries = %+v, err = %v", entries, err)
+	}
+
+	if err := os.WriteFile(p, []byte("tampered\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	entries, err = VerifyTools(VerifyCommandOptions{GoCLIToolsPath: t.TempDir()})
+	if err != nil || len(entries) != 1 || entries[0].Status != VerifyMismatch {
+		t.Errorf("tampered: entries = %+v, err = %v", entries, err)
+	}
+}
@@ -0,0 +1,66 @@
+// Package clipboard copies text to the system clipboard using the platform's clipboard tools.
+package clipboard
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// ErrUnavailable 表示当前系统没有可用的剪贴板工具
+var ErrUnavailable = errors.New("no clipboard tool found (install pbcopy, wl-copy, xclip or xsel)")
+
+// candidates 返回各平台按优先级排列的剪贴板命令
+//   - macOS: pbcopy
+//   - Windows: clip
+//   - 其他（Linux/BSD）: Wayland 会话优先 wl-copy，其次 xclip、xsel
+func candidates(goos string, wayland bool) [][]string {
+	switch goos {
+	case "darwin":
+		return [][]string{{"pbcopy"}}
+	case "windows":
+		return [][]string{{"clip"}}
+	}
+	x11 := [][]string{
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+	}
+	if wayland {
+		return append([][]string{{"wl-copy"}}, x11...)
+	}
+	return x11
+}
+
+// command 返回第一个在 PATH 中可用的剪贴板命令
+func command(goos string, wayland bool, lookPath func(string) (string, error)) ([]string, error) {
+	for _, c := range candidates(goos, wayland) {
+		if _, err := lookPath(c[0]); err == nil {
+			return c, nil
+		}
+	}
+	return nil, ErrUnavailable
+}
+
+func systemCommand() ([]string, error) {
+	return command(runtime.GOOS, os.Getenv("WAYLAND_DISPLAY") != "", exec.LookPath)
+}
+
+// Available 检查系统剪贴板工具是否可用，不可用时返回 ErrUnavailable
+func Available() error {
+	_, err := systemCommand()
+	return err
+}
+
+// WriteAll 将 text 写入系统剪贴板
+func WriteAll(text string) error {
+	c, err := systemCommand()
+	if err != nil {
+		return err
+	}
+	_, err = executor.NewExecutor(c[0], c[1:]...).WithStdin(strings.NewReader(text)).Output()
+	return err
+}
@@ -0,0 +1,40 @@
+package clipboard
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// 测试按平台与 PATH 选择剪贴板命令
+func TestCommand(t *testing.T) {
+	only := func(names ...string) func(string) (string, error) {
+		return func(name string) (string, error) {
+			if slices.Contains(names, name) {
+				return "/usr/bin/" + name, nil
+			}
+			return "", errors.New("not found")
+		}
+	}
+	tests := []struct {
+		goos    string
+		wayland bool
+		path    []string
+		want    string
+	}{
+		{"darwin", false, []string{"pbcopy"}, "pbcopy"},
+		{"windows", false, []string{"clip"}, "clip"},
+		{"linux", true, []string{"wl-copy", "xclip"}, "wl-copy"},
+		{"linux", false, []string{"wl-copy", "xclip"}, "xclip"},
+		{"linux", false, []string{"xsel"}, "xsel"},
+	}
+	for _, tt := range tests {
+		c, err := command(tt.goos, tt.wayland, only(tt.path...))
+		if err != nil || c[0] != tt.want {
+			t.Errorf("command(%s, wayland=%v) = %v, %v; want %s", tt.goos, tt.wayland, c, err, tt.want)
+		}
+	}
+	if _, err := command("linux", false, only()); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("expected ErrUnavailable, got %v", err)
+	}
+}
@@ -3,14 +3,17 @@ package count
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 
 	"github.com/yeisme/gocli/pkg/models"
+	"github.com/yeisme/gocli/pkg/utils/fsop"
 	"github.com/yeisme/gocli/pkg/utils/gitignore"
 )
 
@@ -30,6 +33,12 @@ type ProjectCounter struct {
 //	opts: 包含各种统计选项，如包含/排除规则、是否遵循符号链接等
 //	返回值: 一个包含所有已处理文件信息的切片，以及遇到的第一个非文件大小限制的错误
 func (p *ProjectCounter) CountAllFiles(ctx context.Context, root string, opts Options) ([]models.FileInfo, error) {
+	files, _, err := p.countAllFiles(ctx, root, opts)
+	return files, err
+}
+
+// countAllFiles 是 CountAllFiles 的实现，额外返回因超过大小限制而跳过的文件
+func (p *ProjectCounter) countAllFiles(ctx context.Context, root string, opts Options) ([]models.FileInfo, []models.SkippedFile, error) {
 	// 确保内部的计数器都已初始化，防止空指针异常
 	p = ensureCounters(p)
 
@@ -38,22 +47,28 @@ func (p *ProjectCounter) CountAllFiles(ctx context.Context, root string, opts Op
 
 	// 步骤1: 收集所有需要处理的文件路径
 	// 这个阶段会遍历目录，并根据 .gitignore、include/exclude 规则、文件大小等进行过滤，并且过滤一些常见的目录 .git
-	filesToProcess, err := collectFiles(ctx, root, opts, gi)
+	filesToProcess, skipped, err := collectFiles(ctx, root, opts, gi)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// 步骤2: 准备并发处理根据用户设置或CPU核心数确定并发的 worker 数量
 	conc := prepareConcurrency(opts.Concurrency)
 
 	// 步骤3: 并发处理所有收集到的文件，并收集结果
-	results, firstErr := processFilesConcurrently(ctx, p, root, filesToProcess, opts, conc)
+	results, tooLarge, firstErr := processFilesConcurrently(ctx, p, root, filesToProcess, opts, conc)
+	skipped = append(skipped, tooLarge...)
+	// 被取消时不返回部分结果（例如 Ctrl+C 中断统计），返回取消的原因
+	if ctx.Err() != nil {
+		return nil, nil, context.Cause(ctx)
+	}
 	// 如果处理过程中发生错误，并且没有成功处理任何文件，则返回错误
 	// 否则，即使有错误，也可能返回部分成功的结果
 	if firstErr != nil && len(results) == 0 {
-		return nil, firstErr
+		return nil, nil, firstErr
 	}
-	return results, nil
+	sort.Slice(skipped, func(i, j int) bool { return skipped[i].Path < skipped[j].Path })
+	return results, skipped, nil
 }
 
 // CountProjectSummary 在 CountAllFiles 的基础上，对所有文件的统计结果进行聚合
@@ -65,13 +80,27 @@ func (p *ProjectCounter) CountAllFiles(ctx context.Context, root string, opts Op
 //
 // 返回值: 一个包含详细聚合分析结果的指针，或者在获取文件列表时发生的错误
 func (p *ProjectCounter) CountProjectSummary(ctx context.Context, root string, opts Options) (*models.AnalysisResult, error) {
-	// 首先，获取所有独立文件的统计信息
-	files, err := p.CountAllFiles(ctx, root, opts)
+	// 作者归属需要 git 工作区，不在仓库内时省略
+	opts.Owners = opts.Owners || opts.OwnersFast
+	if opts.Owners && !inGitWorkTree(root) {
+		opts.Owners, opts.OwnersFast = false, false
+	}
+	// 首先，获取所有独立文件的统计信息（逐文件 blame 在 worker 中完成）
+	files, skipped, err := p.countAllFiles(ctx, root, opts)
 	if err != nil {
 		return nil, err
 	}
+	if opts.OwnersFast {
+		if byPath, err := numstatAuthors(ctx, root); err == nil {
+			for i := range files {
+				files[i].Authors = byPath[filepath.ToSlash(files[i].Path)]
+			}
+		}
+	}
 	// 然后，将这些独立的文件信息聚合成一个总的分析报告
-	return aggregateAnalysis(files, opts), nil
+	res := aggregateAnalysis(files, opts)
+	res.Skipped = skipped
+	return res, nil
 }
 
 // -----------------------------------------------------------------------------
@@ -94,6 +123,13 @@ func matchesAny(relPath string, patterns []string) bool {
 		if p == "" {
 			continue
 		}
+		// `**` 模式只做分段匹配，不再退回子串匹配
+		if isDoublestar(p) {
+			if matchDoublestar(normalizePattern(p), relPath) {
+				return true
+			}
+			continue
+		}
 		// 优先使用更精确的 glob 模式匹配
 		if ok, _ := filepath.Match(p, relPath); ok {
 			return true
@@ -112,13 +148,32 @@ func isSymlink(d fs.DirEntry) bool {
 	return d.Type()&fs.ModeSymlink != 0
 }
 
-// isSizeLimitError 用于识别因文件过大而被跳过时可能产生的错误
-// 注意:在当前的实现中，我们在遍历（WalkDir）时已经通过 `overSize` 函数提前过滤了过大的文件，
-// 所以这个函数主要用于防御性编程或兼容未来的修改
+// SizeLimitError 表示文件超过 Options.MaxFileSizeBytes 而未被统计
+type SizeLimitError struct {
+	Path  string
+	Size  int64
+	Limit int64
+}
+
+func (e *SizeLimitError) Error() string {
+	return fmt.Sprintf("file size exceeds limit: %d > %d", e.Size, e.Limit)
+}
+
+// skippedFile 把超限信息转换为结果中的跳过记录
+func (e *SizeLimitError) skippedFile(rel string) models.SkippedFile {
+	return models.SkippedFile{Path: rel, Size: e.Size, Reason: fmt.Sprintf("exceeds max file size (%d bytes)", e.Limit)}
+}
+
+// isSizeLimitError 用于识别因文件过大而被跳过时产生的错误
+// 遍历时已经通过 `overSize` 提前过滤了过大的文件，这里处理收集之后文件变大（SingleFileCounter 再次检查）的情况
 func isSizeLimitError(err error) bool {
 	if err == nil {
 		return false
 	}
+	var sizeErr *SizeLimitError
+	if errors.As(err, &sizeErr) {
+		return true
+	}
 	// Go 标准库中没有一个特定的错误类型来表示"文件过大"
 	// 这是一个尝试性的检查，但目前没有稳定的方式来识别它，因此通常返回 false
 	var pathErr *fs.PathError
@@ -163,17 +218,28 @@ func loadGitIgnore(root string, respect bool) *gitignore.GitIgnore {
 	return gi
 }
 
-// collectFiles 使用 `filepath.WalkDir` 递归遍历项目目录，收集所有符合条件的文件路径
-// 这是文件发现和过滤的主要逻辑所在
+// collectFiles 使用 `fsop.Walk` 递归遍历项目目录，收集所有符合条件的文件路径
+// 这是文件发现和过滤的主要逻辑所在；跟随符号链接时由 fsop.Walk 负责循环检测、深度限制与去重
 //
 //	ctx: 用于取消遍历过程
 //	root: 遍历的起始目录
 //	opts: 包含过滤规则的选项
 //	gi: 已加载的 gitignore 规则处理器
-func collectFiles(ctx context.Context, root string, opts Options, gi *gitignore.GitIgnore) ([]string, error) {
+func collectFiles(ctx context.Context, root string, opts Options, gi *gitignore.GitIgnore) ([]string, []models.SkippedFile, error) {
 	// 预分配切片容量，提高性能256 是一个合理的初始猜测值
 	files := make([]string, 0, 256)
-	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, walkErr error) error {
+	var skipped []models.SkippedFile
+	// tooLarge 检查大小限制，超限时记录到 skipped
+	tooLarge := func(path, relSlash string) bool {
+		size, over := fileOverSize(path, opts.MaxFileSizeBytes)
+		if over {
+			e := &SizeLimitError{Path: path, Size: size, Limit: opts.MaxFileSizeBytes}
+			skipped = append(skipped, e.skippedFile(relSlash))
+		}
+		return over
+	}
+	walkOpts := fsop.WalkOptions{FollowSymlinks: opts.FollowSymlinks, StayInRoot: opts.StayInRoot, MaxDepth: opts.MaxDepth}
+	err := fsop.Walk(root, walkOpts, func(path string, d fs.DirEntry, walkErr error) error {
 		// 首先处理遍历过程中可能发生的 I/O 错误
 		if walkErr != nil {
 			return walkErr
@@ -205,6 +271,9 @@ func collectFiles(ctx context.Context, root string, opts Options, gi *gitignore.
 		if !shouldIncludeFile(relSlash, opts, gi) {
 			return nil
 		}
+		if opts.GoOnly && !isGoFile(relSlash) {
+			return nil
+		}
 
 		// 处理符号链接
 		if isSymlink(d) {
@@ -213,7 +282,7 @@ func collectFiles(ctx context.Context, root string, opts Options, gi *gitignore.
 				return nil
 			}
 			// 如果跟随，依然要检查链接指向的文件大小是否超限
-			if overSize(path, opts.MaxFileSizeBytes) {
+			if tooLarge(path, relSlash) {
 				return nil
 			}
 			files = append(files, path)
@@ -221,7 +290,7 @@ func collectFiles(ctx context.Context, root string, opts Options, gi *gitignore.
 		}
 
 		// 对于普通文件，检查是否超过大小限制
-		if overSize(path, opts.MaxFileSizeBytes) {
+		if tooLarge(path, relSlash) {
 			return nil
 		}
 
@@ -230,9 +299,9 @@ func collectFiles(ctx context.Context, root string, opts Options, gi *gitignore.
 		return nil
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return files, nil
+	return files, skipped, nil
 }
 
 // toRelSlash 将绝对路径 `path` 转换为相对于 `root` 的路径，并确保路径分隔符为 `/`
@@ -246,6 +315,7 @@ func toRelSlash(root, path string) string {
 // 跳过的条件:
 //  1. 目录被 `.gitignore` 规则匹配
 //  2. 没有设置 `Include` 规则，但目录匹配了 `Exclude` 规则
+//  3. `Include` 规则全部为 `**` 模式，且目录之下不可能存在匹配的路径（例如 `pkg/**/*.go` 时的 `cmd`）
 func shouldSkipDir(relSlash string, opts Options, gi *gitignore.GitIgnore) bool {
 	// 默认忽略任意层级的 .git 目录（例如 .git, foo/.git, a/b/.git）
 	if relSlash == ".git" || strings.HasSuffix(relSlash, "/.git") || strings.Contains(relSlash, "/.git/") {
@@ -254,6 +324,9 @@ func shouldSkipDir(relSlash string, opts Options, gi *gitignore.GitIgnore) bool
 	if gi != nil && gi.IsIgnored(relSlash) {
 		return true
 	}
+	if len(opts.Include) > 0 && !mayIncludeUnder(relSlash, opts.Include) {
+		return true
+	}
 	// 当 Include 列表为空时，Exclude 规则才对目录生效
 	// 这是为了避免排除一个目录，但其子文件可能被 Include 规则包含的情况
 	if len(opts.Include) == 0 {
@@ -264,7 +337,8 @@ func shouldSkipDir(relSlash string, opts Options, gi *gitignore.GitIgnore) bool
 		// 另外支持像 `pkg/*`、`pkg/`、`pkg` 这样的排除模式匹配目录及其子项
 		for _, raw := range opts.Exclude {
 			p := strings.TrimSpace(raw)
-			if p == "" {
+			// `**` 模式已由 matchesAny 按分段匹配处理，不做前缀比较
+			if p == "" || isDoublestar(p) {
 				continue
 			}
 			// 规范化为使用 `/` 的形式，并去掉前导 `./` 或 `.\\`
@@ -300,6 +374,21 @@ func shouldSkipDir(relSlash string, opts Options, gi *gitignore.GitIgnore) bool
 	return false
 }
 
+// mayIncludeUnder 判断目录下是否可能有文件被 Include 规则包含；
+// 只要有一个非 `**` 模式（其子串/后缀匹配无法按目录推断）就保守地返回 true
+func mayIncludeUnder(relSlash string, include []string) bool {
+	for _, raw := range include {
+		p := normalizePattern(raw)
+		if p == "" {
+			continue
+		}
+		if !isDoublestar(p) || mayMatchUnder(p, relSlash) {
+			return true
+		}
+	}
+	return false
+}
+
 // shouldIncludeFile 判断是否应该包含一个文件用于统计
 // 包含的逻辑优先级:
 //  1. 如果被 `.gitignore` 忽略，则不包含
@@ -348,6 +437,12 @@ func includeMatches(rel string, include []string) bool {
 		if p == "" {
 			continue
 		}
+		if isDoublestar(p) {
+			if matchDoublestar(p, rel) {
+				return true
+			}
+			continue
+		}
 		if ok, _ := filepath.Match(p, rel); ok {
 			return true
 		}
@@ -379,6 +474,12 @@ func excludeMatches(rel string, exclude []string) bool {
 		if p == "" {
 			continue
 		}
+		if isDoublestar(p) {
+			if matchDoublestar(p, rel) {
+				return true
+			}
+			continue
+		}
 		if ok, _ := filepath.Match(p, rel); ok {
 			return true
 		}
@@ -408,14 +509,20 @@ func excludeMatches(rel string, exclude []string) bool {
 // overSize 检查文件大小是否超过给定的限制 `limit`
 // 如果 limit <= 0，则表示没有大小限制
 func overSize(path string, limit int64) bool {
+	_, over := fileOverSize(path, limit)
+	return over
+}
+
+// fileOverSize 与 overSize 相同，同时返回文件大小
+func fileOverSize(path string, limit int64) (int64, bool) {
 	if limit <= 0 {
-		return false
+		return 0, false
 	}
 	if st, err := os.Stat(path); err == nil {
-		return st.Size() > limit
+		return st.Size(), st.Size() > limit
 	}
 	// 如果获取文件状态失败，保守地认为它没有超大，让后续处理步骤去报告这个错误
-	return false
+	return 0, false
 }
 
 // prepareConcurrency 确定用于处理文件的并发 worker 数量
@@ -444,7 +551,7 @@ func processFilesConcurrently(
 	files []string,
 	opts Options,
 	conc int,
-) ([]models.FileInfo, error) {
+) ([]models.FileInfo, []models.SkippedFile, error) {
 	// 定义一个内部类型，用于在 channel 中传递结果或错误
 	type item struct {
 		info models.FileInfo
@@ -479,12 +586,14 @@ func processFilesConcurrently(
 		// 在这个 goroutine 退出前，要确保关闭 outCh
 		// 这需要等待所有 worker 都完成（wg.Wait()）
 		defer close(outCh)
+	dispatch:
 		for _, f := range files {
-			// 在发送任务前检查上下文是否已取消
-			if ctx.Err() != nil {
-				break
+			// 上下文取消后停止分发，不再等待空闲的 worker
+			select {
+			case <-ctx.Done():
+				break dispatch
+			case inCh <- f:
 			}
-			inCh <- f
 		}
 		// 所有任务都已发送，关闭 inCh，worker 会在读取完 channel 后自动退出
 		close(inCh)
@@ -494,11 +603,23 @@ func processFilesConcurrently(
 
 	// 在主 goroutine 中收集结果
 	results := make([]models.FileInfo, 0, len(files))
+	var skipped []models.SkippedFile
 	var firstErr error
+	done := 0
+	if opts.Progress != nil {
+		opts.Progress(0, len(files))
+	}
 	for it := range outCh {
+		done++
+		if opts.Progress != nil {
+			opts.Progress(done, len(files))
+		}
 		if it.err != nil {
-			// 忽略因文件过大而产生的错误，但记录遇到的第一个其他类型的错误
-			if !isSizeLimitError(it.err) && firstErr == nil {
+			// 因文件过大而产生的错误记为跳过，并记录遇到的第一个其他类型的错误
+			var sizeErr *SizeLimitError
+			if errors.As(it.err, &sizeErr) {
+				skipped = append(skipped, sizeErr.skippedFile(toRelSlash(root, sizeErr.Path)))
+			} else if !isSizeLimitError(it.err) && firstErr == nil {
 				firstErr = it.err
 			}
 			continue
@@ -506,7 +627,7 @@ func processFilesConcurrently(
 		results = append(results, it.info)
 	}
 
-	return results, firstErr
+	return results, skipped, firstErr
 }
 
 // processFile 处理单个文件的统计任务
@@ -528,19 +649,29 @@ func processFile(ctx context.Context, p *ProjectCounter, root, path string, opts
 		fi.Path = rel
 	}
 
-	// 如果文件是 Go 文件，并且选项要求分析特定语言细节
-	if opts.WithLanguageSpecific && fi.Language == "Go" {
+	// 逐文件统计作者归属；未跟踪的文件没有作者
+	if opts.Owners && !opts.OwnersFast {
+		fi.Authors, _ = blameAuthors(ctx, path)
+	}
+
+	// Go 文件总是记录用途与构建约束（用于拆分生产/测试代码）；选项要求时再分析包名、导入等细节
+	if fi.Language == "Go" {
+		details := &models.GoDetails{}
 		// 调用 Go 语言专用的计数器
-		if details, derr := p.GoCounter.CountGoDetails(ctx, path); derr == nil && details != nil {
-			// 根据选项决定是否包含函数和结构体的计数
-			if !opts.WithFunctions {
-				details.Functions = 0
-			}
-			if !opts.WithStructs {
-				details.Structs = 0
+		if opts.WithLanguageSpecific {
+			if d, derr := p.GoCounter.CountGoDetails(ctx, path); derr == nil && d != nil {
+				// 根据选项决定是否包含函数和结构体的计数
+				if !opts.WithFunctions {
+					d.Functions = 0
+				}
+				if !opts.WithStructs {
+					d.Structs = 0
+				}
+				details = d
 			}
-			fi.LanguageSpecific = details
 		}
+		details.GoBuild = classifyGoFile(path)
+		fi.LanguageSpecific = details
 	}
 
 	return *fi, nil
@@ -609,5 +740,11 @@ func aggregateAnalysis(files []models.FileInfo, opts Options) *models.AnalysisRe
 			res.Files = append(res.Files, f)
 		}
 	}
+	if ls, ok := res.Languages["Go"]; ok {
+		ls.Go = goBreakdown(files)
+	}
+	if opts.Owners || opts.OwnersFast {
+		aggregateOwners(res, files)
+	}
 	return res
 }
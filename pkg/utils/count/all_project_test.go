@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sync/atomic"
 	"testing"
 
 	"github.com/yeisme/gocli/pkg/models"
@@ -74,14 +75,14 @@ func Test_collectFiles_and_Filters(t *testing.T) {
 		panic(err)
 	}
 	gi := loadGitIgnore(dir, true)
-	files, err := collectFiles(context.Background(), dir, Options{Include: []string{"*.go"}}, gi)
+	files, _, err := collectFiles(context.Background(), dir, Options{Include: []string{"*.go"}}, gi)
 	if err != nil {
 		t.Fatalf("err: %v", err)
 	}
 	if len(files) != 1 {
 		t.Fatalf("expected 1 got %d", len(files))
 	}
-	files2, _ := collectFiles(context.Background(), dir, Options{}, gi)
+	files2, _, _ := collectFiles(context.Background(), dir, Options{}, gi)
 	foundSub := false
 	for _, f := range files2 {
 		if filepath.Base(f) == "c.go" {
@@ -93,6 +94,47 @@ func Test_collectFiles_and_Filters(t *testing.T) {
 	}
 }
 
+// 测试 --follow-symlinks 遇到循环链接时能结束：每个文件只统计一次，root 之外的链接默认不统计
+func Test_collectFiles_SymlinkCycle(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require extra privileges on windows")
+	}
+	dir, outside := t.TempDir(), t.TempDir()
+	for _, p := range []string{filepath.Join(dir, "pkg", "a.go"), filepath.Join(dir, "b.go"), filepath.Join(outside, "c.go")} {
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("package x\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	links := map[string]string{
+		filepath.Join(dir, "pkg", "up"): dir,                        // pkg/up -> 项目根（循环）
+		filepath.Join(dir, "alias.go"):  filepath.Join(dir, "b.go"), // 同一文件的第二条路径
+		filepath.Join(dir, "ext"):       outside,                    // 指向项目之外
+	}
+	for link, target := range links {
+		if err := os.Symlink(target, link); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, _, err := collectFiles(context.Background(), dir, Options{FollowSymlinks: true, StayInRoot: true}, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("stay in root: expected 2 files, got %v", files)
+	}
+	files, _, err = collectFiles(context.Background(), dir, Options{FollowSymlinks: true}, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("outside root allowed: expected 3 files, got %v", files)
+	}
+}
+
 func Test_toRelSlash(t *testing.T) {
 	root := filepath.Join("a", "b")
 	path := filepath.Join(root, "c", "d.go")
@@ -116,6 +158,66 @@ func Test_shouldSkipDir_and_shouldIncludeFile(t *testing.T) {
 	}
 }
 
+// 测试 `**` 模式：跨层匹配文件，并在遍历时剪枝不可能匹配或被排除的目录
+func Test_doublestarPatterns(t *testing.T) {
+	cases := []struct {
+		pattern, rel string
+		want         bool
+	}{
+		{"pkg/**/*.go", "pkg/a.go", true},
+		{"pkg/**/*.go", "pkg/x/y/b.go", true},
+		{"pkg/**/*.go", "cmd/c.go", false},
+		{"pkg/**/*.go", "pkg/x/readme.md", false},
+		{"**/*.go", "main.go", true},
+		{"**/testdata/**", "testdata/a.txt", true},
+		{"**/testdata/**", "pkg/x/testdata/in/a.go", true},
+		{"**/testdata/**", "pkg/testdatax/a.go", false},
+	}
+	for _, c := range cases {
+		if got := matchDoublestar(c.pattern, c.rel); got != c.want {
+			t.Errorf("matchDoublestar(%q, %q) = %v, want %v", c.pattern, c.rel, got, c.want)
+		}
+	}
+
+	include := Options{Include: []string{"pkg/**/*.go"}}
+	for dir, skip := range map[string]bool{"cmd": true, "pkg": false, "pkg/x/y": false} {
+		if got := shouldSkipDir(dir, include, nil); got != skip {
+			t.Errorf("include pkg/**/*.go: shouldSkipDir(%q) = %v, want %v", dir, got, skip)
+		}
+	}
+	exclude := Options{Exclude: []string{"**/testdata/**"}}
+	for dir, skip := range map[string]bool{"testdata": true, "pkg/x/testdata": true, "pkg": false, "pkg/x": false} {
+		if got := shouldSkipDir(dir, exclude, nil); got != skip {
+			t.Errorf("exclude **/testdata/**: shouldSkipDir(%q) = %v, want %v", dir, got, skip)
+		}
+	}
+
+	dir := t.TempDir()
+	for _, name := range []string{"main.go", "pkg/a.go", "pkg/x/y/b.go", "pkg/x/testdata/t.go", "cmd/c.go"} {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("package x\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files, _, err := collectFiles(context.Background(), dir, include, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("include pkg/**/*.go: expected 3 files, got %v", files)
+	}
+	files, _, err = collectFiles(context.Background(), dir, Options{Exclude: []string{"**/testdata/**", "cmd/**"}}, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("exclude **/testdata/** and cmd/**: expected 3 files, got %v", files)
+	}
+}
+
 func Test_overSize(t *testing.T) {
 	dir := t.TempDir()
 	p := filepath.Join(dir, "f.txt")
@@ -130,6 +232,34 @@ func Test_overSize(t *testing.T) {
 	}
 }
 
+// 测试超过 MaxFileSizeBytes 的文件出现在结果的 Skipped 中，而不是被静默丢弃
+func Test_CountProjectSummary_Skipped(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "small.go"), []byte("package a\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "gen"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	big := make([]byte, 4096)
+	if err := os.WriteFile(filepath.Join(dir, "gen", "big.go"), big, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	res, err := (&ProjectCounter{}).CountProjectSummary(context.Background(), dir, Options{MaxFileSizeBytes: 1024})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Total.FileCount != 1 || len(res.Skipped) != 1 {
+		t.Fatalf("expected 1 counted and 1 skipped file, got %d / %v", res.Total.FileCount, res.Skipped)
+	}
+	if sf := res.Skipped[0]; sf.Path != "gen/big.go" || sf.Size != 4096 || sf.Reason == "" {
+		t.Fatalf("unexpected skipped entry %+v", sf)
+	}
+	if !isSizeLimitError(&SizeLimitError{Size: 2, Limit: 1}) {
+		t.Fatal("SizeLimitError should be recognized")
+	}
+}
+
 func Test_process_and_summary(t *testing.T) {
 	dir := t.TempDir()
 	if err := os.WriteFile(filepath.Join(dir, "a.go"), []byte("package a\n// c\n\nfunc A(){}"), 0o644); err != nil {
@@ -147,6 +277,24 @@ func Test_process_and_summary(t *testing.T) {
 	}
 }
 
+// 测试 Progress 回调：先报告 (0, total)，最后一次为 (total, total)
+func Test_processFilesConcurrently_Progress(t *testing.T) {
+	dir := t.TempDir()
+	for i := 0; i < 10; i++ {
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("f%d.go", i)), []byte("package p"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var calls [][2]int
+	opts := Options{Concurrency: 4, Progress: func(done, total int) { calls = append(calls, [2]int{done, total}) }}
+	if _, err := (&ProjectCounter{}).CountAllFiles(context.Background(), dir, opts); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 11 || calls[0] != [2]int{0, 10} || calls[10] != [2]int{10, 10} {
+		t.Fatalf("unexpected progress calls %v", calls)
+	}
+}
+
 func Test_processFilesConcurrently_ContextCancel(t *testing.T) {
 	dir := t.TempDir()
 	for i := 0; i < 50; i++ {
@@ -163,6 +311,47 @@ func Test_processFilesConcurrently_ContextCancel(t *testing.T) {
 	}
 }
 
+// cancelAfterCounter 在统计第 n 个文件时取消 ctx，模拟统计途中按下 Ctrl+C
+type cancelAfterCounter struct {
+	SingleFileCounter
+	n      int32
+	calls  atomic.Int32
+	cancel context.CancelCauseFunc
+}
+
+func (c *cancelAfterCounter) CountSingleFile(ctx context.Context, path string, opts Options) (*models.FileInfo, error) {
+	if c.calls.Add(1) == c.n {
+		c.cancel(errInterrupted)
+	}
+	return c.SingleFileCounter.CountSingleFile(ctx, path, opts)
+}
+
+var errInterrupted = errors.New("interrupted")
+
+// 测试统计途中取消：尽早返回取消原因而不是部分结果，远未处理完所有文件
+func Test_CountAllFiles_CancelMidway(t *testing.T) {
+	dir := t.TempDir()
+	const total = 500
+	for i := 0; i < total; i++ {
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("f%d.txt", i)), []byte("line\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+	fc := &cancelAfterCounter{n: 5, cancel: cancel}
+	files, err := (&ProjectCounter{FileCounter: fc}).CountAllFiles(ctx, dir, Options{Concurrency: 2})
+	if !errors.Is(err, errInterrupted) {
+		t.Fatalf("err = %v, want the cancel cause", err)
+	}
+	if files != nil {
+		t.Errorf("expected no partial results, got %d files", len(files))
+	}
+	if n := fc.calls.Load(); n > total/10 {
+		t.Errorf("counted %d of %d files after cancelling", n, total)
+	}
+}
+
 func Test_isSymlink(t *testing.T) {
 	dir := t.TempDir()
 	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("hi"), 0o644); err != nil {
@@ -14,16 +14,27 @@ type Options struct {
 	Include          []string // 仅统计匹配这些 glob 的路径（优先级高于 Exclude）
 	Exclude          []string // 排除匹配这些 glob 的路径（如 vendor、.git、node_modules 等）
 	RespectGitignore bool     // 是否遵循 .gitignore
-	FollowSymlinks   bool     // 是否跟随符号链接
+	FollowSymlinks   bool     // 是否跟随符号链接（按真实路径检测循环，同一文件只统计一次）
+	StayInRoot       bool     // 跟随符号链接时跳过解析到 root 之外的链接
+	MaxDepth         int      // 最大目录深度（<=0 表示使用 fsop.DefaultMaxDepth）
 	MaxFileSizeBytes int64    // 超过该大小的文件将被跳过（0 表示不限制）
+	GoOnly           bool     // 只统计 .go 文件，跳过其他文件（大仓库中更快）
 
 	// 并发控制
 	Concurrency int // 并发文件处理数量（<=0 表示由实现决定）
 
+	// Progress 非空时在收集完文件后以 (0, total) 调用一次，之后每处理完一个文件调用一次；
+	// 始终在同一个 goroutine 中调用
+	Progress func(done, total int)
+
 	// 统计项开关（关闭可加速）
 	WithFunctions bool // 统计函数数量（若实现支持）
 	WithStructs   bool // 统计结构体数量（若实现支持）
 
+	// 作者归属（仅在 git 工作区内生效，否则忽略）
+	Owners     bool // 按 git blame 统计每个文件各作者仍然存在的行数，聚合到 LanguageStats.Owners
+	OwnersFast bool // 改用一次 git log --numstat 近似统计（新增减删除），隐含 Owners
+
 	// 结果细节
 	WithFileDetails     bool // 填充 AnalysisResult.Files 列表
 	WithLanguageDetails bool // 填充 LanguageStats.Files 列表
@@ -0,0 +1,65 @@
+package count
+
+import (
+	"path"
+	"strings"
+)
+
+// isDoublestar 判断模式是否使用了 `**`（跨任意层目录）
+func isDoublestar(pattern string) bool {
+	return strings.Contains(pattern, "**")
+}
+
+// matchDoublestar 按 `/` 分段匹配路径，单独成段的 `**` 匹配零个或多个目录层级，
+// 其他段使用 path.Match（`*`、`?`、`[...]` 不跨越 `/`）
+//
+//	pkg/**/*.go      匹配 pkg/a.go、pkg/x/y/b.go
+//	**/testdata/**   匹配 testdata、a/testdata 以及其下的所有路径
+func matchDoublestar(pattern, rel string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(rel, "/"))
+}
+
+func matchSegments(pat, segs []string) bool {
+	for len(pat) > 0 {
+		if pat[0] == "**" {
+			for len(pat) > 0 && pat[0] == "**" {
+				pat = pat[1:]
+			}
+			if len(pat) == 0 {
+				return true
+			}
+			for i := 0; i <= len(segs); i++ {
+				if matchSegments(pat, segs[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segs) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pat[0], segs[0]); !ok {
+			return false
+		}
+		pat, segs = pat[1:], segs[1:]
+	}
+	return len(segs) == 0
+}
+
+// mayMatchUnder 判断目录 dir 之下是否可能存在匹配 pattern 的路径，用于 include 时剪枝整个目录
+func mayMatchUnder(pattern, dir string) bool {
+	pat, segs := strings.Split(pattern, "/"), strings.Split(dir, "/")
+	for len(segs) > 0 {
+		if len(pat) == 0 {
+			return false
+		}
+		if pat[0] == "**" {
+			return true
+		}
+		if ok, _ := path.Match(pat[0], segs[0]); !ok {
+			return false
+		}
+		pat, segs = pat[1:], segs[1:]
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+package count
+
+import (
+	"bufio"
+	"go/build"
+	"go/build/constraint"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/yeisme/gocli/pkg/models"
+)
+
+// generatedComment 匹配 https://go.dev/s/generatedcode 约定的生成代码注释
+var generatedComment = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// isGoFile 判断路径是否为 Go 源文件（--go-only 只统计这些文件）
+func isGoFile(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".go")
+}
+
+// classifyGoFile 读取 Go 文件 package 子句之前的部分，判断它是否为测试文件、生成的代码，
+// 取出 //go:build 表达式，并按当前平台的构建上下文（GOOS/GOARCH、构建标签、文件名后缀）判断是否参与编译
+func classifyGoFile(path string) models.GoBuild {
+	b := models.GoBuild{Test: strings.HasSuffix(filepath.Base(path), "_test.go")}
+	if f, err := os.Open(path); err == nil {
+		sc := bufio.NewScanner(f)
+		for sc.Scan() {
+			line := strings.TrimSpace(sc.Text())
+			if strings.HasPrefix(line, "package ") {
+				break
+			}
+			switch {
+			case generatedComment.MatchString(line):
+				b.Generated = true
+			case constraint.IsGoBuild(line):
+				if expr, err := constraint.Parse(line); err == nil {
+					b.Constraint = expr.String()
+				}
+			}
+		}
+		_ = f.Close()
+	}
+	if ok, err := build.Default.MatchFile(filepath.Dir(path), filepath.Base(path)); err == nil {
+		b.Inactive = !ok
+	}
+	return b
+}
+
+// goBreakdown 汇总 Go 文件的生产/测试/生成/未编译统计以及按 //go:build 表达式的分组；没有 Go 文件时返回 nil
+func goBreakdown(files []models.FileInfo) *models.GoBreakdown {
+	var gb *models.GoBreakdown
+	groups := make(map[string]*models.ConstraintStats)
+	for _, f := range files {
+		gd, ok := f.LanguageSpecific.(*models.GoDetails)
+		if f.Language != "Go" || !ok || gd == nil {
+			continue
+		}
+		if gb == nil {
+			gb = &models.GoBreakdown{}
+		}
+		if gd.Test {
+			gb.Tests.Add(f.Stats)
+		} else {
+			gb.Prod.Add(f.Stats)
+		}
+		if gd.Generated {
+			gb.Generated.Add(f.Stats)
+		}
+		if gd.Inactive {
+			gb.Inactive.Add(f.Stats)
+		}
+		if gd.Constraint == "" {
+			continue
+		}
+		g, ok := groups[gd.Constraint]
+		if !ok {
+			g = &models.ConstraintStats{Expr: gd.Constraint, Inactive: true}
+			groups[gd.Constraint] = g
+		}
+		// 同一表达式的文件可能因文件名后缀而有不同结果，只要有一个参与编译就不算未编译
+		g.Inactive = g.Inactive && gd.Inactive
+		g.Add(f.Stats)
+	}
+	if gb == nil {
+		return nil
+	}
+	for _, g := range groups {
+		gb.Constraints = append(gb.Constraints, *g)
+	}
+	sort.Slice(gb.Constraints, func(i, j int) bool { return gb.Constraints[i].Expr < gb.Constraints[j].Expr })
+	return gb
+}
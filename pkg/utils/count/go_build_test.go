@@ -0,0 +1,81 @@
+package count
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/yeisme/gocli/pkg/models"
+)
+
+// 测试 Go 代码的拆分：生产/测试代码合计等于 Go 总计，生成的代码、//go:build 排除的文件与其他平台的文件名后缀
+// 被标记出来并按表达式分组；--go-only 跳过非 Go 文件
+func Test_goBreakdown(t *testing.T) {
+	otherOS := "plan9"
+	if runtime.GOOS == otherOS {
+		otherOS = "windows"
+	}
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.go":                 "package a\n\nfunc A() int {\n\treturn 1\n}\n",
+		"a_test.go":            "package a\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) {\n\tif A() != 1 {\n\t\tt.Fatal()\n\t}\n}\n",
+		"gen.go":               "// Code generated by stringer; DO NOT EDIT.\n\npackage a\n\nconst g = 1\n",
+		"ignored.go":           "//go:build ignore\n\npackage main\n\nfunc main() {}\n",
+		"new.go":               "//go:build go1.1\n\npackage a\n\nconst n = 1\n",
+		"a_" + otherOS + ".go": "package a\n\nconst os = 1\n",
+		"README.md":            "# a\n\ntext\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res, err := (&ProjectCounter{}).CountProjectSummary(context.Background(), dir, Options{Concurrency: 2, WithFileDetails: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	goStats := res.Languages["Go"]
+	if goStats == nil || goStats.Go == nil {
+		t.Fatalf("missing Go breakdown: %+v", res.Languages)
+	}
+	gb := goStats.Go
+	if gb.Prod.FileCount != 5 || gb.Tests.FileCount != 1 {
+		t.Errorf("prod/tests files = %d/%d, want 5/1", gb.Prod.FileCount, gb.Tests.FileCount)
+	}
+	if gb.Prod.Stats.Code+gb.Tests.Stats.Code != goStats.Stats.Code || gb.Tests.Stats.Code == 0 {
+		t.Errorf("prod %d + tests %d code != Go %d", gb.Prod.Stats.Code, gb.Tests.Stats.Code, goStats.Stats.Code)
+	}
+	for _, f := range res.Files {
+		if f.Path == "a_test.go" && f.Stats != gb.Tests.Stats {
+			t.Errorf("tests stats = %+v, want %+v", gb.Tests.Stats, f.Stats)
+		}
+	}
+	if gb.Generated.FileCount != 1 || gb.Inactive.FileCount != 2 {
+		t.Errorf("generated/inactive files = %d/%d, want 1/2", gb.Generated.FileCount, gb.Inactive.FileCount)
+	}
+	if len(gb.Constraints) != 2 || gb.Constraints[0].Expr != "go1.1" || gb.Constraints[0].Inactive ||
+		gb.Constraints[1].Expr != "ignore" || !gb.Constraints[1].Inactive || gb.Constraints[1].FileCount != 1 {
+		t.Errorf("constraints = %+v", gb.Constraints)
+	}
+	if s := GoCodeSummary(res); !strings.HasPrefix(s, "Go: ") || !strings.Contains(s, " prod / ") || !strings.Contains(s, "2 file(s) not built") {
+		t.Errorf("summary = %q", s)
+	}
+
+	res, err = (&ProjectCounter{}).CountProjectSummary(context.Background(), dir, Options{Concurrency: 2, GoOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Languages) != 1 || res.Total.FileCount != 6 {
+		t.Errorf("--go-only counted %d file(s) in %d language(s)", res.Total.FileCount, len(res.Languages))
+	}
+	if res.Languages["Go"].Go.Prod != gb.Prod {
+		t.Errorf("--go-only prod = %+v, want %+v", res.Languages["Go"].Go.Prod, gb.Prod)
+	}
+	if GoCodeSummary(&models.AnalysisResult{}) != "" {
+		t.Error("expected no summary without Go files")
+	}
+}
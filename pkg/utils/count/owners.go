@@ -0,0 +1,175 @@
+package count
+
+import (
+	"context"
+	"math"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yeisme/gocli/pkg/models"
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// maxLanguageOwners 每种语言报告的作者数量
+const maxLanguageOwners = 3
+
+// notCommittedEmail 是 git blame 为工作区中尚未提交的行使用的作者邮箱
+const notCommittedEmail = "not.committed.yet"
+
+// inGitWorkTree 报告 root 是否位于 git 工作区内（git 不可用时为 false）
+func inGitWorkTree(root string) bool {
+	if _, err := exec.LookPath("git"); err != nil {
+		return false
+	}
+	out, err := executor.NewExecutor("git", "rev-parse", "--is-inside-work-tree").WithDir(root).ReadOnly().Output()
+	return err == nil && strings.TrimSpace(out) == "true"
+}
+
+// blameAuthors 通过 git blame --line-porcelain 统计文件中每位作者仍然存在的行数；
+// 未跟踪的文件返回错误，尚未提交的行不计入任何作者
+func blameAuthors(ctx context.Context, path string) ([]models.Owner, error) {
+	out, err := executor.NewExecutor("git", "blame", "--line-porcelain", "--", filepath.Base(path)).
+		WithDir(filepath.Dir(path)).WithContext(ctx).ReadOnly().Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseBlamePorcelain(out), nil
+}
+
+// parseBlamePorcelain 解析 --line-porcelain 输出：每一行源码都带有完整的 author / author-mail 头
+func parseBlamePorcelain(out string) []models.Owner {
+	counts := map[string]*models.Owner{}
+	name := ""
+	for line := range strings.SplitSeq(out, "\n") {
+		switch {
+		case strings.HasPrefix(line, "author "):
+			name = strings.TrimPrefix(line, "author ")
+		case strings.HasPrefix(line, "author-mail "):
+			email := strings.ToLower(strings.Trim(strings.TrimPrefix(line, "author-mail "), "<>"))
+			if email == notCommittedEmail {
+				continue
+			}
+			o, ok := counts[email]
+			if !ok {
+				o = &models.Owner{Name: name, Email: email}
+				counts[email] = o
+			}
+			o.Lines++
+		}
+	}
+	return ownerList(counts)
+}
+
+// numstatAuthors 用一次 git log --numstat 近似统计 root 下每个文件各作者的行数（新增减删除，按文件与作者累计，
+// 结果小于 0 时记为 0），键为相对 root 的路径（以 / 分隔）。比逐文件 blame 快，但不区分被他人改写的行
+func numstatAuthors(ctx context.Context, root string) (map[string][]models.Owner, error) {
+	out, err := executor.NewExecutor("git", "log", "--numstat", "--no-renames", "--relative", "--format=%x00%aN%x00%aE", "HEAD").
+		WithDir(root).WithContext(ctx).ReadOnly().Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseNumstat(out), nil
+}
+
+// parseNumstat 解析 "--format=%x00%aN%x00%aE" 与 --numstat 交替的输出；二进制文件（- -）被忽略
+func parseNumstat(out string) map[string][]models.Owner {
+	perFile := map[string]map[string]*models.Owner{}
+	var name, email string
+	for line := range strings.SplitSeq(out, "\n") {
+		if rest, ok := strings.CutPrefix(line, "\x00"); ok {
+			name, email, _ = strings.Cut(rest, "\x00")
+			email = strings.ToLower(strings.TrimSpace(email))
+			continue
+		}
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 || email == "" {
+			continue
+		}
+		added, err1 := strconv.Atoi(fields[0])
+		deleted, err2 := strconv.Atoi(fields[1])
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		counts := perFile[fields[2]]
+		if counts == nil {
+			counts = map[string]*models.Owner{}
+			perFile[fields[2]] = counts
+		}
+		o, ok := counts[email]
+		if !ok {
+			// git log 从新到旧输出，第一次出现的名字是作者最近使用的名字
+			o = &models.Owner{Name: name, Email: email}
+			counts[email] = o
+		}
+		o.Lines += added - deleted
+	}
+	result := make(map[string][]models.Owner, len(perFile))
+	for file, counts := range perFile {
+		for _, o := range counts {
+			o.Lines = max(o.Lines, 0)
+		}
+		result[file] = ownerList(counts)
+	}
+	return result
+}
+
+// ownerList 把作者计数按行数降序（同数按邮箱）排列并计算百分比，行数为 0 的作者被忽略
+func ownerList(counts map[string]*models.Owner) []models.Owner {
+	total := 0
+	for _, o := range counts {
+		total += o.Lines
+	}
+	owners := make([]models.Owner, 0, len(counts))
+	for _, o := range counts {
+		if o.Lines <= 0 {
+			continue
+		}
+		owner := *o
+		owner.Percent = math.Round(float64(o.Lines)*1000/float64(total)) / 10
+		owners = append(owners, owner)
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		if owners[i].Lines != owners[j].Lines {
+			return owners[i].Lines > owners[j].Lines
+		}
+		return owners[i].Email < owners[j].Email
+	})
+	return owners
+}
+
+// aggregateOwners 汇总文件的作者行数：每种语言保留前 maxLanguageOwners 位，Total 为整个项目的完整分布
+func aggregateOwners(res *models.AnalysisResult, files []models.FileInfo) {
+	perLang := map[string]map[string]*models.Owner{}
+	total := map[string]*models.Owner{}
+	add := func(counts map[string]*models.Owner, a models.Owner) {
+		o, ok := counts[a.Email]
+		if !ok {
+			o = &models.Owner{Name: a.Name, Email: a.Email}
+			counts[a.Email] = o
+		}
+		o.Lines += a.Lines
+	}
+	for _, f := range files {
+		lang := f.Language
+		if lang == "" {
+			lang = "Unknown"
+		}
+		for _, a := range f.Authors {
+			if perLang[lang] == nil {
+				perLang[lang] = map[string]*models.Owner{}
+			}
+			add(perLang[lang], a)
+			add(total, a)
+		}
+	}
+	for lang, counts := range perLang {
+		if ls := res.Languages[lang]; ls != nil {
+			owners := ownerList(counts)
+			ls.Owners = owners[:min(len(owners), maxLanguageOwners)]
+		}
+	}
+	res.Total.Owners = ownerList(total)
+}
@@ -0,0 +1,86 @@
+package count
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// 测试 --owners / --owners-fast：两位作者提交的临时仓库中，按仍然存在的行数统计语言与项目的作者归属
+func TestCountProjectOwners(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	root := t.TempDir()
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git := func(author string, args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=" + author, "-c", "user.email=" + strings.ToLower(author) + "@example.com"}, args...)...)
+		cmd.Dir = root
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("Alice", "init", "-q")
+	write("main.go", "package main\n\nfunc main() {\n\tprintln(1)\n\tprintln(2)\n\tprintln(3)\n}\n")
+	write("run.sh", "#!/bin/sh\necho hi\n")
+	git("Alice", "add", "-A")
+	git("Alice", "commit", "-q", "-m", "alice")
+	// Bob 改写一行并新增两行：Go 中 Alice 6 行、Bob 3 行
+	write("main.go", "package main\n\nfunc main() {\n\tprintln(1)\n\tprintln(20)\n\tprintln(3)\n\tprintln(4)\n\tprintln(5)\n}\n")
+	write("big.go", "package main\n\n"+strings.Repeat("// filler line\n", 200))
+	git("Bob", "add", "-A")
+	git("Bob", "commit", "-q", "-m", "bob")
+	// 未提交的行不属于任何作者
+	write("run.sh", "#!/bin/sh\necho hi\necho local\n")
+
+	opts := Options{Owners: true, MaxFileSizeBytes: 1024, Concurrency: 2}
+	res, err := (&ProjectCounter{}).CountProjectSummary(context.Background(), root, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	goOwners := res.Languages["Go"].Owners
+	if len(goOwners) != 2 || goOwners[0].Email != "alice@example.com" || goOwners[0].Lines != 6 || goOwners[1].Lines != 3 {
+		t.Fatalf("unexpected Go owners (big.go must be skipped by the size limit): %+v", goOwners)
+	}
+	if goOwners[0].Percent != 66.7 || goOwners[1].Name != "Bob" {
+		t.Errorf("unexpected Go owners: %+v", goOwners)
+	}
+	shell := res.Languages["Shell"].Owners
+	if len(shell) != 1 || shell[0].Lines != 2 {
+		t.Errorf("uncommitted lines should not be attributed: %+v", shell)
+	}
+	if total := res.Total.Owners; len(total) != 2 || total[0].Lines != 8 || total[1].Lines != 3 {
+		t.Errorf("unexpected project owners: %+v", total)
+	}
+
+	// 近似模式：Bob 新增 3 行删除 1 行记为 2 行，被改写的行仍属于 Alice
+	opts = Options{OwnersFast: true, MaxFileSizeBytes: 1024}
+	res, err = (&ProjectCounter{}).CountProjectSummary(context.Background(), root, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	goOwners = res.Languages["Go"].Owners
+	if len(goOwners) != 2 || goOwners[0].Lines != 7 || goOwners[1].Lines != 2 {
+		t.Errorf("unexpected fast Go owners: %+v", goOwners)
+	}
+
+	// 不在 git 仓库内时省略作者归属
+	plain := t.TempDir()
+	if err := os.WriteFile(filepath.Join(plain, "a.go"), []byte("package a\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	res, err = (&ProjectCounter{}).CountProjectSummary(context.Background(), plain, Options{Owners: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Total.Owners != nil || res.Languages["Go"].Owners != nil {
+		t.Errorf("owners outside a git repository: %+v", res.Total.Owners)
+	}
+}
@@ -0,0 +1,213 @@
+package count
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/build"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/yeisme/gocli/pkg/models"
+)
+
+// MarkdownOptions 控制 RenderMarkdown 的输出内容
+type MarkdownOptions struct {
+	// Title 区块标题，为空时使用 "Project Statistics"
+	Title string
+	// GoPackages Go 包数量，>0 时在汇总中输出
+	GoPackages int
+}
+
+// languageRow 是报告中单个语言的一行
+type languageRow struct {
+	Name  string
+	Stats *models.LanguageStats
+}
+
+// sortedLanguages 返回按代码行数降序（同数按名称）排列的语言，忽略 Unknown
+func sortedLanguages(res *models.AnalysisResult) []languageRow {
+	rows := make([]languageRow, 0, len(res.Languages))
+	for name, ls := range res.Languages {
+		if name == "Unknown" || ls == nil {
+			continue
+		}
+		rows = append(rows, languageRow{Name: name, Stats: ls})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Stats.Stats.Code != rows[j].Stats.Stats.Code {
+			return rows[i].Stats.Stats.Code > rows[j].Stats.Stats.Code
+		}
+		return rows[i].Name < rows[j].Name
+	})
+	return rows
+}
+
+// RenderMarkdown 将统计结果渲染为可直接粘贴到 README 的 Markdown 区块
+// 包含语言表（language, files, code, comments, blanks, %）与汇总列表；% 为代码行占比
+func RenderMarkdown(w io.Writer, res *models.AnalysisResult, opts MarkdownOptions) error {
+	if res == nil {
+		return errors.New("nil analysis result")
+	}
+	title := opts.Title
+	if title == "" {
+		title = "Project Statistics"
+	}
+
+	langs := sortedLanguages(res)
+	var total models.LanguageStats
+	for _, l := range langs {
+		total.FileCount += l.Stats.FileCount
+		total.Stats.Code += l.Stats.Stats.Code
+		total.Stats.Comments += l.Stats.Stats.Comments
+		total.Stats.Blanks += l.Stats.Stats.Blanks
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n\n", title)
+	b.WriteString("| Language | Files | Code | Comments | Blanks | % |\n")
+	b.WriteString("| --- | ---: | ---: | ---: | ---: | ---: |\n")
+	for _, l := range langs {
+		fmt.Fprintf(&b, "| %s | %d | %d | %d | %d | %s |\n",
+			escapeMarkdownCell(l.Name), l.Stats.FileCount, l.Stats.Stats.Code,
+			l.Stats.Stats.Comments, l.Stats.Stats.Blanks, percent(l.Stats.Stats.Code, total.Stats.Code))
+	}
+	fmt.Fprintf(&b, "| **Total** | **%d** | **%d** | **%d** | **%d** | **%s** |\n\n",
+		total.FileCount, total.Stats.Code, total.Stats.Comments, total.Stats.Blanks, percent(total.Stats.Code, total.Stats.Code))
+
+	lines := total.Stats.Code + total.Stats.Comments + total.Stats.Blanks
+	fmt.Fprintf(&b, "- Languages: %d\n", len(langs))
+	fmt.Fprintf(&b, "- Files: %d\n", total.FileCount)
+	fmt.Fprintf(&b, "- Lines: %d (code %d, comments %d, blanks %d)\n",
+		lines, total.Stats.Code, total.Stats.Comments, total.Stats.Blanks)
+	if opts.GoPackages > 0 {
+		fmt.Fprintf(&b, "- Go packages: %d\n", opts.GoPackages)
+	}
+	if len(res.Total.Owners) > 0 {
+		b.WriteString("\n### Owners\n\n| Owner | Lines | Share | Top 3 in |\n| --- | ---: | ---: | --- |\n")
+		for _, o := range res.Total.Owners {
+			var top []string
+			for _, l := range langs {
+				if slices.ContainsFunc(l.Stats.Owners, func(lo models.Owner) bool { return lo.Email == o.Email }) {
+					top = append(top, l.Name)
+				}
+			}
+			fmt.Fprintf(&b, "| %s | %d | %.1f%% | %s |\n", escapeMarkdownCell(o.Name), o.Lines, o.Percent, escapeMarkdownCell(strings.Join(top, ", ")))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// percent 计算 part 占 whole 的百分比，保留一位小数
+func percent(part, whole int) string {
+	if whole <= 0 {
+		return "0.0%"
+	}
+	return fmt.Sprintf("%.1f%%", float64(part)*100/float64(whole))
+}
+
+// escapeMarkdownCell 转义表格单元格中的竖线
+func escapeMarkdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// Badge 是 shields.io endpoint badge 的 JSON 结构
+// 参见 https://shields.io/badges/endpoint-badge
+type Badge struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// Badges 根据统计结果生成徽章，键为输出文件名
+//   - loc.json: 代码行数（不含 Unknown）
+//   - go-files.json: Go 文件数
+//   - coverage.json: 覆盖率占位，由 CI 后续覆盖
+func Badges(res *models.AnalysisResult) map[string]Badge {
+	code := 0
+	for _, l := range sortedLanguages(res) {
+		code += l.Stats.Stats.Code
+	}
+	goFiles := 0
+	if ls := res.Languages["Go"]; ls != nil {
+		goFiles = ls.FileCount
+	}
+	return map[string]Badge{
+		"loc.json":      {SchemaVersion: 1, Label: "lines of code", Message: humanizeCount(code), Color: "blue"},
+		"go-files.json": {SchemaVersion: 1, Label: "go files", Message: humanizeCount(goFiles), Color: "00ADD8"},
+		"coverage.json": {SchemaVersion: 1, Label: "coverage", Message: "unknown", Color: "lightgrey"},
+	}
+}
+
+// WriteBadges 将徽章 JSON 写入 dir（不存在时创建），返回写入的文件路径
+// 已存在的 coverage.json 不会被占位内容覆盖
+func WriteBadges(dir string, res *models.AnalysisResult) ([]string, error) {
+	if res == nil {
+		return nil, errors.New("nil analysis result")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create badge dir failed: %w", err)
+	}
+	badges := Badges(res)
+	names := make([]string, 0, len(badges))
+	for name := range badges {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var written []string
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if name == "coverage.json" {
+			if _, err := os.Stat(path); err == nil {
+				continue
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return written, err
+			}
+		}
+		b, err := json.MarshalIndent(badges[name], "", "  ")
+		if err != nil {
+			return written, err
+		}
+		if err := os.WriteFile(path, append(b, '\n'), 0o644); err != nil {
+			return written, fmt.Errorf("write badge %s failed: %w", path, err)
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// GoCodeSummary 返回 Go 代码行数的一行摘要，如 "Go: 42k code (34k prod / 8k tests)"；
+// 存在当前平台不编译的文件时追加其代码行数，没有 Go 统计时返回空字符串
+func GoCodeSummary(res *models.AnalysisResult) string {
+	ls := res.Languages["Go"]
+	if ls == nil || ls.Go == nil {
+		return ""
+	}
+	gb := ls.Go
+	s := fmt.Sprintf("Go: %s code (%s prod / %s tests)", humanizeCount(ls.Stats.Code), humanizeCount(gb.Prod.Stats.Code), humanizeCount(gb.Tests.Stats.Code))
+	if gb.Inactive.FileCount > 0 {
+		s += fmt.Sprintf(", %s in %d file(s) not built for %s/%s", humanizeCount(gb.Inactive.Stats.Code), gb.Inactive.FileCount, build.Default.GOOS, build.Default.GOARCH)
+	}
+	return s
+}
+
+// humanizeCount 将数字格式化为徽章友好的短形式，如 950、12.3k、1.2M
+func humanizeCount(n int) string {
+	switch {
+	case n >= 1_000_000:
+		return strings.Replace(fmt.Sprintf("%.1fM", float64(n)/1_000_000), ".0M", "M", 1)
+	case n >= 1_000:
+		return strings.Replace(fmt.Sprintf("%.1fk", float64(n)/1_000), ".0k", "k", 1)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
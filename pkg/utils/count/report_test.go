@@ -0,0 +1,81 @@
+package count
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yeisme/gocli/pkg/models"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+func fixtureAnalysisResult() *models.AnalysisResult {
+	return &models.AnalysisResult{
+		Languages: map[string]*models.LanguageStats{
+			"Go":       {FileCount: 42, Stats: models.Stats{Code: 12345, Comments: 2100, Blanks: 1500}},
+			"Markdown": {FileCount: 3, Stats: models.Stats{Code: 400, Comments: 0, Blanks: 120}},
+			"YAML":     {FileCount: 5, Stats: models.Stats{Code: 400, Comments: 10, Blanks: 8}},
+			"Unknown":  {FileCount: 7, Stats: models.Stats{Code: 999}},
+		},
+	}
+}
+
+// 测试 Markdown 输出与 golden 文件一致（go test -run TestRenderMarkdown -update 更新）
+func TestRenderMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderMarkdown(&buf, fixtureAnalysisResult(), MarkdownOptions{GoPackages: 12}); err != nil {
+		t.Fatalf("RenderMarkdown failed: %v", err)
+	}
+
+	golden := filepath.Join("testdata", "info.md.golden")
+	if *updateGolden {
+		if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("read golden failed: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("markdown mismatch\n--- got ---\n%s\n--- want ---\n%s", buf.String(), want)
+	}
+}
+
+// 测试徽章文件写入，且不覆盖已有的 coverage.json
+func TestWriteBadges(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "badges")
+	if _, err := WriteBadges(dir, fixtureAnalysisResult()); err != nil {
+		t.Fatalf("WriteBadges failed: %v", err)
+	}
+	var loc Badge
+	b, err := os.ReadFile(filepath.Join(dir, "loc.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &loc); err != nil {
+		t.Fatal(err)
+	}
+	if loc.SchemaVersion != 1 || loc.Message != "13.1k" {
+		t.Errorf("unexpected loc badge: %+v", loc)
+	}
+
+	coverage := filepath.Join(dir, "coverage.json")
+	if err := os.WriteFile(coverage, []byte(`{"message":"87%"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	written, err := WriteBadges(dir, fixtureAnalysisResult())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(written) != 2 {
+		t.Errorf("expected 2 files written, got %v", written)
+	}
+	if b, _ := os.ReadFile(coverage); string(b) != `{"message":"87%"}` {
+		t.Errorf("coverage.json should be kept, got %s", b)
+	}
+}
@@ -3,7 +3,6 @@ package count
 import (
 	"bufio"
 	"context"
-	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -25,7 +24,7 @@ func (s *SingleFileCounter) CountSingleFile(ctx context.Context, filePath string
 	if opts.MaxFileSizeBytes > 0 {
 		fi, err := os.Stat(filePath)
 		if err == nil && fi.Size() > opts.MaxFileSizeBytes {
-			return nil, fmt.Errorf("file size exceeds limit: %d > %d", fi.Size(), opts.MaxFileSizeBytes)
+			return nil, &SizeLimitError{Path: filePath, Size: fi.Size(), Limit: opts.MaxFileSizeBytes}
 		}
 	}
 
@@ -0,0 +1,184 @@
+package deps
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yeisme/gocli/pkg/utils/executor"
+	"golang.org/x/mod/modfile"
+)
+
+// Replace 描述 go.mod 中的一条 replace 指令
+type Replace struct {
+	Old        string `json:"old"`
+	OldVersion string `json:"old_version,omitempty"`
+	New        string `json:"new"`
+	NewVersion string `json:"new_version,omitempty"`
+	// Local 为 true 表示替换为本地文件系统路径（如 ../foo），否则为模块替换
+	Local bool `json:"local"`
+	// Dir 本地替换解析后的路径（相对路径基于 go.mod 所在目录）
+	Dir string `json:"dir,omitempty"`
+	// Exists 本地替换路径是否存在（模块替换恒为 false）
+	Exists bool `json:"exists"`
+}
+
+// Exclude 描述 go.mod 中的一条 exclude 指令
+type Exclude struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
+// Retract 描述主模块自身声明的 retract 区间
+type Retract struct {
+	Low       string `json:"low"`
+	High      string `json:"high"`
+	Rationale string `json:"rationale,omitempty"`
+}
+
+// Require 描述一条 require；Retracted 非空时表示该版本已被上游撤回
+type Require struct {
+	Path      string   `json:"path"`
+	Version   string   `json:"version"`
+	Indirect  bool     `json:"indirect"`
+	Retracted []string `json:"retracted,omitempty"`
+}
+
+// Directives 汇总 go.mod 中容易被忽视的指令
+type Directives struct {
+	GoMod    string    `json:"gomod"`
+	Module   string    `json:"module"`
+	Replaces []Replace `json:"replaces"`
+	Excludes []Exclude `json:"excludes"`
+	Retracts []Retract `json:"retracts"`
+	// RetractedRequires 依赖的版本已被撤回（仅在调用 CheckRetracted 后填充）
+	RetractedRequires []Require `json:"retracted_requires,omitempty"`
+
+	requires []Require
+}
+
+// LocalReplaces 返回所有文件系统替换
+func (d *Directives) LocalReplaces() []Replace {
+	var out []Replace
+	for _, r := range d.Replaces {
+		if r.Local {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Empty 报告是否没有任何需要提示的指令
+func (d *Directives) Empty() bool {
+	return len(d.Replaces) == 0 && len(d.Excludes) == 0 && len(d.Retracts) == 0 && len(d.RetractedRequires) == 0
+}
+
+// FindGoMod 通过 `go env GOMOD` 定位当前模块的 go.mod；不在模块中时返回错误
+func FindGoMod() (string, error) {
+	out, err := executor.NewExecutor("go", "env", "GOMOD").ReadOnly().Output()
+	if err != nil {
+		return "", err
+	}
+	p := strings.TrimSpace(out)
+	if p == "" || p == os.DevNull {
+		return "", errors.New("go.mod not found: not inside a Go module")
+	}
+	return p, nil
+}
+
+// ParseDirectives 读取并解析 gomod 文件中的 replace/exclude/retract 指令
+func ParseDirectives(gomod string) (*Directives, error) {
+	data, err := os.ReadFile(gomod)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDirectivesData(gomod, data)
+}
+
+// ParseDirectivesData 解析 go.mod 内容；本地替换路径相对于 gomod 所在目录解析并检查是否存在
+func ParseDirectivesData(gomod string, data []byte) (*Directives, error) {
+	f, err := modfile.Parse(gomod, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s failed: %w", gomod, err)
+	}
+	d := &Directives{GoMod: gomod, Replaces: []Replace{}, Excludes: []Exclude{}, Retracts: []Retract{}}
+	if f.Module != nil {
+		d.Module = f.Module.Mod.Path
+	}
+	base := filepath.Dir(gomod)
+	for _, r := range f.Replace {
+		rep := Replace{
+			Old:        r.Old.Path,
+			OldVersion: r.Old.Version,
+			New:        r.New.Path,
+			NewVersion: r.New.Version,
+		}
+		// 与 go 命令一致：右侧没有版本且为路径形式时视为本地替换
+		if r.New.Version == "" && modfile.IsDirectoryPath(r.New.Path) {
+			rep.Local = true
+			rep.Dir = r.New.Path
+			if !filepath.IsAbs(rep.Dir) {
+				rep.Dir = filepath.Join(base, filepath.FromSlash(rep.Dir))
+			}
+			if fi, err := os.Stat(rep.Dir); err == nil && fi.IsDir() {
+				rep.Exists = true
+			}
+		}
+		d.Replaces = append(d.Replaces, rep)
+	}
+	for _, e := range f.Exclude {
+		d.Excludes = append(d.Excludes, Exclude{Path: e.Mod.Path, Version: e.Mod.Version})
+	}
+	for _, r := range f.Retract {
+		d.Retracts = append(d.Retracts, Retract{Low: r.Low, High: r.High, Rationale: r.Rationale})
+	}
+	for _, r := range f.Require {
+		d.requires = append(d.requires, Require{Path: r.Mod.Path, Version: r.Mod.Version, Indirect: r.Indirect})
+	}
+	return d, nil
+}
+
+// CheckRetracted 使用 `go list -m -u -retracted -json` 查询 require 的版本是否已被撤回
+// 需要访问模块代理（网络），结果写入 d.RetractedRequires
+func (d *Directives) CheckRetracted() error {
+	if len(d.requires) == 0 {
+		return nil
+	}
+	args := []string{"list", "-m", "-u", "-retracted", "-json"}
+	for _, r := range d.requires {
+		args = append(args, r.Path+"@"+r.Version)
+	}
+	out, err := executor.NewExecutor("go", args...).WithDir(filepath.Dir(d.GoMod)).Output()
+	if err != nil {
+		return err
+	}
+	retracted := map[string][]string{}
+	dec := json.NewDecoder(strings.NewReader(out))
+	for {
+		var m struct {
+			Path      string
+			Version   string
+			Retracted []string
+		}
+		if err := dec.Decode(&m); err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("decode go list output failed: %w", err)
+		}
+		if len(m.Retracted) > 0 {
+			retracted[m.Path+"@"+m.Version] = m.Retracted
+		}
+	}
+	d.RetractedRequires = nil
+	for _, r := range d.requires {
+		if reasons, ok := retracted[r.Path+"@"+r.Version]; ok {
+			r.Retracted = reasons
+			d.RetractedRequires = append(d.RetractedRequires, r)
+		}
+	}
+	return nil
+}
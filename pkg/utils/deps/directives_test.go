@@ -0,0 +1,65 @@
+package deps
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// 测试解析各种形态的 replace/exclude/retract 指令
+func TestParseDirectives(t *testing.T) {
+	gomod := filepath.Join("testdata", "directives", "go.mod")
+	d, err := ParseDirectives(gomod)
+	if err != nil {
+		t.Fatalf("ParseDirectives failed: %v", err)
+	}
+	if d.Module != "example.com/app" || len(d.Replaces) != 4 {
+		t.Fatalf("unexpected directives: %+v", d)
+	}
+
+	want := []struct {
+		old, oldVer, new string
+		local, exists    bool
+	}{
+		{"example.com/foo", "", "./localfoo", true, true},
+		{"example.com/bar", "v1.2.0", "example.com/bar-fork", false, false},
+		{"example.com/baz", "", "../does-not-exist", true, false},
+		{"example.com/qux", "", "/abs/qux", true, false},
+	}
+	for i, w := range want {
+		r := d.Replaces[i]
+		if r.Old != w.old || r.OldVersion != w.oldVer || r.New != w.new || r.Local != w.local || r.Exists != w.exists {
+			t.Errorf("replace %d: got %+v, want %+v", i, r, w)
+		}
+	}
+	if dir := d.Replaces[0].Dir; dir != filepath.Join("testdata", "directives", "localfoo") {
+		t.Errorf("local replace should resolve relative to go.mod, got %s", dir)
+	}
+	if n := len(d.LocalReplaces()); n != 3 {
+		t.Errorf("expected 3 filesystem replaces, got %d", n)
+	}
+
+	if len(d.Excludes) != 1 || d.Excludes[0] != (Exclude{Path: "example.com/bar", Version: "v1.1.0"}) {
+		t.Errorf("unexpected excludes: %+v", d.Excludes)
+	}
+	if len(d.Retracts) != 2 || d.Retracts[0].Low != "v1.0.1" || d.Retracts[0].Rationale != "published by mistake" ||
+		d.Retracts[1].Low != "v0.9.0" || d.Retracts[1].High != "v0.9.5" {
+		t.Errorf("unexpected retracts: %+v", d.Retracts)
+	}
+	if len(d.requires) != 3 || !d.requires[2].Indirect {
+		t.Errorf("unexpected requires: %+v", d.requires)
+	}
+}
+
+// 测试没有任何指令的 go.mod
+func TestParseDirectives_Empty(t *testing.T) {
+	d, err := ParseDirectivesData("go.mod", []byte("module example.com/plain\n\ngo 1.21\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d.Empty() || len(d.LocalReplaces()) != 0 {
+		t.Errorf("expected no directives, got %+v", d)
+	}
+	if _, err := ParseDirectivesData("go.mod", []byte("module\nreplace =>\n")); err == nil {
+		t.Errorf("expected parse error for malformed go.mod")
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/yeisme/gocli/pkg/utils/executor"
@@ -116,6 +118,92 @@ func (g *Graph) Modules() []Module {
 // Has 判断图中是否存在指定模块 ID.
 func (g *Graph) Has(id string) bool { _, ok := g.modules[id]; return ok }
 
+// Roots 返回主模块（工作区模式下可能有多个），按 ID 排序.
+// `go mod graph` 中只有主模块不带版本号；依赖反过来要求主模块时带有版本，是另一个节点.
+func (g *Graph) Roots() []Module {
+	var out []Module
+	for _, m := range g.modules {
+		if m.Version == "" {
+			out = append(out, m)
+		}
+	}
+	sortModules(out)
+	return out
+}
+
+// ModuleForPackage 返回图中提供包 pkg 的模块路径（按最长前缀匹配），找不到时返回空字符串.
+func (g *Graph) ModuleForPackage(pkg string) string {
+	best := ""
+	for _, m := range g.modules {
+		if (pkg == m.Path || strings.HasPrefix(pkg, m.Path+"/")) && len(m.Path) > len(best) {
+			best = m.Path
+		}
+	}
+	return best
+}
+
+// maxPathSearch 限制 PathsTo 扩展的部分路径数量，避免在稠密的依赖图中耗时过长.
+const maxPathSearch = 100000
+
+// PathsTo 返回从主模块（Roots）到路径为 target 的模块（任意版本）的依赖链，按长度从短到长，最多 limit 条.
+// 每条链包含两端；只沿着能够到达 target 的模块扩展，链中不会重复出现同一模块.
+func (g *Graph) PathsTo(target string, limit int) [][]Module {
+	// 反向遍历得到所有能到达 target 的模块
+	reach := make(map[string]bool)
+	var queue []string
+	for id, m := range g.modules {
+		if m.Path == target {
+			reach[id] = true
+			queue = append(queue, id)
+		}
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for pid := range g.revEdges[id] {
+			if !reach[pid] {
+				reach[pid] = true
+				queue = append(queue, pid)
+			}
+		}
+	}
+
+	var paths [][]Module
+	var partial [][]string
+	for _, r := range g.Roots() {
+		if reach[r.ID()] {
+			partial = append(partial, []string{r.ID()})
+		}
+	}
+	for steps := 0; len(partial) > 0 && len(paths) < limit && steps < maxPathSearch; steps++ {
+		cur := partial[0]
+		partial = partial[1:]
+		last := g.modules[cur[len(cur)-1]]
+		if last.Path == target {
+			path := make([]Module, len(cur))
+			for i, id := range cur {
+				path[i] = g.modules[id]
+			}
+			paths = append(paths, path)
+			continue
+		}
+		children := g.Children(last.ID())
+		sortModules(children)
+		for _, c := range children {
+			if !reach[c.ID()] || slices.Contains(cur, c.ID()) {
+				continue
+			}
+			partial = append(partial, append(slices.Clip(cur), c.ID()))
+		}
+	}
+	return paths
+}
+
+// sortModules 按 ID 排序，保证输出稳定.
+func sortModules(ms []Module) {
+	sort.Slice(ms, func(i, j int) bool { return ms[i].ID() < ms[j].ID() })
+}
+
 // ParseGoModGraph 解析 `go mod graph` 的多行文本输出并构建 Graph.
 // 也可通过 ParseGoModGraphReader 从 io.Reader 解析.
 func ParseGoModGraph(output string) (*Graph, error) {
@@ -159,7 +247,7 @@ func RunGoModGraph(args ...string) (string, error) {
 	if len(args) > 0 {
 		base = append(base, args...)
 	}
-	return executor.NewExecutor("go", base...).Output()
+	return executor.NewExecutor("go", base...).ReadOnly().Output()
 }
 
 // parseModuleToken 辅助函数：将 token（如 "github.com/foo/bar@v1.2.3" 或无版本）解析为 Module.
@@ -33,7 +33,7 @@ func RunGoModList(args []string, option struct {
 		base = append(base, args...)
 	}
 
-	output, err := executor.NewExecutor("go", base...).Output()
+	output, err := executor.NewExecutor("go", base...).ReadOnly().Output()
 	if err != nil {
 		return "", err
 	}
@@ -93,7 +93,7 @@ func RunGoModWhy(args []string, options struct {
 	} else {
 		base = append(base, args...)
 	}
-	output, err := executor.NewExecutor("go", base...).Output()
+	output, err := executor.NewExecutor("go", base...).ReadOnly().Output()
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,151 @@
+package deps
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// ModFiles 是 go.mod 与 go.sum 的内容快照，用于比较 tidy、go get 等维护命令前后的变化
+type ModFiles struct {
+	Mod []byte
+	Sum []byte
+}
+
+// ReadModFiles 读取 dir 下的 go.mod 与 go.sum，go.sum 不存在时 Sum 为空
+func ReadModFiles(dir string) (ModFiles, error) {
+	var m ModFiles
+	var err error
+	if m.Mod, err = os.ReadFile(filepath.Join(dir, "go.mod")); err != nil {
+		return m, err
+	}
+	if m.Sum, err = os.ReadFile(filepath.Join(dir, "go.sum")); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return m, err
+	}
+	return m, nil
+}
+
+// ModChanges 汇总两个 go.mod/go.sum 快照之间的差异：require 版本变化沿用 UpdateReport，另加 go.sum 条目增减
+type ModChanges struct {
+	UpdateReport
+	SumAdded   int `json:"sum_added"`   // go.sum 中新增的条目数
+	SumRemoved int `json:"sum_removed"` // go.sum 中删除的条目数
+}
+
+// Empty 报告两个快照是否没有差异
+func (c ModChanges) Empty() bool {
+	return c.UpdateReport.Empty() && c.SumAdded == 0 && c.SumRemoved == 0
+}
+
+// DiffModFiles 比较 before 与 after 两个快照中的 require 版本（DiffModuleVersions）与 go.sum 条目
+func DiffModFiles(before, after ModFiles) (ModChanges, error) {
+	old, err := RequiredVersions("go.mod", before.Mod)
+	if err != nil {
+		return ModChanges{}, err
+	}
+	cur, err := RequiredVersions("go.mod", after.Mod)
+	if err != nil {
+		return ModChanges{}, err
+	}
+	c := ModChanges{UpdateReport: DiffModuleVersions(old, cur)}
+	oldSum, curSum := sumLines(before.Sum), sumLines(after.Sum)
+	for l := range curSum {
+		if !oldSum[l] {
+			c.SumAdded++
+		}
+	}
+	for l := range oldSum {
+		if !curSum[l] {
+			c.SumRemoved++
+		}
+	}
+	return c, nil
+}
+
+// sumLines 返回 go.sum 的非空行集合
+func sumLines(data []byte) map[string]bool {
+	set := map[string]bool{}
+	for line := range strings.SplitSeq(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			set[line] = true
+		}
+	}
+	return set
+}
+
+// WriteModChanges 以与 project update 相同的 diff 风格输出变化，最后一行为统计：
+//
+//	  ~ example.com/mod v0.1.0 -> v0.2.0
+//	  + example.com/new v1.0.0
+//	  - example.com/old v0.9.1
+//	go.mod: 1 updated, 1 added, 1 removed; go.sum: +2 -4 entries
+func WriteModChanges(w io.Writer, c ModChanges) {
+	for _, m := range c.Updated {
+		fmt.Fprintf(w, "  ~ %s %s -> %s\n", m.Path, m.Old, m.New)
+	}
+	for _, m := range c.Added {
+		fmt.Fprintf(w, "  + %s %s\n", m.Path, m.New)
+	}
+	for _, m := range c.Removed {
+		fmt.Fprintf(w, "  - %s %s\n", m.Path, m.Old)
+	}
+	fmt.Fprintf(w, "go.mod: %d updated, %d added, %d removed; go.sum: +%d -%d entries\n",
+		len(c.Updated), len(c.Added), len(c.Removed), c.SumAdded, c.SumRemoved)
+}
+
+// DirtyModFiles 返回 dir 中存在未提交修改（含未跟踪）的 go.mod/go.sum 文件名；
+// dir 不在 git 仓库内或 git 不可用时返回 nil，不作限制
+func DirtyModFiles(dir string) []string {
+	out, err := executor.NewExecutor("git", "status", "--porcelain", "--", "go.mod", "go.sum").
+		WithDir(dir).ReadOnly().Output()
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for line := range strings.SplitSeq(out, "\n") {
+		if len(line) > 3 {
+			files = append(files, filepath.Base(strings.TrimSpace(line[3:])))
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// TidyDiff 把 dir 的 go.mod/go.sum 复制到临时目录，通过 GOFLAGS=-modfile 在副本上执行 `go mod tidy`，
+// 返回 tidy 将会产生的变化，真实的 go.mod/go.sum 不会被修改
+func TidyDiff(dir string) (ModChanges, error) {
+	before, err := ReadModFiles(dir)
+	if err != nil {
+		return ModChanges{}, err
+	}
+	tmp, err := os.MkdirTemp("", "gocli-tidy-*")
+	if err != nil {
+		return ModChanges{}, err
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+	// -modfile 对应的 go.sum 位于同一目录：go.mod -> go.sum
+	modPath := filepath.Join(tmp, "go.mod")
+	if err := os.WriteFile(modPath, before.Mod, 0o644); err != nil {
+		return ModChanges{}, err
+	}
+	if err := os.WriteFile(filepath.Join(tmp, "go.sum"), before.Sum, 0o644); err != nil {
+		return ModChanges{}, err
+	}
+
+	goflags := strings.TrimSpace(os.Getenv("GOFLAGS") + " -modfile=" + modPath)
+	if _, err := executor.NewExecutor("go", "mod", "tidy").WithDir(dir).WithEnv("GOFLAGS=" + goflags).ReadOnly().Output(); err != nil {
+		return ModChanges{}, err
+	}
+	after, err := ReadModFiles(tmp)
+	if err != nil {
+		return ModChanges{}, err
+	}
+	return DiffModFiles(before, after)
+}
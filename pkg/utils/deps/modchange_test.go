@@ -0,0 +1,124 @@
+package deps
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeUnusedRequireModule 在 dir 中创建一个 require 了未使用的本地模块（通过 replace 指向 ./unused）的模块，
+// go mod tidy 会删除该 require，且无需访问网络
+func writeUnusedRequireModule(t *testing.T, dir string) {
+	t.Helper()
+	files := map[string]string{
+		"go.mod":        "module example.com/app\n\ngo 1.21\n\nrequire example.com/unused v0.0.0\n\nreplace example.com/unused => ./unused\n",
+		"main.go":       "package main\n\nfunc main() {}\n",
+		"unused/go.mod": "module example.com/unused\n\ngo 1.21\n",
+		"unused/u.go":   "package unused\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// 测试 --tidy-diff：在临时 modfile 上执行 tidy，报告被删除的 require，真实 go.mod 保持不变
+func TestTidyDiff(t *testing.T) {
+	t.Setenv("GOFLAGS", "")
+	t.Setenv("GOWORK", "off")
+	t.Setenv("GOPROXY", "off")
+	dir := t.TempDir()
+	writeUnusedRequireModule(t, dir)
+	before, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := TidyDiff(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes.Removed) != 1 || changes.Removed[0].Path != "example.com/unused" || len(changes.Added)+len(changes.Updated) != 0 {
+		t.Fatalf("expected the unused require to be removed: %+v", changes)
+	}
+	after, _ := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if !bytes.Equal(before, after) {
+		t.Errorf("TidyDiff modified go.mod:\n%s", after)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "go.sum")); err == nil {
+		t.Errorf("TidyDiff should not create go.sum in the module")
+	}
+
+	var b strings.Builder
+	WriteModChanges(&b, changes)
+	if b.String() != "  - example.com/unused v0.0.0\ngo.mod: 0 updated, 0 added, 1 removed; go.sum: +0 -0 entries\n" {
+		t.Errorf("unexpected summary:\n%s", b.String())
+	}
+}
+
+// 测试 require 版本与 go.sum 条目的差异统计
+func TestDiffModFiles(t *testing.T) {
+	before := ModFiles{
+		Mod: []byte("module m\n\nrequire (\n\ta.example v1.0.0\n\tb.example v1.0.0\n)\n"),
+		Sum: []byte("a.example v1.0.0 h1:a=\nb.example v1.0.0 h1:b=\n"),
+	}
+	after := ModFiles{
+		Mod: []byte("module m\n\nrequire (\n\ta.example v1.1.0\n\td.example v0.1.0 // indirect\n)\n"),
+		Sum: []byte("a.example v1.1.0 h1:a2=\nd.example v0.1.0 h1:d=\nd.example v0.1.0/go.mod h1:dm=\n"),
+	}
+	c, err := DiffModFiles(before, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Updated) != 1 || c.Updated[0].New != "v1.1.0" || len(c.Added) != 1 || len(c.Removed) != 1 || c.Removed[0].Path != "b.example" {
+		t.Errorf("unexpected require changes: %+v", c.UpdateReport)
+	}
+	if c.SumAdded != 3 || c.SumRemoved != 2 {
+		t.Errorf("go.sum delta = +%d -%d", c.SumAdded, c.SumRemoved)
+	}
+	if same, _ := DiffModFiles(before, before); !same.Empty() {
+		t.Errorf("identical snapshots should be empty: %+v", same)
+	}
+}
+
+// 测试 go.mod/go.sum 未提交修改的检测，不在 git 仓库内时不作限制
+func TestDirtyModFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	if files := DirtyModFiles(dir); files != nil {
+		t.Errorf("outside git: %v", files)
+	}
+	writeUnusedRequireModule(t, dir)
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	git("add", "-A")
+	git("commit", "-q", "-m", "init")
+	if files := DirtyModFiles(dir); len(files) != 0 {
+		t.Errorf("clean tree: %v", files)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.sum"), []byte("x v1 h1:x=\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if files := DirtyModFiles(dir); len(files) != 1 || files[0] != "go.sum" {
+		t.Errorf("dirty files = %v", files)
+	}
+}
@@ -0,0 +1,101 @@
+package deps
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yeisme/gocli/pkg/utils/executor"
+	"golang.org/x/mod/modfile"
+)
+
+// ReplaceEdit 是一条待写入 go.mod 的 replace，由 "old[@v]=new[@v]" 解析而来
+type ReplaceEdit struct {
+	Old string
+	New string
+	// MissingGoMod 为 true 表示本地替换目录中没有 go.mod（仅由 RunGoModEditReplace 填充），构建会失败
+	MissingGoMod bool
+}
+
+// ParseReplaceSpec 解析 "old[@v]=new[@v]" 形式的 replace 参数，两侧均不能为空
+func ParseReplaceSpec(spec string) (ReplaceEdit, error) {
+	oldPath, newPath, ok := strings.Cut(spec, "=")
+	oldPath, newPath = strings.TrimSpace(oldPath), strings.TrimSpace(newPath)
+	if !ok || oldPath == "" || newPath == "" {
+		return ReplaceEdit{}, fmt.Errorf("invalid replace %q: expected old[@version]=new[@version]", spec)
+	}
+	return ReplaceEdit{Old: oldPath, New: newPath}, nil
+}
+
+// localReplaceTarget 将本地替换路径改写为相对于 go.mod 所在目录的形式：
+//   - 命令行中的相对路径基于当前工作目录，而 go.mod 中的相对路径基于 go.mod 所在目录；
+//   - 已存在的目录即使没有 ./ 前缀（如 "fork"）也视为本地路径，避免被 go 命令当作模块路径
+//
+// 非本地路径（模块路径或带版本）原样返回
+func localReplaceTarget(target, modDir string) string {
+	if strings.Contains(target, "@") {
+		return target
+	}
+	if !modfile.IsDirectoryPath(target) {
+		if fi, err := os.Stat(target); err != nil || !fi.IsDir() {
+			return target
+		}
+	}
+	if filepath.IsAbs(target) {
+		return target
+	}
+	abs, err := filepath.Abs(target)
+	if err != nil {
+		return target
+	}
+	rel, err := filepath.Rel(modDir, abs)
+	if err != nil {
+		return target
+	}
+	rel = filepath.ToSlash(rel)
+	if !strings.HasPrefix(rel, "../") && rel != ".." {
+		rel = "./" + rel
+	}
+	return rel
+}
+
+// RunGoModEditReplace 通过一次 `go mod edit -replace=... -dropreplace=...` 修改当前模块的 go.mod：
+//   - replaces: 新增或更新的 replace（同一 old 已存在时被覆盖）；
+//   - drops: 需要移除的 replace 左侧（old 或 old@version）
+//
+// 返回实际写入的 replace（本地路径已改写为相对 go.mod 的形式），便于调用方输出摘要与警告
+func RunGoModEditReplace(replaces []ReplaceEdit, drops []string) ([]ReplaceEdit, error) {
+	if len(replaces) == 0 && len(drops) == 0 {
+		return nil, nil
+	}
+	gomod, err := FindGoMod()
+	if err != nil {
+		return nil, err
+	}
+	modDir := filepath.Dir(gomod)
+
+	args := []string{"mod", "edit"}
+	applied := make([]ReplaceEdit, 0, len(replaces))
+	for _, r := range replaces {
+		r.New = localReplaceTarget(r.New, modDir)
+		if modfile.IsDirectoryPath(r.New) {
+			dir := r.New
+			if !filepath.IsAbs(dir) {
+				dir = filepath.Join(modDir, filepath.FromSlash(dir))
+			}
+			if _, err := os.Stat(filepath.Join(dir, "go.mod")); err != nil {
+				r.MissingGoMod = true
+			}
+		}
+		args = append(args, "-replace="+r.Old+"="+r.New)
+		applied = append(applied, r)
+	}
+	for _, d := range drops {
+		args = append(args, "-dropreplace="+strings.TrimSpace(d))
+	}
+	if _, err := executor.NewExecutor("go", args...).Output(); err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
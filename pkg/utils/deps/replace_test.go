@@ -0,0 +1,52 @@
+package deps
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// 测试 replace 参数解析
+func TestParseReplaceSpec(t *testing.T) {
+	r, err := ParseReplaceSpec("example.com/foo@v1.0.0 = ../foo")
+	if err != nil || r.Old != "example.com/foo@v1.0.0" || r.New != "../foo" {
+		t.Fatalf("ParseReplaceSpec = %+v, %v", r, err)
+	}
+	for _, bad := range []string{"example.com/foo", "=../foo", "example.com/foo="} {
+		if _, err := ParseReplaceSpec(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+// 测试本地替换路径改写为相对 go.mod 所在目录，模块路径原样保留
+func TestLocalReplaceTarget(t *testing.T) {
+	root := t.TempDir()
+	for _, d := range []string{"app/sub", "fork"} {
+		if err := os.MkdirAll(filepath.Join(root, d), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(filepath.Join(root, "app", "sub"))
+	modDir := filepath.Join(root, "app")
+
+	cases := map[string]string{
+		"../../fork":              "../fork",
+		"./local":                 "./sub/local",
+		"example.com/fork":        "example.com/fork",
+		"example.com/fork@v1.2.3": "example.com/fork@v1.2.3",
+	}
+	for in, want := range cases {
+		if got := localReplaceTarget(in, modDir); got != want {
+			t.Errorf("localReplaceTarget(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	// 已存在的目录即使没有 ./ 前缀也视为本地路径
+	if err := os.Mkdir("vendored", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if got := localReplaceTarget("vendored", modDir); got != "./sub/vendored" {
+		t.Errorf("existing directory should become a local path, got %q", got)
+	}
+}
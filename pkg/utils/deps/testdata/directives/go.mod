@@ -0,0 +1,24 @@
+module example.com/app
+
+go 1.21
+
+require (
+	example.com/foo v1.0.0
+	example.com/bar v1.2.0
+	example.com/baz v0.3.0 // indirect
+)
+
+replace example.com/foo => ./localfoo
+
+replace (
+	example.com/bar v1.2.0 => example.com/bar-fork v1.2.1
+	example.com/baz => ../does-not-exist
+	example.com/qux => /abs/qux
+)
+
+exclude example.com/bar v1.1.0
+
+retract (
+	v1.0.1 // published by mistake
+	[v0.9.0, v0.9.5]
+)
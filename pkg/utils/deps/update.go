@@ -1,7 +1,15 @@
 // Package deps provides utilities for managing Go module dependencies.
 package deps
 
-import "github.com/yeisme/gocli/pkg/utils/executor"
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yeisme/gocli/pkg/utils/executor"
+	"golang.org/x/mod/modfile"
+)
 
 // RunGoUpdate 执行 `go get -u <targets>` 以更新模块依赖到最新次要/补丁版本
 //
@@ -22,3 +30,101 @@ func RunGoUpdate(args []string) (string, error) {
 	}
 	return output, nil
 }
+
+// RunGoGet 执行 `go get <targets>`，用于按 path@version（或 path@patch 等版本查询）逐个升级选定的模块
+func RunGoGet(targets []string) (string, error) {
+	output, err := executor.NewExecutor("go", append([]string{"get"}, targets...)...).Output()
+	if err != nil {
+		return "", err
+	}
+	return output, nil
+}
+
+// ModuleChange 描述一个模块在更新前后的版本变化；Old 为空表示新增，New 为空表示移除
+type ModuleChange struct {
+	Path string `json:"path"`
+	Old  string `json:"old,omitempty"`
+	New  string `json:"new,omitempty"`
+}
+
+// UpdateReport 汇总一次依赖更新的结果
+type UpdateReport struct {
+	Updated []ModuleChange `json:"updated"`
+	Added   []ModuleChange `json:"added"`
+	Removed []ModuleChange `json:"removed"`
+	// Excluded 因排除规则被保持（或回退）在原版本的模块
+	Excluded []ModuleChange `json:"excluded,omitempty"`
+}
+
+// Empty 报告是否没有任何版本变化
+func (r *UpdateReport) Empty() bool {
+	return len(r.Updated) == 0 && len(r.Added) == 0 && len(r.Removed) == 0
+}
+
+// RequiredVersions 解析 go.mod 内容，返回 require 中各模块的版本
+func RequiredVersions(gomod string, data []byte) (map[string]string, error) {
+	f, err := modfile.ParseLax(gomod, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s failed: %w", gomod, err)
+	}
+	versions := make(map[string]string, len(f.Require))
+	for _, r := range f.Require {
+		versions[r.Mod.Path] = r.Mod.Version
+	}
+	return versions, nil
+}
+
+// DiffModuleVersions 比较更新前后的 require 版本，结果按模块路径排序
+func DiffModuleVersions(before, after map[string]string) UpdateReport {
+	r := UpdateReport{Updated: []ModuleChange{}, Added: []ModuleChange{}, Removed: []ModuleChange{}}
+	for path, nv := range after {
+		ov, ok := before[path]
+		switch {
+		case !ok:
+			r.Added = append(r.Added, ModuleChange{Path: path, New: nv})
+		case ov != nv:
+			r.Updated = append(r.Updated, ModuleChange{Path: path, Old: ov, New: nv})
+		}
+	}
+	for path, ov := range before {
+		if _, ok := after[path]; !ok {
+			r.Removed = append(r.Removed, ModuleChange{Path: path, Old: ov})
+		}
+	}
+	for _, list := range [][]ModuleChange{r.Updated, r.Added, r.Removed} {
+		sort.Slice(list, func(i, j int) bool { return list[i].Path < list[j].Path })
+	}
+	return r
+}
+
+// ListModuleUpdates 使用 `go list -m -u -json all` 查询可用更新（只读，不修改 go.mod/go.sum）
+func ListModuleUpdates() ([]ModuleChange, error) {
+	out, err := executor.NewExecutor("go", "list", "-m", "-u", "-json", "all").ReadOnly().Output()
+	if err != nil {
+		return nil, err
+	}
+	return ParseModuleUpdates(out)
+}
+
+// ParseModuleUpdates 解析 `go list -m -u -json` 的输出，返回存在更新的非主模块
+func ParseModuleUpdates(out string) ([]ModuleChange, error) {
+	var changes []ModuleChange
+	dec := json.NewDecoder(strings.NewReader(out))
+	for dec.More() {
+		var m struct {
+			Path    string
+			Version string
+			Main    bool
+			Update  *struct{ Version string }
+		}
+		if err := dec.Decode(&m); err != nil {
+			return nil, fmt.Errorf("decode go list output failed: %w", err)
+		}
+		if m.Main || m.Update == nil || m.Update.Version == "" {
+			continue
+		}
+		changes = append(changes, ModuleChange{Path: m.Path, Old: m.Version, New: m.Update.Version})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
@@ -0,0 +1,100 @@
+package deps
+
+import (
+	"reflect"
+	"testing"
+)
+
+// 测试更新前后 go.mod 的版本对比
+func TestDiffModuleVersions(t *testing.T) {
+	before, err := RequiredVersions("go.mod", []byte(`module example.com/m
+
+go 1.22
+
+require (
+	github.com/a/a v1.0.0
+	github.com/b/b v1.2.0
+	github.com/c/c v0.1.0 // indirect
+)
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	after, err := RequiredVersions("go.mod", []byte(`module example.com/m
+
+go 1.22
+
+require (
+	github.com/a/a v1.3.1
+	github.com/b/b v1.2.0
+	github.com/d/d v0.2.0 // indirect
+)
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := DiffModuleVersions(before, after)
+	if want := []ModuleChange{{Path: "github.com/a/a", Old: "v1.0.0", New: "v1.3.1"}}; !reflect.DeepEqual(r.Updated, want) {
+		t.Errorf("updated = %+v, want %+v", r.Updated, want)
+	}
+	if want := []ModuleChange{{Path: "github.com/d/d", New: "v0.2.0"}}; !reflect.DeepEqual(r.Added, want) {
+		t.Errorf("added = %+v, want %+v", r.Added, want)
+	}
+	if want := []ModuleChange{{Path: "github.com/c/c", Old: "v0.1.0"}}; !reflect.DeepEqual(r.Removed, want) {
+		t.Errorf("removed = %+v, want %+v", r.Removed, want)
+	}
+	if r.Empty() {
+		t.Error("report should not be empty")
+	}
+	if same := DiffModuleVersions(before, before); !same.Empty() {
+		t.Errorf("expected empty report, got %+v", same)
+	}
+}
+
+// 测试解析 go list -m -u -json 输出：跳过主模块与没有更新的模块
+func TestParseModuleUpdates(t *testing.T) {
+	out := `{
+	"Path": "example.com/m",
+	"Main": true,
+	"Dir": "/src/m",
+	"GoVersion": "1.22"
+}
+{
+	"Path": "golang.org/x/text",
+	"Version": "v0.3.0",
+	"Update": {
+		"Path": "golang.org/x/text",
+		"Version": "v0.14.0"
+	},
+	"Indirect": true
+}
+{
+	"Path": "github.com/b/b",
+	"Version": "v1.2.0"
+}
+{
+	"Path": "github.com/a/a",
+	"Version": "v1.0.0",
+	"Update": {
+		"Path": "github.com/a/a",
+		"Version": "v1.3.1"
+	}
+}
+`
+	changes, err := ParseModuleUpdates(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ModuleChange{
+		{Path: "github.com/a/a", Old: "v1.0.0", New: "v1.3.1"},
+		{Path: "golang.org/x/text", Old: "v0.3.0", New: "v0.14.0"},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("changes = %+v, want %+v", changes, want)
+	}
+
+	if _, err := ParseModuleUpdates("{not json"); err == nil {
+		t.Error("expected decode error")
+	}
+}
@@ -0,0 +1,171 @@
+package deps
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// WhyResult 是 `go mod why` 针对单个目标的结构化结果
+type WhyResult struct {
+	// Target 查询的包或模块
+	Target string `json:"target"`
+	// Chain 从主模块到目标的导入链（包含两端）；NotNeeded 为 true 时为空
+	Chain []string `json:"chain,omitempty"`
+	// NotNeeded 主模块不需要该目标
+	NotNeeded bool `json:"not_needed"`
+	// Note go mod why 给出的括号说明，如 "(main module does not need package x)"
+	Note string `json:"note,omitempty"`
+	// Module 提供目标的模块路径（AnnotateWhyPaths 填充）
+	Module string `json:"module,omitempty"`
+	// ModulePaths 从主模块到 Module 的依赖链（来自 go mod graph，元素为 path@version，AnnotateWhyPaths 填充）
+	ModulePaths [][]string `json:"module_paths,omitempty"`
+}
+
+// MaxWhyPaths 是 AnnotateWhyPaths 为每个目标列出的最多依赖链数
+const MaxWhyPaths = 10
+
+// AnnotateWhyPaths 结合 `go mod graph` 为需要的目标补充所在模块及从主模块到该模块的依赖链.
+// moduleTargets 为 true（go mod why -m）时目标本身就是模块路径，否则按包路径查找提供它的模块；
+// 目标属于主模块时不产生依赖链. 与导入链经过的模块最一致的依赖链排在前面
+func AnnotateWhyPaths(results []WhyResult, g *Graph, moduleTargets bool, limit int) {
+	if limit <= 0 {
+		limit = MaxWhyPaths
+	}
+	mains := make(map[string]bool)
+	for _, r := range g.Roots() {
+		mains[r.Path] = true
+	}
+	for i := range results {
+		r := &results[i]
+		if r.NotNeeded || len(r.Chain) == 0 {
+			continue
+		}
+		r.Module = r.Target
+		if !moduleTargets {
+			r.Module = g.ModuleForPackage(r.Target)
+		}
+		if r.Module == "" || mains[r.Module] {
+			continue
+		}
+		// 导入链经过的模块，用于优先展示与 go mod why 结果一致的依赖链
+		chainMods := make(map[string]bool)
+		for _, pkg := range r.Chain {
+			if m := g.ModuleForPackage(pkg); m != "" {
+				chainMods[m] = true
+			}
+		}
+		paths := g.PathsTo(r.Module, limit*5)
+		// 先按链外模块数升序，再按经过的链内模块数降序；其余保持 PathsTo 的由短到长
+		score := func(path []Module) (outside, inside int) {
+			for _, m := range path {
+				if chainMods[m.Path] {
+					inside++
+				} else {
+					outside++
+				}
+			}
+			return outside, inside
+		}
+		sort.SliceStable(paths, func(a, b int) bool {
+			oa, ia := score(paths[a])
+			ob, ib := score(paths[b])
+			if oa != ob {
+				return oa < ob
+			}
+			return ia > ib
+		})
+		for _, path := range paths[:min(limit, len(paths))] {
+			ids := make([]string, len(path))
+			for j, m := range path {
+				ids[j] = m.ID()
+			}
+			r.ModulePaths = append(r.ModulePaths, ids)
+		}
+	}
+}
+
+// ParseGoModWhy 解析 `go mod why` 的输出
+// 输出由空行分隔的段落组成，每段以 "# <target>" 开头，随后是导入链（每行一个）
+// 或一行括号说明（表示不需要该目标）
+func ParseGoModWhy(output string) []WhyResult {
+	var results []WhyResult
+	var cur *WhyResult
+	flush := func() {
+		if cur != nil {
+			results = append(results, *cur)
+			cur = nil
+		}
+	}
+	for line := range strings.SplitSeq(strings.ReplaceAll(output, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "# "):
+			flush()
+			cur = &WhyResult{Target: strings.TrimSpace(strings.TrimPrefix(line, "# "))}
+		case cur == nil || line == "":
+			continue
+		case strings.HasPrefix(line, "("):
+			cur.Note = line
+			if strings.Contains(line, "does not need") {
+				cur.NotNeeded = true
+			}
+		default:
+			cur.Chain = append(cur.Chain, line)
+		}
+	}
+	flush()
+	return results
+}
+
+// RunGoModWhyTargets 对每个目标并发执行 `go mod why`，并按输入顺序返回结构化结果
+// workers <= 0 时使用默认并发数；targets 为空时退化为一次 `go mod why ./...`
+func RunGoModWhyTargets(targets []string, workers int, options struct {
+	Module bool
+	Vendor bool
+}) ([]WhyResult, error) {
+	if len(targets) == 0 {
+		out, err := RunGoModWhy(nil, options)
+		if err != nil {
+			return nil, err
+		}
+		return ParseGoModWhy(out), nil
+	}
+	if workers <= 0 {
+		workers = 4
+	}
+	workers = min(workers, len(targets))
+
+	parts := make([][]WhyResult, len(targets))
+	errs := make([]error, len(targets))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				out, err := RunGoModWhy([]string{targets[i]}, options)
+				if err != nil {
+					errs[i] = err
+					continue
+				}
+				parts[i] = ParseGoModWhy(out)
+			}
+		}()
+	}
+	for i := range targets {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var results []WhyResult
+	for i := range targets {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		results = append(results, parts[i]...)
+	}
+	return results, nil
+}
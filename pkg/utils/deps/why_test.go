@@ -0,0 +1,90 @@
+package deps
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseGoModWhy(t *testing.T) {
+	input := `# golang.org/x/text/language
+rsc.io/quote
+rsc.io/sampler
+golang.org/x/text/language
+
+# golang.org/x/text/encoding
+(main module does not need package golang.org/x/text/encoding)
+
+# github.com/pkg/errors
+(main module does not need module github.com/pkg/errors)
+`
+	results := ParseGoModWhy(input)
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d: %+v", len(results), results)
+	}
+
+	first := results[0]
+	if first.Target != "golang.org/x/text/language" || first.NotNeeded {
+		t.Fatalf("unexpected first result: %+v", first)
+	}
+	wantChain := []string{"rsc.io/quote", "rsc.io/sampler", "golang.org/x/text/language"}
+	if len(first.Chain) != len(wantChain) {
+		t.Fatalf("expected chain %v, got %v", wantChain, first.Chain)
+	}
+	for i := range wantChain {
+		if first.Chain[i] != wantChain[i] {
+			t.Fatalf("expected chain %v, got %v", wantChain, first.Chain)
+		}
+	}
+
+	for _, r := range results[1:] {
+		if !r.NotNeeded || len(r.Chain) != 0 || r.Note == "" {
+			t.Errorf("expected not-needed result with note, got %+v", r)
+		}
+	}
+}
+
+func TestParseGoModWhy_CRLFAndEmpty(t *testing.T) {
+	if got := ParseGoModWhy(""); len(got) != 0 {
+		t.Fatalf("expected no results for empty output, got %+v", got)
+	}
+	results := ParseGoModWhy("# example.com/m\r\nexample.com/main\r\nexample.com/m\r\n")
+	if len(results) != 1 || len(results[0].Chain) != 2 || results[0].Chain[1] != "example.com/m" {
+		t.Fatalf("unexpected result: %+v", results)
+	}
+}
+
+// 测试 --why-paths：按包查找模块，依赖链从主模块出发，经过导入链中模块的链排在前面
+func TestAnnotateWhyPaths(t *testing.T) {
+	g, err := ParseGoModGraph(`example.com/main golang.org/x/text@v0.3.0
+example.com/main rsc.io/quote@v1.5.2
+example.com/main rsc.io/other@v1.0.0
+rsc.io/quote@v1.5.2 rsc.io/sampler@v1.3.0
+rsc.io/sampler@v1.3.0 golang.org/x/text@v0.0.0-2017
+rsc.io/other@v1.0.0 rsc.io/sampler@v1.3.0
+rsc.io/sampler@v1.3.0 example.com/main@v0.1.0
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := []WhyResult{
+		{Target: "golang.org/x/text/language", Chain: []string{"example.com/main", "rsc.io/quote", "rsc.io/sampler", "golang.org/x/text/language"}},
+		{Target: "example.com/main/internal", Chain: []string{"example.com/main", "example.com/main/internal"}},
+		{Target: "golang.org/x/text/encoding", NotNeeded: true},
+	}
+	AnnotateWhyPaths(results, g, false, 2)
+
+	r := results[0]
+	if r.Module != "golang.org/x/text" || len(r.ModulePaths) != 2 {
+		t.Fatalf("unexpected annotation: %+v", r)
+	}
+	want := "example.com/main rsc.io/quote@v1.5.2 rsc.io/sampler@v1.3.0 golang.org/x/text@v0.0.0-2017"
+	if got := strings.Join(r.ModulePaths[0], " "); got != want {
+		t.Errorf("first path = %q, want the one through the import chain %q", got, want)
+	}
+	if got := strings.Join(r.ModulePaths[1], " "); got != "example.com/main golang.org/x/text@v0.3.0" {
+		t.Errorf("second path = %q, want the direct requirement", got)
+	}
+	if len(results[1].ModulePaths) != 0 || len(results[2].ModulePaths) != 0 {
+		t.Errorf("main-module and not-needed targets should have no paths: %+v", results[1:])
+	}
+}
@@ -0,0 +1,86 @@
+package doc
+
+import (
+	"fmt"
+	"go/build"
+	"slices"
+	"strings"
+)
+
+// knownOS 与 knownArch 对应 go/build 内部的 GOOS/GOARCH 列表（syslist.go），用于识别 Tags 中的平台名
+var (
+	knownOS = []string{
+		"aix", "android", "darwin", "dragonfly", "freebsd", "hurd", "illumos", "ios", "js", "linux",
+		"nacl", "netbsd", "openbsd", "plan9", "solaris", "wasip1", "windows", "zos",
+	}
+	knownArch = []string{
+		"386", "amd64", "amd64p32", "arm", "armbe", "arm64", "arm64be", "loong64", "mips", "mipsle",
+		"mips64", "mips64le", "mips64p32", "mips64p32le", "ppc", "ppc64", "ppc64le", "riscv", "riscv64",
+		"s390", "s390x", "sparc", "sparc64", "wasm",
+	}
+)
+
+// splitTags 把 Tags 拆分为 GOOS、GOARCH 与其余构建标签；未出现的 GOOS/GOARCH 为空，表示沿用当前平台
+func (o Options) splitTags() (goos, goarch string, tags []string, err error) {
+	for _, t := range o.Tags {
+		t = strings.TrimSpace(t)
+		switch {
+		case t == "":
+		case slices.Contains(knownOS, t):
+			if goos != "" && goos != t {
+				return "", "", nil, fmt.Errorf("doc: --tags lists more than one GOOS (%s, %s)", goos, t)
+			}
+			goos = t
+		case slices.Contains(knownArch, t):
+			if goarch != "" && goarch != t {
+				return "", "", nil, fmt.Errorf("doc: --tags lists more than one GOARCH (%s, %s)", goarch, t)
+			}
+			goarch = t
+		case strings.ContainsAny(t, " \t\"'!&|()"):
+			return "", "", nil, fmt.Errorf("doc: invalid build tag %q", t)
+		default:
+			tags = append(tags, t)
+		}
+	}
+	return goos, goarch, tags, nil
+}
+
+// BuildContext 返回按 Tags 调整后的 go/build 上下文：GOOS/GOARCH 名称设置目标平台，其余作为额外构建标签；
+// 平台改变时关闭 cgo，与 go 命令交叉编译时的默认值一致
+func (o Options) BuildContext() build.Context {
+	ctx := build.Default
+	goos, goarch, tags, _ := o.splitTags()
+	if goos != "" && goos != ctx.GOOS || goarch != "" && goarch != ctx.GOARCH {
+		ctx.CgoEnabled = false
+	}
+	if goos != "" {
+		ctx.GOOS = goos
+	}
+	if goarch != "" {
+		ctx.GOARCH = goarch
+	}
+	ctx.BuildTags = append(slices.Clone(ctx.BuildTags), tags...)
+	return ctx
+}
+
+// GoBuildArgs 返回调用 go 命令（go list、go/packages）时对应 Tags 的环境变量与构建参数，没有 Tags 时均为空
+func (o Options) GoBuildArgs() (env, flags []string) {
+	ctx := o.BuildContext()
+	if ctx.GOOS != build.Default.GOOS || ctx.GOARCH != build.Default.GOARCH {
+		env = []string{"GOOS=" + ctx.GOOS, "GOARCH=" + ctx.GOARCH, "CGO_ENABLED=0"}
+	}
+	if _, _, tags, _ := o.splitTags(); len(tags) > 0 {
+		flags = append(flags, "-tags="+strings.Join(tags, ","))
+	}
+	return env, flags
+}
+
+// buildDescription 描述 Tags 对应的构建配置，用于错误信息，例如 "linux/amd64 tags=foo"
+func (o Options) buildDescription() string {
+	ctx := o.BuildContext()
+	s := ctx.GOOS + "/" + ctx.GOARCH
+	if len(ctx.BuildTags) > 0 {
+		s += " tags=" + strings.Join(ctx.BuildTags, ",")
+	}
+	return s
+}
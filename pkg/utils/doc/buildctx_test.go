@@ -0,0 +1,56 @@
+package doc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// 测试 Tags：只渲染满足构建约束的文件（文件名后缀与 //go:build），GOOS 名称切换平台，其余作为构建标签
+func TestGetGoDocBuildTags(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"common.go":       "// Package plat is a fixture.\npackage plat\n\n// Common is always built.\nfunc Common() {}\n",
+		"plat_linux.go":   "package plat\n\n// OnLinux is linux only.\nfunc OnLinux() {}\n",
+		"plat_windows.go": "package plat\n\n// OnWindows is windows only.\nfunc OnWindows() {}\n",
+		"extra.go":        "//go:build extra\n\npackage plat\n\n// Extra needs the extra tag.\nfunc Extra() {}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := []struct {
+		tags       []string
+		want, deny []string
+	}{
+		{[]string{"linux"}, []string{"Common", "OnLinux"}, []string{"OnWindows", "Extra"}},
+		{[]string{"windows", "extra"}, []string{"Common", "OnWindows", "Extra"}, []string{"OnLinux"}},
+	}
+	for _, tc := range cases {
+		opts := Options{Style: StylePlain, Mode: ModeGodoc, Tags: tc.tags}
+		if err := opts.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		out, err := GetGoDoc(opts, "", dir)
+		if err != nil {
+			t.Fatalf("tags %v: %v", tc.tags, err)
+		}
+		for _, w := range tc.want {
+			if !strings.Contains(out, "func "+w+"()") {
+				t.Errorf("tags %v: missing %s:\n%s", tc.tags, w, out)
+			}
+		}
+		for _, d := range tc.deny {
+			if strings.Contains(out, "func "+d+"()") {
+				t.Errorf("tags %v: unexpected %s:\n%s", tc.tags, d, out)
+			}
+		}
+	}
+
+	if err := (Options{Style: StylePlain, Mode: ModeGodoc, Tags: []string{"linux", "darwin"}}).Validate(); err == nil {
+		t.Error("two GOOS values should be rejected")
+	}
+}
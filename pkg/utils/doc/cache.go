@@ -0,0 +1,168 @@
+package doc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// cacheFormat 是缓存文件的格式版本，渲染逻辑或文件格式变化时递增以使旧缓存全部失效
+const cacheFormat = "gocli-doc-cache v4"
+
+// DefaultCacheSizeMB 是 doc.cache_size_mb 未设置时的缓存容量上限
+const DefaultCacheSizeMB = 100
+
+// CacheDir 返回文档渲染缓存目录 ~/.gocli/cache/doc
+func CacheDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".gocli", "cache", "doc")
+}
+
+// cacheable 报告本次渲染能否使用缓存：运行示例和类型检查的结果依赖包目录之外的内容，不缓存
+func cacheable(opts Options) bool {
+	return opts.Cache && !opts.VerifyExamples && !opts.typeChecked() && CacheDir() != ""
+}
+
+// cacheKey 由包目录中文件的名称、大小、修改时间以及影响输出的选项计算缓存键；
+// 任一文件新增、删除或修改都会得到新的键
+func cacheKey(opts Options, dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	entries, err := os.ReadDir(abs)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n", cacheFormat, abs)
+	fmt.Fprintf(h, "style=%s private=%t tests=%t examples=%t toc=%t detailed=%t readme=%t width=%d only=%s skip=%s source=%s sort=%s xref=%t build=%s\n",
+		opts.Style, opts.IncludePrivate, opts.IncludeTests, opts.IncludeExamples, opts.TOC, opts.Detailed, opts.IncludeReadme,
+		wrapWidth(opts), strings.Join(opts.Only, ","), strings.Join(opts.Skip, ","), opts.SourceURL, opts.Sort, opts.XRef, opts.buildDescription())
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		fi, err := e.Info()
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s %d %d\n", e.Name(), fi.Size(), fi.ModTime().UnixNano())
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// readCache 读取缓存条目；文件缺失、格式不符或校验和不一致时返回 ok=false（损坏的条目被删除）。
+// 命中时更新条目的修改时间，供 LRU 淘汰使用
+func readCache(key string) (string, bool) {
+	p := filepath.Join(CacheDir(), key)
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return "", false
+	}
+	header, body, found := bytes.Cut(data, []byte("\n"))
+	sum := sha256.Sum256(body)
+	if !found || string(header) != cacheFormat+" "+hex.EncodeToString(sum[:]) {
+		log.Debug().Str("entry", p).Msg("doc cache: discarding corrupt entry")
+		_ = os.Remove(p)
+		return "", false
+	}
+	now := time.Now()
+	_ = os.Chtimes(p, now, now)
+	return string(body), true
+}
+
+// writeCache 写入缓存条目并按 sizeMB 淘汰最久未使用的条目；失败只记录日志
+func writeCache(key, doc string, sizeMB int) {
+	dir := CacheDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		log.Debug().Err(err).Msg("doc cache: cannot create cache dir")
+		return
+	}
+	sum := sha256.Sum256([]byte(doc))
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		log.Debug().Err(err).Msg("doc cache: cannot write entry")
+		return
+	}
+	_, werr := fmt.Fprintf(tmp, "%s %s\n%s", cacheFormat, hex.EncodeToString(sum[:]), doc)
+	cerr := tmp.Close()
+	if werr != nil || cerr != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, key)); err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if sizeMB <= 0 {
+		sizeMB = DefaultCacheSizeMB
+	}
+	pruneCache(dir, int64(sizeMB)<<20)
+}
+
+// pruneCache 按修改时间从旧到新删除条目，直到缓存总大小不超过 limit
+func pruneCache(dir string, limit int64) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	type entry struct {
+		path string
+		size int64
+		mod  time.Time
+	}
+	var list []entry
+	var total int64
+	for _, e := range entries {
+		fi, err := e.Info()
+		if err != nil || fi.IsDir() || strings.HasPrefix(e.Name(), ".tmp-") {
+			continue
+		}
+		list = append(list, entry{filepath.Join(dir, e.Name()), fi.Size(), fi.ModTime()})
+		total += fi.Size()
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].mod.Before(list[j].mod) })
+	for _, e := range list {
+		if total <= limit {
+			break
+		}
+		if os.Remove(e.path) == nil {
+			total -= e.size
+		}
+	}
+}
+
+// ClearCache 删除全部文档渲染缓存，返回删除的条目数与释放的字节数
+func ClearCache() (files int, freed int64, err error) {
+	dir := CacheDir()
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, e := range entries {
+		fi, ierr := e.Info()
+		if ierr != nil || fi.IsDir() {
+			continue
+		}
+		if rerr := os.Remove(filepath.Join(dir, e.Name())); rerr != nil {
+			err = rerr
+			continue
+		}
+		files++
+		freed += fi.Size()
+	}
+	return files, freed, err
+}
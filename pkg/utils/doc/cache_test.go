@@ -0,0 +1,114 @@
+package doc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// cacheEntries 返回缓存目录中的条目路径
+func cacheEntries(t *testing.T) []string {
+	t.Helper()
+	entries, err := os.ReadDir(CacheDir())
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, e := range entries {
+		paths = append(paths, filepath.Join(CacheDir(), e.Name()))
+	}
+	return paths
+}
+
+// 测试缓存命中、文件变化后失效、损坏条目回退到重新渲染以及清空缓存
+func TestGetGoDoc_Cache(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", os.Getenv("HOME"))
+	pkgDir := t.TempDir()
+	src := filepath.Join(pkgDir, "a.go")
+	if err := os.WriteFile(src, []byte("// Package cached is a cache fixture.\npackage cached\n\n// Hello greets.\nfunc Hello() {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	opts := Options{Style: StylePlain, Mode: ModeGodoc, Width: 80, Cache: true}
+
+	first, err := GetGoDoc(opts, "", pkgDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := cacheEntries(t)
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 cache entry after a miss, got %v", entries)
+	}
+
+	// 以带有效校验和的内容覆盖条目，命中时应直接返回缓存内容而不重新渲染
+	writeCache(filepath.Base(entries[0]), "from cache", 0)
+	hit, err := GetGoDoc(opts, "", pkgDir)
+	if err != nil || hit != "from cache" {
+		t.Fatalf("expected cache hit, got %q (err %v)", hit, err)
+	}
+
+	// 不同选项使用不同的键
+	if out, _ := GetGoDoc(Options{Style: StylePlain, Mode: ModeGodoc, Width: 80, Cache: true, IncludePrivate: true}, "", pkgDir); out == "from cache" {
+		t.Error("options should be part of the cache key")
+	}
+
+	// 修改文件后缓存失效
+	if err := os.WriteFile(src, []byte("// Package cached is a cache fixture.\npackage cached\n\n// Bye says goodbye.\nfunc Bye() {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Minute)
+	_ = os.Chtimes(src, future, future)
+	changed, err := GetGoDoc(opts, "", pkgDir)
+	if err != nil || !strings.Contains(changed, "Bye") || strings.Contains(changed, "Hello") {
+		t.Fatalf("changed file should invalidate the cache, got:\n%s", changed)
+	}
+
+	// 损坏的条目被丢弃并重新渲染
+	key, err := cacheKey(normalizeDocOptions(opts), pkgDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(CacheDir(), key), []byte("garbage"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fresh, err := GetGoDoc(opts, "", pkgDir)
+	if err != nil || fresh != changed {
+		t.Fatalf("corrupt entry should fall back to a fresh render, got %q (err %v)", fresh, err)
+	}
+	if first == changed {
+		t.Error("fixture change should alter the rendered docs")
+	}
+
+	files, freed, err := ClearCache()
+	if err != nil || files == 0 || freed == 0 {
+		t.Fatalf("ClearCache = %d, %d, %v", files, freed, err)
+	}
+	if left := cacheEntries(t); len(left) != 0 {
+		t.Errorf("cache should be empty, got %v", left)
+	}
+}
+
+// 测试超过容量上限时淘汰最久未使用的条目
+func TestPruneCache(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	for i, name := range []string{"old", "mid", "new"} {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, make([]byte, 100), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		mod := now.Add(time.Duration(i) * time.Minute)
+		_ = os.Chtimes(p, mod, mod)
+	}
+	pruneCache(dir, 250)
+	if _, err := os.Stat(filepath.Join(dir, "old")); !os.IsNotExist(err) {
+		t.Error("least recently used entry should be evicted")
+	}
+	for _, name := range []string{"mid", "new"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s should be kept: %v", name, err)
+		}
+	}
+}
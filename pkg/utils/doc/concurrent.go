@@ -0,0 +1,105 @@
+package doc
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// PackageDoc 是 GetGoDocs 中单个包的渲染结果
+type PackageDoc struct {
+	Dir string // 包目录（与输入一致）
+	Doc string // 经 RenderGodoc 渲染后的文档
+	Err error  // 解析或渲染失败的原因
+}
+
+// prepareConcurrency 确定并发 worker 数量：c<=0 时使用 CPU 核数
+func prepareConcurrency(c int) int {
+	if c > 0 {
+		return c
+	}
+	return max(runtime.NumCPU(), 1)
+}
+
+// GetGoDocs 使用 worker pool 并发解析并渲染多个包目录的文档
+//   - 每个包在独立的 GetGoDoc 调用中解析，各自使用自己的 token.FileSet
+//   - worker 数量由 opts.Concurrency 控制（<=0 使用 CPU 核数），且不超过包的数量
+//   - 结果按输入顺序排序后返回，保证输出与串行渲染一致；单个包失败只记录在对应结果的 Err 中
+//   - opts.Context 取消时不再开始新的包，等待进行中的包完成后返回取消原因
+//
+// 调用前应通过 SetLogger 设置日志记录器，worker 不会修改包级状态
+func GetGoDocs(opts Options, root string, dirs []string) ([]PackageDoc, error) {
+	opts.Mode = ModeGodoc
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	conc := min(prepareConcurrency(opts.Concurrency), max(len(dirs), 1))
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	type item struct {
+		index int
+		doc   PackageDoc
+	}
+	inCh := make(chan int)
+	outCh := make(chan item)
+	var wg sync.WaitGroup
+	for range conc {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range inCh {
+				outCh <- item{index: i, doc: renderPackageDoc(opts, root, dirs[i])}
+			}
+		}()
+	}
+	go func() {
+		defer close(inCh)
+		for i := range dirs {
+			select {
+			case <-ctx.Done():
+				return
+			case inCh <- i:
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(outCh)
+	}()
+
+	items := make([]item, 0, len(dirs))
+	for it := range outCh {
+		items = append(items, it)
+	}
+	if ctx.Err() != nil {
+		return nil, context.Cause(ctx)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].index < items[j].index })
+	docs := make([]PackageDoc, len(items))
+	for i, it := range items {
+		docs[i] = it.doc
+	}
+	return docs, nil
+}
+
+// renderPackageDoc 解析并渲染单个包，与 project doc 处理单个目录时的流程一致
+func renderPackageDoc(opts Options, root, dir string) PackageDoc {
+	s, err := GetGoDoc(opts, root, dir)
+	if err != nil {
+		return PackageDoc{Dir: dir, Err: err}
+	}
+	if strings.TrimSpace(s) == "" {
+		return PackageDoc{Dir: dir, Err: fmt.Errorf("no go files found under %s", dir)}
+	}
+	var b strings.Builder
+	if err := RenderGodoc(&b, s, opts); err != nil {
+		return PackageDoc{Dir: dir, Err: err}
+	}
+	return PackageDoc{Dir: dir, Doc: b.String()}
+}
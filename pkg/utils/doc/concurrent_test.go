@@ -0,0 +1,39 @@
+package doc
+
+import (
+	"testing"
+)
+
+// 测试并发渲染的结果与串行渲染一致，且按输入顺序返回，失败的包只记录错误
+func TestGetGoDocsOrder(t *testing.T) {
+	dirs := []string{sectionFixture, "testdata/missing", readmeFixture, "testdata/examplepkg"}
+	opts := Options{Style: StylePlain, Mode: ModeGodoc, Concurrency: 3}
+	docs, err := GetGoDocs(opts, "", dirs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != len(dirs) {
+		t.Fatalf("expected %d results, got %d", len(dirs), len(docs))
+	}
+	for i, d := range docs {
+		if d.Dir != dirs[i] {
+			t.Errorf("result %d: dir = %s, want %s", i, d.Dir, dirs[i])
+		}
+		if dirs[i] == "testdata/missing" {
+			if d.Err == nil {
+				t.Errorf("expected error for missing package")
+			}
+			continue
+		}
+		if d.Err != nil {
+			t.Fatalf("render %s: %v", dirs[i], d.Err)
+		}
+		serial, err := GetGoDoc(opts, "", dirs[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.Doc != serial {
+			t.Errorf("%s: concurrent output differs from serial rendering", dirs[i])
+		}
+	}
+}
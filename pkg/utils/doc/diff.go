@@ -0,0 +1,284 @@
+package doc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	gdoc "go/doc"
+	"go/parser"
+	"go/token"
+	"io"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// 符号变化的类型
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// SymbolChange 描述一个符号在两个版本之间的变化
+type SymbolChange struct {
+	Kind   string `json:"kind"`          // added / removed / changed
+	Symbol string `json:"symbol"`        // 符号名，方法写作 Type.Method
+	Old    string `json:"old,omitempty"` // 旧版本的声明签名（added 时为空）
+	New    string `json:"new,omitempty"` // 新版本的声明签名（removed 时为空）
+}
+
+// PackageDiff 是一个包的符号级差异
+type PackageDiff struct {
+	ImportPath string         `json:"import_path"`
+	Changes    []SymbolChange `json:"changes"`
+}
+
+// PackageSymbols 解析 dir 中满足 opts.Tags 构建配置的非测试文件，返回符号名到声明签名（不含函数体与注释）的映射；
+// 常量与变量按名称逐个列出，方法、结构体字段与接口方法的名称为 Type.Name，opts.IncludePrivate 时包含未导出符号。
+// 目录中没有 Go 包时返回空映射
+func PackageSymbols(dir string, opts Options) (map[string]string, error) {
+	syms := map[string]string{}
+	ctx := opts.BuildContext()
+	bp, err := ctx.ImportDir(dir, 0)
+	if err != nil {
+		var noGo *build.NoGoError
+		if errors.As(err, &noGo) {
+			return syms, nil
+		}
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range append(bp.GoFiles, bp.CgoFiles...) {
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	var mode gdoc.Mode
+	if opts.IncludePrivate {
+		mode = gdoc.AllDecls
+	}
+	dpkg, err := gdoc.NewFromFiles(fset, files, bp.ImportPath, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	addValues := func(values []*gdoc.Value) {
+		for _, v := range values {
+			for name, sig := range valueSignatures(fset, v.Decl) {
+				syms[name] = sig
+			}
+		}
+	}
+	addFunc := func(name string, fn *gdoc.Func) {
+		decl := *fn.Decl
+		decl.Doc, decl.Body = nil, nil
+		syms[name] = nodeString(fset, &decl)
+	}
+	addValues(dpkg.Consts)
+	addValues(dpkg.Vars)
+	for _, fn := range dpkg.Funcs {
+		addFunc(fn.Name, fn)
+	}
+	for _, t := range dpkg.Types {
+		for _, spec := range t.Decl.Specs {
+			if ts, ok := spec.(*ast.TypeSpec); ok && ts.Name.Name == t.Name {
+				maps.Copy(syms, typeSignatures(fset, ts))
+			}
+		}
+		addValues(t.Consts)
+		addValues(t.Vars)
+		for _, fn := range t.Funcs {
+			addFunc(fn.Name, fn)
+		}
+		for _, m := range t.Methods {
+			addFunc(t.Name+"."+m.Name, m)
+		}
+	}
+	return syms, nil
+}
+
+// typeSignatures 返回类型声明的签名；结构体字段与接口方法（含嵌入）各自作为 Type.Name 符号列出，
+// 这样增删一个字段只报告该字段，而不是整个类型
+func typeSignatures(fset *token.FileSet, ts *ast.TypeSpec) map[string]string {
+	name := ts.Name.Name
+	head := "type " + name
+	if ts.TypeParams != nil {
+		params := make([]string, 0, len(ts.TypeParams.List))
+		for _, f := range ts.TypeParams.List {
+			params = append(params, fieldString(fset, f))
+		}
+		head += "[" + strings.Join(params, ", ") + "]"
+	}
+	out := map[string]string{}
+	var fields *ast.FieldList
+	switch tt := ts.Type.(type) {
+	case *ast.StructType:
+		out[name] = head + " struct"
+		fields = tt.Fields
+	case *ast.InterfaceType:
+		out[name] = head + " interface"
+		fields = tt.Methods
+	default:
+		if ts.Assign.IsValid() {
+			head += " ="
+		}
+		out[name] = head + " " + nodeString(fset, ts.Type)
+		return out
+	}
+	for _, f := range fields.List {
+		if len(f.Names) == 0 {
+			// 嵌入字段或嵌入接口（类型约束）
+			embedded := nodeString(fset, f.Type)
+			out[name+"."+embedded] = name + " embeds " + embedded
+			continue
+		}
+		for _, n := range f.Names {
+			if ft, ok := f.Type.(*ast.FuncType); ok {
+				out[name+"."+n.Name] = name + "." + n.Name + strings.TrimPrefix(nodeString(fset, ft), "func")
+				continue
+			}
+			out[name+"."+n.Name] = name + "." + n.Name + " " + nodeString(fset, f.Type)
+		}
+	}
+	return out
+}
+
+// fieldString 输出参数/类型参数形式的字段，例如 "K comparable"
+func fieldString(fset *token.FileSet, f *ast.Field) string {
+	names := make([]string, 0, len(f.Names))
+	for _, n := range f.Names {
+		names = append(names, n.Name)
+	}
+	if len(names) == 0 {
+		return nodeString(fset, f.Type)
+	}
+	return strings.Join(names, ", ") + " " + nodeString(fset, f.Type)
+}
+
+// valueSignatures 返回常量/变量声明中每个名称的签名，例如 "const MaxSize int = 10"；
+// go/doc 过滤掉的名称（_）被跳过
+func valueSignatures(fset *token.FileSet, decl *ast.GenDecl) map[string]string {
+	out := map[string]string{}
+	for _, spec := range decl.Specs {
+		vs, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		for i, name := range vs.Names {
+			if name.Name == "_" {
+				continue
+			}
+			sig := decl.Tok.String() + " " + name.Name
+			if vs.Type != nil {
+				sig += " " + nodeString(fset, vs.Type)
+			}
+			if i < len(vs.Values) {
+				sig += " = " + nodeString(fset, vs.Values[i])
+			}
+			out[name.Name] = sig
+		}
+	}
+	return out
+}
+
+// DiffSymbols 比较两个版本的符号（PackageSymbols 的结果），按符号名排序返回新增、删除与签名变化的符号
+func DiffSymbols(old, cur map[string]string) []SymbolChange {
+	var changes []SymbolChange
+	for name, sig := range cur {
+		prev, ok := old[name]
+		switch {
+		case !ok:
+			changes = append(changes, SymbolChange{Kind: ChangeAdded, Symbol: name, New: sig})
+		case prev != sig:
+			changes = append(changes, SymbolChange{Kind: ChangeChanged, Symbol: name, Old: prev, New: sig})
+		}
+	}
+	for name, sig := range old {
+		if _, ok := cur[name]; !ok {
+			changes = append(changes, SymbolChange{Kind: ChangeRemoved, Symbol: name, Old: sig})
+		}
+	}
+	slices.SortFunc(changes, func(a, b SymbolChange) int { return strings.Compare(a.Symbol, b.Symbol) })
+	return changes
+}
+
+// WriteSymbolDiff 按 style 输出各包的符号差异，from/to 为比较的两个版本的名称：
+//   - plain: 每个包一个标题，+ 新增、- 删除、~ 变化（下方缩进给出旧签名）
+//   - markdown: 每个包一个二级标题与 diff 代码块（多行签名的每一行都带 +/-），可直接粘贴到 release notes
+//   - json: PackageDiff 数组
+func WriteSymbolDiff(w io.Writer, style Style, from, to string, diffs []PackageDiff) error {
+	switch style {
+	case StyleJSON:
+		if diffs == nil {
+			diffs = []PackageDiff{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(diffs)
+	case StylePlain, StyleMarkdown:
+	default:
+		return fmt.Errorf("doc: --diff supports --style plain, markdown or json, got %s", style)
+	}
+
+	var b strings.Builder
+	if style == StyleMarkdown {
+		fmt.Fprintf(&b, "# API changes %s..%s\n\n", from, to)
+	}
+	if len(diffs) == 0 {
+		fmt.Fprintf(&b, "No API changes between %s and %s.\n", from, to)
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+	for i, d := range diffs {
+		if style == StyleMarkdown {
+			fmt.Fprintf(&b, "## %s\n\n```diff\n", d.ImportPath)
+			for _, c := range d.Changes {
+				if c.Old != "" {
+					writeDiffLines(&b, "- ", "- ", c.Old)
+				}
+				if c.New != "" {
+					writeDiffLines(&b, "+ ", "+ ", c.New)
+				}
+			}
+			b.WriteString("```\n\n")
+			continue
+		}
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s (%s..%s)\n", d.ImportPath, from, to)
+		for _, c := range d.Changes {
+			switch c.Kind {
+			case ChangeAdded:
+				writeDiffLines(&b, "  + ", "    ", c.New)
+			case ChangeRemoved:
+				writeDiffLines(&b, "  - ", "    ", c.Old)
+			case ChangeChanged:
+				writeDiffLines(&b, "  ~ ", "    ", c.New)
+				writeDiffLines(&b, "      was: ", "           ", c.Old)
+			}
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeDiffLines 写入可能多行的签名，第一行使用 prefix，后续行使用 indent
+func writeDiffLines(b *strings.Builder, prefix, indent, sig string) {
+	for i, line := range strings.Split(sig, "\n") {
+		if i == 0 {
+			b.WriteString(prefix)
+		} else {
+			b.WriteString(indent)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+}
@@ -0,0 +1,78 @@
+package doc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// 测试符号签名与差异：结构体字段和接口方法单独列出，函数体与注释变化不算签名变化
+func TestDiffSymbols(t *testing.T) {
+	write := func(src string) string {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "p.go"), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+	oldDir := write(`package p
+
+// Max 上限
+const Max = 10
+
+type T struct {
+	A int
+	b string
+}
+
+type I interface{ Do() error }
+
+func F(x int) int { return x }
+func Gone() {}
+`)
+	newDir := write(`package p
+
+const Max = 20
+
+type T struct {
+	A int
+	B string ` + "`json:\"b\"`" + `
+}
+
+type I interface {
+	Do(force bool) error
+}
+
+// F 注释变化，签名不变
+func F(x int) int { return x + 1 }
+func (T) M() {}
+`)
+	old, err := PackageSymbols(oldDir, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cur, err := PackageSymbols(newDir, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, c := range DiffSymbols(old, cur) {
+		got = append(got, c.Kind+" "+c.Symbol)
+	}
+	want := "removed Gone,changed I.Do,changed Max,added T.B,added T.M"
+	if strings.Join(got, ",") != want {
+		t.Errorf("DiffSymbols = %s, want %s", strings.Join(got, ","), want)
+	}
+	if cur["T.B"] != "T.B string" || cur["I.Do"] != "I.Do(force bool) error" || cur["T"] != "type T struct" {
+		t.Errorf("unexpected signatures: %q %q %q", cur["T.B"], cur["I.Do"], cur["T"])
+	}
+
+	var b strings.Builder
+	if err := WriteSymbolDiff(&b, StyleMarkdown, "v1", "working tree", []PackageDiff{{ImportPath: "p", Changes: DiffSymbols(old, cur)}}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "## p\n\n```diff\n- func Gone()\n- I.Do() error\n+ I.Do(force bool) error\n") {
+		t.Errorf("unexpected markdown diff:\n%s", b.String())
+	}
+}
@@ -0,0 +1,309 @@
+package doc
+
+import (
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"strconv"
+	"strings"
+
+	runewidth "github.com/mattn/go-runewidth"
+)
+
+// structTag 是结构体标签中的一个键值对，如 json:"name,omitempty"
+type structTag struct {
+	Key   string
+	Value string
+}
+
+// member 是 Detailed 模式下类型成员表中的一行：结构体字段或接口方法
+type member struct {
+	Name     string
+	Type     string
+	Tags     []structTag
+	Doc      string
+	Embedded bool
+	// Notes 是完整的文档注释与行尾注释（逐行），Doc 只是其中的第一行
+	Notes []string
+}
+
+// typeMembers 返回 t 的结构体字段或接口方法；其他类型返回 nil。
+// kind 为 "fields" 或 "methods"，供渲染时作为表格标题
+func typeMembers(decl *ast.GenDecl, name string, fset *token.FileSet) (kind string, rows []member) {
+	if decl == nil {
+		return "", nil
+	}
+	for _, spec := range decl.Specs {
+		ts, ok := spec.(*ast.TypeSpec)
+		if !ok || ts.Name.Name != name {
+			continue
+		}
+		switch st := ts.Type.(type) {
+		case *ast.StructType:
+			return "fields", structMembers(st.Fields, fset)
+		case *ast.InterfaceType:
+			return "methods", interfaceMembers(st.Methods, fset)
+		}
+	}
+	return "", nil
+}
+
+// structMembers 将结构体字段展开为成员行；同一行声明的多个字段（a, b int）各占一行，嵌入字段以类型名作为字段名
+func structMembers(fields *ast.FieldList, fset *token.FileSet) []member {
+	return nestedStructMembers(fields, fset, "")
+}
+
+// nestedStructMembers 与 structMembers 相同，字段名带上 prefix；
+// 匿名结构体类型的字段（如 Hotload struct{...}）类型显示为 struct{...}，其字段以 Hotload.Enabled 的形式紧随其后
+func nestedStructMembers(fields *ast.FieldList, fset *token.FileSet, prefix string) []member {
+	if fields == nil {
+		return nil
+	}
+	var rows []member
+	for _, f := range fields.List {
+		typ := nodeString(fset, f.Type)
+		inline, isInline := f.Type.(*ast.StructType)
+		if isInline {
+			typ = "struct{...}"
+		}
+		var tags []structTag
+		if f.Tag != nil {
+			if raw, err := strconv.Unquote(f.Tag.Value); err == nil {
+				tags = parseStructTag(raw)
+			}
+		}
+		doc, notes := fieldDoc(f), fieldNotes(f)
+		if len(f.Names) == 0 {
+			rows = append(rows, member{Name: prefix + strings.TrimPrefix(typ, "*"), Type: typ, Tags: tags, Doc: doc, Embedded: true, Notes: notes})
+			continue
+		}
+		for _, n := range f.Names {
+			rows = append(rows, member{Name: prefix + n.Name, Type: typ, Tags: tags, Doc: doc, Notes: notes})
+			if isInline {
+				rows = append(rows, nestedStructMembers(inline.Fields, fset, prefix+n.Name+".")...)
+			}
+		}
+	}
+	return rows
+}
+
+// interfaceMembers 将接口方法展开为成员行，Type 为去掉 func 关键字的方法签名；嵌入的接口或类型约束标记为 Embedded
+func interfaceMembers(methods *ast.FieldList, fset *token.FileSet) []member {
+	if methods == nil {
+		return nil
+	}
+	var rows []member
+	for _, f := range methods.List {
+		doc, notes := fieldDoc(f), fieldNotes(f)
+		if len(f.Names) == 0 {
+			typ := nodeString(fset, f.Type)
+			rows = append(rows, member{Name: typ, Type: typ, Doc: doc, Embedded: true, Notes: notes})
+			continue
+		}
+		sig := strings.TrimPrefix(nodeString(fset, f.Type), "func")
+		for _, n := range f.Names {
+			rows = append(rows, member{Name: n.Name, Type: sig, Doc: doc, Notes: notes})
+		}
+	}
+	return rows
+}
+
+// fieldDoc 返回字段文档注释的第一行，没有文档注释时使用行尾注释
+func fieldDoc(f *ast.Field) string {
+	text := f.Doc.Text()
+	if strings.TrimSpace(text) == "" {
+		text = f.Comment.Text()
+	}
+	for line := range strings.SplitSeq(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// fieldNotes 返回字段的完整文档注释与行尾注释（去掉空行），两者都存在时文档注释在前
+func fieldNotes(f *ast.Field) []string {
+	var notes []string
+	for _, text := range []string{f.Doc.Text(), f.Comment.Text()} {
+		for line := range strings.SplitSeq(text, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				notes = append(notes, line)
+			}
+		}
+	}
+	return notes
+}
+
+// parseStructTag 按 reflect.StructTag 的约定（key:"value" 以空格分隔）拆分标签，保持原有顺序；
+// 遇到格式错误时停止解析，返回已解析的部分
+func parseStructTag(tag string) []structTag {
+	var tags []structTag
+	for tag != "" {
+		tag = strings.TrimLeft(tag, " ")
+		if tag == "" {
+			break
+		}
+		i := 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		value, err := strconv.Unquote(tag[:i+1])
+		if err != nil {
+			break
+		}
+		tags = append(tags, structTag{Key: key, Value: value})
+		tag = tag[i+1:]
+	}
+	return tags
+}
+
+// nodeString 以 gofmt 格式输出 AST 节点
+func nodeString(fset *token.FileSet, node ast.Node) string {
+	var b strings.Builder
+	_ = printer.Fprint(&b, fset, node)
+	return b.String()
+}
+
+// memberHeaders 返回成员表的列名；接口方法没有标签列
+func memberHeaders(kind string) []string {
+	if kind == "methods" {
+		return []string{"Method", "Signature", "Doc"}
+	}
+	return []string{"Field", "Type", "Tags", "Doc"}
+}
+
+// memberCells 将成员行转换为单元格，嵌入成员在名称后标注 (embedded)
+func memberCells(kind string, m member) []string {
+	name := m.Name
+	if m.Embedded {
+		name += " (embedded)"
+	}
+	if kind == "methods" {
+		return []string{name, m.Type, m.Doc}
+	}
+	tags := make([]string, len(m.Tags))
+	for i, t := range m.Tags {
+		tags[i] = t.Key + ":" + t.Value
+	}
+	return []string{name, m.Type, strings.Join(tags, " "), m.Doc}
+}
+
+// renderMembers 在类型声明之后输出字段 / 方法表：markdown 风格输出标准表格，其余风格输出按列对齐的文本
+func renderMembers(buf *strings.Builder, kind string, rows []member, opts Options) {
+	if len(rows) == 0 {
+		return
+	}
+	headers := memberHeaders(kind)
+	cells := make([][]string, len(rows))
+	for i, m := range rows {
+		cells[i] = memberCells(kind, m)
+	}
+
+	if opts.Style == StyleMarkdown {
+		// 缩进会让 markdown 表格变成代码块，因此表格顶格输出，前后留空行
+		cell := func(s string) string {
+			if s == "" {
+				return " "
+			}
+			return strings.ReplaceAll(s, "|", `\|`)
+		}
+		fmt.Fprintln(buf)
+		fmt.Fprintf(buf, "| %s |\n", strings.Join(headers, " | "))
+		fmt.Fprintf(buf, "|%s\n", strings.Repeat(" --- |", len(headers)))
+		for _, row := range cells {
+			escaped := make([]string, len(row))
+			for i, c := range row {
+				escaped[i] = cell(c)
+			}
+			fmt.Fprintf(buf, "| %s |\n", strings.Join(escaped, " | "))
+		}
+		fmt.Fprintln(buf)
+		renderMemberNotes(buf, kind, rows, opts)
+		return
+	}
+
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = runewidth.StringWidth(h)
+	}
+	for _, row := range cells {
+		for i, c := range row {
+			widths[i] = max(widths[i], runewidth.StringWidth(c))
+		}
+	}
+	writeRow := func(row []string) {
+		var line strings.Builder
+		for i, c := range row {
+			if i == len(row)-1 {
+				line.WriteString(c)
+				break
+			}
+			line.WriteString(runewidth.FillRight(c, widths[i]+2))
+		}
+		fmt.Fprintf(buf, "        %s\n", strings.TrimRight(line.String(), " "))
+	}
+	fmt.Fprintf(buf, "    -- %s --\n", kind)
+	upper := make([]string, len(headers))
+	for i, h := range headers {
+		upper[i] = strings.ToUpper(h)
+	}
+	writeRow(upper)
+	for _, row := range cells {
+		writeRow(row)
+	}
+	renderMemberNotes(buf, kind, rows, opts)
+}
+
+// renderMemberNotes 在成员表之后输出表格 Doc 列放不下的注释：多行文档注释，或同时带有文档注释与行尾注释的成员。
+// 每个成员先输出名称与类型，完整注释缩进在其下方；没有这样的成员时不输出
+func renderMemberNotes(buf *strings.Builder, kind string, rows []member, opts Options) {
+	var noted []member
+	for _, m := range rows {
+		if len(m.Notes) > 1 {
+			noted = append(noted, m)
+		}
+	}
+	if len(noted) == 0 {
+		return
+	}
+	title := "field docs"
+	if kind == "methods" {
+		title = "method docs"
+	}
+
+	if opts.Style == StyleMarkdown {
+		for _, m := range noted {
+			fmt.Fprintf(buf, "- `%s %s`\n", m.Name, m.Type)
+			for _, line := range m.Notes {
+				fmt.Fprintf(buf, "  %s\n", line)
+			}
+		}
+		fmt.Fprintln(buf)
+		return
+	}
+	fmt.Fprintf(buf, "    -- %s --\n", title)
+	for _, m := range noted {
+		fmt.Fprintf(buf, "        %s %s\n", m.Name, m.Type)
+		for _, line := range m.Notes {
+			fmt.Fprintf(buf, "            %s\n", line)
+		}
+	}
+}
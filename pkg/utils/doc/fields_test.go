@@ -0,0 +1,110 @@
+package doc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const fieldFixture = "testdata/fieldpkg"
+
+// 测试按键拆分结构体标签并保持原有顺序，格式错误时返回已解析的部分
+func TestParseStructTag(t *testing.T) {
+	got := parseStructTag(`json:"name,omitempty" yaml:"name" jsonschema:"title=A\"B"`)
+	want := []structTag{{"json", "name,omitempty"}, {"yaml", "name"}, {"jsonschema", `title=A"B`}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseStructTag = %v, want %v", got, want)
+	}
+	if got := parseStructTag(`json:"a" broken`); len(got) != 1 || got[0].Key != "json" {
+		t.Errorf("malformed tail should keep parsed tags, got %v", got)
+	}
+}
+
+// 测试 Detailed 模式下 plain 输出字段表与方法表：标签拆分、首行文档、行尾注释与嵌入标记
+func TestDetailedMembersPlain(t *testing.T) {
+	out, err := GetGoDoc(Options{Style: StylePlain, Mode: ModeGodoc, Detailed: true}, "", fieldFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"-- fields --",
+		"Base (embedded)",
+		"mapstructure:GOROOT json:goroot,omitempty yaml:goroot  GoRoot is the Go installation root.",
+		"mapstructure:GOPATH jsonschema:title=GOPATH,nullable",
+		"Go 工作空间路径",
+		"-- methods --",
+		"io.Closer (embedded)",
+		"Load",
+		"(path string) (*EnvConfig, error)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "It may be empty.  ") {
+		t.Errorf("only the first doc line belongs in the table:\n%s", out)
+	}
+	for _, name := range []string{"Min ", "Max "} {
+		if !strings.Contains(out, "        "+name) {
+			t.Errorf("grouped field %q should get its own row:\n%s", name, out)
+		}
+	}
+
+	// 简洁模式不输出成员表
+	out, err = GetGoDoc(Options{Style: StylePlain, Mode: ModeGodoc}, "", fieldFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, "-- fields --") {
+		t.Errorf("member tables are detailed-only:\n%s", out)
+	}
+}
+
+// 测试 markdown 风格输出标准表格
+func TestDetailedMembersMarkdown(t *testing.T) {
+	out, err := GetGoDoc(Options{Style: StyleMarkdown, Mode: ModeGodoc, Detailed: true}, "", fieldFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"\n| Field | Type | Tags | Doc |\n| --- | --- | --- | --- |\n| Base (embedded) | Base |   |   |\n",
+		"| GoRoot | string | mapstructure:GOROOT json:goroot,omitempty yaml:goroot | GoRoot is the Go installation root. |",
+		"\n| Method | Signature | Doc |\n",
+		"| Load | (path string) (*EnvConfig, error) | Load reads the config at path. |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+// 测试 Doc 列放不下的注释在成员表下方完整输出，匿名结构体的字段以 父字段.子字段 展开
+func TestDetailedMemberNotes(t *testing.T) {
+	out, err := GetGoDoc(Options{Style: StylePlain, Mode: ModeGodoc, Detailed: true}, "", fieldFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"Hotload          struct{...}  json:hotload",
+		"Hotload.Enabled  bool         json:enabled",
+		"Hotload.Delay    int",
+		"    -- field docs --\n        GoRoot string\n            GoRoot is the Go installation root.\n            It may be empty.\n",
+		"        Timeout int\n            Timeout bounds each request.\n            seconds\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	// 单行注释已在表格中完整显示，不再重复
+	if strings.Contains(out, "        GoPath string\n") || strings.Contains(out, "-- method docs --") {
+		t.Errorf("single-line comments should stay in the table only:\n%s", out)
+	}
+
+	out, err = GetGoDoc(Options{Style: StyleMarkdown, Mode: ModeGodoc, Detailed: true}, "", fieldFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "- `GoRoot string`\n  GoRoot is the Go installation root.\n  It may be empty.\n"; !strings.Contains(out, want) {
+		t.Errorf("missing %q in:\n%s", want, out)
+	}
+}
@@ -7,8 +7,10 @@ import (
 	"go/parser"
 	"go/printer"
 	"go/token"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 )
 
@@ -26,6 +28,28 @@ func GetGoDoc(opts Options, _, path string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	// 开启 doc.cache 时先查找渲染缓存，键由包目录文件的名称/大小/修改时间与选项决定；
+	// 缓存不可用或损坏时总是回退到重新渲染
+	if !cacheable(opts) {
+		return renderGoDoc(opts, dir)
+	}
+	key, err := cacheKey(opts, dir)
+	if err != nil {
+		return renderGoDoc(opts, dir)
+	}
+	if s, ok := readCache(key); ok {
+		log.Debug().Str("dir", dir).Str("key", key).Msg("GetGoDoc: cache hit")
+		return s, nil
+	}
+	s, err := renderGoDoc(opts, dir)
+	if err == nil {
+		writeCache(key, s, opts.CacheSizeMB)
+	}
+	return s, err
+}
+
+// renderGoDoc 解析 dir 中的包并按 opts 渲染
+func renderGoDoc(opts Options, dir string) (string, error) {
 	log.Debug().
 		Str("dir", dir).
 		Bool("includeTests", opts.IncludeTests).
@@ -36,7 +60,7 @@ func GetGoDoc(opts Options, _, path string) (string, error) {
 	// 3. 解析目录文件（examples 也需要解析 *_test.go）
 	includeTestFiles := opts.IncludeTests || opts.IncludeExamples
 	fset := token.NewFileSet()
-	filesByPkg, err := parseDirectoryFiles(fset, dir, includeTestFiles)
+	filesByPkg, err := parseDirectoryFiles(fset, dir, includeTestFiles, opts)
 	if err != nil {
 		return "", err
 	}
@@ -45,30 +69,64 @@ func GetGoDoc(opts Options, _, path string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	// 5. 构建 *go/doc.Package
-	dpkg, err := buildDocPackage(fset, dir, mainFiles, opts.IncludePrivate)
+	// 5. 构建 *go/doc.Package；只开启 examples（未开启 tests）时一并传入外部测试包文件，
+	// 由 go/doc 将示例关联到对应的函数/类型/方法
+	docFiles := mainFiles
+	if opts.IncludeExamples && !opts.IncludeTests && len(extraTestFiles) > 0 {
+		docFiles = append(append([]*ast.File{}, mainFiles...), extraTestFiles...)
+	}
+	dpkg, err := buildDocPackage(fset, dir, docFiles, opts.IncludePrivate)
 	if err != nil {
 		return "", err
 	}
-	// 6. 附加测试文件名（仅当需要展示 tests）
+	// 6. 附加测试文件名（仅当需要展示 tests），然后按 opts.Sort 统一排列符号
 	if opts.IncludeTests {
 		appendTestFilenames(dpkg, fset, mainFiles, extraTestFiles)
 	}
-	// 7. 如果只开启 examples（未开启 tests），仍需合并外部测试包 examples
-	if opts.IncludeExamples && !opts.IncludeTests && len(extraTestFiles) > 0 {
-		importPath := filepath.Base(dir) + "_test"
-		if tpkg, e := gdoc.NewFromFiles(fset, extraTestFiles, importPath, gdoc.Mode(0)); e == nil && len(tpkg.Examples) > 0 {
-			log.Debug().Int("examples", len(tpkg.Examples)).Msg("GetGoDoc: merged external test package examples")
-			dpkg.Examples = append(dpkg.Examples, tpkg.Examples...)
+	sortPackage(dpkg, fset, opts.Sort)
+	// 7. 汇总包级以及关联到函数/类型/方法上的示例
+	examples := allExamples(dpkg)
+	if len(examples) > 0 {
+		log.Debug().Int("examples", len(examples)).Msg("GetGoDoc: collected examples")
+	}
+	// 8. 运行可测试示例，渲染时标注结果
+	if opts.VerifyExamples && len(examples) > 0 {
+		results, verr := verifyExamples(dir, examples)
+		if verr != nil {
+			log.Warn().Err(verr).Str("dir", dir).Msg("GetGoDoc: failed to verify examples")
+		} else {
+			opts.exampleResults = results
+			logExampleFailures(dir, results)
 		}
 	}
-	// 8. 收集测试/benchmark/example 函数（仅 tests 模式）
+	// 9. 类型检查，计算接口实现关系（仅 detailed 模式，失败时省略提示）
+	if opts.typeChecked() {
+		load := loadTypeRelations
+		if opts.Implementers == ImplementersModule {
+			load = loadModuleTypeRelations
+		}
+		rel, terr := load(dir, opts)
+		if terr != nil {
+			log.Warn().Err(terr).Str("dir", dir).Msg("GetGoDoc: type check failed, omitting implementation hints")
+		} else {
+			opts.relations = rel
+		}
+	}
+	// 10. 收集测试/benchmark/example 函数（仅 tests 模式）
 	var testFuncs []*ast.FuncDecl
 	if opts.IncludeTests {
 		testFuncs = collectTestFunctions(fset, mainFiles, extraTestFiles)
+		sortTestFuncs(testFuncs, fset, opts.Sort)
 	}
-	// 9. 渲染
+	// 11. 渲染
 	str, _ := parseGoDoc(opts, dpkg, fset, testFuncs)
+	// 12. 合并包目录下的 README（不存在时忽略）
+	if opts.IncludeReadme {
+		if readmePath, readme := findReadme(dir); readmePath != "" {
+			log.Debug().Str("readme", readmePath).Msg("GetGoDoc: merging package README")
+			return mergeReadme(opts.Style, readmePath, readme, str)
+		}
+	}
 	return str, nil
 }
 
@@ -79,6 +137,9 @@ func normalizeDocOptions(o Options) Options {
 	if o.IncludeTests && !o.IncludeExamples { // tests imply examples
 		o.IncludeExamples = true
 	}
+	if slices.Contains(o.Only, SectionExamples) || o.VerifyExamples { // --only examples / --verify-examples imply examples
+		o.IncludeExamples = true
+	}
 	return o
 }
 
@@ -94,7 +155,9 @@ func resolveTargetDir(path string) (string, error) {
 	return dir, nil
 }
 
-func parseDirectoryFiles(fset *token.FileSet, dir string, includeTests bool) (map[string][]*ast.File, error) {
+// parseDirectoryFiles 解析 dir 中满足 opts.Tags 构建配置的 Go 文件，按包名分组
+func parseDirectoryFiles(fset *token.FileSet, dir string, includeTests bool, opts Options) (map[string][]*ast.File, error) {
+	ctx := opts.BuildContext()
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, fmt.Errorf("read dir failed: %w", err)
@@ -111,6 +174,9 @@ func parseDirectoryFiles(fset *token.FileSet, dir string, includeTests bool) (ma
 		if !includeTests && strings.HasSuffix(name, "_test.go") {
 			continue
 		}
+		if match, err := ctx.MatchFile(dir, name); err != nil || !match {
+			continue
+		}
 		full := filepath.Join(dir, name)
 		f, parseErr := parser.ParseFile(fset, full, nil, parser.ParseComments)
 		if parseErr != nil {
@@ -119,17 +185,18 @@ func parseDirectoryFiles(fset *token.FileSet, dir string, includeTests bool) (ma
 		filesByPkg[f.Name.Name] = append(filesByPkg[f.Name.Name], f)
 	}
 	if len(filesByPkg) == 0 {
-		return nil, fmt.Errorf("no go files found under %s", dir)
+		return nil, fmt.Errorf("no go files found under %s for %s", dir, opts.buildDescription())
 	}
 	return filesByPkg, nil
 }
 
 func selectPackageFiles(filesByPkg map[string][]*ast.File, includeTests bool) (mainFiles []*ast.File, extraTestFiles []*ast.File, err error) {
+	// 按包名顺序遍历，目录中有多个非测试包时选择结果保持稳定
 	var chosenName string
-	for name, fs := range filesByPkg {
+	for _, name := range slices.Sorted(maps.Keys(filesByPkg)) {
 		if mainFiles == nil || (!strings.HasSuffix(name, "_test") && strings.HasSuffix(chosenName, "_test")) {
 			chosenName = name
-			mainFiles = fs
+			mainFiles = filesByPkg[name]
 		}
 	}
 	if len(mainFiles) == 0 {
@@ -211,16 +278,13 @@ func collectTestFunctions(fset *token.FileSet, mainFiles, extraTestFiles []*ast.
 
 // parseGoDoc 解析 doc.Package ，并结合 opts 生成合适的文档结构
 func parseGoDoc(opts Options, dpkg *gdoc.Package, fset *token.FileSet, testFuncs []*ast.FuncDecl) (string, error) {
-	// dispatch by style - currently only plain is implemented
 	switch opts.Style {
 	case StylePlain:
 		return renderPlainDoc(opts, dpkg, fset, testFuncs)
 	case StyleMarkdown:
-		// TODO: implement Markdown renderer
-		return renderPlainDoc(opts, dpkg, fset, testFuncs)
+		return renderMarkdownDoc(opts, dpkg, fset, testFuncs)
 	case StyleHTML:
-		// TODO: implement HTML renderer
-		return renderPlainDoc(opts, dpkg, fset, testFuncs)
+		return renderHTMLDoc(opts, dpkg, fset, testFuncs)
 	default:
 		return renderPlainDoc(opts, dpkg, fset, testFuncs)
 	}
@@ -258,29 +322,41 @@ func declPosition(n ast.Node, fset *token.FileSet) string {
 	return fmt.Sprintf("%s:%d", base, pos.Line)
 }
 
+// allExamples 返回包级示例以及 go/doc 关联到函数、类型、构造函数和方法上的示例
+func allExamples(dpkg *gdoc.Package) []*gdoc.Example {
+	examples := append([]*gdoc.Example{}, dpkg.Examples...)
+	for _, f := range dpkg.Funcs {
+		examples = append(examples, f.Examples...)
+	}
+	for _, t := range dpkg.Types {
+		examples = append(examples, t.Examples...)
+		for _, f := range t.Funcs {
+			examples = append(examples, f.Examples...)
+		}
+		for _, m := range t.Methods {
+			examples = append(examples, m.Examples...)
+		}
+	}
+	return examples
+}
+
 // renderExamples 输出 examples 列表，支持简洁模式与 detailed 模式
 func renderExamples(buf *strings.Builder, dpkg *gdoc.Package, fset *token.FileSet, opts Options) {
-	if len(dpkg.Examples) == 0 {
+	examples := allExamples(dpkg)
+	if len(examples) == 0 {
 		return
 	}
 
 	// 简洁模式：只输出名称 + 首行摘要，用箭头连接
 	if !opts.Detailed {
 		fmt.Fprintf(buf, "Examples:\n")
-		for _, ex := range dpkg.Examples {
+		for _, ex := range examples {
 			name := ex.Name
 			if name == "" {
 				name = "_"
 			}
-			summary := ""
-			if ex.Doc != "" {
-				summary = strings.SplitN(strings.TrimSpace(ex.Doc), "\n", 2)[0]
-			}
-			if summary != "" {
-				fmt.Fprintf(buf, "    Example %s —> %s\n", name, summary)
-			} else {
-				fmt.Fprintf(buf, "    Example %s\n", name)
-			}
+			_, status := exampleStatus(opts, ex)
+			fmt.Fprintf(buf, "%s\n", wrapSummary("    Example "+name+status, docSummary(ex.Doc), opts.Width))
 		}
 		fmt.Fprintln(buf)
 		return
@@ -288,14 +364,15 @@ func renderExamples(buf *strings.Builder, dpkg *gdoc.Package, fset *token.FileSe
 
 	// Detailed 模式：输出完整文档、位置以及代码（签名 / 示例代码）
 	fmt.Fprintf(buf, "=== Examples ===\n\n")
-	for _, ex := range dpkg.Examples {
+	for _, ex := range examples {
 		name := ex.Name
 		if name == "" {
 			name = "_"
 		}
-		fmt.Fprintf(buf, "Example %s:\n", name)
+		result, status := exampleStatus(opts, ex)
+		fmt.Fprintf(buf, "Example %s:%s\n", name, status)
 		if ex.Doc != "" {
-			fmt.Fprintf(buf, "%s\n", indentLines(strings.TrimSpace(ex.Doc), "    "))
+			fmt.Fprintf(buf, "%s\n", docBlock(ex.Doc, "    ", opts.Width))
 		}
 		if pos := declPosition(ex.Code, fset); pos != "" { // 代码位置（如果能获取）
 			fmt.Fprintf(buf, "    // defined at %s\n", pos)
@@ -321,6 +398,12 @@ func renderExamples(buf *strings.Builder, dpkg *gdoc.Package, fset *token.FileSe
 				}
 			}
 		}
+		if result.Status == ExampleFail && len(result.Output) > 0 { // 校验失败时附上 go test 的输出
+			fmt.Fprintf(buf, "    // Verify failed:\n")
+			for _, line := range result.Output {
+				fmt.Fprintf(buf, "    //   %s\n", line)
+			}
+		}
 		fmt.Fprintln(buf)
 	}
 }
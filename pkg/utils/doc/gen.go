@@ -15,6 +15,16 @@ var (
 	log zerolog.Logger = zerolog.Nop()
 )
 
+// SetLogger 设置包级日志记录器，nil 表示不输出
+// 并发生成文档（GetGoDocs）前应先调用，worker 中不会再修改日志记录器
+func SetLogger(logger *zerolog.Logger) {
+	if logger != nil {
+		log = *logger
+	} else {
+		log = zerolog.Nop()
+	}
+}
+
 // GetDoc 返回仓库的文档字符串
 // 实现策略:
 //
@@ -24,11 +34,7 @@ var (
 //
 // 如果两者都失败则返回错误
 func GetDoc(logger *zerolog.Logger, opt Options, root, path string) (string, error) {
-	if logger != nil {
-		log = *logger
-	} else {
-		log = zerolog.Nop()
-	}
+	SetLogger(logger)
 
 	// 验证 options
 	if err := opt.Validate(); err != nil {
@@ -0,0 +1,50 @@
+package doc
+
+import (
+	"fmt"
+	"go/ast"
+	gdoc "go/doc"
+	"go/token"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// definedAtRe 匹配 plain 渲染结果中的 "defined at file.go:10" 位置提示
+var definedAtRe = regexp.MustCompile(`defined at ([\w.\-]+\.go):(\d+)`)
+
+// renderHTMLDoc 基于 plain 渲染结果生成 HTML 片段
+// 当 opts.SourceURL 非空时，"defined at" 位置提示会被转换为指向源码行锚点的链接
+func renderHTMLDoc(opts Options, dpkg *gdoc.Package, fset *token.FileSet, testFuncs []*ast.FuncDecl) (string, error) {
+	plain, err := renderPlainDoc(opts, dpkg, fset, testFuncs)
+	if err != nil {
+		return "", err
+	}
+
+	body := html.EscapeString(plain)
+	if opts.SourceURL != "" {
+		base := strings.TrimRight(opts.SourceURL, "/")
+		body = definedAtRe.ReplaceAllStringFunc(body, func(m string) string {
+			sub := definedAtRe.FindStringSubmatch(m)
+			return fmt.Sprintf(`defined at <a href="%s/%s#L%s">%s:%s</a>`, base, sub[1], sub[2], sub[1], sub[2])
+		})
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "<h1>package %s</h1>\n", html.EscapeString(dpkg.Name))
+	fmt.Fprintf(&buf, "<pre class=\"godoc\">\n%s</pre>\n", body)
+	return buf.String(), nil
+}
+
+// renderHTMLSource 将源码渲染为带行号锚点（#L10）的 HTML 片段
+func renderHTMLSource(name string, src []byte) string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "<h1>%s</h1>\n<pre class=\"source\">\n", html.EscapeString(name))
+	lines := strings.Split(strings.TrimRight(string(src), "\n"), "\n")
+	for i, l := range lines {
+		n := i + 1
+		fmt.Fprintf(&buf, "<span id=\"L%d\"><a href=\"#L%d\">%5d</a>  %s</span>\n", n, n, n, html.EscapeString(l))
+	}
+	buf.WriteString("</pre>\n")
+	return buf.String()
+}
@@ -0,0 +1,477 @@
+package doc
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	gdoc "go/doc"
+	"go/doc/comment"
+	"go/printer"
+	"go/token"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// markdownLinkBase 是指向其他包的文档链接（[pkg.Name]）使用的地址前缀
+const markdownLinkBase = "https://pkg.go.dev"
+
+// xrefEntry 是 XRef 符号索引中的一项
+type xrefEntry struct {
+	Name   string // 锚点名：Name 或 Type.Method
+	Kind   string // const / var / func / type / method
+	Anchor string
+}
+
+// markdownRenderer 渲染 markdown 风格的文档，XRef 时记录已输出的锚点用于链接与索引
+type markdownRenderer struct {
+	buf     strings.Builder
+	opts    Options
+	dpkg    *gdoc.Package
+	fset    *token.FileSet
+	symbols map[string]bool // XRef 时可以链接的符号（与锚点同名）
+	index   []xrefEntry
+}
+
+// renderMarkdownDoc 渲染 markdown 风格的文档：每个函数、类型与方法一个标题，声明放在 go 代码块中，
+// 文档注释经 go/doc/comment 转换为 markdown（[pkg.Name] 形式的链接指向 pkg.go.dev）。
+// 开启 XRef 时每个声明带有锚点，注释中提到的包内导出符号（Name 或 Type.Method）转换为相对链接，
+// 末尾附加按字母排序的符号索引
+func renderMarkdownDoc(opts Options, dpkg *gdoc.Package, fset *token.FileSet, testFuncs []*ast.FuncDecl) (string, error) {
+	r := &markdownRenderer{opts: opts, dpkg: dpkg, fset: fset}
+	if opts.XRef {
+		r.symbols = markdownSymbols(dpkg, opts)
+	}
+	if !opts.filtered() {
+		r.header()
+		r.filesAndImports()
+		r.notes()
+	}
+	r.decls()
+	if opts.IncludeExamples && opts.ShowSection(SectionExamples) {
+		r.examples()
+	}
+	if !opts.filtered() {
+		r.tests(testFuncs)
+	}
+	if opts.XRef {
+		r.xrefIndex()
+	}
+	return r.buf.String(), nil
+}
+
+// markdownSymbols 返回会输出锚点的符号（受 Only/Skip 影响），方法记作 Type.Method
+func markdownSymbols(dpkg *gdoc.Package, opts Options) map[string]bool {
+	syms := map[string]bool{}
+	addValues := func(vs []*gdoc.Value) {
+		for _, v := range vs {
+			for _, n := range v.Names {
+				syms[n] = true
+			}
+		}
+	}
+	if opts.ShowSection(SectionConsts) {
+		addValues(dpkg.Consts)
+	}
+	if opts.ShowSection(SectionVars) {
+		addValues(dpkg.Vars)
+	}
+	if opts.ShowSection(SectionFuncs) {
+		for _, f := range dpkg.Funcs {
+			syms[f.Name] = true
+		}
+	}
+	if opts.ShowSection(SectionTypes) {
+		for _, t := range dpkg.Types {
+			syms[t.Name] = true
+			addValues(t.Consts)
+			addValues(t.Vars)
+			for _, f := range t.Funcs {
+				syms[f.Name] = true
+			}
+			for _, m := range t.Methods {
+				syms[t.Name+"."+m.Name] = true
+			}
+		}
+	}
+	return syms
+}
+
+// anchor 在 XRef 模式下输出锚点并记入索引
+func (r *markdownRenderer) anchor(name, kind string) {
+	if !r.opts.XRef {
+		return
+	}
+	fmt.Fprintf(&r.buf, "<a id=\"%s\"></a>\n", name)
+	r.index = append(r.index, xrefEntry{Name: name, Kind: kind, Anchor: name})
+}
+
+// comment 将文档注释转换为 markdown；self 为注释所属的符号，XRef 时不链接到自身
+func (r *markdownRenderer) comment(text, self string) string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return ""
+	}
+	d := r.dpkg.Parser().Parse(text)
+	if r.opts.XRef {
+		linkMentions(d, r.symbols, self)
+	}
+	pr := r.dpkg.Printer()
+	pr.HeadingLevel = 4
+	pr.DocLinkURL = func(l *comment.DocLink) string {
+		if l.ImportPath != "" {
+			return l.DefaultURL(markdownLinkBase)
+		}
+		// 包内链接只有在锚点存在（XRef）时才输出，否则按普通文本显示
+		if name := docLinkName(l); r.symbols[name] {
+			return "#" + name
+		}
+		return ""
+	}
+	return strings.TrimSpace(string(pr.Markdown(d)))
+}
+
+// synopsis 返回注释的第一句（简洁模式使用）
+func (r *markdownRenderer) synopsis(text, self string) string {
+	return r.comment(r.dpkg.Synopsis(text), self)
+}
+
+// docFor 按模式返回完整注释（detailed）或第一句
+func (r *markdownRenderer) docFor(text, self string) string {
+	if r.opts.Detailed {
+		return r.comment(text, self)
+	}
+	return r.synopsis(text, self)
+}
+
+// para 输出一个段落（空内容忽略）
+func (r *markdownRenderer) para(s string) {
+	if s != "" {
+		fmt.Fprintf(&r.buf, "%s\n\n", s)
+	}
+}
+
+// code 输出 go 代码块
+func (r *markdownRenderer) code(lang, src string) {
+	src = strings.TrimSpace(src)
+	if src == "" {
+		return
+	}
+	fmt.Fprintf(&r.buf, "```%s\n%s\n```\n\n", lang, src)
+}
+
+// position 在 detailed 模式下输出声明位置
+func (r *markdownRenderer) position(n ast.Node) {
+	if !r.opts.Detailed {
+		return
+	}
+	if pos := declPosition(n, r.fset); pos != "" {
+		fmt.Fprintf(&r.buf, "*defined at %s*\n\n", pos)
+	}
+}
+
+func (r *markdownRenderer) header() {
+	fmt.Fprintf(&r.buf, "# package %s\n\n", r.dpkg.Name)
+	r.para(r.comment(r.dpkg.Doc, ""))
+}
+
+func (r *markdownRenderer) filesAndImports() {
+	if len(r.dpkg.Filenames) > 0 {
+		r.buf.WriteString("## Files\n\n")
+		for _, fn := range r.dpkg.Filenames {
+			fmt.Fprintf(&r.buf, "- %s\n", filepath.Base(fn))
+		}
+		r.buf.WriteString("\n")
+	}
+	if len(r.dpkg.Imports) > 0 {
+		r.buf.WriteString("## Imports\n\n")
+		for _, im := range slices.Sorted(slices.Values(r.dpkg.Imports)) {
+			fmt.Fprintf(&r.buf, "- `%s`\n", im)
+		}
+		r.buf.WriteString("\n")
+	}
+}
+
+func (r *markdownRenderer) notes() {
+	if len(r.dpkg.Notes) == 0 {
+		return
+	}
+	r.buf.WriteString("## Notes\n\n")
+	for _, k := range slices.Sorted(maps.Keys(r.dpkg.Notes)) {
+		fmt.Fprintf(&r.buf, "### %s\n\n", k)
+		for _, n := range r.dpkg.Notes[k] {
+			fmt.Fprintf(&r.buf, "- %s\n", strings.Join(strings.Fields(n.Body), " "))
+		}
+		r.buf.WriteString("\n")
+	}
+}
+
+func (r *markdownRenderer) decls() {
+	if len(r.dpkg.Consts) > 0 && r.opts.ShowSection(SectionConsts) {
+		r.buf.WriteString("## Constants\n\n")
+		r.values(r.dpkg.Consts, "const")
+	}
+	if len(r.dpkg.Vars) > 0 && r.opts.ShowSection(SectionVars) {
+		r.buf.WriteString("## Variables\n\n")
+		r.values(r.dpkg.Vars, "var")
+	}
+	if len(r.dpkg.Funcs) > 0 && r.opts.ShowSection(SectionFuncs) {
+		r.buf.WriteString("## Functions\n\n")
+		for _, f := range r.dpkg.Funcs {
+			r.fn(f, "###", "", "func")
+		}
+	}
+	if len(r.dpkg.Types) > 0 && r.opts.ShowSection(SectionTypes) {
+		r.buf.WriteString("## Types\n\n")
+		for _, t := range r.dpkg.Types {
+			r.typ(t)
+		}
+	}
+}
+
+// values 输出一组常量/变量声明：锚点、声明代码块与注释
+func (r *markdownRenderer) values(vs []*gdoc.Value, kind string) {
+	for _, v := range vs {
+		for _, n := range v.Names {
+			r.anchor(n, kind)
+		}
+		r.code("go", nodeString(r.fset, v.Decl))
+		r.position(v.Decl)
+		r.para(r.docFor(v.Doc, firstName(v.Names)))
+	}
+}
+
+// fn 输出函数或方法：标题、签名与注释；recv 非空时为方法，锚点为 recv.Name
+func (r *markdownRenderer) fn(f *gdoc.Func, level, recv, kind string) {
+	name := f.Name
+	if recv != "" {
+		name = recv + "." + f.Name
+	}
+	r.anchor(name, kind)
+	fmt.Fprintf(&r.buf, "%s %s\n\n", level, funcHeading(f, r.fset))
+	if f.Decl != nil {
+		fd := *f.Decl
+		fd.Body = nil
+		fd.Doc = nil
+		r.code("go", nodeString(r.fset, &fd))
+	}
+	r.position(f.Decl)
+	r.para(r.docFor(f.Doc, name))
+}
+
+// funcHeading 返回函数标题："func Name" 或 "func (r *T) Name"
+func funcHeading(f *gdoc.Func, fset *token.FileSet) string {
+	if f.Decl == nil || f.Decl.Recv == nil || len(f.Decl.Recv.List) == 0 {
+		return "func " + f.Name
+	}
+	field := f.Decl.Recv.List[0]
+	recv := nodeString(fset, field.Type)
+	if len(field.Names) > 0 {
+		recv = field.Names[0].Name + " " + recv
+	}
+	return fmt.Sprintf("func (%s) %s", recv, f.Name)
+}
+
+func (r *markdownRenderer) typ(t *gdoc.Type) {
+	r.anchor(t.Name, "type")
+	fmt.Fprintf(&r.buf, "### type %s\n\n", t.Name)
+	r.code("go", nodeString(r.fset, t.Decl))
+	r.position(t.Decl)
+	r.para(r.docFor(t.Doc, t.Name))
+	if r.opts.Detailed {
+		kind, members := typeMembers(t.Decl, t.Name, r.fset)
+		renderMembers(&r.buf, kind, members, r.opts)
+		r.relations(t.Name)
+	}
+	r.values(t.Consts, "const")
+	r.values(t.Vars, "var")
+	for _, f := range t.Funcs {
+		r.fn(f, "####", "", "func")
+	}
+	for _, m := range t.Methods {
+		r.fn(m, "####", t.Name, "method")
+	}
+}
+
+// relations 输出 TypeInfo/Implementers 计算的接口实现关系，XRef 时包内类型链接到其锚点
+func (r *markdownRenderer) relations(name string) {
+	rel := r.opts.relations
+	if rel == nil {
+		return
+	}
+	link := func(names []string) string {
+		out := make([]string, len(names))
+		for i, n := range names {
+			out[i] = "`" + n + "`"
+			if base := strings.TrimPrefix(n, "*"); r.symbols[base] {
+				out[i] = fmt.Sprintf("[%s](#%s)", out[i], base)
+			}
+		}
+		return strings.Join(out, ", ")
+	}
+	if ifaces := rel.Implements[name]; len(ifaces) > 0 {
+		fmt.Fprintf(&r.buf, "**Implements:** %s\n\n", link(ifaces))
+	}
+	if impls := rel.ImplementedBy[name]; len(impls) > 0 {
+		fmt.Fprintf(&r.buf, "**Implemented by:** %s\n\n", link(impls))
+	}
+}
+
+func (r *markdownRenderer) examples() {
+	examples := allExamples(r.dpkg)
+	if len(examples) == 0 {
+		return
+	}
+	r.buf.WriteString("## Examples\n\n")
+	for _, ex := range examples {
+		name := ex.Name
+		if name == "" {
+			name = "_"
+		}
+		result, status := exampleStatus(r.opts, ex)
+		if !r.opts.Detailed {
+			fmt.Fprintf(&r.buf, "- Example %s%s", name, status)
+			if s := r.synopsis(ex.Doc, ""); s != "" {
+				fmt.Fprintf(&r.buf, " - %s", s)
+			}
+			r.buf.WriteString("\n")
+			continue
+		}
+		fmt.Fprintf(&r.buf, "### Example %s%s\n\n", name, status)
+		r.para(r.comment(ex.Doc, ""))
+		r.position(ex.Code)
+		if ex.Code != nil {
+			var cb strings.Builder
+			_ = printer.Fprint(&cb, r.fset, ex.Code)
+			r.code("go", cb.String())
+		}
+		if out := strings.TrimRight(ex.Output, "\n"); out != "" {
+			r.buf.WriteString("Output:\n\n")
+			r.code("text", out)
+		}
+		if result.Status == ExampleFail && len(result.Output) > 0 {
+			r.buf.WriteString("Verify failed:\n\n")
+			r.code("text", strings.Join(result.Output, "\n"))
+		}
+	}
+	if !r.opts.Detailed {
+		r.buf.WriteString("\n")
+	}
+}
+
+func (r *markdownRenderer) tests(testFuncs []*ast.FuncDecl) {
+	if !r.opts.IncludeTests || len(testFuncs) == 0 {
+		return
+	}
+	r.buf.WriteString("## Tests\n\n")
+	for _, fd := range testFuncs {
+		if fd == nil {
+			continue
+		}
+		cloned := *fd
+		cloned.Body = nil
+		cloned.Doc = nil
+		fmt.Fprintf(&r.buf, "- `%s`", nodeString(r.fset, &cloned))
+		if fd.Doc != nil {
+			if s := strings.SplitN(strings.TrimSpace(fd.Doc.Text()), "\n", 2)[0]; s != "" {
+				fmt.Fprintf(&r.buf, " - %s", s)
+			}
+		}
+		r.buf.WriteString("\n")
+	}
+	r.buf.WriteString("\n")
+}
+
+// xrefIndex 输出按字母排序（不区分大小写）的符号索引
+func (r *markdownRenderer) xrefIndex() {
+	if len(r.index) == 0 {
+		return
+	}
+	entries := slices.Clone(r.index)
+	slices.SortStableFunc(entries, func(a, b xrefEntry) int {
+		return cmp.Or(cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)), cmp.Compare(a.Name, b.Name))
+	})
+	r.buf.WriteString("## Index\n\n")
+	for _, e := range entries {
+		fmt.Fprintf(&r.buf, "- [%s](#%s) (%s)\n", e.Name, e.Anchor, e.Kind)
+	}
+	r.buf.WriteString("\n")
+}
+
+// mentionRe 匹配注释正文中的标识符或 Type.Method 形式的引用
+var mentionRe = regexp.MustCompile(`\b[A-Za-z_][A-Za-z0-9_]*(?:\.[A-Za-z_][A-Za-z0-9_]*)?\b`)
+
+// linkMentions 将段落与列表中提到的包内导出符号替换为文档链接；代码块、标题、已有链接以及 self 自身不处理
+func linkMentions(d *comment.Doc, symbols map[string]bool, self string) {
+	var walk func(blocks []comment.Block)
+	walk = func(blocks []comment.Block) {
+		for _, b := range blocks {
+			switch b := b.(type) {
+			case *comment.Paragraph:
+				b.Text = linkText(b.Text, symbols, self)
+			case *comment.List:
+				for _, item := range b.Items {
+					walk(item.Content)
+				}
+			}
+		}
+	}
+	walk(d.Content)
+}
+
+// linkText 拆分 Plain 文本中的符号引用，其余 Text 节点原样保留
+func linkText(texts []comment.Text, symbols map[string]bool, self string) []comment.Text {
+	var out []comment.Text
+	for _, t := range texts {
+		plain, ok := t.(comment.Plain)
+		if !ok {
+			out = append(out, t)
+			continue
+		}
+		s := string(plain)
+		last := 0
+		for _, m := range mentionRe.FindAllStringIndex(s, -1) {
+			word := s[m[0]:m[1]]
+			// Type.Field 等不是符号的引用只链接前面的类型
+			if recv, _, ok := strings.Cut(word, "."); ok && !symbols[word] {
+				word, m[1] = recv, m[0]+len(recv)
+			}
+			if word == self || !symbols[word] || !exportedMention(word) {
+				continue
+			}
+			if m[0] > last {
+				out = append(out, comment.Plain(s[last:m[0]]))
+			}
+			link := &comment.DocLink{Text: []comment.Text{comment.Plain(word)}, Name: word}
+			if recv, name, ok := strings.Cut(word, "."); ok {
+				link.Recv, link.Name = recv, name
+			}
+			out = append(out, link)
+			last = m[1]
+		}
+		if last < len(s) {
+			out = append(out, comment.Plain(s[last:]))
+		}
+	}
+	return out
+}
+
+// exportedMention 报告引用中的每一段是否都是导出标识符，避免把普通的小写单词当作符号
+func exportedMention(word string) bool {
+	for part := range strings.SplitSeq(word, ".") {
+		if !token.IsExported(part) {
+			return false
+		}
+	}
+	return true
+}
+
+// docLinkName 返回包内文档链接对应的锚点名
+func docLinkName(l *comment.DocLink) string {
+	if l.Recv != "" {
+		return l.Recv + "." + l.Name
+	}
+	return l.Name
+}
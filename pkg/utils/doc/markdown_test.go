@@ -0,0 +1,43 @@
+package doc
+
+import (
+	"strings"
+	"testing"
+)
+
+// 测试 markdown 渲染与 --xref：声明带锚点，注释中的包内符号转换为链接，末尾输出按字母排序的索引
+func TestMarkdownXRef(t *testing.T) {
+	out, err := GetGoDoc(Options{Style: StyleMarkdown, Mode: ModeGodoc, Detailed: true, XRef: true}, "", implFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# package implpkg\n",
+		"<a id=\"Circle.Area\"></a>\n#### func (c *Circle) Area\n\n```go\nfunc (c *Circle) Area() float64\n```\n",
+		"Shape is implemented by [Square](#Square) and \\*[Circle](#Circle).",
+		"## Index\n\n- [Circle](#Circle) (type)\n- [Circle.Area](#Circle.Area) (method)\n- [Label](#Label) (type)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	// 符号不链接到自身
+	if strings.Contains(out, "[Label](#Label) implements") {
+		t.Errorf("a symbol should not link to itself:\n%s", out)
+	}
+
+	out, err = GetGoDoc(Options{Style: StyleMarkdown, Mode: ModeGodoc}, "", implFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, "<a id=") || strings.Contains(out, "](#") || strings.Contains(out, "## Index") {
+		t.Errorf("anchors and links are xref-only:\n%s", out)
+	}
+	if !strings.Contains(out, "### type Square\n") {
+		t.Errorf("missing type heading:\n%s", out)
+	}
+
+	if err := (Options{Style: StylePlain, Mode: ModeGodoc, XRef: true}).Validate(); err == nil {
+		t.Error("--xref should require the markdown style")
+	}
+}
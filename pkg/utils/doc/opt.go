@@ -1,7 +1,11 @@
 package doc
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"slices"
+	"strings"
 )
 
 // Style 定义文档渲染的样式
@@ -30,11 +34,23 @@ const (
 	ModeMarkdown Mode = "markdown"
 )
 
+// 可通过 Options.Only / Options.Skip 过滤的文档段落名称
+const (
+	SectionConsts   = "consts"
+	SectionVars     = "vars"
+	SectionFuncs    = "funcs"
+	SectionTypes    = "types"
+	SectionExamples = "examples"
+)
+
+// Sections 按渲染顺序列出所有可过滤的段落
+var Sections = []string{SectionConsts, SectionVars, SectionFuncs, SectionTypes, SectionExamples}
+
 // Options 用于配置文档命令选项
 type Options struct {
 
 	// Output 指定生成文档的输出路径（文件或目录），为空则输出到 stdout 或默认位置
-	Output string `mapstructure:"output" jsonschema:"title=Output,description=Output path (file or directory); empty for stdout,nullable"`
+	Output string `mapstructure:"output" gocli:"output" jsonschema:"title=Output,description=Output path (file or directory); empty for stdout; ~ and $VAR/${VAR} are expanded and relative paths set in a config file resolve against its directory (defaults against the working directory),nullable"`
 
 	// Style 渲染风格，使用什么样式渲染（markdown, html, plain）
 	Style Style `mapstructure:"style" jsonschema:"title=Style,description=Render style: markdown|html|plain|json|yaml,enum=markdown,enum=html,enum=plain,enum=json,enum=yaml"`
@@ -51,6 +67,9 @@ type Options struct {
 	// IncludeExamples 是否渲染示例（go/doc 中的 Examples）当未显式指定且开启 --tests 时会被自动启用
 	IncludeExamples bool `mapstructure:"include_examples" jsonschema:"title=IncludeExamples,description=Include code examples (auto-enabled with tests)"`
 
+	// VerifyExamples 通过 go test -run Example 实际运行可测试示例，并在输出中标注 PASS/FAIL（隐含 IncludeExamples）
+	VerifyExamples bool `mapstructure:"verify_examples" jsonschema:"title=VerifyExamples,description=Run testable examples with go test and annotate each as PASS/FAIL (implies include_examples)"`
+
 	// TOC 是否生成目录 (table of contents)
 	TOC bool `mapstructure:"toc" jsonschema:"title=TOC,description=Generate table of contents"`
 
@@ -60,11 +79,93 @@ type Options struct {
 	// Theme 用于指定渲染时的主题 (例如 "dracula", "dark", "light")
 	Theme string `mapstructure:"theme" jsonschema:"title=Theme,description=Render theme name (implementation specific),nullable"`
 
-	// Width 用于指定渲染的宽度，0 表示自动检测终端宽度
+	// NoColor plain 风格下不着色；未设置时在终端输出中为标题、声明与 Deprecated 着色（遵循 NO_COLOR/FORCE_COLOR），
+	// 配色按 Theme 选择（名称含 light 为浅色背景，notty/ascii 不着色，未指定时按终端背景）
+	NoColor bool `mapstructure:"no_color" jsonschema:"title=NoColor,description=Do not color plain style output (headings and signatures are colored on terminals otherwise)"`
+
+	// Width 用于指定渲染的宽度，0 表示自动检测终端宽度（输出不是终端时为 DefaultWrapWidth）
 	Width int `mapstructure:"width" jsonschema:"title=Width,description=Render width (0=auto),minimum=0"`
 
+	// Concurrency --all 模式下并发解析/渲染包的 worker 数量，<=0 表示使用 CPU 核数
+	Concurrency int `mapstructure:"concurrency" jsonschema:"title=Concurrency,description=Number of packages parsed and rendered concurrently in --all mode (0 uses CPU cores),minimum=0"`
+
+	// IncludeReadme 是否在包文档前合并包目录下的 README.md / README
+	IncludeReadme bool `mapstructure:"include_readme" jsonschema:"title=IncludeReadme,description=Prepend the package directory README before the package documentation"`
+
 	// Detailed 详细模式，是否输出更详细的文档信息，仅在 godoc 模式下有效，用于更详细的文档输出
 	Detailed bool `mapstructure:"detailed" jsonschema:"title=Detailed,description=Produce more detailed output (godoc mode only)"`
+
+	// TypeInfo 在 Detailed 模式下通过 go/types 类型检查，为每个类型标注实现的包内接口（implements）以及接口的包内实现（implemented by）
+	// 需要加载依赖做类型检查，速度较慢；类型检查失败时省略这些提示
+	TypeInfo bool `mapstructure:"type_info" jsonschema:"title=TypeInfo,description=Type-check the package and show in-package interface implementations in detailed mode (slower)"`
+
+	// Implementers 在 Detailed 模式下列出接口的实现类型与类型实现的接口：package 只查找当前包（等价于 TypeInfo），
+	// module 通过 go/packages 加载整个模块，其他包中的类型写作 pkg.T；为空时不启用
+	Implementers string `mapstructure:"implementers" jsonschema:"title=Implementers,description=List interface implementations in detailed mode: package or module scope (module type-checks every package of the module),enum=,enum=package,enum=module"`
+
+	// XRef markdown 风格下为每个声明生成锚点，把文档注释中提到的包内导出符号转换为相对链接，并在末尾附加按字母排序的符号索引
+	XRef bool `mapstructure:"xref" jsonschema:"title=XRef,description=Markdown style only: link mentions of package symbols in doc comments and append an alphabetical symbol index"`
+
+	// Sort 符号排序方式：alpha（默认，按名称）或 source（按声明位置），作用于常量、变量、函数、类型及其方法、示例与 notes
+	Sort string `mapstructure:"sort" jsonschema:"title=Sort,description=Symbol order: alpha (by name) or source (by declaration position),enum=alpha,enum=source"`
+
+	// Only 只渲染这些段落（consts/vars/funcs/types/examples），包注释、文件列表等其他内容一并省略；与 Skip 互斥
+	Only []string `mapstructure:"only" jsonschema:"title=Only,description=Render only these sections: consts|vars|funcs|types|examples (mutually exclusive with skip),nullable"`
+
+	// Skip 不渲染这些段落，其余内容照常输出；与 Only 互斥
+	Skip []string `mapstructure:"skip" jsonschema:"title=Skip,description=Omit these sections: consts|vars|funcs|types|examples (mutually exclusive with only),nullable"`
+
+	// Tags 构建配置：GOOS/GOARCH 名称（如 linux、arm64）选择目标平台，其余作为构建标签；
+	// 只解析满足该配置构建约束（//go:build 与 _GOOS/_GOARCH 文件名后缀）的文件，为空时使用当前平台
+	Tags []string `mapstructure:"tags" jsonschema:"title=Tags,description=Build configuration: GOOS/GOARCH names select the target platform and other entries are build tags; only files matching the constraints are documented,nullable"`
+
+	// Cache 是否启用渲染缓存（~/.gocli/cache/doc），按包目录文件的名称、大小、修改时间与选项复用渲染结果
+	Cache bool `mapstructure:"cache" jsonschema:"title=Cache,description=Cache rendered package docs under ~/.gocli/cache/doc keyed by the package files and options"`
+
+	// CacheSizeMB 渲染缓存的容量上限（MB），超过时淘汰最久未使用的条目
+	CacheSizeMB int `mapstructure:"cache_size_mb" jsonschema:"title=CacheSizeMB,description=Maximum size of the doc render cache in MB (least recently used entries are evicted),minimum=0"`
+
+	// Serve 以 HTTP 服务方式浏览文档的监听地址（如 ":6060"），为空则不启动服务，仅命令行使用
+	Serve string `mapstructure:"-" jsonschema:"-"`
+
+	// All 递归渲染参数下的所有包（参数也可以直接写成 ./...），仅命令行使用
+	All bool `mapstructure:"-" jsonschema:"-"`
+
+	// Fetch 三方库不在 GOMODCACHE 中时通过 go mod download 拉取（需要网络），仅命令行使用
+	Fetch bool `mapstructure:"-" jsonschema:"-"`
+
+	// Tree 为参数下的所有包生成与模块目录结构一致的 markdown 文档树及 SUMMARY.md（-o 为输出根目录），仅命令行使用
+	Tree bool `mapstructure:"-" jsonschema:"-"`
+
+	// KeepStale Tree 模式下保留上次生成但已不对应任何包的文件，仅命令行使用
+	KeepStale bool `mapstructure:"-" jsonschema:"-"`
+
+	// IncludeInternal Tree 模式下同时生成 internal 包的文档，仅命令行使用
+	IncludeInternal bool `mapstructure:"-" jsonschema:"-"`
+
+	// IncludeTestdata Tree 模式下同时生成 testdata 目录中的包的文档，仅命令行使用
+	IncludeTestdata bool `mapstructure:"-" jsonschema:"-"`
+
+	// Diff 与该 git 版本（tag、分支或提交）比较，输出包的导出符号新增/删除/签名变化，仅命令行使用
+	Diff string `mapstructure:"-" jsonschema:"-"`
+
+	// Append 以追加方式写入输出文件（等价于在 -o 路径后加 :append），仅命令行使用
+	Append bool `mapstructure:"-" jsonschema:"-"`
+
+	// Stdin 参数为 "-" 时读取待渲染内容的 Reader，为 nil 时使用 os.Stdin，仅命令行使用
+	Stdin io.Reader `mapstructure:"-" jsonschema:"-"`
+
+	// Context 取消时 GetGoDocs 不再开始新的包并返回取消原因，为 nil 时不可取消，仅命令行使用
+	Context context.Context `mapstructure:"-" jsonschema:"-"`
+
+	// SourceURL HTML 渲染时 "defined at" 链接的源码地址前缀，为空则不生成链接，由文档服务内部设置
+	SourceURL string `mapstructure:"-" jsonschema:"-"`
+
+	// exampleResults VerifyExamples 的运行结果（测试函数名 -> 结果），由 GetGoDoc 内部填充
+	exampleResults map[string]ExampleResult
+
+	// relations TypeInfo/Implementers 计算出的接口实现关系，由 GetGoDoc 内部填充
+	relations *typeRelations
 }
 
 // Validate 检查 Options 的基本有效性
@@ -77,9 +178,47 @@ func (o Options) Validate() error {
 	if !o.Mode.IsValid() {
 		return fmt.Errorf("doc: invalid mode: %s", o.Mode)
 	}
+	if o.Sort != "" && !slices.Contains(SortOrders, o.Sort) {
+		return fmt.Errorf("doc: unknown sort order %q (valid: %s)", o.Sort, strings.Join(SortOrders, ", "))
+	}
+	if o.XRef && o.Style != StyleMarkdown {
+		return fmt.Errorf("doc: --xref requires --style markdown")
+	}
+	if o.Implementers != "" && !slices.Contains(ImplementersScopes, o.Implementers) {
+		return fmt.Errorf("doc: unknown implementers scope %q (valid: %s)", o.Implementers, strings.Join(ImplementersScopes, ", "))
+	}
+	if _, _, _, err := o.splitTags(); err != nil {
+		return err
+	}
+	if len(o.Only) > 0 && len(o.Skip) > 0 {
+		return fmt.Errorf("doc: --only and --skip are mutually exclusive")
+	}
+	for _, name := range append(append([]string{}, o.Only...), o.Skip...) {
+		if !slices.Contains(Sections, name) {
+			return fmt.Errorf("doc: unknown section %q (valid: %s)", name, strings.Join(Sections, ", "))
+		}
+	}
 	return nil
 }
 
+// ShowSection 报告在 Only/Skip 过滤后是否渲染名为 name 的段落
+func (o Options) ShowSection(name string) bool {
+	if len(o.Only) > 0 {
+		return slices.Contains(o.Only, name)
+	}
+	return !slices.Contains(o.Skip, name)
+}
+
+// typeChecked 报告是否需要类型检查计算接口实现关系（TypeInfo 或 Implementers，且为 Detailed 模式）
+func (o Options) typeChecked() bool {
+	return (o.TypeInfo || o.Implementers != "") && o.Detailed
+}
+
+// filtered 报告是否指定了 Only，此时包注释、文件/导入列表、notes 与 tests 等非段落内容均不输出
+func (o Options) filtered() bool {
+	return len(o.Only) > 0
+}
+
 // IsValid 返回 Style 是否是已知值
 func (s Style) IsValid() bool {
 	switch s {
@@ -0,0 +1,91 @@
+package doc
+
+import (
+	"strings"
+	"testing"
+)
+
+const sectionFixture = "testdata/sectionpkg"
+
+// sectionHeadings 为 plain 简洁模式下各段落的标题
+var sectionHeadings = map[string]string{
+	SectionConsts:   "Constants:",
+	SectionVars:     "Variables:",
+	SectionFuncs:    "Functions:",
+	SectionTypes:    "Types:",
+	SectionExamples: "Examples:",
+}
+
+// assertSections 检查输出恰好包含 want 中的段落
+func assertSections(t *testing.T, out string, want ...string) {
+	t.Helper()
+	for _, name := range Sections {
+		has := strings.Contains(out, sectionHeadings[name])
+		if has != strings.Contains(strings.Join(want, ","), name) {
+			t.Errorf("section %s present=%v, want sections %v:\n%s", name, has, want, out)
+		}
+	}
+}
+
+// 测试 --only / --skip 过滤渲染的段落
+func TestGetGoDoc_SectionFilter(t *testing.T) {
+	out, err := GetGoDoc(Options{Style: StylePlain, Mode: ModeGodoc, Only: []string{SectionFuncs, SectionTypes}}, "", sectionFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSections(t, out, SectionFuncs, SectionTypes)
+	if strings.Contains(out, "fixture for section filtering") || strings.Contains(out, "Files:") {
+		t.Errorf("--only should omit the package overview and file list:\n%s", out)
+	}
+
+	out, err = GetGoDoc(Options{Style: StylePlain, Mode: ModeGodoc, IncludeExamples: true, Skip: []string{SectionConsts, SectionVars}}, "", sectionFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSections(t, out, SectionFuncs, SectionTypes, SectionExamples)
+	if !strings.Contains(out, "fixture for section filtering") {
+		t.Errorf("--skip should keep the package overview:\n%s", out)
+	}
+
+	out, err = GetGoDoc(Options{Style: StylePlain, Mode: ModeGodoc, IncludeExamples: true, Only: []string{SectionExamples}}, "", sectionFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSections(t, out, SectionExamples)
+	if !strings.Contains(out, "demonstrates Count") {
+		t.Errorf("expected the package example:\n%s", out)
+	}
+
+	// detailed 模式同样生效
+	out, err = GetGoDoc(Options{Style: StylePlain, Mode: ModeGodoc, Detailed: true, Only: []string{SectionConsts}}, "", sectionFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "=== Constants ===") || strings.Contains(out, "=== Functions ===") || strings.Contains(out, "=== Types ===") {
+		t.Errorf("detailed --only consts rendered unexpected sections:\n%s", out)
+	}
+}
+
+// 测试 Only/Skip 互斥以及未知段落名的错误信息
+func TestOptionsValidate_Sections(t *testing.T) {
+	base := Options{Style: StylePlain, Mode: ModeGodoc}
+
+	o := base
+	o.Only, o.Skip = []string{SectionFuncs}, []string{SectionVars}
+	if err := o.Validate(); err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("expected mutually exclusive error, got %v", err)
+	}
+
+	o = base
+	o.Skip = []string{"methods"}
+	err := o.Validate()
+	if err == nil || !strings.Contains(err.Error(), `unknown section "methods"`) || !strings.Contains(err.Error(), "consts, vars, funcs, types, examples") {
+		t.Errorf("expected unknown section error listing valid names, got %v", err)
+	}
+
+	o = base
+	o.Only = []string{SectionExamples}
+	if err := o.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -14,15 +14,22 @@ import (
 // so we can later add other renderers (markdown/html/json) easily.
 func renderPlainDoc(opts Options, dpkg *gdoc.Package, fset *token.FileSet, testFuncs []*ast.FuncDecl) (string, error) {
 	var buf strings.Builder
+	// 段落折行宽度（0 表示不折行），代码块与签名保持原样
+	opts.Width = wrapWidth(opts)
 
-	renderHeader(&buf, dpkg)
-	renderFilesAndImports(&buf, dpkg)
-	renderNotes(&buf, dpkg)
+	// --only 时只输出所选段落
+	if !opts.filtered() {
+		renderHeader(&buf, dpkg, opts.Width)
+		renderFilesAndImports(&buf, dpkg)
+		renderNotes(&buf, dpkg, opts.Width)
+	}
 	renderDecls(&buf, dpkg, fset, opts)
-	if opts.IncludeExamples {
+	if opts.IncludeExamples && opts.ShowSection(SectionExamples) {
 		renderExamples(&buf, dpkg, fset, opts)
 	}
-	renderTests(&buf, testFuncs, fset, opts)
+	if !opts.filtered() {
+		renderTests(&buf, testFuncs, fset, opts)
+	}
 
 	return buf.String(), nil
 }
@@ -89,11 +96,7 @@ func renderTests(buf *strings.Builder, testFuncs []*ast.FuncDecl, fset *token.Fi
 				if sig == "" {
 					continue
 				}
-				if summary != "" {
-					fmt.Fprintf(buf, "    %s —> %s\n", sig, summary)
-				} else {
-					fmt.Fprintf(buf, "    %s\n", sig)
-				}
+				fmt.Fprintf(buf, "%s\n", wrapSummary("    "+sig, summary, opts.Width))
 			}
 		}
 		fmt.Fprintln(buf)
@@ -114,19 +117,15 @@ func renderTests(buf *strings.Builder, testFuncs []*ast.FuncDecl, fset *token.Fi
 				fmt.Fprintf(buf, "    // defined at %s\n", pos)
 			}
 			if sig != "" {
-				line := sig
-				if summary != "" {
-					line += " —> " + summary
-				}
-				fmt.Fprintf(buf, "%s\n\n", indentLines(line, "    "))
+				fmt.Fprintf(buf, "%s\n\n", indentLines(wrapSummary(sig, summary, opts.Width-4), "    "))
 			}
 		}
 	}
 }
 
-func renderHeader(buf *strings.Builder, dpkg *gdoc.Package) {
+func renderHeader(buf *strings.Builder, dpkg *gdoc.Package, width int) {
 	if strings.TrimSpace(dpkg.Doc) != "" {
-		fmt.Fprintf(buf, "%s\n\n", strings.TrimSpace(dpkg.Doc))
+		fmt.Fprintf(buf, "%s\n\n", docBlock(dpkg.Doc, "", width))
 	}
 }
 
@@ -152,7 +151,7 @@ func renderFilesAndImports(buf *strings.Builder, dpkg *gdoc.Package) {
 	}
 }
 
-func renderNotes(buf *strings.Builder, dpkg *gdoc.Package) {
+func renderNotes(buf *strings.Builder, dpkg *gdoc.Package, width int) {
 	if len(dpkg.Notes) == 0 {
 		return
 	}
@@ -164,7 +163,7 @@ func renderNotes(buf *strings.Builder, dpkg *gdoc.Package) {
 	for _, k := range keys {
 		fmt.Fprintf(buf, "Notes (%s):\n", k)
 		for _, n := range dpkg.Notes[k] {
-			fmt.Fprintf(buf, "    %s\n", strings.TrimSpace(n.Body))
+			fmt.Fprintf(buf, "%s\n", docBlock(n.Body, "    ", width))
 		}
 		fmt.Fprintln(buf)
 	}
@@ -172,80 +171,65 @@ func renderNotes(buf *strings.Builder, dpkg *gdoc.Package) {
 
 func renderDecls(buf *strings.Builder, dpkg *gdoc.Package, fset *token.FileSet, opts Options) {
 	if !opts.Detailed {
-		renderDeclsSimple(buf, dpkg, fset)
+		renderDeclsSimple(buf, dpkg, fset, opts)
 		return
 	}
-	renderDeclsDetailed(buf, dpkg, fset)
+	renderDeclsDetailed(buf, dpkg, fset, opts)
 }
 
 // renderDeclsSimple simple (summary) renderer
-func renderDeclsSimple(buf *strings.Builder, dpkg *gdoc.Package, fset *token.FileSet) {
+func renderDeclsSimple(buf *strings.Builder, dpkg *gdoc.Package, fset *token.FileSet, opts Options) {
 	joinNames := func(names []string) string { return strings.Join(names, ", ") }
 
-	if len(dpkg.Consts) > 0 {
+	if len(dpkg.Consts) > 0 && opts.ShowSection(SectionConsts) {
 		fmt.Fprintf(buf, "Constants:\n")
 		for _, v := range dpkg.Consts {
-			fmt.Fprintf(buf, "    %s", joinNames(v.Names))
-			if v.Doc != "" {
-				first := strings.SplitN(strings.TrimSpace(v.Doc), "\n", 2)[0]
-				fmt.Fprintf(buf, " —> %s", first)
-			}
-			fmt.Fprintln(buf)
+			fmt.Fprintf(buf, "%s\n", wrapSummary("    "+joinNames(v.Names), docSummary(v.Doc), opts.Width))
 		}
 		fmt.Fprintln(buf)
 	}
 
-	if len(dpkg.Vars) > 0 {
+	if len(dpkg.Vars) > 0 && opts.ShowSection(SectionVars) {
 		fmt.Fprintf(buf, "Variables:\n")
 		for _, v := range dpkg.Vars {
-			fmt.Fprintf(buf, "    %s", joinNames(v.Names))
-			if v.Doc != "" {
-				first := strings.SplitN(strings.TrimSpace(v.Doc), "\n", 2)[0]
-				fmt.Fprintf(buf, " —> %s", first)
-			}
-			fmt.Fprintln(buf)
+			fmt.Fprintf(buf, "%s\n", wrapSummary("    "+joinNames(v.Names), docSummary(v.Doc), opts.Width))
 		}
 		fmt.Fprintln(buf)
 	}
 
-	if len(dpkg.Funcs) > 0 {
+	if len(dpkg.Funcs) > 0 && opts.ShowSection(SectionFuncs) {
 		fmt.Fprintf(buf, "Functions:\n")
 		for _, f := range dpkg.Funcs {
-			printFuncSignatureSimple(buf, f, fset)
-			if f.Doc != "" {
-				first := strings.SplitN(strings.TrimSpace(f.Doc), "\n", 2)[0]
-				fmt.Fprintf(buf, " —> %s", first)
-			}
-			fmt.Fprintln(buf)
+			fmt.Fprintf(buf, "%s\n", wrapSummary("    "+funcSignatureSimple(f, fset), docSummary(f.Doc), opts.Width))
 		}
 		fmt.Fprintln(buf)
 	}
 
-	if len(dpkg.Types) > 0 {
+	if len(dpkg.Types) > 0 && opts.ShowSection(SectionTypes) {
 		fmt.Fprintf(buf, "Types:\n")
 		for _, t := range dpkg.Types {
-			fmt.Fprintf(buf, "    %s", t.Name)
-			if t.Doc != "" {
-				first := strings.SplitN(strings.TrimSpace(t.Doc), "\n", 2)[0]
-				fmt.Fprintf(buf, " —> %s", first)
-			}
-			fmt.Fprintln(buf)
+			fmt.Fprintf(buf, "%s\n", wrapSummary("    "+t.Name, docSummary(t.Doc), opts.Width))
 			renderTypeSummarySimple(buf, t, fset)
 		}
 		fmt.Fprintln(buf)
 	}
 }
 
-func printFuncSignatureSimple(buf *strings.Builder, f *gdoc.Func, fset *token.FileSet) {
-	if f.Decl != nil {
-		fd := *f.Decl
-		fd.Body = nil
-		var sb strings.Builder
-		_ = printer.Fprint(&sb, fset, &fd)
-		fmt.Fprintf(buf, "    %s", strings.TrimSpace(sb.String()))
-	} else {
-		fmt.Fprintf(buf, "    %s", f.Name)
+// funcSignatureSimple 返回函数声明（不含函数体），没有声明时返回名称
+func funcSignatureSimple(f *gdoc.Func, fset *token.FileSet) string {
+	if f.Decl == nil {
+		return f.Name
 	}
+	fd := *f.Decl
+	fd.Body = nil
+	var sb strings.Builder
+	_ = printer.Fprint(&sb, fset, &fd)
+	return strings.TrimSpace(sb.String())
+}
+
+// docSummary 返回文档注释的首行
+func docSummary(doc string) string {
+	return strings.SplitN(strings.TrimSpace(doc), "\n", 2)[0]
 }
 
 func renderTypeSummarySimple(buf *strings.Builder, t *gdoc.Type, fset *token.FileSet) {
@@ -279,16 +263,16 @@ func renderTypeSummarySimple(buf *strings.Builder, t *gdoc.Type, fset *token.Fil
 }
 
 // detailed renderer (beautified)
-func renderDeclsDetailed(buf *strings.Builder, dpkg *gdoc.Package, fset *token.FileSet) {
+func renderDeclsDetailed(buf *strings.Builder, dpkg *gdoc.Package, fset *token.FileSet, opts Options) {
 	indent := func(s string, pref string) string {
 		return indentLines(s, pref)
 	}
 
-	if len(dpkg.Consts) > 0 {
+	if len(dpkg.Consts) > 0 && opts.ShowSection(SectionConsts) {
 		fmt.Fprintf(buf, "=== Constants ===\n\n")
 		for _, v := range dpkg.Consts {
 			if v.Doc != "" {
-				fmt.Fprintf(buf, "%s\n", indent(strings.TrimSpace(v.Doc), "    "))
+				fmt.Fprintf(buf, "%s\n", docBlock(v.Doc, "    ", opts.Width))
 			}
 			if pos := declPosition(v.Decl, fset); pos != "" {
 				fmt.Fprintf(buf, "    // defined at %s\n", pos)
@@ -298,11 +282,11 @@ func renderDeclsDetailed(buf *strings.Builder, dpkg *gdoc.Package, fset *token.F
 		}
 	}
 
-	if len(dpkg.Vars) > 0 {
+	if len(dpkg.Vars) > 0 && opts.ShowSection(SectionVars) {
 		fmt.Fprintf(buf, "=== Variables ===\n\n")
 		for _, v := range dpkg.Vars {
 			if v.Doc != "" {
-				fmt.Fprintf(buf, "%s\n", indent(strings.TrimSpace(v.Doc), "    "))
+				fmt.Fprintf(buf, "%s\n", docBlock(v.Doc, "    ", opts.Width))
 			}
 			if pos := declPosition(v.Decl, fset); pos != "" {
 				fmt.Fprintf(buf, "    // defined at %s\n", pos)
@@ -312,11 +296,11 @@ func renderDeclsDetailed(buf *strings.Builder, dpkg *gdoc.Package, fset *token.F
 		}
 	}
 
-	if len(dpkg.Funcs) > 0 {
+	if len(dpkg.Funcs) > 0 && opts.ShowSection(SectionFuncs) {
 		fmt.Fprintf(buf, "=== Functions ===\n\n")
 		for _, f := range dpkg.Funcs {
 			if f.Doc != "" {
-				fmt.Fprintf(buf, "%s\n", indent(strings.TrimSpace(f.Doc), "    "))
+				fmt.Fprintf(buf, "%s\n", docBlock(f.Doc, "    ", opts.Width))
 			}
 			if pos := declPosition(f.Decl, fset); pos != "" {
 				fmt.Fprintf(buf, "    // defined at %s\n", pos)
@@ -333,17 +317,19 @@ func renderDeclsDetailed(buf *strings.Builder, dpkg *gdoc.Package, fset *token.F
 		}
 	}
 
-	if len(dpkg.Types) > 0 {
+	if len(dpkg.Types) > 0 && opts.ShowSection(SectionTypes) {
 		fmt.Fprintf(buf, "=== Types ===\n\n")
 		for _, t := range dpkg.Types {
 			if t.Doc != "" {
-				fmt.Fprintf(buf, "%s\n", indent(strings.TrimSpace(t.Doc), "    "))
+				fmt.Fprintf(buf, "%s\n", docBlock(t.Doc, "    ", opts.Width))
 			}
 			if pos := declPosition(t.Decl, fset); pos != "" {
 				fmt.Fprintf(buf, "    // defined at %s\n", pos)
 			}
 			// print type decl
 			fmt.Fprintf(buf, "%s\n", indentCapture(func() string { var b strings.Builder; _ = printer.Fprint(&b, fset, t.Decl); return b.String() }, "    "))
+			kind, members := typeMembers(t.Decl, t.Name, fset)
+			renderMembers(buf, kind, members, opts)
 
 			if len(t.Consts) > 0 {
 				fmt.Fprintf(buf, "    -- associated constants --\n")
@@ -375,7 +361,7 @@ func renderDeclsDetailed(buf *strings.Builder, dpkg *gdoc.Package, fset *token.F
 				fmt.Fprintf(buf, "    -- methods --\n")
 				for _, m := range t.Methods {
 					if m.Doc != "" {
-						fmt.Fprintf(buf, "%s\n", indent(strings.TrimSpace(m.Doc), "        "))
+						fmt.Fprintf(buf, "%s\n", docBlock(m.Doc, "        ", opts.Width))
 					}
 					if pos := declPosition(m.Decl, fset); pos != "" {
 						fmt.Fprintf(buf, "        // defined at %s\n", pos)
@@ -389,7 +375,21 @@ func renderDeclsDetailed(buf *strings.Builder, dpkg *gdoc.Package, fset *token.F
 					}
 				}
 			}
+			renderTypeRelations(buf, t.Name, opts.relations)
 			fmt.Fprintln(buf)
 		}
 	}
 }
+
+// renderTypeRelations 在类型的方法列表之后输出 --type-info 计算的接口实现关系
+func renderTypeRelations(buf *strings.Builder, name string, rel *typeRelations) {
+	if rel == nil {
+		return
+	}
+	if ifaces := rel.Implements[name]; len(ifaces) > 0 {
+		fmt.Fprintf(buf, "    implements: %s\n", strings.Join(ifaces, ", "))
+	}
+	if impls := rel.ImplementedBy[name]; len(impls) > 0 {
+		fmt.Fprintf(buf, "    implemented by: %s\n", strings.Join(impls, ", "))
+	}
+}
@@ -0,0 +1,119 @@
+package doc
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/yuin/goldmark"
+)
+
+// readmeNames 按优先级查找的包目录 README 文件名
+var readmeNames = []string{"README.md", "readme.md", "README.markdown", "README"}
+
+// packageDocHeading 合并 README 后，包文档前的分隔标题
+const packageDocHeading = "Package documentation"
+
+// findReadme 返回 dir 下 README 的路径与内容，不存在时均为空字符串（不是错误）
+func findReadme(dir string) (path, content string) {
+	for _, name := range readmeNames {
+		p := filepath.Join(dir, name)
+		b, err := os.ReadFile(p)
+		if err != nil {
+			continue
+		}
+		return p, string(b)
+	}
+	return "", ""
+}
+
+// mergeReadme 将 README 置于渲染结果之前，包文档放在 "Package documentation" 标题下
+//   - markdown: README 原样输出
+//   - html: README 转换为 HTML
+//   - 其他（plain 等）: 去除 Markdown 语法后的纯文本
+func mergeReadme(style Style, readmePath, readme, rendered string) (string, error) {
+	var b strings.Builder
+	switch style {
+	case StyleMarkdown:
+		b.WriteString(strings.TrimSpace(readme))
+		fmt.Fprintf(&b, "\n\n## %s\n\n", packageDocHeading)
+	case StyleHTML:
+		var buf bytes.Buffer
+		if err := goldmark.Convert([]byte(readme), &buf); err != nil {
+			return "", fmt.Errorf("convert %s to html failed: %w", readmePath, err)
+		}
+		fmt.Fprintf(&b, "<div class=\"readme\">\n%s</div>\n", buf.String())
+		fmt.Fprintf(&b, "<h2>%s</h2>\n", html.EscapeString(packageDocHeading))
+	default:
+		b.WriteString(strings.TrimSpace(stripMarkdown(readme)))
+		fmt.Fprintf(&b, "\n\n%s\n%s\n\n", packageDocHeading, strings.Repeat("=", len(packageDocHeading)))
+	}
+	b.WriteString(rendered)
+	return b.String(), nil
+}
+
+var (
+	mdImageRe      = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdLinkRe       = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)[^)]*\)`)
+	mdRefLinkRe    = regexp.MustCompile(`\[([^\]]+)\]\[[^\]]*\]`)
+	mdRefDefRe     = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s+\S+`)
+	mdHeadingRe    = regexp.MustCompile(`^\s{0,3}#{1,6}\s+(.*?)\s*#*\s*$`)
+	mdSetextRe     = regexp.MustCompile(`^\s{0,3}(=+|-+)\s*$`)
+	mdBoldRe       = regexp.MustCompile(`(\*\*|__)(\S(?:.*?\S)?)(\*\*|__)`)
+	mdItalicRe     = regexp.MustCompile(`(^|[^\w*])[*_](\S(?:[^*_]*?\S)?)[*_]([^\w*]|$)`)
+	mdInlineCodeRe = regexp.MustCompile("`([^`]+)`")
+	mdHTMLTagRe    = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+	mdHTMLCommRe   = regexp.MustCompile(`(?s)<!--.*?-->`)
+)
+
+// stripMarkdown 去除常见的 Markdown 语法，得到适合终端阅读的纯文本
+// 代码块内容原样保留（去掉围栏），链接保留文本与地址，图片只保留替代文本
+func stripMarkdown(md string) string {
+	md = mdHTMLCommRe.ReplaceAllString(strings.ReplaceAll(md, "\r\n", "\n"), "")
+	lines := strings.Split(md, "\n")
+	out := make([]string, 0, len(lines))
+	inFence := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			out = append(out, "    "+line)
+			continue
+		}
+		if mdRefDefRe.MatchString(line) {
+			continue
+		}
+		// setext 标题的下划线（且上一行是文本时）直接丢弃
+		if mdSetextRe.MatchString(line) && len(out) > 0 && strings.TrimSpace(out[len(out)-1]) != "" {
+			continue
+		}
+		if m := mdHeadingRe.FindStringSubmatch(line); m != nil {
+			line = m[1]
+		}
+		if strings.HasPrefix(trimmed, ">") {
+			line = strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+		}
+		line = mdImageRe.ReplaceAllString(line, "$1")
+		line = mdLinkRe.ReplaceAllStringFunc(line, func(s string) string {
+			m := mdLinkRe.FindStringSubmatch(s)
+			if m[1] == m[2] {
+				return m[1]
+			}
+			return m[1] + " (" + m[2] + ")"
+		})
+		line = mdRefLinkRe.ReplaceAllString(line, "$1")
+		line = mdInlineCodeRe.ReplaceAllString(line, "$1")
+		line = mdBoldRe.ReplaceAllString(line, "$2")
+		line = mdItalicRe.ReplaceAllString(line, "$1$2$3")
+		line = mdHTMLTagRe.ReplaceAllString(line, "")
+		out = append(out, strings.TrimRight(line, " "))
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,67 @@
+package doc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const readmeFixture = "testdata/readmepkg"
+
+// 测试 plain 模式下 README 在前（去除 Markdown 语法），包文档位于分隔标题之后
+func TestGetGoDoc_WithReadmePlain(t *testing.T) {
+	out, err := GetGoDoc(Options{Style: StylePlain, Mode: ModeGodoc, IncludeReadme: true}, "", readmeFixture)
+	if err != nil {
+		t.Fatalf("GetGoDoc failed: %v", err)
+	}
+	readmeIdx := strings.Index(out, "Readme Package")
+	headingIdx := strings.Index(out, packageDocHeading)
+	pkgIdx := strings.Index(out, "fixture for README merging tests")
+	if readmeIdx < 0 || headingIdx < 0 || pkgIdx < 0 || readmeIdx >= headingIdx || headingIdx >= pkgIdx {
+		t.Fatalf("unexpected ordering (readme=%d heading=%d pkg=%d):\n%s", readmeIdx, headingIdx, pkgIdx, out)
+	}
+	readme := out[:headingIdx]
+	for _, syntax := range []string{"# ", "**", "](", "`", "```"} {
+		if strings.Contains(readme, syntax) {
+			t.Errorf("markdown syntax %q should be stripped:\n%s", syntax, readme)
+		}
+	}
+	if !strings.Contains(readme, "the docs (https://example.com/docs)") || !strings.Contains(readme, "fmt.Println(readmepkg.Hello())") {
+		t.Errorf("link text or code block content lost:\n%s", readme)
+	}
+}
+
+// 测试 markdown 保留原文、html 转换，以及缺少 README 时不报错
+func TestGetGoDoc_WithReadmeStyles(t *testing.T) {
+	md, err := GetGoDoc(Options{Style: StyleMarkdown, Mode: ModeGodoc, IncludeReadme: true}, "", readmeFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(md, "# Readme Package") || !strings.Contains(md, "## "+packageDocHeading) {
+		t.Errorf("markdown README should be kept raw:\n%s", md)
+	}
+
+	htmlOut, err := GetGoDoc(Options{Style: StyleHTML, Mode: ModeGodoc, IncludeReadme: true}, "", readmeFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(htmlOut, "<h1>Readme Package</h1>") || !strings.Contains(htmlOut, "<strong>greeting</strong>") {
+		t.Errorf("README should be converted to HTML:\n%s", htmlOut)
+	}
+	if strings.Index(htmlOut, "<h2>"+packageDocHeading+"</h2>") > strings.Index(htmlOut, "<h1>package readmepkg</h1>") {
+		t.Errorf("package documentation heading should precede package docs:\n%s", htmlOut)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "x.go"), []byte("// Package x has no README.\npackage x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	plain, err := GetGoDoc(Options{Style: StylePlain, Mode: ModeGodoc, IncludeReadme: true}, "", dir)
+	if err != nil {
+		t.Fatalf("missing README must not be an error: %v", err)
+	}
+	if strings.Contains(plain, packageDocHeading) {
+		t.Errorf("no README should be merged:\n%s", plain)
+	}
+}
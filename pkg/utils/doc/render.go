@@ -2,15 +2,25 @@ package doc
 
 import (
 	"io"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/yeisme/gocli/pkg/style"
 )
 
 // RenderGodoc 渲染 Godoc 文档，并支持多种输出格式
 // 当前实现为简单渲染：
-//   - 当 opts.Title 非空时，将标题作为第一行输出
-//   - 当 opts.TOC 为 true 时，会从输入中抽取一级/二级标题生成简单 TOC（基于行前缀 'Package ' 或 '##'）
+//   - plain 风格在 out 允许颜色（style.ColorEnabled）且未设置 NoColor 时为标题、声明与 Deprecated 着色
+//   - 其余情况原样输出
 func RenderGodoc(out io.Writer, input string, opts Options) error {
 	switch opts.Style {
 	case StylePlain:
+		if p, ok := newPlainPalette(out, opts); ok {
+			input = colorizePlain(input, p)
+		}
+		_ = renderPlain(out, input, opts)
+	case StyleMarkdown, StyleHTML:
 		_ = renderPlain(out, input, opts)
 	}
 	return nil
@@ -21,3 +31,89 @@ func renderPlain(out io.Writer, input string, _ Options) error {
 	_, _ = io.WriteString(out, input)
 	return nil
 }
+
+// plainPalette 是 plain 风格着色使用的样式
+type plainPalette struct {
+	heading    lipgloss.Style // Package 行与段落标题（Functions:、=== Types === 等）
+	signature  lipgloss.Style // 声明与符号名
+	dim        lipgloss.Style // —> 箭头与 "// defined at" 位置注释
+	deprecated lipgloss.Style // 含 Deprecated: 的行
+}
+
+// newPlainPalette 按 opts.Theme 选择配色：名称含 light 时使用浅色背景配色，notty/ascii 不着色，
+// 其他主题使用深色背景配色，未指定时按终端背景选择。out 不允许颜色或设置了 NoColor 时返回 false
+func newPlainPalette(out io.Writer, opts Options) (plainPalette, bool) {
+	if opts.NoColor || !style.ColorEnabled(out) {
+		return plainPalette{}, false
+	}
+	theme := strings.ToLower(opts.Theme)
+	if theme == "notty" || theme == "ascii" {
+		return plainPalette{}, false
+	}
+	re := style.NewRenderer(out)
+	dark := re.HasDarkBackground()
+	switch {
+	case strings.Contains(theme, "light"):
+		dark = false
+	case theme != "":
+		dark = true
+	}
+	return plainPaletteFor(re, dark), true
+}
+
+// plainPaletteFor 返回深色或浅色背景下的配色
+func plainPaletteFor(re *lipgloss.Renderer, dark bool) plainPalette {
+	accent, sig, dim := style.ColorAccentPrimary, style.ColorJSONKey, style.ColorJSONPunct
+	if !dark {
+		accent, sig, dim = lipgloss.Color("#005FAF"), lipgloss.Color("#00656B"), lipgloss.Color("#8A8A8A")
+	}
+	return plainPalette{
+		heading:    re.NewStyle().Bold(true).Foreground(accent),
+		signature:  re.NewStyle().Foreground(sig),
+		dim:        re.NewStyle().Foreground(dim),
+		deprecated: re.NewStyle().Foreground(style.ColorDanger),
+	}
+}
+
+// plainHeadingRe 匹配 plain 输出中的段落标题，例如 "Functions:"、"=== Types ==="
+var plainHeadingRe = regexp.MustCompile(`^(?:[A-Z][A-Za-z /]*:|=== .+ ===)$`)
+
+// plainDeclPrefixes 是声明行（去掉缩进后）的开头
+var plainDeclPrefixes = []string{"func ", "func(", "type ", "const ", "const (", "var ", "var ("}
+
+// colorizePlain 逐行为 plain 文本着色：
+//   - 顶格的 "Package " 行与段落标题使用 heading
+//   - 含 Deprecated: 的行整行使用 deprecated
+//   - 缩进的声明行与 "名称 —> 摘要" 行的名称部分使用 signature，箭头与 "// defined at" 使用 dim
+func colorizePlain(s string, p plainPalette) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " \t")
+		indent := line[:len(line)-len(trimmed)]
+		switch {
+		case trimmed == "":
+		case indent == "" && (strings.HasPrefix(line, "Package ") || plainHeadingRe.MatchString(line)):
+			lines[i] = p.heading.Render(line)
+		case strings.Contains(trimmed, "Deprecated:"):
+			lines[i] = indent + p.deprecated.Render(trimmed)
+		case strings.HasPrefix(trimmed, "// defined at"):
+			lines[i] = indent + p.dim.Render(trimmed)
+		case indent != "" && strings.Contains(trimmed, " —> "):
+			name, summary, _ := strings.Cut(trimmed, " —> ")
+			lines[i] = indent + p.signature.Render(name) + p.dim.Render(" —> ") + summary
+		case indent != "" && hasDeclPrefix(trimmed):
+			lines[i] = indent + p.signature.Render(trimmed)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hasDeclPrefix 报告 s 是否以声明关键字开头
+func hasDeclPrefix(s string) bool {
+	for _, prefix := range plainDeclPrefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package doc
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/yeisme/gocli/pkg/style"
+)
+
+// 测试 plain 风格着色：标题、声明、Deprecated 行着色，文档正文不变；去掉颜色后与原文一致
+func TestColorizePlain(t *testing.T) {
+	in := strings.Join([]string{
+		"Package demo does things",
+		"",
+		"Functions:",
+		"    func Run(ctx context.Context) error —> Run starts the demo",
+		"",
+		"=== Types ===",
+		"",
+		"    // defined at demo.go:12",
+		"    type Config struct",
+		"    Deprecated: use Run instead.",
+		"    plain doc text",
+	}, "\n")
+
+	re := lipgloss.NewRenderer(io.Discard)
+	re.SetColorProfile(termenv.ANSI256)
+	out := colorizePlain(in, plainPaletteFor(re, true))
+	if style.StripANSI(out) != in {
+		t.Fatalf("colorizing changed the text:\n%s", style.StripANSI(out))
+	}
+	lines := strings.Split(out, "\n")
+	for _, i := range []int{0, 2, 3, 5, 7, 8, 9} {
+		if !strings.Contains(lines[i], "\x1b[") {
+			t.Errorf("line %d is not colored: %q", i, lines[i])
+		}
+	}
+	if lines[10] != "    plain doc text" {
+		t.Errorf("doc text should stay plain: %q", lines[10])
+	}
+	if !strings.HasSuffix(lines[3], "Run starts the demo") {
+		t.Errorf("summary after the arrow should stay plain: %q", lines[3])
+	}
+
+	// NoColor 与不支持颜色的 writer 都原样输出
+	t.Setenv("FORCE_COLOR", "1")
+	var b strings.Builder
+	if err := RenderGodoc(&b, in, Options{Style: StylePlain, NoColor: true}); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != in {
+		t.Errorf("NoColor output should be unchanged:\n%s", b.String())
+	}
+	t.Setenv("FORCE_COLOR", "")
+	t.Setenv("NO_COLOR", "1")
+	b.Reset()
+	_ = RenderGodoc(&b, in, Options{Style: StylePlain})
+	if b.String() != in {
+		t.Errorf("NO_COLOR output should be unchanged:\n%s", b.String())
+	}
+}
@@ -0,0 +1,235 @@
+package doc
+
+import (
+	"fmt"
+	"html"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/mod/modfile"
+)
+
+// Server 提供类似 godoc 的本地 HTTP 文档服务
+//
+//   - /                     列出模块内的所有包
+//   - /pkg/<import-path>    使用 HTML 渲染器按需渲染包文档（带缓存）
+//   - /src/<file>#L10       显示带行号锚点的源码，供 "defined at" 链接跳转
+//
+// 渲染结果会被缓存，文件变更时调用 Invalidate 清空缓存，刷新页面即可看到最新内容
+type Server struct {
+	root       string
+	modulePath string
+	opts       Options
+
+	mu    sync.RWMutex
+	cache map[string]string
+}
+
+// NewServer 为 root 下的 Go 模块创建文档服务，root 需要包含 go.mod
+func NewServer(root string, opts Options) (*Server, error) {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("doc: resolve root %q failed: %w", root, err)
+	}
+	data, err := os.ReadFile(filepath.Join(abs, "go.mod"))
+	if err != nil {
+		return nil, fmt.Errorf("doc: read go.mod under %s failed: %w", abs, err)
+	}
+	modulePath := modfile.ModulePath(data)
+	if modulePath == "" {
+		return nil, fmt.Errorf("doc: no module path found in %s", filepath.Join(abs, "go.mod"))
+	}
+
+	// 服务模式统一使用 godoc + HTML 渲染，并开启 detailed 以输出 "defined at" 位置
+	opts.Mode = ModeGodoc
+	opts.Style = StyleHTML
+	opts.Detailed = true
+
+	return &Server{
+		root:       abs,
+		modulePath: modulePath,
+		opts:       opts,
+		cache:      make(map[string]string),
+	}, nil
+}
+
+// Invalidate 清空已渲染包的缓存，通常在监听到文件变更后调用
+func (s *Server) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.cache) > 0 {
+		log.Debug().Int("packages", len(s.cache)).Msg("doc server: cache invalidated")
+	}
+	s.cache = make(map[string]string)
+}
+
+// Handler 返回文档服务的 http.Handler
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pkg/", s.handlePackage)
+	mux.HandleFunc("/src/", s.handleSource)
+	mux.HandleFunc("/", s.handleIndex)
+	return mux
+}
+
+// Packages 递归发现模块内的所有包，返回排序后的 import path 列表
+// 会跳过隐藏目录、vendor、testdata 以及嵌套模块
+func (s *Server) Packages() ([]string, error) {
+	var pkgs []string
+	err := filepath.WalkDir(s.root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if p != s.root {
+			name := d.Name()
+			if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "vendor" || name == "testdata" {
+				return filepath.SkipDir
+			}
+			if _, statErr := os.Stat(filepath.Join(p, "go.mod")); statErr == nil {
+				return filepath.SkipDir
+			}
+		}
+		if hasGoSources(p) {
+			pkgs = append(pkgs, s.importPathFor(p))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(pkgs)
+	return pkgs, nil
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	pkgs, err := s.Packages()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "<h1>%s</h1>\n<ul>\n", html.EscapeString(s.modulePath))
+	for _, p := range pkgs {
+		fmt.Fprintf(&buf, "<li><a href=\"/pkg/%s\">%s</a></li>\n", p, html.EscapeString(p))
+	}
+	buf.WriteString("</ul>\n")
+	writePage(w, s.modulePath, buf.String())
+}
+
+func (s *Server) handlePackage(w http.ResponseWriter, r *http.Request) {
+	importPath := strings.Trim(strings.TrimPrefix(r.URL.Path, "/pkg/"), "/")
+	dir, rel, ok := s.dirFor(importPath)
+	if !ok || !hasGoSources(dir) {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.RLock()
+	body, cached := s.cache[importPath]
+	s.mu.RUnlock()
+	if !cached {
+		opts := s.opts
+		opts.SourceURL = path.Join("/src", rel)
+		str, err := GetGoDoc(opts, s.root, dir)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		body = str
+		s.mu.Lock()
+		s.cache[importPath] = body
+		s.mu.Unlock()
+	}
+	writePage(w, importPath, body)
+}
+
+func (s *Server) handleSource(w http.ResponseWriter, r *http.Request) {
+	rel := path.Clean("/" + strings.TrimPrefix(r.URL.Path, "/src/"))
+	if !strings.HasSuffix(rel, ".go") {
+		http.NotFound(w, r)
+		return
+	}
+	// rel 已经过 path.Clean 且以 / 开头，拼接后不会逃逸出模块根目录
+	file := filepath.Join(s.root, filepath.FromSlash(rel))
+	src, err := os.ReadFile(file)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	name := strings.TrimPrefix(rel, "/")
+	writePage(w, name, renderHTMLSource(name, src))
+}
+
+// dirFor 将 import path 映射为模块内的目录，返回 (目录, 相对模块根的 slash 路径, 是否属于本模块)
+func (s *Server) dirFor(importPath string) (string, string, bool) {
+	if importPath == s.modulePath {
+		return s.root, "", true
+	}
+	rel, ok := strings.CutPrefix(importPath, s.modulePath+"/")
+	if !ok {
+		return "", "", false
+	}
+	rel = path.Clean(rel)
+	if rel == "." || strings.HasPrefix(rel, "..") {
+		return "", "", false
+	}
+	return filepath.Join(s.root, filepath.FromSlash(rel)), rel, true
+}
+
+// importPathFor 将模块内目录映射为 import path
+func (s *Server) importPathFor(dir string) string {
+	rel, err := filepath.Rel(s.root, dir)
+	if err != nil || rel == "." {
+		return s.modulePath
+	}
+	return s.modulePath + "/" + filepath.ToSlash(rel)
+}
+
+// hasGoSources 判断目录下是否包含非测试的 .go 文件
+func hasGoSources(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if !e.IsDir() && strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go") {
+			return true
+		}
+	}
+	return false
+}
+
+func writePage(w http.ResponseWriter, title, body string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+pre { font-family: monospace; line-height: 1.4; }
+pre.source span:target { background: #fff3b0; }
+pre.source a { color: #999; text-decoration: none; }
+</style>
+</head>
+<body>
+<p><a href="/">index</a></p>
+%s</body>
+</html>
+`, html.EscapeString(title), body)
+}
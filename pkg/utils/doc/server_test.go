@@ -0,0 +1,102 @@
+package doc
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// 构造一个最小的临时模块
+func newTestModule(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":           "module example.com/demo\n\ngo 1.22\n",
+		"demo.go":          "// Package demo is a test package.\npackage demo\n\n// Hello returns a greeting.\nfunc Hello() string { return \"hi\" }\n",
+		"sub/sub.go":       "package sub\n\n// Answer is the answer.\nconst Answer = 42\n",
+		"testdata/skip.go": "package skip\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func get(t *testing.T, h http.Handler, url string) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+	b, _ := io.ReadAll(rec.Body)
+	return rec.Code, string(b)
+}
+
+// 测试文档服务的索引、包与源码页面
+func TestServer_Handlers(t *testing.T) {
+	srv, err := NewServer(newTestModule(t), Options{})
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	h := srv.Handler()
+
+	code, body := get(t, h, "/")
+	if code != http.StatusOK || !strings.Contains(body, "example.com/demo/sub") {
+		t.Fatalf("index should list packages, got %d: %s", code, body)
+	}
+	if strings.Contains(body, "testdata") {
+		t.Errorf("index should skip testdata, got: %s", body)
+	}
+
+	code, body = get(t, h, "/pkg/example.com/demo")
+	if code != http.StatusOK || !strings.Contains(body, "Hello") {
+		t.Fatalf("package page should contain Hello, got %d: %s", code, body)
+	}
+	if !strings.Contains(body, `href="/src/demo.go#L5"`) {
+		t.Errorf("package page should link to source line, got: %s", body)
+	}
+
+	code, body = get(t, h, "/src/demo.go")
+	if code != http.StatusOK || !strings.Contains(body, `id="L5"`) {
+		t.Fatalf("source page should have line anchors, got %d: %s", code, body)
+	}
+
+	if code, _ = get(t, h, "/pkg/other.com/x"); code != http.StatusNotFound {
+		t.Errorf("foreign import path should be 404, got %d", code)
+	}
+	// ServeMux 会先清理路径，这里直接调用 handler 验证不会逃逸出模块根目录
+	if code, _ = get(t, http.HandlerFunc(srv.handleSource), "/src/../../etc/passwd.go"); code != http.StatusNotFound {
+		t.Errorf("path traversal should be 404, got %d", code)
+	}
+}
+
+// 测试缓存失效后重新渲染
+func TestServer_Invalidate(t *testing.T) {
+	root := newTestModule(t)
+	srv, err := NewServer(root, Options{})
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	h := srv.Handler()
+	_, _ = get(t, h, "/pkg/example.com/demo/sub")
+
+	src := "package sub\n\n// Question is new.\nconst Question = 1\n"
+	if err := os.WriteFile(filepath.Join(root, "sub", "sub.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, body := get(t, h, "/pkg/example.com/demo/sub"); strings.Contains(body, "Question") {
+		t.Errorf("cached page should not change before Invalidate")
+	}
+	srv.Invalidate()
+	if _, body := get(t, h, "/pkg/example.com/demo/sub"); !strings.Contains(body, "Question") {
+		t.Errorf("page should be re-rendered after Invalidate, got: %s", body)
+	}
+}
@@ -0,0 +1,138 @@
+package doc
+
+import (
+	"cmp"
+	"go/ast"
+	gdoc "go/doc"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// 符号排序方式（Options.Sort）
+const (
+	// SortAlpha 按名称排序（默认），输出与文件的拆分、重命名无关
+	SortAlpha = "alpha"
+	// SortSource 按声明位置（文件名、文件内偏移）排序
+	SortSource = "source"
+)
+
+// SortOrders 列出所有可用的排序方式
+var SortOrders = []string{SortAlpha, SortSource}
+
+// symbolSorter 按 Options.Sort 比较两个符号：alpha 先比较名称，名称相同时比较声明文本；
+// source 比较声明所在的文件名与文件内偏移
+type symbolSorter struct {
+	fset   *token.FileSet
+	source bool
+}
+
+func newSymbolSorter(order string, fset *token.FileSet) symbolSorter {
+	return symbolSorter{fset: fset, source: order == SortSource}
+}
+
+// compare 比较名称分别为 an、bn，声明节点分别为 a、b 的两个符号
+func (s symbolSorter) compare(an string, a ast.Node, bn string, b ast.Node) int {
+	if s.source {
+		if c := s.comparePos(nodePos(a), nodePos(b)); c != 0 {
+			return c
+		}
+		return cmp.Compare(an, bn)
+	}
+	if c := cmp.Compare(an, bn); c != 0 {
+		return c
+	}
+	return cmp.Compare(s.text(a), s.text(b))
+}
+
+// comparePos 按文件名（不含目录）与文件内偏移比较两个位置
+func (s symbolSorter) comparePos(a, b token.Pos) int {
+	if s.fset == nil {
+		return 0
+	}
+	pa, pb := s.fset.Position(a), s.fset.Position(b)
+	if c := cmp.Compare(filepath.Base(pa.Filename), filepath.Base(pb.Filename)); c != 0 {
+		return c
+	}
+	return cmp.Compare(pa.Offset, pb.Offset)
+}
+
+func nodePos(n ast.Node) token.Pos {
+	if n == nil {
+		return token.NoPos
+	}
+	return n.Pos()
+}
+
+func (s symbolSorter) text(n ast.Node) string {
+	if n == nil || s.fset == nil {
+		return ""
+	}
+	return nodeString(s.fset, n)
+}
+
+func (s symbolSorter) values(vs []*gdoc.Value) {
+	slices.SortStableFunc(vs, func(a, b *gdoc.Value) int {
+		return s.compare(firstName(a.Names), a.Decl, firstName(b.Names), b.Decl)
+	})
+}
+
+func (s symbolSorter) funcs(fs []*gdoc.Func) {
+	slices.SortStableFunc(fs, func(a, b *gdoc.Func) int {
+		return s.compare(a.Name, a.Decl, b.Name, b.Decl)
+	})
+	for _, f := range fs {
+		s.examples(f.Examples)
+	}
+}
+
+func (s symbolSorter) examples(exs []*gdoc.Example) {
+	slices.SortStableFunc(exs, func(a, b *gdoc.Example) int {
+		return s.compare(a.Name+"_"+a.Suffix, a.Code, b.Name+"_"+b.Suffix, b.Code)
+	})
+}
+
+// sortPackage 按 order 就地重排 dpkg 中的常量、变量、函数、类型（含其关联的常量、变量、构造函数与方法）、
+// 示例与 notes，使渲染结果不依赖 go/doc 的内部顺序或文件的解析顺序。文件列表始终按名称排序
+func sortPackage(dpkg *gdoc.Package, fset *token.FileSet, order string) {
+	s := newSymbolSorter(order, fset)
+	slices.Sort(dpkg.Filenames)
+	s.values(dpkg.Consts)
+	s.values(dpkg.Vars)
+	s.funcs(dpkg.Funcs)
+	slices.SortStableFunc(dpkg.Types, func(a, b *gdoc.Type) int {
+		return s.compare(a.Name, a.Decl, b.Name, b.Decl)
+	})
+	for _, t := range dpkg.Types {
+		s.values(t.Consts)
+		s.values(t.Vars)
+		s.funcs(t.Funcs)
+		s.funcs(t.Methods)
+		s.examples(t.Examples)
+	}
+	s.examples(dpkg.Examples)
+	for _, notes := range dpkg.Notes {
+		slices.SortStableFunc(notes, func(a, b *gdoc.Note) int {
+			if s.source {
+				return s.comparePos(a.Pos, b.Pos)
+			}
+			return cmp.Or(cmp.Compare(a.UID, b.UID), strings.Compare(a.Body, b.Body))
+		})
+	}
+}
+
+// sortTestFuncs 按 order 排序 tests 模式下收集的测试/benchmark/example 函数
+func sortTestFuncs(fns []*ast.FuncDecl, fset *token.FileSet, order string) {
+	s := newSymbolSorter(order, fset)
+	slices.SortStableFunc(fns, func(a, b *ast.FuncDecl) int {
+		return s.compare(a.Name.Name, a, b.Name.Name, b)
+	})
+}
+
+func firstName(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	return names[0]
+}
@@ -0,0 +1,91 @@
+package doc
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+// renderSortFixture 将 testdata/sortpkg 复制到临时目录（可重命名其中的文件）后渲染，
+// 去掉包含临时目录的文件列表行，使不同目录下的输出可以比较
+func renderSortFixture(t *testing.T, order string, rename map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.CopyFS(dir, os.DirFS(filepath.Join("testdata", "sortpkg"))); err != nil {
+		t.Fatal(err)
+	}
+	for from, to := range rename {
+		if err := os.Rename(filepath.Join(dir, from), filepath.Join(dir, to)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	out, err := GetGoDoc(Options{Style: StylePlain, Mode: ModeGodoc, IncludeExamples: true, Sort: order}, "", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var kept []string
+	for line := range strings.SplitSeq(out, "\n") {
+		if !strings.Contains(line, dir) {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// 测试 alpha 排序的输出与 golden 文件一致，并且重命名文件（改变解析顺序）后输出不变
+// （go test -run TestSortAlphaGolden -update 更新）
+func TestSortAlphaGolden(t *testing.T) {
+	got := renderSortFixture(t, SortAlpha, nil)
+	golden := filepath.Join("testdata", "sortpkg.alpha.golden")
+	if *updateGolden {
+		if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("read golden failed: %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("alpha output differs from %s:\n%s", golden, got)
+	}
+	// m.go 重命名为 0m.go 后先于 a.go 被解析
+	if renamed := renderSortFixture(t, SortAlpha, map[string]string{"m.go": "0m.go"}); renamed != got {
+		t.Errorf("alpha output changed after renaming a file:\n%s", renamed)
+	}
+	// 默认（未指定）即 alpha
+	if def := renderSortFixture(t, "", nil); def != got {
+		t.Errorf("empty sort should default to alpha:\n%s", def)
+	}
+}
+
+// 测试 source 排序按声明位置：同一文件内保持声明顺序，文件按名称先后排列
+func TestSortSource(t *testing.T) {
+	out := renderSortFixture(t, SortSource, nil)
+	order := func(s string, names ...string) {
+		t.Helper()
+		last := -1
+		for _, n := range names {
+			i := strings.Index(s, n)
+			if i < 0 || i < last {
+				t.Errorf("expected %v in order:\n%s", names, s)
+				return
+			}
+			last = i
+		}
+	}
+	order(out, "func Zeta()", "func Alpha()")
+	order(out, "Zed()", "Abc()")
+	order(out, "second note", "first note")
+
+	renamed := renderSortFixture(t, SortSource, map[string]string{"m.go": "0m.go"})
+	order(renamed, "func Alpha()", "func Zeta()")
+
+	if err := (Options{Style: StylePlain, Mode: ModeGodoc, Sort: "random"}).Validate(); err == nil {
+		t.Error("unknown sort order should be rejected")
+	}
+}
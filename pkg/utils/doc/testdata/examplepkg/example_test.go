@@ -0,0 +1,24 @@
+package examplepkg_test
+
+import (
+	"fmt"
+
+	"examplepkg"
+)
+
+// ExampleAdd prints a correct sum.
+func ExampleAdd() {
+	fmt.Println(examplepkg.Add(1, 2))
+	// Output: 3
+}
+
+// ExampleAdd_wrong has an outdated output comment.
+func ExampleAdd_wrong() {
+	fmt.Println(examplepkg.Add(2, 2))
+	// Output: 5
+}
+
+// ExampleAdd_noOutput is compiled but not run.
+func ExampleAdd_noOutput() {
+	_ = examplepkg.Add(0, 0)
+}
@@ -0,0 +1,5 @@
+// Package examplepkg is a fixture for example verification.
+package examplepkg
+
+// Add returns a + b.
+func Add(a, b int) int { return a + b }
@@ -0,0 +1,3 @@
+module examplepkg
+
+go 1.21
@@ -0,0 +1,37 @@
+// Package fieldpkg is a fixture for struct field and interface method tables.
+package fieldpkg
+
+import "io"
+
+// Base is embedded by EnvConfig.
+type Base struct{ ID int }
+
+// EnvConfig mirrors a tagged configuration struct.
+type EnvConfig struct {
+	Base
+
+	// GoRoot is the Go installation root.
+	// It may be empty.
+	GoRoot string `mapstructure:"GOROOT" json:"goroot,omitempty" yaml:"goroot"`
+	GoPath string `mapstructure:"GOPATH" jsonschema:"title=GOPATH,nullable"` // Go 工作空间路径
+
+	Min, Max int // bounds
+
+	// Hotload configures reloading.
+	Hotload struct {
+		// Enabled turns it on.
+		Enabled bool `json:"enabled"`
+		Delay   int  // milliseconds
+	} `json:"hotload"`
+
+	// Timeout bounds each request.
+	Timeout int // seconds
+}
+
+// Loader loads configuration.
+type Loader interface {
+	io.Closer
+
+	// Load reads the config at path.
+	Load(path string) (*EnvConfig, error)
+}
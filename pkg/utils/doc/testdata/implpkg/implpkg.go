@@ -0,0 +1,22 @@
+// Package implpkg is a fixture for interface implementation hints.
+package implpkg
+
+// Shape is implemented by Square and *Circle.
+type Shape interface {
+	Area() float64
+}
+
+// Square has a value receiver.
+type Square struct{ Side float64 }
+
+// Area returns the area of the square.
+func (s Square) Area() float64 { return s.Side * s.Side }
+
+// Circle has a pointer receiver.
+type Circle struct{ R float64 }
+
+// Area returns the area of the circle.
+func (c *Circle) Area() float64 { return 3 * c.R * c.R }
+
+// Label implements nothing.
+type Label string
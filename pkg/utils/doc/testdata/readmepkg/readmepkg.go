@@ -0,0 +1,5 @@
+// Package readmepkg is a fixture for README merging tests.
+package readmepkg
+
+// Hello returns a greeting.
+func Hello() string { return "hello" }
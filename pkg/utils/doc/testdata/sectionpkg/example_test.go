@@ -0,0 +1,13 @@
+package sectionpkg_test
+
+import (
+	"fmt"
+
+	"sectionpkg"
+)
+
+// Example demonstrates Count.
+func Example() {
+	fmt.Println(sectionpkg.Count())
+	// Output: 10
+}
@@ -0,0 +1,19 @@
+// Package sectionpkg is a fixture for section filtering tests.
+package sectionpkg
+
+// MaxItems limits the number of items.
+const MaxItems = 10
+
+// DefaultName is the default item name.
+var DefaultName = "item"
+
+// Item is a named item.
+type Item struct {
+	Name string
+}
+
+// NewItem creates an item.
+func NewItem(name string) *Item { return &Item{Name: name} }
+
+// Count returns the number of items.
+func Count() int { return MaxItems }
@@ -0,0 +1,19 @@
+// Package sortpkg is a fixture for deterministic symbol ordering.
+package sortpkg
+
+// Zeta is declared first in a.go.
+func Zeta() {}
+
+// TODO(bob): second note, declared first.
+
+// Mid is a type with methods.
+type Mid struct{}
+
+// Zed is declared before Abc.
+func (Mid) Zed() {}
+
+// Abc is declared after Zed.
+func (Mid) Abc() {}
+
+// MaxSize is a constant.
+const MaxSize = 10
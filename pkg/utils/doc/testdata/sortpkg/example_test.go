@@ -0,0 +1,9 @@
+package sortpkg
+
+func ExampleZeta() {
+	Zeta()
+}
+
+func ExampleAlpha() {
+	Alpha()
+}
@@ -0,0 +1,15 @@
+package sortpkg
+
+// Alpha is declared in m.go.
+func Alpha() {}
+
+// TODO(alice): first note, declared last.
+
+// Beta is a named int.
+type Beta int
+
+// MinSize is a constant from m.go.
+const MinSize = 1
+
+// Verbose is a variable.
+var Verbose = false
@@ -0,0 +1,196 @@
+package doc
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// 接口实现的查找范围（Options.Implementers）
+const (
+	// ImplementersPackage 只在当前包内查找，等价于 TypeInfo
+	ImplementersPackage = "package"
+	// ImplementersModule 在当前模块的所有包中查找，其他包的类型写作 pkg.T
+	ImplementersModule = "module"
+)
+
+// ImplementersScopes 列出所有可用的查找范围
+var ImplementersScopes = []string{ImplementersPackage, ImplementersModule}
+
+// typeRelations 记录包内类型与接口之间的实现关系（类型名 -> 名称列表）
+type typeRelations struct {
+	Implements    map[string][]string // 具体类型实现的接口
+	ImplementedBy map[string][]string // 接口的实现类型，仅指针接收者实现时写作 *T
+}
+
+// loadTypeRelations 使用 go/packages 对 dir 下的包做类型检查，计算包内接口与具体类型的实现关系
+//   - 只考虑同一包中声明的类型；未开启 IncludePrivate 时只考虑导出类型
+//   - 按 opts.Tags 的构建配置加载
+//   - 空接口和泛型类型/接口不参与计算
+//   - 类型检查失败（例如依赖缺失）时返回错误，调用方应忽略实现提示而不是让文档生成失败
+func loadTypeRelations(dir string, opts Options) (*typeRelations, error) {
+	// 依赖也从源码做类型检查而不是读取编译器导出数据，避免导出数据格式与 x/tools 版本不匹配
+	cfg := packagesConfig(packages.NeedName|packages.NeedTypes|packages.NeedSyntax|packages.NeedImports|packages.NeedDeps, dir, opts)
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) == 0 || pkgs[0].Types == nil {
+		return nil, fmt.Errorf("no package loaded from %s", dir)
+	}
+	if len(pkgs[0].Errors) > 0 {
+		return nil, fmt.Errorf("type check %s failed: %v", dir, pkgs[0].Errors[0])
+	}
+	return computeTypeRelations(pkgs[0].Types, opts.IncludePrivate), nil
+}
+
+// loadModuleTypeRelations 与 loadTypeRelations 相同，但会加载 dir 所在模块的全部包（./...），
+// 额外记录其他包中实现了本包接口的导出类型，以及本包类型实现的其他包中的导出接口（写作 pkg.T）。
+// 其他包类型检查失败时只跳过该包，本包失败时返回错误
+func loadModuleTypeRelations(dir string, opts Options) (*typeRelations, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	root := findModuleRoot(abs)
+	if root == "" {
+		return nil, fmt.Errorf("no go.mod found above %s", dir)
+	}
+	cfg := packagesConfig(packages.NeedName|packages.NeedFiles|packages.NeedTypes|packages.NeedSyntax|packages.NeedImports|packages.NeedDeps, root, opts)
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		return nil, err
+	}
+	var target *packages.Package
+	var others []*types.Package
+	for _, p := range pkgs {
+		if len(p.GoFiles) > 0 && filepath.Dir(p.GoFiles[0]) == abs {
+			target = p
+			continue
+		}
+		if p.Types != nil && len(p.Errors) == 0 {
+			others = append(others, p.Types)
+		}
+	}
+	if target == nil || target.Types == nil {
+		return nil, fmt.Errorf("no package loaded from %s", dir)
+	}
+	if len(target.Errors) > 0 {
+		return nil, fmt.Errorf("type check %s failed: %v", dir, target.Errors[0])
+	}
+	return computeScopedRelations(target.Types, others, opts.IncludePrivate), nil
+}
+
+// packagesConfig 返回 go/packages 的加载配置，GOOS/GOARCH 与构建标签取自 opts.Tags
+func packagesConfig(mode packages.LoadMode, dir string, opts Options) *packages.Config {
+	cfg := &packages.Config{Mode: mode, Dir: dir}
+	env, flags := opts.GoBuildArgs()
+	if len(env) > 0 {
+		cfg.Env = append(os.Environ(), env...)
+	}
+	cfg.BuildFlags = flags
+	return cfg
+}
+
+// findModuleRoot 自 dir 向上查找包含 go.mod 的目录，未找到时返回空字符串
+func findModuleRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func computeTypeRelations(pkg *types.Package, includePrivate bool) *typeRelations {
+	return computeScopedRelations(pkg, nil, includePrivate)
+}
+
+// computeScopedRelations 计算 pkg 内的实现关系，并加入 pkg 与 others 之间的关系；
+// 只记录 pkg 一侧的条目，others 中的类型只考虑导出类型
+func computeScopedRelations(pkg *types.Package, others []*types.Package, includePrivate bool) *typeRelations {
+	rel := &typeRelations{Implements: map[string][]string{}, ImplementedBy: map[string][]string{}}
+	ifaces, concretes := splitNamedTypes(pkg, includePrivate)
+	var extIfaces, extConcretes []*types.TypeName
+	for _, o := range others {
+		i, c := splitNamedTypes(o, false)
+		extIfaces = append(extIfaces, i...)
+		extConcretes = append(extConcretes, c...)
+	}
+
+	record := func(it, ct *types.TypeName) {
+		iface := it.Type().Underlying().(*types.Interface)
+		ptr := ""
+		if !types.Implements(ct.Type(), iface) {
+			if !types.Implements(types.NewPointer(ct.Type()), iface) {
+				return
+			}
+			ptr = "*"
+		}
+		if ct.Pkg() == pkg {
+			rel.Implements[ct.Name()] = append(rel.Implements[ct.Name()], qualifiedTypeName(it, pkg))
+		}
+		if it.Pkg() == pkg {
+			rel.ImplementedBy[it.Name()] = append(rel.ImplementedBy[it.Name()], ptr+qualifiedTypeName(ct, pkg))
+		}
+	}
+	for _, it := range ifaces {
+		for _, ct := range concretes {
+			record(it, ct)
+		}
+		for _, ct := range extConcretes {
+			record(it, ct)
+		}
+	}
+	for _, it := range extIfaces {
+		for _, ct := range concretes {
+			record(it, ct)
+		}
+	}
+	for _, m := range []map[string][]string{rel.Implements, rel.ImplementedBy} {
+		for _, v := range m {
+			sort.Strings(v)
+		}
+	}
+	return rel
+}
+
+// qualifiedTypeName 返回类型名，不属于 pkg 的类型加上包名前缀
+func qualifiedTypeName(tn *types.TypeName, pkg *types.Package) string {
+	if tn.Pkg() == pkg || tn.Pkg() == nil {
+		return tn.Name()
+	}
+	return tn.Pkg().Name() + "." + tn.Name()
+}
+
+// splitNamedTypes 把 pkg 中声明的具名类型分为非空接口与具体类型，跳过别名与泛型类型
+func splitNamedTypes(pkg *types.Package, includePrivate bool) (ifaces, concretes []*types.TypeName) {
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || tn.IsAlias() || (!includePrivate && !token.IsExported(name)) {
+			continue
+		}
+		named, ok := tn.Type().(*types.Named)
+		if !ok || named.TypeParams().Len() > 0 {
+			continue
+		}
+		if iface, ok := named.Underlying().(*types.Interface); ok {
+			if iface.NumMethods() > 0 {
+				ifaces = append(ifaces, tn)
+			}
+			continue
+		}
+		concretes = append(concretes, tn)
+	}
+	return ifaces, concretes
+}
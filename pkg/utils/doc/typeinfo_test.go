@@ -0,0 +1,109 @@
+package doc
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const implFixture = "testdata/implpkg"
+
+// 测试 --type-info：值接收者与指针接收者的实现都会标注，未实现接口的类型不输出提示
+func TestTypeInfoRelations(t *testing.T) {
+	out, err := GetGoDoc(Options{Style: StylePlain, Mode: ModeGodoc, Detailed: true, TypeInfo: true}, "", implFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"implemented by: *Circle, Square", "implements: Shape"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, "implements: Shape"); n != 2 {
+		t.Errorf("expected Square and Circle to implement Shape, got %d hints:\n%s", n, out)
+	}
+
+	// 未开启 TypeInfo 时不做类型检查
+	out, err = GetGoDoc(Options{Style: StylePlain, Mode: ModeGodoc, Detailed: true}, "", implFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, "implements:") || strings.Contains(out, "implemented by:") {
+		t.Errorf("unexpected implementation hints without TypeInfo:\n%s", out)
+	}
+}
+
+// 测试类型检查失败（依赖缺失）时返回错误，GetGoDoc 仍正常输出文档
+func TestTypeInfoDegrades(t *testing.T) {
+	dir := t.TempDir()
+	src := "package broken\n\nimport \"example.invalid/missing\"\n\n// T wraps a missing type.\ntype T struct{ missing.X }\n"
+	if err := os.WriteFile(filepath.Join(dir, "broken.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadTypeRelations(dir, Options{}); err == nil {
+		t.Fatal("expected type check error")
+	}
+	out, err := GetGoDoc(Options{Style: StylePlain, Mode: ModeGodoc, Detailed: true, TypeInfo: true}, "", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "T wraps a missing type.") {
+		t.Errorf("docs missing after failed type check:\n%s", out)
+	}
+}
+
+// 测试 --implementers=module：其他包中实现本包接口的类型、本包类型实现的其他包接口都以 pkg.T 列出
+func TestImplementersModule(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not available")
+	}
+	t.Setenv("GOFLAGS", "")
+	t.Setenv("GOWORK", "off")
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":         "module example.com/m\n\ngo 1.21\n",
+		"api/api.go":     "// Package api declares interfaces.\npackage api\n\n// Store persists values.\ntype Store interface{ Put(string) }\n\n// Closer closes.\ntype Closer interface{ Close() error }\n\n// Mem is an in-package Store.\ntype Mem struct{}\n\n// Put stores v.\nfunc (Mem) Put(string) {}\n",
+		"disk/disk.go":   "package disk\n\n// File is a Store backed by a file.\ntype File struct{}\n\n// Put stores v.\nfunc (*File) Put(string) {}\n\n// Close closes the file.\nfunc (*File) Close() error { return nil }\n",
+		"util/closer.go": "package util\n\n// Flusher flushes.\ntype Flusher interface{ Close() error }\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	opts := Options{Style: StylePlain, Mode: ModeGodoc, Detailed: true, Implementers: ImplementersModule}
+	out, err := GetGoDoc(opts, "", filepath.Join(root, "api"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "implemented by: *disk.File, Mem") {
+		t.Errorf("missing module-wide implementations in:\n%s", out)
+	}
+	out, err = GetGoDoc(opts, "", filepath.Join(root, "disk"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "implements: api.Closer, api.Store, util.Flusher") {
+		t.Errorf("missing interfaces from other packages in:\n%s", out)
+	}
+
+	// package 范围只看当前包
+	opts.Implementers = ImplementersPackage
+	out, err = GetGoDoc(opts, "", filepath.Join(root, "api"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, "disk.File") || !strings.Contains(out, "implemented by: Mem") {
+		t.Errorf("package scope should only relate in-package types:\n%s", out)
+	}
+	if err := (Options{Style: StylePlain, Mode: ModeGodoc, Implementers: "repo"}).Validate(); err == nil {
+		t.Error("unknown implementers scope should be rejected")
+	}
+}
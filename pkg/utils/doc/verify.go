@@ -0,0 +1,136 @@
+package doc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	gdoc "go/doc"
+	"sort"
+	"strings"
+
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// 示例校验结果状态
+const (
+	ExamplePass   = "PASS"
+	ExampleFail   = "FAIL"
+	ExampleNotRun = "NOT RUN" // 没有 // Output: 注释的示例只编译不运行
+)
+
+// ExampleResult 记录一个可测试示例的运行结果
+type ExampleResult struct {
+	Status string
+	// Output 失败时 go test 打印的内容（got/want 或构建错误）
+	Output []string
+}
+
+// exampleTestName 返回示例对应的测试函数名，go/doc 的 Example.Name 去掉了 "Example" 前缀
+func exampleTestName(ex *gdoc.Example) string {
+	return "Example" + ex.Name
+}
+
+// verifyExamples 在 dir 中运行 go test -run '^Example' 并按测试函数名返回各示例的结果
+// 包无法编译时所有带 Output 的示例都标记为失败，Output 为构建错误
+func verifyExamples(dir string, examples []*gdoc.Example) (map[string]ExampleResult, error) {
+	stdout, stderr, err := executor.NewExecutor("go", "test", "-json", "-count=1", "-run", "^Example", ".").WithDir(dir).Run()
+	results, buildOutput := parseExampleEvents(stdout)
+
+	var execErr *executor.ExecError
+	if err != nil && !errors.As(err, &execErr) {
+		return nil, err
+	}
+	if len(results) == 0 && err != nil {
+		// 没有任何示例事件：通常是构建失败（新版本 go 以 build-output 事件输出，旧版本写到 stderr）
+		lines := buildOutput
+		if msg := strings.TrimSpace(stderr); msg != "" {
+			lines = append(lines, strings.Split(msg, "\n")...)
+		}
+		if len(lines) == 0 {
+			return nil, err
+		}
+		for _, ex := range examples {
+			if hasOutput(ex) {
+				results[exampleTestName(ex)] = ExampleResult{Status: ExampleFail, Output: lines}
+			}
+		}
+	}
+	for _, ex := range examples {
+		name := exampleTestName(ex)
+		if _, ok := results[name]; !ok && !hasOutput(ex) {
+			results[name] = ExampleResult{Status: ExampleNotRun}
+		}
+	}
+	return results, nil
+}
+
+// parseExampleEvents 解析 go test -json 的输出，只保留 Example 开头的测试，同时返回构建输出
+func parseExampleEvents(stdout string) (map[string]ExampleResult, []string) {
+	results := map[string]ExampleResult{}
+	var buildOutput []string
+	for _, line := range strings.Split(stdout, "\n") {
+		var ev struct {
+			Action string
+			Test   string
+			Output string
+		}
+		if !strings.HasPrefix(line, "{") || json.Unmarshal([]byte(line), &ev) != nil {
+			continue
+		}
+		if ev.Action == "build-output" {
+			buildOutput = append(buildOutput, strings.TrimRight(ev.Output, "\n"))
+			continue
+		}
+		if !strings.HasPrefix(ev.Test, "Example") {
+			continue
+		}
+		r := results[ev.Test]
+		switch ev.Action {
+		case "output":
+			out := strings.TrimRight(ev.Output, "\n")
+			if !strings.HasPrefix(out, "=== ") && !strings.HasPrefix(out, "--- ") && strings.TrimSpace(out) != "" {
+				r.Output = append(r.Output, out)
+			}
+		case "pass":
+			r.Status, r.Output = ExamplePass, nil
+		case "fail":
+			r.Status = ExampleFail
+		default:
+			continue
+		}
+		results[ev.Test] = r
+	}
+	return results, buildOutput
+}
+
+// hasOutput 报告示例是否带有 // Output: 注释（只有这类示例会被 go test 运行）
+func hasOutput(ex *gdoc.Example) bool {
+	return ex.Output != "" || ex.EmptyOutput
+}
+
+// exampleStatus 返回渲染时附加在示例后面的标记，未开启校验时为空
+func exampleStatus(opts Options, ex *gdoc.Example) (ExampleResult, string) {
+	if opts.exampleResults == nil {
+		return ExampleResult{}, ""
+	}
+	r, ok := opts.exampleResults[exampleTestName(ex)]
+	if !ok || r.Status == "" {
+		return r, ""
+	}
+	return r, fmt.Sprintf(" [%s]", r.Status)
+}
+
+// logExampleFailures 汇总失败的示例，便于在 CI 中发现问题
+func logExampleFailures(dir string, results map[string]ExampleResult) {
+	var failed []string
+	for name, r := range results {
+		if r.Status == ExampleFail {
+			failed = append(failed, name)
+		}
+	}
+	if len(failed) == 0 {
+		return
+	}
+	sort.Strings(failed)
+	log.Warn().Str("dir", dir).Strs("examples", failed).Msgf("%d example(s) failed verification", len(failed))
+}
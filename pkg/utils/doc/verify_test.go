@@ -0,0 +1,24 @@
+package doc
+
+import (
+	"strings"
+	"testing"
+)
+
+// 测试 --verify-examples：运行示例并在输出中标注 PASS/FAIL/NOT RUN
+func TestGetGoDoc_VerifyExamples(t *testing.T) {
+	out, err := GetGoDoc(Options{Style: StylePlain, Mode: ModeGodoc, VerifyExamples: true, Detailed: true}, "", "testdata/examplepkg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"Example Add: [PASS]",
+		"Example Add_wrong: [FAIL]",
+		"Example Add_noOutput: [NOT RUN]",
+		"// Verify failed:",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
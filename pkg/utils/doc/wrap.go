@@ -0,0 +1,208 @@
+package doc
+
+import (
+	"os"
+	"strings"
+	"unicode"
+
+	runewidth "github.com/mattn/go-runewidth"
+	"github.com/yeisme/gocli/pkg/style"
+)
+
+const (
+	// wrapMargin 自动探测终端宽度时预留的右边距
+	wrapMargin = 2
+	// minWrapWidth 文本折行的最小宽度，过窄时不再缩小
+	minWrapWidth = 20
+	// DefaultWrapWidth 未指定宽度且输出不是终端（-o 文件、管道）时的折行宽度
+	DefaultWrapWidth = 80
+)
+
+// wrapWidth 返回段落折行宽度：优先使用 opts.Width，stdout 为终端时取终端宽度减去边距，
+// 否则使用 DefaultWrapWidth，使写入文件的结果不随生成它的终端变化
+func wrapWidth(opts Options) int {
+	if opts.Width > 0 {
+		return opts.Width
+	}
+	if style.IsTerminal(os.Stdout) {
+		if w := style.TerminalWidth(os.Stdout); w > 0 {
+			return max(w-wrapMargin, minWrapWidth)
+		}
+	}
+	return DefaultWrapWidth
+}
+
+// docBlock 将文档注释按 width 折行并为每行加上 prefix，width<=0 时只缩进
+func docBlock(text, prefix string, width int) string {
+	text = strings.TrimSpace(text)
+	if width > 0 {
+		text = wrapText(text, max(width-runewidth.StringWidth(prefix), minWrapWidth))
+	}
+	return indentLines(text, prefix)
+}
+
+// FormatDocText 把任意文档注释文本（例如从标准输入读取的片段）按 opts 的宽度重排；
+// 每个非空行都以 // 开头时先去掉注释标记
+func FormatDocText(text string, opts Options) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	comment := true
+	for _, l := range lines {
+		if t := strings.TrimSpace(l); t != "" && !strings.HasPrefix(t, "//") {
+			comment = false
+			break
+		}
+	}
+	if comment {
+		for i, l := range lines {
+			l = strings.TrimPrefix(strings.TrimSpace(l), "//")
+			lines[i] = strings.TrimPrefix(l, " ")
+		}
+	}
+	return docBlock(strings.Join(lines, "\n"), "", wrapWidth(opts)) + "\n"
+}
+
+// wrapText 按显示宽度重排 go/doc 文本中的段落：
+//   - 连续的非缩进行视为同一段落，合并后按单词重新折行
+//   - 以空白缩进的行（代码块、列表）与空行原样保留
+//   - 宽度按 rune 的显示宽度计算；超长单词（如没有空格的中日韩文本）按 rune 断开
+func wrapText(text string, width int) string {
+	if width <= 0 || text == "" {
+		return text
+	}
+	var out, para []string
+	flush := func() {
+		if len(para) > 0 {
+			out = append(out, wrapParagraph(strings.Join(para, " "), width)...)
+			para = nil
+		}
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" || line[0] == ' ' || line[0] == '\t' {
+			flush()
+			out = append(out, line)
+			continue
+		}
+		para = append(para, strings.TrimSpace(line))
+	}
+	flush()
+	return strings.Join(out, "\n")
+}
+
+// wrapParagraph 将单个段落折成不超过 width 显示宽度的若干行
+func wrapParagraph(s string, width int) []string {
+	var lines []string
+	var cur strings.Builder
+	curWidth := 0
+	newline := func() {
+		lines = append(lines, cur.String())
+		cur.Reset()
+		curWidth = 0
+	}
+	for _, word := range strings.Fields(s) {
+		ww := runewidth.StringWidth(word)
+		sep := 0
+		if curWidth > 0 {
+			sep = 1
+		}
+		if curWidth+sep+ww <= width {
+			if sep == 1 {
+				cur.WriteByte(' ')
+			}
+			cur.WriteString(word)
+			curWidth += sep + ww
+			continue
+		}
+		if ww <= width && !hasWideRune(word) {
+			newline()
+			cur.WriteString(word)
+			curWidth = ww
+			continue
+		}
+		// 超长单词或宽字符文本：先填满当前行剩余空间，再逐 rune 断行
+		if curWidth > 0 && curWidth+1 < width {
+			cur.WriteByte(' ')
+			curWidth++
+		} else if curWidth > 0 {
+			newline()
+		}
+		for _, r := range word {
+			rw := runewidth.RuneWidth(r)
+			if curWidth+rw > width {
+				newline()
+			}
+			cur.WriteRune(r)
+			curWidth += rw
+		}
+	}
+	if cur.Len() > 0 {
+		lines = append(lines, cur.String())
+	}
+	return lines
+}
+
+// hasWideRune 报告字符串中是否包含可在任意位置断行的宽字符（中日韩等）
+func hasWideRune(s string) bool {
+	for _, r := range s {
+		if runewidth.RuneWidth(r) > 1 || unicode.Is(unicode.Han, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapSummary 拼接 "head —> summary" 形式的摘要行：整行超出 width 时摘要按单词折行，
+// 续行比 head 的缩进多 4 列；head（签名）本身不折断，多行 head 按最后一行计算宽度；
+// width<=0 或 summary 为空时原样拼接
+func wrapSummary(head, summary string, width int) string {
+	if summary == "" {
+		return head
+	}
+	// 多行签名只有最后一行与摘要相接
+	last := head[strings.LastIndex(head, "\n")+1:]
+	if width <= 0 || runewidth.StringWidth(last+" —> "+summary) <= width {
+		return head + " —> " + summary
+	}
+	indent := head[:len(head)-len(strings.TrimLeft(head, " \t"))] + "    "
+	words := strings.Fields(summary)
+	var b strings.Builder
+	b.WriteString(head + " —>")
+	cur := runewidth.StringWidth(last) + 3
+	i := 0
+	// 首行至少放一个单词，保证 " —> " 与摘要在同一行
+	for ; i < len(words); i++ {
+		ww := runewidth.StringWidth(words[i])
+		if cur+1+ww > width && hasWideRune(words[i]) {
+			// 宽字符文本按 rune 填满首行剩余空间，其余部分留给续行
+			head, tail := splitWidth(words[i], width-cur-1)
+			if head != "" {
+				b.WriteString(" " + head)
+				words[i] = tail
+			}
+			break
+		}
+		if i > 0 && cur+1+ww > width {
+			break
+		}
+		b.WriteString(" " + words[i])
+		cur += 1 + ww
+	}
+	if i < len(words) {
+		rest := wrapParagraph(strings.Join(words[i:], " "), max(width-runewidth.StringWidth(indent), minWrapWidth))
+		for _, l := range rest {
+			b.WriteString("\n" + indent + l)
+		}
+	}
+	return b.String()
+}
+
+// splitWidth 在不超过 width 显示宽度的最后一个 rune 处切分 s
+func splitWidth(s string, width int) (head, tail string) {
+	w := 0
+	for i, r := range s {
+		w += runewidth.RuneWidth(r)
+		if w > width {
+			return s[:i], s[i:]
+		}
+	}
+	return s, ""
+}
@@ -0,0 +1,91 @@
+package doc
+
+import (
+	"strings"
+	"testing"
+
+	runewidth "github.com/mattn/go-runewidth"
+)
+
+// 测试段落按宽度折行，代码块保持原样，中日韩文本按 rune 宽度断行
+func TestWrapText(t *testing.T) {
+	code := "\tresult := somePackage.SomeVeryLongFunctionName(argumentNumberOne, argumentNumberTwo)"
+	text := strings.Join([]string{
+		"Package foo provides a deliberately long paragraph that must be wrapped",
+		"across several lines when it is rendered in a narrow terminal window.",
+		"",
+		code,
+		"",
+		"这是一段没有空格的中文文档注释，用于验证折行时按照字符显示宽度而不是字节数计算，避免超出终端宽度。",
+	}, "\n")
+
+	const width = 40
+	out := wrapText(text, width)
+	sawCode := false
+	for _, line := range strings.Split(out, "\n") {
+		if line == code {
+			sawCode = true
+			continue
+		}
+		if w := runewidth.StringWidth(line); w > width {
+			t.Errorf("line exceeds %d columns (%d): %q", width, w, line)
+		}
+	}
+	if !sawCode {
+		t.Errorf("code block line should be kept unwrapped:\n%s", out)
+	}
+	if runewidth.StringWidth(code) <= width {
+		t.Fatalf("fixture code line should be wider than %d", width)
+	}
+	if !strings.Contains(strings.ReplaceAll(out, "\n", " "), "must be wrapped across several lines") {
+		t.Errorf("paragraph words should be preserved in order:\n%s", out)
+	}
+}
+
+// 测试摘要行折行：签名不折断，箭头后至少保留一个单词，续行多缩进 4 列且不超过宽度
+func TestWrapSummary(t *testing.T) {
+	const width = 40
+	if got := wrapSummary("    Foo", "short summary", width); got != "    Foo —> short summary" {
+		t.Errorf("short line should stay on one line: %q", got)
+	}
+	if got := wrapSummary("    Foo", "", width); got != "    Foo" {
+		t.Errorf("empty summary: %q", got)
+	}
+
+	head := "    func Run(ctx context.Context) error"
+	out := wrapSummary(head, "Run starts the service and blocks until the context is cancelled.", width)
+	lines := strings.Split(out, "\n")
+	if len(lines) < 2 || !strings.HasPrefix(lines[0], head+" —> Run") {
+		t.Fatalf("unexpected first line:\n%s", out)
+	}
+	for _, l := range lines[1:] {
+		if !strings.HasPrefix(l, "        ") || runewidth.StringWidth(l) > width {
+			t.Errorf("bad continuation line %q", l)
+		}
+	}
+	if strings.Join(strings.Fields(out), " ") != strings.TrimSpace(head)+" —> Run starts the service and blocks until the context is cancelled." {
+		t.Errorf("words should be preserved in order:\n%s", out)
+	}
+
+	// 中文摘要按 rune 填满首行剩余空间
+	out = wrapSummary("    Foo", "Foo 返回一个非常非常长的没有空格的中文摘要用于验证折行", 30)
+	for _, l := range strings.Split(out, "\n") {
+		if runewidth.StringWidth(l) > 30 {
+			t.Errorf("line exceeds 30 columns: %q", l)
+		}
+	}
+	if first := strings.Split(out, "\n")[0]; runewidth.StringWidth(first) < 29 {
+		t.Errorf("first line should be filled: %q", first)
+	}
+}
+
+// 测试折行宽度：显式宽度优先，stdout 不是终端（go test 下）时使用默认宽度而不是 $COLUMNS
+func TestWrapWidth(t *testing.T) {
+	t.Setenv("COLUMNS", "200")
+	if got := wrapWidth(Options{Width: 60}); got != 60 {
+		t.Errorf("wrapWidth(Width=60) = %d, want 60", got)
+	}
+	if got := wrapWidth(Options{}); got != DefaultWrapWidth {
+		t.Errorf("wrapWidth() = %d, want %d", got, DefaultWrapWidth)
+	}
+}
@@ -0,0 +1,138 @@
+// Package dotenv 提供简单的 .env 文件解析，用于给子进程注入环境变量.
+//
+// 支持的语法：
+//   - KEY=VALUE，键和值两侧的空白会被去除
+//   - 以 # 开头的注释行与空行；未加引号的值中 " #" 之后视为行内注释
+//   - 可选的 export 前缀（export KEY=VALUE）
+//   - 双引号值（支持 \n \r \t \" \\ 转义）与单引号值（原样保留）
+//   - CRLF 换行
+//
+// 暂不支持 ${VAR} 插值.
+package dotenv
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Parse 从 r 中解析 .env 内容，返回 KEY -> VALUE 映射（同一键后出现的覆盖先出现的）
+func Parse(r io.Reader) (map[string]string, error) {
+	env := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(strings.TrimSuffix(scanner.Text(), "\r"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+
+		key, raw, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("line %d: invalid entry %q, expected KEY=VALUE", lineNo, line)
+		}
+		value, err := parseValue(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		env[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// parseValue 解析等号右侧的值
+func parseValue(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+	switch raw[0] {
+	case '\'':
+		end := strings.IndexByte(raw[1:], '\'')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated single-quoted value")
+		}
+		return raw[1 : end+1], nil
+	case '"':
+		var b strings.Builder
+		for i := 1; i < len(raw); i++ {
+			c := raw[i]
+			switch {
+			case c == '"':
+				return b.String(), nil
+			case c == '\\' && i+1 < len(raw):
+				i++
+				switch raw[i] {
+				case 'n':
+					b.WriteByte('\n')
+				case 'r':
+					b.WriteByte('\r')
+				case 't':
+					b.WriteByte('\t')
+				default:
+					b.WriteByte(raw[i])
+				}
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return "", fmt.Errorf("unterminated double-quoted value")
+	}
+	// 未加引号：" #" 之后为行内注释
+	if idx := strings.Index(raw, " #"); idx >= 0 {
+		raw = raw[:idx]
+	}
+	return strings.TrimSpace(raw), nil
+}
+
+// ParseFile 解析单个 .env 文件
+func ParseFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	env, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s failed: %w", path, err)
+	}
+	return env, nil
+}
+
+// Load 依次解析多个 .env 文件并合并，后面的文件覆盖前面的同名键
+func Load(paths ...string) (map[string]string, error) {
+	merged := make(map[string]string)
+	for _, p := range paths {
+		env, err := ParseFile(p)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range env {
+			merged[k] = v
+		}
+	}
+	return merged, nil
+}
+
+// ToEnviron 将映射转换为按键排序的 KEY=VALUE 列表，可直接追加到 exec.Cmd.Env
+func ToEnviron(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := make([]string, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, k+"="+env[k])
+	}
+	return out
+}
@@ -0,0 +1,104 @@
+package dotenv
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/yeisme/gocli/pkg/utils/executor"
+)
+
+// 测试各种语法边界
+func TestParse(t *testing.T) {
+	input := "# comment\r\n" +
+		"\r\n" +
+		"PLAIN=value\r\n" +
+		"export EXPORTED=yes\r\n" +
+		"SPACED = padded value \r\n" +
+		"DSN=\"user=app password=a=b\"\r\n" +
+		"SINGLE='keep $HOME \\n raw'\r\n" +
+		"ESCAPED=\"line1\\nline2 \\\"q\\\"\"\r\n" +
+		"INLINE=abc # trailing comment\r\n" +
+		"HASH=abc#def\r\n" +
+		"EMPTY=\r\n"
+
+	env, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	want := map[string]string{
+		"PLAIN":    "value",
+		"EXPORTED": "yes",
+		"SPACED":   "padded value",
+		"DSN":      "user=app password=a=b",
+		"SINGLE":   "keep $HOME \\n raw",
+		"ESCAPED":  "line1\nline2 \"q\"",
+		"INLINE":   "abc",
+		"HASH":     "abc#def",
+		"EMPTY":    "",
+	}
+	if len(env) != len(want) {
+		t.Errorf("expected %d entries, got %d: %v", len(want), len(env), env)
+	}
+	for k, v := range want {
+		if env[k] != v {
+			t.Errorf("%s: expected %q, got %q", k, v, env[k])
+		}
+	}
+}
+
+// 测试非法行会报告行号
+func TestParse_Invalid(t *testing.T) {
+	for _, input := range []string{"A=1\nNOEQUALS\n", "A=\"unterminated\n", "BAD KEY=1\n"} {
+		if _, err := Parse(strings.NewReader(input)); err == nil {
+			t.Errorf("expected error for %q", input)
+		}
+	}
+	_, err := Parse(strings.NewReader("A=1\nNOEQUALS\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("error should mention line 2, got: %v", err)
+	}
+}
+
+// 测试多个文件的覆盖顺序以及对现有环境变量的覆盖
+func TestLoad_Precedence(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, ".env")
+	local := filepath.Join(dir, ".env.local")
+	if err := os.WriteFile(base, []byte("A=base\nB=base\nDOTENV_TEST=base\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(local, []byte("B=local\nDOTENV_TEST=local\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := Load(base, local)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if env["A"] != "base" || env["B"] != "local" {
+		t.Errorf("later files should override earlier ones, got: %v", env)
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.env")); err == nil {
+		t.Errorf("Load should fail for a missing file")
+	}
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	// 文件中的值覆盖当前进程已有的环境变量，但只作用于子进程
+	t.Setenv("DOTENV_TEST", "shell")
+	out, err := executor.NewExecutor("sh", "-c", `printf %s "$DOTENV_TEST"`).WithEnv(ToEnviron(env)...).Output()
+	if err != nil {
+		t.Fatalf("run sh failed: %v", err)
+	}
+	if out != "local" {
+		t.Errorf("child process should see value from file, got %q", out)
+	}
+	if os.Getenv("DOTENV_TEST") != "shell" {
+		t.Errorf("current process env must not be mutated")
+	}
+}
@@ -3,13 +3,55 @@ package executor
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
+// ErrTimeout 表示命令因超过 WithTimeout 设置的时限（或 context 截止时间）而被终止
+var ErrTimeout = errors.New("command timed out")
+
+// waitDelay 命令被终止后等待其 I/O 管道关闭的最长时间，避免子进程持有管道导致 Wait 卡住
+const waitDelay = 5 * time.Second
+
+// baseContext 保存 SetBaseContext 设置的 context；用结构体包装，使 atomic.Value 中始终是同一具体类型
+var baseContext atomic.Value // baseCtx
+
+type baseCtx struct{ ctx context.Context }
+
+// SetBaseContext 设置未通过 WithContext/WithTimeout 指定 context 的命令共用的 context
+// （gocli 在收到 Ctrl+C / SIGTERM 时取消它）：取消后正在运行的子进程先收到中断信号，
+// 在 waitDelay 内仍未退出时被强制终止。nil 表示不绑定
+func SetBaseContext(ctx context.Context) {
+	baseContext.Store(baseCtx{ctx: ctx})
+}
+
+// BaseContext 返回 SetBaseContext 设置的 context，未设置时返回 context.Background()
+func BaseContext() context.Context {
+	if b, _ := baseContext.Load().(baseCtx); b.ctx != nil {
+		return b.ctx
+	}
+	return context.Background()
+}
+
+// Canceled 在基础 context 已被取消时返回取消的原因（context.Cause），否则返回 nil；
+// 批量操作在处理下一项之前调用，以便及早停止而不是逐项失败
+func Canceled() error {
+	ctx := BaseContext()
+	if ctx.Err() == nil {
+		return nil
+	}
+	return context.Cause(ctx)
+}
+
 // ExecError 是一个结构化的命令执行错误，包含了丰富的上下文信息
 type ExecError struct {
 	Cmd    string   // 执行的命令
@@ -75,8 +117,17 @@ func (e *ExecError) ExitCode() int {
 // Executor 是一个命令执行器的构建器
 // 它采用链式调用来配置命令，最终通过 Run, Output 等方法执行
 // 一个 Executor 实例应该用于一次命令执行
+// 处于录制模式（StartRecording）时，执行方法只记录命令而不真正执行
 type Executor struct {
-	cmd *exec.Cmd
+	cmd     *exec.Cmd
+	ctx     context.Context
+	timeout time.Duration
+
+	stopSignal os.Signal     // context 取消时先发送的信号（WithStopSignal），为 nil 时直接终止进程
+	onStart    func(pid int) // 进程启动后回调其 PID（WithOnStart），仅 RunStreaming/RunLines 使用
+
+	extraEnv []string // 通过 WithEnv 附加的环境变量（用于录制）
+	readOnly bool     // 只读查询，录制模式下仍然执行
 }
 
 // NewExecutor 创建一个新的命令执行器
@@ -110,21 +161,119 @@ func (e *Executor) WithStderr(w io.Writer) *Executor {
 	return e
 }
 
+// WithContext 设置命令的 context，context 取消或到期时命令会被终止
+func (e *Executor) WithContext(ctx context.Context) *Executor {
+	e.ctx = ctx
+	return e
+}
+
+// WithTimeout 设置命令的最长执行时间，超时后命令会被终止并返回包装了 ErrTimeout 的错误
+// d <= 0 表示不限制
+func (e *Executor) WithTimeout(d time.Duration) *Executor {
+	e.timeout = d
+	return e
+}
+
+// WithStopSignal 设置 context 取消时发送给进程的信号（例如 os.Interrupt），让进程有机会优雅退出；
+// 进程在 waitDelay 内没有退出时仍会被强制终止。平台不支持该信号时（如 Windows 上的 os.Interrupt）直接终止
+func (e *Executor) WithStopSignal(sig os.Signal) *Executor {
+	e.stopSignal = sig
+	return e
+}
+
+// WithOnStart 设置进程启动后的回调，参数为子进程 PID（例如按 PID 记录其输出文件）
+func (e *Executor) WithOnStart(fn func(pid int)) *Executor {
+	e.onStart = fn
+	return e
+}
+
 // WithEnv 附加环境变量到命令
 // 它会附加到当前进程的环境变量之上
 func (e *Executor) WithEnv(envs ...string) *Executor {
 	e.cmd.Env = append(e.cmd.Environ(), envs...)
+	e.extraEnv = append(e.extraEnv, envs...)
 	return e
 }
 
+// WithCleanEnv 以 envs 作为命令的完整环境变量，不继承当前进程的环境
+// envs 为空时子进程的环境为空
+func (e *Executor) WithCleanEnv(envs ...string) *Executor {
+	e.cmd.Env = append([]string{}, envs...)
+	e.extraEnv = append([]string(nil), envs...)
+	return e
+}
+
+// bind 在设置了 context 或超时时，使用 exec.CommandContext 重建底层命令
+// 返回的 finish 必须在命令结束后调用：释放 context 资源，并把超时/取消导致的失败转换为明确的错误
+func (e *Executor) bind() (finish func(error) error) {
+	ctx, stopSignal := e.ctx, e.stopSignal
+	if ctx == nil && e.timeout <= 0 {
+		b, _ := baseContext.Load().(baseCtx)
+		if b.ctx == nil {
+			return func(err error) error { return err }
+		}
+		// 绑定到基础 context：被中断时让子进程有机会优雅退出
+		ctx = b.ctx
+		if stopSignal == nil {
+			stopSignal = os.Interrupt
+		}
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cancel := context.CancelFunc(func() {})
+	if e.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, e.timeout)
+	}
+
+	old := e.cmd
+	cmd := exec.CommandContext(ctx, old.Path, old.Args[1:]...)
+	cmd.Args = old.Args
+	cmd.Dir = old.Dir
+	cmd.Env = old.Env
+	cmd.Stdin = old.Stdin
+	cmd.Stdout = old.Stdout
+	cmd.Stderr = old.Stderr
+	cmd.WaitDelay = waitDelay
+	if sig := stopSignal; sig != nil {
+		cmd.Cancel = func() error {
+			if err := cmd.Process.Signal(sig); err != nil {
+				return cmd.Process.Kill()
+			}
+			return nil
+		}
+	}
+	e.cmd = cmd
+
+	return func(err error) error {
+		defer cancel()
+		if err == nil {
+			return nil
+		}
+		switch ctxErr := ctx.Err(); {
+		case errors.Is(ctxErr, context.DeadlineExceeded) && e.timeout > 0:
+			return fmt.Errorf("%w after %s", ErrTimeout, e.timeout)
+		case errors.Is(ctxErr, context.DeadlineExceeded):
+			return fmt.Errorf("%w: %w", ErrTimeout, ctxErr)
+		case ctxErr != nil:
+			return fmt.Errorf("command canceled: %w", context.Cause(ctx))
+		}
+		return err
+	}
+}
+
 // Run 执行命令，并分别返回标准输出和标准错误
 // 即使命令执行失败，stdout 和 stderr 也会返回捕获到的内容
 func (e *Executor) Run() (stdout, stderr string, err error) {
+	if e.intercept() {
+		return "", "", nil
+	}
 	var outBuf, errBuf bytes.Buffer
+	finish := e.bind()
 	e.cmd.Stdout = &outBuf
 	e.cmd.Stderr = &errBuf
 
-	runErr := e.cmd.Run()
+	runErr := finish(e.cmd.Run())
 	stdout = outBuf.String()
 	stderr = errBuf.String()
 
@@ -143,14 +292,23 @@ func (e *Executor) Run() (stdout, stderr string, err error) {
 // Output 执行命令并返回其标准输出
 // 如果发生错误，错误信息中会包含标准错误的内容
 func (e *Executor) Output() (string, error) {
+	if e.intercept() {
+		return "", nil
+	}
+	finish := e.bind()
 	output, err := e.cmd.Output()
+	var stderr string
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		stderr = string(exitErr.Stderr)
+	}
+	err = finish(err)
 	if err != nil {
 		// *exec.ExitError 已经包含了 Stderr
-		if exitErr, ok := err.(*exec.ExitError); ok {
+		if stderr != "" {
 			return string(output), &ExecError{
 				Cmd:    e.cmd.Path,
 				Args:   e.cmd.Args[1:],
-				Stderr: string(exitErr.Stderr),
+				Stderr: stderr,
 				Err:    err,
 			}
 		}
@@ -165,8 +323,12 @@ func (e *Executor) Output() (string, error) {
 
 // CombinedOutput 执行命令并返回其合并的标准输出和标准错误
 func (e *Executor) CombinedOutput() (string, error) {
+	if e.intercept() {
+		return "", nil
+	}
+	finish := e.bind()
 	output, err := e.cmd.CombinedOutput()
-	if err != nil {
+	if err = finish(err); err != nil {
 		// CombinedOutput 的 Stderr 已经混入 output 中
 		return string(output), &ExecError{
 			Cmd:    e.cmd.Path,
@@ -182,7 +344,11 @@ func (e *Executor) CombinedOutput() (string, error) {
 // 为了在出错时仍能返回 stderr 内容，会在内部附加一个缓冲区捕获 stderr.
 // 仅在返回错误时，错误中的 Stderr 才会包含该缓冲区内容.
 func (e *Executor) RunStreaming(stdout, stderr io.Writer) error {
+	if e.intercept() {
+		return nil
+	}
 	var errBuf bytes.Buffer
+	finish := e.bind()
 
 	if stdout != nil {
 		e.cmd.Stdout = stdout
@@ -198,7 +364,7 @@ func (e *Executor) RunStreaming(stdout, stderr io.Writer) error {
 		e.cmd.Stderr = &errBuf
 	}
 
-	if err := e.cmd.Run(); err != nil {
+	if err := finish(e.startAndWait()); err != nil {
 		return &ExecError{
 			Cmd:    e.cmd.Path,
 			Args:   e.cmd.Args[1:],
@@ -208,3 +374,65 @@ func (e *Executor) RunStreaming(stdout, stderr io.Writer) error {
 	}
 	return nil
 }
+
+// startAndWait 启动命令并等待其结束，设置了 onStart 时在启动成功后回调 PID
+func (e *Executor) startAndWait() error {
+	if e.onStart == nil {
+		return e.cmd.Run()
+	}
+	if err := e.cmd.Start(); err != nil {
+		return err
+	}
+	e.onStart(e.cmd.Process.Pid)
+	return e.cmd.Wait()
+}
+
+// RunLines 执行命令，子进程每输出一行就立即回调 onStdout/onStderr（不含行尾换行符）
+// 适用于需要实时展示进度的长时间命令（构建、测试等）；回调为 nil 时丢弃对应输出
+// 两个回调不会并发执行；返回的错误与 RunStreaming 相同，Stderr 中包含捕获的标准错误
+func (e *Executor) RunLines(onStdout, onStderr func(line string)) error {
+	var mu sync.Mutex
+	stdout := &lineWriter{mu: &mu, fn: onStdout}
+	stderr := &lineWriter{mu: &mu, fn: onStderr}
+	err := e.RunStreaming(stdout, stderr)
+	stdout.flush()
+	stderr.flush()
+	return err
+}
+
+// lineWriter 将写入的数据按行切分并回调，未以换行结尾的残余部分在 flush 时输出
+type lineWriter struct {
+	mu  *sync.Mutex
+	fn  func(string)
+	buf []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.emit(w.buf[:i])
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+func (w *lineWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buf) > 0 {
+		w.emit(w.buf)
+		w.buf = nil
+	}
+}
+
+func (w *lineWriter) emit(line []byte) {
+	if w.fn != nil {
+		w.fn(strings.TrimSuffix(string(line), "\r"))
+	}
+}
@@ -1,10 +1,14 @@
 package executor
 
 import (
+	"context"
+	"errors"
+	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 )
 
 // 测试基础命令执行
@@ -73,6 +77,21 @@ func TestExecutor_WithEnv(t *testing.T) {
 	}
 }
 
+// 测试 WithCleanEnv 不继承当前进程的环境变量
+func TestExecutor_WithCleanEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("relies on the env utility")
+	}
+	t.Setenv("GOCLI_INHERITED", "1")
+	out, err := NewExecutor("env").WithCleanEnv("FOO=bar").Output()
+	if err != nil {
+		t.Fatalf("Run with clean env failed: %v", err)
+	}
+	if strings.TrimSpace(out) != "FOO=bar" {
+		t.Errorf("expected only FOO=bar in the environment, got: %q", out)
+	}
+}
+
 // 测试 WithStdin
 func TestExecutor_WithStdin(t *testing.T) {
 	var e *Executor
@@ -102,3 +121,90 @@ func TestExecutor_Run_Error(t *testing.T) {
 		t.Errorf("error should indicate command not found, got: %v", err)
 	}
 }
+
+// 测试 WithTimeout 超时后终止命令并返回 ErrTimeout，WithContext 取消时返回取消错误
+func TestExecutor_WithTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sleep is not available on windows")
+	}
+	start := time.Now()
+	_, err := NewExecutor("sleep", "10").WithTimeout(100 * time.Millisecond).CombinedOutput()
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("command was not killed in time: %s", elapsed)
+	}
+	var execErr *ExecError
+	if !errors.As(err, &execErr) || !strings.Contains(err.Error(), "timed out after 100ms") {
+		t.Errorf("expected ExecError with timeout message, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = NewExecutor("sleep", "10").WithContext(ctx).RunStreaming(nil, nil)
+	if !errors.Is(err, context.Canceled) || errors.Is(err, ErrTimeout) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	if out, err := NewExecutor("echo", "ok").WithTimeout(time.Minute).Output(); err != nil || !strings.Contains(out, "ok") {
+		t.Errorf("command within timeout should succeed, got %q, %v", out, err)
+	}
+}
+
+// 测试 RunLines 按行实时回调输出，并在失败时仍返回退出错误
+func TestExecutor_RunLines(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available on windows")
+	}
+	var stdout, stderr []string
+	err := NewExecutor("sh", "-c", "echo one; echo two >&2; printf three; exit 3").RunLines(
+		func(l string) { stdout = append(stdout, l) },
+		func(l string) { stderr = append(stderr, l) },
+	)
+	if strings.Join(stdout, "|") != "one|three" {
+		t.Errorf("unexpected stdout lines: %q", stdout)
+	}
+	if strings.Join(stderr, "|") != "two" {
+		t.Errorf("unexpected stderr lines: %q", stderr)
+	}
+	var execErr *ExecError
+	if !errors.As(err, &execErr) || execErr.ExitCode() != 3 || !strings.Contains(execErr.Stderr, "two") {
+		t.Errorf("expected exit error with code 3 and captured stderr, got %v", err)
+	}
+}
+
+// 测试录制模式：命令只被记录而不执行，ReadOnly 查询仍然执行
+func TestExecutor_Recording(t *testing.T) {
+	dir := t.TempDir()
+	rec := StartRecording()
+	defer StopRecording()
+
+	marker := filepath.Join(dir, "marker")
+	out, err := NewExecutor("touch", marker).WithDir(dir).WithEnv("CGO_ENABLED=0", "MSG=hello world").CombinedOutput()
+	if err != nil || out != "" {
+		t.Fatalf("recorded command should return empty output and nil error, got %q, %v", out, err)
+	}
+	if _, err := NewExecutor("go", "mod", "tidy").Output(); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := NewExecutor("echo", "query").ReadOnly().Output(); err != nil || !strings.Contains(got, "query") {
+		t.Errorf("read-only command should still run, got %q, %v", got, err)
+	}
+	StopRecording()
+
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Errorf("recorded command must not be executed")
+	}
+	records := rec.Records()
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %+v", records)
+	}
+	want := "$ cd " + dir + " && CGO_ENABLED=0 MSG='hello world' touch " + marker
+	if got := records[0].String(); got != want {
+		t.Errorf("unexpected record:\n got %q\nwant %q", got, want)
+	}
+	if got := records[1].String(); got != "$ go mod tidy" {
+		t.Errorf("unexpected record %q", got)
+	}
+}
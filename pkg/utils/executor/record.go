@@ -0,0 +1,122 @@
+package executor
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// CommandRecord 描述一条在录制模式下被拦截（未实际执行）的外部命令
+type CommandRecord struct {
+	Name string   `json:"name"`          // 程序名（调用 NewExecutor 时传入的名称）
+	Args []string `json:"args"`          // 命令参数
+	Dir  string   `json:"dir,omitempty"` // 工作目录，空表示当前目录
+	Env  []string `json:"env,omitempty"` // 通过 WithEnv 额外附加的环境变量
+}
+
+// String 以可直接复制到 shell 的形式返回命令，例如 "$ cd dir && CGO_ENABLED=0 go test ./..."
+func (r CommandRecord) String() string {
+	var b strings.Builder
+	b.WriteString("$ ")
+	if r.Dir != "" {
+		b.WriteString("cd " + shellQuote(r.Dir) + " && ")
+	}
+	for _, e := range r.Env {
+		if k, v, ok := strings.Cut(e, "="); ok {
+			b.WriteString(k + "=" + shellQuote(v) + " ")
+		}
+	}
+	b.WriteString(shellQuote(r.Name))
+	for _, a := range r.Args {
+		b.WriteString(" " + shellQuote(a))
+	}
+	return b.String()
+}
+
+// Recorder 收集录制模式下的命令记录，可安全地并发使用
+type Recorder struct {
+	mu      sync.Mutex
+	records []CommandRecord
+}
+
+// Records 返回目前为止录制到的命令（按执行顺序）
+func (r *Recorder) Records() []CommandRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]CommandRecord(nil), r.records...)
+}
+
+// Print 将录制到的命令逐行写入 w；没有任何命令时给出提示
+func (r *Recorder) Print(w io.Writer) {
+	records := r.Records()
+	if len(records) == 0 {
+		fmt.Fprintln(w, "[dry-run] no external commands would be executed")
+		return
+	}
+	for _, rec := range records {
+		fmt.Fprintln(w, rec.String())
+	}
+}
+
+func (r *Recorder) add(rec CommandRecord) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, rec)
+}
+
+// activeRecorder 当前进程级的录制器；为 nil 时命令正常执行
+var activeRecorder atomic.Pointer[Recorder]
+
+// StartRecording 开启录制模式：之后所有 Executor 都不会真正执行命令，
+// 而是追加一条 CommandRecord 并返回空输出与 nil 错误（ReadOnly 标记的查询命令除外）
+// 用于实现 --dry-run，也可在测试中断言将要执行的命令
+func StartRecording() *Recorder {
+	r := &Recorder{}
+	activeRecorder.Store(r)
+	return r
+}
+
+// StopRecording 关闭录制模式
+func StopRecording() {
+	activeRecorder.Store(nil)
+}
+
+// Recording 报告当前是否处于录制模式，供需要跳过后续文件操作的调用方判断
+func Recording() bool {
+	return activeRecorder.Load() != nil
+}
+
+// ReadOnly 标记该命令为无副作用的查询（如 go env、go list、git ls-remote），
+// 录制模式下仍会真正执行且不被记录，以便 dry-run 能得到后续步骤需要的信息
+func (e *Executor) ReadOnly() *Executor {
+	e.readOnly = true
+	return e
+}
+
+// intercept 在录制模式下记录命令并返回 true，调用方应直接返回空结果
+func (e *Executor) intercept() bool {
+	r := activeRecorder.Load()
+	if r == nil || e.readOnly {
+		return false
+	}
+	r.add(CommandRecord{
+		Name: e.cmd.Args[0],
+		Args: append([]string(nil), e.cmd.Args[1:]...),
+		Dir:  e.cmd.Dir,
+		Env:  append([]string(nil), e.extraEnv...),
+	})
+	return true
+}
+
+// shellQuote 在需要时为参数加上单引号，使记录的命令可以直接粘贴到 shell 中执行
+func shellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	if !strings.ContainsAny(s, " \t\n\"'\\$`!*?[]{}()<>|&;#~") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
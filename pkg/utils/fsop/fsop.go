@@ -10,9 +10,10 @@ import (
 )
 
 // walkSubdirectories 是内部通用实现，支持 ignorePatterns（可为 nil）
+// 通过 Walk 遍历：不跟随符号链接，目录深度受 DefaultMaxDepth 限制
 func walkSubdirectories(root string, ignorePatterns []string) ([]string, error) {
 	var subdirs []string
-	walkErr := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+	walkErr := Walk(root, WalkOptions{}, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -38,7 +39,7 @@ func walkSubdirectories(root string, ignorePatterns []string) ([]string, error)
 // walkSubdirectoriesWithGitIgnore 使用 gitignore 包进行更精确的过滤
 func walkSubdirectoriesWithGitIgnore(root string, gi *gitignore.GitIgnore) ([]string, error) {
 	var subdirs []string
-	walkErr := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+	walkErr := Walk(root, WalkOptions{}, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
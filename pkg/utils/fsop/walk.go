@@ -0,0 +1,138 @@
+package fsop
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultMaxDepth 是 WalkOptions.MaxDepth 未设置时 Walk 使用的目录深度上限
+const DefaultMaxDepth = 40
+
+// WalkOptions 控制 Walk 如何处理符号链接以及最多进入多深的目录
+type WalkOptions struct {
+	FollowSymlinks bool // 进入指向目录的符号链接，并把指向文件的符号链接作为文件回调
+	StayInRoot     bool // 跟随符号链接时跳过解析到 root 之外的链接
+	MaxDepth       int  // 最大目录深度（root 为 0），<=0 时使用 DefaultMaxDepth
+}
+
+// walker 保存一次 Walk 的状态：已进入目录与已回调文件的真实路径
+type walker struct {
+	opts     WalkOptions
+	fn       fs.WalkDirFunc
+	realRoot string
+	dirs     map[string]bool
+	files    map[string]bool
+}
+
+// Walk 与 filepath.WalkDir 一样遍历以 root 为根的目录树，以 root 下的逻辑路径回调每个目录和文件，
+// 但可以安全地跟随符号链接：
+//   - 每个目录按真实路径最多进入一次，指向祖先目录的链接不会形成循环
+//   - 每个文件按真实路径最多回调一次，即使能通过多个链接到达
+//   - 深度超过 MaxDepth 的目录会被回调但不再进入
+//   - 设置 StayInRoot 时跳过解析到 root 之外的链接
+//
+// 跟随的文件链接以符号链接自身的 DirEntry 回调，跟随的目录链接以目录 DirEntry 回调；跟随时跳过悬空链接。
+// fn 可以返回 filepath.SkipDir 或 filepath.SkipAll，含义与 filepath.WalkDir 相同
+func Walk(root string, opts WalkOptions, fn fs.WalkDirFunc) error {
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = DefaultMaxDepth
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return fn(root, nil, err)
+	}
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return fn(root, nil, err)
+	}
+	w := &walker{opts: opts, fn: fn, realRoot: realRoot, dirs: map[string]bool{}, files: map[string]bool{}}
+	err = w.walk(root, realRoot, fs.FileInfoToDirEntry(info), 0)
+	if errors.Is(err, filepath.SkipDir) || errors.Is(err, filepath.SkipAll) {
+		return nil
+	}
+	return err
+}
+
+// walk 处理 path（真实路径为 real）；返回 SkipDir 表示跳过父目录中剩余的条目
+func (w *walker) walk(path, real string, d fs.DirEntry, depth int) error {
+	if !d.IsDir() {
+		if w.opts.FollowSymlinks {
+			if w.files[real] {
+				return nil
+			}
+			w.files[real] = true
+		}
+		return w.fn(path, d, nil)
+	}
+
+	w.dirs[real] = true
+	if err := w.fn(path, d, nil); err != nil {
+		if errors.Is(err, filepath.SkipDir) {
+			return nil
+		}
+		return err
+	}
+	if depth >= w.opts.MaxDepth {
+		return nil
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		if err := w.fn(path, d, err); err != nil && !errors.Is(err, filepath.SkipDir) {
+			return err
+		}
+		return nil
+	}
+	for _, e := range entries {
+		p := filepath.Join(path, e.Name())
+		entry, entryReal := e, filepath.Join(real, e.Name())
+		if e.Type()&fs.ModeSymlink != 0 && w.opts.FollowSymlinks {
+			var ok bool
+			if entry, entryReal, ok = w.resolveLink(p, e); !ok {
+				continue
+			}
+		}
+		if entry.IsDir() && w.dirs[entryReal] {
+			// 目录已经通过其他路径进入过（例如指向祖先目录的符号链接）
+			continue
+		}
+		if err := w.walk(p, entryReal, entry, depth+1); err != nil {
+			if errors.Is(err, filepath.SkipDir) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveLink 解析符号链接 p：指向目录时返回目录 DirEntry，指向文件时保留原 DirEntry；
+// 悬空链接或（StayInRoot 时）指向 root 之外的链接返回 ok=false
+func (w *walker) resolveLink(p string, e fs.DirEntry) (fs.DirEntry, string, bool) {
+	real, err := filepath.EvalSymlinks(p)
+	if err != nil {
+		return nil, "", false
+	}
+	if w.opts.StayInRoot && !within(w.realRoot, real) {
+		return nil, "", false
+	}
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, "", false
+	}
+	if info.IsDir() {
+		return fs.FileInfoToDirEntry(info), real, true
+	}
+	return e, real, true
+}
+
+// within 判断 path 是否等于 dir 或位于 dir 之下
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
@@ -0,0 +1,104 @@
+package fsop
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+)
+
+// 测试跟随符号链接时遇到指向祖先目录的循环能够结束，经两条路径可达的文件只回调一次，root 之外的链接被跳过
+func TestWalkSymlinkCycle(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require extra privileges on windows")
+	}
+	root, outside := t.TempDir(), t.TempDir()
+	mustWrite(t, filepath.Join(root, "a", "x.txt"))
+	mustWrite(t, filepath.Join(outside, "o.txt"))
+	mustLink(t, root, filepath.Join(root, "a", "loop"))             // a/loop -> root
+	mustLink(t, filepath.Join(root, "a"), filepath.Join(root, "b")) // b -> a
+	mustLink(t, filepath.Join(root, "a", "x.txt"), filepath.Join(root, "y.txt"))
+	mustLink(t, outside, filepath.Join(root, "out"))
+
+	walk := func(opts WalkOptions) []string {
+		var files []string
+		err := Walk(root, opts, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() {
+				rel, _ := filepath.Rel(root, path)
+				files = append(files, filepath.ToSlash(rel))
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Walk(%+v): %v", opts, err)
+		}
+		sort.Strings(files)
+		return files
+	}
+
+	got := walk(WalkOptions{FollowSymlinks: true, StayInRoot: true})
+	if len(got) != 1 || got[0] != "a/x.txt" {
+		t.Errorf("stay in root: files = %v, want [a/x.txt]", got)
+	}
+	got = walk(WalkOptions{FollowSymlinks: true})
+	if len(got) != 2 || got[0] != "a/x.txt" || got[1] != "out/o.txt" {
+		t.Errorf("follow outside root: files = %v, want [a/x.txt out/o.txt]", got)
+	}
+	// 不跟随时符号链接本身作为条目回调，不会进入
+	got = walk(WalkOptions{})
+	want := []string{"a/loop", "a/x.txt", "b", "out", "y.txt"}
+	if len(got) != len(want) {
+		t.Fatalf("no follow: files = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("no follow: files = %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+// 测试 MaxDepth 限制进入的目录深度
+func TestWalkMaxDepth(t *testing.T) {
+	root := t.TempDir()
+	mustWrite(t, filepath.Join(root, "1", "2", "3", "deep.txt"))
+	var dirs []string
+	err := Walk(root, WalkOptions{MaxDepth: 2}, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != root {
+			rel, _ := filepath.Rel(root, path)
+			dirs = append(dirs, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dirs) != 2 || dirs[0] != "1" || dirs[1] != "1/2" {
+		t.Errorf("entries = %v, want [1 1/2]", dirs)
+	}
+}
+
+func mustWrite(t *testing.T, p string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte("line\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func mustLink(t *testing.T, target, link string) {
+	t.Helper()
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,6 +1,8 @@
 package hotload
 
 import (
+	"path/filepath"
+	"slices"
 	"time"
 )
 
@@ -9,7 +11,7 @@ func shouldDebounce(ctx *WatchContext) bool {
 	return ctx.changeDetected
 }
 
-// armOrResetDebounce 启动或重置一个基于配置防抖时长的定时器.
+// armOrResetDebounce 启动或重置一个基于配置防抖时长的定时器，调用方需持有 ctx.mu.
 func armOrResetDebounce(ctx *WatchContext, fire func()) {
 	if ctx.timer != nil {
 		ctx.timer.Reset(ctx.debounceDuration)
@@ -19,9 +21,15 @@ func armOrResetDebounce(ctx *WatchContext, fire func()) {
 }
 
 // onDebounceFire 在防抖定时器触发时运行：刷新状态缓存并调用钩子.
-func onDebounceFire(ctx *WatchContext, hook Func) {
-	// 安全检查
+// 持有 ctx.mu 时取出本次变更的副本并重置防抖状态，钩子在锁外执行，期间到达的事件会重新启动定时器.
+func onDebounceFire(ctx *WatchContext, hook ChangeFunc) {
+	ctx.hookMu.Lock()
+	defer ctx.hookMu.Unlock()
+
+	ctx.mu.Lock()
+	// 安全检查（上一次钩子执行期间重新启动的定时器可能已被一并处理）
 	if !ctx.changeDetected {
+		ctx.mu.Unlock()
 		return
 	}
 
@@ -37,7 +45,7 @@ func onDebounceFire(ctx *WatchContext, hook Func) {
 	logEventCountMutex.Unlock()
 
 	// 重新构建状态缓存以确保一致性
-	newCache, err := newWatcherWithState(ctx.rootPath, ctx.config.Recursive)
+	newCache, err := rebuildStateCache(ctx)
 	if err != nil {
 		logger.Error().Msgf("变更后更新状态缓存失败: %v", err)
 	} else {
@@ -45,11 +53,25 @@ func onDebounceFire(ctx *WatchContext, hook Func) {
 		logger.Debug().Msgf("状态缓存已更新，包含 %d 个文件", len(ctx.cache))
 	}
 
-	hook()
+	trigger, changed := ctx.trigger, slices.Clone(ctx.changed)
 
 	// 重置标记和定时器
 	ctx.changeDetected = false
+	ctx.trigger = ""
+	ctx.changed = nil
 	ctx.timer = nil
+	ctx.mu.Unlock()
+
+	ctx.stats.setTrigger(triggerPath(ctx.rootPath, trigger))
+	hook(changed)
+}
+
+// triggerPath 返回相对监视目录的触发文件路径，位于目录之外（watch_paths）时保留原路径
+func triggerPath(root, name string) string {
+	if rel, err := filepath.Rel(root, name); err == nil && filepath.IsLocal(rel) {
+		return filepath.ToSlash(rel)
+	}
+	return name
 }
 
 // stringContains 是一个小工具，避免在多个文件中引入 strings 包.
@@ -0,0 +1,68 @@
+package hotload
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/configs"
+)
+
+// 测试钩子执行期间持续写入文件：钩子收到的是防抖状态的副本（go test -race 下不应出现数据竞争），
+// 执行期间到达的变更不会丢失，所有文件最终都出现在某次钩子调用中
+func TestChangeLoopPassesChangedCopy(t *testing.T) {
+	root := t.TempDir()
+	config := configs.HotloadConfig{
+		Enabled:   true,
+		Filter:    []string{"*.go"},
+		Recursive: true,
+		Debounce:  20,
+	}
+	ctx, err := newWatchContext(root, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	batches := make(chan []string, 100)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = runChangeLoop(ctx, func(changed []string) {
+			time.Sleep(50 * time.Millisecond)
+			batches <- changed
+		})
+	}()
+	defer func() {
+		_ = ctx.watcher.Close()
+		<-done
+	}()
+
+	var want []string
+	for i := range 10 {
+		name := filepath.Join(root, fmt.Sprintf("f%d.go", i))
+		want = append(want, name)
+		writeFile(t, name, "package main\n")
+		time.Sleep(40 * time.Millisecond)
+	}
+
+	seen := map[string]bool{}
+	deadline := time.After(5 * time.Second)
+	for len(seen) < len(want) {
+		select {
+		case changed := <-batches:
+			for _, f := range changed {
+				seen[f] = true
+			}
+		case <-deadline:
+			var missing []string
+			for _, f := range want {
+				if !seen[f] {
+					missing = append(missing, filepath.Base(f))
+				}
+			}
+			slices.Sort(missing)
+			t.Fatalf("changes never reported to the hook: %v", missing)
+		}
+	}
+}
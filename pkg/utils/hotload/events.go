@@ -3,10 +3,13 @@ package hotload
 import (
 	"os"
 	"path/filepath"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/yeisme/gocli/pkg/configs"
+	"github.com/yeisme/gocli/pkg/utils/executor"
 	"github.com/yeisme/gocli/pkg/utils/gitignore"
 )
 
@@ -21,28 +24,53 @@ type WatchContext struct {
 	cache            map[string]fileState
 	debounceDuration time.Duration
 
+	// extraRoots 来自 watch_paths 的额外监视路径
+	extraRoots []*extraRoot
+
+	// mu 保护缓存、额外路径与防抖状态：事件循环与防抖定时器运行在不同的 goroutine 中
+	mu sync.Mutex
+	// hookMu 串行化钩子调用，钩子执行期间的新变更在下一次触发时处理
+	hookMu sync.Mutex
+
 	// debounce runtime
 	timer          *time.Timer
 	changeDetected bool
+	// trigger 本次防抖窗口内第一个发生变更的文件
+	trigger string
+	// changed 本次防抖窗口内发生变更的所有文件（去重）
+	changed []string
+
+	stats *Stats
 }
 
 // runEventLoop 处理 fsnotify 事件，应用过滤、状态跟踪和去抖动逻辑.
 func runEventLoop(ctx *WatchContext, hook Func) error {
+	return runChangeLoop(ctx, func([]string) { hook() })
+}
+
+// runChangeLoop 与 runEventLoop 相同，钩子同时收到防抖窗口内变更的文件.
+func runChangeLoop(ctx *WatchContext, hook ChangeFunc) error {
+	// gocli 收到 Ctrl+C 时取消基础 context，结束监听
+	interrupted := executor.BaseContext().Done()
 	done := make(chan struct{})
 	go func() {
 		defer close(done)
 		for {
 			select {
+			case <-interrupted:
+				return
 			case event, ok := <-ctx.watcher.Events:
 				if !ok {
 					return
 				}
+				ctx.mu.Lock()
 				handleEvent(ctx, event)
 				if shouldDebounce(ctx) {
 					armOrResetDebounce(ctx, func() {
 						onDebounceFire(ctx, hook)
 					})
 				}
+				ctx.mu.Unlock()
 			case err, ok := <-ctx.watcher.Errors:
 				if !ok {
 					return
@@ -55,12 +83,25 @@ func runEventLoop(ctx *WatchContext, hook Func) error {
 	return nil
 }
 
-// handleEvent 决定事件是否有意义并更新缓存与标志位.
+// handleEvent 决定事件是否有意义并更新缓存与标志位，调用方需持有 ctx.mu.
 func handleEvent(ctx *WatchContext, event fsnotify.Event) {
 	logEventWithThrottle(event.Op.String(), event.Name)
+	ctx.stats.Events.Add(1)
+
+	if len(ctx.extraRoots) > 0 {
+		// 等待中的 watch_paths 通过祖先目录的监视得知路径出现
+		if event.Has(fsnotify.Create) {
+			onPendingPathEvent(ctx, event.Name)
+		}
+		// 祖先目录中与监视路径无关的事件
+		if watchedRoot(ctx, event.Name) == "" {
+			return
+		}
+	}
 
 	// Ignore paths based on built-in, user patterns and .gitignore
 	if isPathIgnored(ctx, event.Name) {
+		ctx.stats.Filtered.Add(1)
 		return
 	}
 
@@ -75,6 +116,14 @@ func handleEvent(ctx *WatchContext, event fsnotify.Event) {
 	}
 
 	if isRealChange {
+		if ctx.changeDetected {
+			ctx.stats.Coalesced.Add(1)
+		} else {
+			ctx.trigger = event.Name
+		}
+		if !slices.Contains(ctx.changed, event.Name) {
+			ctx.changed = append(ctx.changed, event.Name)
+		}
 		ctx.changeDetected = true
 	}
 }
@@ -91,8 +140,8 @@ func isPathIgnored(ctx *WatchContext, name string) bool {
 		logIgnoreWithThrottle("filters/patterns", name)
 		return true
 	}
-	if ctx.config.GitIgnore && ctx.gi != nil && len(ctx.gi.GetPatterns()) > 0 {
-		if ctx.gi.IsIgnored(name) {
+	if gi, rel := gitIgnoreFor(ctx, name); ctx.config.GitIgnore && gi != nil && len(gi.GetPatterns()) > 0 {
+		if gi.IsIgnored(rel) {
 			logIgnoreWithThrottle(".gitignore", name)
 			return true
 		}
@@ -33,6 +33,9 @@ var (
 // Func 定义热重载钩子函数的类型.
 type Func func()
 
+// ChangeFunc 是接收变更文件的热重载钩子，changed 为本次防抖窗口内发生变更的文件（按发生顺序去重）.
+type ChangeFunc func(changed []string)
+
 // fileState 存储用于检测真实变更的文件关键信息（修改时间、大小和内容哈希）.
 type fileState struct {
 	modTime time.Time
@@ -329,6 +332,16 @@ func detectEditorSavePattern(filePath string, newSize int64) bool {
 
 // WatchWithConfig 根据配置监控目录并触发热重载回调
 func WatchWithConfig(config configs.HotloadConfig, hotloadHook Func) error {
+	return WatchWithStats(config, nil, hotloadHook)
+}
+
+// WatchWithStats 与 WatchWithConfig 相同，并把事件统计与触发文件记录到 stats（为 nil 时不对外暴露统计）
+func WatchWithStats(config configs.HotloadConfig, stats *Stats, hotloadHook Func) error {
+	return WatchChanges(config, stats, func([]string) { hotloadHook() })
+}
+
+// WatchChanges 与 WatchWithStats 相同，钩子同时收到本次变更的文件，调用方可以据此只重启受影响的部分
+func WatchChanges(config configs.HotloadConfig, stats *Stats, hotloadHook ChangeFunc) error {
 	if !config.Enabled {
 		logger.Warn().Msg("Hot reload is disabled in configuration")
 		return nil
@@ -347,7 +360,7 @@ func WatchWithConfig(config configs.HotloadConfig, hotloadHook Func) error {
 	logger.Debug().Msgf("Filter: %v, IgnorePatterns: %v, Debounce: %dms",
 		config.Filter, config.IgnorePatterns, config.Debounce)
 
-	return baseDirWatcherWithConfig(watchDir, config, hotloadHook)
+	return baseDirWatcherWithConfig(watchDir, config, stats, hotloadHook)
 }
 
 // initializeFileStateCache 初始化文件状态缓存
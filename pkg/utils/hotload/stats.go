@@ -0,0 +1,156 @@
+package hotload
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/style"
+)
+
+// Stats 汇总一次热重载会话的事件与重建统计.
+// 计数字段为原子类型，监视 goroutine 与执行重建的钩子可以并发读写.
+type Stats struct {
+	Events      atomic.Int64 // 收到的文件系统事件
+	Filtered    atomic.Int64 // 被过滤器、忽略模式或 .gitignore 过滤掉的事件
+	Coalesced   atomic.Int64 // 防抖窗口内合并到同一次重建中的变更
+	Rebuilds    atomic.Int64 // 由变更触发的重建（不含初始构建）
+	Failed      atomic.Int64 // 失败的重建
+	RebuildTime atomic.Int64 // 重建累计耗时（纳秒）
+
+	started time.Time
+	trigger atomic.Value // string: 最近一次触发重建的文件（相对监视目录）
+}
+
+// NewStats 创建从当前时刻开始计时的统计
+func NewStats() *Stats {
+	return &Stats{started: time.Now()}
+}
+
+// Trigger 返回最近一次触发重建的文件，未触发过时为空
+func (s *Stats) Trigger() string {
+	t, _ := s.trigger.Load().(string)
+	return t
+}
+
+func (s *Stats) setTrigger(path string) {
+	s.trigger.Store(path)
+}
+
+// RecordRebuild 记录一次重建的耗时与结果，返回该次重建的序号（从 1 开始）
+func (s *Stats) RecordRebuild(d time.Duration, err error) int64 {
+	s.RebuildTime.Add(int64(d))
+	if err != nil {
+		s.Failed.Add(1)
+	}
+	return s.Rebuilds.Add(1)
+}
+
+// AverageRebuild 返回重建的平均耗时，没有重建时为 0
+func (s *Stats) AverageRebuild() time.Duration {
+	n := s.Rebuilds.Load()
+	if n == 0 {
+		return 0
+	}
+	return time.Duration(s.RebuildTime.Load() / n)
+}
+
+// StatusLine 返回第 n 次重建之后输出的单行摘要，例如
+// "[hot-reload] #12 rebuilt in 1.8s (trigger: pkg/server/handler.go) — running for 34m, 12 restarts, 2 failed"
+func (s *Stats) StatusLine(n int64, d time.Duration, err error) string {
+	return s.statusLine(n, d, err, time.Since(s.started))
+}
+
+func (s *Stats) statusLine(n int64, d time.Duration, err error, uptime time.Duration) string {
+	var b strings.Builder
+	if err != nil {
+		fmt.Fprintf(&b, "[hot-reload] #%d failed after %s", n, formatRebuildDuration(d))
+	} else {
+		fmt.Fprintf(&b, "[hot-reload] #%d rebuilt in %s", n, formatRebuildDuration(d))
+	}
+	if t := s.Trigger(); t != "" {
+		fmt.Fprintf(&b, " (trigger: %s)", t)
+	}
+	fmt.Fprintf(&b, " — running for %s, %d restarts, %d failed", formatUptime(uptime), s.Rebuilds.Load(), s.Failed.Load())
+	return b.String()
+}
+
+// Report 返回多行的统计报告（--stats 时按需输出）
+func (s *Stats) Report() string {
+	return s.report(time.Since(s.started))
+}
+
+func (s *Stats) report(uptime time.Duration) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[hot-reload] stats after %s:\n", formatUptime(uptime))
+	fmt.Fprintf(&b, "  events received:       %d\n", s.Events.Load())
+	fmt.Fprintf(&b, "  filtered by ignores:   %d\n", s.Filtered.Load())
+	fmt.Fprintf(&b, "  debounce coalesced:    %d\n", s.Coalesced.Load())
+	fmt.Fprintf(&b, "  rebuilds:              %d (%d failed)\n", s.Rebuilds.Load(), s.Failed.Load())
+	fmt.Fprintf(&b, "  average rebuild time:  %s\n", formatRebuildDuration(s.AverageRebuild()))
+	return b.String()
+}
+
+// ListenDump 在收到 SIGUSR1（非 Windows）或 in 为终端且输入一行 "s" 时把 Report 写入 w.
+// 返回的 stop 停止监听信号；读取 in 的 goroutine 会一直阻塞到输入结束
+func (s *Stats) ListenDump(w io.Writer, in *os.File) (stop func()) {
+	done := make(chan struct{})
+	sigs := make(chan os.Signal, 1)
+	notifyDumpSignal(sigs)
+	keys := make(chan struct{})
+	if in != nil && style.IsTerminal(in) {
+		go func() {
+			sc := bufio.NewScanner(in)
+			for sc.Scan() {
+				if strings.TrimSpace(sc.Text()) != "s" {
+					continue
+				}
+				select {
+				case keys <- struct{}{}:
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		for {
+			select {
+			case <-sigs:
+			case <-keys:
+			case <-done:
+				return
+			}
+			_, _ = io.WriteString(w, s.Report())
+		}
+	}()
+	return func() {
+		signal.Stop(sigs)
+		close(done)
+	}
+}
+
+// formatRebuildDuration 输出重建耗时：不足 1 秒精确到毫秒，否则精确到 0.1 秒
+func formatRebuildDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
+
+// formatUptime 输出会话时长：45s、34m、2h05m
+func formatUptime(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package hotload
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyDumpSignal 将 SIGUSR1 转发到 c，用于按需输出统计
+func notifyDumpSignal(c chan<- os.Signal) {
+	signal.Notify(c, syscall.SIGUSR1)
+}
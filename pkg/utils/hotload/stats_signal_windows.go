@@ -0,0 +1,8 @@
+//go:build windows
+
+package hotload
+
+import "os"
+
+// notifyDumpSignal 在 Windows 上没有 SIGUSR1，只能通过键盘输入 s 输出统计
+func notifyDumpSignal(chan<- os.Signal) {}
@@ -0,0 +1,95 @@
+package hotload
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/configs"
+)
+
+// 测试状态行与统计报告的格式：成功/失败两种形式、触发文件、会话时长与平均重建耗时
+func TestStatsStatusLine(t *testing.T) {
+	s := NewStats()
+	s.setTrigger("pkg/server/handler.go")
+	for range 9 {
+		s.RecordRebuild(time.Second, nil)
+	}
+	s.RecordRebuild(2*time.Second, errors.New("boom"))
+	s.RecordRebuild(time.Second, errors.New("boom"))
+	n := s.RecordRebuild(1800*time.Millisecond, nil)
+
+	want := "[hot-reload] #12 rebuilt in 1.8s (trigger: pkg/server/handler.go) — running for 34m, 12 restarts, 2 failed"
+	if got := s.statusLine(n, 1800*time.Millisecond, nil, 34*time.Minute+10*time.Second); got != want {
+		t.Errorf("statusLine:\n got %q\nwant %q", got, want)
+	}
+	failed := s.statusLine(n, 250*time.Millisecond, errors.New("boom"), 2*time.Hour+5*time.Minute)
+	if !strings.HasPrefix(failed, "[hot-reload] #12 failed after 250ms") || !strings.Contains(failed, "running for 2h05m") {
+		t.Errorf("unexpected failure line: %q", failed)
+	}
+
+	if avg := s.AverageRebuild(); avg != 1150*time.Millisecond {
+		t.Errorf("AverageRebuild = %s, want 1.15s", avg)
+	}
+	s.Events.Add(40)
+	s.Filtered.Add(7)
+	s.Coalesced.Add(3)
+	report := s.report(45 * time.Second)
+	for _, want := range []string{"stats after 45s", "events received:       40", "filtered by ignores:   7",
+		"debounce coalesced:    3", "rebuilds:              12 (2 failed)", "average rebuild time:  1.2s"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report is missing %q:\n%s", want, report)
+		}
+	}
+}
+
+// 测试监视循环中的计数：被 .gitignore 过滤的事件计入 Filtered，触发重建的文件记录为 Trigger
+func TestStatsCounters(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ".gitignore"), "*.gen.go\n")
+	writeFile(t, filepath.Join(root, "main.go"), "package main\n")
+	if err := os.MkdirAll(filepath.Join(root, "pkg"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	config := configs.HotloadConfig{
+		Enabled:   true,
+		Filter:    []string{"*.go"},
+		Recursive: true,
+		Debounce:  50,
+		GitIgnore: true,
+	}
+	ctx, err := newWatchContext(root, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats := ctx.stats
+	fired := make(chan struct{}, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = runEventLoop(ctx, func() { fired <- struct{}{} })
+	}()
+	defer func() {
+		_ = ctx.watcher.Close()
+		<-done
+	}()
+
+	writeFile(t, filepath.Join(root, "pkg", "x.gen.go"), "package pkg\n")
+	expectNoHook(t, fired)
+	if stats.Filtered.Load() == 0 {
+		t.Error("ignored file was not counted as filtered")
+	}
+
+	writeFile(t, filepath.Join(root, "pkg", "handler.go"), "package pkg\n")
+	expectHook(t, fired, "change in pkg/handler.go")
+	if got := stats.Trigger(); got != "pkg/handler.go" {
+		t.Errorf("Trigger() = %q, want pkg/handler.go", got)
+	}
+	if stats.Events.Load() < 2 {
+		t.Errorf("Events = %d, want at least 2", stats.Events.Load())
+	}
+}
@@ -0,0 +1,213 @@
+package hotload
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yeisme/gocli/pkg/utils/gitignore"
+)
+
+// extraRoot 是 watch_paths 中配置的一个额外监视路径（通常位于项目目录之外）.
+type extraRoot struct {
+	path   string               // 解析后的路径，相对路径基于主监视目录
+	gi     *gitignore.GitIgnore // 该路径自身的 .gitignore
+	active bool                 // 路径已存在并注册到 watcher
+	parent string               // 路径不存在时正在监视的最近祖先目录
+}
+
+// resolveWatchPath 将 watch_paths 中的相对路径解析为相对于主监视目录的路径.
+func resolveWatchPath(rootPath, p string) string {
+	if filepath.IsAbs(p) {
+		return filepath.Clean(p)
+	}
+	return filepath.Join(rootPath, p)
+}
+
+// setupExtraRoots 注册 config.WatchPaths 中的额外路径.
+// 不存在的路径只警告一次，并监视其最近的祖先目录，等路径出现后再注册.
+func setupExtraRoots(ctx *WatchContext) {
+	seen := map[string]bool{}
+	for _, p := range ctx.config.WatchPaths {
+		if strings.TrimSpace(p) == "" {
+			continue
+		}
+		path := resolveWatchPath(ctx.rootPath, p)
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+
+		r := &extraRoot{path: path}
+		ctx.extraRoots = append(ctx.extraRoots, r)
+		if _, err := os.Stat(path); err != nil {
+			logger.Warn().Msgf("Watch path %s does not exist yet, waiting for it to appear", path)
+			watchNearestParent(ctx, r)
+			continue
+		}
+		activateExtraRoot(ctx, r)
+	}
+}
+
+// activateExtraRoot 为已存在的额外路径加载 .gitignore、注册目录并合并状态缓存.
+func activateExtraRoot(ctx *WatchContext, r *extraRoot) bool {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return false
+	}
+	r.gi = &gitignore.GitIgnore{}
+	if info.IsDir() && ctx.config.GitIgnore {
+		if gi, err := gitignore.LoadGitIgnoreFromDir(r.path); err == nil {
+			r.gi = gi
+		}
+	}
+
+	if info.IsDir() && ctx.config.Recursive {
+		if err := addDirectoriesToWatcher(ctx.watcher, r.path, ctx.config, r.gi); err != nil {
+			logger.Warn().Msgf("Failed to watch path %s: %v", r.path, err)
+			return false
+		}
+	} else if err := ctx.watcher.Add(r.path); err != nil {
+		logger.Warn().Msgf("Failed to watch path %s: %v", r.path, err)
+		return false
+	}
+
+	cache, err := newWatcherWithState(r.path, ctx.config.Recursive)
+	if err != nil {
+		logger.Warn().Msgf("Failed to scan watch path %s: %v", r.path, err)
+	}
+	for k, v := range cache {
+		ctx.cache[k] = v
+	}
+
+	// 不再需要祖先目录的监视（该目录本身在监视范围内或仍被其他路径等待时保留）
+	if r.parent != "" && watchedRoot(ctx, r.parent) == "" && !parentInUse(ctx, r) {
+		_ = ctx.watcher.Remove(r.parent)
+	}
+	r.parent = ""
+	r.active = true
+	logger.Info().Msgf("Watching additional path %s", r.path)
+	return true
+}
+
+// watchNearestParent 监视 r.path 最近的已存在祖先目录，以便在路径出现时收到创建事件.
+func watchNearestParent(ctx *WatchContext, r *extraRoot) {
+	dir := filepath.Dir(r.path)
+	for {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			break
+		}
+		next := filepath.Dir(dir)
+		if next == dir {
+			return
+		}
+		dir = next
+	}
+	if dir == r.parent {
+		return
+	}
+	if err := ctx.watcher.Add(dir); err != nil {
+		logger.Warn().Msgf("Failed to watch %s for pending path %s: %v", dir, r.path, err)
+		return
+	}
+	r.parent = dir
+}
+
+func parentInUse(ctx *WatchContext, self *extraRoot) bool {
+	for _, r := range ctx.extraRoots {
+		if r != self && !r.active && r.parent == self.parent {
+			return true
+		}
+	}
+	return false
+}
+
+// onPendingPathEvent 在尚未出现的额外路径（或其祖先目录）被创建时注册该路径，路径中已有文件时视为一次变更.
+func onPendingPathEvent(ctx *WatchContext, name string) {
+	for _, r := range ctx.extraRoots {
+		if r.active || !isWithin(name, r.path) {
+			continue
+		}
+		if _, err := os.Stat(r.path); err != nil {
+			// 中间目录被创建：将监视下移到新的最近祖先
+			watchNearestParent(ctx, r)
+			continue
+		}
+		if activateExtraRoot(ctx, r) && hasFilesUnder(ctx.cache, r.path) {
+			ctx.changeDetected = true
+		}
+	}
+}
+
+// watchedRoot 返回 name 所属的监视根（主目录或已注册的额外路径），不属于任何监视根时返回空字符串.
+func watchedRoot(ctx *WatchContext, name string) string {
+	for _, r := range ctx.extraRoots {
+		if r.active && isWithin(r.path, name) {
+			return r.path
+		}
+	}
+	if isWithin(ctx.rootPath, name) {
+		return ctx.rootPath
+	}
+	return ""
+}
+
+// gitIgnoreFor 返回用于判断 name 的 .gitignore 规则以及 name 相对于对应根目录的路径.
+// 主目录保持原有行为，直接使用事件路径匹配.
+func gitIgnoreFor(ctx *WatchContext, name string) (*gitignore.GitIgnore, string) {
+	for _, r := range ctx.extraRoots {
+		if r.active && isWithin(r.path, name) {
+			rel, err := filepath.Rel(r.path, name)
+			if err != nil {
+				rel = name
+			}
+			return r.gi, rel
+		}
+	}
+	return ctx.gi, name
+}
+
+// isWithin 判断 path 是否等于 dir 或位于 dir 之下.
+func isWithin(dir, path string) bool {
+	absDir, err1 := filepath.Abs(dir)
+	absPath, err2 := filepath.Abs(path)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	rel, err := filepath.Rel(absDir, absPath)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
+
+func hasFilesUnder(cache stateCache, dir string) bool {
+	for p := range cache {
+		if isWithin(dir, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// rebuildStateCache 重新扫描主目录和所有已注册的额外路径.
+func rebuildStateCache(ctx *WatchContext) (stateCache, error) {
+	cache, err := newWatcherWithState(ctx.rootPath, ctx.config.Recursive)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range ctx.extraRoots {
+		if !r.active {
+			continue
+		}
+		extra, err := newWatcherWithState(r.path, ctx.config.Recursive)
+		if err != nil {
+			logger.Warn().Msgf("Failed to rescan watch path %s: %v", r.path, err)
+			continue
+		}
+		for k, v := range extra {
+			cache[k] = v
+		}
+	}
+	return cache, nil
+}
@@ -0,0 +1,89 @@
+package hotload
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/yeisme/gocli/pkg/configs"
+)
+
+// 测试 watch_paths：项目目录之外的兄弟目录中的变更会触发钩子，
+// 该目录自身 .gitignore 忽略的文件不会触发，尚不存在的路径在创建后开始监视
+func TestWatchPathsOutsideRoot(t *testing.T) {
+	base := t.TempDir()
+	app := filepath.Join(base, "app")
+	shared := filepath.Join(base, "shared", "templates")
+	for _, d := range []string{app, shared} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(t, filepath.Join(app, "main.go"), "package main\n")
+	writeFile(t, filepath.Join(shared, ".gitignore"), "*.gen.tmpl\n")
+	writeFile(t, filepath.Join(shared, "page.tmpl"), "v1")
+
+	config := configs.HotloadConfig{
+		Enabled:    true,
+		Filter:     []string{"*.go", "*.tmpl"},
+		Recursive:  true,
+		Debounce:   50,
+		GitIgnore:  true,
+		WatchPaths: []string{"../shared/templates", "../later"},
+	}
+	ctx, err := newWatchContext(app, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fired := make(chan struct{}, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = runEventLoop(ctx, func() { fired <- struct{}{} })
+	}()
+	defer func() {
+		_ = ctx.watcher.Close()
+		<-done
+	}()
+
+	// 被 shared/templates/.gitignore 忽略
+	writeFile(t, filepath.Join(shared, "page.gen.tmpl"), "generated")
+	expectNoHook(t, fired)
+
+	writeFile(t, filepath.Join(shared, "page.tmpl"), "v2")
+	expectHook(t, fired, "change in secondary tree")
+
+	// ../later 启动时不存在，创建后开始监视
+	later := filepath.Join(base, "later")
+	if err := os.MkdirAll(later, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(later, "extra.go"), "package later\n")
+	expectHook(t, fired, "file in a watch path created after startup")
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func expectHook(t *testing.T, fired <-chan struct{}, what string) {
+	t.Helper()
+	select {
+	case <-fired:
+	case <-time.After(3 * time.Second):
+		t.Fatalf("hook was not triggered by %s", what)
+	}
+}
+
+func expectNoHook(t *testing.T, fired <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-fired:
+		t.Fatal("hook triggered by an ignored file")
+	case <-time.After(300 * time.Millisecond):
+	}
+}
@@ -9,37 +9,65 @@ import (
 )
 
 // baseDirWatcherWithConfig 是简易的协调器，用于将 watcher、缓存和过滤器连接起来并启动事件循环.
-func baseDirWatcherWithConfig(rootPath string, config configs.HotloadConfig, hook Func) error {
-	watcher, err := fsnotify.NewWatcher()
+func baseDirWatcherWithConfig(rootPath string, config configs.HotloadConfig, stats *Stats, hook ChangeFunc) error {
+	ctx, err := newWatchContext(rootPath, config)
 	if err != nil {
-		return fmt.Errorf("创建 watcher 失败: %w", err)
+		return err
+	}
+	if stats != nil {
+		ctx.stats = stats
 	}
 	defer func() {
-		if cerr := watcher.Close(); cerr != nil {
+		if cerr := ctx.watcher.Close(); cerr != nil {
 			logger.Error().Msgf("关闭 watcher 失败: %v", cerr)
 		}
 	}()
 
+	logger.Info().Msgf("已在 %s 启动可配置的 watcher (recursive=%t, debounce=%dms)",
+		rootPath, config.Recursive, ctx.debounceDuration/time.Millisecond)
+	logger.Debug().Msgf("监视 %d 个文件，过滤器: %v，忽略模式: %v",
+		len(ctx.cache), config.Filter, config.IgnorePatterns)
+	logger.Info().Msg("Hotload 已启动.按 Ctrl+C 退出.")
+
+	return runChangeLoop(ctx, hook)
+}
+
+// newWatchContext 创建 watcher，构建初始状态缓存并注册主目录与 watch_paths 中的额外目录.
+// 返回的 WatchContext 持有 watcher，关闭 watcher 后 runEventLoop 返回.
+func newWatchContext(rootPath string, config configs.HotloadConfig) (*WatchContext, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("创建 watcher 失败: %w", err)
+	}
+	ctx, err := setupWatchContext(watcher, rootPath, config)
+	if err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+	return ctx, nil
+}
+
+func setupWatchContext(watcher *fsnotify.Watcher, rootPath string, config configs.HotloadConfig) (*WatchContext, error) {
 	// 初始文件状态缓存
 	cache, err := initializeFileStateCache(rootPath, config.Recursive)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// 在启用时加载 .gitignore
 	gi, err := loadGitIgnore(rootPath, config.GitIgnore)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// 注册要监视的目录
 	if config.Recursive {
 		if err := addDirectoriesToWatcher(watcher, rootPath, config, gi); err != nil {
-			return err
+			return nil, err
 		}
 	} else {
 		if err := watcher.Add(rootPath); err != nil {
-			return fmt.Errorf("将根路径 '%s' 添加到 watcher 失败: %w", rootPath, err)
+			return nil, fmt.Errorf("将根路径 '%s' 添加到 watcher 失败: %w", rootPath, err)
 		}
 	}
 
@@ -56,13 +84,10 @@ func baseDirWatcherWithConfig(rootPath string, config configs.HotloadConfig, hoo
 		gi:               gi,
 		cache:            cache,
 		debounceDuration: debounceDuration,
+		stats:            NewStats(),
 	}
 
-	logger.Info().Msgf("已在 %s 启动可配置的 watcher (recursive=%t, debounce=%dms)",
-		rootPath, config.Recursive, debounceDuration/time.Millisecond)
-	logger.Debug().Msgf("监视 %d 个文件，过滤器: %v，忽略模式: %v",
-		len(cache), config.Filter, config.IgnorePatterns)
-	logger.Info().Msg("Hotload 已启动.按 Ctrl+C 退出.")
-
-	return runEventLoop(ctx, hook)
+	// 注册 watch_paths 中的额外目录（不存在的目录等待其出现）
+	setupExtraRoots(ctx)
+	return ctx, nil
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/yeisme/gocli/pkg/configs"
+	"github.com/yeisme/gocli/pkg/style"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
@@ -28,15 +29,20 @@ type Logger = *zerolog.Logger
 // 这样可以确保在应用程序的任何地方都能获取到一致的日志记录
 var globalLogger Logger
 
+// commandField 是 SetCommand 附加到每条日志上的字段名
+const commandField = "command"
+
 // InitLogger 初始化日志记录器
+// 控制台日志写到 stderr（stdout 只留给命令的结果输出）；config.JSON 为 true 时控制台输出 JSON，
+// 每行包含 level、time（RFC 3339）、message 以及 SetCommand 设置的 command 字段。
+// 重复调用时原地更新已有的全局日志记录器，使提前通过 GetLogger 取得它的包也使用新的配置
 func InitLogger(ctx context.Context, config *configs.LogConfig, appConfig *configs.AppConfig) Logger {
-	// 优先级：quiet > debug > verbose > config.Level
-	if appConfig.Quiet {
+	// 优先级：config.LevelOverride（--log-level）> quiet > debug > verbose > config.Level
+	if level, err := zerolog.ParseLevel(config.LevelOverride); config.LevelOverride != "" && err == nil {
+		zerolog.SetGlobalLevel(level)
+	} else if appConfig.Quiet {
 		zerolog.SetGlobalLevel(zerolog.PanicLevel)
-		logger := zerolog.New(io.Discard)
-		globalLogger = &logger
-		log.Logger = logger
-		return &logger
+		return setGlobalLogger(zerolog.New(io.Discard))
 	} else if appConfig.Debug {
 		zerolog.SetGlobalLevel(zerolog.DebugLevel)
 	} else if appConfig.Verbose {
@@ -71,7 +77,8 @@ func InitLogger(ctx context.Context, config *configs.LogConfig, appConfig *confi
 
 	var logger zerolog.Logger
 
-	// 创建日志记录器
+	// 创建日志记录器；JSON 日志使用完整的 RFC 3339 时间，便于日志处理系统解析
+	zerolog.TimeFieldFormat = time.RFC3339
 	if appConfig.Debug {
 		logger = zerolog.New(output).With().Timestamp().
 			Caller().Stack().
@@ -80,23 +87,42 @@ func InitLogger(ctx context.Context, config *configs.LogConfig, appConfig *confi
 		logger = zerolog.New(output).With().Timestamp().
 			Ctx(ctx).Logger()
 	} else {
-		zerolog.TimeFieldFormat = time.Kitchen
+		if !config.JSON {
+			zerolog.TimeFieldFormat = time.Kitchen
+		}
 		logger = zerolog.New(output).With().Timestamp().
 			Logger()
 	}
 
-	globalLogger = &logger
+	return setGlobalLogger(logger)
+}
+
+// setGlobalLogger 设置全局日志记录器：已存在时原地替换其内容，保持已分发的指针有效
+func setGlobalLogger(logger zerolog.Logger) Logger {
+	if globalLogger == nil {
+		globalLogger = &logger
+	} else {
+		*globalLogger = logger
+	}
 	log.Logger = logger
-	return &logger
+	return globalLogger
+}
+
+// SetCommand 为之后的所有日志附加 command 字段（例如 "gocli project run"），
+// 使 JSON 日志可以按命令筛选；人类可读的控制台输出不显示该字段
+func SetCommand(command string) {
+	setGlobalLogger(GetLogger().With().Str(commandField, command).Logger())
 }
 
-// createConsoleWriter 创建控制台输出写入器
+// createConsoleWriter 创建控制台输出写入器（stderr），经 style.SyncWriter 与 spinner 共用输出锁，避免日志与进度帧交错
 func createConsoleWriter(useJSON bool) io.Writer {
 	if useJSON {
-		return os.Stdout
+		return style.SyncWriter(os.Stderr)
 	}
 	return zerolog.ConsoleWriter{
-		Out: os.Stdout,
+		Out:           style.SyncWriter(os.Stderr),
+		NoColor:       !style.ColorEnabled(os.Stderr),
+		FieldsExclude: []string{commandField},
 	}
 }
 
@@ -105,7 +131,7 @@ func createFileWriter(config *configs.LogConfig) io.Writer {
 	// 确保日志目录存在
 	logDir := filepath.Dir(config.FilePath)
 	if err := os.MkdirAll(logDir, 0755); err != nil {
-		return os.Stdout
+		return os.Stderr
 	}
 
 	// 使用 lumberjack 进行日志轮转
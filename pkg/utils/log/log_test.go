@@ -0,0 +1,76 @@
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/yeisme/gocli/pkg/configs"
+)
+
+// 测试 JSON 日志：写到 stderr 而不是 stdout，带有 level/time/message/command 字段，
+// 重新初始化会更新此前取得的日志记录器
+func TestInitLoggerJSON(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	t.Cleanup(func() { os.Stderr = stderr })
+
+	app := &configs.AppConfig{}
+	early := InitLogger(context.Background(), &configs.LogConfig{Level: "info", Mode: "console"}, app)
+	InitLogger(context.Background(), &configs.LogConfig{Level: "info", Mode: "console", JSON: true}, app)
+	SetCommand("gocli project run")
+	early.Info().Str("port", "8080").Msg("started")
+	os.Stderr = stderr
+	_ = w.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(strings.TrimSpace(string(data))), &entry); err != nil {
+		t.Fatalf("stderr is not a JSON log line: %v\n%s", err, data)
+	}
+	for key, want := range map[string]string{"level": "info", "message": "started", "command": "gocli project run", "port": "8080"} {
+		if entry[key] != want {
+			t.Errorf("%s = %v, want %q", key, entry[key], want)
+		}
+	}
+	if ts, _ := entry["time"].(string); !strings.Contains(ts, "T") {
+		t.Errorf("time should be RFC 3339, got %v", entry["time"])
+	}
+}
+
+// 测试日志级别的优先级：--log-level > quiet > debug > verbose > log.level
+func TestInitLoggerLevel(t *testing.T) {
+	saved := zerolog.GlobalLevel()
+	t.Cleanup(func() { zerolog.SetGlobalLevel(saved) })
+
+	cases := []struct {
+		name string
+		log  configs.LogConfig
+		app  configs.AppConfig
+		want zerolog.Level
+	}{
+		{"config", configs.LogConfig{Level: "warn"}, configs.AppConfig{}, zerolog.WarnLevel},
+		{"verbose", configs.LogConfig{Level: "warn"}, configs.AppConfig{Verbose: true}, zerolog.InfoLevel},
+		{"debug over verbose", configs.LogConfig{Level: "warn"}, configs.AppConfig{Debug: true, Verbose: true}, zerolog.DebugLevel},
+		{"quiet over debug", configs.LogConfig{Level: "warn"}, configs.AppConfig{Debug: true, Quiet: true}, zerolog.PanicLevel},
+		{"flag over verbose", configs.LogConfig{Level: "warn", LevelOverride: "trace"}, configs.AppConfig{Verbose: true}, zerolog.TraceLevel},
+		{"flag over quiet", configs.LogConfig{Level: "warn", LevelOverride: "error"}, configs.AppConfig{Quiet: true}, zerolog.ErrorLevel},
+	}
+	for _, c := range cases {
+		InitLogger(context.Background(), &c.log, &c.app)
+		if got := zerolog.GlobalLevel(); got != c.want {
+			t.Errorf("%s: level = %s, want %s", c.name, got, c.want)
+		}
+	}
+}
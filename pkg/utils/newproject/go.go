@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	"github.com/yeisme/gocli/pkg/models"
+	"github.com/yeisme/gocli/pkg/style"
 	"github.com/yeisme/gocli/pkg/utils/executor"
 )
 
@@ -137,7 +138,12 @@ func downloadToTemp(url string) (string, error) {
 		_ = tmpFile.Close()
 	}()
 
-	if _, err := io.Copy(tmpFile, resp.Body); err != nil {
+	// 在 stderr 上显示下载进度，Content-Length 未知时只显示已下载的字节数
+	sp := style.NewByteProgress(os.Stderr, "Downloading template", resp.ContentLength)
+	sp.Start()
+	_, err = io.Copy(tmpFile, sp.Wrap(resp.Body))
+	sp.Finish(err)
+	if err != nil {
 		return "", err
 	}
 	return tmpFile.Name(), nil